@@ -8,11 +8,13 @@ require (
 	github.com/VictoriaMetrics/fastcache v1.5.7
 	github.com/agl/ed25519 v0.0.0-20170116200512-5312a6153412
 	github.com/btcsuite/btcutil v1.0.1
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.0.1
 	github.com/golang/mock v1.4.0
-	github.com/golang/protobuf v1.3.3 // indirect
+	github.com/golang/protobuf v1.3.3
 	github.com/google/tink v1.3.0-rc4
 	github.com/google/uuid v1.1.1
 	github.com/gorilla/mux v1.7.3
+	github.com/kilic/bls12-381 v0.1.0
 	github.com/minio/sha256-simd v0.1.1 // indirect
 	github.com/mitchellh/mapstructure v1.1.2
 	github.com/multiformats/go-multibase v0.0.1
@@ -26,7 +28,7 @@ require (
 	github.com/xeipuuv/gojsonschema v1.2.0
 	golang.org/x/crypto v0.0.0-20200210222208-86ce3cb69678
 	golang.org/x/net v0.0.0-20190613194153-d28f0bde5980 // indirect
-	golang.org/x/sys v0.0.0-20200202164722-d101bd2416d5 // indirect
+	golang.org/x/sys v0.7.0 // indirect
 	gopkg.in/yaml.v2 v2.2.8 // indirect
 	nhooyr.io/websocket v1.7.4
 )
@@ -0,0 +1,75 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didsync
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didsync"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+// provider contains dependencies for the DID sync protocol and is typically created by using aries.Context()
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// Client enable access to DID sync api.
+type Client struct {
+	didSyncSvc protocolService
+}
+
+// protocolService defines DID sync service.
+type protocolService interface {
+	// DIDComm service
+	service.Handler
+
+	// UpdateDID updates the peer DID of the connection and sends the delta
+	UpdateDID(connectionID string, doc *did.Doc) error
+
+	// DeactivateDID deactivates the peer DID of the connection and sends the deactivation
+	DeactivateDID(connectionID string) error
+}
+
+// New return new instance of DID sync client.
+func New(ctx provider) (*Client, error) {
+	svc, err := ctx.Service(didsync.DIDSync)
+	if err != nil {
+		return nil, err
+	}
+
+	didSyncSvc, ok := svc.(protocolService)
+	if !ok {
+		return nil, errors.New("cast service to DID sync service failed")
+	}
+
+	return &Client{
+		didSyncSvc: didSyncSvc,
+	}, nil
+}
+
+// UpdateDID updates the peer DID of the agent on the connection (passed in connectionID) with the DID
+// document doc, and sends the delta signed with the current key to the agent on the other end of the connection.
+func (c *Client) UpdateDID(connectionID string, doc *did.Doc) error {
+	if err := c.didSyncSvc.UpdateDID(connectionID, doc); err != nil {
+		return fmt.Errorf("update DID : %w", err)
+	}
+
+	return nil
+}
+
+// DeactivateDID deactivates the peer DID of the agent on the connection (passed in connectionID), and sends
+// the deactivation signed with the current key to the agent on the other end of the connection.
+func (c *Client) DeactivateDID(connectionID string) error {
+	if err := c.didSyncSvc.DeactivateDID(connectionID); err != nil {
+		return fmt.Errorf("deactivate DID : %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didsync
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	mockdidsync "github.com/hyperledger/aries-framework-go/pkg/internal/mock/didcomm/protocol/didsync"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/internal/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new client", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdidsync.MockDIDSyncSvc{}},
+		)
+		require.NoError(t, err)
+		require.NotNil(t, svc)
+	})
+
+	t.Run("test error from get service from context", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: fmt.Errorf("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+
+	t.Run("test error from cast service", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: nil})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cast service to DID sync service failed")
+	})
+}
+
+func TestUpdateDID(t *testing.T) {
+	doc := &did.Doc{ID: "did:peer:1zQmSeRuG9CHwzKV1T224GrSvFPmAq3NirQ27HYhhUCHbpnX"}
+
+	t.Run("test update DID - success", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdidsync.MockDIDSyncSvc{
+				UpdateDIDFunc: func(connectionID string, d *did.Doc) error {
+					require.Equal(t, "conn1", connectionID)
+					require.Equal(t, doc, d)
+
+					return nil
+				},
+			}})
+		require.NoError(t, err)
+
+		require.NoError(t, c.UpdateDID("conn1", doc))
+	})
+
+	t.Run("test update DID - error", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdidsync.MockDIDSyncSvc{
+				UpdateDIDFunc: func(string, *did.Doc) error {
+					return errors.New("update error")
+				},
+			}})
+		require.NoError(t, err)
+
+		err = c.UpdateDID("conn1", doc)
+		require.EqualError(t, err, "update DID : update error")
+	})
+}
+
+func TestDeactivateDID(t *testing.T) {
+	t.Run("test deactivate DID - success", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdidsync.MockDIDSyncSvc{
+				DeactivateDIDFunc: func(connectionID string) error {
+					require.Equal(t, "conn1", connectionID)
+
+					return nil
+				},
+			}})
+		require.NoError(t, err)
+
+		require.NoError(t, c.DeactivateDID("conn1"))
+	})
+
+	t.Run("test deactivate DID - error", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdidsync.MockDIDSyncSvc{
+				DeactivateDIDFunc: func(string) error {
+					return errors.New("deactivate error")
+				},
+			}})
+		require.NoError(t, err)
+
+		err = c.DeactivateDID("conn1")
+		require.EqualError(t, err, "deactivate DID : deactivate error")
+	})
+}
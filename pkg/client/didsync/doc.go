@@ -0,0 +1,10 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package didsync enables the agent to update its peer DID of a connection, for example when its keys or
+// endpoints rotate. The signed delta is sent to the agent on the other end of the connection, so both
+// agents stay in sync.
+package didsync
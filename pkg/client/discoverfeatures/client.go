@@ -0,0 +1,68 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+)
+
+// provider contains dependencies for the discover features protocol and is typically created by using aries.Context()
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// Client enable access to discover features api.
+type Client struct {
+	discoverFeaturesSvc protocolService
+}
+
+// protocolService defines discover features service.
+type protocolService interface {
+	// DIDComm service
+	service.Handler
+
+	// Query sends the query message and waits for the disclose message
+	Query(connectionID, query string) (*discoverfeatures.Disclose, error)
+}
+
+// New return new instance of discover features client.
+func New(ctx provider) (*Client, error) {
+	svc, err := ctx.Service(discoverfeatures.DiscoverFeatures)
+	if err != nil {
+		return nil, err
+	}
+
+	discoverFeaturesSvc, ok := svc.(protocolService)
+	if !ok {
+		return nil, errors.New("cast service to discover features service failed")
+	}
+
+	return &Client{
+		discoverFeaturesSvc: discoverFeaturesSvc,
+	}, nil
+}
+
+// Query asks the agent on the other end of the connection (passed in connectionID) which protocols
+// matching the query are supported. The query may contain the '*' wildcard (e.g https://didcomm.org/*).
+// Returns the disclosed protocol URIs.
+func (c *Client) Query(connectionID, query string) ([]string, error) {
+	disclose, err := c.discoverFeaturesSvc.Query(connectionID, query)
+	if err != nil {
+		return nil, fmt.Errorf("discover features query : %w", err)
+	}
+
+	protocols := make([]string, len(disclose.Protocols))
+	for i, protocol := range disclose.Protocols {
+		protocols[i] = protocol.PID
+	}
+
+	return protocols, nil
+}
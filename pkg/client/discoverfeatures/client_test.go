@@ -0,0 +1,76 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+	mockdiscoverfeatures "github.com/hyperledger/aries-framework-go/pkg/internal/mock/didcomm/protocol/discoverfeatures"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/internal/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new client", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{}},
+		)
+		require.NoError(t, err)
+		require.NotNil(t, svc)
+	})
+
+	t.Run("test error from get service from context", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: fmt.Errorf("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+
+	t.Run("test error from cast service", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: nil})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cast service to discover features service failed")
+	})
+}
+
+func TestQuery(t *testing.T) {
+	t.Run("test query - success", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+				QueryFunc: func(connectionID, query string) (*discoverfeatures.Disclose, error) {
+					require.Equal(t, "conn1", connectionID)
+					require.Equal(t, "https://didcomm.org/*", query)
+
+					return &discoverfeatures.Disclose{Protocols: []*discoverfeatures.ProtocolDescriptor{
+						{PID: "https://didcomm.org/trust_ping/1.0"},
+						{PID: "https://didcomm.org/didexchange/1.0"},
+					}}, nil
+				},
+			}})
+		require.NoError(t, err)
+
+		protocols, err := c.Query("conn1", "https://didcomm.org/*")
+		require.NoError(t, err)
+		require.Equal(t, []string{"https://didcomm.org/trust_ping/1.0", "https://didcomm.org/didexchange/1.0"}, protocols)
+	})
+
+	t.Run("test query - error", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+				QueryFunc: func(string, string) (*discoverfeatures.Disclose, error) {
+					return nil, errors.New("query error")
+				},
+			}})
+		require.NoError(t, err)
+
+		_, err = c.Query("conn1", "*")
+		require.EqualError(t, err, "discover features query : query error")
+	})
+}
@@ -0,0 +1,9 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package discoverfeatures enables the agent to learn which protocols are supported by the other agent.
+// The agent sends the query message and waits for the disclose message.
+package discoverfeatures
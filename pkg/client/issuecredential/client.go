@@ -0,0 +1,185 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+// Provider contains dependencies for the issuecredential protocol and is typically created by using aries.Context()
+type Provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// ProtocolService defines the issuecredential service.
+type ProtocolService interface {
+	service.DIDComm
+	Continue(piID string, opt issuecredential.Opt) error
+	Stop(piID string, err error) error
+	Actions() ([]issuecredential.Action, error)
+}
+
+// Client enable access to issuecredential API
+type Client struct {
+	service.Event
+	service ProtocolService
+}
+
+// New return new instance of the issuecredential client
+func New(ctx Provider) (*Client, error) {
+	raw, err := ctx.Service(issuecredential.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, ok := raw.(ProtocolService)
+	if !ok {
+		return nil, errors.New("cast service to issuecredential service failed")
+	}
+
+	return &Client{
+		Event:   svc,
+		service: svc,
+	}, nil
+}
+
+// Actions returns unfinished actions for the async usage
+func (c *Client) Actions() ([]issuecredential.Action, error) {
+	return c.service.Actions()
+}
+
+// SendOffer is used by the Issuer to send an offer.
+func (c *Client) SendOffer(offer *issuecredential.OfferCredential, myDID, theirDID string) (string, error) {
+	if offer == nil {
+		return "", errors.New("offer credential is not provided")
+	}
+
+	offer.Type = issuecredential.OfferCredentialMsgType
+
+	return c.service.HandleOutbound(service.NewDIDCommMsgMap(offer), myDID, theirDID)
+}
+
+// SendProposal is used by the Holder to send a proposal.
+func (c *Client) SendProposal(proposal *issuecredential.ProposeCredential, myDID, theirDID string) (string, error) {
+	if proposal == nil {
+		return "", errors.New("propose credential is not provided")
+	}
+
+	proposal.Type = issuecredential.ProposeCredentialMsgType
+
+	return c.service.HandleOutbound(service.NewDIDCommMsgMap(proposal), myDID, theirDID)
+}
+
+// SendRequest is used by the Holder to send a request.
+func (c *Client) SendRequest(request *issuecredential.RequestCredential, myDID, theirDID string) (string, error) {
+	if request == nil {
+		return "", errors.New("request credential is not provided")
+	}
+
+	request.Type = issuecredential.RequestCredentialMsgType
+
+	return c.service.HandleOutbound(service.NewDIDCommMsgMap(request), myDID, theirDID)
+}
+
+// AcceptProposal is used when the Issuer is willing to accept the proposal.
+// NOTE: For async usage.
+func (c *Client) AcceptProposal(piID string, msg *issuecredential.OfferCredential) error {
+	return c.service.Continue(piID, WithOfferCredential(msg))
+}
+
+// AcceptOffer is used when the Holder is willing to accept the offer.
+// NOTE: For async usage.
+func (c *Client) AcceptOffer(piID string) error {
+	return c.service.Continue(piID, nil)
+}
+
+// NegotiateProposal is used when the Holder wants to negotiate about an offer they received.
+// NOTE: For async usage. This function can be used only after receiving OfferCredential.
+func (c *Client) NegotiateProposal(piID string, msg *issuecredential.ProposeCredential) error {
+	return c.service.Continue(piID, WithProposeCredential(msg))
+}
+
+// AcceptRequest is used when the Issuer is willing to accept the request.
+// NOTE: For async usage.
+func (c *Client) AcceptRequest(piID string, msg *issuecredential.IssueCredential) error {
+	return c.service.Continue(piID, WithIssueCredential(msg))
+}
+
+// AcceptCredential is used when the Holder is willing to accept the IssueCredential.
+// NOTE: For async usage.
+func (c *Client) AcceptCredential(piID string) error {
+	return c.service.Continue(piID, nil)
+}
+
+// DeclineProposal is used when the Issuer does not want to accept the proposal.
+// NOTE: For async usage.
+func (c *Client) DeclineProposal(piID, reason string) error {
+	return c.service.Stop(piID, errors.New(reason))
+}
+
+// DeclineOffer is used when the Holder does not want to accept the offer.
+// NOTE: For async usage.
+func (c *Client) DeclineOffer(piID, reason string) error {
+	return c.service.Stop(piID, errors.New(reason))
+}
+
+// DeclineRequest is used when the Issuer does not want to accept the request.
+// NOTE: For async usage.
+func (c *Client) DeclineRequest(piID, reason string) error {
+	return c.service.Stop(piID, errors.New(reason))
+}
+
+// DeclineCredential is used when the Holder does not want to accept the IssueCredential.
+// NOTE: For async usage.
+func (c *Client) DeclineCredential(piID, reason string) error {
+	return c.service.Stop(piID, errors.New(reason))
+}
+
+// WithOfferCredential is used when the Issuer is willing to accept the proposal.
+// USAGE: event.Continue(WithOfferCredential(offer))
+func WithOfferCredential(msg *issuecredential.OfferCredential) issuecredential.Opt {
+	return issuecredential.WithOfferCredential(msg)
+}
+
+// WithProposeCredential is used when the Holder wants to negotiate about an offer they received.
+// USAGE: event.Continue(WithProposeCredential(proposal))
+func WithProposeCredential(msg *issuecredential.ProposeCredential) issuecredential.Opt {
+	return issuecredential.WithProposeCredential(msg)
+}
+
+// WithRequestCredential is used when the Holder is willing to accept the offer with some specific request.
+// USAGE: event.Continue(WithRequestCredential(request))
+func WithRequestCredential(msg *issuecredential.RequestCredential) issuecredential.Opt {
+	return issuecredential.WithRequestCredential(msg)
+}
+
+// WithIssueCredential is used when the Issuer is willing to accept the request.
+// USAGE: event.Continue(WithIssueCredential(credential))
+func WithIssueCredential(msg *issuecredential.IssueCredential) issuecredential.Opt {
+	return issuecredential.WithIssueCredential(msg)
+}
+
+// NewIssueCredential creates the IssueCredential message which carries the given verifiable credentials.
+func NewIssueCredential(comment string, credentials ...*verifiable.Credential) (*issuecredential.IssueCredential, error) {
+	msg := &issuecredential.IssueCredential{Comment: comment}
+
+	for _, vc := range credentials {
+		attachment, err := issuecredential.NewCredentialAttachment(vc)
+		if err != nil {
+			return nil, fmt.Errorf("credential attachment: %w", err)
+		}
+
+		msg.CredentialsAttach = append(msg.CredentialsAttach, attachment)
+	}
+
+	return msg, nil
+}
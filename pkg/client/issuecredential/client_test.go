@@ -0,0 +1,174 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	issuecredentialMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/issuecredential"
+)
+
+const (
+	Alice = "Alice"
+	Bob   = "Bob"
+)
+
+func newClient(t *testing.T, ctrl *gomock.Controller, svc ProtocolService) *Client {
+	t.Helper()
+
+	provider := issuecredentialMocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(issuecredential.Name).Return(svc, nil)
+
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("get service error", func(t *testing.T) {
+		provider := issuecredentialMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(nil, errors.New("test error"))
+
+		_, err := New(provider)
+		require.EqualError(t, err, "test error")
+	})
+
+	t.Run("cast service error", func(t *testing.T) {
+		provider := issuecredentialMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(nil, nil)
+
+		_, err := New(provider)
+		require.EqualError(t, err, "cast service to issuecredential service failed")
+	})
+}
+
+func TestClient_SendMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectOutbound := func(svc *issuecredentialMocks.MockProtocolService, msgType string) {
+		svc.EXPECT().HandleOutbound(gomock.Any(), Alice, Bob).
+			DoAndReturn(func(msg service.DIDCommMsg, _, _ string) (string, error) {
+				require.Equal(t, msgType, msg.Type())
+
+				return "piID", nil
+			})
+	}
+
+	t.Run("SendOffer", func(t *testing.T) {
+		svc := issuecredentialMocks.NewMockProtocolService(ctrl)
+		expectOutbound(svc, issuecredential.OfferCredentialMsgType)
+
+		client := newClient(t, ctrl, svc)
+
+		piID, err := client.SendOffer(&issuecredential.OfferCredential{}, Alice, Bob)
+		require.NoError(t, err)
+		require.Equal(t, "piID", piID)
+
+		_, err = client.SendOffer(nil, Alice, Bob)
+		require.EqualError(t, err, "offer credential is not provided")
+	})
+
+	t.Run("SendProposal", func(t *testing.T) {
+		svc := issuecredentialMocks.NewMockProtocolService(ctrl)
+		expectOutbound(svc, issuecredential.ProposeCredentialMsgType)
+
+		client := newClient(t, ctrl, svc)
+
+		piID, err := client.SendProposal(&issuecredential.ProposeCredential{}, Alice, Bob)
+		require.NoError(t, err)
+		require.Equal(t, "piID", piID)
+
+		_, err = client.SendProposal(nil, Alice, Bob)
+		require.EqualError(t, err, "propose credential is not provided")
+	})
+
+	t.Run("SendRequest", func(t *testing.T) {
+		svc := issuecredentialMocks.NewMockProtocolService(ctrl)
+		expectOutbound(svc, issuecredential.RequestCredentialMsgType)
+
+		client := newClient(t, ctrl, svc)
+
+		piID, err := client.SendRequest(&issuecredential.RequestCredential{}, Alice, Bob)
+		require.NoError(t, err)
+		require.Equal(t, "piID", piID)
+
+		_, err = client.SendRequest(nil, Alice, Bob)
+		require.EqualError(t, err, "request credential is not provided")
+	})
+}
+
+func TestClient_AcceptDecline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const piID = "piID"
+
+	svc := issuecredentialMocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().Continue(piID, gomock.Any()).Return(nil).Times(5)
+	svc.EXPECT().Stop(piID, gomock.Any()).Return(nil).Times(4)
+	svc.EXPECT().Actions().Return([]issuecredential.Action{{PIID: piID}}, nil)
+
+	client := newClient(t, ctrl, svc)
+
+	actions, err := client.Actions()
+	require.NoError(t, err)
+	require.Len(t, actions, 1)
+
+	require.NoError(t, client.AcceptProposal(piID, &issuecredential.OfferCredential{}))
+	require.NoError(t, client.AcceptOffer(piID))
+	require.NoError(t, client.NegotiateProposal(piID, &issuecredential.ProposeCredential{}))
+	require.NoError(t, client.AcceptRequest(piID, &issuecredential.IssueCredential{}))
+	require.NoError(t, client.AcceptCredential(piID))
+
+	require.NoError(t, client.DeclineProposal(piID, "reason"))
+	require.NoError(t, client.DeclineOffer(piID, "reason"))
+	require.NoError(t, client.DeclineRequest(piID, "reason"))
+	require.NoError(t, client.DeclineCredential(piID, "reason"))
+}
+
+func TestNewIssueCredential(t *testing.T) {
+	issued := time.Date(2010, time.January, 1, 19, 23, 24, 0, time.UTC)
+
+	vc := &verifiable.Credential{
+		Context: []string{"https://www.w3.org/2018/credentials/v1"},
+		ID:      "http://example.edu/credentials/1872",
+		Types:   []string{"VerifiableCredential", "UniversityDegreeCredential"},
+		Subject: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+		Issuer:  verifiable.Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+		Issued:  &issued,
+	}
+
+	msg, err := NewIssueCredential("comment", vc)
+	require.NoError(t, err)
+	require.Equal(t, "comment", msg.Comment)
+	require.Len(t, msg.CredentialsAttach, 1)
+
+	credentials, err := msg.Credentials()
+	require.NoError(t, err)
+	require.Len(t, credentials, 1)
+	require.Equal(t, vc.ID, credentials[0].ID)
+}
+
+func TestWithOptions(t *testing.T) {
+	require.NotNil(t, WithOfferCredential(&issuecredential.OfferCredential{}))
+	require.NotNil(t, WithProposeCredential(&issuecredential.ProposeCredential{}))
+	require.NotNil(t, WithRequestCredential(&issuecredential.RequestCredential{}))
+	require.NotNil(t, WithIssueCredential(&issuecredential.IssueCredential{}))
+}
@@ -0,0 +1,45 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package issuecredential provides support for the Issue Credential Protocol 1.0:
+// https://github.com/hyperledger/aries-rfcs/blob/master/features/0036-issue-credential/README.md.
+//
+// Formalizes messages used to issue a credential. The protocol is executed between the Issuer and the Holder.
+// The Holder may start the protocol by sending a proposal or a request, the Issuer may start it by sending an offer.
+// Incoming messages are delivered to the client as action events. The consumer decides whether to continue or
+// to stop the protocol.
+// 	issuecredential := client.New(...)
+// 	issuecredential.RegisterActionEvent(actions)
+// 	for {
+// 	  select {
+// 	    case event := <-actions:
+// 	      switch event.Message.Type() {
+// 	      case issuecredential.ProposeCredentialMsgType:
+// 	        // the Issuer accepts the proposal and provides an offer
+// 	        event.Continue(WithOfferCredential(...))
+// 	      case issuecredential.OfferCredentialMsgType:
+// 	        // the Holder accepts the offer
+// 	        event.Continue(nil)
+// 	      case issuecredential.RequestCredentialMsgType:
+// 	        // the Issuer issues the credential
+// 	        event.Continue(WithIssueCredential(...))
+// 	      case issuecredential.IssueCredentialMsgType:
+// 	        // the Holder accepts the credential
+// 	        event.Continue(nil)
+// 	      }
+// 	  }
+// 	}
+//
+// Actions can also be handled asynchronously using the Actions function and the Accept/Decline functions.
+//
+//  Basic Flow:
+//  1) Prepare client context
+//  2) Create client
+//  3) Register for action events
+//  4) Handle actions
+//  5) Send an offer, a proposal or a request
+//
+package issuecredential
@@ -0,0 +1,88 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package messagepickup
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/messagepickup"
+)
+
+// provider contains dependencies for the message pickup protocol and is typically created by using aries.Context()
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// Client enable access to message pickup api.
+type Client struct {
+	messagePickupSvc protocolService
+}
+
+// protocolService defines message pickup service.
+type protocolService interface {
+	// DIDComm service
+	service.Handler
+
+	// StatusRequest requests the status of the queued messages from the mediator
+	StatusRequest(connectionID string) (*messagepickup.Status, error)
+
+	// BatchPickup requests the queued messages from the mediator
+	BatchPickup(connectionID string, batchSize int) (int, error)
+
+	// Noop lets the mediator deliver the queued messages over the return route
+	Noop(connectionID string) error
+}
+
+// New return new instance of message pickup client.
+func New(ctx provider) (*Client, error) {
+	svc, err := ctx.Service(messagepickup.MessagePickup)
+	if err != nil {
+		return nil, err
+	}
+
+	messagePickupSvc, ok := svc.(protocolService)
+	if !ok {
+		return nil, errors.New("cast service to message pickup service failed")
+	}
+
+	return &Client{
+		messagePickupSvc: messagePickupSvc,
+	}, nil
+}
+
+// StatusRequest returns the status of the messages queued by the mediator (passed in connectionID).
+func (c *Client) StatusRequest(connectionID string) (*messagepickup.Status, error) {
+	status, err := c.messagePickupSvc.StatusRequest(connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("status request : %w", err)
+	}
+
+	return status, nil
+}
+
+// BatchPickup picks up to batchSize messages queued by the mediator (passed in connectionID).
+// The messages are handled by the agent as any other inbound messages. Returns the number of received messages.
+func (c *Client) BatchPickup(connectionID string, batchSize int) (int, error) {
+	count, err := c.messagePickupSvc.BatchPickup(connectionID, batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("batch pickup : %w", err)
+	}
+
+	return count, nil
+}
+
+// Noop sends the noop message to the mediator (passed in connectionID). The mediator delivers the queued messages
+// over the return route (e.g WebSocket connection opened by the agent).
+func (c *Client) Noop(connectionID string) error {
+	if err := c.messagePickupSvc.Noop(connectionID); err != nil {
+		return fmt.Errorf("noop : %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,11 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package messagepickup enables the agent to fetch the messages queued by the mediator (router) while the agent
+// was offline. The agent requests the status of the queued messages and picks them up in batches.
+// Alternatively, the agent connected over a transport with the return route option (e.g WebSocket) can send
+// the noop message to let the mediator deliver the queued messages implicitly.
+package messagepickup
@@ -0,0 +1,211 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outofband
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/route"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/legacykms"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+// Provider contains dependencies for the out-of-band protocol and is typically created by using aries.Context()
+type Provider interface {
+	Service(id string) (interface{}, error)
+	LegacyKMS() legacykms.KeyManager
+	ServiceEndpoint() string
+	StorageProvider() storage.Provider
+	TransientStorageProvider() storage.Provider
+}
+
+// ProtocolService defines the outofband service.
+type ProtocolService interface {
+	service.Event
+	AcceptInvitation(inv *outofband.Invitation) (string, error)
+}
+
+// InvitationOption allows to customize the invitation.
+type InvitationOption func(inv *outofband.Invitation)
+
+// Client enable access to outofband API
+type Client struct {
+	service.Event
+	service         ProtocolService
+	routeSvc        route.ProtocolService
+	legacyKMS       legacykms.KeyManager
+	serviceEndpoint string
+	connectionStore *connection.Recorder
+}
+
+// New return new instance of the outofband client
+func New(ctx Provider) (*Client, error) {
+	raw, err := ctx.Service(outofband.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, ok := raw.(ProtocolService)
+	if !ok {
+		return nil, errors.New("cast service to outofband service failed")
+	}
+
+	raw, err = ctx.Service(route.Coordination)
+	if err != nil {
+		return nil, err
+	}
+
+	routeSvc, ok := raw.(route.ProtocolService)
+	if !ok {
+		return nil, errors.New("cast service to Route Service failed")
+	}
+
+	connectionStore, err := connection.NewRecorder(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		Event:           svc,
+		service:         svc,
+		routeSvc:        routeSvc,
+		legacyKMS:       ctx.LegacyKMS(),
+		serviceEndpoint: ctx.ServiceEndpoint(),
+		connectionStore: connectionStore,
+	}, nil
+}
+
+// CreateInvitation creates the out-of-band invitation. By default, the didexchange protocol is used as
+// the handshake protocol and the new key pair is used for the inline service block.
+// The invitation is stored so the handshake protocol can cross reference it.
+func (c *Client) CreateInvitation(label string, opts ...InvitationOption) (*outofband.Invitation, error) {
+	inv := &outofband.Invitation{
+		ID:                 uuid.New().String(),
+		Type:               outofband.InvitationMsgType,
+		Label:              label,
+		HandshakeProtocols: []string{didexchange.DIDExchangeSpec},
+	}
+
+	for _, opt := range opts {
+		opt(inv)
+	}
+
+	if len(inv.Service) == 0 {
+		block, err := c.newServiceBlock()
+		if err != nil {
+			return nil, err
+		}
+
+		inv.Service = []interface{}{block}
+	}
+
+	didInv := &didexchange.Invitation{
+		ID:    inv.ID,
+		Type:  didexchange.InvitationMsgType,
+		Label: label,
+	}
+
+	if err := setDIDCommService(didInv, inv.Service); err != nil {
+		return nil, err
+	}
+
+	if err := c.connectionStore.SaveInvitation(inv.ID, didInv); err != nil {
+		return nil, fmt.Errorf("save invitation: %w", err)
+	}
+
+	return inv, nil
+}
+
+// setDIDCommService sets the first did-communication service entry to the didexchange invitation,
+// the handshake protocol is initiated against it.
+func setDIDCommService(didInv *didexchange.Invitation, services []interface{}) error {
+	for _, raw := range services {
+		switch svc := raw.(type) {
+		case string:
+			didInv.DID = svc
+
+			return nil
+		case *outofband.ServiceBlock:
+			if svc.Type != outofband.DIDCommServiceType {
+				continue
+			}
+
+			didInv.RecipientKeys = svc.RecipientKeys
+			didInv.RoutingKeys = svc.RoutingKeys
+			didInv.ServiceEndpoint = svc.ServiceEndpoint
+
+			return nil
+		default:
+			return fmt.Errorf("unsupported service type %T", svc)
+		}
+	}
+
+	return fmt.Errorf("no %s service is provided", outofband.DIDCommServiceType)
+}
+
+func (c *Client) newServiceBlock() (*outofband.ServiceBlock, error) {
+	_, sigPubKey, err := c.legacyKMS.CreateKeySet()
+	if err != nil {
+		return nil, fmt.Errorf("create key set: %w", err)
+	}
+
+	serviceEndpoint, routingKeys, err := route.GetRouterConfig(c.routeSvc, c.serviceEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = route.AddKeyToRouter(c.routeSvc, sigPubKey); err != nil {
+		return nil, err
+	}
+
+	return &outofband.ServiceBlock{
+		ID:              "#inline",
+		Type:            outofband.DIDCommServiceType,
+		RecipientKeys:   []string{sigPubKey},
+		RoutingKeys:     routingKeys,
+		ServiceEndpoint: serviceEndpoint,
+	}, nil
+}
+
+// AcceptInvitation accepts the out-of-band invitation received out of band (e.g QR code).
+// The handshake protocol is started and the attached requests are handled as soon as the connection
+// is established. Returns the ID of the connection.
+func (c *Client) AcceptInvitation(inv *outofband.Invitation) (string, error) {
+	return c.service.AcceptInvitation(inv)
+}
+
+// WithGoal sets the goal and the goal code of the invitation.
+func WithGoal(goal, goalCode string) InvitationOption {
+	return func(inv *outofband.Invitation) {
+		inv.Goal = goal
+		inv.GoalCode = goalCode
+	}
+}
+
+// WithAttachments attaches the request messages (e.g issue-credential offer or present-proof request)
+// to the invitation.
+func WithAttachments(attachments ...decorator.Attachment) InvitationOption {
+	return func(inv *outofband.Invitation) {
+		inv.Requests = append(inv.Requests, attachments...)
+	}
+}
+
+// WithServices sets the services of the invitation. Each service is either a DID (string)
+// or an inline block (*outofband.ServiceBlock).
+func WithServices(services ...interface{}) InvitationOption {
+	return func(inv *outofband.Invitation) {
+		inv.Service = append(inv.Service, services...)
+	}
+}
@@ -0,0 +1,213 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outofband
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/route"
+	outofbandMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/outofband"
+	mockroute "github.com/hyperledger/aries-framework-go/pkg/internal/mock/didcomm/protocol/route"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/internal/mock/provider"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms/legacykms"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	endpoint = "http://example.com"
+	sigKey   = "sigKey"
+)
+
+func newProvider(svc ProtocolService, routeSvc route.ProtocolService) *mockprovider.Provider {
+	return &mockprovider.Provider{
+		ServiceMap: map[string]interface{}{
+			outofband.Name:     svc,
+			route.Coordination: routeSvc,
+		},
+		KMSValue:                      &mockkms.CloseableKMS{CreateSigningKeyValue: sigKey},
+		ServiceEndpointValue:          endpoint,
+		StorageProviderValue:          mem.NewProvider(),
+		TransientStorageProviderValue: mem.NewProvider(),
+	}
+}
+
+func TestNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("get service error", func(t *testing.T) {
+		provider := outofbandMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(outofband.Name).Return(nil, errors.New("test error"))
+
+		_, err := New(provider)
+		require.EqualError(t, err, "test error")
+	})
+
+	t.Run("cast service error", func(t *testing.T) {
+		provider := outofbandMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(outofband.Name).Return(nil, nil)
+
+		_, err := New(provider)
+		require.EqualError(t, err, "cast service to outofband service failed")
+	})
+
+	t.Run("get route service error", func(t *testing.T) {
+		provider := outofbandMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(outofband.Name).Return(outofbandMocks.NewMockProtocolService(ctrl), nil)
+		provider.EXPECT().Service(route.Coordination).Return(nil, errors.New("test error"))
+
+		_, err := New(provider)
+		require.EqualError(t, err, "test error")
+	})
+
+	t.Run("cast route service error", func(t *testing.T) {
+		provider := outofbandMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(outofband.Name).Return(outofbandMocks.NewMockProtocolService(ctrl), nil)
+		provider.EXPECT().Service(route.Coordination).Return(nil, nil)
+
+		_, err := New(provider)
+		require.EqualError(t, err, "cast service to Route Service failed")
+	})
+}
+
+func TestClient_CreateInvitation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("inline service", func(t *testing.T) {
+		provider := newProvider(outofbandMocks.NewMockProtocolService(ctrl), &mockroute.MockRouteSvc{})
+
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		request := decorator.Attachment{ID: "request", Data: decorator.AttachmentData{JSON: map[string]interface{}{}}}
+
+		inv, err := client.CreateInvitation("label", WithGoal("goal", "goal-code"), WithAttachments(request))
+		require.NoError(t, err)
+		require.NotEmpty(t, inv.ID)
+		require.Equal(t, outofband.InvitationMsgType, inv.Type)
+		require.Equal(t, "goal", inv.Goal)
+		require.Equal(t, "goal-code", inv.GoalCode)
+		require.Equal(t, []string{didexchange.DIDExchangeSpec}, inv.HandshakeProtocols)
+		require.Equal(t, []decorator.Attachment{request}, inv.Requests)
+		require.Len(t, inv.Service, 1)
+
+		block, ok := inv.Service[0].(*outofband.ServiceBlock)
+		require.True(t, ok)
+		require.Equal(t, outofband.DIDCommServiceType, block.Type)
+		require.Equal(t, []string{sigKey}, block.RecipientKeys)
+		require.Equal(t, endpoint, block.ServiceEndpoint)
+
+		// the handshake protocol cross references the stored invitation
+		lookup, err := connection.NewLookup(provider)
+		require.NoError(t, err)
+
+		didInv := &didexchange.Invitation{}
+		require.NoError(t, lookup.GetInvitation(inv.ID, didInv))
+		require.Equal(t, []string{sigKey}, didInv.RecipientKeys)
+		require.Equal(t, endpoint, didInv.ServiceEndpoint)
+	})
+
+	t.Run("DID service", func(t *testing.T) {
+		client, err := New(newProvider(outofbandMocks.NewMockProtocolService(ctrl), &mockroute.MockRouteSvc{}))
+		require.NoError(t, err)
+
+		inv, err := client.CreateInvitation("label", WithServices("did:example:123"))
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{"did:example:123"}, inv.Service)
+	})
+
+	t.Run("service selected by type", func(t *testing.T) {
+		provider := newProvider(outofbandMocks.NewMockProtocolService(ctrl), &mockroute.MockRouteSvc{})
+
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		other := &outofband.ServiceBlock{Type: "other", RecipientKeys: []string{"other"}, ServiceEndpoint: "other"}
+		didComm := &outofband.ServiceBlock{
+			Type:            outofband.DIDCommServiceType,
+			RecipientKeys:   []string{sigKey},
+			ServiceEndpoint: endpoint,
+		}
+
+		inv, err := client.CreateInvitation("label", WithServices(other, didComm))
+		require.NoError(t, err)
+
+		lookup, err := connection.NewLookup(provider)
+		require.NoError(t, err)
+
+		didInv := &didexchange.Invitation{}
+		require.NoError(t, lookup.GetInvitation(inv.ID, didInv))
+		require.Equal(t, []string{sigKey}, didInv.RecipientKeys)
+		require.Equal(t, endpoint, didInv.ServiceEndpoint)
+
+		_, err = client.CreateInvitation("label", WithServices(other))
+		require.EqualError(t, err, "no did-communication service is provided")
+	})
+
+	t.Run("unsupported service", func(t *testing.T) {
+		client, err := New(newProvider(outofbandMocks.NewMockProtocolService(ctrl), &mockroute.MockRouteSvc{}))
+		require.NoError(t, err)
+
+		_, err = client.CreateInvitation("label", WithServices(1))
+		require.EqualError(t, err, "unsupported service type int")
+	})
+
+	t.Run("create key error", func(t *testing.T) {
+		provider := newProvider(outofbandMocks.NewMockProtocolService(ctrl), &mockroute.MockRouteSvc{})
+		provider.KMSValue = &mockkms.CloseableKMS{CreateKeyErr: errors.New("test error")}
+
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		_, err = client.CreateInvitation("label")
+		require.EqualError(t, err, "create key set: test error")
+	})
+
+	t.Run("router config error", func(t *testing.T) {
+		client, err := New(newProvider(outofbandMocks.NewMockProtocolService(ctrl),
+			&mockroute.MockRouteSvc{ConfigErr: errors.New("test error")}))
+		require.NoError(t, err)
+
+		_, err = client.CreateInvitation("label")
+		require.EqualError(t, err, "fetch router config : test error")
+	})
+
+	t.Run("add key error", func(t *testing.T) {
+		client, err := New(newProvider(outofbandMocks.NewMockProtocolService(ctrl),
+			&mockroute.MockRouteSvc{AddKeyErr: errors.New("test error")}))
+		require.NoError(t, err)
+
+		_, err = client.CreateInvitation("label")
+		require.EqualError(t, err, "add key to the router : test error")
+	})
+}
+
+func TestClient_AcceptInvitation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inv := &outofband.Invitation{ID: "invitationID"}
+
+	svc := outofbandMocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().AcceptInvitation(inv).Return("connID", nil)
+
+	client, err := New(newProvider(svc, &mockroute.MockRouteSvc{}))
+	require.NoError(t, err)
+
+	connID, err := client.AcceptInvitation(inv)
+	require.NoError(t, err)
+	require.Equal(t, "connID", connID)
+}
@@ -0,0 +1,26 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package outofband provides support for the Out-of-Band Protocol 1.0:
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0434-outofband.
+//
+// The invitation is transferred out of band (e.g QR code, email) and allows to create a connection with
+// the sender using one of the handshake protocols (currently didexchange only). The sender may attach
+// request messages (e.g an issue-credential offer or a present-proof request) to the invitation.
+// Those requests are dispatched to the appropriate protocol services once the connection is established.
+// 	// the sender
+// 	invitation, err := outofband.CreateInvitation("label", WithAttachments(request))
+// 	...
+// 	// the receiver
+// 	connectionID, err := outofband.AcceptInvitation(invitation)
+//
+//  Basic Flow:
+//  1) Prepare client context
+//  2) Create client
+//  3) Create an invitation and send it out of band
+//  4) Accept the invitation on the receiver side
+//
+package outofband
@@ -0,0 +1,200 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	vcstore "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+)
+
+const (
+	vpContext = "https://www.w3.org/2018/credentials/v1"
+	vpType    = "VerifiablePresentation"
+)
+
+// Provider contains dependencies for the presentproof protocol and is typically created by using aries.Context()
+type Provider interface {
+	Service(id string) (interface{}, error)
+	StorageProvider() storage.Provider
+}
+
+// ProtocolService defines the presentproof service.
+type ProtocolService interface {
+	service.DIDComm
+	Continue(piID string, opt presentproof.Opt) error
+	Stop(piID string, err error) error
+	Actions() ([]presentproof.Action, error)
+}
+
+// PresentationSigner signs the verifiable presentation built from the stored credentials.
+// It returns the serialized presentation with a proof (e.g JWS or JSON-LD with an embedded proof).
+type PresentationSigner func(vp *verifiable.Presentation) ([]byte, error)
+
+// Client enable access to presentproof API
+type Client struct {
+	service.Event
+	service ProtocolService
+	vcStore *vcstore.Store
+}
+
+// New return new instance of the presentproof client
+func New(ctx Provider) (*Client, error) {
+	raw, err := ctx.Service(presentproof.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, ok := raw.(ProtocolService)
+	if !ok {
+		return nil, errors.New("cast service to presentproof service failed")
+	}
+
+	store, err := vcstore.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("new vc store: %w", err)
+	}
+
+	return &Client{
+		Event:   svc,
+		service: svc,
+		vcStore: store,
+	}, nil
+}
+
+// Actions returns unfinished actions for the async usage
+func (c *Client) Actions() ([]presentproof.Action, error) {
+	return c.service.Actions()
+}
+
+// SendRequestPresentation is used by the Verifier to send a request.
+func (c *Client) SendRequestPresentation(msg *presentproof.RequestPresentation, myDID, theirDID string) (string, error) {
+	if msg == nil {
+		return "", errors.New("request presentation is not provided")
+	}
+
+	msg.Type = presentproof.RequestPresentationMsgType
+
+	return c.service.HandleOutbound(service.NewDIDCommMsgMap(msg), myDID, theirDID)
+}
+
+// SendProposePresentation is used by the Prover to send a proposal.
+func (c *Client) SendProposePresentation(msg *presentproof.ProposePresentation, myDID, theirDID string) (string, error) {
+	if msg == nil {
+		return "", errors.New("propose presentation is not provided")
+	}
+
+	msg.Type = presentproof.ProposePresentationMsgType
+
+	return c.service.HandleOutbound(service.NewDIDCommMsgMap(msg), myDID, theirDID)
+}
+
+// CreatePresentation creates the Presentation message for the Prover.
+// The verifiable presentation encloses the credentials (by their IDs) kept in the verifiable credential store
+// and is signed by the given signer.
+func (c *Client) CreatePresentation(comment string, signer PresentationSigner,
+	vcIDs ...string) (*presentproof.Presentation, error) {
+	if len(vcIDs) == 0 {
+		return nil, errors.New("credentials are not provided")
+	}
+
+	credentials := make([]interface{}, len(vcIDs))
+
+	for i, id := range vcIDs {
+		vc, err := c.vcStore.GetVC(id)
+		if err != nil {
+			return nil, fmt.Errorf("get credential %s: %w", id, err)
+		}
+
+		credentials[i] = vc
+	}
+
+	vp := &verifiable.Presentation{
+		Context: []string{vpContext},
+		Type:    []string{vpType},
+	}
+
+	if err := vp.SetCredentials(credentials...); err != nil {
+		return nil, fmt.Errorf("set credentials: %w", err)
+	}
+
+	signed, err := signer(vp)
+	if err != nil {
+		return nil, fmt.Errorf("sign presentation: %w", err)
+	}
+
+	return &presentproof.Presentation{
+		Comment:             comment,
+		PresentationsAttach: []decorator.Attachment{presentproof.NewPresentationAttachment(signed)},
+	}, nil
+}
+
+// AcceptRequestPresentation is used by the Prover to accept a presentation request.
+// NOTE: For async usage. The message can be created by the CreatePresentation function.
+func (c *Client) AcceptRequestPresentation(piID string, msg *presentproof.Presentation) error {
+	return c.service.Continue(piID, WithPresentation(msg))
+}
+
+// NegotiateRequestPresentation is used by the Prover to counter a presentation request they received with a proposal.
+// NOTE: For async usage.
+func (c *Client) NegotiateRequestPresentation(piID string, msg *presentproof.ProposePresentation) error {
+	return c.service.Continue(piID, WithProposePresentation(msg))
+}
+
+// AcceptProposePresentation is used when the Verifier is willing to accept the propose presentation.
+// NOTE: For async usage.
+func (c *Client) AcceptProposePresentation(piID string, msg *presentproof.RequestPresentation) error {
+	return c.service.Continue(piID, WithRequestPresentation(msg))
+}
+
+// AcceptPresentation is used by the Verifier to accept a presentation.
+// NOTE: For async usage.
+func (c *Client) AcceptPresentation(piID string) error {
+	return c.service.Continue(piID, nil)
+}
+
+// DeclineRequestPresentation is used when the Prover does not want to accept the request presentation.
+// NOTE: For async usage.
+func (c *Client) DeclineRequestPresentation(piID, reason string) error {
+	return c.service.Stop(piID, errors.New(reason))
+}
+
+// DeclineProposePresentation is used when the Verifier does not want to accept the propose presentation.
+// NOTE: For async usage.
+func (c *Client) DeclineProposePresentation(piID, reason string) error {
+	return c.service.Stop(piID, errors.New(reason))
+}
+
+// DeclinePresentation is used by the Verifier to decline a presentation.
+// NOTE: For async usage.
+func (c *Client) DeclinePresentation(piID, reason string) error {
+	return c.service.Stop(piID, errors.New(reason))
+}
+
+// WithPresentation is used by the Prover to provide the presentation.
+// USAGE: event.Continue(WithPresentation(presentation))
+func WithPresentation(msg *presentproof.Presentation) presentproof.Opt {
+	return presentproof.WithPresentation(msg)
+}
+
+// WithProposePresentation is used by the Prover to counter a presentation request they received with a proposal.
+// USAGE: event.Continue(WithProposePresentation(proposal))
+func WithProposePresentation(msg *presentproof.ProposePresentation) presentproof.Opt {
+	return presentproof.WithProposePresentation(msg)
+}
+
+// WithRequestPresentation is used by the Verifier when they are willing to accept the proposal.
+// USAGE: event.Continue(WithRequestPresentation(request))
+func WithRequestPresentation(msg *presentproof.RequestPresentation) presentproof.Opt {
+	return presentproof.WithRequestPresentation(msg)
+}
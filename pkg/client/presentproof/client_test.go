@@ -0,0 +1,224 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	presentproofMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/presentproof"
+	storageMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+	vcstore "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+)
+
+const (
+	Alice = "Alice"
+	Bob   = "Bob"
+)
+
+const vcJSON = `{
+  "@context": ["https://www.w3.org/2018/credentials/v1"],
+  "id": "http://example.edu/credentials/1872",
+  "type": ["VerifiableCredential", "UniversityDegreeCredential"],
+  "credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"},
+  "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+  "issuanceDate": "2010-01-01T19:23:24Z"
+}`
+
+type storageProvider struct {
+	provider storage.Provider
+}
+
+func (p *storageProvider) StorageProvider() storage.Provider {
+	return p.provider
+}
+
+func newClient(t *testing.T, ctrl *gomock.Controller, svc ProtocolService, sp storage.Provider) *Client {
+	t.Helper()
+
+	provider := presentproofMocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(presentproof.Name).Return(svc, nil)
+	provider.EXPECT().StorageProvider().Return(sp)
+
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("get service error", func(t *testing.T) {
+		provider := presentproofMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(nil, errors.New("test error"))
+
+		_, err := New(provider)
+		require.EqualError(t, err, "test error")
+	})
+
+	t.Run("cast service error", func(t *testing.T) {
+		provider := presentproofMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(nil, nil)
+
+		_, err := New(provider)
+		require.EqualError(t, err, "cast service to presentproof service failed")
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		sp := storageMocks.NewMockProvider(ctrl)
+		sp.EXPECT().OpenStore(gomock.Any()).Return(nil, errors.New("test error"))
+
+		provider := presentproofMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(presentproofMocks.NewMockProtocolService(ctrl), nil)
+		provider.EXPECT().StorageProvider().Return(sp)
+
+		_, err := New(provider)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "new vc store")
+	})
+}
+
+func TestClient_SendMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectOutbound := func(svc *presentproofMocks.MockProtocolService, msgType string) {
+		svc.EXPECT().HandleOutbound(gomock.Any(), Alice, Bob).
+			DoAndReturn(func(msg service.DIDCommMsg, _, _ string) (string, error) {
+				require.Equal(t, msgType, msg.Type())
+
+				return "piID", nil
+			})
+	}
+
+	t.Run("SendRequestPresentation", func(t *testing.T) {
+		svc := presentproofMocks.NewMockProtocolService(ctrl)
+		expectOutbound(svc, presentproof.RequestPresentationMsgType)
+
+		client := newClient(t, ctrl, svc, mem.NewProvider())
+
+		piID, err := client.SendRequestPresentation(&presentproof.RequestPresentation{}, Alice, Bob)
+		require.NoError(t, err)
+		require.Equal(t, "piID", piID)
+
+		_, err = client.SendRequestPresentation(nil, Alice, Bob)
+		require.EqualError(t, err, "request presentation is not provided")
+	})
+
+	t.Run("SendProposePresentation", func(t *testing.T) {
+		svc := presentproofMocks.NewMockProtocolService(ctrl)
+		expectOutbound(svc, presentproof.ProposePresentationMsgType)
+
+		client := newClient(t, ctrl, svc, mem.NewProvider())
+
+		piID, err := client.SendProposePresentation(&presentproof.ProposePresentation{}, Alice, Bob)
+		require.NoError(t, err)
+		require.Equal(t, "piID", piID)
+
+		_, err = client.SendProposePresentation(nil, Alice, Bob)
+		require.EqualError(t, err, "propose presentation is not provided")
+	})
+}
+
+func TestClient_AcceptDecline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const piID = "piID"
+
+	svc := presentproofMocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().Continue(piID, gomock.Any()).Return(nil).Times(4)
+	svc.EXPECT().Stop(piID, gomock.Any()).Return(nil).Times(3)
+	svc.EXPECT().Actions().Return([]presentproof.Action{{PIID: piID}}, nil)
+
+	client := newClient(t, ctrl, svc, mem.NewProvider())
+
+	actions, err := client.Actions()
+	require.NoError(t, err)
+	require.Len(t, actions, 1)
+
+	require.NoError(t, client.AcceptRequestPresentation(piID, &presentproof.Presentation{}))
+	require.NoError(t, client.NegotiateRequestPresentation(piID, &presentproof.ProposePresentation{}))
+	require.NoError(t, client.AcceptProposePresentation(piID, &presentproof.RequestPresentation{}))
+	require.NoError(t, client.AcceptPresentation(piID))
+
+	require.NoError(t, client.DeclineRequestPresentation(piID, "reason"))
+	require.NoError(t, client.DeclineProposePresentation(piID, "reason"))
+	require.NoError(t, client.DeclinePresentation(piID, "reason"))
+}
+
+func TestClient_CreatePresentation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sp := mem.NewProvider()
+
+	vc, _, err := verifiable.NewCredential([]byte(vcJSON))
+	require.NoError(t, err)
+
+	store, err := vcstore.New(&storageProvider{provider: sp})
+	require.NoError(t, err)
+	require.NoError(t, store.SaveVC(vc))
+
+	client := newClient(t, ctrl, presentproofMocks.NewMockProtocolService(ctrl), sp)
+
+	signer := func(vp *verifiable.Presentation) ([]byte, error) {
+		require.Len(t, vp.Credentials(), 1)
+
+		vp.Proofs = []verifiable.Proof{{"type": "Ed25519Signature2018"}}
+
+		return vp.MarshalJSON()
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		msg, err := client.CreatePresentation("comment", signer, vc.ID)
+		require.NoError(t, err)
+		require.Equal(t, "comment", msg.Comment)
+
+		presentations, err := msg.Presentations()
+		require.NoError(t, err)
+		require.Len(t, presentations, 1)
+		require.Len(t, presentations[0].Credentials(), 1)
+	})
+
+	t.Run("No credentials", func(t *testing.T) {
+		msg, err := client.CreatePresentation("comment", signer)
+		require.EqualError(t, err, "credentials are not provided")
+		require.Nil(t, msg)
+	})
+
+	t.Run("Credential not found", func(t *testing.T) {
+		msg, err := client.CreatePresentation("comment", signer, "unknown")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get credential unknown")
+		require.Nil(t, msg)
+	})
+
+	t.Run("Sign error", func(t *testing.T) {
+		msg, err := client.CreatePresentation("comment", func(*verifiable.Presentation) ([]byte, error) {
+			return nil, errors.New("test error")
+		}, vc.ID)
+		require.EqualError(t, err, "sign presentation: test error")
+		require.Nil(t, msg)
+	})
+}
+
+func TestWithOptions(t *testing.T) {
+	require.NotNil(t, WithPresentation(&presentproof.Presentation{}))
+	require.NotNil(t, WithProposePresentation(&presentproof.ProposePresentation{}))
+	require.NotNil(t, WithRequestPresentation(&presentproof.RequestPresentation{}))
+}
@@ -0,0 +1,44 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package presentproof provides support for the Present Proof Protocol 1.0:
+// https://github.com/hyperledger/aries-rfcs/blob/master/features/0037-present-proof/README.md.
+//
+// Formalizes messages used to request and present a proof. The protocol is executed between the Verifier and
+// the Prover. The Verifier starts the protocol by sending a request, the Prover may start it by sending a proposal.
+// Incoming messages are delivered to the client as action events. Received presentations are verified by
+// the service before the action event is triggered.
+// 	presentproof := client.New(...)
+// 	presentproof.RegisterActionEvent(actions)
+// 	for {
+// 	  select {
+// 	    case event := <-actions:
+// 	      switch event.Message.Type() {
+// 	      case presentproof.RequestPresentationMsgType:
+// 	        // the Prover picks credentials from the store and provides a presentation
+// 	        presentation, err := presentproof.CreatePresentation("comment", signer, vcIDs...)
+// 	        ...
+// 	        event.Continue(WithPresentation(presentation))
+// 	      case presentproof.ProposePresentationMsgType:
+// 	        // the Verifier accepts the proposal and provides a request
+// 	        event.Continue(WithRequestPresentation(...))
+// 	      case presentproof.PresentationMsgType:
+// 	        // the Verifier accepts the presentation
+// 	        event.Continue(nil)
+// 	      }
+// 	  }
+// 	}
+//
+// Actions can also be handled asynchronously using the Actions function and the Accept/Decline functions.
+//
+//  Basic Flow:
+//  1) Prepare client context
+//  2) Create client
+//  3) Register for action events
+//  4) Handle actions
+//  5) Send a request or a proposal
+//
+package presentproof
@@ -0,0 +1,63 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+)
+
+// provider contains dependencies for the trust ping protocol and is typically created by using aries.Context()
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// Client enable access to trust ping api.
+type Client struct {
+	trustPingSvc protocolService
+}
+
+// protocolService defines trust ping service.
+type protocolService interface {
+	// DIDComm service
+	service.Handler
+
+	// Ping sends the ping message and waits for the response
+	Ping(connectionID string, timeout time.Duration) (*trustping.PingResponse, error)
+}
+
+// New return new instance of trust ping client.
+func New(ctx provider) (*Client, error) {
+	svc, err := ctx.Service(trustping.TrustPing)
+	if err != nil {
+		return nil, err
+	}
+
+	trustPingSvc, ok := svc.(protocolService)
+	if !ok {
+		return nil, errors.New("cast service to trust ping service failed")
+	}
+
+	return &Client{
+		trustPingSvc: trustPingSvc,
+	}, nil
+}
+
+// Ping sends the ping message to the agent on the other end of the connection (passed in connectionID).
+// This function blocks until the response arrives or the timeout expires (trustping.DefaultTimeout if zero).
+func (c *Client) Ping(connectionID string, timeout time.Duration) (*trustping.PingResponse, error) {
+	resp, err := c.trustPingSvc.Ping(connectionID, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("trust ping : %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	mocktrustping "github.com/hyperledger/aries-framework-go/pkg/internal/mock/didcomm/protocol/trustping"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/internal/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new client", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			ServiceValue: &mocktrustping.MockTrustPingSvc{}},
+		)
+		require.NoError(t, err)
+		require.NotNil(t, svc)
+	})
+
+	t.Run("test error from get service from context", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: fmt.Errorf("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+
+	t.Run("test error from cast service", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: nil})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cast service to trust ping service failed")
+	})
+}
+
+func TestPing(t *testing.T) {
+	t.Run("test ping - success", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mocktrustping.MockTrustPingSvc{
+				PingFunc: func(connectionID string, timeout time.Duration) (*trustping.PingResponse, error) {
+					require.Equal(t, "conn1", connectionID)
+					require.Equal(t, time.Second, timeout)
+
+					return &trustping.PingResponse{ID: "id"}, nil
+				},
+			}})
+		require.NoError(t, err)
+
+		resp, err := c.Ping("conn1", time.Second)
+		require.NoError(t, err)
+		require.Equal(t, "id", resp.ID)
+	})
+
+	t.Run("test ping - error", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mocktrustping.MockTrustPingSvc{
+				PingFunc: func(string, time.Duration) (*trustping.PingResponse, error) {
+					return nil, errors.New("ping error")
+				},
+			}})
+		require.NoError(t, err)
+
+		_, err = c.Ping("conn1", time.Second)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "trust ping")
+	})
+}
@@ -0,0 +1,9 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package trustping enables the agent to check that the connection with the other agent is alive.
+// The agent sends the ping message and waits for the ping response.
+package trustping
@@ -49,6 +49,12 @@ const (
 
 	// VC error group for Verifiable Credential command errors
 	VC Group = 6000
+
+	// TrustPing error group for Trust Ping command errors
+	TrustPing Group = 7000
+
+	// KMS error group for KMS command errors
+	KMS Group = 8000
 )
 
 // Error is the  interface for representing an command error condition, with the nil value representing no error.
@@ -0,0 +1,171 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+var logger = log.New("aries-framework/command/kms")
+
+// Error codes
+const (
+	// InvalidRequestErrorCode for invalid requests
+	InvalidRequestErrorCode = command.Code(iota + command.KMS)
+
+	// MissingPassphraseErrorCode for missing passphrase error
+	MissingPassphraseErrorCode
+
+	// MissingKeyIDsErrorCode for missing key IDs error
+	MissingKeyIDsErrorCode
+
+	// MissingBundleErrorCode for missing backup bundle error
+	MissingBundleErrorCode
+
+	// ExportKeysErrorCode for export keys error
+	ExportKeysErrorCode
+
+	// ImportKeysErrorCode for import keys error
+	ImportKeysErrorCode
+)
+
+const (
+	// command name
+	commandName = "kms"
+
+	// command methods
+	exportKeysCommandMethod = "ExportKeys"
+	importKeysCommandMethod = "ImportKeys"
+
+	// log constants
+	keyIDs        = "keyIDs"
+	successString = "success"
+)
+
+// errBackupNotSupported is returned when the KMS of the agent can't export and import keys
+var errBackupNotSupported = errors.New("the KMS does not support keys backup")
+
+// provider contains dependencies for the KMS command and is typically created by using aries.Context().
+type provider interface {
+	KMS() kms.KeyManager
+}
+
+// keyBackup is implemented by the KMS supporting the export and import of keys (e.g localkms.LocalKMS).
+type keyBackup interface {
+	ExportKeys(passphrase string, keyIDs ...string) ([]byte, error)
+	ImportKeys(passphrase string, bundle []byte) ([]string, error)
+}
+
+// Command contains command operations provided by KMS controller.
+type Command struct {
+	kms kms.KeyManager
+}
+
+// New returns new KMS controller command instance.
+func New(ctx provider) *Command {
+	return &Command{
+		kms: ctx.KMS(),
+	}
+}
+
+// GetHandlers returns list of all commands supported by this controller command
+func (o *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(commandName, exportKeysCommandMethod, o.ExportKeys),
+		cmdutil.NewCommandHandler(commandName, importKeysCommandMethod, o.ImportKeys),
+	}
+}
+
+// ExportKeys exports the requested keysets into a backup bundle encrypted with the passphrase.
+func (o *Command) ExportKeys(rw io.Writer, req io.Reader) command.Error {
+	var request ExportKeysArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, commandName, exportKeysCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Passphrase == "" {
+		logutil.LogDebug(logger, commandName, exportKeysCommandMethod, "missing passphrase")
+		return command.NewValidationError(MissingPassphraseErrorCode, errors.New("passphrase is mandatory"))
+	}
+
+	if len(request.KeyIDs) == 0 {
+		logutil.LogDebug(logger, commandName, exportKeysCommandMethod, "missing keyIDs")
+		return command.NewValidationError(MissingKeyIDsErrorCode, errors.New("keyIDs are mandatory"))
+	}
+
+	backup, ok := o.kms.(keyBackup)
+	if !ok {
+		logutil.LogError(logger, commandName, exportKeysCommandMethod, errBackupNotSupported.Error())
+		return command.NewExecuteError(ExportKeysErrorCode, errBackupNotSupported)
+	}
+
+	bundle, err := backup.ExportKeys(request.Passphrase, request.KeyIDs...)
+	if err != nil {
+		logutil.LogError(logger, commandName, exportKeysCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(keyIDs, fmt.Sprint(request.KeyIDs)))
+		return command.NewExecuteError(ExportKeysErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ExportKeysResponse{Bundle: bundle}, logger)
+
+	logutil.LogDebug(logger, commandName, exportKeysCommandMethod, successString,
+		logutil.CreateKeyValueString(keyIDs, fmt.Sprint(request.KeyIDs)))
+
+	return nil
+}
+
+// ImportKeys imports the keysets of the backup bundle encrypted with the passphrase, keeping their IDs.
+func (o *Command) ImportKeys(rw io.Writer, req io.Reader) command.Error {
+	var request ImportKeysArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, commandName, importKeysCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Passphrase == "" {
+		logutil.LogDebug(logger, commandName, importKeysCommandMethod, "missing passphrase")
+		return command.NewValidationError(MissingPassphraseErrorCode, errors.New("passphrase is mandatory"))
+	}
+
+	if len(request.Bundle) == 0 {
+		logutil.LogDebug(logger, commandName, importKeysCommandMethod, "missing bundle")
+		return command.NewValidationError(MissingBundleErrorCode, errors.New("bundle is mandatory"))
+	}
+
+	backup, ok := o.kms.(keyBackup)
+	if !ok {
+		logutil.LogError(logger, commandName, importKeysCommandMethod, errBackupNotSupported.Error())
+		return command.NewExecuteError(ImportKeysErrorCode, errBackupNotSupported)
+	}
+
+	imported, err := backup.ImportKeys(request.Passphrase, request.Bundle)
+	if err != nil {
+		logutil.LogError(logger, commandName, importKeysCommandMethod, err.Error())
+		return command.NewExecuteError(ImportKeysErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ImportKeysResponse{KeyIDs: imported}, logger)
+
+	logutil.LogDebug(logger, commandName, importKeysCommandMethod, successString,
+		logutil.CreateKeyValueString(keyIDs, fmt.Sprint(imported)))
+
+	return nil
+}
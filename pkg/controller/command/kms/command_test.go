@@ -0,0 +1,194 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/internal/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+func newLocalKMS(t *testing.T) *localkms.LocalKMS {
+	t.Helper()
+
+	k, err := localkms.New("local-lock://test/key/uri", &mockprovider.Provider{
+		StorageProviderValue: mockstorage.NewMockStoreProvider(),
+		SecretLockValue:      &noop.NoLock{},
+	})
+	require.NoError(t, err)
+
+	return k
+}
+
+func TestNew(t *testing.T) {
+	cmd := New(&mockprovider.Provider{KeyManagerValue: newLocalKMS(t)})
+	require.NotNil(t, cmd)
+
+	handlers := cmd.GetHandlers()
+	require.Equal(t, 2, len(handlers))
+}
+
+func TestExportImportKeys(t *testing.T) {
+	source := newLocalKMS(t)
+
+	kid, _, err := source.Create("ED25519")
+	require.NoError(t, err)
+
+	cmd := New(&mockprovider.Provider{KeyManagerValue: source})
+
+	var b bytes.Buffer
+	cmdErr := cmd.ExportKeys(&b, bytes.NewBufferString(
+		fmt.Sprintf(`{"passphrase":"secret","keyIDs":["%s"]}`, kid)))
+	require.NoError(t, cmdErr)
+
+	exported := ExportKeysResponse{}
+	require.NoError(t, json.NewDecoder(&b).Decode(&exported))
+	require.NotEmpty(t, exported.Bundle)
+
+	target := newLocalKMS(t)
+	cmd = New(&mockprovider.Provider{KeyManagerValue: target})
+
+	req, err := json.Marshal(&ImportKeysArgs{Passphrase: "secret", Bundle: exported.Bundle})
+	require.NoError(t, err)
+
+	b.Reset()
+	cmdErr = cmd.ImportKeys(&b, bytes.NewBuffer(req))
+	require.NoError(t, cmdErr)
+
+	imported := ImportKeysResponse{}
+	require.NoError(t, json.NewDecoder(&b).Decode(&imported))
+	require.Equal(t, []string{kid}, imported.KeyIDs)
+
+	_, err = target.Get(kid)
+	require.NoError(t, err)
+}
+
+func TestExportKeys_Failure(t *testing.T) {
+	tests := []struct {
+		name     string
+		noBackup bool
+		request  string
+		code     command.Code
+		errMsg   string
+	}{
+		{
+			name:    "invalid request",
+			request: `--`,
+			code:    InvalidRequestErrorCode,
+			errMsg:  "request decode",
+		},
+		{
+			name:    "missing passphrase",
+			request: `{"keyIDs":["kid"]}`,
+			code:    MissingPassphraseErrorCode,
+			errMsg:  "passphrase is mandatory",
+		},
+		{
+			name:    "missing key IDs",
+			request: `{"passphrase":"secret"}`,
+			code:    MissingKeyIDsErrorCode,
+			errMsg:  "keyIDs are mandatory",
+		},
+		{
+			name:     "backup not supported",
+			noBackup: true,
+			request:  `{"passphrase":"secret","keyIDs":["kid"]}`,
+			code:     ExportKeysErrorCode,
+			errMsg:   errBackupNotSupported.Error(),
+		},
+		{
+			name:    "export error",
+			request: `{"passphrase":"secret","keyIDs":["kid"]}`,
+			code:    ExportKeysErrorCode,
+			errMsg:  "export keys: get keyset 'kid'",
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			prov := &mockprovider.Provider{KeyManagerValue: newLocalKMS(t)}
+			if tc.noBackup {
+				prov.KeyManagerValue = &mockkms.KeyManager{}
+			}
+
+			var b bytes.Buffer
+			err := New(prov).ExportKeys(&b, bytes.NewBufferString(tc.request))
+			require.Error(t, err)
+			require.Equal(t, tc.code, err.Code())
+			require.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+}
+
+func TestImportKeys_Failure(t *testing.T) {
+	tests := []struct {
+		name     string
+		noBackup bool
+		request  string
+		code     command.Code
+		errMsg   string
+	}{
+		{
+			name:    "invalid request",
+			request: `--`,
+			code:    InvalidRequestErrorCode,
+			errMsg:  "request decode",
+		},
+		{
+			name:    "missing passphrase",
+			request: `{"bundle":{}}`,
+			code:    MissingPassphraseErrorCode,
+			errMsg:  "passphrase is mandatory",
+		},
+		{
+			name:    "missing bundle",
+			request: `{"passphrase":"secret"}`,
+			code:    MissingBundleErrorCode,
+			errMsg:  "bundle is mandatory",
+		},
+		{
+			name:     "backup not supported",
+			noBackup: true,
+			request:  `{"passphrase":"secret","bundle":{}}`,
+			code:     ImportKeysErrorCode,
+			errMsg:   errBackupNotSupported.Error(),
+		},
+		{
+			name:    "import error",
+			request: `{"passphrase":"secret","bundle":"invalid"}`,
+			code:    ImportKeysErrorCode,
+			errMsg:  "import keys: unmarshal bundle",
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			prov := &mockprovider.Provider{KeyManagerValue: newLocalKMS(t)}
+			if tc.noBackup {
+				prov.KeyManagerValue = &mockkms.KeyManager{}
+			}
+
+			var b bytes.Buffer
+			err := New(prov).ImportKeys(&b, bytes.NewBufferString(tc.request))
+			require.Error(t, err)
+			require.Equal(t, tc.code, err.Code())
+			require.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import "encoding/json"
+
+// ExportKeysArgs contains parameters for exporting keys into a backup bundle.
+type ExportKeysArgs struct {
+	// Passphrase used to encrypt the backup bundle
+	Passphrase string `json:"passphrase"`
+
+	// KeyIDs of the keysets to export
+	KeyIDs []string `json:"keyIDs"`
+}
+
+// ExportKeysResponse contains the exported backup bundle.
+type ExportKeysResponse struct {
+	// Bundle is the encrypted backup bundle
+	Bundle json.RawMessage `json:"bundle"`
+}
+
+// ImportKeysArgs contains parameters for importing the keys of a backup bundle.
+type ImportKeysArgs struct {
+	// Passphrase used to encrypt the backup bundle
+	Passphrase string `json:"passphrase"`
+
+	// Bundle is the encrypted backup bundle returned by the export
+	Bundle json.RawMessage `json:"bundle"`
+}
+
+// ImportKeysResponse contains the result of the keys import.
+type ImportKeysResponse struct {
+	// KeyIDs of the imported keysets
+	KeyIDs []string `json:"keyIDs"`
+}
@@ -0,0 +1,112 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/trustping"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+)
+
+var logger = log.New("aries-framework/command/trustping")
+
+// Error codes
+const (
+	// InvalidRequestErrorCode for invalid requests
+	InvalidRequestErrorCode = command.Code(iota + command.TrustPing)
+
+	// PingMissingConnIDCode for missing connection ID error
+	PingMissingConnIDCode
+
+	// PingErrorCode for ping error
+	PingErrorCode
+)
+
+const (
+	// command name
+	commandName = "trustping"
+
+	// command methods
+	pingCommandMethod = "Ping"
+
+	// log constants
+	connectionID  = "connectionID"
+	successString = "success"
+)
+
+// provider contains dependencies for the trust ping protocol and is typically created by using aries.Context().
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// Command contains command operations provided by trust ping controller.
+type Command struct {
+	trustPingClient *trustping.Client
+}
+
+// New returns new trust ping controller command instance.
+func New(ctx provider) (*Command, error) {
+	trustPingClient, err := trustping.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create trust ping client : %w", err)
+	}
+
+	return &Command{
+		trustPingClient: trustPingClient,
+	}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command
+func (o *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(commandName, pingCommandMethod, o.Ping),
+	}
+}
+
+// Ping sends the trust ping to the agent on the other end of the connection and waits for the response.
+func (o *Command) Ping(rw io.Writer, req io.Reader) command.Error {
+	var request PingArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, commandName, pingCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.ConnectionID == "" {
+		logutil.LogDebug(logger, commandName, pingCommandMethod, "missing connectionID",
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+		return command.NewValidationError(PingMissingConnIDCode, errors.New("connectionID is mandatory"))
+	}
+
+	start := time.Now()
+
+	resp, err := o.trustPingClient.Ping(request.ConnectionID, time.Duration(request.Timeout)*time.Millisecond)
+	if err != nil {
+		logutil.LogError(logger, commandName, pingCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+		return command.NewExecuteError(PingErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &PingResponse{
+		ResponseID:   resp.ID,
+		ResponseTime: time.Since(start).Milliseconds(),
+	}, logger)
+
+	logutil.LogDebug(logger, commandName, pingCommandMethod, successString,
+		logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	mocktrustping "github.com/hyperledger/aries-framework-go/pkg/internal/mock/didcomm/protocol/trustping"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/internal/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new command", func(t *testing.T) {
+		cmd, err := New(
+			&mockprovider.Provider{
+				ServiceValue: &mocktrustping.MockTrustPingSvc{},
+			},
+		)
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handlers := cmd.GetHandlers()
+		require.Equal(t, 1, len(handlers))
+	})
+
+	t.Run("test new command - client creation fail", func(t *testing.T) {
+		cmd, err := New(
+			&mockprovider.Provider{},
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create trust ping client")
+		require.Nil(t, cmd)
+	})
+}
+
+func TestPing(t *testing.T) {
+	t.Run("test ping - success", func(t *testing.T) {
+		cmd, err := New(
+			&mockprovider.Provider{
+				ServiceValue: &mocktrustping.MockTrustPingSvc{
+					PingFunc: func(connectionID string, timeout time.Duration) (*trustping.PingResponse, error) {
+						require.Equal(t, "123-abc", connectionID)
+						require.Equal(t, 100*time.Millisecond, timeout)
+
+						return &trustping.PingResponse{ID: "response-id"}, nil
+					},
+				},
+			},
+		)
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		jsonReq := `{"connectionID":"123-abc","timeout":100}`
+		var b bytes.Buffer
+		err = cmd.Ping(&b, bytes.NewBufferString(jsonReq))
+		require.NoError(t, err)
+
+		response := PingResponse{}
+		err = json.NewDecoder(&b).Decode(&response)
+		require.NoError(t, err)
+		require.Equal(t, "response-id", response.ResponseID)
+	})
+
+	t.Run("test ping - empty connectionID", func(t *testing.T) {
+		cmd, err := New(
+			&mockprovider.Provider{
+				ServiceValue: &mocktrustping.MockTrustPingSvc{},
+			},
+		)
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		jsonReq := `{"connectionID":""}`
+		var b bytes.Buffer
+		err = cmd.Ping(&b, bytes.NewBufferString(jsonReq))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "connectionID is mandatory")
+	})
+
+	t.Run("test ping - invalid request", func(t *testing.T) {
+		cmd, err := New(
+			&mockprovider.Provider{
+				ServiceValue: &mocktrustping.MockTrustPingSvc{},
+			},
+		)
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		jsonReq := `--`
+		var b bytes.Buffer
+		err = cmd.Ping(&b, bytes.NewBufferString(jsonReq))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "request decode")
+	})
+
+	t.Run("test ping - error", func(t *testing.T) {
+		cmd, err := New(
+			&mockprovider.Provider{
+				ServiceValue: &mocktrustping.MockTrustPingSvc{
+					PingFunc: func(string, time.Duration) (*trustping.PingResponse, error) {
+						return nil, errors.New("ping error")
+					},
+				},
+			},
+		)
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		jsonReq := `{"connectionID":"123-abc"}`
+		var b bytes.Buffer
+		err = cmd.Ping(&b, bytes.NewBufferString(jsonReq))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "ping error")
+	})
+}
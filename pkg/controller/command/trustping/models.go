@@ -0,0 +1,25 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+// PingArgs contains parameters for sending the trust ping.
+type PingArgs struct {
+	// ConnectionID of the connection to check
+	ConnectionID string `json:"connectionID"`
+
+	// Timeout in milliseconds to wait for the response (optional)
+	Timeout int64 `json:"timeout,omitempty"`
+}
+
+// PingResponse contains the result of the trust ping.
+type PingResponse struct {
+	// ResponseID is the ID of the received ping response message
+	ResponseID string `json:"responseID"`
+
+	// ResponseTime is the round trip time in milliseconds
+	ResponseTime int64 `json:"responseTime"`
+}
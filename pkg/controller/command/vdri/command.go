@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
 )
@@ -30,6 +31,12 @@ const (
 
 	// CreatePublicDIDError is for failures while creating public DIDs
 	CreatePublicDIDError
+
+	// UpdateDIDError is for failures while updating DIDs
+	UpdateDIDError
+
+	// DeactivateDIDError is for failures while deactivating DIDs
+	DeactivateDIDError
 )
 
 const (
@@ -37,10 +44,22 @@ const (
 	commandName = "vdri"
 
 	// error messages
-	errDIDMethodMandatory = "invalid method name"
+	errDIDMethodMandatory   = "invalid method name"
+	errDIDDocumentMandatory = "DID document is mandatory"
+	errDIDMandatory         = "DID is mandatory"
 
 	// command methods
 	createPublicDIDCommandMethod = "CreatePublicDID"
+	updateDIDCommandMethod       = "UpdateDID"
+	deactivateDIDCommandMethod   = "DeactivateDID"
+
+	// log constants
+	didString = "did"
+
+	// operation types of the request builders
+	createOperation     = "create"
+	updateOperation     = "update"
+	deactivateOperation = "deactivate"
 )
 
 // provider contains dependencies for the vdri controller command operations
@@ -65,6 +84,8 @@ func New(ctx provider) *Command {
 func (o *Command) GetHandlers() []command.Handler {
 	return []command.Handler{
 		cmdutil.NewCommandHandler(commandName, createPublicDIDCommandMethod, o.CreatePublicDID),
+		cmdutil.NewCommandHandler(commandName, updateDIDCommandMethod, o.UpdateDID),
+		cmdutil.NewCommandHandler(commandName, deactivateDIDCommandMethod, o.DeactivateDID),
 	}
 }
 
@@ -101,14 +122,95 @@ func (o *Command) CreatePublicDID(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// UpdateDID updates the DID document of a DID using agent VDRI, the update is signed by the agent
+func (o *Command) UpdateDID(rw io.Writer, req io.Reader) command.Error {
+	var request UpdateDIDArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, commandName, updateDIDCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if len(request.DIDDocument) == 0 {
+		logutil.LogDebug(logger, commandName, updateDIDCommandMethod, errDIDDocumentMandatory)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errDIDDocumentMandatory))
+	}
+
+	doc, err := did.ParseDocument(request.DIDDocument)
+	if err != nil {
+		logutil.LogInfo(logger, commandName, updateDIDCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("parse DID document: %w", err))
+	}
+
+	err = o.ctx.VDRIRegistry().Update(doc, updateOptions(updateOperation, request.RequestHeader)...)
+	if err != nil {
+		logutil.LogError(logger, commandName, updateDIDCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(didString, doc.ID))
+		return command.NewExecuteError(UpdateDIDError, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, commandName, updateDIDCommandMethod, "success",
+		logutil.CreateKeyValueString(didString, doc.ID))
+
+	return nil
+}
+
+// DeactivateDID deactivates a DID using agent VDRI, the deactivation is signed by the agent
+func (o *Command) DeactivateDID(rw io.Writer, req io.Reader) command.Error {
+	var request DeactivateDIDArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, commandName, deactivateDIDCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if request.DID == "" {
+		logutil.LogDebug(logger, commandName, deactivateDIDCommandMethod, errDIDMandatory)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errDIDMandatory))
+	}
+
+	err = o.ctx.VDRIRegistry().Deactivate(request.DID, updateOptions(deactivateOperation, request.RequestHeader)...)
+	if err != nil {
+		logutil.LogError(logger, commandName, deactivateDIDCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(didString, request.DID))
+		return command.NewExecuteError(DeactivateDIDError, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, commandName, deactivateDIDCommandMethod, "success",
+		logutil.CreateKeyValueString(didString, request.DID))
+
+	return nil
+}
+
+// updateOptions returns the options of the operation, with the request builder of the request header if set
+func updateOptions(operation, header string) []vdriapi.UpdateOpts {
+	if header == "" {
+		return nil
+	}
+
+	return []vdriapi.UpdateOpts{vdriapi.WithUpdateRequestBuilder(getOperationRequestBuilder(operation, header))}
+}
+
 // prepareBasicRequestBuilder is basic request builder for public DID creation
 // request body format is : {"header": {raw header}, "payload": "payload"}
 func getBasicRequestBuilder(header string) func(payload []byte) (io.Reader, error) {
+	return getOperationRequestBuilder(createOperation, header)
+}
+
+// getOperationRequestBuilder is the basic request builder of the operation, the payload is the DID document
+// of the create and update operations and is empty for the deactivate operation
+func getOperationRequestBuilder(operation, header string) func(payload []byte) (io.Reader, error) {
 	return func(payload []byte) (io.Reader, error) {
 		encodedDoc := base64.URLEncoding.EncodeToString(payload)
 
 		schema := &createPayloadSchema{
-			Operation:           "create",
+			Operation:           operation,
 			DidDocument:         encodedDoc,
 			NextUpdateOTPHash:   "",
 			NextRecoveryOTPHash: "",
@@ -15,6 +15,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/mock/didcomm/protocol"
 	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
 )
@@ -102,6 +104,139 @@ func TestOperation_CreatePublicDID(t *testing.T) {
 	})
 }
 
+func TestOperation_UpdateDID(t *testing.T) {
+	didDoc, err := (&mockvdri.MockVDRIRegistry{}).Create("sidetree")
+	require.NoError(t, err)
+
+	docBytes, err := didDoc.JSONBytes()
+	require.NoError(t, err)
+
+	t.Run("Test successful update DID", func(t *testing.T) {
+		cmd := New(&protocol.MockProvider{CustomVDRI: &mockvdri.MockVDRIRegistry{
+			UpdateFunc: func(doc *did.Doc, opts ...vdriapi.UpdateOpts) error {
+				require.Equal(t, didDoc.ID, doc.ID)
+				require.Empty(t, opts)
+				return nil
+			}}})
+		require.NotNil(t, cmd)
+
+		req, err := json.Marshal(UpdateDIDArgs{DIDDocument: docBytes})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.UpdateDID(&b, bytes.NewBuffer(req))
+		require.NoError(t, cmdErr)
+	})
+
+	t.Run("Test successful update DID with request header", func(t *testing.T) {
+		cmd := New(&protocol.MockProvider{CustomVDRI: &mockvdri.MockVDRIRegistry{
+			UpdateFunc: func(doc *did.Doc, opts ...vdriapi.UpdateOpts) error {
+				updateOpts := &vdriapi.UpdateDIDOpts{}
+				for _, opt := range opts {
+					opt(updateOpts)
+				}
+				require.NotNil(t, updateOpts.RequestBuilder)
+				return nil
+			}}})
+		require.NotNil(t, cmd)
+
+		req, err := json.Marshal(UpdateDIDArgs{DIDDocument: docBytes, RequestHeader: "{}"})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.UpdateDID(&b, bytes.NewBuffer(req))
+		require.NoError(t, cmdErr)
+	})
+
+	t.Run("Test update DID validation error", func(t *testing.T) {
+		cmd := New(&protocol.MockProvider{})
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.UpdateDID(&b, bytes.NewBufferString(`"""`))
+		require.Error(t, cmdErr)
+		require.Equal(t, cmdErr.Type(), command.ValidationError)
+		require.Equal(t, cmdErr.Code(), InvalidRequestErrorCode)
+		require.Contains(t, cmdErr.Error(), "cannot unmarshal")
+
+		cmdErr = cmd.UpdateDID(&b, bytes.NewBufferString(`{}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, cmdErr.Type(), command.ValidationError)
+		require.Equal(t, cmdErr.Code(), InvalidRequestErrorCode)
+		require.Contains(t, cmdErr.Error(), errDIDDocumentMandatory)
+
+		cmdErr = cmd.UpdateDID(&b, bytes.NewBufferString(`{"didDocument":{}}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, cmdErr.Type(), command.ValidationError)
+		require.Equal(t, cmdErr.Code(), InvalidRequestErrorCode)
+		require.Contains(t, cmdErr.Error(), "parse DID document")
+	})
+
+	t.Run("Failed update DID, VDRI error", func(t *testing.T) {
+		const errMsg = "just fail it error"
+		cmd := New(&protocol.MockProvider{CustomVDRI: &mockvdri.MockVDRIRegistry{UpdateErr: fmt.Errorf(errMsg)}})
+		require.NotNil(t, cmd)
+
+		req, err := json.Marshal(UpdateDIDArgs{DIDDocument: docBytes})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.UpdateDID(&b, bytes.NewBuffer(req))
+		require.Error(t, cmdErr)
+		require.Equal(t, cmdErr.Type(), command.ExecuteError)
+		require.Equal(t, cmdErr.Code(), UpdateDIDError)
+		require.Contains(t, cmdErr.Error(), errMsg)
+	})
+}
+
+func TestOperation_DeactivateDID(t *testing.T) {
+	t.Run("Test successful deactivate DID", func(t *testing.T) {
+		cmd := New(&protocol.MockProvider{CustomVDRI: &mockvdri.MockVDRIRegistry{
+			DeactivateFunc: func(didID string, opts ...vdriapi.UpdateOpts) error {
+				require.Equal(t, "did:example:123", didID)
+				require.Len(t, opts, 1)
+				return nil
+			}}})
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		req := []byte(`{"did":"did:example:123", "header":"{}"}`)
+		cmdErr := cmd.DeactivateDID(&b, bytes.NewBuffer(req))
+		require.NoError(t, cmdErr)
+	})
+
+	t.Run("Test deactivate DID validation error", func(t *testing.T) {
+		cmd := New(&protocol.MockProvider{})
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.DeactivateDID(&b, bytes.NewBufferString(`"""`))
+		require.Error(t, cmdErr)
+		require.Equal(t, cmdErr.Type(), command.ValidationError)
+		require.Equal(t, cmdErr.Code(), InvalidRequestErrorCode)
+		require.Contains(t, cmdErr.Error(), "cannot unmarshal")
+
+		cmdErr = cmd.DeactivateDID(&b, bytes.NewBufferString(`{}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, cmdErr.Type(), command.ValidationError)
+		require.Equal(t, cmdErr.Code(), InvalidRequestErrorCode)
+		require.Contains(t, cmdErr.Error(), errDIDMandatory)
+	})
+
+	t.Run("Failed deactivate DID, VDRI error", func(t *testing.T) {
+		const errMsg = "just fail it error"
+		cmd := New(&protocol.MockProvider{CustomVDRI: &mockvdri.MockVDRIRegistry{UpdateErr: fmt.Errorf(errMsg)}})
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.DeactivateDID(&b, bytes.NewBufferString(`{"did":"did:example:123"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, cmdErr.Type(), command.ExecuteError)
+		require.Equal(t, cmdErr.Code(), DeactivateDIDError)
+		require.Contains(t, cmdErr.Error(), errMsg)
+	})
+}
+
 func TestBuildSideTreeRequest(t *testing.T) {
 	registry := mockvdri.MockVDRIRegistry{}
 	didDoc, err := registry.Create("sidetree")
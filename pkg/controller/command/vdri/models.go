@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package vdri
 
 import (
+	"encoding/json"
+
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 )
 
@@ -24,3 +26,21 @@ type CreatePublicDIDResponse struct {
 	// TODO return base64-encoded raw bytes of the DID doc [Issue: #855]
 	DID *did.Doc `json:"did"`
 }
+
+// UpdateDIDArgs contains parameters for updating the DID document of a DID
+type UpdateDIDArgs struct {
+	// DIDDocument is the updated DID document
+	DIDDocument json.RawMessage `json:"didDocument"`
+
+	// RequestHeader to be included while submitting request to http binding URL
+	RequestHeader string `json:"header,omitempty"`
+}
+
+// DeactivateDIDArgs contains parameters for deactivating a DID
+type DeactivateDIDArgs struct {
+	// DID to deactivate
+	DID string `json:"did"`
+
+	// RequestHeader to be included while submitting request to http binding URL
+	RequestHeader string `json:"header,omitempty"`
+}
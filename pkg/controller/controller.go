@@ -11,14 +11,18 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	didexchangecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/didexchange"
+	kmscmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
 	messagingcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/messaging"
 	routercmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/route"
+	trustpingcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/trustping"
 	vdricmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
 	didexchangerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/didexchange"
+	kmsrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/kms"
 	messagingrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/messaging"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest/route"
+	trustpingrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/trustping"
 	vdrirest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/vdri"
 	verifiablerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/webhook"
@@ -106,16 +110,27 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 		return nil, err
 	}
 
+	// trust ping REST operation
+	trustPingOp, err := trustpingrest.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// verifiable command operation
 	verifiablecmd := verifiablerest.New()
 
+	// KMS REST operation
+	kmsOp := kmsrest.New(ctx)
+
 	// creat handlers from all operations
 	var allHandlers []rest.Handler
 	allHandlers = append(allHandlers, exchangeOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, vdriOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, messagingOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, routeOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, trustPingOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, verifiablecmd.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, kmsOp.GetRESTHandlers()...)
 
 	return allHandlers, nil
 }
@@ -155,15 +170,26 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 		return nil, err
 	}
 
+	// trust ping command operation
+	tpcmd, err := trustpingcmd.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// verifiable command operation
 	verifiablecmd := verifiable.New()
 
+	// KMS command operation
+	kcmd := kmscmd.New(ctx)
+
 	var allHandlers []command.Handler
 	allHandlers = append(allHandlers, didexcmd.GetHandlers()...)
 	allHandlers = append(allHandlers, vcmd.GetHandlers()...)
 	allHandlers = append(allHandlers, msgcmd.GetHandlers()...)
 	allHandlers = append(allHandlers, routecmd.GetHandlers()...)
+	allHandlers = append(allHandlers, tpcmd.GetHandlers()...)
 	allHandlers = append(allHandlers, verifiablecmd.GetHandlers()...)
+	allHandlers = append(allHandlers, kcmd.GetHandlers()...)
 
 	return allHandlers, nil
 }
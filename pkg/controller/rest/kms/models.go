@@ -0,0 +1,55 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
+)
+
+// exportKeysReq model
+//
+// This is used to export keysets into an encrypted backup bundle.
+//
+// swagger:parameters exportKeysRequest
+type exportKeysReq struct { // nolint: unused,deadcode
+	// Params for exporting the keys
+	//
+	// in: body
+	Params kms.ExportKeysArgs
+}
+
+// exportKeysRes model
+//
+// response of the keys export
+//
+// swagger:response exportKeysResponse
+type exportKeysRes struct { // nolint: unused,deadcode
+	// in: body
+	Params kms.ExportKeysResponse
+}
+
+// importKeysReq model
+//
+// This is used to import the keysets of an encrypted backup bundle.
+//
+// swagger:parameters importKeysRequest
+type importKeysReq struct { // nolint: unused,deadcode
+	// Params for importing the keys
+	//
+	// in: body
+	Params kms.ImportKeysArgs
+}
+
+// importKeysRes model
+//
+// response of the keys import
+//
+// swagger:response importKeysResponse
+type importKeysRes struct { // nolint: unused,deadcode
+	// in: body
+	Params kms.ImportKeysResponse
+}
@@ -0,0 +1,78 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"net/http"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	kmsOperationID = "/kms"
+	exportKeysPath = kmsOperationID + "/export"
+	importKeysPath = kmsOperationID + "/import"
+)
+
+// provider contains dependencies for the KMS command and is typically created by using aries.Context().
+type provider interface {
+	KMS() kmsapi.KeyManager
+}
+
+// Operation contains basic common operations provided by controller REST API
+type Operation struct {
+	handlers []rest.Handler
+	command  *kms.Command
+}
+
+// New returns new KMS rest client instance
+func New(ctx provider) *Operation {
+	o := &Operation{command: kms.New(ctx)}
+
+	o.registerHandler()
+
+	return o
+}
+
+// GetRESTHandlers get all controller API handler available for this service
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// registerHandler register handlers to be exposed from this service as REST API endpoints.
+func (o *Operation) registerHandler() {
+	// Add more endpoints here to expose them as controller API endpoints
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(exportKeysPath, http.MethodPost, o.ExportKeys),
+		cmdutil.NewHTTPHandler(importKeysPath, http.MethodPost, o.ImportKeys),
+	}
+}
+
+// ExportKeys swagger:route POST /kms/export kms exportKeysRequest
+//
+// Exports the keysets into a backup bundle encrypted with the passphrase.
+//
+// Responses:
+//    default: genericError
+//    200: exportKeysResponse
+func (o *Operation) ExportKeys(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.ExportKeys, rw, req.Body)
+}
+
+// ImportKeys swagger:route POST /kms/import kms importKeysRequest
+//
+// Imports the keysets of a backup bundle encrypted with the passphrase.
+//
+// Responses:
+//    default: genericError
+//    200: importKeysResponse
+func (o *Operation) ImportKeys(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.ImportKeys, rw, req.Body)
+}
@@ -0,0 +1,190 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/internal/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+func newLocalKMS(t *testing.T) *localkms.LocalKMS {
+	t.Helper()
+
+	k, err := localkms.New("local-lock://test/key/uri", &mockprovider.Provider{
+		StorageProviderValue: mockstorage.NewMockStoreProvider(),
+		SecretLockValue:      &noop.NoLock{},
+	})
+	require.NoError(t, err)
+
+	return k
+}
+
+func TestGetAPIHandlers(t *testing.T) {
+	svc := New(&mockprovider.Provider{KeyManagerValue: newLocalKMS(t)})
+	require.NotNil(t, svc)
+
+	handlers := svc.GetRESTHandlers()
+	require.Equal(t, len(handlers), 2)
+}
+
+func TestExportImportKeys(t *testing.T) {
+	t.Run("test export and import keys - success", func(t *testing.T) {
+		source := newLocalKMS(t)
+
+		kid, _, err := source.Create("ED25519")
+		require.NoError(t, err)
+
+		svc := New(&mockprovider.Provider{KeyManagerValue: source})
+
+		var jsonStr = []byte(fmt.Sprintf(`{
+		"passphrase":"secret",
+		"keyIDs":["%s"]
+		}`, kid))
+
+		handler := lookupHandler(t, svc, exportKeysPath)
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+		require.NoError(t, err)
+
+		exported := kms.ExportKeysResponse{}
+		err = json.Unmarshal(buf.Bytes(), &exported)
+		require.NoError(t, err)
+
+		target := newLocalKMS(t)
+		svc = New(&mockprovider.Provider{KeyManagerValue: target})
+
+		jsonStr, err = json.Marshal(&kms.ImportKeysArgs{Passphrase: "secret", Bundle: exported.Bundle})
+		require.NoError(t, err)
+
+		handler = lookupHandler(t, svc, importKeysPath)
+		buf, err = getSuccessResponseFromHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+		require.NoError(t, err)
+
+		imported := kms.ImportKeysResponse{}
+		err = json.Unmarshal(buf.Bytes(), &imported)
+		require.NoError(t, err)
+
+		// verify response
+		require.Equal(t, []string{kid}, imported.KeyIDs)
+	})
+
+	t.Run("test export keys - missing passphrase", func(t *testing.T) {
+		svc := New(&mockprovider.Provider{KeyManagerValue: newLocalKMS(t)})
+
+		var jsonStr = []byte(`{
+		"keyIDs":["kid"]
+		}`)
+
+		handler := lookupHandler(t, svc, exportKeysPath)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, kms.MissingPassphraseErrorCode, "passphrase is mandatory", buf.Bytes())
+	})
+
+	t.Run("test import keys - error", func(t *testing.T) {
+		svc := New(&mockprovider.Provider{KeyManagerValue: newLocalKMS(t)})
+
+		var jsonStr = []byte(`{
+		"passphrase":"secret",
+		"bundle":"invalid"
+		}`)
+
+		handler := lookupHandler(t, svc, importKeysPath)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, kms.ImportKeysErrorCode, "import keys: unmarshal bundle", buf.Bytes())
+	})
+}
+
+func lookupHandler(t *testing.T, op *Operation, path string) rest.Handler {
+	handlers := op.GetRESTHandlers()
+	require.NotEmpty(t, handlers)
+
+	for _, h := range handlers {
+		if h.Path() == path {
+			return h
+		}
+	}
+
+	require.Fail(t, "unable to find handler")
+
+	return nil
+}
+
+// getSuccessResponseFromHandler reads response from given http handle func.
+// expects http status OK.
+func getSuccessResponseFromHandler(handler rest.Handler, requestBody io.Reader,
+	path string) (*bytes.Buffer, error) {
+	response, status, err := sendRequestToHandler(handler, requestBody, path)
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: got %v, want %v",
+			status, http.StatusOK)
+	}
+
+	return response, err
+}
+
+// sendRequestToHandler reads response from given http handle func.
+func sendRequestToHandler(handler rest.Handler, requestBody io.Reader, path string) (*bytes.Buffer, int, error) {
+	// prepare request
+	req, err := http.NewRequest(handler.Method(), path, requestBody)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// prepare router
+	router := mux.NewRouter()
+
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	// create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	rr := httptest.NewRecorder()
+
+	// serve http on given response and request
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code, nil
+}
+
+func verifyError(t *testing.T, expectedCode command.Code, expectedMsg string, data []byte) {
+	// Parser generic error response
+	errResponse := struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}{}
+	err := json.Unmarshal(data, &errResponse)
+	require.NoError(t, err)
+
+	// verify response
+	require.EqualValues(t, expectedCode, errResponse.Code)
+	require.NotEmpty(t, errResponse.Message)
+
+	if expectedMsg != "" {
+		require.Contains(t, errResponse.Message, expectedMsg)
+	}
+}
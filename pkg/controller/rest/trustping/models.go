@@ -0,0 +1,33 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/trustping"
+)
+
+// pingReq model
+//
+// This is used to send the trust ping to the agent on the other end of the connection.
+//
+// swagger:parameters trustPingRequest
+type pingReq struct { // nolint: unused,deadcode
+	// Params for sending the trust ping
+	//
+	// in: body
+	Params trustping.PingArgs
+}
+
+// pingRes model
+//
+// response of the trust ping
+//
+// swagger:response trustPingResponse
+type pingRes struct { // nolint: unused,deadcode
+	// in: body
+	Params trustping.PingResponse
+}
@@ -0,0 +1,70 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/trustping"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+const (
+	trustPingOperationID = "/trustping"
+	pingPath             = trustPingOperationID + "/ping"
+)
+
+// provider contains dependencies for the trust ping protocol and is typically created by using aries.Context().
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// Operation contains basic common operations provided by controller REST API
+type Operation struct {
+	handlers []rest.Handler
+	command  *trustping.Command
+}
+
+// New returns new trust ping rest client instance
+func New(ctx provider) (*Operation, error) {
+	trustPingCmd, err := trustping.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create trust ping command : %w", err)
+	}
+
+	o := &Operation{command: trustPingCmd}
+
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this service
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// registerHandler register handlers to be exposed from this protocol service as REST API endpoints.
+func (o *Operation) registerHandler() {
+	// Add more protocol endpoints here to expose them as controller API endpoints
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(pingPath, http.MethodPost, o.Ping),
+	}
+}
+
+// Ping swagger:route POST /trustping/ping trustping trustPingRequest
+//
+// Sends the trust ping to the agent on the other end of the connection and waits for the response.
+//
+// Responses:
+//    default: genericError
+//    200: trustPingResponse
+func (o *Operation) Ping(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Ping, rw, req.Body)
+}
@@ -32,3 +32,27 @@ type createPublicDIDResponse struct {
 	// in: body
 	DID did.Doc `json:"did"`
 }
+
+// updateDIDRequest model
+//
+// This is used for operation to update the DID document of a DID
+//
+// swagger:parameters updateDID
+type updateDIDRequest struct { // nolint: unused,deadcode
+	// Params for updating the DID document
+	//
+	// in: body
+	Params vdricommand.UpdateDIDArgs
+}
+
+// deactivateDIDRequest model
+//
+// This is used for operation to deactivate a DID
+//
+// swagger:parameters deactivateDID
+type deactivateDIDRequest struct { // nolint: unused,deadcode
+	// Params for deactivating the DID
+	//
+	// in: body
+	Params vdricommand.DeactivateDIDArgs
+}
@@ -21,6 +21,8 @@ import (
 const (
 	vdriOperationID     = "/vdri"
 	createPublicDIDPath = vdriOperationID + "/create-public-did"
+	updateDIDPath       = vdriOperationID + "/update-did"
+	deactivateDIDPath   = vdriOperationID + "/deactivate-did"
 )
 
 // provider contains dependencies for the common controller operations
@@ -53,6 +55,8 @@ func (o *Operation) registerHandler() {
 	// Add more protocol endpoints here to expose them as controller API endpoints
 	o.handlers = []rest.Handler{
 		cmdutil.NewHTTPHandler(createPublicDIDPath, http.MethodPost, o.CreatePublicDID),
+		cmdutil.NewHTTPHandler(updateDIDPath, http.MethodPost, o.UpdateDID),
+		cmdutil.NewHTTPHandler(deactivateDIDPath, http.MethodPost, o.DeactivateDID),
 	}
 }
 
@@ -73,6 +77,26 @@ func (o *Operation) CreatePublicDID(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.CreatePublicDID, rw, bytes.NewReader(reqBytes))
 }
 
+// UpdateDID swagger:route POST /vdri/update-did vdri updateDID
+//
+// Updates the DID document of a DID, the update is signed by the agent.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) UpdateDID(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.UpdateDID, rw, req.Body)
+}
+
+// DeactivateDID swagger:route POST /vdri/deactivate-did vdri deactivateDID
+//
+// Deactivates a DID, the deactivation is signed by the agent.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) DeactivateDID(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.DeactivateDID, rw, req.Body)
+}
+
 // queryValuesAsJSON converts query strings to `map[string]string`
 // and marshals them to JSON bytes
 func queryValuesAsJSON(vals url.Values) ([]byte, error) {
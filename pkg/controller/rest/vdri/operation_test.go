@@ -83,12 +83,93 @@ func TestOperation_CreatePublicDID(t *testing.T) {
 	})
 }
 
+func TestOperation_UpdateDID(t *testing.T) {
+	didDoc, err := (&mockvdri.MockVDRIRegistry{}).Create("sidetree")
+	require.NoError(t, err)
+
+	docBytes, err := didDoc.JSONBytes()
+	require.NoError(t, err)
+
+	req, err := json.Marshal(vdri.UpdateDIDArgs{DIDDocument: docBytes})
+	require.NoError(t, err)
+
+	t.Run("Successful update DID", func(t *testing.T) {
+		svc := New(&protocol.MockProvider{CustomVDRI: &mockvdri.MockVDRIRegistry{}})
+		require.NotNil(t, svc)
+
+		handler := lookupHandler(t, svc, updateDIDPath)
+		_, err := getSuccessResponseFromHandler(handler, bytes.NewBuffer(req), handler.Path())
+		require.NoError(t, err)
+	})
+
+	t.Run("Failed update DID", func(t *testing.T) {
+		svc := New(&protocol.MockProvider{})
+		require.NotNil(t, svc)
+
+		handler := lookupHandler(t, svc, updateDIDPath)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString("{}"), handler.Path())
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, vdri.InvalidRequestErrorCode, "DID document is mandatory", buf.Bytes())
+	})
+
+	t.Run("Failed update DID, VDRI error", func(t *testing.T) {
+		svc := New(&protocol.MockProvider{CustomVDRI: &mockvdri.MockVDRIRegistry{UpdateErr: fmt.Errorf("just-fail-it")}})
+		require.NotNil(t, svc)
+
+		handler := lookupHandler(t, svc, updateDIDPath)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBuffer(req), handler.Path())
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, vdri.UpdateDIDError, "just-fail-it", buf.Bytes())
+	})
+}
+
+func TestOperation_DeactivateDID(t *testing.T) {
+	const req = `{"did":"did:example:123"}`
+
+	t.Run("Successful deactivate DID", func(t *testing.T) {
+		svc := New(&protocol.MockProvider{CustomVDRI: &mockvdri.MockVDRIRegistry{}})
+		require.NotNil(t, svc)
+
+		handler := lookupHandler(t, svc, deactivateDIDPath)
+		_, err := getSuccessResponseFromHandler(handler, bytes.NewBufferString(req), handler.Path())
+		require.NoError(t, err)
+	})
+
+	t.Run("Failed deactivate DID", func(t *testing.T) {
+		svc := New(&protocol.MockProvider{})
+		require.NotNil(t, svc)
+
+		handler := lookupHandler(t, svc, deactivateDIDPath)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString("{}"), handler.Path())
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, vdri.InvalidRequestErrorCode, "DID is mandatory", buf.Bytes())
+	})
+
+	t.Run("Failed deactivate DID, VDRI error", func(t *testing.T) {
+		svc := New(&protocol.MockProvider{CustomVDRI: &mockvdri.MockVDRIRegistry{UpdateErr: fmt.Errorf("just-fail-it")}})
+		require.NotNil(t, svc)
+
+		handler := lookupHandler(t, svc, deactivateDIDPath)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(req), handler.Path())
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, vdri.DeactivateDIDError, "just-fail-it", buf.Bytes())
+	})
+}
+
 func lookupCreatePublicDIDHandler(t *testing.T, op *Operation) rest.Handler {
+	return lookupHandler(t, op, createPublicDIDPath)
+}
+
+func lookupHandler(t *testing.T, op *Operation, path string) rest.Handler {
 	handlers := op.GetRESTHandlers()
 	require.NotEmpty(t, handlers)
 
 	for _, h := range handlers {
-		if h.Path() == createPublicDIDPath {
+		if h.Path() == path {
 			return h
 		}
 	}
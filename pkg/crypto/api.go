@@ -32,4 +32,77 @@ type Crypto interface {
 	// returns:
 	// 		error in case of errors or nil if signature verification was successful
 	Verify(signature, msg []byte, kh interface{}) error
+	// SignMulti will create a BBS+ signature of messages using a matching BBS+ signing primitive in kh key handle
+	// returns:
+	// 		signature in []byte
+	//		error in case of errors
+	SignMulti(messages [][]byte, kh interface{}) ([]byte, error)
+	// VerifyMulti will verify a BBS+ signature of messages using a matching BBS+ primitive in kh key handle
+	// returns:
+	// 		error in case of errors or nil if signature verification was successful
+	VerifyMulti(messages [][]byte, signature []byte, kh interface{}) error
+	// DeriveProof will create a BBS+ signature proof bound to nonce from the signature of messages, revealing only
+	// the messages at revealedIndexes, using a matching BBS+ primitive in kh key handle
+	// returns:
+	// 		signature proof in []byte
+	//		error in case of errors
+	DeriveProof(messages [][]byte, signature, nonce []byte, revealedIndexes []int, kh interface{}) ([]byte, error)
+	// VerifyProof will verify a BBS+ signature proof bound to nonce of the revealed messages using a matching
+	// BBS+ primitive in kh key handle
+	// returns:
+	// 		error in case of errors or nil if signature proof verification was successful
+	VerifyProof(revealedMessages [][]byte, proof, nonce []byte, kh interface{}) error
+	// WrapKey will execute key wrapping of cek using apu, apv and recipient public key 'recPubKey'.
+	// 'opts' allows setting the optional sender key handle using WithSender() option. It allows ECDH-1PU key
+	// wrapping (aka Authcrypt). The absence of this option uses ECDH-ES key wrapping (aka Anoncrypt).
+	// returns:
+	// 		RecipientWrappedKey containing the wrapped cek value
+	// 		error in case of errors
+	WrapKey(cek, apu, apv []byte, recPubKey *PublicKey, opts ...WrapKeyOpts) (*RecipientWrappedKey, error)
+	// UnwrapKey unwraps a key in recWK using recipient private key kh.
+	// 'opts' allows setting the optional sender public key using WithSender() option. It allows ECDH-1PU key
+	// unwrapping (aka Authcrypt). The absence of this option uses ECDH-ES key unwrapping (aka Anoncrypt).
+	// returns:
+	// 		unwrapped key in raw bytes
+	// 		error in case of errors
+	UnwrapKey(recWK *RecipientWrappedKey, kh interface{}, opts ...WrapKeyOpts) ([]byte, error)
+}
+
+// RecipientWrappedKey contains recipient key material required to unwrap CEK
+type RecipientWrappedKey struct {
+	KID          string    `json:"kid,omitempty"`
+	EncryptedCEK []byte    `json:"encryptedcek,omitempty"`
+	EPK          PublicKey `json:"epk,omitempty"`
+	Alg          string    `json:"alg,omitempty"`
+	APU          []byte    `json:"apu,omitempty"`
+	APV          []byte    `json:"apv,omitempty"`
+}
+
+// PublicKey mainly to exchange EPK in RecipientWrappedKey
+type PublicKey struct {
+	KID   string `json:"kid,omitempty"`
+	X     []byte `json:"x,omitempty"`
+	Y     []byte `json:"y,omitempty"`
+	Curve string `json:"curve,omitempty"`
+	Type  string `json:"type,omitempty"`
+}
+
+// WrapKeyOptions holds the options of the key wrapping. It's intended for the implementations of
+// the Crypto interface, use the WrapKeyOpts functions (eg: WithSender()) to set the options.
+type WrapKeyOptions struct {
+	// SenderKey is the key of the sender used with ECDH-1PU key wrapping (Authcrypt)
+	SenderKey interface{}
+}
+
+// WrapKeyOpts are the crypto.Wrap key options
+type WrapKeyOpts func(opts *WrapKeyOptions)
+
+// WithSender option is for setting a sender key with crypto wrapping (eg: AuthCrypt). For Anoncrypt,
+// this option must not be set.
+// Sender is the private key handle when wrapping the key and the public key (*PublicKey or
+// public key handle) when unwrapping it.
+func WithSender(senderKey interface{}) WrapKeyOpts {
+	return func(opts *WrapKeyOptions) {
+		opts.SenderKey = senderKey
+	}
 }
@@ -0,0 +1,216 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package bbs12381g2pub implements the BBS+ signature scheme (https://eprint.iacr.org/2016/663.pdf, section 4.3)
+// over the BLS12-381 curve with the public keys in G2, in pure Go.
+//
+// A BBS+ signature signs a list of messages at once. From a signature, the holder derives zero-knowledge
+// proofs of knowledge of the signature which reveal only some of the messages, allowing selective disclosure.
+//
+// The signatures and proofs are not interoperable with the other BbsBlsSignature2020 implementations (e.g. the
+// ones based on Hyperledger Ursa): the message generators of a public key are hashed to G1 from the compressed
+// public key, the message index and the messages count with the domain separation tag
+// "BBS_BLS12381G1_XMD:SHA-256_SSWU_RO_", and the messages are mapped to scalars with their BLAKE2b-512 digests,
+// so they are verified by this package only.
+package bbs12381g2pub
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	bls12381 "github.com/kilic/bls12-381"
+	"golang.org/x/crypto/blake2b"
+)
+
+// BBSG2Pub defines BBS+ signature scheme where public key is a point in the field of G2.
+type BBSG2Pub struct{}
+
+// New creates a new BBSG2Pub.
+func New() *BBSG2Pub {
+	return &BBSG2Pub{}
+}
+
+// Sign signs the messages with the private key, the signature is A || e || s.
+func (bbs *BBSG2Pub) Sign(messages [][]byte, privKeyBytes []byte) ([]byte, error) {
+	privKey, err := UnmarshalPrivateKey(privKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal private key: %w", err)
+	}
+
+	if len(messages) == 0 {
+		return nil, errors.New("messages are not defined")
+	}
+
+	pubKey, err := privKey.PublicKey().toPublicKeyWithGenerators(len(messages))
+	if err != nil {
+		return nil, fmt.Errorf("build generators from public key: %w", err)
+	}
+
+	e, err := randomFr()
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := randomFr()
+	if err != nil {
+		return nil, err
+	}
+
+	// A = B^(1 / (x + e))
+	exp := bls12381.NewFr()
+	exp.Add(privKey.FR, e)
+	exp.Inverse(exp)
+
+	g1 := bls12381.NewG1()
+	a := g1.MulScalar(g1.New(), computeB(s, messagesToFr(messages), pubKey), exp)
+
+	return (&signature{a: a, e: e, s: s}).toBytes(), nil
+}
+
+// Verify checks the signature of the messages with the public key.
+func (bbs *BBSG2Pub) Verify(messages [][]byte, sigBytes, pubKeyBytes []byte) error {
+	sig, err := parseSignature(sigBytes)
+	if err != nil {
+		return fmt.Errorf("parse signature: %w", err)
+	}
+
+	pubKey, err := UnmarshalPublicKey(pubKeyBytes)
+	if err != nil {
+		return fmt.Errorf("parse public key: %w", err)
+	}
+
+	if len(messages) == 0 {
+		return errors.New("messages are not defined")
+	}
+
+	pubKeyWithGenerators, err := pubKey.toPublicKeyWithGenerators(len(messages))
+	if err != nil {
+		return fmt.Errorf("build generators from public key: %w", err)
+	}
+
+	return sig.verify(messagesToFr(messages), pubKeyWithGenerators)
+}
+
+// DeriveProof derives from the signature of the messages a proof of knowledge of the signature
+// revealing only the messages at the given indexes, the proof is bound to the nonce.
+func (bbs *BBSG2Pub) DeriveProof(messages [][]byte, sigBytes, nonce, pubKeyBytes []byte,
+	revealedIndexes []int) ([]byte, error) {
+	sig, err := parseSignature(sigBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse signature: %w", err)
+	}
+
+	pubKey, err := UnmarshalPublicKey(pubKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse public key: %w", err)
+	}
+
+	if len(messages) == 0 {
+		return nil, errors.New("messages are not defined")
+	}
+
+	revealed, err := revealedBitvector(revealedIndexes, len(messages))
+	if err != nil {
+		return nil, err
+	}
+
+	pubKeyWithGenerators, err := pubKey.toPublicKeyWithGenerators(len(messages))
+	if err != nil {
+		return nil, fmt.Errorf("build generators from public key: %w", err)
+	}
+
+	messagesFr := messagesToFr(messages)
+
+	if err = sig.verify(messagesFr, pubKeyWithGenerators); err != nil {
+		return nil, err
+	}
+
+	p, err := newProof(sig, messagesFr, revealed, pubKeyWithGenerators, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.toBytes(), nil
+}
+
+// VerifyProof checks the proof of knowledge of a signature of the public key revealing the messages,
+// in the order of their indexes in the signed messages. The proof must be bound to the nonce.
+func (bbs *BBSG2Pub) VerifyProof(messages [][]byte, proofBytes, nonce, pubKeyBytes []byte) error {
+	p, err := parseProof(proofBytes)
+	if err != nil {
+		return fmt.Errorf("parse signature proof: %w", err)
+	}
+
+	pubKey, err := UnmarshalPublicKey(pubKeyBytes)
+	if err != nil {
+		return fmt.Errorf("parse public key: %w", err)
+	}
+
+	if len(messages) != p.revealedCount() {
+		return fmt.Errorf("invalid size: %d revealed messages, %d expected", len(messages), p.revealedCount())
+	}
+
+	pubKeyWithGenerators, err := pubKey.toPublicKeyWithGenerators(p.messagesCount)
+	if err != nil {
+		return fmt.Errorf("build generators from public key: %w", err)
+	}
+
+	return p.verify(messagesToFr(messages), pubKeyWithGenerators, nonce)
+}
+
+// computeB computes B = g1 * h0^s * h1^m1 * ... * hL^mL.
+func computeB(s *bls12381.Fr, messages []*bls12381.Fr, pubKey *publicKeyWithGenerators) *bls12381.PointG1 {
+	g1 := bls12381.NewG1()
+
+	b := g1.One()
+	g1.Add(b, b, g1.MulScalar(g1.New(), pubKey.h0, s))
+
+	for i, m := range messages {
+		g1.Add(b, b, g1.MulScalar(g1.New(), pubKey.h[i], m))
+	}
+
+	return b
+}
+
+// messagesToFr maps the messages to Fr elements with the BLAKE2b-512 digests of the messages.
+func messagesToFr(messages [][]byte) []*bls12381.Fr {
+	messagesFr := make([]*bls12381.Fr, len(messages))
+
+	for i, m := range messages {
+		messagesFr[i] = hashToFr(m)
+	}
+
+	return messagesFr
+}
+
+// hashToFr maps the data to a Fr element with the BLAKE2b-512 digest of the data.
+func hashToFr(data []byte) *bls12381.Fr {
+	digest := blake2b.Sum512(data)
+
+	return bls12381.NewFr().FromBytes(digest[:])
+}
+
+func randomFr() (*bls12381.Fr, error) {
+	fr, err := bls12381.NewFr().Rand(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate random Fr: %w", err)
+	}
+
+	return fr, nil
+}
+
+func frFromBytes(b []byte) (*bls12381.Fr, error) {
+	fr := bls12381.NewFr().FromBytes(b)
+
+	// Fr elements are encoded in their canonical form
+	if fr.ToBig().Cmp(bls12381.NewG1().Q()) >= 0 || !bytes.Equal(fr.ToBytes(), b) {
+		return nil, errors.New("invalid Fr element")
+	}
+
+	return fr, nil
+}
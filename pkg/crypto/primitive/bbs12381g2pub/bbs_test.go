@@ -0,0 +1,227 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs12381g2pub
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func generateKeyPairBytes(t *testing.T) ([]byte, []byte) {
+	pubKey, privKey, err := GenerateKeyPair(sha256.New, nil)
+	require.NoError(t, err)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(t, err)
+
+	privKeyBytes, err := privKey.Marshal()
+	require.NoError(t, err)
+
+	return pubKeyBytes, privKeyBytes
+}
+
+func TestGenerateKeyPair(t *testing.T) {
+	seed := make([]byte, 32)
+
+	pubKey, privKey, err := GenerateKeyPair(sha256.New, seed)
+	require.NoError(t, err)
+
+	// the keys are derived from the seed
+	pubKey2, privKey2, err := GenerateKeyPair(sha256.New, seed)
+	require.NoError(t, err)
+	require.Equal(t, privKey, privKey2)
+	require.Equal(t, pubKey, pubKey2)
+
+	privKeyBytes, err := privKey.Marshal()
+	require.NoError(t, err)
+	require.Len(t, privKeyBytes, PrivateKeySize)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(t, err)
+	require.Len(t, pubKeyBytes, PublicKeySize)
+
+	privKey2, err = UnmarshalPrivateKey(privKeyBytes)
+	require.NoError(t, err)
+	require.Equal(t, privKey, privKey2)
+
+	pubKey2, err = UnmarshalPublicKey(pubKeyBytes)
+	require.NoError(t, err)
+
+	pubKeyBytes2, err := pubKey2.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, pubKeyBytes, pubKeyBytes2)
+
+	_, _, err = GenerateKeyPair(sha256.New, []byte("short seed"))
+	require.EqualError(t, err, "seed must be at least 32 bytes")
+
+	_, err = UnmarshalPrivateKey([]byte("invalid size"))
+	require.EqualError(t, err, "invalid size of private key")
+
+	_, err = UnmarshalPrivateKey(make([]byte, PrivateKeySize))
+	require.EqualError(t, err, "invalid private key")
+
+	_, err = UnmarshalPublicKey([]byte("invalid size"))
+	require.EqualError(t, err, "invalid size of public key")
+
+	_, err = UnmarshalPublicKey(make([]byte, PublicKeySize))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "deserialize public key")
+}
+
+func TestBBSG2Pub_SignVerify(t *testing.T) {
+	pubKey, privKey := generateKeyPairBytes(t)
+	messages := [][]byte{[]byte("message 1"), []byte("message 2"), []byte("message 3")}
+
+	bbs := New()
+
+	sig, err := bbs.Sign(messages, privKey)
+	require.NoError(t, err)
+	require.Len(t, sig, SignatureSize)
+
+	require.NoError(t, bbs.Verify(messages, sig, pubKey))
+
+	t.Run("invalid signature", func(t *testing.T) {
+		err = bbs.Verify([][]byte{[]byte("message 1"), []byte("other"), []byte("message 3")}, sig, pubKey)
+		require.EqualError(t, err, "invalid BBS+ signature")
+
+		err = bbs.Verify(messages[:2], sig, pubKey)
+		require.EqualError(t, err, "invalid BBS+ signature")
+
+		otherPubKey, _ := generateKeyPairBytes(t)
+		err = bbs.Verify(messages, sig, otherPubKey)
+		require.EqualError(t, err, "invalid BBS+ signature")
+
+		err = bbs.Verify(messages, sig[1:], pubKey)
+		require.EqualError(t, err, "parse signature: invalid size of signature")
+
+		invalidE := append([]byte{}, sig...)
+		copy(invalidE[g1CompressedSize:], []byte{0xff, 0xff})
+		err = bbs.Verify(messages, invalidE, pubKey)
+		require.EqualError(t, err, "parse signature: deserialize signature e: invalid Fr element")
+
+		err = bbs.Verify(messages, sig, pubKey[1:])
+		require.EqualError(t, err, "parse public key: invalid size of public key")
+
+		err = bbs.Verify(nil, sig, pubKey)
+		require.EqualError(t, err, "messages are not defined")
+	})
+
+	t.Run("invalid sign parameters", func(t *testing.T) {
+		_, err = bbs.Sign(messages, privKey[1:])
+		require.EqualError(t, err, "unmarshal private key: invalid size of private key")
+
+		_, err = bbs.Sign(nil, privKey)
+		require.EqualError(t, err, "messages are not defined")
+	})
+}
+
+func TestBBSG2Pub_DeriveProof(t *testing.T) {
+	pubKey, privKey := generateKeyPairBytes(t)
+	messages := [][]byte{
+		[]byte("message 1"), []byte("message 2"), []byte("message 3"),
+		[]byte("message 4"), []byte("message 5"), []byte("message 6"),
+		[]byte("message 7"), []byte("message 8"), []byte("message 9"),
+	}
+
+	bbs := New()
+
+	sig, err := bbs.Sign(messages, privKey)
+	require.NoError(t, err)
+
+	nonce := []byte("nonce")
+
+	proof, err := bbs.DeriveProof(messages, sig, nonce, pubKey, []int{8, 0, 2})
+	require.NoError(t, err)
+
+	revealedMessages := [][]byte{messages[0], messages[2], messages[8]}
+	require.NoError(t, bbs.VerifyProof(revealedMessages, proof, nonce, pubKey))
+
+	t.Run("proofs are unlinkable", func(t *testing.T) {
+		proof2, err := bbs.DeriveProof(messages, sig, nonce, pubKey, []int{0, 2, 8})
+		require.NoError(t, err)
+		require.NotEqual(t, proof, proof2)
+		require.NoError(t, bbs.VerifyProof(revealedMessages, proof2, nonce, pubKey))
+	})
+
+	t.Run("reveal all or no messages", func(t *testing.T) {
+		proof, err := bbs.DeriveProof(messages, sig, nonce, pubKey, []int{0, 1, 2, 3, 4, 5, 6, 7, 8})
+		require.NoError(t, err)
+		require.NoError(t, bbs.VerifyProof(messages, proof, nonce, pubKey))
+
+		proof, err = bbs.DeriveProof(messages, sig, nonce, pubKey, nil)
+		require.NoError(t, err)
+		require.NoError(t, bbs.VerifyProof(nil, proof, nonce, pubKey))
+	})
+
+	t.Run("invalid proof", func(t *testing.T) {
+		err = bbs.VerifyProof(revealedMessages, proof, []byte("other nonce"), pubKey)
+		require.EqualError(t, err, "invalid BBS+ signature proof")
+
+		err = bbs.VerifyProof([][]byte{messages[0], messages[1], messages[8]}, proof, nonce, pubKey)
+		require.EqualError(t, err, "invalid BBS+ signature proof")
+
+		otherPubKey, _ := generateKeyPairBytes(t)
+		err = bbs.VerifyProof(revealedMessages, proof, nonce, otherPubKey)
+		require.EqualError(t, err, "invalid BBS+ signature proof")
+
+		err = bbs.VerifyProof(revealedMessages[:2], proof, nonce, pubKey)
+		require.EqualError(t, err, "invalid size: 2 revealed messages, 3 expected")
+
+		// reveal another message
+		invalidRevealed := append([]byte{}, proof...)
+		invalidRevealed[messagesCountSize] ^= 2
+		err = bbs.VerifyProof(revealedMessages, invalidRevealed, nonce, pubKey)
+		require.EqualError(t, err, "parse signature proof: invalid size of signature proof")
+
+		invalidResponse := append([]byte{}, proof...)
+		invalidResponse[len(invalidResponse)-1]++
+		err = bbs.VerifyProof(revealedMessages, invalidResponse, nonce, pubKey)
+		require.EqualError(t, err, "invalid BBS+ signature proof")
+
+		err = bbs.VerifyProof(revealedMessages, proof[:1], nonce, pubKey)
+		require.EqualError(t, err, "parse signature proof: invalid size of signature proof")
+
+		err = bbs.VerifyProof(revealedMessages, []byte{0, 0}, nonce, pubKey)
+		require.EqualError(t, err, "parse signature proof: invalid size of signature proof")
+
+		invalidPoint := append([]byte{}, proof...)
+		invalidPoint[messagesCountSize+2] = 0
+		err = bbs.VerifyProof(revealedMessages, invalidPoint, nonce, pubKey)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse signature proof: deserialize G1 compressed point")
+
+		invalidFr := append([]byte{}, proof...)
+		copy(invalidFr[len(invalidFr)-frSize:], []byte{0xff, 0xff})
+		err = bbs.VerifyProof(revealedMessages, invalidFr, nonce, pubKey)
+		require.EqualError(t, err, "parse signature proof: invalid Fr element")
+
+		err = bbs.VerifyProof(revealedMessages, proof, nonce, pubKey[1:])
+		require.EqualError(t, err, "parse public key: invalid size of public key")
+	})
+
+	t.Run("invalid derive parameters", func(t *testing.T) {
+		_, err = bbs.DeriveProof(messages, sig, nonce, pubKey, []int{9})
+		require.EqualError(t, err, "invalid revealed index: 9")
+
+		_, err = bbs.DeriveProof(messages, sig, nonce, pubKey, []int{1, 1})
+		require.EqualError(t, err, "duplicated revealed index: 1")
+
+		_, err = bbs.DeriveProof(messages[1:], sig, nonce, pubKey, []int{1})
+		require.EqualError(t, err, "invalid BBS+ signature")
+
+		_, err = bbs.DeriveProof(nil, sig, nonce, pubKey, nil)
+		require.EqualError(t, err, "messages are not defined")
+
+		_, err = bbs.DeriveProof(messages, sig[1:], nonce, pubKey, nil)
+		require.EqualError(t, err, "parse signature: invalid size of signature")
+
+		_, err = bbs.DeriveProof(messages, sig, nonce, pubKey[1:], nil)
+		require.EqualError(t, err, "parse public key: invalid size of public key")
+	})
+}
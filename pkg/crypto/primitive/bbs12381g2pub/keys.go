@@ -0,0 +1,162 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs12381g2pub
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	bls12381 "github.com/kilic/bls12-381"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// PublicKeySize is the size in bytes of the compressed G2 point of the public keys
+	PublicKeySize = 96
+	// PrivateKeySize is the size in bytes of the private keys
+	PrivateKeySize = 32
+
+	seedSize   = 32
+	okmSize    = 48
+	keyGenSalt = "BBS-SIG-KEYGEN-SALT-"
+
+	// generatorsDST is the domain separation tag of the message generators hashed to G1
+	generatorsDST = "BBS_BLS12381G1_XMD:SHA-256_SSWU_RO_"
+)
+
+// PublicKey is the public key w = g2^x of the private key x.
+type PublicKey struct {
+	PointG2 *bls12381.PointG2
+}
+
+// PrivateKey is the private key x.
+type PrivateKey struct {
+	FR *bls12381.Fr
+}
+
+// publicKeyWithGenerators is the public key with the generators h0, h1, ..., hL of L messages.
+type publicKeyWithGenerators struct {
+	w  *bls12381.PointG2
+	h0 *bls12381.PointG1
+	h  []*bls12381.PointG1
+}
+
+// GenerateKeyPair generates a key pair deriving the private key from the seed with HKDF and the h hash function,
+// the seed is random if nil.
+func GenerateKeyPair(h func() hash.Hash, seed []byte) (*PublicKey, *PrivateKey, error) {
+	if seed == nil {
+		seed = make([]byte, seedSize)
+
+		if _, err := rand.Read(seed); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(seed) < seedSize {
+		return nil, nil, fmt.Errorf("seed must be at least %d bytes", seedSize)
+	}
+
+	okm := make([]byte, okmSize)
+
+	if _, err := io.ReadFull(hkdf.New(h, seed, []byte(keyGenSalt), nil), okm); err != nil {
+		return nil, nil, err
+	}
+
+	privKey := &PrivateKey{FR: bls12381.NewFr().FromBytes(okm)}
+	if privKey.FR.IsZero() {
+		return nil, nil, errors.New("invalid private key")
+	}
+
+	return privKey.PublicKey(), privKey, nil
+}
+
+// PublicKey returns the public key of the private key.
+func (k *PrivateKey) PublicKey() *PublicKey {
+	g2 := bls12381.NewG2()
+
+	return &PublicKey{PointG2: g2.MulScalar(g2.New(), g2.One(), k.FR)}
+}
+
+// Marshal returns the big-endian bytes of the private key.
+func (k *PrivateKey) Marshal() ([]byte, error) {
+	return k.FR.ToBytes(), nil
+}
+
+// UnmarshalPrivateKey parses the big-endian bytes of a private key.
+func UnmarshalPrivateKey(privKeyBytes []byte) (*PrivateKey, error) {
+	if len(privKeyBytes) != PrivateKeySize {
+		return nil, errors.New("invalid size of private key")
+	}
+
+	fr := bls12381.NewFr().FromBytes(privKeyBytes)
+	if fr.IsZero() {
+		return nil, errors.New("invalid private key")
+	}
+
+	return &PrivateKey{FR: fr}, nil
+}
+
+// Marshal returns the compressed G2 point of the public key.
+func (pk *PublicKey) Marshal() ([]byte, error) {
+	return bls12381.NewG2().ToCompressed(pk.PointG2), nil
+}
+
+// UnmarshalPublicKey parses the compressed G2 point of a public key.
+func UnmarshalPublicKey(pubKeyBytes []byte) (*PublicKey, error) {
+	if len(pubKeyBytes) != PublicKeySize {
+		return nil, errors.New("invalid size of public key")
+	}
+
+	g2 := bls12381.NewG2()
+
+	point, err := g2.FromCompressed(pubKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("deserialize public key: %w", err)
+	}
+
+	if g2.IsZero(point) {
+		return nil, errors.New("invalid public key")
+	}
+
+	return &PublicKey{PointG2: point}, nil
+}
+
+// toPublicKeyWithGenerators hashes to G1 the generators of messagesCount messages,
+// hi = hash_to_curve(w || i || messagesCount).
+func (pk *PublicKey) toPublicKeyWithGenerators(messagesCount int) (*publicKeyWithGenerators, error) {
+	g1 := bls12381.NewG1()
+	w := bls12381.NewG2().ToCompressed(pk.PointG2)
+
+	generator := func(i int) (*bls12381.PointG1, error) {
+		data := make([]byte, len(w)+8) // nolint:gomnd
+		copy(data, w)
+		binary.BigEndian.PutUint32(data[len(w):], uint32(i))
+		binary.BigEndian.PutUint32(data[len(w)+4:], uint32(messagesCount))
+
+		return g1.HashToCurve(data, []byte(generatorsDST))
+	}
+
+	h0, err := generator(0)
+	if err != nil {
+		return nil, err
+	}
+
+	h := make([]*bls12381.PointG1, messagesCount)
+
+	for i := range h {
+		h[i], err = generator(i + 1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &publicKeyWithGenerators{w: pk.PointG2, h0: h0, h: h}, nil
+}
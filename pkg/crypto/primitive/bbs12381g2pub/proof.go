@@ -0,0 +1,329 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs12381g2pub
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	bls12381 "github.com/kilic/bls12-381"
+)
+
+const (
+	messagesCountSize = 2
+	bitsPerByte       = 8
+
+	// proofFrCount is the number of Fr elements c, z_e, z_r2, z_r3 and z_s of the proof
+	proofFrCount = 5
+)
+
+// proof is the zero-knowledge proof of knowledge of a signature (A, e, s) revealing some of the messages.
+//
+// The signature is randomized by r1 and r2 as A' = A^r1, Abar = A'^(-e) * B^r1 and d = B^r1 * h0^(-r2)
+// with r3 = 1 / r1 and s' = s - r2 * r3, so that e(A', w) = e(Abar, g2). The Schnorr proofs for
+// Abar / d = A'^(-e) * h0^r2 and g1 * prod(revealed hi^mi) = d^r3 * h0^(-s') * prod(hidden hj^(-mj))
+// prove the knowledge of the signature.
+type proof struct {
+	messagesCount int
+	revealed      []bool
+
+	aPrime *bls12381.PointG1
+	aBar   *bls12381.PointG1
+	d      *bls12381.PointG1
+
+	c   *bls12381.Fr
+	zE  *bls12381.Fr
+	zR2 *bls12381.Fr
+	zR3 *bls12381.Fr
+	zS  *bls12381.Fr
+
+	// zM are the responses of the hidden messages, in the order of their indexes
+	zM []*bls12381.Fr
+}
+
+// nolint:funlen
+func newProof(sig *signature, messages []*bls12381.Fr, revealed []bool, pubKey *publicKeyWithGenerators,
+	nonce []byte) (*proof, error) {
+	random, err := randomFrs(2 + proofFrCount - 1 + len(messages)) // nolint:gomnd
+	if err != nil {
+		return nil, err
+	}
+
+	r1, r2 := random[0], random[1]
+	eBlinding, r2Blinding, r3Blinding, sBlinding := random[2], random[3], random[4], random[5]
+	mBlindings := random[6:]
+
+	g1 := bls12381.NewG1()
+	b := computeB(sig.s, messages, pubKey)
+
+	aPrime := g1.MulScalar(g1.New(), sig.a, r1)
+	aBar := g1.Add(g1.New(), g1.MulScalar(g1.New(), aPrime, frNeg(sig.e)), g1.MulScalar(g1.New(), b, r1))
+	d := g1.Add(g1.New(), g1.MulScalar(g1.New(), b, r1), g1.MulScalar(g1.New(), pubKey.h0, frNeg(r2)))
+
+	r3 := bls12381.NewFr()
+	r3.Inverse(r1)
+
+	sPrime := frSub(sig.s, frMul(r2, r3))
+
+	t1 := g1.Add(g1.New(), g1.MulScalar(g1.New(), aPrime, eBlinding), g1.MulScalar(g1.New(), pubKey.h0, r2Blinding))
+
+	t2 := g1.Add(g1.New(), g1.MulScalar(g1.New(), d, r3Blinding), g1.MulScalar(g1.New(), pubKey.h0, sBlinding))
+	c2 := g1.One()
+
+	for i, m := range messages {
+		if revealed[i] {
+			g1.Add(c2, c2, g1.MulScalar(g1.New(), pubKey.h[i], m))
+		} else {
+			g1.Add(t2, t2, g1.MulScalar(g1.New(), pubKey.h[i], mBlindings[i]))
+		}
+	}
+
+	p := &proof{
+		messagesCount: len(messages),
+		revealed:      revealed,
+		aPrime:        aPrime,
+		aBar:          aBar,
+		d:             d,
+	}
+
+	p.c = p.challenge(c2, t1, t2, nonce)
+
+	// z = blinding + c * witness
+	response := func(blinding, witness *bls12381.Fr) *bls12381.Fr {
+		return frAdd(blinding, frMul(p.c, witness))
+	}
+
+	p.zE = response(eBlinding, frNeg(sig.e))
+	p.zR2 = response(r2Blinding, r2)
+	p.zR3 = response(r3Blinding, r3)
+	p.zS = response(sBlinding, frNeg(sPrime))
+
+	for i, m := range messages {
+		if !revealed[i] {
+			p.zM = append(p.zM, response(mBlindings[i], frNeg(m)))
+		}
+	}
+
+	return p, nil
+}
+
+func (p *proof) verify(messages []*bls12381.Fr, pubKey *publicKeyWithGenerators, nonce []byte) error {
+	g1, g2 := bls12381.NewG1(), bls12381.NewG2()
+
+	if g1.IsZero(p.aPrime) {
+		return errors.New("invalid BBS+ signature proof")
+	}
+
+	engine := bls12381.NewEngine()
+	engine.AddPair(p.aPrime, pubKey.w)
+	engine.AddPairInv(p.aBar, g2.One())
+
+	if !engine.Check() {
+		return errors.New("invalid BBS+ signature proof")
+	}
+
+	// T1 = A'^z_e * h0^z_r2 * (Abar / d)^(-c)
+	c1 := g1.Sub(g1.New(), p.aBar, p.d)
+	t1 := g1.Add(g1.New(), g1.MulScalar(g1.New(), p.aPrime, p.zE), g1.MulScalar(g1.New(), pubKey.h0, p.zR2))
+	g1.Add(t1, t1, g1.MulScalar(g1.New(), c1, frNeg(p.c)))
+
+	// T2 = d^z_r3 * h0^z_s * prod(hidden hj^z_mj) * (g1 * prod(revealed hi^mi))^(-c)
+	t2 := g1.Add(g1.New(), g1.MulScalar(g1.New(), p.d, p.zR3), g1.MulScalar(g1.New(), pubKey.h0, p.zS))
+	c2 := g1.One()
+
+	revealedIndex, hiddenIndex := 0, 0
+
+	for i, r := range p.revealed {
+		if r {
+			g1.Add(c2, c2, g1.MulScalar(g1.New(), pubKey.h[i], messages[revealedIndex]))
+			revealedIndex++
+		} else {
+			g1.Add(t2, t2, g1.MulScalar(g1.New(), pubKey.h[i], p.zM[hiddenIndex]))
+			hiddenIndex++
+		}
+	}
+
+	g1.Add(t2, t2, g1.MulScalar(g1.New(), c2, frNeg(p.c)))
+
+	if !p.challenge(c2, t1, t2, nonce).Equal(p.c) {
+		return errors.New("invalid BBS+ signature proof")
+	}
+
+	return nil
+}
+
+// challenge computes the Fiat-Shamir challenge c = H(A', Abar, d, g1 * prod(revealed hi^mi), T1, T2, nonce).
+func (p *proof) challenge(c2, t1, t2 *bls12381.PointG1, nonce []byte) *bls12381.Fr {
+	g1 := bls12381.NewG1()
+
+	var data []byte
+	for _, point := range []*bls12381.PointG1{p.aPrime, p.aBar, p.d, c2, t1, t2} {
+		data = append(data, g1.ToCompressed(point)...)
+	}
+
+	return hashToFr(append(data, nonce...))
+}
+
+func (p *proof) revealedCount() int {
+	count := 0
+
+	for _, r := range p.revealed {
+		if r {
+			count++
+		}
+	}
+
+	return count
+}
+
+// toBytes serializes the proof as messages count (2 bytes) || revealed bitvector || A' || Abar || d ||
+// c || z_e || z_r2 || z_r3 || z_s || z_m of the hidden messages.
+func (p *proof) toBytes() []byte {
+	b := make([]byte, messagesCountSize+bitvectorSize(p.messagesCount))
+	binary.BigEndian.PutUint16(b, uint16(p.messagesCount))
+
+	for i, r := range p.revealed {
+		if r {
+			b[messagesCountSize+i/bitsPerByte] |= 1 << (i % bitsPerByte)
+		}
+	}
+
+	g1 := bls12381.NewG1()
+	for _, point := range []*bls12381.PointG1{p.aPrime, p.aBar, p.d} {
+		b = append(b, g1.ToCompressed(point)...)
+	}
+
+	for _, fr := range append([]*bls12381.Fr{p.c, p.zE, p.zR2, p.zR3, p.zS}, p.zM...) {
+		b = append(b, fr.ToBytes()...)
+	}
+
+	return b
+}
+
+func parseProof(b []byte) (*proof, error) {
+	if len(b) < messagesCountSize {
+		return nil, errors.New("invalid size of signature proof")
+	}
+
+	p := &proof{messagesCount: int(binary.BigEndian.Uint16(b))}
+	offset := messagesCountSize + bitvectorSize(p.messagesCount)
+
+	if p.messagesCount == 0 || len(b) < offset {
+		return nil, errors.New("invalid size of signature proof")
+	}
+
+	p.revealed = make([]bool, p.messagesCount)
+	for i := range p.revealed {
+		p.revealed[i] = b[messagesCountSize+i/bitsPerByte]&(1<<(i%bitsPerByte)) != 0
+	}
+
+	hiddenCount := p.messagesCount - p.revealedCount()
+
+	if len(b) != offset+3*g1CompressedSize+(proofFrCount+hiddenCount)*frSize {
+		return nil, errors.New("invalid size of signature proof")
+	}
+
+	points := make([]*bls12381.PointG1, 3) // nolint:gomnd
+	for i := range points {
+		point, err := bls12381.NewG1().FromCompressed(b[offset : offset+g1CompressedSize])
+		if err != nil {
+			return nil, fmt.Errorf("deserialize G1 compressed point: %w", err)
+		}
+
+		points[i] = point
+		offset += g1CompressedSize
+	}
+
+	frs := make([]*bls12381.Fr, proofFrCount+hiddenCount)
+	for i := range frs {
+		fr, err := frFromBytes(b[offset : offset+frSize])
+		if err != nil {
+			return nil, err
+		}
+
+		frs[i] = fr
+		offset += frSize
+	}
+
+	p.aPrime, p.aBar, p.d = points[0], points[1], points[2]
+	p.c, p.zE, p.zR2, p.zR3, p.zS = frs[0], frs[1], frs[2], frs[3], frs[4]
+	p.zM = frs[proofFrCount:]
+
+	return p, nil
+}
+
+// revealedBitvector returns the revealed flags of the messages from the indexes of the revealed messages.
+func revealedBitvector(revealedIndexes []int, messagesCount int) ([]bool, error) {
+	if messagesCount > math.MaxUint16 {
+		return nil, fmt.Errorf("too many messages: %d", messagesCount)
+	}
+
+	revealed := make([]bool, messagesCount)
+
+	for _, i := range revealedIndexes {
+		if i < 0 || i >= messagesCount {
+			return nil, fmt.Errorf("invalid revealed index: %d", i)
+		}
+
+		if revealed[i] {
+			return nil, fmt.Errorf("duplicated revealed index: %d", i)
+		}
+
+		revealed[i] = true
+	}
+
+	return revealed, nil
+}
+
+func bitvectorSize(bits int) int {
+	return (bits + bitsPerByte - 1) / bitsPerByte
+}
+
+func randomFrs(count int) ([]*bls12381.Fr, error) {
+	frs := make([]*bls12381.Fr, count)
+
+	for i := range frs {
+		fr, err := randomFr()
+		if err != nil {
+			return nil, err
+		}
+
+		frs[i] = fr
+	}
+
+	return frs, nil
+}
+
+func frAdd(a, b *bls12381.Fr) *bls12381.Fr {
+	r := bls12381.NewFr()
+	r.Add(a, b)
+
+	return r
+}
+
+func frSub(a, b *bls12381.Fr) *bls12381.Fr {
+	r := bls12381.NewFr()
+	r.Sub(a, b)
+
+	return r
+}
+
+func frMul(a, b *bls12381.Fr) *bls12381.Fr {
+	r := bls12381.NewFr()
+	r.Mul(a, b)
+
+	return r
+}
+
+func frNeg(a *bls12381.Fr) *bls12381.Fr {
+	r := bls12381.NewFr()
+	r.Neg(a)
+
+	return r
+}
@@ -0,0 +1,81 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs12381g2pub
+
+import (
+	"errors"
+	"fmt"
+
+	bls12381 "github.com/kilic/bls12-381"
+)
+
+const (
+	g1CompressedSize = 48
+	frSize           = 32
+
+	// SignatureSize is the size in bytes of the signatures A || e || s
+	SignatureSize = g1CompressedSize + 2*frSize
+)
+
+// signature is the BBS+ signature (A, e, s).
+type signature struct {
+	a *bls12381.PointG1
+	e *bls12381.Fr
+	s *bls12381.Fr
+}
+
+func parseSignature(sigBytes []byte) (*signature, error) {
+	if len(sigBytes) != SignatureSize {
+		return nil, errors.New("invalid size of signature")
+	}
+
+	a, err := bls12381.NewG1().FromCompressed(sigBytes[:g1CompressedSize])
+	if err != nil {
+		return nil, fmt.Errorf("deserialize G1 compressed signature: %w", err)
+	}
+
+	e, err := frFromBytes(sigBytes[g1CompressedSize : g1CompressedSize+frSize])
+	if err != nil {
+		return nil, fmt.Errorf("deserialize signature e: %w", err)
+	}
+
+	s, err := frFromBytes(sigBytes[g1CompressedSize+frSize:])
+	if err != nil {
+		return nil, fmt.Errorf("deserialize signature s: %w", err)
+	}
+
+	return &signature{a: a, e: e, s: s}, nil
+}
+
+func (sig *signature) toBytes() []byte {
+	b := bls12381.NewG1().ToCompressed(sig.a)
+	b = append(b, sig.e.ToBytes()...)
+
+	return append(b, sig.s.ToBytes()...)
+}
+
+// verify checks e(A, w * g2^e) = e(B, g2).
+func (sig *signature) verify(messages []*bls12381.Fr, pubKey *publicKeyWithGenerators) error {
+	g1, g2 := bls12381.NewG1(), bls12381.NewG2()
+
+	if g1.IsZero(sig.a) {
+		return errors.New("invalid BBS+ signature")
+	}
+
+	p2 := g2.MulScalar(g2.New(), g2.One(), sig.e)
+	g2.Add(p2, p2, pubKey.w)
+
+	engine := bls12381.NewEngine()
+	engine.AddPair(sig.a, p2)
+	engine.AddPairInv(computeB(sig.s, messages, pubKey), g2.One())
+
+	if !engine.Check() {
+		return errors.New("invalid BBS+ signature")
+	}
+
+	return nil
+}
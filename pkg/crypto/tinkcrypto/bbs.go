@@ -0,0 +1,100 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tinkcrypto
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/tink/go/keyset"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs"
+)
+
+// SignMulti will create a BBS+ signature of messages using the implementation's corresponding BBS+ signing key
+// referenced by kh
+func (t *Crypto) SignMulti(messages [][]byte, kh interface{}) ([]byte, error) {
+	keyHandle, ok := kh.(*keyset.Handle)
+	if !ok {
+		return nil, errors.New("bad key handle format")
+	}
+
+	signer, err := bbs.NewSigner(keyHandle)
+	if err != nil {
+		return nil, fmt.Errorf("create new BBS+ signer: %w", err)
+	}
+
+	s, err := signer.Sign(messages)
+	if err != nil {
+		return nil, fmt.Errorf("BBS+ sign messages: %w", err)
+	}
+
+	return s, nil
+}
+
+// VerifyMulti will verify the BBS+ signature of messages using the implementation's corresponding BBS+ key
+// referenced by kh
+func (t *Crypto) VerifyMulti(messages [][]byte, signature []byte, kh interface{}) error {
+	verifier, err := newBBSVerifier(kh)
+	if err != nil {
+		return err
+	}
+
+	err = verifier.Verify(messages, signature)
+	if err != nil {
+		err = fmt.Errorf("BBS+ verify messages: %w", err)
+	}
+
+	return err
+}
+
+// DeriveProof will create a BBS+ signature proof bound to nonce from the signature of messages, revealing only
+// the messages at revealedIndexes, using the implementation's corresponding BBS+ key referenced by kh
+func (t *Crypto) DeriveProof(messages [][]byte, signature, nonce []byte, revealedIndexes []int,
+	kh interface{}) ([]byte, error) {
+	verifier, err := newBBSVerifier(kh)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := verifier.DeriveProof(messages, signature, nonce, revealedIndexes)
+	if err != nil {
+		return nil, fmt.Errorf("BBS+ derive proof: %w", err)
+	}
+
+	return proof, nil
+}
+
+// VerifyProof will verify the BBS+ signature proof bound to nonce of the revealed messages using the
+// implementation's corresponding BBS+ key referenced by kh
+func (t *Crypto) VerifyProof(revealedMessages [][]byte, proof, nonce []byte, kh interface{}) error {
+	verifier, err := newBBSVerifier(kh)
+	if err != nil {
+		return err
+	}
+
+	err = verifier.VerifyProof(revealedMessages, proof, nonce)
+	if err != nil {
+		err = fmt.Errorf("BBS+ verify proof: %w", err)
+	}
+
+	return err
+}
+
+func newBBSVerifier(kh interface{}) (bbs.Verifier, error) {
+	keyHandle, ok := kh.(*keyset.Handle)
+	if !ok {
+		return nil, errors.New("bad key handle format")
+	}
+
+	verifier, err := bbs.NewVerifier(keyHandle)
+	if err != nil {
+		return nil, fmt.Errorf("create new BBS+ verifier: %w", err)
+	}
+
+	return verifier, nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tinkcrypto
+
+import (
+	"testing"
+
+	"github.com/google/tink/go/keyset"
+	"github.com/google/tink/go/signature"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs"
+)
+
+func TestBBS(t *testing.T) {
+	kh, err := keyset.NewHandle(bbs.BLS12381G2KeyTemplate())
+	require.NoError(t, err)
+
+	pubKH, err := kh.Public()
+	require.NoError(t, err)
+
+	c := Crypto{}
+	messages := [][]byte{[]byte("message 1"), []byte("message 2"), []byte("message 3")}
+	nonce := []byte("nonce")
+
+	t.Run("test sign, verify, derive and verify proof", func(t *testing.T) {
+		sig, err := c.SignMulti(messages, kh)
+		require.NoError(t, err)
+
+		require.NoError(t, c.VerifyMulti(messages, sig, pubKH))
+
+		proof, err := c.DeriveProof(messages, sig, nonce, []int{0, 2}, pubKH)
+		require.NoError(t, err)
+
+		require.NoError(t, c.VerifyProof([][]byte{messages[0], messages[2]}, proof, nonce, pubKH))
+	})
+
+	t.Run("test failures", func(t *testing.T) {
+		sig, err := c.SignMulti(messages, kh)
+		require.NoError(t, err)
+
+		err = c.VerifyMulti(messages[1:], sig, pubKH)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "BBS+ verify messages")
+
+		_, err = c.DeriveProof(messages[1:], sig, nonce, nil, pubKH)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "BBS+ derive proof")
+
+		proof, err := c.DeriveProof(messages, sig, nonce, []int{1}, pubKH)
+		require.NoError(t, err)
+
+		err = c.VerifyProof(messages[:1], proof, nonce, pubKH)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "BBS+ verify proof")
+
+		_, err = c.SignMulti(messages, nil)
+		require.EqualError(t, err, "bad key handle format")
+
+		_, err = c.SignMulti(messages, pubKH)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create new BBS+ signer")
+
+		err = c.VerifyMulti(messages, sig, nil)
+		require.EqualError(t, err, "bad key handle format")
+
+		ed25519KH, err := keyset.NewHandle(signature.ED25519KeyTemplate())
+		require.NoError(t, err)
+
+		err = c.VerifyMulti(messages, sig, ed25519KH)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create new BBS+ verifier")
+
+		_, err = c.DeriveProof(messages, sig, nonce, nil, nil)
+		require.EqualError(t, err, "bad key handle format")
+
+		err = c.VerifyProof(messages, proof, nonce, nil)
+		require.EqualError(t, err, "bad key handle format")
+	})
+}
@@ -20,6 +20,9 @@ import (
 	"github.com/google/tink/go/signature"
 	aeadsubtle "github.com/google/tink/go/subtle/aead"
 	"golang.org/x/crypto/chacha20poly1305"
+
+	// register the key managers of the secp256k1 signing keys, not supported by Tink
+	_ "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1"
 )
 
 // Package provider/tinkcrypto includes implementation of spi/crypto. SPI implementation will be built
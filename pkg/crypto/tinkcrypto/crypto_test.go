@@ -17,6 +17,7 @@ import (
 	chacha "golang.org/x/crypto/chacha20poly1305"
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1"
 )
 
 // Assert that Crypto implements the Crypto interface.
@@ -162,4 +163,24 @@ func TestCrypto_SignVerify(t *testing.T) {
 		err = c.Verify(s, msg, badKH)
 		require.Error(t, err)
 	})
+
+	t.Run("test with secp256k1 signature", func(t *testing.T) {
+		kh, err := keyset.NewHandle(secp256k1.ECDSASecp256k1KeyTemplate())
+		require.NoError(t, err)
+
+		c := Crypto{}
+		msg := []byte("test message")
+		s, err := c.Sign(msg, kh)
+		require.NoError(t, err)
+
+		// get corresponding public key handle to verify
+		pubKH, err := kh.Public()
+		require.NoError(t, err)
+
+		err = c.Verify(s, msg, pubKH)
+		require.NoError(t, err)
+
+		err = c.Verify(s, []byte("other message"), pubKH)
+		require.Error(t, err)
+	})
 }
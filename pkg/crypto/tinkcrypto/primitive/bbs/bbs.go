@@ -0,0 +1,96 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package bbs provides the Tink key managers of the BBS+ BLS12-381 G2 signing keys, which are not supported
+// by Tink. The private key primitive is a Signer signing a list of messages at once, the public key primitive
+// a Verifier which also derives and verifies the selective disclosure proofs of the signatures.
+//
+// The key managers are registered in the Tink registry when this package is initialized, the keys are
+// created with the key template of this package:
+//
+//	kh, err := keyset.NewHandle(bbs.BLS12381G2KeyTemplate())
+package bbs
+
+import (
+	"fmt"
+
+	"github.com/google/tink/go/core/registry"
+	"github.com/google/tink/go/keyset"
+)
+
+// Signer signs a list of messages with a BBS+ private key.
+type Signer interface {
+	// Sign signs the messages, the signature is the BBS+ signature of all the messages
+	Sign(messages [][]byte) ([]byte, error)
+}
+
+// Verifier verifies the BBS+ signatures and their proofs with a BBS+ public key.
+type Verifier interface {
+	// Verify verifies the BBS+ signature of the messages
+	Verify(messages [][]byte, signature []byte) error
+
+	// DeriveProof derives from the signature of the messages a proof bound to the nonce
+	// revealing only the messages at the given indexes
+	DeriveProof(messages [][]byte, signature, nonce []byte, revealedIndexes []int) ([]byte, error)
+
+	// VerifyProof verifies the proof bound to the nonce revealing the messages
+	VerifyProof(revealedMessages [][]byte, proof, nonce []byte) error
+}
+
+// nolint:gochecknoinits
+func init() {
+	if err := registry.RegisterKeyManager(newPrivateKeyManager()); err != nil {
+		panic(fmt.Sprintf("bbs.init() failed: %v", err))
+	}
+
+	if err := registry.RegisterKeyManager(newPublicKeyManager()); err != nil {
+		panic(fmt.Sprintf("bbs.init() failed: %v", err))
+	}
+}
+
+// NewSigner returns the Signer of the primary key of the private key handle kh.
+func NewSigner(kh *keyset.Handle) (Signer, error) {
+	ps, err := kh.Primitives()
+	if err != nil {
+		return nil, fmt.Errorf("bbs: get primitives: %w", err)
+	}
+
+	s, ok := ps.Primary.Primitive.(*signer)
+	if !ok {
+		return nil, fmt.Errorf("bbs: not a BBS+ private key primitive %T", ps.Primary.Primitive)
+	}
+
+	return s, nil
+}
+
+// NewVerifier returns the Verifier of the primary key of kh. The key handle is either the private
+// key handle or the public key handle of the BBS+ key.
+func NewVerifier(kh *keyset.Handle) (Verifier, error) {
+	ps, err := kh.Primitives()
+	if err != nil {
+		return nil, fmt.Errorf("bbs: get primitives: %w", err)
+	}
+
+	switch p := ps.Primary.Primitive.(type) {
+	case *signer:
+		return &verifier{pubKey: p.pubKey}, nil
+	case *verifier:
+		return p, nil
+	default:
+		return nil, fmt.Errorf("bbs: unsupported primitive %T", p)
+	}
+}
+
+// PublicKey returns the compressed G2 point of the public key of the primary key in kh. The key handle
+// is either the private key handle or the public key handle of the BBS+ key.
+func PublicKey(kh *keyset.Handle) ([]byte, error) {
+	v, err := NewVerifier(kh)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*verifier).pubKey, nil
+}
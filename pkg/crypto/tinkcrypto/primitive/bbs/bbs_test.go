@@ -0,0 +1,168 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/aead"
+	"github.com/google/tink/go/keyset"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	bbspb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/bbspb"
+)
+
+func TestKeyTemplate(t *testing.T) {
+	kh, err := keyset.NewHandle(BLS12381G2KeyTemplate())
+	require.NoError(t, err)
+
+	pub, err := PublicKey(kh)
+	require.NoError(t, err)
+	require.Len(t, pub, bbs12381g2pub.PublicKeySize)
+
+	pubKH, err := kh.Public()
+	require.NoError(t, err)
+
+	// the public key is the same from both private and public key handles
+	pub2, err := PublicKey(pubKH)
+	require.NoError(t, err)
+	require.Equal(t, pub, pub2)
+
+	s, err := NewSigner(kh)
+	require.NoError(t, err)
+
+	messages := [][]byte{[]byte("message 1"), []byte("message 2")}
+
+	sig, err := s.Sign(messages)
+	require.NoError(t, err)
+
+	// the signature is the BBS+ signature of the messages
+	require.NoError(t, bbs12381g2pub.New().Verify(messages, sig, pub))
+
+	for _, h := range []*keyset.Handle{kh, pubKH} {
+		v, err := NewVerifier(h)
+		require.NoError(t, err)
+		require.NoError(t, v.Verify(messages, sig))
+
+		err = v.Verify([][]byte{[]byte("other message"), messages[1]}, sig)
+		require.EqualError(t, err, "bbs_verifier: invalid BBS+ signature")
+
+		nonce := []byte("nonce")
+
+		proof, err := v.DeriveProof(messages, sig, nonce, []int{1})
+		require.NoError(t, err)
+		require.NoError(t, v.VerifyProof(messages[1:], proof, nonce))
+
+		err = v.VerifyProof(messages[:1], proof, nonce)
+		require.EqualError(t, err, "bbs_verifier: invalid BBS+ signature proof")
+
+		_, err = v.DeriveProof(messages, sig, nonce, []int{2})
+		require.EqualError(t, err, "bbs_verifier: invalid revealed index: 2")
+	}
+
+	_, err = NewSigner(pubKH)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "bbs: not a BBS+ private key primitive")
+
+	_, err = (&signer{privKey: []byte("bad key")}).Sign(messages)
+	require.EqualError(t, err, "bbs_signer: unmarshal private key: invalid size of private key")
+}
+
+func TestPrimitives_Failure(t *testing.T) {
+	kh, err := keyset.NewHandle(aead.AES256GCMKeyTemplate())
+	require.NoError(t, err)
+
+	_, err = PublicKey(kh)
+	require.Contains(t, err.Error(), "bbs: unsupported primitive")
+
+	_, err = NewSigner(kh)
+	require.Contains(t, err.Error(), "bbs: not a BBS+ private key primitive")
+}
+
+func TestPrivateKeyManager(t *testing.T) {
+	km := newPrivateKeyManager()
+	require.True(t, km.DoesSupport(privateKeyTypeURL))
+	require.False(t, km.DoesSupport(publicKeyTypeURL))
+	require.Equal(t, privateKeyTypeURL, km.TypeURL())
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := km.Primitive(nil)
+		require.Equal(t, errInvalidPrivateKey, err)
+
+		_, err = km.Primitive([]byte("bad key"))
+		require.Equal(t, errInvalidPrivateKey, err)
+
+		serializedKey, err := proto.Marshal(&bbspb.BBSPrivateKey{Version: 1})
+		require.NoError(t, err)
+
+		_, err = km.Primitive(serializedKey)
+		require.Equal(t, errInvalidPrivateKey, err)
+
+		serializedKey, err = proto.Marshal(&bbspb.BBSPrivateKey{})
+		require.NoError(t, err)
+
+		_, err = km.Primitive(serializedKey)
+		require.Equal(t, errInvalidPrivateKey, err)
+
+		serializedKey, err = proto.Marshal(&bbspb.BBSPrivateKey{
+			PublicKey: &bbspb.BBSPublicKey{KeyValue: []byte{1}},
+		})
+		require.NoError(t, err)
+
+		_, err = km.Primitive(serializedKey)
+		require.Equal(t, errInvalidPrivateKey, err)
+
+		key, err := km.NewKey(nil)
+		require.NoError(t, err)
+
+		key.(*bbspb.BBSPrivateKey).KeyValue = []byte{1}
+
+		serializedKey, err = proto.Marshal(key)
+		require.NoError(t, err)
+
+		_, err = km.Primitive(serializedKey)
+		require.Equal(t, errInvalidPrivateKey, err)
+
+		_, err = km.PublicKeyData([]byte("bad key"))
+		require.Equal(t, errInvalidPrivateKey, err)
+	})
+
+	t.Run("invalid key format", func(t *testing.T) {
+		_, err := km.NewKey([]byte("bad format"))
+		require.Equal(t, errInvalidPrivateKeyFormat, err)
+
+		_, err = km.NewKeyData([]byte("bad format"))
+		require.Equal(t, errInvalidPrivateKeyFormat, err)
+	})
+}
+
+func TestPublicKeyManager(t *testing.T) {
+	km := newPublicKeyManager()
+	require.True(t, km.DoesSupport(publicKeyTypeURL))
+	require.False(t, km.DoesSupport(privateKeyTypeURL))
+	require.Equal(t, publicKeyTypeURL, km.TypeURL())
+
+	_, err := km.Primitive(nil)
+	require.Equal(t, errInvalidPublicKey, err)
+
+	_, err = km.Primitive([]byte("bad key"))
+	require.Equal(t, errInvalidPublicKey, err)
+
+	serializedKey, err := proto.Marshal(&bbspb.BBSPublicKey{Version: 1})
+	require.NoError(t, err)
+
+	_, err = km.Primitive(serializedKey)
+	require.Equal(t, errInvalidPublicKey, err)
+
+	_, err = km.NewKey(nil)
+	require.Equal(t, errNotSupported, err)
+
+	_, err = km.NewKeyData(nil)
+	require.Equal(t, errNotSupported, err)
+}
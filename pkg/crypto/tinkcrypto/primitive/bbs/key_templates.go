@@ -0,0 +1,22 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs
+
+import (
+	tinkpb "github.com/google/tink/proto/tink_go_proto"
+)
+
+// BLS12381G2KeyTemplate is a KeyTemplate that generates the BBS+ BLS12-381 G2 signing key.
+func BLS12381G2KeyTemplate() *tinkpb.KeyTemplate {
+	return &tinkpb.KeyTemplate{
+		TypeUrl: privateKeyTypeURL,
+		// the key format has no parameters, its serialization is empty
+		Value: nil,
+		// the signatures are used as is in Linked Data proofs
+		OutputPrefixType: tinkpb.OutputPrefixType_RAW,
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/core/registry"
+	"github.com/google/tink/go/keyset"
+	tinkpb "github.com/google/tink/proto/tink_go_proto"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	bbspb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/bbspb"
+)
+
+const (
+	privateKeyVersion = 0
+	privateKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.BBSPrivateKey"
+)
+
+// common errors
+var (
+	errInvalidPrivateKey       = errors.New("bbs_private_key_manager: invalid key")
+	errInvalidPrivateKeyFormat = errors.New("bbs_private_key_manager: invalid key format")
+)
+
+// privateKeyManager is an implementation of PrivateKeyManager interface.
+// It generates new BBSPrivateKeys and produces new instances of Signer primitive.
+type privateKeyManager struct{}
+
+// Assert that privateKeyManager implements the PrivateKeyManager interface.
+var _ registry.PrivateKeyManager = (*privateKeyManager)(nil)
+
+// newPrivateKeyManager creates a new privateKeyManager.
+func newPrivateKeyManager() *privateKeyManager {
+	return new(privateKeyManager)
+}
+
+// Primitive creates the Signer for the given serialized BBSPrivateKey proto.
+func (km *privateKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidPrivateKey
+	}
+
+	key := new(bbspb.BBSPrivateKey)
+
+	if err := proto.Unmarshal(serializedKey, key); err != nil {
+		return nil, errInvalidPrivateKey
+	}
+
+	if err := keyset.ValidateKeyVersion(key.Version, privateKeyVersion); err != nil {
+		return nil, errInvalidPrivateKey
+	}
+
+	if err := validatePublicKey(key.GetPublicKey(), privateKeyVersion); err != nil {
+		return nil, errInvalidPrivateKey
+	}
+
+	if _, err := bbs12381g2pub.UnmarshalPrivateKey(key.KeyValue); err != nil {
+		return nil, errInvalidPrivateKey
+	}
+
+	return &signer{privKey: key.KeyValue, pubKey: key.PublicKey.KeyValue}, nil
+}
+
+// NewKey creates a new BBSPrivateKey, the serialized BBSKeyFormat has no parameters.
+func (km *privateKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	if err := proto.Unmarshal(serializedKeyFormat, new(bbspb.BBSKeyFormat)); err != nil {
+		return nil, errInvalidPrivateKeyFormat
+	}
+
+	pubKey, privKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+	if err != nil {
+		return nil, fmt.Errorf("bbs_private_key_manager: %w", err)
+	}
+
+	pubKeyBytes, err := pubKey.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("bbs_private_key_manager: %w", err)
+	}
+
+	privKeyBytes, err := privKey.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("bbs_private_key_manager: %w", err)
+	}
+
+	return &bbspb.BBSPrivateKey{
+		Version: privateKeyVersion,
+		PublicKey: &bbspb.BBSPublicKey{
+			Version:  privateKeyVersion,
+			KeyValue: pubKeyBytes,
+		},
+		KeyValue: privKeyBytes,
+	}, nil
+}
+
+// NewKeyData creates a new KeyData according to specification in the given serialized BBSKeyFormat.
+// It should be used solely by the key management API.
+func (km *privateKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	key, err := km.NewKey(serializedKeyFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	serializedKey, err := proto.Marshal(key)
+	if err != nil {
+		return nil, errInvalidPrivateKeyFormat
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         privateKeyTypeURL,
+		Value:           serializedKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PRIVATE,
+	}, nil
+}
+
+// PublicKeyData returns the enclosed public key data of serializedPrivKey.
+func (km *privateKeyManager) PublicKeyData(serializedPrivKey []byte) (*tinkpb.KeyData, error) {
+	privKey := new(bbspb.BBSPrivateKey)
+
+	if err := proto.Unmarshal(serializedPrivKey, privKey); err != nil {
+		return nil, errInvalidPrivateKey
+	}
+
+	serializedPubKey, err := proto.Marshal(privKey.PublicKey)
+	if err != nil {
+		return nil, errInvalidPrivateKey
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         publicKeyTypeURL,
+		Value:           serializedPubKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PUBLIC,
+	}, nil
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *privateKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == privateKeyTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *privateKeyManager) TypeURL() string {
+	return privateKeyTypeURL
+}
+
+// validatePublicKey validates the version and the G2 point of the public key.
+func validatePublicKey(key *bbspb.BBSPublicKey, version uint32) error {
+	if key == nil {
+		return errors.New("public key is missing")
+	}
+
+	if err := keyset.ValidateKeyVersion(key.Version, version); err != nil {
+		return err
+	}
+
+	_, err := bbs12381g2pub.UnmarshalPublicKey(key.KeyValue)
+
+	return err
+}
@@ -0,0 +1,79 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs
+
+import (
+	"errors"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/core/registry"
+	tinkpb "github.com/google/tink/proto/tink_go_proto"
+
+	bbspb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/bbspb"
+)
+
+const (
+	publicKeyVersion = 0
+	publicKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.BBSPublicKey"
+)
+
+// common errors
+var (
+	errInvalidPublicKey = errors.New("bbs_public_key_manager: invalid key")
+	errNotSupported     = errors.New("bbs_public_key_manager: not supported")
+)
+
+// publicKeyManager is an implementation of KeyManager interface.
+// It produces new instances of Verifier primitive.
+type publicKeyManager struct{}
+
+// Assert that publicKeyManager implements the KeyManager interface.
+var _ registry.KeyManager = (*publicKeyManager)(nil)
+
+// newPublicKeyManager creates a new publicKeyManager.
+func newPublicKeyManager() *publicKeyManager {
+	return new(publicKeyManager)
+}
+
+// Primitive creates the Verifier for the given serialized BBSPublicKey proto.
+func (km *publicKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidPublicKey
+	}
+
+	key := new(bbspb.BBSPublicKey)
+
+	if err := proto.Unmarshal(serializedKey, key); err != nil {
+		return nil, errInvalidPublicKey
+	}
+
+	if err := validatePublicKey(key, publicKeyVersion); err != nil {
+		return nil, errInvalidPublicKey
+	}
+
+	return &verifier{pubKey: key.KeyValue}, nil
+}
+
+// NewKey is not implemented for public keys.
+func (km *publicKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	return nil, errNotSupported
+}
+
+// NewKeyData is not implemented for public keys.
+func (km *publicKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	return nil, errNotSupported
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *publicKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == publicKeyTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *publicKeyManager) TypeURL() string {
+	return publicKeyTypeURL
+}
@@ -0,0 +1,68 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+)
+
+// signer is the Signer of the BBS+ private keys.
+type signer struct {
+	privKey []byte
+	pubKey  []byte
+}
+
+// Assert that signer implements the Signer interface.
+var _ Signer = (*signer)(nil)
+
+// Sign signs the messages.
+func (s *signer) Sign(messages [][]byte) ([]byte, error) {
+	signature, err := bbs12381g2pub.New().Sign(messages, s.privKey)
+	if err != nil {
+		return nil, fmt.Errorf("bbs_signer: %w", err)
+	}
+
+	return signature, nil
+}
+
+// verifier is the Verifier of the BBS+ public keys.
+type verifier struct {
+	pubKey []byte
+}
+
+// Assert that verifier implements the Verifier interface.
+var _ Verifier = (*verifier)(nil)
+
+// Verify verifies the signature of the messages.
+func (v *verifier) Verify(messages [][]byte, signature []byte) error {
+	if err := bbs12381g2pub.New().Verify(messages, signature, v.pubKey); err != nil {
+		return fmt.Errorf("bbs_verifier: %w", err)
+	}
+
+	return nil
+}
+
+// DeriveProof derives the proof of the signature revealing the messages at the given indexes.
+func (v *verifier) DeriveProof(messages [][]byte, signature, nonce []byte, revealedIndexes []int) ([]byte, error) {
+	proof, err := bbs12381g2pub.New().DeriveProof(messages, signature, nonce, v.pubKey, revealedIndexes)
+	if err != nil {
+		return nil, fmt.Errorf("bbs_verifier: %w", err)
+	}
+
+	return proof, nil
+}
+
+// VerifyProof verifies the proof revealing the messages.
+func (v *verifier) VerifyProof(revealedMessages [][]byte, proof, nonce []byte) error {
+	if err := bbs12381g2pub.New().VerifyProof(revealedMessages, proof, nonce, v.pubKey); err != nil {
+		return fmt.Errorf("bbs_verifier: %w", err)
+	}
+
+	return nil
+}
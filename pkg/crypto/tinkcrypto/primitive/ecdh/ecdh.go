@@ -0,0 +1,56 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ecdh provides the Tink key managers of the ECDH key wrapping keys (X25519 and NIST P-256, P-384
+// and P-521 curves). The private key primitive computes the shared secret with the public key of the other
+// party (KeyAgreement) so that the private key never leaves the key handle. The public key primitive is
+// the *crypto.PublicKey.
+//
+// The key managers are registered in the Tink registry when this package is initialized, the keys are
+// created with the key templates of this package:
+//
+//	kh, err := keyset.NewHandle(ecdh.X25519KWKeyTemplate())
+package ecdh
+
+import (
+	"fmt"
+
+	"github.com/google/tink/go/core/registry"
+	"github.com/google/tink/go/keyset"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+)
+
+// nolint:gochecknoinits
+func init() {
+	if err := registry.RegisterKeyManager(newPrivateKeyManager()); err != nil {
+		panic(fmt.Sprintf("ecdh.init() failed: %v", err))
+	}
+
+	if err := registry.RegisterKeyManager(newPublicKeyManager()); err != nil {
+		panic(fmt.Sprintf("ecdh.init() failed: %v", err))
+	}
+}
+
+// PublicKey returns the public key of the primary key in kh. The key handle is either the private
+// key handle or the public key handle of the ECDH key.
+func PublicKey(kh *keyset.Handle) (*crypto.PublicKey, error) {
+	ps, err := kh.Primitives()
+	if err != nil {
+		return nil, fmt.Errorf("ecdh: get primitives: %w", err)
+	}
+
+	switch p := ps.Primary.Primitive.(type) {
+	case KeyAgreement:
+		return p.PublicKey(), nil
+	case *crypto.PublicKey:
+		pub := *p
+
+		return &pub, nil
+	default:
+		return nil, fmt.Errorf("ecdh: unsupported primitive %T", p)
+	}
+}
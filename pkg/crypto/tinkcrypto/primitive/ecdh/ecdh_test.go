@@ -0,0 +1,223 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdh
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/aead"
+	"github.com/google/tink/go/keyset"
+	tinkpb "github.com/google/tink/proto/tink_go_proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	ecdhkwpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdhkwpb"
+)
+
+func TestKeyTemplates(t *testing.T) {
+	tests := []struct {
+		name     string
+		template *tinkpb.KeyTemplate
+		curve    string
+		keyType  string
+		keySize  int
+	}{
+		{"X25519", X25519KWKeyTemplate(), X25519, OKPType, 32},
+		{"NIST P-256", NISTP256KWKeyTemplate(), P256, ECType, 32},
+		{"NIST P-384", NISTP384KWKeyTemplate(), P384, ECType, 48},
+		{"NIST P-521", NISTP521KWKeyTemplate(), P521, ECType, 66},
+	}
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			kh, err := keyset.NewHandle(tc.template)
+			require.NoError(t, err)
+
+			pub, err := PublicKey(kh)
+			require.NoError(t, err)
+			require.Equal(t, tc.curve, pub.Curve)
+			require.Equal(t, tc.keyType, pub.Type)
+			require.NotEmpty(t, pub.X)
+
+			pubKH, err := kh.Public()
+			require.NoError(t, err)
+
+			// the public key is the same from both private and public key handles
+			pub2, err := PublicKey(pubKH)
+			require.NoError(t, err)
+			require.Equal(t, pub, pub2)
+
+			ps, err := kh.Primitives()
+			require.NoError(t, err)
+
+			ka, ok := ps.Primary.Primitive.(KeyAgreement)
+			require.True(t, ok)
+
+			// both parties compute the same shared secret
+			other, err := GenerateKey(tc.curve)
+			require.NoError(t, err)
+
+			z1, err := ka.ComputeSharedSecret(other.PublicKey())
+			require.NoError(t, err)
+
+			z2, err := other.ComputeSharedSecret(pub)
+			require.NoError(t, err)
+			require.Equal(t, z1, z2)
+			require.Len(t, z1, tc.keySize)
+		})
+	}
+}
+
+func TestComputeSharedSecret_Failure(t *testing.T) {
+	x25519, err := GenerateKey(X25519)
+	require.NoError(t, err)
+
+	p256, err := GenerateKey(P256)
+	require.NoError(t, err)
+
+	_, err = x25519.ComputeSharedSecret(p256.PublicKey())
+	require.EqualError(t, err, "ecdh: curve mismatch: P-256")
+
+	_, err = p256.ComputeSharedSecret(x25519.PublicKey())
+	require.EqualError(t, err, "ecdh: curve mismatch: X25519")
+
+	_, err = p256.ComputeSharedSecret(&crypto.PublicKey{Curve: P256, X: []byte{1}, Y: []byte{2}})
+	require.EqualError(t, err, "ecdh: compute shared secret: invalid public key")
+
+	_, err = x25519.ComputeSharedSecret(&crypto.PublicKey{Curve: X25519, X: make([]byte, 32)})
+	require.Error(t, err)
+
+	_, err = GenerateKey("unknown")
+	require.Equal(t, errUnsupportedCurve, err)
+}
+
+func TestPublicKey_Failure(t *testing.T) {
+	kh, err := keyset.NewHandle(aead.AES256GCMKeyTemplate())
+	require.NoError(t, err)
+
+	_, err = PublicKey(kh)
+	require.Contains(t, err.Error(), "ecdh: unsupported primitive")
+}
+
+func TestPrivateKeyManager(t *testing.T) {
+	km := newPrivateKeyManager()
+	require.True(t, km.DoesSupport(privateKeyTypeURL))
+	require.False(t, km.DoesSupport(publicKeyTypeURL))
+	require.Equal(t, privateKeyTypeURL, km.TypeURL())
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := km.Primitive(nil)
+		require.Equal(t, errInvalidPrivateKey, err)
+
+		_, err = km.Primitive([]byte("bad key"))
+		require.Equal(t, errInvalidPrivateKey, err)
+
+		serializedKey, err := proto.Marshal(&ecdhkwpb.EcdhKwPrivateKey{})
+		require.NoError(t, err)
+
+		_, err = km.Primitive(serializedKey)
+		require.Equal(t, errInvalidPrivateKey, err)
+
+		_, err = km.PublicKeyData([]byte("bad key"))
+		require.Equal(t, errInvalidPrivateKey, err)
+	})
+
+	t.Run("invalid key format", func(t *testing.T) {
+		_, err := km.NewKey(nil)
+		require.Equal(t, errInvalidPrivateKeyFormat, err)
+
+		_, err = km.NewKey([]byte("bad format"))
+		require.Equal(t, errInvalidPrivateKeyFormat, err)
+
+		serializedFormat, err := proto.Marshal(&ecdhkwpb.EcdhKwKeyFormat{
+			Params: &ecdhkwpb.EcdhKwParams{Curve: ecdhkwpb.CurveType_UNKNOWN_CURVE},
+		})
+		require.NoError(t, err)
+
+		_, err = km.NewKeyData(serializedFormat)
+		require.Equal(t, errInvalidPrivateKeyFormat, err)
+	})
+}
+
+func TestPublicKeyManager(t *testing.T) {
+	km := newPublicKeyManager()
+	require.True(t, km.DoesSupport(publicKeyTypeURL))
+	require.False(t, km.DoesSupport(privateKeyTypeURL))
+	require.Equal(t, publicKeyTypeURL, km.TypeURL())
+
+	_, err := km.Primitive(nil)
+	require.Equal(t, errInvalidPublicKey, err)
+
+	_, err = km.Primitive([]byte("bad key"))
+	require.Equal(t, errInvalidPublicKey, err)
+
+	serializedKey, err := proto.Marshal(&ecdhkwpb.EcdhKwPublicKey{Version: 1})
+	require.NoError(t, err)
+
+	_, err = km.Primitive(serializedKey)
+	require.Equal(t, errInvalidPublicKey, err)
+
+	_, err = km.NewKey(nil)
+	require.Equal(t, errNotSupported, err)
+
+	_, err = km.NewKeyData(nil)
+	require.Equal(t, errNotSupported, err)
+}
+
+func TestNewKeysetHandle(t *testing.T) {
+	for _, curve := range []string{X25519, P256, P384, P521} {
+		c := curve
+		t.Run(c, func(t *testing.T) {
+			other, err := GenerateKey(c)
+			require.NoError(t, err)
+
+			var privKey []byte
+
+			switch k := other.(type) {
+			case *x25519KeyAgreement:
+				privKey = k.priv
+			case *nistKeyAgreement:
+				privKey = k.priv.D.Bytes()
+			}
+
+			kh, err := NewKeysetHandle(c, privKey)
+			require.NoError(t, err)
+
+			pub, err := PublicKey(kh)
+			require.NoError(t, err)
+			require.Equal(t, other.PublicKey(), pub)
+
+			ps, err := kh.Primitives()
+			require.NoError(t, err)
+
+			ka, ok := ps.Primary.Primitive.(KeyAgreement)
+			require.True(t, ok)
+
+			peer, err := GenerateKey(c)
+			require.NoError(t, err)
+
+			z1, err := ka.ComputeSharedSecret(peer.PublicKey())
+			require.NoError(t, err)
+
+			z2, err := other.ComputeSharedSecret(peer.PublicKey())
+			require.NoError(t, err)
+			require.Equal(t, z1, z2)
+		})
+	}
+
+	t.Run("unsupported curve", func(t *testing.T) {
+		_, err := NewKeysetHandle("unknown", []byte("key"))
+		require.Equal(t, errUnsupportedCurve, err)
+	})
+
+	t.Run("invalid X25519 key", func(t *testing.T) {
+		_, err := NewKeysetHandle(X25519, []byte("key"))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,144 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdh
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+
+	hybrid "github.com/google/tink/go/subtle/hybrid"
+	"golang.org/x/crypto/curve25519"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+)
+
+// supported curves (JWK crv names) and key types (JWK kty names)
+const (
+	// X25519 curve
+	X25519 = "X25519"
+	// P256 is NIST P-256 curve
+	P256 = "P-256"
+	// P384 is NIST P-384 curve
+	P384 = "P-384"
+	// P521 is NIST P-521 curve
+	P521 = "P-521"
+
+	// OKPType is the key type of X25519 keys
+	OKPType = "OKP"
+	// ECType is the key type of NIST curve keys
+	ECType = "EC"
+)
+
+var errUnsupportedCurve = errors.New("unsupported curve")
+
+// KeyAgreement is the primitive of the ECDH private key.
+type KeyAgreement interface {
+	// PublicKey returns the public key of the key pair.
+	PublicKey() *crypto.PublicKey
+
+	// ComputeSharedSecret computes the shared secret (Z) with the public key of the other party.
+	ComputeSharedSecret(pub *crypto.PublicKey) ([]byte, error)
+}
+
+// GenerateKey creates a new (ephemeral) key pair on the curve.
+func GenerateKey(curve string) (KeyAgreement, error) {
+	if curve == X25519 {
+		priv := make([]byte, curve25519.ScalarSize)
+
+		if _, err := rand.Read(priv); err != nil {
+			return nil, fmt.Errorf("ecdh: generate key: %w", err)
+		}
+
+		return newX25519KeyAgreement(priv)
+	}
+
+	c, err := hybrid.GetCurve(curve)
+	if err != nil {
+		return nil, errUnsupportedCurve
+	}
+
+	priv, err := hybrid.GenerateECDHKeyPair(c)
+	if err != nil {
+		return nil, fmt.Errorf("ecdh: generate key: %w", err)
+	}
+
+	return &nistKeyAgreement{curve: curve, priv: priv}, nil
+}
+
+// x25519KeyAgreement is the key agreement over X25519 curve.
+type x25519KeyAgreement struct {
+	priv []byte
+	pub  []byte
+}
+
+func newX25519KeyAgreement(priv []byte) (*x25519KeyAgreement, error) {
+	pub, err := curve25519.X25519(priv, curve25519.Basepoint)
+	if err != nil {
+		return nil, fmt.Errorf("ecdh: compute public key: %w", err)
+	}
+
+	return &x25519KeyAgreement{priv: priv, pub: pub}, nil
+}
+
+func (k *x25519KeyAgreement) PublicKey() *crypto.PublicKey {
+	return &crypto.PublicKey{X: k.pub, Curve: X25519, Type: OKPType}
+}
+
+func (k *x25519KeyAgreement) ComputeSharedSecret(pub *crypto.PublicKey) ([]byte, error) {
+	if pub.Curve != X25519 {
+		return nil, fmt.Errorf("ecdh: curve mismatch: %s", pub.Curve)
+	}
+
+	z, err := curve25519.X25519(k.priv, pub.X)
+	if err != nil {
+		return nil, fmt.Errorf("ecdh: compute shared secret: %w", err)
+	}
+
+	return z, nil
+}
+
+// nistKeyAgreement is the key agreement over NIST P-256, P-384 and P-521 curves.
+type nistKeyAgreement struct {
+	curve string
+	priv  *hybrid.ECPrivateKey
+}
+
+func newNISTKeyAgreement(curve string, d []byte) (*nistKeyAgreement, error) {
+	c, err := hybrid.GetCurve(curve)
+	if err != nil {
+		return nil, errUnsupportedCurve
+	}
+
+	return &nistKeyAgreement{curve: curve, priv: hybrid.GetECPrivateKey(c, d)}, nil
+}
+
+func (k *nistKeyAgreement) PublicKey() *crypto.PublicKey {
+	return &crypto.PublicKey{
+		X:     k.priv.PublicKey.Point.X.Bytes(),
+		Y:     k.priv.PublicKey.Point.Y.Bytes(),
+		Curve: k.curve,
+		Type:  ECType,
+	}
+}
+
+func (k *nistKeyAgreement) ComputeSharedSecret(pub *crypto.PublicKey) ([]byte, error) {
+	if pub.Curve != k.curve {
+		return nil, fmt.Errorf("ecdh: curve mismatch: %s", pub.Curve)
+	}
+
+	z, err := hybrid.ComputeSharedSecret(&hybrid.ECPoint{
+		X: new(big.Int).SetBytes(pub.X),
+		Y: new(big.Int).SetBytes(pub.Y),
+	}, k.priv)
+	if err != nil {
+		return nil, fmt.Errorf("ecdh: compute shared secret: %w", err)
+	}
+
+	return z, nil
+}
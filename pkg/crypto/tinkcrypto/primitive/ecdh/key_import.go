@@ -0,0 +1,91 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdh
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/insecurecleartextkeyset"
+	"github.com/google/tink/go/keyset"
+	"github.com/google/tink/go/subtle/random"
+	tinkpb "github.com/google/tink/proto/tink_go_proto"
+
+	ecdhkwpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdhkwpb"
+)
+
+// NewKeysetHandle creates a key handle of the existing private key privKey on curve (eg. a key migrated from
+// another key store). The private key is the raw key material: the 32 bytes scalar for X25519 or the big-endian
+// D value for NIST curves. The key handle is the same as the ones created with the ecdh key templates.
+func NewKeysetHandle(curve string, privKey []byte) (*keyset.Handle, error) {
+	curveType, err := curveType(curve)
+	if err != nil {
+		return nil, err
+	}
+
+	var ka KeyAgreement
+
+	if curve == X25519 {
+		ka, err = newX25519KeyAgreement(privKey)
+	} else {
+		ka, err = newNISTKeyAgreement(curve, privKey)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	pub := ka.PublicKey()
+
+	serializedKey, err := proto.Marshal(&ecdhkwpb.EcdhKwPrivateKey{
+		Version: privateKeyVersion,
+		PublicKey: &ecdhkwpb.EcdhKwPublicKey{
+			Version: privateKeyVersion,
+			Params:  &ecdhkwpb.EcdhKwParams{Curve: curveType},
+			X:       pub.X,
+			Y:       pub.Y,
+		},
+		KeyValue: privKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ecdh: marshal private key: %w", err)
+	}
+
+	keyID := random.GetRandomUint32()
+
+	ks := &tinkpb.Keyset{
+		PrimaryKeyId: keyID,
+		Key: []*tinkpb.Keyset_Key{{
+			KeyData: &tinkpb.KeyData{
+				TypeUrl:         privateKeyTypeURL,
+				Value:           serializedKey,
+				KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PRIVATE,
+			},
+			Status:           tinkpb.KeyStatusType_ENABLED,
+			KeyId:            keyID,
+			OutputPrefixType: tinkpb.OutputPrefixType_RAW,
+		}},
+	}
+
+	return insecurecleartextkeyset.Read(&keyset.MemReaderWriter{Keyset: ks})
+}
+
+// curveType returns the curve type of the curve name (JWK crv).
+func curveType(curve string) (ecdhkwpb.CurveType, error) {
+	switch curve {
+	case X25519:
+		return ecdhkwpb.CurveType_X25519, nil
+	case P256:
+		return ecdhkwpb.CurveType_NIST_P256, nil
+	case P384:
+		return ecdhkwpb.CurveType_NIST_P384, nil
+	case P521:
+		return ecdhkwpb.CurveType_NIST_P521, nil
+	default:
+		return ecdhkwpb.CurveType_UNKNOWN_CURVE, errUnsupportedCurve
+	}
+}
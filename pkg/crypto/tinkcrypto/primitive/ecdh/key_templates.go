@@ -0,0 +1,50 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdh
+
+import (
+	"github.com/golang/protobuf/proto"
+	tinkpb "github.com/google/tink/proto/tink_go_proto"
+
+	ecdhkwpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdhkwpb"
+)
+
+// X25519KWKeyTemplate is a KeyTemplate that generates the X25519 key wrapping key.
+func X25519KWKeyTemplate() *tinkpb.KeyTemplate {
+	return createKeyTemplate(ecdhkwpb.CurveType_X25519)
+}
+
+// NISTP256KWKeyTemplate is a KeyTemplate that generates the NIST P-256 key wrapping key.
+func NISTP256KWKeyTemplate() *tinkpb.KeyTemplate {
+	return createKeyTemplate(ecdhkwpb.CurveType_NIST_P256)
+}
+
+// NISTP384KWKeyTemplate is a KeyTemplate that generates the NIST P-384 key wrapping key.
+func NISTP384KWKeyTemplate() *tinkpb.KeyTemplate {
+	return createKeyTemplate(ecdhkwpb.CurveType_NIST_P384)
+}
+
+// NISTP521KWKeyTemplate is a KeyTemplate that generates the NIST P-521 key wrapping key.
+func NISTP521KWKeyTemplate() *tinkpb.KeyTemplate {
+	return createKeyTemplate(ecdhkwpb.CurveType_NIST_P521)
+}
+
+func createKeyTemplate(c ecdhkwpb.CurveType) *tinkpb.KeyTemplate {
+	format := &ecdhkwpb.EcdhKwKeyFormat{
+		Params: &ecdhkwpb.EcdhKwParams{Curve: c},
+	}
+
+	// marshalling of the key format never fails
+	serializedFormat, _ := proto.Marshal(format) // nolint:errcheck
+
+	return &tinkpb.KeyTemplate{
+		TypeUrl: privateKeyTypeURL,
+		Value:   serializedFormat,
+		// the keys are used with the raw key material of the other party
+		OutputPrefixType: tinkpb.OutputPrefixType_RAW,
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdh
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/core/registry"
+	"github.com/google/tink/go/keyset"
+	tinkpb "github.com/google/tink/proto/tink_go_proto"
+
+	ecdhkwpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdhkwpb"
+)
+
+const (
+	privateKeyVersion = 0
+	privateKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.EcdhKwPrivateKey"
+)
+
+// common errors
+var (
+	errInvalidPrivateKey       = errors.New("ecdh_private_key_manager: invalid key")
+	errInvalidPrivateKeyFormat = errors.New("ecdh_private_key_manager: invalid key format")
+)
+
+// privateKeyManager is an implementation of PrivateKeyManager interface.
+// It generates new EcdhKwPrivateKeys and produces new instances of KeyAgreement primitive.
+type privateKeyManager struct{}
+
+// Assert that privateKeyManager implements the PrivateKeyManager interface.
+var _ registry.PrivateKeyManager = (*privateKeyManager)(nil)
+
+// newPrivateKeyManager creates a new privateKeyManager.
+func newPrivateKeyManager() *privateKeyManager {
+	return new(privateKeyManager)
+}
+
+// Primitive creates the KeyAgreement for the given serialized EcdhKwPrivateKey proto.
+func (km *privateKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidPrivateKey
+	}
+
+	key := new(ecdhkwpb.EcdhKwPrivateKey)
+
+	if err := proto.Unmarshal(serializedKey, key); err != nil {
+		return nil, errInvalidPrivateKey
+	}
+
+	curve, err := validatePublicKey(key.GetPublicKey(), privateKeyVersion)
+	if err != nil {
+		return nil, errInvalidPrivateKey
+	}
+
+	if curve == X25519 {
+		return newX25519KeyAgreement(key.KeyValue)
+	}
+
+	return newNISTKeyAgreement(curve, key.KeyValue)
+}
+
+// NewKey creates a new EcdhKwPrivateKey according to specification the given serialized EcdhKwKeyFormat.
+func (km *privateKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	if len(serializedKeyFormat) == 0 {
+		return nil, errInvalidPrivateKeyFormat
+	}
+
+	keyFormat := new(ecdhkwpb.EcdhKwKeyFormat)
+
+	if err := proto.Unmarshal(serializedKeyFormat, keyFormat); err != nil {
+		return nil, errInvalidPrivateKeyFormat
+	}
+
+	curve, err := curveName(keyFormat.GetParams().GetCurve())
+	if err != nil {
+		return nil, errInvalidPrivateKeyFormat
+	}
+
+	ka, err := GenerateKey(curve)
+	if err != nil {
+		return nil, fmt.Errorf("ecdh_private_key_manager: %w", err)
+	}
+
+	var keyValue []byte
+
+	switch k := ka.(type) {
+	case *x25519KeyAgreement:
+		keyValue = k.priv
+	case *nistKeyAgreement:
+		keyValue = k.priv.D.Bytes()
+	}
+
+	pub := ka.PublicKey()
+
+	return &ecdhkwpb.EcdhKwPrivateKey{
+		Version: privateKeyVersion,
+		PublicKey: &ecdhkwpb.EcdhKwPublicKey{
+			Version: privateKeyVersion,
+			Params:  keyFormat.Params,
+			X:       pub.X,
+			Y:       pub.Y,
+		},
+		KeyValue: keyValue,
+	}, nil
+}
+
+// NewKeyData creates a new KeyData according to specification in the given serialized EcdhKwKeyFormat.
+// It should be used solely by the key management API.
+func (km *privateKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	key, err := km.NewKey(serializedKeyFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	serializedKey, err := proto.Marshal(key)
+	if err != nil {
+		return nil, errInvalidPrivateKeyFormat
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         privateKeyTypeURL,
+		Value:           serializedKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PRIVATE,
+	}, nil
+}
+
+// PublicKeyData returns the enclosed public key data of serializedPrivKey.
+func (km *privateKeyManager) PublicKeyData(serializedPrivKey []byte) (*tinkpb.KeyData, error) {
+	privKey := new(ecdhkwpb.EcdhKwPrivateKey)
+
+	if err := proto.Unmarshal(serializedPrivKey, privKey); err != nil {
+		return nil, errInvalidPrivateKey
+	}
+
+	serializedPubKey, err := proto.Marshal(privKey.PublicKey)
+	if err != nil {
+		return nil, errInvalidPrivateKey
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         publicKeyTypeURL,
+		Value:           serializedPubKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PUBLIC,
+	}, nil
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *privateKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == privateKeyTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *privateKeyManager) TypeURL() string {
+	return privateKeyTypeURL
+}
+
+// validatePublicKey validates the public key and returns the name of its curve.
+func validatePublicKey(key *ecdhkwpb.EcdhKwPublicKey, version uint32) (string, error) {
+	if key == nil {
+		return "", errors.New("public key is missing")
+	}
+
+	if err := keyset.ValidateKeyVersion(key.Version, version); err != nil {
+		return "", err
+	}
+
+	return curveName(key.GetParams().GetCurve())
+}
+
+// curveName returns the name of the curve (JWK crv) of the curve type.
+func curveName(c ecdhkwpb.CurveType) (string, error) {
+	switch c {
+	case ecdhkwpb.CurveType_X25519:
+		return X25519, nil
+	case ecdhkwpb.CurveType_NIST_P256:
+		return P256, nil
+	case ecdhkwpb.CurveType_NIST_P384:
+		return P384, nil
+	case ecdhkwpb.CurveType_NIST_P521:
+		return P521, nil
+	default:
+		return "", errUnsupportedCurve
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdh
+
+import (
+	"errors"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/core/registry"
+	tinkpb "github.com/google/tink/proto/tink_go_proto"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	ecdhkwpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdhkwpb"
+)
+
+const (
+	publicKeyVersion = 0
+	publicKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.EcdhKwPublicKey"
+)
+
+// common errors
+var (
+	errInvalidPublicKey = errors.New("ecdh_public_key_manager: invalid key")
+	errNotSupported     = errors.New("ecdh_public_key_manager: not supported")
+)
+
+// publicKeyManager is an implementation of KeyManager interface.
+// It produces new instances of *crypto.PublicKey primitive.
+type publicKeyManager struct{}
+
+// Assert that publicKeyManager implements the KeyManager interface.
+var _ registry.KeyManager = (*publicKeyManager)(nil)
+
+// newPublicKeyManager creates a new publicKeyManager.
+func newPublicKeyManager() *publicKeyManager {
+	return new(publicKeyManager)
+}
+
+// Primitive creates the *crypto.PublicKey for the given serialized EcdhKwPublicKey proto.
+func (km *publicKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidPublicKey
+	}
+
+	key := new(ecdhkwpb.EcdhKwPublicKey)
+
+	if err := proto.Unmarshal(serializedKey, key); err != nil {
+		return nil, errInvalidPublicKey
+	}
+
+	curve, err := validatePublicKey(key, publicKeyVersion)
+	if err != nil {
+		return nil, errInvalidPublicKey
+	}
+
+	keyType := ECType
+	if curve == X25519 {
+		keyType = OKPType
+	}
+
+	return &crypto.PublicKey{X: key.X, Y: key.Y, Curve: curve, Type: keyType}, nil
+}
+
+// NewKey is not implemented for public keys.
+func (km *publicKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	return nil, errNotSupported
+}
+
+// NewKeyData is not implemented for public keys.
+func (km *publicKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	return nil, errNotSupported
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *publicKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == publicKeyTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *publicKeyManager) TypeURL() string {
+	return publicKeyTypeURL
+}
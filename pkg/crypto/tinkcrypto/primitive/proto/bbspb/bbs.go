@@ -0,0 +1,64 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package bbspb contains the protocol buffer messages of the BBS+ signing keys described in ../bbs.proto.
+package bbspb
+
+import (
+	"github.com/golang/protobuf/proto"
+)
+
+// BBSPublicKey is the BBS+ BLS12-381 G2 public key.
+type BBSPublicKey struct {
+	Version  uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	KeyValue []byte `protobuf:"bytes,2,opt,name=key_value,json=keyValue,proto3" json:"key_value,omitempty"`
+}
+
+// Reset resets the message.
+func (m *BBSPublicKey) Reset() { *m = BBSPublicKey{} }
+
+// String returns the text representation of the message.
+func (m *BBSPublicKey) String() string { return proto.CompactTextString(m) }
+
+// ProtoMessage marks the struct as a protocol buffer message.
+func (*BBSPublicKey) ProtoMessage() {}
+
+// BBSPrivateKey is the BBS+ BLS12-381 private key.
+type BBSPrivateKey struct {
+	Version   uint32        `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	PublicKey *BBSPublicKey `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	KeyValue  []byte        `protobuf:"bytes,3,opt,name=key_value,json=keyValue,proto3" json:"key_value,omitempty"`
+}
+
+// Reset resets the message.
+func (m *BBSPrivateKey) Reset() { *m = BBSPrivateKey{} }
+
+// String returns the text representation of the message.
+func (m *BBSPrivateKey) String() string { return proto.CompactTextString(m) }
+
+// ProtoMessage marks the struct as a protocol buffer message.
+func (*BBSPrivateKey) ProtoMessage() {}
+
+// GetPublicKey returns the public key.
+func (m *BBSPrivateKey) GetPublicKey() *BBSPublicKey {
+	if m != nil {
+		return m.PublicKey
+	}
+
+	return nil
+}
+
+// BBSKeyFormat is the format of the new BBS+ key, the keys have no parameters.
+type BBSKeyFormat struct{}
+
+// Reset resets the message.
+func (m *BBSKeyFormat) Reset() { *m = BBSKeyFormat{} }
+
+// String returns the text representation of the message.
+func (m *BBSKeyFormat) String() string { return proto.CompactTextString(m) }
+
+// ProtoMessage marks the struct as a protocol buffer message.
+func (*BBSKeyFormat) ProtoMessage() {}
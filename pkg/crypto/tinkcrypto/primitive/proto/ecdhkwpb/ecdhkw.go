@@ -0,0 +1,144 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ecdhkwpb contains the protocol buffer messages of the ECDH key wrapping keys
+// described in ../ecdhkw.proto.
+package ecdhkwpb
+
+import (
+	"github.com/golang/protobuf/proto"
+)
+
+// CurveType is the curve of the ECDH key.
+type CurveType int32
+
+// supported curves
+const (
+	CurveType_UNKNOWN_CURVE CurveType = 0 // nolint:golint,stylecheck
+	CurveType_NIST_P256     CurveType = 1 // nolint:golint,stylecheck
+	CurveType_NIST_P384     CurveType = 2 // nolint:golint,stylecheck
+	CurveType_NIST_P521     CurveType = 3 // nolint:golint,stylecheck
+	CurveType_X25519        CurveType = 4 // nolint:golint,stylecheck
+)
+
+// CurveType_name maps the curve values to their names.
+var CurveType_name = map[int32]string{ // nolint:golint,stylecheck,gochecknoglobals
+	0: "UNKNOWN_CURVE",
+	1: "NIST_P256",
+	2: "NIST_P384",
+	3: "NIST_P521",
+	4: "X25519",
+}
+
+// CurveType_value maps the curve names to their values.
+var CurveType_value = map[string]int32{ // nolint:golint,stylecheck,gochecknoglobals
+	"UNKNOWN_CURVE": 0,
+	"NIST_P256":     1,
+	"NIST_P384":     2,
+	"NIST_P521":     3,
+	"X25519":        4,
+}
+
+func (x CurveType) String() string {
+	return proto.EnumName(CurveType_name, int32(x))
+}
+
+// EcdhKwParams are the parameters of the ECDH key.
+type EcdhKwParams struct {
+	Curve CurveType `protobuf:"varint,1,opt,name=curve,proto3,enum=hyperledger.aries.crypto.tink.CurveType" json:"curve,omitempty"` // nolint:lll
+}
+
+// Reset resets the message.
+func (m *EcdhKwParams) Reset() { *m = EcdhKwParams{} }
+
+// String returns the text representation of the message.
+func (m *EcdhKwParams) String() string { return proto.CompactTextString(m) }
+
+// ProtoMessage marks the struct as a protocol buffer message.
+func (*EcdhKwParams) ProtoMessage() {}
+
+// GetCurve returns the curve.
+func (m *EcdhKwParams) GetCurve() CurveType {
+	if m != nil {
+		return m.Curve
+	}
+
+	return CurveType_UNKNOWN_CURVE
+}
+
+// EcdhKwPublicKey is the ECDH public key.
+type EcdhKwPublicKey struct {
+	Version uint32        `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Params  *EcdhKwParams `protobuf:"bytes,2,opt,name=params,proto3" json:"params,omitempty"`
+	X       []byte        `protobuf:"bytes,3,opt,name=x,proto3" json:"x,omitempty"`
+	Y       []byte        `protobuf:"bytes,4,opt,name=y,proto3" json:"y,omitempty"`
+}
+
+// Reset resets the message.
+func (m *EcdhKwPublicKey) Reset() { *m = EcdhKwPublicKey{} }
+
+// String returns the text representation of the message.
+func (m *EcdhKwPublicKey) String() string { return proto.CompactTextString(m) }
+
+// ProtoMessage marks the struct as a protocol buffer message.
+func (*EcdhKwPublicKey) ProtoMessage() {}
+
+// GetParams returns the key parameters.
+func (m *EcdhKwPublicKey) GetParams() *EcdhKwParams {
+	if m != nil {
+		return m.Params
+	}
+
+	return nil
+}
+
+// EcdhKwPrivateKey is the ECDH private key.
+type EcdhKwPrivateKey struct {
+	Version   uint32           `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	PublicKey *EcdhKwPublicKey `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	KeyValue  []byte           `protobuf:"bytes,3,opt,name=key_value,json=keyValue,proto3" json:"key_value,omitempty"`
+}
+
+// Reset resets the message.
+func (m *EcdhKwPrivateKey) Reset() { *m = EcdhKwPrivateKey{} }
+
+// String returns the text representation of the message.
+func (m *EcdhKwPrivateKey) String() string { return proto.CompactTextString(m) }
+
+// ProtoMessage marks the struct as a protocol buffer message.
+func (*EcdhKwPrivateKey) ProtoMessage() {}
+
+// GetPublicKey returns the public key.
+func (m *EcdhKwPrivateKey) GetPublicKey() *EcdhKwPublicKey {
+	if m != nil {
+		return m.PublicKey
+	}
+
+	return nil
+}
+
+// EcdhKwKeyFormat is the format of the new ECDH key.
+type EcdhKwKeyFormat struct {
+	Params *EcdhKwParams `protobuf:"bytes,1,opt,name=params,proto3" json:"params,omitempty"`
+}
+
+// Reset resets the message.
+func (m *EcdhKwKeyFormat) Reset() { *m = EcdhKwKeyFormat{} }
+
+// String returns the text representation of the message.
+func (m *EcdhKwKeyFormat) String() string { return proto.CompactTextString(m) }
+
+// ProtoMessage marks the struct as a protocol buffer message.
+func (*EcdhKwKeyFormat) ProtoMessage() {}
+
+// GetParams returns the key parameters.
+func (m *EcdhKwKeyFormat) GetParams() *EcdhKwParams {
+	if m != nil {
+		return m.Params
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package secp256k1pb contains the protocol buffer messages of the secp256k1 signing keys
+// described in ../secp256k1.proto.
+package secp256k1pb
+
+import (
+	"github.com/golang/protobuf/proto"
+)
+
+// Secp256k1PublicKey is the secp256k1 public key.
+type Secp256k1PublicKey struct {
+	Version uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	X       []byte `protobuf:"bytes,2,opt,name=x,proto3" json:"x,omitempty"`
+	Y       []byte `protobuf:"bytes,3,opt,name=y,proto3" json:"y,omitempty"`
+}
+
+// Reset resets the message.
+func (m *Secp256k1PublicKey) Reset() { *m = Secp256k1PublicKey{} }
+
+// String returns the text representation of the message.
+func (m *Secp256k1PublicKey) String() string { return proto.CompactTextString(m) }
+
+// ProtoMessage marks the struct as a protocol buffer message.
+func (*Secp256k1PublicKey) ProtoMessage() {}
+
+// Secp256k1PrivateKey is the secp256k1 private key.
+type Secp256k1PrivateKey struct {
+	Version   uint32              `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	PublicKey *Secp256k1PublicKey `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"` // nolint:lll
+	KeyValue  []byte              `protobuf:"bytes,3,opt,name=key_value,json=keyValue,proto3" json:"key_value,omitempty"`
+}
+
+// Reset resets the message.
+func (m *Secp256k1PrivateKey) Reset() { *m = Secp256k1PrivateKey{} }
+
+// String returns the text representation of the message.
+func (m *Secp256k1PrivateKey) String() string { return proto.CompactTextString(m) }
+
+// ProtoMessage marks the struct as a protocol buffer message.
+func (*Secp256k1PrivateKey) ProtoMessage() {}
+
+// GetPublicKey returns the public key.
+func (m *Secp256k1PrivateKey) GetPublicKey() *Secp256k1PublicKey {
+	if m != nil {
+		return m.PublicKey
+	}
+
+	return nil
+}
+
+// Secp256k1KeyFormat is the format of the new secp256k1 key, the keys have no parameters.
+type Secp256k1KeyFormat struct{}
+
+// Reset resets the message.
+func (m *Secp256k1KeyFormat) Reset() { *m = Secp256k1KeyFormat{} }
+
+// String returns the text representation of the message.
+func (m *Secp256k1KeyFormat) String() string { return proto.CompactTextString(m) }
+
+// ProtoMessage marks the struct as a protocol buffer message.
+func (*Secp256k1KeyFormat) ProtoMessage() {}
@@ -0,0 +1,22 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	tinkpb "github.com/google/tink/proto/tink_go_proto"
+)
+
+// ECDSASecp256k1KeyTemplate is a KeyTemplate that generates the ECDSA secp256k1 signing key.
+func ECDSASecp256k1KeyTemplate() *tinkpb.KeyTemplate {
+	return &tinkpb.KeyTemplate{
+		TypeUrl: privateKeyTypeURL,
+		// the key format has no parameters, its serialization is empty
+		Value: nil,
+		// the signatures are used as is in JWS and Linked Data proofs
+		OutputPrefixType: tinkpb.OutputPrefixType_RAW,
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/core/registry"
+	"github.com/google/tink/go/keyset"
+	tinkpb "github.com/google/tink/proto/tink_go_proto"
+
+	secp256k1pb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/secp256k1pb"
+	secp256k1curve "github.com/hyperledger/aries-framework-go/pkg/internal/secp256k1"
+)
+
+const (
+	privateKeyVersion = 0
+	privateKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.Secp256k1PrivateKey"
+)
+
+// common errors
+var (
+	errInvalidPrivateKey       = errors.New("secp256k1_private_key_manager: invalid key")
+	errInvalidPrivateKeyFormat = errors.New("secp256k1_private_key_manager: invalid key format")
+)
+
+// privateKeyManager is an implementation of PrivateKeyManager interface.
+// It generates new Secp256k1PrivateKeys and produces new instances of tink.Signer primitive.
+type privateKeyManager struct{}
+
+// Assert that privateKeyManager implements the PrivateKeyManager interface.
+var _ registry.PrivateKeyManager = (*privateKeyManager)(nil)
+
+// newPrivateKeyManager creates a new privateKeyManager.
+func newPrivateKeyManager() *privateKeyManager {
+	return new(privateKeyManager)
+}
+
+// Primitive creates the tink.Signer for the given serialized Secp256k1PrivateKey proto.
+func (km *privateKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidPrivateKey
+	}
+
+	key := new(secp256k1pb.Secp256k1PrivateKey)
+
+	if err := proto.Unmarshal(serializedKey, key); err != nil {
+		return nil, errInvalidPrivateKey
+	}
+
+	if err := keyset.ValidateKeyVersion(key.Version, privateKeyVersion); err != nil {
+		return nil, errInvalidPrivateKey
+	}
+
+	pub, err := validatePublicKey(key.GetPublicKey(), privateKeyVersion)
+	if err != nil {
+		return nil, errInvalidPrivateKey
+	}
+
+	return &signer{key: &ecdsa.PrivateKey{PublicKey: *pub, D: new(big.Int).SetBytes(key.KeyValue)}}, nil
+}
+
+// NewKey creates a new Secp256k1PrivateKey, the serialized Secp256k1KeyFormat has no parameters.
+func (km *privateKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	if err := proto.Unmarshal(serializedKeyFormat, new(secp256k1pb.Secp256k1KeyFormat)); err != nil {
+		return nil, errInvalidPrivateKeyFormat
+	}
+
+	priv, err := secp256k1curve.GenerateKey()
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1_private_key_manager: %w", err)
+	}
+
+	return &secp256k1pb.Secp256k1PrivateKey{
+		Version: privateKeyVersion,
+		PublicKey: &secp256k1pb.Secp256k1PublicKey{
+			Version: privateKeyVersion,
+			X:       priv.X.Bytes(),
+			Y:       priv.Y.Bytes(),
+		},
+		KeyValue: priv.D.Bytes(),
+	}, nil
+}
+
+// NewKeyData creates a new KeyData according to specification in the given serialized Secp256k1KeyFormat.
+// It should be used solely by the key management API.
+func (km *privateKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	key, err := km.NewKey(serializedKeyFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	serializedKey, err := proto.Marshal(key)
+	if err != nil {
+		return nil, errInvalidPrivateKeyFormat
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         privateKeyTypeURL,
+		Value:           serializedKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PRIVATE,
+	}, nil
+}
+
+// PublicKeyData returns the enclosed public key data of serializedPrivKey.
+func (km *privateKeyManager) PublicKeyData(serializedPrivKey []byte) (*tinkpb.KeyData, error) {
+	privKey := new(secp256k1pb.Secp256k1PrivateKey)
+
+	if err := proto.Unmarshal(serializedPrivKey, privKey); err != nil {
+		return nil, errInvalidPrivateKey
+	}
+
+	serializedPubKey, err := proto.Marshal(privKey.PublicKey)
+	if err != nil {
+		return nil, errInvalidPrivateKey
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         publicKeyTypeURL,
+		Value:           serializedPubKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PUBLIC,
+	}, nil
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *privateKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == privateKeyTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *privateKeyManager) TypeURL() string {
+	return privateKeyTypeURL
+}
+
+// validatePublicKey validates the public key and returns it as an ECDSA public key.
+func validatePublicKey(key *secp256k1pb.Secp256k1PublicKey, version uint32) (*ecdsa.PublicKey, error) {
+	if key == nil {
+		return nil, errors.New("public key is missing")
+	}
+
+	if err := keyset.ValidateKeyVersion(key.Version, version); err != nil {
+		return nil, err
+	}
+
+	x := new(big.Int).SetBytes(key.X)
+	y := new(big.Int).SetBytes(key.Y)
+
+	if !secp256k1curve.S256().IsOnCurve(x, y) {
+		return nil, errors.New("public key is not on the secp256k1 curve")
+	}
+
+	return &ecdsa.PublicKey{Curve: secp256k1curve.S256(), X: x, Y: y}, nil
+}
@@ -0,0 +1,57 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package secp256k1 provides the Tink key managers of the ECDSA secp256k1 signing keys (the ES256K JWS
+// algorithm), which are not supported by Tink. The private key primitive is a tink.Signer and the public key
+// primitive a tink.Verifier, the signatures are the concatenation of R and S (IEEE P1363) of the SHA-256
+// digest of the message, as in JWS and Linked Data proofs.
+//
+// The key managers are registered in the Tink registry when this package is initialized, the keys are
+// created with the key template of this package:
+//
+//	kh, err := keyset.NewHandle(secp256k1.ECDSASecp256k1KeyTemplate())
+package secp256k1
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/google/tink/go/core/registry"
+	"github.com/google/tink/go/keyset"
+)
+
+// nolint:gochecknoinits
+func init() {
+	if err := registry.RegisterKeyManager(newPrivateKeyManager()); err != nil {
+		panic(fmt.Sprintf("secp256k1.init() failed: %v", err))
+	}
+
+	if err := registry.RegisterKeyManager(newPublicKeyManager()); err != nil {
+		panic(fmt.Sprintf("secp256k1.init() failed: %v", err))
+	}
+}
+
+// PublicKey returns the public key of the primary key in kh. The key handle is either the private
+// key handle or the public key handle of the secp256k1 key.
+func PublicKey(kh *keyset.Handle) (*ecdsa.PublicKey, error) {
+	ps, err := kh.Primitives()
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1: get primitives: %w", err)
+	}
+
+	switch p := ps.Primary.Primitive.(type) {
+	case *signer:
+		pub := p.key.PublicKey
+
+		return &pub, nil
+	case *verifier:
+		pub := *p.key
+
+		return &pub, nil
+	default:
+		return nil, fmt.Errorf("secp256k1: unsupported primitive %T", p)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/aead"
+	"github.com/google/tink/go/keyset"
+	"github.com/google/tink/go/signature"
+	"github.com/stretchr/testify/require"
+
+	secp256k1pb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/secp256k1pb"
+	secp256k1curve "github.com/hyperledger/aries-framework-go/pkg/internal/secp256k1"
+)
+
+func TestKeyTemplate(t *testing.T) {
+	kh, err := keyset.NewHandle(ECDSASecp256k1KeyTemplate())
+	require.NoError(t, err)
+
+	pub, err := PublicKey(kh)
+	require.NoError(t, err)
+	require.Equal(t, secp256k1curve.S256(), pub.Curve)
+
+	pubKH, err := kh.Public()
+	require.NoError(t, err)
+
+	// the public key is the same from both private and public key handles
+	pub2, err := PublicKey(pubKH)
+	require.NoError(t, err)
+	require.Equal(t, pub, pub2)
+
+	s, err := signature.NewSigner(kh)
+	require.NoError(t, err)
+
+	msg := []byte("test message")
+
+	sig, err := s.Sign(msg)
+	require.NoError(t, err)
+	require.Len(t, sig, secp256k1curve.SignatureSize)
+
+	// the signature is the raw R || S signature of the SHA-256 digest
+	digest := sha256.Sum256(msg)
+	require.True(t, secp256k1curve.Verify(pub, digest[:], sig))
+
+	v, err := signature.NewVerifier(pubKH)
+	require.NoError(t, err)
+	require.NoError(t, v.Verify(sig, msg))
+	require.Error(t, v.Verify(sig, []byte("other message")))
+
+	err = (&verifier{key: pub}).Verify(sig, []byte("other message"))
+	require.EqualError(t, err, "secp256k1_verifier: invalid signature")
+}
+
+func TestPublicKey_Failure(t *testing.T) {
+	kh, err := keyset.NewHandle(aead.AES256GCMKeyTemplate())
+	require.NoError(t, err)
+
+	_, err = PublicKey(kh)
+	require.Contains(t, err.Error(), "secp256k1: unsupported primitive")
+}
+
+func TestPrivateKeyManager(t *testing.T) {
+	km := newPrivateKeyManager()
+	require.True(t, km.DoesSupport(privateKeyTypeURL))
+	require.False(t, km.DoesSupport(publicKeyTypeURL))
+	require.Equal(t, privateKeyTypeURL, km.TypeURL())
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := km.Primitive(nil)
+		require.Equal(t, errInvalidPrivateKey, err)
+
+		_, err = km.Primitive([]byte("bad key"))
+		require.Equal(t, errInvalidPrivateKey, err)
+
+		serializedKey, err := proto.Marshal(&secp256k1pb.Secp256k1PrivateKey{Version: 1})
+		require.NoError(t, err)
+
+		_, err = km.Primitive(serializedKey)
+		require.Equal(t, errInvalidPrivateKey, err)
+
+		serializedKey, err = proto.Marshal(&secp256k1pb.Secp256k1PrivateKey{})
+		require.NoError(t, err)
+
+		_, err = km.Primitive(serializedKey)
+		require.Equal(t, errInvalidPrivateKey, err)
+
+		serializedKey, err = proto.Marshal(&secp256k1pb.Secp256k1PrivateKey{
+			PublicKey: &secp256k1pb.Secp256k1PublicKey{X: []byte{1}, Y: []byte{2}},
+		})
+		require.NoError(t, err)
+
+		_, err = km.Primitive(serializedKey)
+		require.Equal(t, errInvalidPrivateKey, err)
+
+		_, err = km.PublicKeyData([]byte("bad key"))
+		require.Equal(t, errInvalidPrivateKey, err)
+	})
+
+	t.Run("invalid key format", func(t *testing.T) {
+		_, err := km.NewKey([]byte("bad format"))
+		require.Equal(t, errInvalidPrivateKeyFormat, err)
+
+		_, err = km.NewKeyData([]byte("bad format"))
+		require.Equal(t, errInvalidPrivateKeyFormat, err)
+	})
+}
+
+func TestPublicKeyManager(t *testing.T) {
+	km := newPublicKeyManager()
+	require.True(t, km.DoesSupport(publicKeyTypeURL))
+	require.False(t, km.DoesSupport(privateKeyTypeURL))
+	require.Equal(t, publicKeyTypeURL, km.TypeURL())
+
+	_, err := km.Primitive(nil)
+	require.Equal(t, errInvalidPublicKey, err)
+
+	_, err = km.Primitive([]byte("bad key"))
+	require.Equal(t, errInvalidPublicKey, err)
+
+	serializedKey, err := proto.Marshal(&secp256k1pb.Secp256k1PublicKey{Version: 1})
+	require.NoError(t, err)
+
+	_, err = km.Primitive(serializedKey)
+	require.Equal(t, errInvalidPublicKey, err)
+
+	_, err = km.NewKey(nil)
+	require.Equal(t, errNotSupported, err)
+
+	_, err = km.NewKeyData(nil)
+	require.Equal(t, errNotSupported, err)
+}
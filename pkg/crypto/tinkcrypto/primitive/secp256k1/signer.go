@@ -0,0 +1,57 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/google/tink/go/tink"
+
+	secp256k1curve "github.com/hyperledger/aries-framework-go/pkg/internal/secp256k1"
+)
+
+// signer is the tink.Signer of the secp256k1 private keys.
+type signer struct {
+	key *ecdsa.PrivateKey
+}
+
+// Assert that signer implements the tink.Signer interface.
+var _ tink.Signer = (*signer)(nil)
+
+// Sign signs the SHA-256 digest of data, the signature is the concatenation of R and S.
+func (s *signer) Sign(data []byte) ([]byte, error) {
+	digest := sha256.Sum256(data)
+
+	signature, err := secp256k1curve.Sign(s.key, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1_signer: %w", err)
+	}
+
+	return signature, nil
+}
+
+// verifier is the tink.Verifier of the secp256k1 public keys.
+type verifier struct {
+	key *ecdsa.PublicKey
+}
+
+// Assert that verifier implements the tink.Verifier interface.
+var _ tink.Verifier = (*verifier)(nil)
+
+// Verify verifies the R || S signature of the SHA-256 digest of data.
+func (v *verifier) Verify(signature, data []byte) error {
+	digest := sha256.Sum256(data)
+
+	if !secp256k1curve.Verify(v.key, digest[:], signature) {
+		return errors.New("secp256k1_verifier: invalid signature")
+	}
+
+	return nil
+}
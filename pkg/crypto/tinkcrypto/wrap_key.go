@@ -0,0 +1,221 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tinkcrypto
+
+import (
+	gocrypto "crypto"
+	"crypto/aes"
+	// register SHA-256 used by Concat KDF
+	_ "crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/google/tink/go/keyset"
+	josecipher "github.com/square/go-jose/v3/cipher"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/ecdh"
+)
+
+const (
+	// ECDHESA256KWAlg is the ECDH-ES key agreement with AES-256 key wrapping algorithm (Anoncrypt)
+	ECDHESA256KWAlg = "ECDH-ES+A256KW"
+	// ECDH1PUA256KWAlg is the ECDH-1PU key agreement with AES-256 key wrapping algorithm (Authcrypt)
+	ECDH1PUA256KWAlg = "ECDH-1PU+A256KW"
+
+	// kekSize is the size of AES-256 key encryption key
+	kekSize = 32
+)
+
+// WrapKey will do ECDH (ES or 1PU) key wrapping of cek using apu, apv and recipient public key recPubKey.
+// An ephemeral key pair is created on the curve of recPubKey, its public key is returned in the EPK field
+// of the result. The ECDH-1PU key wrapping is used if the sender private key handle is set with
+// crypto.WithSender() option, the ECDH-ES key wrapping is used otherwise.
+func (t *Crypto) WrapKey(cek, apu, apv []byte, recPubKey *crypto.PublicKey,
+	opts ...crypto.WrapKeyOpts) (*crypto.RecipientWrappedKey, error) {
+	if recPubKey == nil {
+		return nil, errors.New("wrapKey: recipient public key is required")
+	}
+
+	pOpts := &crypto.WrapKeyOptions{}
+
+	for _, opt := range opts {
+		opt(pOpts)
+	}
+
+	epk, err := ecdh.GenerateKey(recPubKey.Curve)
+	if err != nil {
+		return nil, fmt.Errorf("wrapKey: generate ephemeral key: %w", err)
+	}
+
+	z, err := epk.ComputeSharedSecret(recPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("wrapKey: %w", err)
+	}
+
+	alg := ECDHESA256KWAlg
+
+	if pOpts.SenderKey != nil {
+		alg = ECDH1PUA256KWAlg
+
+		senderKA, e := keyAgreement(pOpts.SenderKey)
+		if e != nil {
+			return nil, fmt.Errorf("wrapKey: sender key: %w", e)
+		}
+
+		// ECDH-1PU shared secret is Z = Ze || Zs
+		zs, e := senderKA.ComputeSharedSecret(recPubKey)
+		if e != nil {
+			return nil, fmt.Errorf("wrapKey: %w", e)
+		}
+
+		z = append(z, zs...)
+	}
+
+	block, err := aes.NewCipher(deriveKEK(alg, apu, apv, z))
+	if err != nil {
+		return nil, fmt.Errorf("wrapKey: create new cipher: %w", err)
+	}
+
+	wk, err := josecipher.KeyWrap(block, cek)
+	if err != nil {
+		return nil, fmt.Errorf("wrapKey: failed to wrap key: %w", err)
+	}
+
+	return &crypto.RecipientWrappedKey{
+		KID:          recPubKey.KID,
+		EncryptedCEK: wk,
+		EPK:          *epk.PublicKey(),
+		Alg:          alg,
+		APU:          apu,
+		APV:          apv,
+	}, nil
+}
+
+// UnwrapKey unwraps the key in recWK using the recipient private key handle kh (created by localkms or
+// keyset.NewHandle() with one of ecdh key templates). The sender public key must be set with
+// crypto.WithSender() option to unwrap the key wrapped with ECDH-1PU.
+func (t *Crypto) UnwrapKey(recWK *crypto.RecipientWrappedKey, kh interface{},
+	opts ...crypto.WrapKeyOpts) ([]byte, error) {
+	if recWK == nil {
+		return nil, errors.New("unwrapKey: RecipientWrappedKey is empty")
+	}
+
+	pOpts := &crypto.WrapKeyOptions{}
+
+	for _, opt := range opts {
+		opt(pOpts)
+	}
+
+	recKA, err := keyAgreement(kh)
+	if err != nil {
+		return nil, fmt.Errorf("unwrapKey: %w", err)
+	}
+
+	z, err := recKA.ComputeSharedSecret(&recWK.EPK)
+	if err != nil {
+		return nil, fmt.Errorf("unwrapKey: %w", err)
+	}
+
+	switch recWK.Alg {
+	case ECDHESA256KWAlg:
+	case ECDH1PUA256KWAlg:
+		if pOpts.SenderKey == nil {
+			return nil, errors.New("unwrapKey: sender key is required for ECDH-1PU")
+		}
+
+		senderPubKey, e := publicKey(pOpts.SenderKey)
+		if e != nil {
+			return nil, fmt.Errorf("unwrapKey: sender key: %w", e)
+		}
+
+		zs, e := recKA.ComputeSharedSecret(senderPubKey)
+		if e != nil {
+			return nil, fmt.Errorf("unwrapKey: %w", e)
+		}
+
+		z = append(z, zs...)
+	default:
+		return nil, fmt.Errorf("unwrapKey: unsupported key wrapping algorithm: %s", recWK.Alg)
+	}
+
+	block, err := aes.NewCipher(deriveKEK(recWK.Alg, recWK.APU, recWK.APV, z))
+	if err != nil {
+		return nil, fmt.Errorf("unwrapKey: create new cipher: %w", err)
+	}
+
+	cek, err := josecipher.KeyUnwrap(block, recWK.EncryptedCEK)
+	if err != nil {
+		return nil, fmt.Errorf("unwrapKey: failed to unwrap key: %w", err)
+	}
+
+	return cek, nil
+}
+
+// keyAgreement returns the ECDH primitive of the private key handle kh.
+func keyAgreement(kh interface{}) (ecdh.KeyAgreement, error) {
+	keyHandle, ok := kh.(*keyset.Handle)
+	if !ok {
+		return nil, errors.New("bad key handle format")
+	}
+
+	ps, err := keyHandle.Primitives()
+	if err != nil {
+		return nil, fmt.Errorf("get primitives: %w", err)
+	}
+
+	ka, ok := ps.Primary.Primitive.(ecdh.KeyAgreement)
+	if !ok {
+		return nil, errors.New("key handle is not an ECDH private key")
+	}
+
+	return ka, nil
+}
+
+// publicKey returns the public key from *crypto.PublicKey or the ECDH key handle.
+func publicKey(key interface{}) (*crypto.PublicKey, error) {
+	switch k := key.(type) {
+	case *crypto.PublicKey:
+		return k, nil
+	case *keyset.Handle:
+		return ecdh.PublicKey(k)
+	default:
+		return nil, errors.New("bad key format")
+	}
+}
+
+// deriveKEK derives the key encryption key from the shared secret z using Concat KDF as per
+// https://tools.ietf.org/html/rfc7518#section-4.6.2
+func deriveKEK(alg string, apu, apv, z []byte) []byte {
+	const numBitsPerByte = 8
+
+	// suppPubInfo is the encoded length of the key encryption key in bits
+	supPubInfo := make([]byte, 4)
+	binary.BigEndian.PutUint32(supPubInfo, kekSize*numBitsPerByte)
+
+	reader := josecipher.NewConcatKDF(gocrypto.SHA256, z, lengthPrefix([]byte(alg)), lengthPrefix(apu),
+		lengthPrefix(apv), supPubInfo, []byte{})
+
+	kek := make([]byte, kekSize)
+
+	// Read on the KDF never fails
+	_, _ = reader.Read(kek) // nolint:errcheck
+
+	return kek
+}
+
+// lengthPrefix array with a bigEndian uint32 value of array's length
+func lengthPrefix(array []byte) []byte {
+	const prefixLen = 4
+
+	arrInfo := make([]byte, prefixLen+len(array))
+	binary.BigEndian.PutUint32(arrInfo, uint32(len(array)))
+	copy(arrInfo[prefixLen:], array)
+
+	return arrInfo
+}
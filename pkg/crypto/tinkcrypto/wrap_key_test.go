@@ -0,0 +1,191 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tinkcrypto
+
+import (
+	"testing"
+
+	"github.com/google/tink/go/aead"
+	"github.com/google/tink/go/keyset"
+	"github.com/google/tink/go/subtle/random"
+	tinkpb "github.com/google/tink/proto/tink_go_proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/ecdh"
+)
+
+func TestCrypto_WrapUnwrapKey(t *testing.T) {
+	templates := []struct {
+		name     string
+		template *tinkpb.KeyTemplate
+	}{
+		{"X25519", ecdh.X25519KWKeyTemplate()},
+		{"NIST P-256", ecdh.NISTP256KWKeyTemplate()},
+		{"NIST P-384", ecdh.NISTP384KWKeyTemplate()},
+		{"NIST P-521", ecdh.NISTP521KWKeyTemplate()},
+	}
+
+	c := Crypto{}
+	cek := random.GetRandomBytes(32)
+	apu := []byte("sender")
+	apv := []byte("recipient")
+
+	for _, tt := range templates {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			recKH, err := keyset.NewHandle(tc.template)
+			require.NoError(t, err)
+
+			recPubKey, err := ecdh.PublicKey(recKH)
+			require.NoError(t, err)
+
+			recPubKey.KID = "recipientKID"
+
+			senderKH, err := keyset.NewHandle(tc.template)
+			require.NoError(t, err)
+
+			senderPubKH, err := senderKH.Public()
+			require.NoError(t, err)
+
+			senderPubKey, err := ecdh.PublicKey(senderKH)
+			require.NoError(t, err)
+
+			t.Run("ECDH-ES (anoncrypt)", func(t *testing.T) {
+				wk, err := c.WrapKey(cek, apu, apv, recPubKey)
+				require.NoError(t, err)
+				require.Equal(t, ECDHESA256KWAlg, wk.Alg)
+				require.Equal(t, recPubKey.KID, wk.KID)
+				require.Equal(t, recPubKey.Curve, wk.EPK.Curve)
+				require.Equal(t, apu, wk.APU)
+				require.Equal(t, apv, wk.APV)
+				require.NotEqual(t, cek, wk.EncryptedCEK)
+
+				unwrapped, err := c.UnwrapKey(wk, recKH)
+				require.NoError(t, err)
+				require.Equal(t, cek, unwrapped)
+
+				// unwrap with another recipient key - should fail
+				_, err = c.UnwrapKey(wk, senderKH)
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "unwrapKey: failed to unwrap key")
+			})
+
+			t.Run("ECDH-1PU (authcrypt)", func(t *testing.T) {
+				wk, err := c.WrapKey(cek, apu, apv, recPubKey, crypto.WithSender(senderKH))
+				require.NoError(t, err)
+				require.Equal(t, ECDH1PUA256KWAlg, wk.Alg)
+
+				// sender key as public key
+				unwrapped, err := c.UnwrapKey(wk, recKH, crypto.WithSender(senderPubKey))
+				require.NoError(t, err)
+				require.Equal(t, cek, unwrapped)
+
+				// sender key as public key handle
+				unwrapped, err = c.UnwrapKey(wk, recKH, crypto.WithSender(senderPubKH))
+				require.NoError(t, err)
+				require.Equal(t, cek, unwrapped)
+
+				// unwrap without sender key - should fail
+				_, err = c.UnwrapKey(wk, recKH)
+				require.EqualError(t, err, "unwrapKey: sender key is required for ECDH-1PU")
+
+				// unwrap with the wrong sender key - should fail
+				_, err = c.UnwrapKey(wk, recKH, crypto.WithSender(recPubKey))
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "unwrapKey: failed to unwrap key")
+			})
+		})
+	}
+}
+
+func TestCrypto_WrapUnwrapKey_Failure(t *testing.T) {
+	c := Crypto{}
+	cek := random.GetRandomBytes(32)
+
+	recKH, err := keyset.NewHandle(ecdh.X25519KWKeyTemplate())
+	require.NoError(t, err)
+
+	recPubKey, err := ecdh.PublicKey(recKH)
+	require.NoError(t, err)
+
+	aeadKH, err := keyset.NewHandle(aead.AES256GCMKeyTemplate())
+	require.NoError(t, err)
+
+	t.Run("wrap key failures", func(t *testing.T) {
+		_, err = c.WrapKey(cek, nil, nil, nil)
+		require.EqualError(t, err, "wrapKey: recipient public key is required")
+
+		_, err = c.WrapKey(cek, nil, nil, &crypto.PublicKey{Curve: "unknown"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "wrapKey: generate ephemeral key")
+
+		_, err = c.WrapKey(cek, nil, nil, &crypto.PublicKey{Curve: ecdh.P256, X: []byte{1}, Y: []byte{2}})
+		require.Error(t, err)
+
+		_, err = c.WrapKey(cek, nil, nil, recPubKey, crypto.WithSender("bad key handle"))
+		require.EqualError(t, err, "wrapKey: sender key: bad key handle format")
+
+		_, err = c.WrapKey(cek, nil, nil, recPubKey, crypto.WithSender(aeadKH))
+		require.EqualError(t, err, "wrapKey: sender key: key handle is not an ECDH private key")
+
+		// sender key on another curve
+		p256KH, e := keyset.NewHandle(ecdh.NISTP256KWKeyTemplate())
+		require.NoError(t, e)
+
+		_, err = c.WrapKey(cek, nil, nil, recPubKey, crypto.WithSender(p256KH))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "curve mismatch")
+
+		// cek is not a multiple of 8 bytes
+		_, err = c.WrapKey([]byte("bad cek"), nil, nil, recPubKey)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "wrapKey: failed to wrap key")
+	})
+
+	t.Run("unwrap key failures", func(t *testing.T) {
+		wk, e := c.WrapKey(cek, nil, nil, recPubKey)
+		require.NoError(t, e)
+
+		_, err = c.UnwrapKey(nil, recKH)
+		require.EqualError(t, err, "unwrapKey: RecipientWrappedKey is empty")
+
+		_, err = c.UnwrapKey(wk, nil)
+		require.EqualError(t, err, "unwrapKey: bad key handle format")
+
+		_, err = c.UnwrapKey(wk, aeadKH)
+		require.EqualError(t, err, "unwrapKey: key handle is not an ECDH private key")
+
+		recPubKH, e := recKH.Public()
+		require.NoError(t, e)
+
+		_, err = c.UnwrapKey(wk, recPubKH)
+		require.EqualError(t, err, "unwrapKey: key handle is not an ECDH private key")
+
+		badWK := *wk
+		badWK.Alg = "unsupported"
+
+		_, err = c.UnwrapKey(&badWK, recKH)
+		require.EqualError(t, err, "unwrapKey: unsupported key wrapping algorithm: unsupported")
+
+		badWK.Alg = ECDH1PUA256KWAlg
+
+		_, err = c.UnwrapKey(&badWK, recKH, crypto.WithSender("bad key"))
+		require.EqualError(t, err, "unwrapKey: sender key: bad key format")
+
+		_, err = c.UnwrapKey(&badWK, recKH, crypto.WithSender(&crypto.PublicKey{Curve: ecdh.P256}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "curve mismatch")
+
+		badWK = *wk
+		badWK.EPK = crypto.PublicKey{Curve: ecdh.P256}
+
+		_, err = c.UnwrapKey(&badWK, recKH)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "curve mismatch")
+	})
+}
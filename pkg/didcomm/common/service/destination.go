@@ -24,8 +24,8 @@ type Destination struct {
 
 const (
 	didCommServiceType = "did-communication"
-	// TODO: hardcoded key type https://github.com/hyperledger/aries-framework-go/issues/1008
-	ed25519KeyType = "Ed25519VerificationKey2018"
+	ed25519KeyType     = "Ed25519VerificationKey2018"
+	p256KeyType        = "EcdsaSecp256r1VerificationKey2019"
 )
 
 // GetDestination constructs a Destination struct based on the given DID and parameters
@@ -40,13 +40,14 @@ func GetDestination(did string, vdr vdri.Registry) (*Destination, error) {
 }
 
 // CreateDestination makes a DIDComm Destination object from a DID Doc
+// The recipient keys are the Ed25519 and P-256 keys of the DIDComm service.
 func CreateDestination(didDoc *diddoc.Doc) (*Destination, error) {
 	didCommService, ok := diddoc.LookupService(didDoc, didCommServiceType)
 	if !ok {
 		return nil, fmt.Errorf("create destination: missing DID doc service")
 	}
 
-	recipientKeys, ok := diddoc.LookupRecipientKeys(didDoc, didCommServiceType, ed25519KeyType)
+	recipientKeys, ok := diddoc.LookupRecipientKeys(didDoc, didCommServiceType, ed25519KeyType, p256KeyType)
 	if !ok {
 		return nil, fmt.Errorf("create destination: missing keys")
 	}
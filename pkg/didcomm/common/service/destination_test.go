@@ -71,6 +71,16 @@ func TestPrepareDestination(t *testing.T) {
 		require.Equal(t, []string{"76HmFbj8sds7jjdnZ4hMVcQgtUYZpEN1HEmPnCrH2Bby"}, dest.RoutingKeys)
 	})
 
+	t.Run("successfully prepared destination with P-256 recipient key", func(t *testing.T) {
+		didDoc := mockdiddoc.GetMockDIDDoc()
+		didDoc.PublicKey[0].Type = p256KeyType
+		didDoc.Service[0].RecipientKeys = []string{didDoc.PublicKey[0].ID}
+
+		dest, err := CreateDestination(didDoc)
+		require.NoError(t, err)
+		require.Equal(t, []string{base58.Encode(didDoc.PublicKey[0].Value)}, dest.RecipientKeys)
+	})
+
 	t.Run("error while getting service", func(t *testing.T) {
 		didDoc := mockdiddoc.GetMockDIDDoc()
 		didDoc.Service = nil
@@ -20,7 +20,6 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
-	"github.com/hyperledger/aries-framework-go/pkg/kms/legacykms"
 )
 
 // provider interface for outbound ctx
@@ -29,7 +28,6 @@ type provider interface {
 	OutboundTransports() []transport.OutboundTransport
 	TransportReturnRoute() string
 	VDRIRegistry() vdri.Registry
-	LegacyKMS() legacykms.KeyManager
 }
 
 // OutboundDispatcher dispatch msgs to destination
@@ -38,7 +36,6 @@ type OutboundDispatcher struct {
 	packager             commontransport.Packager
 	transportReturnRoute string
 	vdRegistry           vdri.Registry
-	kms                  legacykms.KeyManager
 }
 
 // NewOutbound return new dispatcher outbound instance
@@ -48,7 +45,6 @@ func NewOutbound(prov provider) *OutboundDispatcher {
 		packager:             prov.Packager(),
 		transportReturnRoute: prov.TransportReturnRoute(),
 		vdRegistry:           prov.VDRIRegistry(),
-		kms:                  prov.LegacyKMS(),
 	}
 }
 
@@ -173,17 +169,8 @@ func (o *OutboundDispatcher) createForwardMessage(msg []byte, des *service.Desti
 		return nil, fmt.Errorf("failed marshal to bytes: %w", err)
 	}
 
-	// create key set
-	_, senderVerKey, err := o.kms.CreateKeySet()
-	if err != nil {
-		return nil, fmt.Errorf("failed CreateSigningKey: %w", err)
-	}
-
-	// pack above message using auth crypt
-	// TODO https://github.com/hyperledger/aries-framework-go/issues/1112 Configurable packing
-	//  algorithm(auth/anon crypt) for Forward(router) message
-	packedMsg, err := o.packager.PackMessage(
-		&commontransport.Envelope{Message: req, FromVerKey: base58.Decode(senderVerKey), ToVerKeys: des.RoutingKeys})
+	// pack above message without sender key (anon crypt), the router must not learn who the sender is
+	packedMsg, err := o.packager.PackMessage(&commontransport.Envelope{Message: req, ToVerKeys: des.RoutingKeys})
 	if err != nil {
 		return nil, fmt.Errorf("pack forward msg: %w", err)
 	}
@@ -23,7 +23,6 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	mockdidcomm "github.com/hyperledger/aries-framework-go/pkg/internal/mock/didcomm"
 	mockpackager "github.com/hyperledger/aries-framework-go/pkg/internal/mock/didcomm/packager"
-	"github.com/hyperledger/aries-framework-go/pkg/kms/legacykms"
 	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
 	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
 )
@@ -75,22 +74,27 @@ func TestOutboundDispatcher_Send(t *testing.T) {
 		}))
 	})
 
-	t.Run("test send with forward message - create key failure", func(t *testing.T) {
+	t.Run("test send with forward message - packed without sender", func(t *testing.T) {
+		packager := &mockPackager{}
 		o := NewOutbound(&mockProvider{
-			packagerValue:           &mockpackager.Packager{PackValue: createPackedMsgForForward(t)},
+			packagerValue:           packager,
 			outboundTransportsValue: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{AcceptValue: true}},
-			legacyKMS: &mockKMS{
-				CreateKeyErr: errors.New("create key error"),
-			},
 		})
 
-		err := o.Send("data", "", &service.Destination{
+		_, err := o.createForwardMessage(createPackedMsgForForward(t), &service.Destination{
 			ServiceEndpoint: "url",
 			RecipientKeys:   []string{"abc"},
 			RoutingKeys:     []string{"xyz"},
 		})
-		require.Error(t, err)
-		require.Contains(t, err.Error(), "create forward msg")
+		require.NoError(t, err)
+		require.Len(t, packager.envelopes, 1)
+		require.Empty(t, packager.envelopes[0].FromVerKey)
+		require.Equal(t, []string{"xyz"}, packager.envelopes[0].ToVerKeys)
+
+		forward := &model.Forward{}
+		require.NoError(t, json.Unmarshal(packager.envelopes[0].Message, forward))
+		require.Equal(t, service.ForwardMsgType, forward.Type)
+		require.Equal(t, "abc", forward.To)
 	})
 
 	t.Run("test send with forward message - packer error", func(t *testing.T) {
@@ -292,7 +296,6 @@ type mockProvider struct {
 	outboundTransportsValue []transport.OutboundTransport
 	transportReturnRoute    string
 	vdriRegistry            vdri.Registry
-	legacyKMS               legacykms.KMS
 }
 
 func (p *mockProvider) Packager() commontransport.Packager {
@@ -311,14 +314,6 @@ func (p *mockProvider) VDRIRegistry() vdri.Registry {
 	return p.vdriRegistry
 }
 
-func (p *mockProvider) LegacyKMS() legacykms.KeyManager {
-	if p.legacyKMS != nil {
-		return p.legacyKMS
-	}
-
-	return &mockKMS{}
-}
-
 // mockOutboundTransport mock outbound transport
 type mockOutboundTransport struct {
 	expectedRequest string
@@ -347,47 +342,15 @@ func (o *mockOutboundTransport) Accept(url string) bool {
 
 // mockPackager mock packager
 type mockPackager struct {
+	envelopes []*commontransport.Envelope
 }
 
 func (m *mockPackager) PackMessage(e *commontransport.Envelope) ([]byte, error) {
+	m.envelopes = append(m.envelopes, e)
+
 	return e.Message, nil
 }
 
 func (m *mockPackager) UnpackMessage(encMessage []byte) (*commontransport.Envelope, error) {
 	return nil, nil
 }
-
-// mockKMS mock Key Management Service (LegacyKMS)
-type mockKMS struct {
-	CreateEncryptionKeyValue string
-	CreateSigningKeyValue    string
-	CreateKeyErr             error
-}
-
-func (m *mockKMS) Close() error {
-	return nil
-}
-
-func (m *mockKMS) CreateKeySet() (string, string, error) {
-	return m.CreateEncryptionKeyValue, m.CreateSigningKeyValue, m.CreateKeyErr
-}
-
-func (m *mockKMS) FindVerKey(candidateKeys []string) (int, error) {
-	return 0, nil
-}
-
-func (m *mockKMS) SignMessage(message []byte, fromVerKey string) ([]byte, error) {
-	return nil, nil
-}
-
-func (m *mockKMS) DeriveKEK(alg, apu, fromKey, toPubKey []byte) ([]byte, error) {
-	return nil, nil
-}
-
-func (m *mockKMS) GetEncryptionKey(verKey []byte) ([]byte, error) {
-	return nil, nil
-}
-
-func (m *mockKMS) ConvertToEncryptionKey(key []byte) ([]byte, error) {
-	return nil, nil
-}
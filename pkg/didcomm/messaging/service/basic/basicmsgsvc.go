@@ -27,8 +27,11 @@ import (
 )
 
 const (
+	// MessageSpec is basic message DIDComm protocol spec.
+	MessageSpec = "https://didcomm.org/basicmessage/1.0/"
+
 	// MessageRequestType is basic message DIDComm message type.
-	MessageRequestType = "https://didcomm.org/basicmessage/1.0/message"
+	MessageRequestType = MessageSpec + "message"
 
 	// error messages
 	errNameAndHandleMandatory = "service name and basic message handle is mandatory"
@@ -90,6 +93,11 @@ func (m *MessageService) Name() string {
 	return m.name
 }
 
+// Spec returns the specification URI of the basic message protocol.
+func (m *MessageService) Spec() string {
+	return MessageSpec
+}
+
 // Accept is acceptance criteria for this basic message service,
 func (m *MessageService) Accept(msgType string, purpose []string) bool {
 	return msgType == MessageRequestType
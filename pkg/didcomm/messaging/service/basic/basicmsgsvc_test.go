@@ -46,6 +46,7 @@ func TestMessageService_Name(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, svc)
 		require.Equal(t, svc.Name(), sampleName)
+		require.Equal(t, MessageSpec, svc.Spec())
 	})
 }
 
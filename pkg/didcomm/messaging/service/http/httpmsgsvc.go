@@ -109,6 +109,11 @@ func (m *OverDIDComm) Name() string {
 	return m.name
 }
 
+// Spec returns the specification URI of the HTTP over DIDComm protocol.
+func (m *OverDIDComm) Spec() string {
+	return OverDIDCommSpec
+}
+
 // Accept is acceptance criteria for this HTTP over DIDComm message service,
 // it accepts http-didcomm-over message type [RFC-0335] and follows `A tagging system` purpose field validation
 // from RFC-0351.
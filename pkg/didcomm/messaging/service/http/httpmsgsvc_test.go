@@ -64,6 +64,7 @@ func TestOverDIDComm_Name(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, svc)
 	require.Equal(t, sampleName, svc.Name())
+	require.Equal(t, OverDIDCommSpec, svc.Spec())
 }
 
 func TestOverDIDComm_Accept(t *testing.T) {
@@ -7,22 +7,35 @@ SPDX-License-Identifier: Apache-2.0
 package packager_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"testing"
 
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	. "github.com/hyperledger/aries-framework-go/pkg/didcomm/packager"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/jwe/anoncrypt"
 	jwe "github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/jwe/authcrypt"
 	legacy "github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/legacy/authcrypt"
 	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/mock/didcomm"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/internal/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/legacykms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
 	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 )
 
@@ -95,20 +108,23 @@ func TestBaseKMSInPackager_UnpackMessage(t *testing.T) {
 			primaryPacker: nil,
 			packers:       nil,
 		}
+		k := withLocalKMS(t, mockedProviders)
+
 		testPacker, err := jwe.New(mockedProviders, jwe.XC20P)
 		require.NoError(t, err)
 
-		// use a real testPacker with a mocked LegacyKMS to validate pack/unpack
+		// use a real testPacker with a local KMS to validate pack/unpack
 		mockedProviders.primaryPacker = testPacker
 		packager, err := New(mockedProviders)
 		require.NoError(t, err)
 
-		// fromKey is stored in the LegacyKMS
+		// fromKey is imported in the KMS
 		_, base58FromVerKey, err := w.CreateKeySet()
 		require.NoError(t, err)
+		importLegacyKeys(t, k, wp.storage)
 
-		// toVerKey is stored in the LegacyKMS as well
-		base58ToEncKey, base58ToVerKey, err := w.CreateKeySet()
+		// toVerKey is only stored in the LegacyKMS
+		_, base58ToVerKey, err := w.CreateKeySet()
 		require.NoError(t, err)
 
 		// PackMessage should pass with both value from and to verification keys
@@ -117,17 +133,15 @@ func TestBaseKMSInPackager_UnpackMessage(t *testing.T) {
 			ToVerKeys:  []string{base58ToVerKey}})
 		require.NoError(t, err)
 
-		// mock LegacyKMS without ToVerKey and ToEncKey then try UnpackMessage
-		delete(wp.storage.Store.Store, base58ToVerKey)
-		delete(wp.storage.Store.Store, base58ToEncKey)
-		// It should fail since Recipient keys are not found in the LegacyKMS
+		// It should fail since Recipient keys are not found in the KMS
 		_, err = packager.UnpackMessage(packMsg)
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "key not found")
+		require.Contains(t, err.Error(), "no recipient key found")
 	})
 
 	t.Run("test Pack/Unpack fails", func(t *testing.T) {
-		w, err := legacykms.New(newMockKMSProvider(mockstorage.NewMockStoreProvider()))
+		wp := newMockKMSProvider(mockstorage.NewMockStoreProvider())
+		w, err := legacykms.New(wp)
 		require.NoError(t, err)
 
 		decryptValue := func(envelope []byte) (*transport.Envelope, error) {
@@ -140,8 +154,9 @@ func TestBaseKMSInPackager_UnpackMessage(t *testing.T) {
 			primaryPacker: nil,
 			packers:       nil,
 		}
+		k := withLocalKMS(t, mockedProviders)
 
-		// use a mocked packager with a mocked LegacyKMS to validate pack/unpack
+		// use a mocked packager with a local KMS to validate pack/unpack
 		e := func(payload []byte, senderPubKey []byte, recipientsKeys [][]byte) (bytes []byte, e error) {
 			p, e := jwe.New(mockedProviders, jwe.XC20P)
 			require.NoError(t, e)
@@ -160,6 +175,7 @@ func TestBaseKMSInPackager_UnpackMessage(t *testing.T) {
 
 		_, base58ToVerKey, err := w.CreateKeySet()
 		require.NoError(t, err)
+		importLegacyKeys(t, k, wp.storage)
 
 		// try pack with nil envelope - should fail
 		packMsg, err := packager.PackMessage(nil)
@@ -197,7 +213,8 @@ func TestBaseKMSInPackager_UnpackMessage(t *testing.T) {
 
 	t.Run("test Pack/Unpack success", func(t *testing.T) {
 		// create a mock LegacyKMS with storage as a map
-		w, err := legacykms.New(newMockKMSProvider(mockstorage.NewMockStoreProvider()))
+		wp := newMockKMSProvider(mockstorage.NewMockStoreProvider())
+		w, err := legacykms.New(wp)
 		require.NoError(t, err)
 		mockedProviders := &mockProvider{
 			storage:       mockstorage.NewMockStoreProvider(),
@@ -205,6 +222,7 @@ func TestBaseKMSInPackager_UnpackMessage(t *testing.T) {
 			primaryPacker: nil,
 			packers:       nil,
 		}
+		k := withLocalKMS(t, mockedProviders)
 
 		// create a real testPacker (no mocking here)
 		testPacker, err := jwe.New(mockedProviders, jwe.XC20P)
@@ -224,6 +242,9 @@ func TestBaseKMSInPackager_UnpackMessage(t *testing.T) {
 		_, base58ToVerKey, err := w.CreateKeySet()
 		require.NoError(t, err)
 
+		// the legacy keys are imported in the KMS used by the JWE packer
+		importLegacyKeys(t, k, wp.storage)
+
 		// pack an non empty envelope - should pass
 		packMsg, err := packager.PackMessage(&transport.Envelope{Message: []byte("msg1"),
 			FromVerKey: base58.Decode(base58FromVerKey),
@@ -340,14 +361,258 @@ func TestBaseKMSInPackager_UnpackMessage(t *testing.T) {
 	})
 }
 
+func TestPackager_PackAnonymousMessage(t *testing.T) {
+	wp := newMockKMSProvider(mockstorage.NewMockStoreProvider())
+	w, err := legacykms.New(wp)
+	require.NoError(t, err)
+
+	mockedProviders := &mockProvider{
+		storage: mockstorage.NewMockStoreProvider(),
+		kms:     w,
+	}
+	k := withLocalKMS(t, mockedProviders)
+
+	jwePacker, err := jwe.New(mockedProviders, jwe.XC20P)
+	require.NoError(t, err)
+
+	anonPacker, err := anoncrypt.New(mockedProviders, anoncrypt.XC20P)
+	require.NoError(t, err)
+
+	_, base58FromVerKey, err := w.CreateKeySet()
+	require.NoError(t, err)
+
+	_, base58ToVerKey, err := w.CreateKeySet()
+	require.NoError(t, err)
+	importLegacyKeys(t, k, wp.storage)
+
+	t.Run("test anonymous message is packed with anoncrypt", func(t *testing.T) {
+		mockedProviders.primaryPacker = jwePacker
+		mockedProviders.packers = []packer.Packer{anonPacker}
+
+		packager, err := New(mockedProviders)
+		require.NoError(t, err)
+
+		packMsg, err := packager.PackMessage(&transport.Envelope{Message: []byte("msg1"),
+			ToVerKeys: []string{base58ToVerKey}})
+		require.NoError(t, err)
+		require.Equal(t, anoncrypt.EncodingType, encodingType(t, packMsg))
+
+		unpackedMsg, err := packager.UnpackMessage(packMsg)
+		require.NoError(t, err)
+		require.Equal(t, []byte("msg1"), unpackedMsg.Message)
+		require.Equal(t, base58ToVerKey, base58.Encode(unpackedMsg.ToVerKey))
+		require.Empty(t, unpackedMsg.FromVerKey)
+		require.Empty(t, unpackedMsg.FromDID)
+
+		// messages with a sender are still packed with the primary packer
+		packMsg, err = packager.PackMessage(&transport.Envelope{Message: []byte("msg2"),
+			FromVerKey: base58.Decode(base58FromVerKey),
+			ToVerKeys:  []string{base58ToVerKey}})
+		require.NoError(t, err)
+		require.Equal(t, jwePacker.EncodingType(), encodingType(t, packMsg))
+	})
+
+	t.Run("test anonymous message is packed with the primary packer without anoncrypt", func(t *testing.T) {
+		mockedProviders.primaryPacker = &didcomm.MockAuthCrypt{
+			EncryptValue: func(payload, senderPubKey []byte, recipients [][]byte) ([]byte, error) {
+				return []byte("primary"), nil
+			},
+		}
+		mockedProviders.packers = nil
+
+		packager, err := New(mockedProviders)
+		require.NoError(t, err)
+
+		packMsg, err := packager.PackMessage(&transport.Envelope{Message: []byte("msg1"),
+			ToVerKeys: []string{base58ToVerKey}})
+		require.NoError(t, err)
+		require.Equal(t, []byte("primary"), packMsg)
+	})
+}
+
+func TestPackager_PackUnpackDestination(t *testing.T) {
+	sender := newAgent(t)
+	receiver := newAgent(t)
+
+	_, senderKey, err := sender.kms.CreateKeySet()
+	require.NoError(t, err)
+
+	_, recipientKey, err := receiver.kms.CreateKeySet()
+	require.NoError(t, err)
+
+	didDoc := mockdiddoc.GetMockDIDDoc()
+	didDoc.PublicKey[1].Value = base58.Decode(recipientKey)
+
+	dest, err := service.CreateDestination(didDoc)
+	require.NoError(t, err)
+	require.Equal(t, []string{recipientKey}, dest.RecipientKeys)
+
+	for _, p := range sender.packers {
+		primaryPacker := p
+		t.Run(primaryPacker.EncodingType(), func(t *testing.T) {
+			sender.primaryPacker = primaryPacker
+
+			senderPackager, err := New(sender)
+			require.NoError(t, err)
+
+			receiverPackager, err := New(receiver)
+			require.NoError(t, err)
+
+			packMsg, err := senderPackager.PackMessage(&transport.Envelope{Message: []byte("msg"),
+				FromVerKey: base58.Decode(senderKey),
+				ToVerKeys:  dest.RecipientKeys})
+			require.NoError(t, err)
+			require.Equal(t, primaryPacker.EncodingType(), encodingType(t, packMsg))
+
+			unpackedMsg, err := receiverPackager.UnpackMessage(packMsg)
+			require.NoError(t, err)
+			require.Equal(t, []byte("msg"), unpackedMsg.Message)
+			require.Equal(t, recipientKey, base58.Encode(unpackedMsg.ToVerKey))
+
+			if primaryPacker.EncodingType() == anoncrypt.EncodingType {
+				// anonymous envelopes carry no sender key
+				require.Empty(t, unpackedMsg.FromVerKey)
+				return
+			}
+
+			require.Equal(t, senderKey, base58.Encode(unpackedMsg.FromVerKey))
+		})
+	}
+
+	t.Run("P-256 keys with the JWE authcrypt packer", func(t *testing.T) {
+		senderP256Key := createP256Key(t, sender)
+		recipientP256Key := createP256Key(t, receiver)
+
+		p256DIDDoc := mockdiddoc.GetMockDIDDoc()
+		p256DIDDoc.PublicKey[1].Type = "EcdsaSecp256r1VerificationKey2019"
+		p256DIDDoc.PublicKey[1].Value = recipientP256Key
+
+		p256Dest, err := service.CreateDestination(p256DIDDoc)
+		require.NoError(t, err)
+		require.Equal(t, []string{base58.Encode(recipientP256Key)}, p256Dest.RecipientKeys)
+
+		sender.primaryPacker = sender.packers[1]
+		require.Equal(t, "prs.hyperledger.aries-auth-message", sender.primaryPacker.EncodingType())
+
+		senderPackager, err := New(sender)
+		require.NoError(t, err)
+
+		receiverPackager, err := New(receiver)
+		require.NoError(t, err)
+
+		packMsg, err := senderPackager.PackMessage(&transport.Envelope{Message: []byte("msg"),
+			FromVerKey: senderP256Key,
+			ToVerKeys:  p256Dest.RecipientKeys})
+		require.NoError(t, err)
+
+		unpackedMsg, err := receiverPackager.UnpackMessage(packMsg)
+		require.NoError(t, err)
+		require.Equal(t, []byte("msg"), unpackedMsg.Message)
+		require.Equal(t, recipientP256Key, unpackedMsg.ToVerKey)
+		require.Equal(t, senderP256Key, unpackedMsg.FromVerKey)
+	})
+}
+
+// createP256Key creates a P-256 key in the local KMS of agent identified by its base58 encoded uncompressed public
+// key, and returns the public key
+func createP256Key(t *testing.T, agent *mockProvider) []byte {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	pub := elliptic.Marshal(elliptic.P256(), priv.X, priv.Y)
+
+	_, _, err = agent.keyManager.(*localkms.LocalKMS).ImportPrivateKey(base58.Encode(pub), "ECDHP256", priv.D.Bytes())
+	require.NoError(t, err)
+
+	return pub
+}
+
+// newAgent creates a provider with the legacy, JWE authcrypt and anoncrypt packers and a LegacyKMS writing its
+// key sets through to a local KMS, as done by the framework
+func newAgent(t *testing.T) *mockProvider {
+	t.Helper()
+
+	agent := &mockProvider{storage: mockstorage.NewMockStoreProvider()}
+	k := withLocalKMS(t, agent)
+
+	w, err := legacykms.New(agent, legacykms.WithKeyImporter(k))
+	require.NoError(t, err)
+
+	agent.kms = w
+
+	jwePacker, err := jwe.New(agent, jwe.XC20P)
+	require.NoError(t, err)
+
+	anonPacker, err := anoncrypt.New(agent, anoncrypt.XC20P)
+	require.NoError(t, err)
+
+	agent.packers = []packer.Packer{legacy.New(agent), jwePacker, anonPacker}
+	agent.primaryPacker = agent.packers[0]
+
+	return agent
+}
+
+func encodingType(t *testing.T, packMsg []byte) string {
+	t.Helper()
+
+	env := &struct {
+		Protected string `json:"protected"`
+	}{}
+	require.NoError(t, json.Unmarshal(packMsg, env))
+
+	protected, err := base64.URLEncoding.DecodeString(env.Protected)
+	if err != nil {
+		protected, err = base64.RawURLEncoding.DecodeString(env.Protected)
+		require.NoError(t, err)
+	}
+
+	headers := &struct {
+		Typ string `json:"typ"`
+	}{}
+	require.NoError(t, json.Unmarshal(protected, headers))
+
+	return headers.Typ
+}
+
 func newMockKMSProvider(storagePvdr *mockstorage.MockStoreProvider) *mockProvider {
-	return &mockProvider{storagePvdr, nil, nil, nil, nil}
+	return &mockProvider{storage: storagePvdr}
+}
+
+// withLocalKMS sets a local KMS and a tink Crypto service in the provider p
+func withLocalKMS(t *testing.T, p *mockProvider) *localkms.LocalKMS {
+	t.Helper()
+
+	k, err := localkms.New("local-lock://test/key/uri", &mockprovider.Provider{
+		StorageProviderValue: mockstorage.NewMockStoreProvider(),
+		SecretLockValue:      &noop.NoLock{},
+	})
+	require.NoError(t, err)
+
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	p.keyManager = k
+	p.crypto = c
+
+	return k
+}
+
+// importLegacyKeys imports the key pairs of the LegacyKMS storage in the local KMS k
+func importLegacyKeys(t *testing.T, k *localkms.LocalKMS, legacyStorage storage.Provider) {
+	t.Helper()
+
+	_, err := k.ImportLegacyKeys(legacyStorage)
+	require.NoError(t, err)
 }
 
 // mockProvider mocks provider for LegacyKMS
 type mockProvider struct {
 	storage       *mockstorage.MockStoreProvider
 	kms           legacykms.KeyManager
+	keyManager    kms.KeyManager
+	crypto        crypto.Crypto
 	packers       []packer.Packer
 	primaryPacker packer.Packer
 	vdriRegistry  vdriapi.Registry
@@ -361,6 +626,14 @@ func (m *mockProvider) LegacyKMS() legacykms.KeyManager {
 	return m.kms
 }
 
+func (m *mockProvider) KMS() kms.KeyManager {
+	return m.keyManager
+}
+
+func (m *mockProvider) Crypto() crypto.Crypto {
+	return m.crypto
+}
+
 func (m *mockProvider) StorageProvider() storage.Provider {
 	return m.storage
 }
@@ -16,6 +16,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/jwe/anoncrypt"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/store/did"
@@ -79,6 +80,8 @@ func (bp *Packager) addPacker(pack packer.Packer) {
 }
 
 // PackMessage Pack a message for one or more recipients.
+// Envelopes without a sender key (eg. forward messages) are packed with the anoncrypt packer if
+// it is registered, the primary packer is used otherwise.
 func (bp *Packager) PackMessage(messageEnvelope *transport.Envelope) ([]byte, error) {
 	if messageEnvelope == nil {
 		return nil, errors.New("envelope argument is nil")
@@ -97,8 +100,14 @@ func (bp *Packager) PackMessage(messageEnvelope *transport.Envelope) ([]byte, er
 		// create 32 byte key
 		recipients = append(recipients, verKeyBytes)
 	}
+	p := bp.primaryPacker
+
+	if anonPacker, ok := bp.packers[anoncrypt.EncodingType]; ok && len(messageEnvelope.FromVerKey) == 0 {
+		p = anonPacker
+	}
+
 	// pack message
-	bytes, err := bp.primaryPacker.Pack(messageEnvelope.Message, messageEnvelope.FromVerKey, recipients)
+	bytes, err := p.Pack(messageEnvelope.Message, messageEnvelope.FromVerKey, recipients)
 	if err != nil {
 		return nil, fmt.Errorf("pack: %w", err)
 	}
@@ -163,11 +172,16 @@ func (bp *Packager) UnpackMessage(encMessage []byte) (*transport.Envelope, error
 		return nil, fmt.Errorf("unpack: %w", err)
 	}
 
-	//	ignore error - agents can communicate without using DIDs - for example, in DIDExchange
-	theirDID, err := bp.connectionStore.GetDID(base58.Encode(envelope.FromVerKey))
-	if errors.Is(err, did.ErrNotFound) {
-	} else if err != nil {
-		return nil, fmt.Errorf("failed to get their did: %w", err)
+	var theirDID string
+
+	// anonymous envelopes (eg. anoncrypt) don't reveal the sender
+	if len(envelope.FromVerKey) != 0 {
+		//	ignore error - agents can communicate without using DIDs - for example, in DIDExchange
+		theirDID, err = bp.connectionStore.GetDID(base58.Encode(envelope.FromVerKey))
+		if errors.Is(err, did.ErrNotFound) {
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to get their did: %w", err)
+		}
 	}
 
 	// ignore error - at beginning of DIDExchange, you might be about to generate a DID
@@ -0,0 +1,131 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package anoncrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+
+	chacha "golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// This package deals with Anoncrypt encryption for Packing/Unpacking DID Comm exchange
+// Using ECDH-ES key agreement with AES-256 key wrapping (ECDH-ES+A256KW) for each recipient
+// and XChacha20Poly1305 or AES-256-GCM content encryption. The recipients keys are fetched from the KMS
+// as key handles and the key wrapping is executed by the Crypto service.
+
+// ContentEncryption represents a content encryption algorithm.
+type ContentEncryption string
+
+const (
+	// XC20P XChacha20Poly1305 algorithm
+	XC20P = ContentEncryption("XC20P") // XChacha20 encryption + Poly1305 authenticator cipher (192 bits nonce)
+	// A256GCM AES-256-GCM algorithm
+	A256GCM = ContentEncryption("A256GCM") // AES-256 encryption in Galois/Counter Mode (96 bits nonce)
+	// EncodingType is the `typ` string identifier in a message that identifies the format as being anoncrypt JWE
+	EncodingType string = "prs.hyperledger.aries-anon-message"
+	// keyWrapAlg is the key management algorithm used to encrypt the cek for each recipient
+	keyWrapAlg = "ECDH-ES+A256KW"
+	// cekSize is the size of the content encryption key (256 bits for both XC20P and A256GCM)
+	cekSize = 32
+)
+
+// errUnsupportedAlg is used when a bad encryption algorithm is used
+var errUnsupportedAlg = errors.New("algorithm not supported")
+
+// Packer represents an Anoncrypt Packer/Unpacker that outputs/reads JWE envelopes without revealing the sender
+type Packer struct {
+	alg        ContentEncryption
+	kms        kms.KeyManager
+	crypto     crypto.Crypto
+	randReader io.Reader
+}
+
+// Envelope represents a JWE envelope as per the JWE JSON serialization
+// https://tools.ietf.org/html/rfc7516#section-7.2.1
+type Envelope struct {
+	Protected  string      `json:"protected,omitempty"`
+	Recipients []Recipient `json:"recipients,omitempty"`
+	IV         string      `json:"iv,omitempty"`
+	Tag        string      `json:"tag,omitempty"`
+	CipherText string      `json:"ciphertext,omitempty"`
+}
+
+// jweHeaders are the Protected JWE headers
+type jweHeaders struct {
+	Typ string `json:"typ,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Enc string `json:"enc,omitempty"`
+}
+
+// Recipient is a recipient of an envelope including the wrapped content encryption key
+type Recipient struct {
+	EncryptedKey string           `json:"encrypted_key,omitempty"`
+	Header       RecipientHeaders `json:"header,omitempty"`
+}
+
+// RecipientHeaders are the recipient headers
+type RecipientHeaders struct {
+	KID string `json:"kid,omitempty"`
+	EPK JWK    `json:"epk,omitempty"`
+}
+
+// JWK is the ephemeral public key of a recipient in JWK format
+type JWK struct {
+	Kty string `json:"kty,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// New will create a Packer instance to 'AnonCrypt' payloads for the given recipients and the content
+// encryption alg argument. Possible algorithms supported are:
+// XC20P (xchacha20-poly1305 ietf)
+// A256GCM (aes-256-gcm)
+// Envelopes are unpacked with the content encryption algorithm found in their headers.
+// The keys of the recipients are fetched from the KMS of ctx by their base58 encoded verification key.
+func New(ctx packer.Provider, alg ContentEncryption) (*Packer, error) {
+	if _, err := createCipher(alg, make([]byte, cekSize)); err != nil {
+		return nil, err
+	}
+
+	return &Packer{
+		alg:        alg,
+		kms:        ctx.KMS(),
+		crypto:     ctx.Crypto(),
+		randReader: rand.Reader,
+	}, nil
+}
+
+// EncodingType returns the type of the encoding, as in the `Typ` field of the envelope header
+func (p *Packer) EncodingType() string {
+	return EncodingType
+}
+
+// createCipher will create and return a new AEAD cipher for the given content encryption alg and cek
+func createCipher(alg ContentEncryption, cek []byte) (cipher.AEAD, error) {
+	switch alg {
+	case XC20P:
+		return chacha.NewX(cek)
+	case A256GCM:
+		block, err := aes.NewCipher(cek)
+		if err != nil {
+			return nil, err
+		}
+
+		return cipher.NewGCM(block)
+	default:
+		return nil, errUnsupportedAlg
+	}
+}
@@ -0,0 +1,458 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package anoncrypt
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/internal/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+// newProvider creates a provider with a local KMS and a tink Crypto service
+func newProvider(t *testing.T) *mockprovider.Provider {
+	t.Helper()
+
+	storeProvider := mockstorage.NewMockStoreProvider()
+
+	k, err := localkms.New("local-lock://test/key/uri", &mockprovider.Provider{
+		StorageProviderValue: storeProvider,
+		SecretLockValue:      &noop.NoLock{},
+	})
+	require.NoError(t, err)
+
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	return &mockprovider.Provider{
+		StorageProviderValue: storeProvider,
+		KeyManagerValue:      k,
+		CryptoValue:          c,
+	}
+}
+
+// createRecipients creates count ed25519 verification keys and imports their encryption key in the KMS of prov,
+// identified by the base58 encoded verification key
+func createRecipients(t *testing.T, prov *mockprovider.Provider, count int) [][]byte {
+	t.Helper()
+
+	var verKeys [][]byte
+
+	for i := 0; i < count; i++ {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		encPriv, err := cryptoutil.SecretEd25519toCurve25519(priv)
+		require.NoError(t, err)
+
+		_, _, err = prov.KMS().(*localkms.LocalKMS).ImportPrivateKey(base58.Encode(pub), "ECDHX25519", encPriv)
+		require.NoError(t, err)
+
+		verKeys = append(verKeys, pub)
+	}
+
+	return verKeys
+}
+
+// createNISTRecipients creates count ECDSA keys on crv and imports them in the KMS of prov as ECDH keys of keyType,
+// identified by the base58 encoded uncompressed public key
+func createNISTRecipients(t *testing.T, prov *mockprovider.Provider, crv elliptic.Curve, keyType string,
+	count int) [][]byte {
+	t.Helper()
+
+	var pubKeys [][]byte
+
+	for i := 0; i < count; i++ {
+		priv, err := ecdsa.GenerateKey(crv, rand.Reader)
+		require.NoError(t, err)
+
+		pub := elliptic.Marshal(crv, priv.X, priv.Y)
+
+		_, _, err = prov.KMS().(*localkms.LocalKMS).ImportPrivateKey(base58.Encode(pub), keyType, priv.D.Bytes())
+		require.NoError(t, err)
+
+		pubKeys = append(pubKeys, pub)
+	}
+
+	return pubKeys
+}
+
+func TestNew(t *testing.T) {
+	prov := newProvider(t)
+
+	packer, err := New(prov, XC20P)
+	require.NoError(t, err)
+	require.Equal(t, EncodingType, packer.EncodingType())
+
+	_, err = New(prov, A256GCM)
+	require.NoError(t, err)
+
+	_, err = New(prov, "C20P")
+	require.EqualError(t, err, "algorithm not supported")
+}
+
+func TestPackUnpack(t *testing.T) {
+	for _, alg := range []ContentEncryption{XC20P, A256GCM} {
+		contentEncryption := alg
+		t.Run(string(contentEncryption), func(t *testing.T) {
+			senderProv := newProvider(t)
+			recProv := newProvider(t)
+
+			recipients := createRecipients(t, recProv, 3)
+
+			sender, err := New(senderProv, contentEncryption)
+			require.NoError(t, err)
+
+			payload := []byte("secret message")
+
+			envelope, err := sender.Pack(payload, nil, recipients)
+			require.NoError(t, err)
+
+			jwe := &Envelope{}
+			require.NoError(t, json.Unmarshal(envelope, jwe))
+			require.Len(t, jwe.Recipients, 3)
+
+			headers, err := decodeHeaders(jwe.Protected)
+			require.NoError(t, err)
+			require.Equal(t, EncodingType, headers.Typ)
+			require.Equal(t, keyWrapAlg, headers.Alg)
+			require.Equal(t, string(contentEncryption), headers.Enc)
+
+			for i, rec := range jwe.Recipients {
+				require.Equal(t, base58.Encode(recipients[i]), rec.Header.KID)
+				require.Equal(t, "OKP", rec.Header.EPK.Kty)
+				require.Equal(t, "X25519", rec.Header.EPK.Crv)
+				require.NotEmpty(t, rec.Header.EPK.X)
+			}
+
+			// a packer configured with another content encryption can unpack the envelope
+			receiver, err := New(recProv, XC20P)
+			require.NoError(t, err)
+
+			msg, err := receiver.Unpack(envelope)
+			require.NoError(t, err)
+			require.Equal(t, payload, msg.Message)
+			require.Equal(t, recipients[0], msg.ToVerKey)
+			require.Empty(t, msg.FromVerKey)
+
+			// the sender can't unpack the envelope
+			_, err = sender.Unpack(envelope)
+			require.Error(t, err)
+			require.True(t, errors.Is(err, errRecipientNotFound))
+		})
+	}
+
+	t.Run("unpack for each recipient", func(t *testing.T) {
+		prov1 := newProvider(t)
+		prov2 := newProvider(t)
+
+		rec1 := createRecipients(t, prov1, 1)[0]
+		rec2 := createRecipients(t, prov2, 1)[0]
+
+		packer1, err := New(prov1, XC20P)
+		require.NoError(t, err)
+
+		packer2, err := New(prov2, XC20P)
+		require.NoError(t, err)
+
+		envelope, err := packer1.Pack([]byte("message"), nil, [][]byte{rec1, rec2})
+		require.NoError(t, err)
+
+		msg, err := packer1.Unpack(envelope)
+		require.NoError(t, err)
+		require.Equal(t, rec1, msg.ToVerKey)
+
+		msg, err = packer2.Unpack(envelope)
+		require.NoError(t, err)
+		require.Equal(t, rec2, msg.ToVerKey)
+		require.Equal(t, []byte("message"), msg.Message)
+	})
+}
+
+func TestPackUnpack_NIST(t *testing.T) {
+	tests := []struct {
+		curve   elliptic.Curve
+		keyType string
+	}{
+		{curve: elliptic.P256(), keyType: "ECDHP256"},
+		{curve: elliptic.P384(), keyType: "ECDHP384"},
+		{curve: elliptic.P521(), keyType: "ECDHP521"},
+	}
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.curve.Params().Name, func(t *testing.T) {
+			recProv := newProvider(t)
+			recipients := createNISTRecipients(t, recProv, tc.curve, tc.keyType, 2)
+
+			sender, err := New(newProvider(t), XC20P)
+			require.NoError(t, err)
+
+			payload := []byte("secret message")
+
+			envelope, err := sender.Pack(payload, nil, recipients)
+			require.NoError(t, err)
+
+			jwe := &Envelope{}
+			require.NoError(t, json.Unmarshal(envelope, jwe))
+			require.Len(t, jwe.Recipients, 2)
+
+			for i, rec := range jwe.Recipients {
+				require.Equal(t, base58.Encode(recipients[i]), rec.Header.KID)
+				require.Equal(t, "EC", rec.Header.EPK.Kty)
+				require.Equal(t, tc.curve.Params().Name, rec.Header.EPK.Crv)
+				require.NotEmpty(t, rec.Header.EPK.Y)
+			}
+
+			receiver, err := New(recProv, XC20P)
+			require.NoError(t, err)
+
+			msg, err := receiver.Unpack(envelope)
+			require.NoError(t, err)
+			require.Equal(t, payload, msg.Message)
+			require.Equal(t, recipients[0], msg.ToVerKey)
+			require.Empty(t, msg.FromVerKey)
+		})
+	}
+
+	t.Run("recipients on different curves", func(t *testing.T) {
+		recProv := newProvider(t)
+		recipients := append(createRecipients(t, recProv, 1),
+			createNISTRecipients(t, recProv, elliptic.P256(), "ECDHP256", 1)...)
+
+		packer, err := New(recProv, A256GCM)
+		require.NoError(t, err)
+
+		envelope, err := packer.Pack([]byte("message"), nil, recipients)
+		require.NoError(t, err)
+
+		msg, err := packer.Unpack(envelope)
+		require.NoError(t, err)
+		require.Equal(t, []byte("message"), msg.Message)
+	})
+}
+
+func TestPack_Failure(t *testing.T) {
+	prov := newProvider(t)
+
+	packer, err := New(prov, XC20P)
+	require.NoError(t, err)
+
+	recipients := createRecipients(t, prov, 1)
+
+	t.Run("empty recipients", func(t *testing.T) {
+		_, err = packer.Pack([]byte("message"), nil, nil)
+		require.EqualError(t, err, "failed to pack message: empty recipients")
+	})
+
+	t.Run("invalid recipient key", func(t *testing.T) {
+		_, err = packer.Pack([]byte("message"), nil, [][]byte{recipients[0], []byte("invalid")})
+		require.Error(t, err)
+		require.True(t, errors.Is(err, cryptoutil.ErrInvalidKey))
+		require.Contains(t, err.Error(), "for recipient 2")
+	})
+
+	t.Run("wrap key failure", func(t *testing.T) {
+		p, e := New(&mockprovider.Provider{
+			KeyManagerValue: prov.KMS(),
+			CryptoValue:     &mockcrypto.Crypto{WrapError: errors.New("wrap error")},
+		}, XC20P)
+		require.NoError(t, e)
+
+		_, e = p.Pack([]byte("message"), nil, recipients)
+		require.EqualError(t, e, "failed to pack message: wrap error")
+	})
+
+	t.Run("rand reader failures", func(t *testing.T) {
+		// the reader is used for the cek then the nonce
+		for i := 0; i < 2; i++ {
+			packer.randReader = &failingReader{failAfter: i}
+
+			_, err = packer.Pack([]byte("message"), nil, recipients)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "read error")
+		}
+	})
+}
+
+func TestUnpack_Failure(t *testing.T) {
+	prov := newProvider(t)
+
+	packer, err := New(prov, A256GCM)
+	require.NoError(t, err)
+
+	envelope, err := packer.Pack([]byte("message"), nil, createRecipients(t, prov, 1))
+	require.NoError(t, err)
+
+	// unpack modifies a valid envelope with update and returns the Unpack error
+	unpack := func(update func(jwe *Envelope)) error {
+		jwe := &Envelope{}
+		require.NoError(t, json.Unmarshal(envelope, jwe))
+
+		update(jwe)
+
+		env, e := json.Marshal(jwe)
+		require.NoError(t, e)
+
+		_, e = packer.Unpack(env)
+
+		return e
+	}
+
+	protected := func(h *jweHeaders) string {
+		b, e := json.Marshal(h)
+		require.NoError(t, e)
+
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	tests := []struct {
+		name   string
+		update func(jwe *Envelope)
+		errMsg string
+	}{
+		{
+			name:   "invalid protected headers encoding",
+			update: func(jwe *Envelope) { jwe.Protected = "!" },
+			errMsg: "unpack: decode headers",
+		},
+		{
+			name:   "invalid protected headers",
+			update: func(jwe *Envelope) { jwe.Protected = base64.RawURLEncoding.EncodeToString([]byte("{")) },
+			errMsg: "unpack: parse headers",
+		},
+		{
+			name: "unsupported key wrapping algorithm",
+			update: func(jwe *Envelope) {
+				jwe.Protected = protected(&jweHeaders{Typ: EncodingType, Alg: "ECDH-SS+XC20PKW", Enc: "XC20P"})
+			},
+			errMsg: "unpack: algorithm not supported: ECDH-SS+XC20PKW",
+		},
+		{
+			name: "unsupported content encryption",
+			update: func(jwe *Envelope) {
+				jwe.Protected = protected(&jweHeaders{Typ: EncodingType, Alg: keyWrapAlg, Enc: "C20P"})
+			},
+			errMsg: "unpack: decrypt payload: algorithm not supported",
+		},
+		{
+			name:   "recipient not found",
+			update: func(jwe *Envelope) { jwe.Recipients[0].Header.KID = base58.Encode(bytes.Repeat([]byte{1}, 32)) },
+			errMsg: "unpack: " + errRecipientNotFound.Error(),
+		},
+		{
+			name:   "unsupported epk",
+			update: func(jwe *Envelope) { jwe.Recipients[0].Header.EPK.Crv = "P-256" },
+			errMsg: "unpack: decrypt cek: unsupported epk: OKP P-256",
+		},
+		{
+			name: "invalid epk y",
+			update: func(jwe *Envelope) {
+				jwe.Recipients[0].Header.EPK = JWK{Kty: "EC", Crv: "P-256", X: "", Y: "!"}
+			},
+			errMsg: "unpack: decrypt cek: illegal base64 data",
+		},
+		{
+			name:   "invalid epk",
+			update: func(jwe *Envelope) { jwe.Recipients[0].Header.EPK.X = "!" },
+			errMsg: "unpack: decrypt cek: illegal base64 data",
+		},
+		{
+			name:   "invalid encrypted key encoding",
+			update: func(jwe *Envelope) { jwe.Recipients[0].EncryptedKey = "!" },
+			errMsg: "unpack: decrypt cek: illegal base64 data",
+		},
+		{
+			name: "invalid encrypted key",
+			update: func(jwe *Envelope) {
+				jwe.Recipients[0].EncryptedKey = base64.RawURLEncoding.EncodeToString(make([]byte, 40))
+			},
+			errMsg: "unpack: decrypt cek",
+		},
+		{
+			name:   "invalid cipher text",
+			update: func(jwe *Envelope) { jwe.CipherText = "!" },
+			errMsg: "unpack: decrypt payload: illegal base64 data",
+		},
+		{
+			name:   "invalid tag",
+			update: func(jwe *Envelope) { jwe.Tag = "!" },
+			errMsg: "unpack: decrypt payload: illegal base64 data",
+		},
+		{
+			name:   "invalid iv",
+			update: func(jwe *Envelope) { jwe.IV = "!" },
+			errMsg: "unpack: decrypt payload: illegal base64 data",
+		},
+		{
+			name:   "bad nonce size",
+			update: func(jwe *Envelope) { jwe.IV = base64.RawURLEncoding.EncodeToString([]byte("nonce")) },
+			errMsg: "unpack: decrypt payload: bad nonce size",
+		},
+		{
+			name:   "tampered cipher text",
+			update: func(jwe *Envelope) { jwe.CipherText = base64.RawURLEncoding.EncodeToString([]byte("tampered")) },
+			errMsg: "unpack: decrypt payload: cipher: message authentication failed",
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			err := unpack(tc.update)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+
+	t.Run("invalid envelope", func(t *testing.T) {
+		_, err := packer.Unpack([]byte("invalid"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unpack json")
+	})
+
+	t.Run("unwrap key failure", func(t *testing.T) {
+		p, e := New(&mockprovider.Provider{
+			KeyManagerValue: prov.KMS(),
+			CryptoValue:     &mockcrypto.Crypto{UnwrapError: errors.New("unwrap error")},
+		}, A256GCM)
+		require.NoError(t, e)
+
+		_, e = p.Unpack(envelope)
+		require.EqualError(t, e, "unpack: decrypt cek: unwrap error")
+	})
+}
+
+type failingReader struct {
+	failAfter int
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.failAfter == 0 {
+		return 0, errors.New("read error")
+	}
+
+	r.failAfter--
+
+	return len(p), nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package anoncrypt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/jwe/internal/ecdhkey"
+)
+
+// Pack will JWE encode the payload argument for the recipients without revealing the sender.
+// The senderKey argument is ignored. A new content encryption key is wrapped by the Crypto service for each
+// recipient with ECDH-ES+A256KW using an ephemeral key agreed with the recipient's encryption key converted
+// from recipientsVerKeys. The keys are either Ed25519 verification keys (X25519 key agreement) or NIST P-256,
+// P-384 or P-521 keys in uncompressed form.
+func (p *Packer) Pack(payload, _ []byte, recipientsVerKeys [][]byte) ([]byte, error) {
+	if len(recipientsVerKeys) == 0 {
+		return nil, errors.New("failed to pack message: empty recipients")
+	}
+
+	h, err := json.Marshal(jweHeaders{
+		Typ: EncodingType,
+		Alg: keyWrapAlg,
+		Enc: string(p.alg),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	encHeaders := base64.RawURLEncoding.EncodeToString(h)
+
+	cek := make([]byte, cekSize)
+
+	// generate a cek for encryption (it will be treated as a symmetric key)
+	_, err = p.randReader.Read(cek)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients, err := p.encodeRecipients(cek, recipientsVerKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack message: %w", err)
+	}
+
+	cipher, err := createCipher(p.alg, cek)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, cipher.NonceSize())
+
+	_, err = p.randReader.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	// encrypt payload using generated nonce and the protected headers as AAD
+	// the output is a []byte containing the cipherText + tag
+	symOutput := cipher.Seal(nil, nonce, payload, []byte(encHeaders))
+	tagIndex := len(symOutput) - cipher.Overhead()
+
+	return json.Marshal(&Envelope{
+		Protected:  encHeaders,
+		Recipients: recipients,
+		IV:         base64.RawURLEncoding.EncodeToString(nonce),
+		Tag:        base64.RawURLEncoding.EncodeToString(symOutput[tagIndex:]),
+		CipherText: base64.RawURLEncoding.EncodeToString(symOutput[:tagIndex]),
+	})
+}
+
+// encodeRecipients will wrap the cek (content encryption key) for each recipient
+// and return a list of recipients in a JWE compliant format ([]Recipient)
+func (p *Packer) encodeRecipients(cek []byte, recipientsVerKeys [][]byte) ([]Recipient, error) {
+	var recipients []Recipient
+
+	for i, verKey := range recipientsVerKeys {
+		recPubKey, err := ecdhkey.PublicKey(verKey)
+		if err != nil {
+			return nil, fmt.Errorf("%w - for recipient %d", err, i+1)
+		}
+
+		rec, err := p.encodeRecipient(cek, recPubKey)
+		if err != nil {
+			return nil, err
+		}
+
+		recipients = append(recipients, *rec)
+	}
+
+	return recipients, nil
+}
+
+// encodeRecipient will wrap the cek with ECDH-ES using the recipient's public key and a new ephemeral key
+// generated by the Crypto service. The ephemeral public key is set in the recipient's `epk` header.
+func (p *Packer) encodeRecipient(cek []byte, recPubKey *crypto.PublicKey) (*Recipient, error) {
+	wk, err := p.crypto.WrapKey(cek, nil, nil, recPubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	epk, err := ecdhkey.ToJWK(&wk.EPK)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Recipient{
+		EncryptedKey: base64.RawURLEncoding.EncodeToString(wk.EncryptedCEK),
+		Header: RecipientHeaders{
+			KID: recPubKey.KID,
+			EPK: JWK(*epk),
+		},
+	}, nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package anoncrypt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/jwe/internal/ecdhkey"
+)
+
+// errRecipientNotFound is returned when none of the envelope's recipients has a key in the KMS
+var errRecipientNotFound = errors.New("no recipient key found in the KMS")
+
+// Unpack will JWE decode the envelope argument for the first recipient found in the KMS.
+// The content encryption algorithm is read from the envelope's protected headers.
+// The returned envelope has no sender key since anoncrypt envelopes don't reveal the sender.
+func (p *Packer) Unpack(envelope []byte) (*transport.Envelope, error) {
+	jwe := &Envelope{}
+
+	err := json.Unmarshal(envelope, jwe)
+	if err != nil {
+		return nil, fmt.Errorf("unpack json: %w", err)
+	}
+
+	headers, err := decodeHeaders(jwe.Protected)
+	if err != nil {
+		return nil, fmt.Errorf("unpack: %w", err)
+	}
+
+	recKH, recipient, err := p.findRecipient(jwe.Recipients)
+	if err != nil {
+		return nil, fmt.Errorf("unpack: %w", err)
+	}
+
+	cek, err := p.decryptCEK(recKH, recipient)
+	if err != nil {
+		return nil, fmt.Errorf("unpack: decrypt cek: %w", err)
+	}
+
+	payload, err := decryptPayload(ContentEncryption(headers.Enc), cek, jwe)
+	if err != nil {
+		return nil, fmt.Errorf("unpack: decrypt payload: %w", err)
+	}
+
+	return &transport.Envelope{
+		Message:  payload,
+		ToVerKey: base58.Decode(recipient.Header.KID),
+	}, nil
+}
+
+func decodeHeaders(protected string) (*jweHeaders, error) {
+	h, err := base64.RawURLEncoding.DecodeString(protected)
+	if err != nil {
+		return nil, fmt.Errorf("decode headers: %w", err)
+	}
+
+	headers := &jweHeaders{}
+
+	err = json.Unmarshal(h, headers)
+	if err != nil {
+		return nil, fmt.Errorf("parse headers: %w", err)
+	}
+
+	if headers.Alg != keyWrapAlg {
+		return nil, fmt.Errorf("%w: %s", errUnsupportedAlg, headers.Alg)
+	}
+
+	return headers, nil
+}
+
+// findRecipient will loop through jweRecipients and returns the key handle of the first recipient
+// found in the KMS
+func (p *Packer) findRecipient(jweRecipients []Recipient) (interface{}, *Recipient, error) {
+	for i := range jweRecipients {
+		kh, err := p.kms.Get(jweRecipients[i].Header.KID)
+		if err == nil {
+			return kh, &jweRecipients[i], nil
+		}
+	}
+
+	return nil, nil, errRecipientNotFound
+}
+
+// decryptCEK will unwrap the cek found in recipient with ECDH-ES using the recipient's key handle recKH
+// and the ephemeral public key from the recipient's `epk` header
+func (p *Packer) decryptCEK(recKH interface{}, recipient *Recipient) ([]byte, error) {
+	epk, err := ecdhkey.FromJWK((*ecdhkey.JWK)(&recipient.Header.EPK))
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedKey, err := base64.RawURLEncoding.DecodeString(recipient.EncryptedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.crypto.UnwrapKey(&crypto.RecipientWrappedKey{
+		KID:          recipient.Header.KID,
+		EncryptedCEK: encryptedKey,
+		EPK:          *epk,
+		Alg:          keyWrapAlg,
+	}, recKH)
+}
+
+func decryptPayload(alg ContentEncryption, cek []byte, jwe *Envelope) ([]byte, error) {
+	cipher, err := createCipher(alg, cek)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(jwe.CipherText)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := base64.RawURLEncoding.DecodeString(jwe.Tag)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := base64.RawURLEncoding.DecodeString(jwe.IV)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nonce) != cipher.NonceSize() {
+		return nil, errors.New("bad nonce size")
+	}
+
+	payload = append(payload, tag...)
+
+	return cipher.Open(nil, nonce, payload, []byte(jwe.Protected))
+}
@@ -11,14 +11,17 @@ import (
 	"errors"
 	"io"
 
-	chacha "golang.org/x/crypto/chacha20poly1305"
-
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
-	"github.com/hyperledger/aries-framework-go/pkg/kms/legacykms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
 // This package deals with Authcrypt encryption for Packing/Unpacking DID Comm exchange
-// Using Chacha20Poly1305 encryption/authentication
+// Using ECDH-1PU key agreement with AES-256 key wrapping (ECDH-1PU+A256KW) for each recipient
+// and Chacha20Poly1305 content encryption. The keys are fetched from the KMS as key handles
+// and the key wrapping is executed by the Crypto service.
+// The sender's key ID is not part of the shared headers, it is encrypted for each recipient (`spk` header)
+// with ECDH-ES+A256KW so that intermediaries can't find out who sent the envelope.
 
 // ContentEncryption represents a content encryption algorithm.
 type ContentEncryption string
@@ -30,11 +33,18 @@ const (
 	XC20P = ContentEncryption("XC20P") // XChacha20 encryption + Poly1305 authenticator cipher (192 bits nonce)
 	// encodingType is the `typ` string identifier in a message that identifies the format as being JWE
 	encodingType string = "prs.hyperledger.aries-auth-message"
+	// keyWrapAlg is the key management algorithm used to encrypt the cek for each recipient
+	keyWrapAlg = "ECDH-1PU+A256KW"
+	// spkWrapAlg is the key management algorithm used to encrypt the sender key ID for each recipient
+	spkWrapAlg = "ECDH-ES+A256KW"
 )
 
 // errUnsupportedAlg is used when a bad encryption algorithm is used
 var errUnsupportedAlg = errors.New("algorithm not supported")
 
+// errCurveMismatch is used when the sender and a recipient keys are not on the same curve
+var errCurveMismatch = errors.New("recipient key curve mismatch")
+
 // TODO https://github.com/hyperledger/aries-framework-go/issues/475 pull alg and nonceSize into separate crypter,
 //  add crypter reference to Packer
 
@@ -42,7 +52,8 @@ var errUnsupportedAlg = errors.New("algorithm not supported")
 type Packer struct {
 	alg        ContentEncryption
 	nonceSize  int
-	legacyKMS  legacykms.KeyManager
+	kms        kms.KeyManager
+	crypto     crypto.Crypto
 	randReader io.Reader
 }
 
@@ -71,29 +82,17 @@ type Recipient struct {
 
 // RecipientHeaders are the recipient headers
 type RecipientHeaders struct {
-	APU string `json:"apu,omitempty"`
-	IV  string `json:"iv,omitempty"`
-	Tag string `json:"tag,omitempty"`
 	KID string `json:"kid,omitempty"`
+	EPK JWK    `json:"epk,omitempty"`
 	SPK string `json:"spk,omitempty"`
 }
 
-// recipientSPKJWEHeaders are the Protected JWE headers of a recipient's SPK field (which is a JWE with a JWK payload)
-type recipientSPKJWEHeaders struct {
-	Typ string `json:"typ,omitempty"`
-	CTY string `json:"cty,omitempty"`
-	Alg string `json:"alg,omitempty"`
-	Enc string `json:"enc,omitempty"`
-	IV  string `json:"iv,omitempty"`
-	Tag string `json:"tag,omitempty"`
-	EPK jwk    `json:"epk,omitempty"`
-}
-
-// jwk formatted key
-type jwk struct {
+// JWK is the ephemeral public key of a recipient in JWK format
+type JWK struct {
 	Kty string `json:"kty,omitempty"`
 	Crv string `json:"crv,omitempty"`
 	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
 }
 
 // New will create an Packer instance to 'AuthCrypt' payloads for the given sender and recipients arguments
@@ -101,24 +100,19 @@ type jwk struct {
 // C20P (chacha20-poly1305 ietf)
 // XC20P (xchacha20-poly1305 ietf)
 // The returned Packer contains all the information required to pack and unpack payloads.
+// The keys of the sender and the recipients are fetched from the KMS of ctx by their base58 encoded
+// verification key.
 func New(ctx packer.Provider, alg ContentEncryption) (*Packer, error) {
-	k := ctx.LegacyKMS()
-
-	var nonceSize int
-
-	switch alg {
-	case C20P:
-		nonceSize = chacha.NonceSize
-	case XC20P:
-		nonceSize = chacha.NonceSizeX
-	default:
-		return nil, errUnsupportedAlg
+	nonceSize, err := nonceSizeOf(alg)
+	if err != nil {
+		return nil, err
 	}
 
 	return &Packer{
 		alg:        alg,
 		nonceSize:  nonceSize,
-		legacyKMS:  k,
+		kms:        ctx.KMS(),
+		crypto:     ctx.Crypto(),
 		randReader: rand.Reader,
 	}, nil
 }
@@ -8,596 +8,580 @@ package authcrypt
 
 import (
 	"bytes"
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
-	"fmt"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/jwe/internal/ecdhkey"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
-	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms/legacykms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/internal/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
 )
 
-func TestEncodingType(t *testing.T) {
-	// create temporary signing keys for tests
-	sigPubKey, sigPrivKey, err := ed25519.GenerateKey(rand.Reader)
-	require.NoError(t, err)
+// newProvider creates a provider with a local KMS and a tink Crypto service
+func newProvider(t *testing.T) *mockprovider.Provider {
+	t.Helper()
 
-	// convert signing keys to encryption keys
-	encPubKey, err := cryptoutil.PublicEd25519toCurve25519(sigPubKey)
-	require.NoError(t, err)
+	storeProvider := mockstorage.NewMockStoreProvider()
 
-	encPrivKey, err := cryptoutil.SecretEd25519toCurve25519(sigPrivKey)
+	k, err := localkms.New("local-lock://test/key/uri", &mockprovider.Provider{
+		StorageProviderValue: storeProvider,
+		SecretLockValue:      &noop.NoLock{},
+	})
 	require.NoError(t, err)
 
+	c, err := tinkcrypto.New()
 	require.NoError(t, err)
 
-	kp := &cryptoutil.MessagingKeys{
-		SigKeyPair: &cryptoutil.SigKeyPair{
-			KeyPair: cryptoutil.KeyPair{Pub: sigPubKey, Priv: sigPrivKey},
-			Alg:     cryptoutil.EdDSA,
-		},
-		EncKeyPair: &cryptoutil.EncKeyPair{
-			KeyPair: cryptoutil.KeyPair{Pub: encPubKey, Priv: encPrivKey},
-			Alg:     cryptoutil.Curve25519,
-		},
+	return &mockprovider.Provider{
+		StorageProviderValue: storeProvider,
+		KeyManagerValue:      k,
+		CryptoValue:          c,
 	}
+}
 
-	kmsProvider, err := mockkms.NewMockProvider(kp)
-	require.NoError(t, err)
+// createKeys creates count ed25519 verification keys and imports their encryption key in the KMS of prov,
+// identified by the base58 encoded verification key
+func createKeys(t *testing.T, prov *mockprovider.Provider, count int) [][]byte {
+	t.Helper()
 
-	packer, e := New(kmsProvider, XC20P)
-	require.NoError(t, e)
-	require.NotEmpty(t, packer)
+	var verKeys [][]byte
 
-	require.Equal(t, encodingType, packer.EncodingType())
+	for i := 0; i < count; i++ {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		encPriv, err := cryptoutil.SecretEd25519toCurve25519(priv)
+		require.NoError(t, err)
+
+		_, _, err = prov.KMS().(*localkms.LocalKMS).ImportPrivateKey(base58.Encode(pub), "ECDHX25519", encPriv)
+		require.NoError(t, err)
+
+		verKeys = append(verKeys, pub)
+	}
+
+	return verKeys
 }
 
-func TestEncrypt(t *testing.T) {
-	// create temporary signing keys for tests
-	sigPubKey, sigPrivKey, err := ed25519.GenerateKey(rand.Reader)
-	require.NoError(t, err)
+// createNISTKeys creates count ECDSA keys on crv and imports them in the KMS of prov as ECDH keys of keyType,
+// identified by the base58 encoded uncompressed public key
+func createNISTKeys(t *testing.T, prov *mockprovider.Provider, crv elliptic.Curve, keyType string,
+	count int) [][]byte {
+	t.Helper()
 
-	// convert signing keys to encryption keys
-	encPubKey, err := cryptoutil.PublicEd25519toCurve25519(sigPubKey)
-	require.NoError(t, err)
+	var pubKeys [][]byte
 
-	encPrivKey, err := cryptoutil.SecretEd25519toCurve25519(sigPrivKey)
-	require.NoError(t, err)
+	for i := 0; i < count; i++ {
+		priv, err := ecdsa.GenerateKey(crv, rand.Reader)
+		require.NoError(t, err)
 
-	require.NoError(t, err)
+		pub := elliptic.Marshal(crv, priv.X, priv.Y)
 
-	sender := &cryptoutil.MessagingKeys{
-		SigKeyPair: &cryptoutil.SigKeyPair{
-			KeyPair: cryptoutil.KeyPair{Pub: sigPubKey, Priv: sigPrivKey},
-			Alg:     cryptoutil.EdDSA,
-		},
-		EncKeyPair: &cryptoutil.EncKeyPair{
-			KeyPair: cryptoutil.KeyPair{Pub: encPubKey, Priv: encPrivKey},
-			Alg:     cryptoutil.Curve25519,
-		},
+		_, _, err = prov.KMS().(*localkms.LocalKMS).ImportPrivateKey(base58.Encode(pub), keyType, priv.D.Bytes())
+		require.NoError(t, err)
+
+		pubKeys = append(pubKeys, pub)
 	}
 
-	t.Logf("sender Signature key pub: %v", base64.RawURLEncoding.EncodeToString(sender.SigKeyPair.Pub))
-	t.Logf("sender Signature key priv: %v", base64.RawURLEncoding.EncodeToString(sender.SigKeyPair.Priv))
-	t.Logf("sender Encryption key pub: %v", base64.RawURLEncoding.EncodeToString(sender.EncKeyPair.Pub))
-	t.Logf("sender Encryption key priv: %v", base64.RawURLEncoding.EncodeToString(sender.EncKeyPair.Priv))
+	return pubKeys
+}
 
-	sigPubKey, sigPrivKey, err = ed25519.GenerateKey(rand.Reader)
-	require.NoError(t, err)
+func TestNew(t *testing.T) {
+	prov := newProvider(t)
 
-	// convert signing keys to encryption keys
-	encPubKey, err = cryptoutil.PublicEd25519toCurve25519(sigPubKey)
+	packer, err := New(prov, XC20P)
 	require.NoError(t, err)
+	require.Equal(t, encodingType, packer.EncodingType())
 
-	encPrivKey, err = cryptoutil.SecretEd25519toCurve25519(sigPrivKey)
+	_, err = New(prov, C20P)
 	require.NoError(t, err)
 
-	rec1 := &cryptoutil.MessagingKeys{
-		SigKeyPair: &cryptoutil.SigKeyPair{
-			KeyPair: cryptoutil.KeyPair{Pub: sigPubKey, Priv: sigPrivKey},
-			Alg:     cryptoutil.EdDSA,
-		},
-		EncKeyPair: &cryptoutil.EncKeyPair{
-			KeyPair: cryptoutil.KeyPair{Pub: encPubKey, Priv: encPrivKey},
-			Alg:     cryptoutil.Curve25519,
-		},
+	_, err = New(prov, "A256GCM")
+	require.EqualError(t, err, "algorithm not supported")
+}
+
+func TestPackUnpack(t *testing.T) {
+	for _, alg := range []ContentEncryption{C20P, XC20P} {
+		contentEncryption := alg
+		t.Run(string(contentEncryption), func(t *testing.T) {
+			senderProv := newProvider(t)
+			recProv := newProvider(t)
+
+			sender := createKeys(t, senderProv, 1)[0]
+			recipients := createKeys(t, recProv, 3)
+
+			senderPacker, err := New(senderProv, contentEncryption)
+			require.NoError(t, err)
+
+			payload := []byte("secret message")
+
+			envelope, err := senderPacker.Pack(payload, sender, recipients)
+			require.NoError(t, err)
+
+			jwe := &Envelope{}
+			require.NoError(t, json.Unmarshal(envelope, jwe))
+			require.Len(t, jwe.Recipients, 3)
+
+			headers, err := decodeHeaders(jwe.Protected)
+			require.NoError(t, err)
+			require.Equal(t, encodingType, headers.Typ)
+			require.Equal(t, keyWrapAlg, headers.Alg)
+			require.Equal(t, string(contentEncryption), headers.Enc)
+
+			// the sender key is only found encrypted in the recipients `spk` headers
+			require.NotContains(t, string(envelope), base58.Encode(sender))
+
+			for i, rec := range jwe.Recipients {
+				require.Equal(t, base58.Encode(recipients[i]), rec.Header.KID)
+				require.Equal(t, "OKP", rec.Header.EPK.Kty)
+				require.Equal(t, "X25519", rec.Header.EPK.Crv)
+				require.NotEmpty(t, rec.Header.EPK.X)
+				require.Len(t, strings.Split(rec.Header.SPK, "."), 5)
+			}
+
+			// a packer configured with another content encryption can unpack the envelope
+			receiver, err := New(recProv, XC20P)
+			require.NoError(t, err)
+
+			msg, err := receiver.Unpack(envelope)
+			require.NoError(t, err)
+			require.Equal(t, payload, msg.Message)
+			require.Equal(t, sender, msg.FromVerKey)
+			require.Equal(t, recipients[0], msg.ToVerKey)
+
+			// the sender can't unpack the envelope
+			_, err = senderPacker.Unpack(envelope)
+			require.Error(t, err)
+			require.True(t, errors.Is(err, errRecipientNotFound))
+		})
 	}
 
-	t.Logf("rec1 Sig key pub: %v", base64.RawURLEncoding.EncodeToString(rec1.SigKeyPair.Pub))
-	t.Logf("rec1 Sig key priv: %v", base64.RawURLEncoding.EncodeToString(rec1.SigKeyPair.Priv))
-	t.Logf("rec1 Enc pub: %v", base64.RawURLEncoding.EncodeToString(rec1.EncKeyPair.Pub))
-	t.Logf("rec1 Enc priv: %v", base64.RawURLEncoding.EncodeToString(rec1.EncKeyPair.Priv))
+	t.Run("unpack for each recipient", func(t *testing.T) {
+		prov1 := newProvider(t)
+		prov2 := newProvider(t)
 
-	sigPubKey, sigPrivKey, err = ed25519.GenerateKey(rand.Reader)
-	require.NoError(t, err)
-	// convert signing keys to encryption keys
+		rec1 := createKeys(t, prov1, 1)[0]
+		rec2 := createKeys(t, prov2, 1)[0]
 
-	encPubKey, err = cryptoutil.PublicEd25519toCurve25519(sigPubKey)
-	require.NoError(t, err)
+		packer1, err := New(prov1, XC20P)
+		require.NoError(t, err)
 
-	encPrivKey, err = cryptoutil.SecretEd25519toCurve25519(sigPrivKey)
-	require.NoError(t, err)
+		packer2, err := New(prov2, XC20P)
+		require.NoError(t, err)
 
-	rec2 := &cryptoutil.MessagingKeys{
-		SigKeyPair: &cryptoutil.SigKeyPair{
-			KeyPair: cryptoutil.KeyPair{Pub: sigPubKey, Priv: sigPrivKey},
-			Alg:     cryptoutil.EdDSA,
-		},
-		EncKeyPair: &cryptoutil.EncKeyPair{
-			KeyPair: cryptoutil.KeyPair{Pub: encPubKey, Priv: encPrivKey},
-			Alg:     cryptoutil.Curve25519,
-		},
+		envelope, err := packer1.Pack([]byte("message"), rec1, [][]byte{rec1, rec2})
+		require.NoError(t, err)
+
+		msg, err := packer1.Unpack(envelope)
+		require.NoError(t, err)
+		require.Equal(t, rec1, msg.ToVerKey)
+
+		msg, err = packer2.Unpack(envelope)
+		require.NoError(t, err)
+		require.Equal(t, rec2, msg.ToVerKey)
+		require.Equal(t, rec1, msg.FromVerKey)
+		require.Equal(t, []byte("message"), msg.Message)
+	})
+}
+
+func TestPackUnpack_NIST(t *testing.T) {
+	tests := []struct {
+		curve   elliptic.Curve
+		keyType string
+	}{
+		{curve: elliptic.P256(), keyType: "ECDHP256"},
+		{curve: elliptic.P384(), keyType: "ECDHP384"},
+		{curve: elliptic.P521(), keyType: "ECDHP521"},
 	}
 
-	t.Logf("rec2 Sig key pub: %v", base64.RawURLEncoding.EncodeToString(rec2.SigKeyPair.Pub))
-	t.Logf("rec2 Sig key priv: %v", base64.RawURLEncoding.EncodeToString(rec2.SigKeyPair.Priv))
-	t.Logf("rec2 Enc pub: %v", base64.RawURLEncoding.EncodeToString(rec2.EncKeyPair.Pub))
-	t.Logf("rec2 Enc priv: %v", base64.RawURLEncoding.EncodeToString(rec2.EncKeyPair.Priv))
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.curve.Params().Name, func(t *testing.T) {
+			senderProv := newProvider(t)
+			recProv := newProvider(t)
 
-	sigPubKey, sigPrivKey, err = ed25519.GenerateKey(rand.Reader)
-	require.NoError(t, err)
+			sender := createNISTKeys(t, senderProv, tc.curve, tc.keyType, 1)[0]
+			recipients := createNISTKeys(t, recProv, tc.curve, tc.keyType, 2)
 
-	// convert signing keys to encryption keys
-	encPubKey, err = cryptoutil.PublicEd25519toCurve25519(sigPubKey)
-	require.NoError(t, err)
+			senderPacker, err := New(senderProv, XC20P)
+			require.NoError(t, err)
 
-	encPrivKey, err = cryptoutil.SecretEd25519toCurve25519(sigPrivKey)
-	require.NoError(t, err)
+			payload := []byte("secret message")
 
-	rec3 := &cryptoutil.MessagingKeys{
-		SigKeyPair: &cryptoutil.SigKeyPair{
-			KeyPair: cryptoutil.KeyPair{Pub: sigPubKey, Priv: sigPrivKey},
-			Alg:     cryptoutil.EdDSA,
-		},
-		EncKeyPair: &cryptoutil.EncKeyPair{
-			KeyPair: cryptoutil.KeyPair{Pub: encPubKey, Priv: encPrivKey},
-			Alg:     cryptoutil.Curve25519,
-		},
-	}
+			envelope, err := senderPacker.Pack(payload, sender, recipients)
+			require.NoError(t, err)
 
-	t.Logf("rec3 Sig key pub: %v", base64.RawURLEncoding.EncodeToString(rec3.SigKeyPair.Pub))
-	t.Logf("rec3 Sig key priv: %v", base64.RawURLEncoding.EncodeToString(rec3.SigKeyPair.Priv))
-	t.Logf("rec3 Enc pub: %v", base64.RawURLEncoding.EncodeToString(rec3.EncKeyPair.Pub))
-	t.Logf("rec3 Enc priv: %v", base64.RawURLEncoding.EncodeToString(rec3.EncKeyPair.Priv))
+			jwe := &Envelope{}
+			require.NoError(t, json.Unmarshal(envelope, jwe))
+			require.Len(t, jwe.Recipients, 2)
 
-	allKMSProvider, err := mockkms.NewMockProvider(sender, rec1, rec2, rec3)
-	require.NoError(t, err)
+			size := (tc.curve.Params().BitSize + 7) / 8
 
-	senderKMSProvider, err := mockkms.NewMockProvider(sender)
-	require.NoError(t, err)
+			for i, rec := range jwe.Recipients {
+				require.Equal(t, base58.Encode(recipients[i]), rec.Header.KID)
+				require.Equal(t, "EC", rec.Header.EPK.Kty)
+				require.Equal(t, tc.curve.Params().Name, rec.Header.EPK.Crv)
 
-	recipient1KMSProvider, err := mockkms.NewMockProvider(rec1)
-	require.NoError(t, err)
+				x, e := base64.RawURLEncoding.DecodeString(rec.Header.EPK.X)
+				require.NoError(t, e)
+				require.Len(t, x, size)
 
-	recipient2KMSProvider, err := mockkms.NewMockProvider(rec2)
-	require.NoError(t, err)
+				y, e := base64.RawURLEncoding.DecodeString(rec.Header.EPK.Y)
+				require.NoError(t, e)
+				require.Len(t, y, size)
+			}
 
-	recipient3KMSProvider, err := mockkms.NewMockProvider(rec3)
-	require.NoError(t, err)
+			receiver, err := New(recProv, XC20P)
+			require.NoError(t, err)
 
-	badKey := cryptoutil.KeyPair{
-		Pub:  nil,
-		Priv: nil,
+			msg, err := receiver.Unpack(envelope)
+			require.NoError(t, err)
+			require.Equal(t, payload, msg.Message)
+			require.Equal(t, sender, msg.FromVerKey)
+			require.Equal(t, recipients[0], msg.ToVerKey)
+		})
 	}
+}
 
-	t.Run("Error test case: Create a new AuthCrypt PackerValue with bad encryption algorithm", func(t *testing.T) {
-		_, e := New(senderKMSProvider, "BAD")
-		require.Error(t, e)
-		require.EqualError(t, e, errUnsupportedAlg.Error())
+func TestPack_Failure(t *testing.T) {
+	prov := newProvider(t)
+
+	packer, err := New(prov, XC20P)
+	require.NoError(t, err)
+
+	keys := createKeys(t, prov, 2)
+	sender, recipients := keys[0], keys[1:]
+
+	t.Run("empty sender key", func(t *testing.T) {
+		_, err = packer.Pack([]byte("message"), nil, recipients)
+		require.EqualError(t, err, "failed to pack message: empty sender key")
 	})
 
-	t.Run("Error test case: Create a new AuthCrypt PackerValue and use an empty keys for encryption", func(t *testing.T) {
-		packer, e := New(senderKMSProvider, XC20P)
-		require.NoError(t, e)
-		require.NotEmpty(t, packer)
-		badKey.Pub = []byte{}
-		enc, e := packer.Pack([]byte("lorem ipsum dolor sit amet"),
-			badKey.Pub, [][]byte{rec1.SigKeyPair.Pub, rec2.SigKeyPair.Pub, rec3.SigKeyPair.Pub})
-		require.EqualError(t, e, "failed to pack message: empty sender key")
-		require.Empty(t, enc)
+	t.Run("sender key not found", func(t *testing.T) {
+		_, err = packer.Pack([]byte("message"), bytes.Repeat([]byte{1}, 32), recipients)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to pack message: get sender key")
 	})
 
-	t.Run("Error test case: Create a new AuthCrypt PackerValue and use a bad key for encryption", func(t *testing.T) {
-		packer, e := New(senderKMSProvider, XC20P)
-		require.NoError(t, e)
-		require.NotEmpty(t, packer)
-		// test bad sender public key
-		badKey.Pub = []byte("badkey")
-
-		enc, e := packer.Pack([]byte("lorem ipsum dolor sit amet"),
-			badKey.Pub, [][]byte{rec1.SigKeyPair.Pub, rec2.SigKeyPair.Pub, rec3.SigKeyPair.Pub})
-		require.EqualError(t, e, "key not found")
-		require.Empty(t, enc)
-
-		// reset badKey
-		badKey.Pub = nil
-
-		// test bad recipient 1 public key size
-		enc, e = packer.Pack([]byte("lorem ipsum dolor sit amet"),
-			sender.SigKeyPair.Pub, [][]byte{[]byte("badkeysize"), rec2.SigKeyPair.Pub, rec3.SigKeyPair.Pub})
-		require.EqualError(t, e, "failed to pack message: invalid key - for recipient 1")
-		require.Empty(t, enc)
-		// test bad recipient 2 public key size
-		enc, e = packer.Pack([]byte("lorem ipsum dolor sit amet"),
-			sender.SigKeyPair.Pub, [][]byte{rec1.SigKeyPair.Pub, []byte("badkeysize"), rec3.SigKeyPair.Pub})
-		require.EqualError(t, e, "failed to pack message: invalid key - for recipient 2")
-		require.Empty(t, enc)
-		// test bad recipient 3 publick key size
-		enc, e = packer.Pack([]byte("lorem ipsum dolor sit amet"),
-			sender.SigKeyPair.Pub, [][]byte{rec1.SigKeyPair.Pub, rec2.SigKeyPair.Pub, []byte("badkeysize")})
-		require.EqualError(t, e, "failed to pack message: invalid key - for recipient 3")
-		require.Empty(t, enc)
-
-		// test invalid recipient 1 key
-		k, e := base64.RawURLEncoding.DecodeString("-----vh1JG9hO0123pO3gWyngGwLivn32PzUdUDwW4w")
-		require.NoError(t, e)
-		enc, e = packer.Pack([]byte("lorem ipsum dolor sit amet"),
-			sender.SigKeyPair.Pub, [][]byte{k, rec2.SigKeyPair.Pub, rec3.SigKeyPair.Pub})
-		require.EqualError(t, e, "failed to pack message: error converting public key")
-		require.Empty(t, enc)
+	t.Run("empty recipients", func(t *testing.T) {
+		_, err = packer.Pack([]byte("message"), sender, nil)
+		require.EqualError(t, err, "failed to pack message: empty recipients")
 	})
 
-	t.Run("Error test case: Create a new AuthCrypt PackerValue and use an empty recipient keys list for encryption", func(t *testing.T) { //nolint:lll
-		packer, e := New(senderKMSProvider, "XC20P")
-		require.NoError(t, e)
-		require.NotEmpty(t, packer)
-		enc, e := packer.Pack([]byte("lorem ipsum dolor sit amet"), sender.SigKeyPair.Pub, [][]byte{})
-		require.EqualError(t, e, "failed to pack message: empty recipients")
-		require.Empty(t, enc)
+	t.Run("invalid recipient key", func(t *testing.T) {
+		_, err = packer.Pack([]byte("message"), sender, [][]byte{recipients[0], []byte("invalid")})
+		require.Error(t, err)
+		require.True(t, errors.Is(err, cryptoutil.ErrInvalidKey))
+		require.Contains(t, err.Error(), "for recipient 2")
 	})
 
-	t.Run("Success test case: Create a valid PackerValue for ChachaPoly1305 encryption (alg: C20P)", func(t *testing.T) {
-		packer, e := New(allKMSProvider, C20P)
-		require.NoError(t, e)
-		require.NotEmpty(t, packer)
-		enc, e := packer.Pack([]byte("lorem ipsum dolor sit amet"),
-			sender.SigKeyPair.Pub, [][]byte{rec1.SigKeyPair.Pub, rec2.SigKeyPair.Pub, rec3.SigKeyPair.Pub})
+	t.Run("invalid sender key", func(t *testing.T) {
+		_, _, e := prov.KMS().(*localkms.LocalKMS).ImportPrivateKey(base58.Encode([]byte("invalid")),
+			"ECDHX25519", make([]byte, 32))
 		require.NoError(t, e)
-		require.NotEmpty(t, enc)
 
-		m, e := prettyPrint(enc)
-		require.NoError(t, e)
-		t.Logf("Encryption with C20P: %s", m)
+		_, e = packer.Pack([]byte("message"), []byte("invalid"), recipients)
+		require.Error(t, e)
+		require.True(t, errors.Is(e, cryptoutil.ErrInvalidKey))
+		require.Contains(t, e.Error(), "failed to pack message: sender key")
 	})
 
-	t.Run("Success test case: Create a valid PackerValue for XChachaPoly1305 encryption (alg: XC20P)", func(t *testing.T) {
-		packer, e := New(allKMSProvider, XC20P)
-		require.NoError(t, e)
-		require.NotEmpty(t, packer)
-		enc, e := packer.Pack([]byte("lorem ipsum dolor sit amet"),
-			sender.SigKeyPair.Pub, [][]byte{rec1.SigKeyPair.Pub, rec2.SigKeyPair.Pub, rec3.SigKeyPair.Pub})
-		require.NoError(t, e)
-		require.NotEmpty(t, enc)
-
-		m, e := prettyPrint(enc)
-		require.NoError(t, e)
-		t.Logf("Encryption with XC20P: %s", m)
+	t.Run("recipient key curve mismatch", func(t *testing.T) {
+		p256Recipient := createNISTKeys(t, newProvider(t), elliptic.P256(), "ECDHP256", 1)[0]
 
-		t.Run("Error test Case: use a valid AuthCrypt PackerValue but scramble the nonce size", func(t *testing.T) {
-			packer.nonceSize = 0
-			_, err = packer.Pack([]byte("lorem ipsum dolor sit amet"),
-				sender.SigKeyPair.Pub, [][]byte{rec1.SigKeyPair.Pub, rec2.SigKeyPair.Pub, rec3.SigKeyPair.Pub})
-			require.Error(t, err)
-		})
+		_, e := packer.Pack([]byte("message"), sender, [][]byte{recipients[0], p256Recipient})
+		require.Error(t, e)
+		require.True(t, errors.Is(e, errCurveMismatch))
+		require.Contains(t, e.Error(), "P-256 instead of X25519 - for recipient 2")
 	})
 
-	t.Run("Success test case: Decrypting a message (with the same packer)", func(t *testing.T) {
-		// not a real life scenario, the legacyKMS is using both sender and rec1 key pairs
-		// allKMSProvider is used here for testing purposes only
-		packer, e := New(allKMSProvider, XC20P)
-		require.NoError(t, e)
-		require.NotEmpty(t, packer)
-		pld := []byte("lorem ipsum dolor sit amet")
-		enc, e := packer.Pack(pld, sender.SigKeyPair.Pub,
-			[][]byte{rec1.SigKeyPair.Pub, rec2.SigKeyPair.Pub, rec3.SigKeyPair.Pub})
-		require.NoError(t, e)
-		require.NotEmpty(t, enc)
-
-		m, e := prettyPrint(enc)
+	t.Run("wrap key failure", func(t *testing.T) {
+		p, e := New(&mockprovider.Provider{
+			KeyManagerValue: prov.KMS(),
+			CryptoValue:     &mockcrypto.Crypto{WrapError: errors.New("wrap error")},
+		}, XC20P)
 		require.NoError(t, e)
-		t.Logf("Encryption with unescaped XC20P: %s", enc)
-		t.Logf("Encryption with XC20P: %s", m)
 
-		// decrypt for rec1 (as found in legacyKMS)
-		env, e := packer.Unpack(enc)
-		require.NoError(t, e)
-		require.NotEmpty(t, env)
-		require.NotEmpty(t, env.Message)
-		require.EqualValues(t, env.Message, pld)
-		require.NotEmpty(t, env.FromVerKey)
-		require.Equal(t, base64.RawURLEncoding.EncodeToString(sender.EncKeyPair.Pub),
-			base64.RawURLEncoding.EncodeToString(env.FromVerKey))
+		_, e = p.Pack([]byte("message"), sender, recipients)
+		require.EqualError(t, e, "failed to pack message: wrap error")
 	})
 
-	t.Run("Success test case: : pack and unpack", func(t *testing.T) {
-		sendPacker, err := New(allKMSProvider, XC20P)
-		require.NoError(t, err)
-		require.NotEmpty(t, sendPacker)
+	t.Run("rand reader failures", func(t *testing.T) {
+		// the reader is used for the nonce, the cek then the cek and the nonce of the recipient's spk
+		for i := 0; i < 4; i++ {
+			packer.randReader = &failingReader{failAfter: i}
 
-		recPacker, err := New(allKMSProvider, XC20P)
-		require.NoError(t, err)
-		require.NotEmpty(t, recPacker)
+			_, err = packer.Pack([]byte("message"), sender, recipients)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "read error")
+		}
+	})
+}
 
-		encSign, _, err := allKMSProvider.LegacyKMS().CreateKeySet()
-		require.NoError(t, err)
-		_, recSign, err := allKMSProvider.LegacyKMS().CreateKeySet()
-		require.NoError(t, err)
+func TestUnpack_Failure(t *testing.T) {
+	prov := newProvider(t)
 
-		msgIn := []byte("Lorem ipsum dolor sit amet, consectetur adipiscing elit.")
-		enc, err := sendPacker.Pack(msgIn, base58.Decode(encSign),
-			[][]byte{base58.Decode(recSign)})
-		require.NoError(t, err)
+	packer, err := New(prov, XC20P)
+	require.NoError(t, err)
 
-		env, err := recPacker.Unpack(enc)
-		require.NoError(t, err)
-		require.NotEmpty(t, env)
-		require.NotEmpty(t, env.Message)
-		require.Equal(t, msgIn, env.Message)
-		require.NotEmpty(t, env.FromVerKey)
-		require.Equal(t, base58.Encode(env.FromVerKey), encSign)
-		// this won't work, since input keys and output keys to this Packer
-		// are different types.
-		// require.Equal(t, base58.Encode(recKey), recSign)
-		require.NotEmpty(t, env.ToVerKey)
-	})
+	keys := createKeys(t, prov, 2)
 
-	t.Run("Success test case: Decrypting a message with two PackerValue instances to simulate two agents", func(t *testing.T) { //nolint:lll
-		// encrypt with sender
-		packer, e := New(allKMSProvider, XC20P)
-		require.NoError(t, e)
-		require.NotEmpty(t, packer)
-		pld := []byte("lorem ipsum dolor sit amet")
-		enc, e := packer.Pack(pld, sender.SigKeyPair.Pub,
-			[][]byte{rec1.SigKeyPair.Pub, rec2.SigKeyPair.Pub, rec3.SigKeyPair.Pub})
-		require.NoError(t, e)
-		require.NotEmpty(t, enc)
+	envelope, err := packer.Pack([]byte("message"), keys[0], keys[1:])
+	require.NoError(t, err)
 
-		m, e := prettyPrint(enc)
-		require.NoError(t, e)
-		t.Logf("Encryption with unescaped XC20P: %s", enc)
-		t.Logf("Encryption with XC20P: %s", m)
+	// unpack modifies a valid envelope with update and returns the Unpack error
+	unpack := func(update func(jwe *Envelope)) error {
+		jwe := &Envelope{}
+		require.NoError(t, json.Unmarshal(envelope, jwe))
 
-		// now decrypt with recipient3
-		packer1, e := New(recipient3KMSProvider, XC20P)
-		require.NoError(t, e)
-		env, e := packer1.Unpack(enc)
-		require.NoError(t, e)
-		require.NotEmpty(t, env)
-		require.NotEmpty(t, env.Message)
-		require.EqualValues(t, env.Message, pld)
-		require.NotEmpty(t, env.FromVerKey)
-		require.Equal(t, base64.RawURLEncoding.EncodeToString(sender.EncKeyPair.Pub),
-			base64.RawURLEncoding.EncodeToString(env.FromVerKey))
-
-		// now try decrypting with recipient2
-		packer2, e := New(recipient2KMSProvider, XC20P)
-		require.NoError(t, e)
-		env, e = packer2.Unpack(enc)
-		require.NoError(t, e)
-		require.NotEmpty(t, env)
-		require.NotEmpty(t, env.Message)
-		require.EqualValues(t, env.Message, pld)
-		require.NotEmpty(t, env.FromVerKey)
-		require.Equal(t, base64.RawURLEncoding.EncodeToString(sender.EncKeyPair.Pub),
-			base64.RawURLEncoding.EncodeToString(env.FromVerKey))
-		t.Logf("Decryption Payload with XC20P: %s", pld)
-	})
+		update(jwe)
 
-	t.Run("Failure test case: Decrypting a message with an unauthorized (recipient2) agent", func(t *testing.T) {
-		packer, e := New(allKMSProvider, XC20P)
-		require.NoError(t, e)
-		require.NotEmpty(t, packer)
-		pld := []byte("lorem ipsum dolor sit amet")
-		enc, e := packer.Pack(pld, sender.SigKeyPair.Pub, [][]byte{rec1.SigKeyPair.Pub, rec3.SigKeyPair.Pub})
+		env, e := json.Marshal(jwe)
 		require.NoError(t, e)
-		require.NotEmpty(t, enc)
 
-		m, e := prettyPrint(enc)
-		require.NoError(t, e)
-		t.Logf("Encryption with unescaped XC20P: %s", enc)
-		t.Logf("Encryption with XC20P: %s", m)
+		_, e = packer.Unpack(env)
 
-		// decrypting for recipient 2 (unauthorized)
-		packer1, e := New(recipient2KMSProvider, XC20P)
-		require.NoError(t, e)
-		env, e := packer1.Unpack(enc)
-		require.Error(t, e)
-		require.Empty(t, env)
-	})
+		return e
+	}
 
-	t.Run("Failure test case: Decrypting a message but scramble JWE beforehand", func(t *testing.T) {
-		packer, e := New(allKMSProvider, XC20P)
-		require.NoError(t, e)
-		require.NotEmpty(t, packer)
-		pld := []byte("lorem ipsum dolor sit amet")
-		enc, e := packer.Pack(pld, sender.SigKeyPair.Pub,
-			[][]byte{rec1.SigKeyPair.Pub, rec2.SigKeyPair.Pub, rec3.SigKeyPair.Pub})
+	protected := func(h *jweHeaders) string {
+		b, e := json.Marshal(h)
 		require.NoError(t, e)
-		require.NotEmpty(t, enc)
 
-		var validJwe *Envelope
-		e = json.Unmarshal(enc, &validJwe)
-		require.NoError(t, e)
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
 
-		// make a jwe copy to test with scrambling its values
-		jwe := &Envelope{}
-		deepCopy(jwe, validJwe)
+	recPubKey, err := ecdhkey.PublicKey(keys[1])
+	require.NoError(t, err)
 
-		// create a new packer for rec1 for testing decryption
-		packer, e = New(recipient1KMSProvider, XC20P)
+	// spk returns the recipient's `spk` header encrypting skid
+	spk := func(skid string) string {
+		s, e := packer.generateSPK(skid, recPubKey)
+		require.NoError(t, e)
 
-		// test bad jwe format
-		enc = []byte("{badJWE}")
+		return s
+	}
 
-		// update jwe with bad cipherText format
-		jwe.CipherText = "badCipherText"
-		enc, e = json.Marshal(jwe)
+	// withSPKHeaders replaces the protected headers of the recipient's `spk` header with h
+	withSPKHeaders := func(jwe *Envelope, h interface{}) {
+		b, e := json.Marshal(h)
 		require.NoError(t, e)
-		// decrypt with bad nonce format
-		env, e := packer.Unpack(enc)
-		require.EqualError(t, e, "unpack: illegal base64 data at input byte 12")
-		require.Empty(t, env)
-		jwe.CipherText = validJwe.CipherText
-
-		// update jwe with bad nonce format
-		jwe.IV = "badIV!"
-		enc, e = json.Marshal(jwe)
-		require.NoError(t, e)
-		// decrypt with bad nonce format
-		env, e = packer.Unpack(enc)
-		require.EqualError(t, e, "unpack: illegal base64 data at input byte 5")
-		require.Empty(t, env)
-		jwe.IV = validJwe.IV
-
-		// update jwe with bad tag format
-		jwe.Tag = "badTag!"
-		enc, e = json.Marshal(jwe)
-		require.NoError(t, e)
-		// decrypt with bad tag format
-		env, e = packer.Unpack(enc)
-		require.EqualError(t, e, "unpack: illegal base64 data at input byte 6")
-		require.Empty(t, env)
-		jwe.Tag = validJwe.Tag
-
-		// update jwe with bad recipient spk (JWE format)
-		jwe.Recipients[0].Header.SPK = "badSPK!"
-		enc, e = json.Marshal(jwe)
-		require.NoError(t, e)
-		// decrypt with bad tag
-		env, e = packer.Unpack(enc)
-		require.EqualError(t, e, "unpack: sender key: bad SPK format")
-		require.Empty(t, env)
-		jwe.Recipients[0].Header.SPK = validJwe.Recipients[0].Header.SPK
-
-		// update jwe with bad recipient tag format
-		jwe.Recipients[0].Header.Tag = "badTag!"
-		enc, e = json.Marshal(jwe)
-		require.NoError(t, e)
-		// decrypt with bad tag
-		env, e = packer.Unpack(enc)
-		require.EqualError(t, e, "unpack: decrypt shared key: illegal base64 data at input byte 6")
-		require.Empty(t, env)
-		jwe.Recipients[0].Header.Tag = validJwe.Recipients[0].Header.Tag
-
-		// update jwe with bad recipient nonce format
-		jwe.Recipients[0].Header.IV = "badIV!"
-		enc, e = json.Marshal(jwe)
-		require.NoError(t, e)
-		// decrypt with bad tag
-		env, e = packer.Unpack(enc)
-		require.EqualError(t, e, "unpack: decrypt shared key: illegal base64 data at input byte 5")
-		require.Empty(t, env)
-		jwe.Recipients[0].Header.IV = validJwe.Recipients[0].Header.IV
-
-		// update jwe with bad recipient nonce format
-		jwe.Recipients[0].Header.IV = "badNonce"
-		enc, e = json.Marshal(jwe)
-		require.NoError(t, e)
-		// decrypt with bad tag
-		env, e = packer.Unpack(enc)
-		require.EqualError(t, e, "unpack: decrypt shared key: bad nonce size")
-		require.Empty(t, env)
-		jwe.Recipients[0].Header.IV = validJwe.Recipients[0].Header.IV
-
-		// update jwe with bad recipient apu format
-		jwe.Recipients[0].Header.APU = "badAPU!"
-		enc, e = json.Marshal(jwe)
-		require.NoError(t, e)
-		// decrypt with bad tag
-		env, e = packer.Unpack(enc)
-		require.EqualError(t, e, "unpack: decrypt shared key: illegal base64 data at input byte 6")
-		require.Empty(t, env)
-		jwe.Recipients[0].Header.APU = validJwe.Recipients[0].Header.APU
-
-		// update jwe with bad recipient kid (sender) format
-		jwe.Recipients[0].Header.KID = "badKID!"
-		enc, e = json.Marshal(jwe)
-		require.NoError(t, e)
-		// decrypt with bad tag
-		env, e = packer.Unpack(enc)
-		require.EqualError(t, e, fmt.Sprintf("unpack: %s", cryptoutil.ErrKeyNotFound.Error()))
-		require.Empty(t, env)
-		jwe.Recipients[0].Header.KID = validJwe.Recipients[0].Header.KID
-
-		// update jwe with bad recipient CEK (encrypted key) format
-		jwe.Recipients[0].EncryptedKey = "badEncryptedKey!"
-		enc, e = json.Marshal(jwe)
-		require.NoError(t, e)
-		// decrypt with bad tag
-		env, e = packer.Unpack(enc)
-		require.EqualError(t, e, "unpack: decrypt shared key: illegal base64 data at input byte 15")
-		require.Empty(t, env)
-		jwe.Recipients[0].EncryptedKey = validJwe.Recipients[0].EncryptedKey
-
-		// update jwe with bad recipient CEK (encrypted key) value
-		jwe.Recipients[0].EncryptedKey = "Np2ZIsTdsM190yv_v3FkfjVshGqAUvH4KfWOnQE8wl4"
-		enc, e = json.Marshal(jwe)
-		require.NoError(t, e)
-		// decrypt with bad tag
-		env, e = packer.Unpack(enc)
-		require.EqualError(t, e, "unpack: decrypt shared key: chacha20poly1305: message authentication failed")
-		require.Empty(t, env)
-		jwe.Recipients[0].EncryptedKey = validJwe.Recipients[0].EncryptedKey
-
-		// now try bad nonce size
-		jwe.IV = "ouaN1Qm8cUzNr1IC"
-		enc, e = json.Marshal(jwe)
-		require.NoError(t, e)
-		// decrypt with bad nonce value
-		require.PanicsWithValue(t, "chacha20poly1305: bad nonce length passed to Open", func() {
-			env, e = packer.Unpack(enc)
-		})
-		require.Empty(t, env)
-		jwe.IV = validJwe.IV
 
-		// now try bad nonce value
-		jwe.Recipients[0].Header.IV = "dZY1WrG0IeIOfLJG8FMLkf3BUqUCe0xI"
-		enc, e = json.Marshal(jwe)
-		require.NoError(t, e)
-		// decrypt with bad tag
-		env, e = packer.Unpack(enc)
-		require.EqualError(t, e, "unpack: decrypt shared key: chacha20poly1305: message authentication failed")
-		require.Empty(t, env)
-		jwe.Recipients[0].Header.IV = validJwe.Recipients[0].Header.IV
-	})
-}
+		parts := strings.Split(jwe.Recipients[0].Header.SPK, ".")
+		parts[0] = base64.RawURLEncoding.EncodeToString(b)
+		jwe.Recipients[0].Header.SPK = strings.Join(parts, ".")
+	}
 
-func deepCopy(envelope, envelope2 *Envelope) {
-	for _, r := range envelope2.Recipients {
-		newRe := Recipient{
-			EncryptedKey: r.EncryptedKey,
-			Header: RecipientHeaders{
-				APU: r.Header.APU,
-				KID: r.Header.KID,
-				IV:  r.Header.IV,
-				SPK: r.Header.SPK,
-				Tag: r.Header.Tag,
+	tests := []struct {
+		name   string
+		update func(jwe *Envelope)
+		errMsg string
+	}{
+		{
+			name:   "invalid protected headers encoding",
+			update: func(jwe *Envelope) { jwe.Protected = "!" },
+			errMsg: "unpack: decode headers",
+		},
+		{
+			name:   "invalid protected headers",
+			update: func(jwe *Envelope) { jwe.Protected = base64.RawURLEncoding.EncodeToString([]byte("{")) },
+			errMsg: "unpack: parse headers",
+		},
+		{
+			name: "unsupported key wrapping algorithm",
+			update: func(jwe *Envelope) {
+				jwe.Protected = protected(&jweHeaders{Typ: encodingType, Alg: "ECDH-SS+XC20PKW", Enc: "XC20P"})
 			},
-		}
-		envelope.Recipients = append(envelope.Recipients, newRe)
+			errMsg: "unpack: algorithm not supported: ECDH-SS+XC20PKW",
+		},
+		{
+			name:   "invalid sender key",
+			update: func(jwe *Envelope) { jwe.Recipients[0].Header.SPK = spk("invalid") },
+			errMsg: "unpack: sender key: " + cryptoutil.ErrInvalidKey.Error(),
+		},
+		{
+			name:   "missing spk",
+			update: func(jwe *Envelope) { jwe.Recipients[0].Header.SPK = "" },
+			errMsg: "unpack: sender key: bad SPK format",
+		},
+		{
+			name:   "invalid spk headers encoding",
+			update: func(jwe *Envelope) { jwe.Recipients[0].Header.SPK = "!...." },
+			errMsg: "unpack: sender key: illegal base64 data",
+		},
+		{
+			name:   "invalid spk headers",
+			update: func(jwe *Envelope) { withSPKHeaders(jwe, "{") },
+			errMsg: "unpack: sender key: json: cannot unmarshal string",
+		},
+		{
+			name: "unsupported spk key wrapping algorithm",
+			update: func(jwe *Envelope) {
+				withSPKHeaders(jwe, &spkHeaders{Typ: "jose", Alg: keyWrapAlg, Enc: "XC20P"})
+			},
+			errMsg: "unpack: sender key: algorithm not supported: ECDH-1PU+A256KW",
+		},
+		{
+			name: "unsupported spk content encryption",
+			update: func(jwe *Envelope) {
+				withSPKHeaders(jwe, &spkHeaders{Typ: "jose", Alg: spkWrapAlg, Enc: "A256GCM"})
+			},
+			errMsg: "unpack: sender key: algorithm not supported",
+		},
+		{
+			name: "unsupported spk epk",
+			update: func(jwe *Envelope) {
+				withSPKHeaders(jwe, &spkHeaders{Typ: "jose", Alg: spkWrapAlg, Enc: "XC20P", EPK: JWK{Kty: "OKP"}})
+			},
+			errMsg: "unpack: sender key: unsupported epk: OKP ",
+		},
+		{
+			name: "invalid spk encoding",
+			update: func(jwe *Envelope) {
+				parts := strings.Split(jwe.Recipients[0].Header.SPK, ".")
+				parts[3] = "!"
+				jwe.Recipients[0].Header.SPK = strings.Join(parts, ".")
+			},
+			errMsg: "unpack: sender key: illegal base64 data",
+		},
+		{
+			name: "bad spk nonce size",
+			update: func(jwe *Envelope) {
+				parts := strings.Split(jwe.Recipients[0].Header.SPK, ".")
+				parts[2] = base64.RawURLEncoding.EncodeToString([]byte("nonce"))
+				jwe.Recipients[0].Header.SPK = strings.Join(parts, ".")
+			},
+			errMsg: "unpack: sender key: bad nonce size",
+		},
+		{
+			name: "tampered spk headers",
+			update: func(jwe *Envelope) {
+				h, e := decodeSPKHeaders(strings.Split(jwe.Recipients[0].Header.SPK, ".")[0])
+				require.NoError(t, e)
+
+				h.Typ = "tampered"
+				withSPKHeaders(jwe, h)
+			},
+			errMsg: "unpack: sender key: chacha20poly1305: message authentication failed",
+		},
+		{
+			name: "spk of another sender",
+			update: func(jwe *Envelope) {
+				jwe.Recipients[0].Header.SPK = spk(base58.Encode(createKeys(t, newProvider(t), 1)[0]))
+			},
+			errMsg: "unpack: decrypt shared key",
+		},
+		{
+			name: "unsupported content encryption",
+			update: func(jwe *Envelope) {
+				jwe.Protected = protected(&jweHeaders{Typ: encodingType, Alg: keyWrapAlg, Enc: "A256GCM"})
+			},
+			errMsg: "unpack: algorithm not supported",
+		},
+		{
+			name:   "recipient not found",
+			update: func(jwe *Envelope) { jwe.Recipients[0].Header.KID = base58.Encode(bytes.Repeat([]byte{1}, 32)) },
+			errMsg: "unpack: " + errRecipientNotFound.Error(),
+		},
+		{
+			name:   "unsupported epk",
+			update: func(jwe *Envelope) { jwe.Recipients[0].Header.EPK.Crv = "P-256" },
+			errMsg: "unpack: decrypt shared key: unsupported epk: OKP P-256",
+		},
+		{
+			name: "invalid epk y",
+			update: func(jwe *Envelope) {
+				jwe.Recipients[0].Header.EPK = JWK{Kty: "EC", Crv: "P-256", X: "", Y: "!"}
+			},
+			errMsg: "unpack: decrypt shared key: illegal base64 data",
+		},
+		{
+			name:   "invalid epk",
+			update: func(jwe *Envelope) { jwe.Recipients[0].Header.EPK.X = "!" },
+			errMsg: "unpack: decrypt shared key: illegal base64 data",
+		},
+		{
+			name:   "invalid encrypted key encoding",
+			update: func(jwe *Envelope) { jwe.Recipients[0].EncryptedKey = "!" },
+			errMsg: "unpack: decrypt shared key: illegal base64 data",
+		},
+		{
+			name: "invalid encrypted key",
+			update: func(jwe *Envelope) {
+				jwe.Recipients[0].EncryptedKey = base64.RawURLEncoding.EncodeToString(make([]byte, 40))
+			},
+			errMsg: "unpack: decrypt shared key",
+		},
+		{
+			name:   "invalid cipher text",
+			update: func(jwe *Envelope) { jwe.CipherText = "!" },
+			errMsg: "unpack: illegal base64 data",
+		},
+		{
+			name:   "invalid tag",
+			update: func(jwe *Envelope) { jwe.Tag = "!" },
+			errMsg: "unpack: illegal base64 data",
+		},
+		{
+			name:   "invalid iv",
+			update: func(jwe *Envelope) { jwe.IV = "!" },
+			errMsg: "unpack: illegal base64 data",
+		},
+		{
+			name:   "bad nonce size",
+			update: func(jwe *Envelope) { jwe.IV = base64.RawURLEncoding.EncodeToString([]byte("nonce")) },
+			errMsg: "unpack: bad nonce size",
+		},
+		{
+			name:   "tampered aad",
+			update: func(jwe *Envelope) { jwe.AAD = base64.RawURLEncoding.EncodeToString([]byte("tampered")) },
+			errMsg: "unpack: chacha20poly1305: message authentication failed",
+		},
+		{
+			name:   "tampered cipher text",
+			update: func(jwe *Envelope) { jwe.CipherText = base64.RawURLEncoding.EncodeToString([]byte("tampered")) },
+			errMsg: "unpack: chacha20poly1305: message authentication failed",
+		},
 	}
 
-	envelope.CipherText = envelope2.CipherText
-	envelope.IV = envelope2.IV
-	envelope.Protected = envelope2.Protected
-	envelope.AAD = envelope2.AAD
-	envelope.Tag = envelope2.Tag
-}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			err := unpack(tc.update)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
 
-func prettyPrint(msg []byte) (string, error) {
-	var prettyJSON bytes.Buffer
+	t.Run("invalid envelope", func(t *testing.T) {
+		_, err := packer.Unpack([]byte("invalid"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unpack json")
+	})
 
-	err := json.Indent(&prettyJSON, msg, "", "\t")
-	if err != nil {
-		return "", err
-	}
+	t.Run("unwrap key failure", func(t *testing.T) {
+		p, e := New(&mockprovider.Provider{
+			KeyManagerValue: prov.KMS(),
+			CryptoValue:     &mockcrypto.Crypto{UnwrapError: errors.New("unwrap error")},
+		}, XC20P)
+		require.NoError(t, e)
 
-	return prettyJSON.String(), nil
+		_, e = p.Unpack(envelope)
+		require.EqualError(t, e, "unpack: sender key: unwrap error")
+	})
 }
 
 func TestBadCreateCipher(t *testing.T) {
@@ -605,61 +589,16 @@ func TestBadCreateCipher(t *testing.T) {
 	require.Error(t, err)
 }
 
-func TestRefEncrypt(t *testing.T) {
-	// reference php crypto material similar to
-	// https://github.com/hyperledger/aries-rfcs/issues/133#issuecomment-518922447
-	var recipientPrivStr = "c8CSJr_27PN9xWCpzXNmepRndD6neQcnO9DS0YWjhNs"
-	recipientPriv, err := base64.RawURLEncoding.DecodeString(recipientPrivStr)
-	require.NoError(t, err)
-
-	var recipientPubStr = "AAjrHjiFLw6kf6CZ5zqH1ooG3y2aQhuqxmUvqJnIvDI"
-	recipientPub, err := base64.RawURLEncoding.DecodeString(recipientPubStr)
-	require.NoError(t, err)
+type failingReader struct {
+	failAfter int
+}
 
-	senderVerKeypCombo := &cryptoutil.MessagingKeys{
-		SigKeyPair: &cryptoutil.SigKeyPair{
-			KeyPair: cryptoutil.KeyPair{Priv: recipientPub, Pub: recipientPub}, // mocking signature keys as enc keys
-			Alg:     cryptoutil.EdDSA,
-		},
-		EncKeyPair: &cryptoutil.EncKeyPair{
-			KeyPair: cryptoutil.KeyPair{Pub: recipientPub, Priv: recipientPriv},
-			Alg:     cryptoutil.Curve25519,
-		},
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.failAfter == 0 {
+		return 0, errors.New("read error")
 	}
-	// create mockkms provider with the above keys
-	mockKMSProvider, err := mockkms.NewMockProvider(senderVerKeypCombo)
-	require.NoError(t, err)
 
-	// refJWE created by executing PHP test code at:
-	// https://github.com/gamringer/php-authcrypt/blob/master/examples/1-crypt.php
-	//nolint:lll
-	const refJWE = `{
-    "protected": "eyJ0eXAiOiJwcnMuaHlwZXJsZWRnZXIuYXJpZXMtYXV0aC1tZXNzYWdlIiwiYWxnIjoiRUNESC1TUytYQzIwUEtXIiwiZW5jIjoiWEMyMFAifQ",
-    "recipients": [
-        {
-            "encrypted_key": "46R0uW5KUbaZYt5PpIW5j1v_H8BS2SLrdPEzUaK8V0U",
-            "header": {
-                "apu": "tDzm-bgMblZUgzONI7NTHcSqObP9NX21Vkeid8RFf-PzbJrdU3ApC_f0fDfZVxTwyw-5OZQcTti1H1esIfBFvg",
-                "iv": "5HTxplQx5sOfwWtfR5oK416ahbRChh-b",
-                "tag": "qrtr29m4EKh5WV6l47fcCw",
-                "kid": "18tUZoFCoRVEHdxTyNLRxzcKYV7ZyBm98gunvcChKr1",
-                "spk": "eyJ0eXAiOiJqb3NlIiwiY3R5IjoiandrK2pzb24iLCJhbGciOiJFQ0RILUVTK1hDMjBQS1ciLCJlbmMiOiJYQzIwUCIsImVwayI6eyJrdHkiOiJPS1AiLCJjcnYiOiJYMjU1MTkiLCJ4IjoiT0ZkRlN3bTR5Sm5oZmxZNUNZZ1FSVG9ra2ExNHQ0VnNCM216M0N4XzZuayJ9LCJpdiI6Ik5SZkp6Z1N5UE9JU3dOMURSR3lTSERXcXVqdUVXQmgtIiwidGFnIjoibTFsekRSTTl5VEp5cEJOYkVnSE5adyJ9.KIcpv4hUlq0gAb8FpWkSWFnlcshrdNRz51iVoTFyy7E.53YTian9wG5u-S2J2YTjI1TayqW-YMuL.uw6ucr25OIZTfsGQRp8t9fllV0ClBmuhblnTHG6hlh0EEqAWal9jgd6jDbf6Xb_HPzpLSfX7uwYTA11Ui7jZloP8aRjnAKsiEO1-4d-R.GTwXUgcy89zjIAi1Z4WpIA"
-            }
-        }
-    ],
-    "aad": "rC0KS-IDOnn39WJvPXJQmP3M5qd_Ax4sYidWXdXSIek",
-    "iv": "JS2FxjEKdndnt-J7QX5pEnVwyBTu0_3d",
-    "tag": "2FqZMMQuNPYfL0JsSkj8LQ",
-    "ciphertext": "qQyzvajdvCDJbwxM"
-}`
-
-	packer, err := New(mockKMSProvider, XC20P)
-	require.NoError(t, err)
-	require.NotNil(t, packer)
+	r.failAfter--
 
-	env, err := packer.Unpack([]byte(refJWE))
-	require.NoError(t, err)
-	require.NotEmpty(t, env)
-	require.NotEmpty(t, env.Message)
-	require.NotEmpty(t, env.FromVerKey)
+	return len(p), nil
 }
@@ -15,6 +15,18 @@ import (
 
 // TODO https://github.com/hyperledger/aries-framework-go/issues/475 pull cipher into separate crypter
 
+// nonceSizeOf returns the nonce size of the content encryption algorithm alg
+func nonceSizeOf(alg ContentEncryption) (int, error) {
+	switch alg {
+	case C20P:
+		return chacha.NonceSize, nil
+	case XC20P:
+		return chacha.NonceSizeX, nil
+	default:
+		return 0, errUnsupportedAlg
+	}
+}
+
 // createCipher will create and return a new Chacha20Poly1305 cipher for the given nonceSize and symmetric key
 func createCipher(nonceSize int, symKey []byte) (cipher.AEAD, error) {
 	switch nonceSize {
@@ -10,6 +10,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -18,43 +19,54 @@ import (
 	chacha "golang.org/x/crypto/chacha20poly1305"
 	"golang.org/x/crypto/poly1305"
 
-	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/jwe/internal/ecdhkey"
 )
 
 // Pack will JWE encode the payload argument for the sender and recipients
 // Using (X)Chacha20 encryption algorithm and Poly1305 authenticator
-// It will encrypt by fetching the sender's encryption key corresponding to senderVerKey and converting the list
-// of recipientsVerKeys into a list of encryption keys
-func (p *Packer) Pack(payload, senderVerKey []byte, recipientsVerKeys [][]byte) ([]byte, error) { //nolint:funlen
-	senderPubKey, err := p.getSenderPubEncKey(senderVerKey)
-	if err != nil {
-		return nil, err
+// It will encrypt by fetching the sender's key handle corresponding to senderVerKey from the KMS and wrapping
+// the content encryption key with ECDH-1PU for each recipient's encryption key converted from recipientsVerKeys.
+// The keys are either Ed25519 verification keys (X25519 key agreement) or NIST P-256, P-384 or P-521 keys in
+// uncompressed form, the sender and the recipients keys must be on the same curve.
+// The sender's key ID is encrypted for each recipient in its `spk` header.
+func (p *Packer) Pack(payload, senderVerKey []byte, recipientsVerKeys [][]byte) ([]byte, error) {
+	if len(senderVerKey) == 0 {
+		return nil, errors.New("failed to pack message: empty sender key")
 	}
 
-	headers := jweHeaders{
-		Typ: encodingType,
-		Alg: "ECDH-SS+" + string(p.alg) + "KW",
-		Enc: string(p.alg),
+	skid := base58.Encode(senderVerKey)
+
+	senderKH, err := p.kms.Get(skid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack message: get sender key: %w", err)
 	}
 
 	if len(recipientsVerKeys) == 0 {
-		return nil, fmt.Errorf("failed to pack message: empty recipients")
+		return nil, errors.New("failed to pack message: empty recipients")
 	}
 
-	chachaRecipients, err := p.convertRecipients(recipientsVerKeys)
+	senderPubKey, err := ecdhkey.PublicKey(senderVerKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to pack message: %w", err)
+		return nil, fmt.Errorf("failed to pack message: sender key: %w", err)
 	}
 
-	aad := buildAAD(chachaRecipients)
-	aadEncoded := base64.RawURLEncoding.EncodeToString(aad)
+	recipients, err := convertRecipients(senderPubKey.Curve, recipientsVerKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack message: %w", err)
+	}
 
-	h, err := json.Marshal(headers)
+	h, err := json.Marshal(jweHeaders{
+		Typ: encodingType,
+		Alg: keyWrapAlg,
+		Enc: string(p.alg),
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	encHeaders := base64.RawURLEncoding.EncodeToString(h)
+	aadEncoded := base64.RawURLEncoding.EncodeToString(buildAAD(recipients))
 	// build the Payload's AAD string
 	pldAAD := encHeaders + "." + aadEncoded
 
@@ -66,17 +78,16 @@ func (p *Packer) Pack(payload, senderVerKey []byte, recipientsVerKeys [][]byte)
 		return nil, err
 	}
 
-	nonceEncoded := base64.RawURLEncoding.EncodeToString(nonce)
-	cek := &[chacha.KeySize]byte{}
+	cek := make([]byte, chacha.KeySize)
 
 	// generate a cek for encryption (it will be treated as a symmetric key)
-	_, err = p.randReader.Read(cek[:])
+	_, err = p.randReader.Read(cek)
 	if err != nil {
 		return nil, err
 	}
 
 	// create a cipher for the given nonceSize and generated cek above
-	cipher, err := createCipher(p.nonceSize, cek[:])
+	cipher, err := createCipher(p.nonceSize, cek)
 	if err != nil {
 		return nil, err
 	}
@@ -85,60 +96,42 @@ func (p *Packer) Pack(payload, senderVerKey []byte, recipientsVerKeys [][]byte)
 	// the output is a []byte containing the cipherText + tag
 	symOutput := cipher.Seal(nil, nonce, payload, []byte(pldAAD))
 
-	tagEncoded := extractTag(symOutput)
-	cipherTextEncoded := extractCipherText(symOutput)
-
-	// now build, encode recipients and include the encrypted cek (with a recipient's ephemeral key)
-	encRec, err := p.encodeRecipients(cek, chachaRecipients, senderPubKey)
+	// now build, encode recipients and include the wrapped cek (with a recipient's ephemeral key)
+	encRec, err := p.encodeRecipients(cek, skid, recipients, senderKH)
 	if err != nil {
-		return nil, err
-	}
-
-	jwe, err := p.buildJWE(encHeaders, encRec, aadEncoded, nonceEncoded, tagEncoded, cipherTextEncoded)
-	if err != nil {
-		return nil, err
-	}
-
-	return jwe, nil
-}
-
-func (p *Packer) getSenderPubEncKey(senderVerKey []byte) (*[chacha.KeySize]byte, error) {
-	if len(senderVerKey) == 0 {
-		return nil, fmt.Errorf("failed to pack message: empty sender key")
-	}
-
-	senderKey, err := p.legacyKMS.GetEncryptionKey(senderVerKey)
-	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to pack message: %w", err)
 	}
 
-	senderPubKey := &[chacha.KeySize]byte{}
-	copy(senderPubKey[:], senderKey)
-
-	return senderPubKey, nil
+	return json.Marshal(&Envelope{
+		Protected:  encHeaders,
+		Recipients: encRec,
+		AAD:        aadEncoded,
+		IV:         base64.RawURLEncoding.EncodeToString(nonce),
+		Tag:        extractTag(symOutput),
+		CipherText: extractCipherText(symOutput),
+	})
 }
 
-// convertRecipients is a utility function that converts keys from signature keys ([][]byte type)
-// into encryption keys ([]*[chacha.KeySize]byte type)
-func (p *Packer) convertRecipients(recipients [][]byte) ([]*[chacha.KeySize]byte, error) {
-	var chachaRecipients []*[chacha.KeySize]byte
+// convertRecipients is a utility function that converts the recipients keys ([][]byte type) into public
+// encryption keys identified by the base58 encoded key. All recipients keys must be on the sender's curve
+func convertRecipients(curve string, recipients [][]byte) ([]*crypto.PublicKey, error) {
+	var pubKeys []*crypto.PublicKey
 
 	for i, rVer := range recipients {
-		if !cryptoutil.IsChachaKeyValid(rVer) {
-			return nil, fmt.Errorf("%w - for recipient %d", cryptoutil.ErrInvalidKey, i+1)
+		pubKey, err := ecdhkey.PublicKey(rVer)
+		if err != nil {
+			return nil, fmt.Errorf("%w - for recipient %d", err, i+1)
 		}
 
-		rEnc, err := cryptoutil.PublicEd25519toCurve25519(rVer)
-		if err != nil {
-			return nil, err
+		if pubKey.Curve != curve {
+			return nil, fmt.Errorf("%w: %s instead of %s - for recipient %d", errCurveMismatch, pubKey.Curve,
+				curve, i+1)
 		}
 
-		chachaRec := new([chacha.KeySize]byte)
-		copy(chachaRec[:], rEnc)
-		chachaRecipients = append(chachaRecipients, chachaRec)
+		pubKeys = append(pubKeys, pubKey)
 	}
 
-	return chachaRecipients, nil
+	return pubKeys, nil
 }
 
 // extractTag is a utility function that extracts base64UrlEncoded tag sub-slice from symOutput returned by cipher.Seal
@@ -161,33 +154,13 @@ func extractCipherText(symOutput []byte) string {
 	return base64.RawURLEncoding.EncodeToString(cipherText)
 }
 
-// buildJWE builds the JSON object representing the JWE output of the encryption
-// and returns its marshaled []byte representation
-func (p *Packer) buildJWE(headers string, recipients []Recipient, aad, iv, tag, cipherText string) ([]byte, error) { //nolint:lll
-	jwe := Envelope{
-		Protected:  headers,
-		Recipients: recipients,
-		AAD:        aad,
-		IV:         iv,
-		Tag:        tag,
-		CipherText: cipherText,
-	}
-
-	jweBytes, err := json.Marshal(jwe)
-	if err != nil {
-		return nil, err
-	}
-
-	return jweBytes, nil
-}
-
 // buildAAD is a utility function to build the Additional Authentication Data for the AEAD (chach20poly1305) cipher.
-// the build takes the list of recipients keys base58 encoded and sorted then SHA256 hash
+// the build takes the list of recipients key IDs (base58 encoded verification keys) sorted then SHA256 hash
 // the concatenation of these keys with a '.' separator
-func buildAAD(recipients []*[chacha.KeySize]byte) []byte {
+func buildAAD(recipients []*crypto.PublicKey) []byte {
 	var keys []string
 	for _, r := range recipients {
-		keys = append(keys, base58.Encode(r[:]))
+		keys = append(keys, r.KID)
 	}
 
 	return hashAAD(keys)
@@ -202,13 +175,14 @@ func hashAAD(keys []string) []byte {
 	return sha[:]
 }
 
-// encodeRecipients is a utility function that will encrypt the cek (content encryption key) for each recipient
+// encodeRecipients is a utility function that will wrap the cek (content encryption key) for each recipient
 // and return a list of encoded recipient keys in a JWE compliant format ([]Recipient)
-func (p *Packer) encodeRecipients(cek *[chacha.KeySize]byte, recipients []*[chacha.KeySize]byte, senderPubKey *[chacha.KeySize]byte) ([]Recipient, error) { //nolint:lll
+func (p *Packer) encodeRecipients(cek []byte, skid string, recipients []*crypto.PublicKey,
+	senderKH interface{}) ([]Recipient, error) {
 	var encodedRecipients []Recipient
 
-	for _, e := range recipients {
-		rec, err := p.encodeRecipient(cek, e, senderPubKey)
+	for _, r := range recipients {
+		rec, err := p.encodeRecipient(cek, skid, r, senderKH)
 		if err != nil {
 			return nil, err
 		}
@@ -219,81 +193,33 @@ func (p *Packer) encodeRecipients(cek *[chacha.KeySize]byte, recipients []*[chac
 	return encodedRecipients, nil
 }
 
-// encodeRecipient will encrypt the cek (content encryption key) with a recipientKey
-// by generating a new ephemeral key to be used by the recipient to later decrypt it
-// it returns a JWE compliant Recipient
-func (p *Packer) encodeRecipient(cek, recipientPubKey, senderPubKey *[chacha.KeySize]byte) (*Recipient, error) { //nolint:lll
-	// generate a random APU value (Agreement PartyUInfo: https://tools.ietf.org/html/rfc7518#section-4.6.1.2)
-	apu := make([]byte, 64)
-
-	_, err := p.randReader.Read(apu)
-	if err != nil {
-		return nil, err
-	}
-
-	// derive an ephemeral key for the sender and recipient
-	kek, err := p.legacyKMS.DeriveKEK([]byte(p.alg), apu, senderPubKey[:], recipientPubKey[:])
+// encodeRecipient will wrap the cek (content encryption key) with ECDH-1PU using the sender's key handle,
+// the recipient's public key and a new ephemeral key generated by the Crypto service
+// it returns a JWE compliant Recipient with the ephemeral public key in its `epk` header and the sender's key ID
+// skid encrypted for the recipient in its `spk` header
+func (p *Packer) encodeRecipient(cek []byte, skid string, recPubKey *crypto.PublicKey,
+	senderKH interface{}) (*Recipient, error) {
+	wk, err := p.crypto.WrapKey(cek, []byte(skid), nil, recPubKey, crypto.WithSender(senderKH))
 	if err != nil {
 		return nil, err
 	}
 
-	sharedKeyCipher, tag, nonce, err := p.encryptCEK(kek, cek[:])
+	epk, err := ecdhkey.ToJWK(&wk.EPK)
 	if err != nil {
 		return nil, err
 	}
 
-	spk, err := p.generateSPK(recipientPubKey, senderPubKey)
+	spk, err := p.generateSPK(skid, recPubKey)
 	if err != nil {
 		return nil, err
 	}
 
-	return p.buildRecipient(sharedKeyCipher, apu, spk, nonce, tag, recipientPubKey)
-}
-
-// buildRecipient will build a proper JSON formatted and JWE compliant Recipient
-func (p *Packer) buildRecipient(key string, apu []byte, spkEncoded, nonceEncoded, tagEncoded string, recipientKey *[chacha.KeySize]byte) (*Recipient, error) { //nolint:lll
-	recipientHeaders := RecipientHeaders{
-		APU: base64.RawURLEncoding.EncodeToString(apu),
-		IV:  nonceEncoded,
-		Tag: tagEncoded,
-		KID: base58.Encode(recipientKey[:]),
-		SPK: spkEncoded,
-	}
-
-	recipient := &Recipient{
-		EncryptedKey: key,
-		Header:       recipientHeaders,
-	}
-
-	return recipient, nil
-}
-
-// encryptCEK will encrypt symKey with the given kek and a newly generated nonce
-// returns:
-// 		encrypted cipher of symKey
-//		resulting tag of the encryption
-//		generated nonce used by the encryption
-//		error in case of failure
-func (p *Packer) encryptCEK(kek, cek []byte) (string, string, string, error) {
-	cipher, err := createCipher(p.nonceSize, kek)
-	if err != nil {
-		return "", "", "", err
-	}
-
-	// create a new nonce
-	nonce := make([]byte, p.nonceSize)
-
-	_, err = p.randReader.Read(nonce)
-	if err != nil {
-		return "", "", "", err
-	}
-
-	// encrypt symmetric shared key using the key encryption key (kek)
-	kekOutput := cipher.Seal(nil, nonce, cek, nil)
-
-	symKeyCipherEncoded := extractCipherText(kekOutput)
-	tagEncoded := extractTag(kekOutput)
-	nonceEncoded := base64.RawURLEncoding.EncodeToString(nonce)
-
-	return symKeyCipherEncoded, tagEncoded, nonceEncoded, nil
+	return &Recipient{
+		EncryptedKey: base64.RawURLEncoding.EncodeToString(wk.EncryptedCEK),
+		Header: RecipientHeaders{
+			KID: recPubKey.KID,
+			EPK: JWK(*epk),
+			SPK: spk,
+		},
+	}, nil
 }
@@ -0,0 +1,167 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package authcrypt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	chacha "golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/jwe/internal/ecdhkey"
+)
+
+// spkHeaders are the protected headers of the compact JWE found in the `spk` header of a recipient
+type spkHeaders struct {
+	Typ string `json:"typ,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Enc string `json:"enc,omitempty"`
+	EPK JWK    `json:"epk,omitempty"`
+}
+
+// generateSPK will encrypt the sender's key ID skid (base58 encoded verification key) for the recipient
+// recPubKey, the output is a compact JWE with the key wrapped with ECDH-ES (anonymous sender) so that
+// only the recipient can find out who sent the envelope
+func (p *Packer) generateSPK(skid string, recPubKey *crypto.PublicKey) (string, error) {
+	cek := make([]byte, chacha.KeySize)
+
+	_, err := p.randReader.Read(cek)
+	if err != nil {
+		return "", err
+	}
+
+	wk, err := p.crypto.WrapKey(cek, nil, nil, recPubKey)
+	if err != nil {
+		return "", err
+	}
+
+	epk, err := ecdhkey.ToJWK(&wk.EPK)
+	if err != nil {
+		return "", err
+	}
+
+	h, err := json.Marshal(spkHeaders{
+		Typ: "jose",
+		Alg: spkWrapAlg,
+		Enc: string(p.alg),
+		EPK: JWK(*epk),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	headersEncoded := base64.RawURLEncoding.EncodeToString(h)
+
+	nonce := make([]byte, p.nonceSize)
+
+	_, err = p.randReader.Read(nonce)
+	if err != nil {
+		return "", err
+	}
+
+	cipher, err := createCipher(p.nonceSize, cek)
+	if err != nil {
+		return "", err
+	}
+
+	// encrypt the sender's key ID using the encoded headers as AAD
+	symOutput := cipher.Seal(nil, nonce, []byte(skid), []byte(headersEncoded))
+
+	return headersEncoded + "." +
+			base64.RawURLEncoding.EncodeToString(wk.EncryptedCEK) + "." +
+			base64.RawURLEncoding.EncodeToString(nonce) + "." +
+			extractCipherText(symOutput) + "." +
+			extractTag(symOutput),
+		nil
+}
+
+// decryptSPK will decrypt the sender's key ID found in the `spk` header of recipient using the recipient's
+// key handle recKH
+func (p *Packer) decryptSPK(recKH interface{}, recipient *Recipient) (string, error) {
+	const jweNumComponents = 5
+
+	jwe := strings.Split(recipient.Header.SPK, ".")
+	if len(jwe) != jweNumComponents {
+		return "", errors.New("bad SPK format")
+	}
+
+	headers, err := decodeSPKHeaders(jwe[0])
+	if err != nil {
+		return "", err
+	}
+
+	var decoded [jweNumComponents - 1][]byte
+
+	for i := range decoded {
+		decoded[i], err = base64.RawURLEncoding.DecodeString(jwe[i+1])
+		if err != nil {
+			return "", err
+		}
+	}
+
+	encryptedCEK, nonce, cipherText, tag := decoded[0], decoded[1], decoded[2], decoded[3]
+
+	nonceSize, err := nonceSizeOf(ContentEncryption(headers.Enc))
+	if err != nil {
+		return "", err
+	}
+
+	if len(nonce) != nonceSize {
+		return "", errors.New("bad nonce size")
+	}
+
+	epk, err := ecdhkey.FromJWK((*ecdhkey.JWK)(&headers.EPK))
+	if err != nil {
+		return "", err
+	}
+
+	cek, err := p.crypto.UnwrapKey(&crypto.RecipientWrappedKey{
+		KID:          recipient.Header.KID,
+		EncryptedCEK: encryptedCEK,
+		EPK:          *epk,
+		Alg:          spkWrapAlg,
+	}, recKH)
+	if err != nil {
+		return "", err
+	}
+
+	cipher, err := createCipher(nonceSize, cek)
+	if err != nil {
+		return "", err
+	}
+
+	skid, err := cipher.Open(nil, nonce, append(cipherText, tag...), []byte(jwe[0]))
+	if err != nil {
+		return "", err
+	}
+
+	return string(skid), nil
+}
+
+func decodeSPKHeaders(encoded string) (*spkHeaders, error) {
+	h, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := &spkHeaders{}
+
+	err = json.Unmarshal(h, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	if headers.Alg != spkWrapAlg {
+		return nil, fmt.Errorf("%w: %s", errUnsupportedAlg, headers.Alg)
+	}
+
+	return headers, nil
+}
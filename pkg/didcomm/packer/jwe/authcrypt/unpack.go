@@ -13,17 +13,19 @@ import (
 	"fmt"
 
 	"github.com/btcsuite/btcutil/base58"
-	chacha "golang.org/x/crypto/chacha20poly1305"
 
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/jwe/internal/ecdhkey"
 )
 
-// Unpack will JWE decode the envelope argument for the recipientPrivKey and validates
-// the envelope's recipients has a match for recipientKeyPair.Pub key.
-// Using (X)Chacha20 cipher and Poly1305 authenticator for the encrypted payload and
-// encrypted CEK.
-// The current recipient is the one with the sender's encrypted key that successfully
-// decrypts with recipientKeyPair.Priv Key.
+// errRecipientNotFound is returned when none of the envelope's recipients has a key in the KMS
+var errRecipientNotFound = errors.New("no recipient key found in the KMS")
+
+// Unpack will JWE decode the envelope argument for the first recipient found in the KMS.
+// The sender is identified by its key ID (base58 encoded key) decrypted from the recipient's `spk` header, its
+// public encryption key is used to unwrap the recipient's CEK with ECDH-1PU.
+// Using (X)Chacha20 cipher and Poly1305 authenticator for the encrypted payload.
 func (p *Packer) Unpack(envelope []byte) (*transport.Envelope, error) {
 	jwe := &Envelope{}
 
@@ -32,127 +34,134 @@ func (p *Packer) Unpack(envelope []byte) (*transport.Envelope, error) {
 		return nil, fmt.Errorf("unpack json: %w", err)
 	}
 
-	recipientPubKey, recipient, err := p.findRecipient(jwe.Recipients)
+	headers, err := decodeHeaders(jwe.Protected)
 	if err != nil {
 		return nil, fmt.Errorf("unpack: %w", err)
 	}
 
-	senderKey, err := p.decryptSPK(recipientPubKey, recipient.Header.SPK)
+	recKH, recipient, err := p.findRecipient(jwe.Recipients)
 	if err != nil {
-		return nil, fmt.Errorf("unpack: sender key: %w", err)
-	}
-
-	// senderKey must not be empty to proceed
-	if senderKey != nil {
-		senderPubKey := new([chacha.KeySize]byte)
-		copy(senderPubKey[:], senderKey)
-
-		sharedKey, er := p.decryptCEK(recipientPubKey, senderPubKey, recipient)
-		if er != nil {
-			return nil, fmt.Errorf("unpack: decrypt shared key: %w", er)
-		}
-
-		symOutput, er := p.decryptPayload(sharedKey, jwe)
-		if er != nil {
-			return nil, fmt.Errorf("unpack: %w", er)
-		}
-
-		return &transport.Envelope{
-			Message:    symOutput,
-			FromVerKey: senderKey,
-			ToVerKey:   recipientPubKey[:],
-		}, nil
+		return nil, fmt.Errorf("unpack: %w", err)
 	}
 
-	return nil, errors.New("unpack: invalid sender key in envelope")
-}
-
-func (p *Packer) decryptPayload(cek []byte, jwe *Envelope) ([]byte, error) {
-	cipher, err := createCipher(p.nonceSize, cek)
+	skid, err := p.decryptSPK(recKH, recipient)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("unpack: sender key: %w", err)
 	}
 
-	pldAAD := jwe.Protected + "." + jwe.AAD
+	senderVerKey := base58.Decode(skid)
 
-	payload, err := base64.RawURLEncoding.DecodeString(jwe.CipherText)
+	senderPubKey, err := ecdhkey.PublicKey(senderVerKey)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("unpack: sender key: %w", err)
 	}
 
-	tag, err := base64.RawURLEncoding.DecodeString(jwe.Tag)
+	cek, err := p.decryptCEK(recKH, []byte(skid), senderPubKey, recipient)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("unpack: decrypt shared key: %w", err)
 	}
 
-	nonce, err := base64.RawURLEncoding.DecodeString(jwe.IV)
+	symOutput, err := p.decryptPayload(headers.Enc, cek, jwe)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("unpack: %w", err)
 	}
 
-	payload = append(payload, tag...)
-
-	return cipher.Open(nil, nonce, payload, []byte(pldAAD))
+	return &transport.Envelope{
+		Message:    symOutput,
+		FromVerKey: senderVerKey,
+		ToVerKey:   base58.Decode(recipient.Header.KID),
+	}, nil
 }
 
-// findRecipient will loop through jweRecipients and returns the first matching key from the legacyKMS
-func (p *Packer) findRecipient(jweRecipients []Recipient) (*[chacha.KeySize]byte, *Recipient, error) {
-	var recipientsKeys []string
-	for _, recipient := range jweRecipients {
-		recipientsKeys = append(recipientsKeys, recipient.Header.KID)
+func decodeHeaders(protected string) (*jweHeaders, error) {
+	h, err := base64.RawURLEncoding.DecodeString(protected)
+	if err != nil {
+		return nil, fmt.Errorf("decode headers: %w", err)
 	}
 
-	i, err := p.legacyKMS.FindVerKey(recipientsKeys)
+	headers := &jweHeaders{}
+
+	err = json.Unmarshal(h, headers)
 	if err != nil {
-		return nil, nil, err
+		return nil, fmt.Errorf("parse headers: %w", err)
 	}
 
-	pubK := new([chacha.KeySize]byte)
-	copy(pubK[:], base58.Decode(recipientsKeys[i]))
+	if headers.Alg != keyWrapAlg {
+		return nil, fmt.Errorf("%w: %s", errUnsupportedAlg, headers.Alg)
+	}
 
-	return pubK, &jweRecipients[i], nil
+	return headers, nil
 }
 
-// decryptCEK will decrypt the CEK found in recipient using recipientKp's private key and senderPubKey
-func (p *Packer) decryptCEK(recipientPubKey, senderPubKey *[chacha.KeySize]byte, recipient *Recipient) ([]byte, error) { //nolint:lll
-	apu, err := base64.RawURLEncoding.DecodeString(recipient.Header.APU)
+func (p *Packer) decryptPayload(enc string, cek []byte, jwe *Envelope) ([]byte, error) {
+	nonceSize, err := nonceSizeOf(ContentEncryption(enc))
 	if err != nil {
 		return nil, err
 	}
 
-	nonce, err := base64.RawURLEncoding.DecodeString(recipient.Header.IV)
+	cipher, err := createCipher(nonceSize, cek)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(nonce) != p.nonceSize {
-		return nil, errors.New("bad nonce size")
-	}
+	pldAAD := jwe.Protected + "." + jwe.AAD
 
-	tag, err := base64.RawURLEncoding.DecodeString(recipient.Header.Tag)
+	payload, err := base64.RawURLEncoding.DecodeString(jwe.CipherText)
 	if err != nil {
 		return nil, err
 	}
 
-	encryptedCEK, err := base64.RawURLEncoding.DecodeString(recipient.EncryptedKey)
+	tag, err := base64.RawURLEncoding.DecodeString(jwe.Tag)
 	if err != nil {
 		return nil, err
 	}
 
-	// derive an ephemeral key for the recipient
-	kek, err := p.legacyKMS.DeriveKEK([]byte(p.alg), apu, recipientPubKey[:], senderPubKey[:])
+	nonce, err := base64.RawURLEncoding.DecodeString(jwe.IV)
 	if err != nil {
 		return nil, err
 	}
 
-	// create a new (chacha20poly1305) cipher with this new key to decrypt the cek
-	cipher, err := createCipher(p.nonceSize, kek)
+	if len(nonce) != nonceSize {
+		return nil, errors.New("bad nonce size")
+	}
+
+	payload = append(payload, tag...)
+
+	return cipher.Open(nil, nonce, payload, []byte(pldAAD))
+}
+
+// findRecipient will loop through jweRecipients and returns the key handle of the first recipient
+// found in the KMS
+func (p *Packer) findRecipient(jweRecipients []Recipient) (interface{}, *Recipient, error) {
+	for i := range jweRecipients {
+		kh, err := p.kms.Get(jweRecipients[i].Header.KID)
+		if err == nil {
+			return kh, &jweRecipients[i], nil
+		}
+	}
+
+	return nil, nil, errRecipientNotFound
+}
+
+// decryptCEK will unwrap the CEK found in recipient using the recipient's key handle recKH, the sender's
+// public key and the ephemeral public key from the recipient's `epk` header
+func (p *Packer) decryptCEK(recKH interface{}, apu []byte, senderPubKey *crypto.PublicKey,
+	recipient *Recipient) ([]byte, error) {
+	epk, err := ecdhkey.FromJWK((*ecdhkey.JWK)(&recipient.Header.EPK))
 	if err != nil {
 		return nil, err
 	}
 
-	cipherText := encryptedCEK
-	cipherText = append(cipherText, tag...)
+	encryptedCEK, err := base64.RawURLEncoding.DecodeString(recipient.EncryptedKey)
+	if err != nil {
+		return nil, err
+	}
 
-	return cipher.Open(nil, nonce, cipherText, nil)
+	return p.crypto.UnwrapKey(&crypto.RecipientWrappedKey{
+		KID:          recipient.Header.KID,
+		EncryptedCEK: encryptedCEK,
+		EPK:          *epk,
+		Alg:          keyWrapAlg,
+		APU:          apu,
+	}, recKH, crypto.WithSender(senderPubKey))
 }
@@ -0,0 +1,178 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ecdhkey converts the keys of the senders and the recipients of the JWE packers (Authcrypt and
+// Anoncrypt) into public ECDH keys and the ephemeral keys of the envelopes from and into their JWK format.
+package ecdhkey
+
+import (
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/ecdh"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
+)
+
+// JWK is an ephemeral public key in JWK format, as found in the `epk` header of the JWE recipients
+type JWK struct {
+	Kty string
+	Crv string
+	X   string
+	Y   string
+}
+
+// PublicKey converts the key of a sender or a recipient, as found in DID documents, into its public encryption
+// key identified by the base58 encoded key (the `kid` header and the sender key ID encrypted in `spk` headers,
+// also used as keyset ID in the KMS).
+// Supported keys are:
+// Ed25519 verification keys (32 bytes), converted into X25519 encryption keys
+// NIST P-256, P-384 and P-521 keys in uncompressed form (elliptic.Marshal), used as is for ECDH
+func PublicKey(key []byte) (*crypto.PublicKey, error) {
+	if len(key) == ed25519.PublicKeySize {
+		if !cryptoutil.IsChachaKeyValid(key) {
+			return nil, cryptoutil.ErrInvalidKey
+		}
+
+		encKey, err := cryptoutil.PublicEd25519toCurve25519(key)
+		if err != nil {
+			return nil, err
+		}
+
+		return &crypto.PublicKey{
+			KID:   base58.Encode(key),
+			X:     encKey,
+			Curve: ecdh.X25519,
+			Type:  ecdh.OKPType,
+		}, nil
+	}
+
+	// an uncompressed point is 0x04 || X || Y
+	crv, ok := nistCurveOfSize((len(key) - 1) / 2) // nolint:gomnd
+	if !ok {
+		return nil, cryptoutil.ErrInvalidKey
+	}
+
+	x, y := elliptic.Unmarshal(crv, key)
+	if x == nil {
+		return nil, cryptoutil.ErrInvalidKey
+	}
+
+	return &crypto.PublicKey{
+		KID:   base58.Encode(key),
+		X:     x.Bytes(),
+		Y:     y.Bytes(),
+		Curve: crv.Params().Name,
+		Type:  ecdh.ECType,
+	}, nil
+}
+
+// nistCurveOfSize returns the NIST curve with coordinates of size bytes
+func nistCurveOfSize(size int) (elliptic.Curve, bool) {
+	for _, crv := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		if coordinateSize(crv) == size {
+			return crv, true
+		}
+	}
+
+	return nil, false
+}
+
+// nistCurve returns the NIST curve of the JWK crv name
+func nistCurve(name string) (elliptic.Curve, bool) {
+	switch name {
+	case ecdh.P256:
+		return elliptic.P256(), true
+	case ecdh.P384:
+		return elliptic.P384(), true
+	case ecdh.P521:
+		return elliptic.P521(), true
+	default:
+		return nil, false
+	}
+}
+
+// coordinateSize returns the size in bytes of the coordinates of crv
+func coordinateSize(crv elliptic.Curve) int {
+	const numBitsPerByte = 8
+
+	return (crv.Params().BitSize + numBitsPerByte - 1) / numBitsPerByte
+}
+
+// ToJWK converts the ephemeral public key epk into its JWK representation. The coordinates of NIST curve keys
+// are padded to the curve size as per https://tools.ietf.org/html/rfc7518#section-6.2.1.2
+func ToJWK(epk *crypto.PublicKey) (*JWK, error) {
+	if epk.Type == ecdh.OKPType && epk.Curve == ecdh.X25519 {
+		return &JWK{
+			Kty: epk.Type,
+			Crv: epk.Curve,
+			X:   base64.RawURLEncoding.EncodeToString(epk.X),
+		}, nil
+	}
+
+	crv, ok := nistCurve(epk.Curve)
+	if epk.Type != ecdh.ECType || !ok {
+		return nil, fmt.Errorf("unsupported epk: %s %s", epk.Type, epk.Curve)
+	}
+
+	size := coordinateSize(crv)
+
+	return &JWK{
+		Kty: epk.Type,
+		Crv: epk.Curve,
+		X:   base64.RawURLEncoding.EncodeToString(padLeft(epk.X, size)),
+		Y:   base64.RawURLEncoding.EncodeToString(padLeft(epk.Y, size)),
+	}, nil
+}
+
+// padLeft prepends zeros to the big-endian coordinate c up to size bytes
+func padLeft(c []byte, size int) []byte {
+	if len(c) >= size {
+		return c
+	}
+
+	padded := make([]byte, size)
+	copy(padded[size-len(c):], c)
+
+	return padded
+}
+
+// FromJWK converts the JWK ephemeral public key found in a recipient's `epk` header into a public key
+func FromJWK(jwk *JWK) (*crypto.PublicKey, error) {
+	_, isNIST := nistCurve(jwk.Crv)
+
+	switch {
+	case jwk.Kty == ecdh.OKPType && jwk.Crv == ecdh.X25519:
+	case jwk.Kty == ecdh.ECType && isNIST:
+	default:
+		return nil, fmt.Errorf("unsupported epk: %s %s", jwk.Kty, jwk.Crv)
+	}
+
+	x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+	if err != nil {
+		return nil, err
+	}
+
+	var y []byte
+
+	if isNIST {
+		y, err = base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &crypto.PublicKey{
+		X:     x,
+		Y:     y,
+		Curve: jwk.Crv,
+		Type:  jwk.Kty,
+	}, nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdhkey
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
+)
+
+func TestPublicKey(t *testing.T) {
+	t.Run("ed25519 key", func(t *testing.T) {
+		pub, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		pubKey, err := PublicKey(pub)
+		require.NoError(t, err)
+		require.Equal(t, base58.Encode(pub), pubKey.KID)
+		require.Equal(t, "OKP", pubKey.Type)
+		require.Equal(t, "X25519", pubKey.Curve)
+		require.Len(t, pubKey.X, 32)
+	})
+
+	t.Run("NIST keys", func(t *testing.T) {
+		for _, crv := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+			priv, err := ecdsa.GenerateKey(crv, rand.Reader)
+			require.NoError(t, err)
+
+			pub := elliptic.Marshal(crv, priv.X, priv.Y)
+
+			pubKey, err := PublicKey(pub)
+			require.NoError(t, err)
+			require.Equal(t, base58.Encode(pub), pubKey.KID)
+			require.Equal(t, "EC", pubKey.Type)
+			require.Equal(t, crv.Params().Name, pubKey.Curve)
+			require.Equal(t, priv.X.Bytes(), pubKey.X)
+			require.Equal(t, priv.Y.Bytes(), pubKey.Y)
+		}
+	})
+
+	t.Run("invalid NIST point", func(t *testing.T) {
+		key := make([]byte, 65)
+		key[0] = 4
+
+		_, err := PublicKey(key)
+		require.True(t, errors.Is(err, cryptoutil.ErrInvalidKey))
+	})
+
+	t.Run("unsupported key size", func(t *testing.T) {
+		_, err := PublicKey(make([]byte, 33))
+		require.True(t, errors.Is(err, cryptoutil.ErrInvalidKey))
+	})
+}
+
+func TestJWK(t *testing.T) {
+	t.Run("X25519 key round trip", func(t *testing.T) {
+		epk := &crypto.PublicKey{Type: "OKP", Curve: "X25519", X: make([]byte, 32)}
+
+		jwk, err := ToJWK(epk)
+		require.NoError(t, err)
+		require.Empty(t, jwk.Y)
+
+		key, err := FromJWK(jwk)
+		require.NoError(t, err)
+		require.Equal(t, epk, key)
+	})
+
+	t.Run("coordinates are padded to the curve size", func(t *testing.T) {
+		jwk, err := ToJWK(&crypto.PublicKey{Type: "EC", Curve: "P-521", X: []byte{1}, Y: make([]byte, 66)})
+		require.NoError(t, err)
+
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		require.NoError(t, err)
+		require.Len(t, x, 66)
+		require.Equal(t, byte(1), x[65])
+
+		key, err := FromJWK(jwk)
+		require.NoError(t, err)
+		require.Equal(t, x, key.X)
+		require.Len(t, key.Y, 66)
+	})
+
+	t.Run("unsupported epk", func(t *testing.T) {
+		_, err := ToJWK(&crypto.PublicKey{Type: "EC", Curve: "secp256k1"})
+		require.EqualError(t, err, "unsupported epk: EC secp256k1")
+
+		_, err = FromJWK(&JWK{Kty: "OKP", Crv: "P-256"})
+		require.EqualError(t, err, "unsupported epk: OKP P-256")
+	})
+
+	t.Run("invalid epk", func(t *testing.T) {
+		_, err := FromJWK(&JWK{Kty: "EC", Crv: "P-256", X: "!"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "illegal base64 data")
+
+		_, err = FromJWK(&JWK{Kty: "EC", Crv: "P-256", Y: "!"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "illegal base64 data")
+	})
+}
@@ -20,7 +20,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/ed25519"
 
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/legacykms"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms/legacykms"
 	mockStorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
@@ -125,6 +127,14 @@ func (p *provider) LegacyKMS() legacykms.KeyManager {
 	return p.crypto
 }
 
+func (p *provider) KMS() kms.KeyManager {
+	return nil
+}
+
+func (p *provider) Crypto() crypto.Crypto {
+	return nil
+}
+
 func newWithKMS(k legacykms.KeyManager) *Packer {
 	return New(&provider{
 		crypto: k,
@@ -193,6 +193,11 @@ func (s *Service) Name() string {
 	return DIDExchange
 }
 
+// Spec returns the specification URI of the protocol.
+func (s *Service) Spec() string {
+	return DIDExchangeSpec
+}
+
 func findNamespace(msgType string) string {
 	namespace := theirNSPrefix
 	if msgType == InvitationMsgType || msgType == ResponseMsgType {
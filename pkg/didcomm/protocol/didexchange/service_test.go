@@ -49,6 +49,7 @@ func TestService_Name(t *testing.T) {
 		})
 		require.NoError(t, err)
 		require.Equal(t, DIDExchange, prov.Name())
+		require.Equal(t, DIDExchangeSpec, prov.Spec())
 	})
 }
 
@@ -0,0 +1,22 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didsync
+
+import vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+
+// Delta DID sync message updating the peer DID document of the sender.
+// The change is the base64url encoded updated DID document, signed by an authentication key of
+// the current DID document. The delta deactivating the DID has no change, its signature is the
+// one of the deactivation of the current DID document.
+type Delta struct {
+	Type        string               `json:"@type,omitempty"`
+	ID          string               `json:"@id,omitempty"`
+	DID         string               `json:"did"`
+	Change      string               `json:"change,omitempty"`
+	ModifiedBy  []vdriapi.ModifiedBy `json:"by"`
+	Deactivated bool                 `json:"deactivated,omitempty"`
+}
@@ -0,0 +1,283 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didsync
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/legacykms"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	didstore "github.com/hyperledger/aries-framework-go/pkg/store/did"
+	"github.com/hyperledger/aries-framework-go/pkg/vdri/peer"
+)
+
+var logger = log.New("aries-framework/didsync/service")
+
+// constants for DID sync spec types
+const (
+	// DIDSync DID sync protocol
+	DIDSync = "didsync"
+
+	// DIDSyncSpec defines the DID sync spec
+	DIDSyncSpec = "https://didcomm.org/peer_did_sync/1.0/"
+
+	// DeltaMsgType defines the DID sync delta message type.
+	DeltaMsgType = DIDSyncSpec + "delta"
+)
+
+// only the numalgo 1 peer DIDs are stored with their deltas, the others are derived from the DID
+const updatablePeerDIDPrefix = "did:peer:1"
+
+// ErrConnectionNotFound connection not found error
+var ErrConnectionNotFound = errors.New("connection not found")
+
+// ErrOutboundNotSupported is returned by HandleOutbound. A delta must be signed by the current keys of the DID
+// and stored once it was sent, so it is sent with UpdateDID instead of being dispatched as an outbound message.
+var ErrOutboundNotSupported = errors.New("DID sync deltas are sent with UpdateDID")
+
+// provider contains dependencies for the DID sync protocol and is typically created by using aries.Context()
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	TransientStorageProvider() storage.Provider
+	VDRIRegistry() vdriapi.Registry
+	Signer() legacykms.Signer
+}
+
+// Service for DID sync protocol.
+// The agents of a pairwise relationship send each other the deltas of their peer DID documents,
+// signed by an authentication key of the previous version of the document, when their keys or
+// endpoints rotate.
+// Reference: https://identity.foundation/peer-did-method-spec/#sync-protocol
+type Service struct {
+	connectionLookup *connection.Lookup
+	outbound         dispatcher.Outbound
+	peerVDRI         *peer.VDRI
+	didStore         *didstore.Store
+	vdriRegistry     vdriapi.Registry
+	signer           legacykms.Signer
+	// the deltas are applied one at a time, each delta is verified against the version of
+	// the DID document updated by the previous one
+	deltaLock sync.Mutex
+}
+
+// New return DID sync service.
+func New(prov provider) (*Service, error) {
+	connectionLookup, err := connection.NewLookup(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	peerVDRI, err := peer.New(prov.StorageProvider())
+	if err != nil {
+		return nil, fmt.Errorf("new peer vdri : %w", err)
+	}
+
+	didStore, err := didstore.New(prov)
+	if err != nil {
+		return nil, fmt.Errorf("new did store : %w", err)
+	}
+
+	return &Service{
+		connectionLookup: connectionLookup,
+		outbound:         prov.OutboundDispatcher(),
+		peerVDRI:         peerVDRI,
+		didStore:         didStore,
+		vdriRegistry:     prov.VDRIRegistry(),
+		signer:           prov.Signer(),
+	}, nil
+}
+
+// HandleInbound handles inbound DID sync messages.
+// The deltas are handled synchronously, in the order they are received.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	err := s.handleDelta(msg, theirDID)
+	if err != nil {
+		logutil.LogError(logger, DIDSync, "processMessage", err.Error(),
+			logutil.CreateKeyValueString("msgType", msg.Type()),
+			logutil.CreateKeyValueString("msgID", msg.ID()))
+
+		return "", err
+	}
+
+	logutil.LogDebug(logger, DIDSync, "processMessage", "success",
+		logutil.CreateKeyValueString("msgType", msg.Type()),
+		logutil.CreateKeyValueString("msgID", msg.ID()))
+
+	return msg.ID(), nil
+}
+
+// HandleOutbound always returns ErrOutboundNotSupported, the deltas are sent with UpdateDID.
+func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	return "", ErrOutboundNotSupported
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	return msgType == DeltaMsgType
+}
+
+// Name of the service
+func (s *Service) Name() string {
+	return DIDSync
+}
+
+// Spec returns the specification URI of the protocol.
+func (s *Service) Spec() string {
+	return DIDSyncSpec
+}
+
+// UpdateDID updates the peer DID document of the agent on the connection identified by connectionID
+// with doc, and sends the delta to the agent on the other end of the connection.
+// The delta is signed by the first authentication key of the current DID document and sent before
+// the update is stored, so the message is still packed with the current keys.
+func (s *Service) UpdateDID(connectionID string, doc *did.Doc) error {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return err
+	}
+
+	if doc.ID != conn.MyDID {
+		return fmt.Errorf("DID %s is not the DID of the connection", doc.ID)
+	}
+
+	if !isUpdatable(doc.ID) {
+		return fmt.Errorf("DID %s can't be updated", doc.ID)
+	}
+
+	change, err := doc.JSONBytes()
+	if err != nil {
+		return fmt.Errorf("marshal DID document : %w", err)
+	}
+
+	by, err := s.peerVDRI.SignDelta(doc.ID, change, vdriapi.WithSigner(s.signer))
+	if err != nil {
+		return err
+	}
+
+	delta := &Delta{
+		ID:         uuid.New().String(),
+		Type:       DeltaMsgType,
+		DID:        doc.ID,
+		Change:     base64.URLEncoding.EncodeToString(change),
+		ModifiedBy: by,
+	}
+
+	if err := s.outbound.SendToDID(delta, conn.MyDID, conn.TheirDID); err != nil {
+		return fmt.Errorf("send delta : %w", err)
+	}
+
+	return s.applyDelta(delta)
+}
+
+// DeactivateDID deactivates the peer DID of the agent on the connection identified by connectionID, and
+// sends the deactivation to the agent on the other end of the connection.
+// As for UpdateDID, the deactivation is signed by the first authentication key of the current DID document
+// and sent before it is stored.
+func (s *Service) DeactivateDID(connectionID string) error {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return err
+	}
+
+	if !isUpdatable(conn.MyDID) {
+		return fmt.Errorf("DID %s can't be deactivated", conn.MyDID)
+	}
+
+	by, err := s.peerVDRI.SignDeactivation(conn.MyDID, vdriapi.WithSigner(s.signer))
+	if err != nil {
+		return err
+	}
+
+	delta := &Delta{
+		ID:          uuid.New().String(),
+		Type:        DeltaMsgType,
+		DID:         conn.MyDID,
+		ModifiedBy:  by,
+		Deactivated: true,
+	}
+
+	if err := s.outbound.SendToDID(delta, conn.MyDID, conn.TheirDID); err != nil {
+		return fmt.Errorf("send delta : %w", err)
+	}
+
+	return s.applyDelta(delta)
+}
+
+func (s *Service) handleDelta(msg service.DIDCommMsg, theirDID string) error {
+	delta := &Delta{}
+
+	err := msg.Decode(delta)
+	if err != nil {
+		return fmt.Errorf("delta message unmarshal : %w", err)
+	}
+
+	// the agents update their own DIDs only
+	if delta.DID == "" || delta.DID != theirDID {
+		return fmt.Errorf("delta of DID %s not sent by the DID subject", delta.DID)
+	}
+
+	return s.applyDelta(delta)
+}
+
+// applyDelta verifies and stores the delta, and maps the keys of the updated DID document to the DID
+func (s *Service) applyDelta(delta *Delta) error {
+	s.deltaLock.Lock()
+	defer s.deltaLock.Unlock()
+
+	if delta.Deactivated {
+		if err := s.peerVDRI.ApplyDeactivation(delta.DID, delta.ModifiedBy); err != nil {
+			return fmt.Errorf("apply deactivation : %w", err)
+		}
+	} else {
+		doc, err := s.peerVDRI.ApplyDelta(delta.DID, delta.Change, delta.ModifiedBy)
+		if err != nil {
+			return fmt.Errorf("apply delta : %w", err)
+		}
+
+		if err := s.didStore.SaveDIDFromDoc(doc); err != nil {
+			return fmt.Errorf("save DID keys : %w", err)
+		}
+	}
+
+	// the delta is stored without the registry, refresh its resolution cache
+	if _, err := s.vdriRegistry.Resolve(delta.DID, vdriapi.WithNoCache(true)); err != nil {
+		logger.Warnf("refresh resolution of DID %s : %s", delta.DID, err)
+	}
+
+	return nil
+}
+
+func isUpdatable(didID string) bool {
+	return strings.HasPrefix(didID, updatablePeerDIDPrefix)
+}
+
+func (s *Service) getConnection(connectionID string) (*connection.Record, error) {
+	conn, err := s.connectionLookup.GetConnectionRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrConnectionNotFound
+		}
+
+		return nil, fmt.Errorf("fetch connection record from store : %w", err)
+	}
+
+	return conn, nil
+}
@@ -0,0 +1,570 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didsync
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/internal/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/internal/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/legacykms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms/legacykms"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	didstore "github.com/hyperledger/aries-framework-go/pkg/store/did"
+	"github.com/hyperledger/aries-framework-go/pkg/vdri/peer"
+)
+
+const (
+	connID         = "connID"
+	ed25519KeyType = "Ed25519VerificationKey2018"
+)
+
+type agent struct {
+	svc  *Service
+	prov *mockprovider.Provider
+	kms  *legacykms.BaseKMS
+	doc  *did.Doc
+}
+
+// newAgents creates the agents connected to each other with their peer DIDs, the deltas sent by
+// an agent are handled synchronously by the other one.
+func newAgents(t *testing.T) (alice, bob *agent) {
+	t.Helper()
+
+	alice = newAgent(t)
+	bob = newAgent(t)
+
+	alice.prov.OutboundDispatcherValue = &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+			return bob.svc.handleDelta(service.NewDIDCommMsgMap(msg), myDID)
+		},
+	}
+	bob.prov.OutboundDispatcherValue = &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+			return alice.svc.handleDelta(service.NewDIDCommMsgMap(msg), myDID)
+		},
+	}
+
+	for _, a := range []*agent{alice, bob} {
+		other := bob
+		if a == bob {
+			other = alice
+		}
+
+		peerVDRI, err := peer.New(a.prov.StorageProviderValue)
+		require.NoError(t, err)
+		require.NoError(t, peerVDRI.Store(a.doc, nil))
+		require.NoError(t, peerVDRI.Store(other.doc, nil))
+
+		recorder, err := connection.NewRecorder(a.prov)
+		require.NoError(t, err)
+		require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+			ConnectionID: connID,
+			ThreadID:     "thID",
+			State:        "completed",
+			MyDID:        a.doc.ID,
+			TheirDID:     other.doc.ID,
+		}))
+
+		a.svc, err = New(a.prov)
+		require.NoError(t, err)
+	}
+
+	return alice, bob
+}
+
+func newAgent(t *testing.T) *agent {
+	t.Helper()
+
+	// the mock store provider shares its store between namespaces
+	prov := &mockprovider.Provider{
+		StorageProviderValue:          mem.NewProvider(),
+		TransientStorageProviderValue: mockstore.NewMockStoreProvider(),
+		VDRIRegistryValue:             &mockvdri.MockVDRIRegistry{},
+	}
+
+	kms, err := legacykms.New(prov)
+	require.NoError(t, err)
+
+	prov.SignerValue = kms
+
+	_, verKey, err := kms.CreateKeySet()
+	require.NoError(t, err)
+
+	peerVDRI, err := peer.New(prov.StorageProviderValue)
+	require.NoError(t, err)
+
+	doc, err := peerVDRI.Build(&vdriapi.PubKey{Value: verKey, Type: ed25519KeyType},
+		vdriapi.WithServiceType(vdriapi.DIDCommServiceType),
+		vdriapi.WithServiceEndpoint("https://example.com/endpoint"))
+	require.NoError(t, err)
+
+	return &agent{prov: prov, kms: kms, doc: doc}
+}
+
+// rotateKey returns the DID document of the agent with a new key
+func rotateKey(t *testing.T, a *agent) *did.Doc {
+	t.Helper()
+
+	_, verKey, err := a.kms.CreateKeySet()
+	require.NoError(t, err)
+
+	pk := a.doc.PublicKey[0]
+	pk.ID = verKey[0:7]
+	pk.Value = base58.Decode(verKey)
+
+	svc := a.doc.Service[0]
+	svc.RecipientKeys = []string{pk.ID}
+
+	return &did.Doc{
+		Context:        a.doc.Context,
+		ID:             a.doc.ID,
+		PublicKey:      []did.PublicKey{pk},
+		Service:        []did.Service{svc},
+		Authentication: []did.VerificationMethod{{PublicKey: pk}},
+	}
+}
+
+func TestServiceNew(t *testing.T) {
+	t.Run("test error from open transient store", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			TransientStorageProviderValue: &mockstore.MockStoreProvider{
+				ErrOpenStoreHandle: errors.New("failed to open store")},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to open transient store")
+	})
+
+	t.Run("test error from open peer store", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				Store:         &mockstore.MockStore{Store: map[string][]byte{}},
+				FailNamespace: peer.StoreNamespace,
+			},
+			TransientStorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "new peer vdri")
+	})
+
+	t.Run("test error from open did store", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				Store:         &mockstore.MockStore{Store: map[string][]byte{}},
+				FailNamespace: "didconnection",
+			},
+			TransientStorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "new did store")
+	})
+
+	t.Run("test service accept", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			StorageProviderValue:          mockstore.NewMockStoreProvider(),
+			TransientStorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, DIDSync, svc.Name())
+		require.Equal(t, DIDSyncSpec, svc.Spec())
+		require.True(t, svc.Accept(DeltaMsgType))
+		require.False(t, svc.Accept("unsupported msg type"))
+
+		_, err = svc.HandleOutbound(service.NewDIDCommMsgMap(&Delta{Type: DeltaMsgType}), "", "")
+		require.True(t, errors.Is(err, ErrOutboundNotSupported))
+
+		msgID, err := svc.HandleInbound(service.NewDIDCommMsgMap(&Delta{Type: DeltaMsgType, ID: "id"}), "", "")
+		require.EqualError(t, err, "delta of DID  not sent by the DID subject")
+		require.Empty(t, msgID)
+	})
+}
+
+func TestHandleInbound(t *testing.T) {
+	alice, bob := newAgents(t)
+
+	var deltas []*Delta
+
+	alice.svc.outbound = &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+			deltas = append(deltas, msg.(*Delta))
+			return nil
+		},
+	}
+
+	// the second delta is signed by the key of the first one
+	first := rotateKey(t, alice)
+	require.NoError(t, alice.svc.UpdateDID(connID, first))
+
+	alice.doc = first
+	second := rotateKey(t, alice)
+	require.NoError(t, alice.svc.UpdateDID(connID, second))
+
+	t.Run("deltas received out of order", func(t *testing.T) {
+		_, err := bob.svc.HandleInbound(service.NewDIDCommMsgMap(deltas[1]), bob.doc.ID, alice.doc.ID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not authenticating DID "+alice.doc.ID)
+	})
+
+	t.Run("deltas received in order", func(t *testing.T) {
+		for _, delta := range deltas {
+			msgID, err := bob.svc.HandleInbound(service.NewDIDCommMsgMap(delta), bob.doc.ID, alice.doc.ID)
+			require.NoError(t, err)
+			require.Equal(t, delta.ID, msgID)
+		}
+
+		doc, err := bob.svc.peerVDRI.Get(alice.doc.ID)
+		require.NoError(t, err)
+		require.Equal(t, second.PublicKey[0].Value, doc.PublicKey[0].Value)
+	})
+}
+
+func TestUpdateDID(t *testing.T) {
+	t.Run("test update DID - success", func(t *testing.T) {
+		alice, bob := newAgents(t)
+
+		// the keys of both sides rotate twice, each delta is signed by the previous key
+		for i := 0; i < 2; i++ {
+			for _, a := range []*agent{alice, bob} {
+				other := bob
+				if a == bob {
+					other = alice
+				}
+
+				updated := rotateKey(t, a)
+				require.NoError(t, a.svc.UpdateDID(connID, updated))
+
+				for _, s := range []*agent{a, other} {
+					doc, err := s.svc.peerVDRI.Get(a.doc.ID)
+					require.NoError(t, err)
+					require.Equal(t, updated.PublicKey[0].Value, doc.PublicKey[0].Value)
+
+					didStore, err := didstore.New(s.prov)
+					require.NoError(t, err)
+
+					keyDID, err := didStore.GetDID(base58.Encode(updated.PublicKey[0].Value))
+					require.NoError(t, err)
+					require.Equal(t, a.doc.ID, keyDID)
+				}
+
+				a.doc = updated
+			}
+		}
+	})
+
+	t.Run("test update DID - resolution cache is refreshed", func(t *testing.T) {
+		alice, _ := newAgents(t)
+
+		var refreshed []string
+
+		alice.svc.vdriRegistry = &mockvdri.MockVDRIRegistry{
+			ResolveFunc: func(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error) {
+				resolveOpts := &vdriapi.ResolveDIDOpts{}
+				for _, opt := range opts {
+					opt(resolveOpts)
+				}
+
+				require.True(t, resolveOpts.NoCache)
+				refreshed = append(refreshed, didID)
+
+				return nil, nil
+			},
+		}
+
+		require.NoError(t, alice.svc.UpdateDID(connID, rotateKey(t, alice)))
+		require.Equal(t, []string{alice.doc.ID}, refreshed)
+	})
+
+	t.Run("test update DID - connection not found", func(t *testing.T) {
+		alice, _ := newAgents(t)
+
+		err := alice.svc.UpdateDID("unknown", alice.doc)
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+	})
+
+	t.Run("test update DID - not the DID of the connection", func(t *testing.T) {
+		alice, bob := newAgents(t)
+
+		err := alice.svc.UpdateDID(connID, bob.doc)
+		require.EqualError(t, err, "DID "+bob.doc.ID+" is not the DID of the connection")
+	})
+
+	t.Run("test update DID - DID can't be updated", func(t *testing.T) {
+		alice, _ := newAgents(t)
+
+		conn, err := alice.svc.getConnection(connID)
+		require.NoError(t, err)
+
+		conn.MyDID = "did:peer:2.Vz6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+
+		recorder, err := connection.NewRecorder(alice.prov)
+		require.NoError(t, err)
+		require.NoError(t, recorder.SaveConnectionRecord(conn))
+
+		err = alice.svc.UpdateDID(connID, &did.Doc{ID: conn.MyDID})
+		require.EqualError(t, err, "DID "+conn.MyDID+" can't be updated")
+	})
+
+	t.Run("test update DID - no authentication key", func(t *testing.T) {
+		alice, _ := newAgents(t)
+
+		doc := *alice.doc
+		doc.Authentication = nil
+		require.NoError(t, alice.svc.peerVDRI.Update(&doc, vdriapi.WithSigner(alice.kms)))
+
+		err := alice.svc.UpdateDID(connID, rotateKey(t, alice))
+		require.EqualError(t, err, "current DID document has no authentication key")
+	})
+
+	t.Run("test update DID - sign error", func(t *testing.T) {
+		alice, _ := newAgents(t)
+		alice.svc.signer = &mockkms.CloseableKMS{SignMessageErr: errors.New("sign error")}
+
+		err := alice.svc.UpdateDID(connID, rotateKey(t, alice))
+		require.EqualError(t, err, "sign delta: sign error")
+	})
+
+	t.Run("test update DID - send error", func(t *testing.T) {
+		alice, _ := newAgents(t)
+		alice.svc.outbound = &mockdispatcher.MockOutbound{SendErr: errors.New("send error")}
+
+		err := alice.svc.UpdateDID(connID, rotateKey(t, alice))
+		require.EqualError(t, err, "send delta : send error")
+
+		// the update is not stored
+		doc, err := alice.svc.peerVDRI.Get(alice.doc.ID)
+		require.NoError(t, err)
+		require.Equal(t, alice.doc.PublicKey[0].Value, doc.PublicKey[0].Value)
+	})
+}
+
+func TestDeactivateDID(t *testing.T) {
+	t.Run("test deactivate DID - success", func(t *testing.T) {
+		alice, bob := newAgents(t)
+
+		require.NoError(t, alice.svc.DeactivateDID(connID))
+
+		for _, s := range []*agent{alice, bob} {
+			result, err := s.svc.peerVDRI.ReadResult(alice.doc.ID)
+			require.NoError(t, err)
+			require.True(t, result.DocumentMetadata.Deactivated)
+		}
+
+		err := alice.svc.UpdateDID(connID, rotateKey(t, alice))
+		require.EqualError(t, err, "peer DID "+alice.doc.ID+" is deactivated")
+
+		err = alice.svc.DeactivateDID(connID)
+		require.EqualError(t, err, "peer DID "+alice.doc.ID+" is deactivated")
+	})
+
+	t.Run("test deactivate DID - connection not found", func(t *testing.T) {
+		alice, _ := newAgents(t)
+
+		err := alice.svc.DeactivateDID("unknown")
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+	})
+
+	t.Run("test deactivate DID - DID can't be deactivated", func(t *testing.T) {
+		alice, _ := newAgents(t)
+
+		conn, err := alice.svc.getConnection(connID)
+		require.NoError(t, err)
+
+		conn.MyDID = "did:peer:2.Vz6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+
+		recorder, err := connection.NewRecorder(alice.prov)
+		require.NoError(t, err)
+		require.NoError(t, recorder.SaveConnectionRecord(conn))
+
+		err = alice.svc.DeactivateDID(connID)
+		require.EqualError(t, err, "DID "+conn.MyDID+" can't be deactivated")
+	})
+
+	t.Run("test deactivate DID - sign error", func(t *testing.T) {
+		alice, _ := newAgents(t)
+		alice.svc.signer = &mockkms.CloseableKMS{SignMessageErr: errors.New("sign error")}
+
+		err := alice.svc.DeactivateDID(connID)
+		require.EqualError(t, err, "sign delta: sign error")
+	})
+
+	t.Run("test deactivate DID - send error", func(t *testing.T) {
+		alice, _ := newAgents(t)
+		alice.svc.outbound = &mockdispatcher.MockOutbound{SendErr: errors.New("send error")}
+
+		err := alice.svc.DeactivateDID(connID)
+		require.EqualError(t, err, "send delta : send error")
+
+		// the deactivation is not stored
+		result, err := alice.svc.peerVDRI.ReadResult(alice.doc.ID)
+		require.NoError(t, err)
+		require.False(t, result.DocumentMetadata.Deactivated)
+	})
+
+	t.Run("test deactivate DID - update signature replayed as deactivation", func(t *testing.T) {
+		alice, bob := newAgents(t)
+
+		var delta *Delta
+
+		alice.svc.outbound = &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				delta = msg.(*Delta)
+				return nil
+			},
+		}
+
+		require.NoError(t, alice.svc.UpdateDID(connID, rotateKey(t, alice)))
+
+		// bob has not received the update, the deactivation is signed by the same key as the update
+		d := *delta
+		d.Change = ""
+		d.Deactivated = true
+
+		err := bob.svc.handleDelta(service.NewDIDCommMsgMap(&d), alice.doc.ID)
+		require.EqualError(t, err, "apply deactivation : delta signature verification failed")
+	})
+}
+
+func TestHandleDelta(t *testing.T) {
+	alice, bob := newAgents(t)
+
+	var delta *Delta
+
+	alice.svc.outbound = &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+			delta = msg.(*Delta)
+			return nil
+		},
+	}
+
+	updated := rotateKey(t, alice)
+	require.NoError(t, alice.svc.UpdateDID(connID, updated))
+
+	change, err := updated.JSONBytes()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		theirDID string
+		update   func(d *Delta)
+		errMsg   string
+	}{
+		{
+			name:     "not sent by the DID subject",
+			theirDID: "did:peer:other",
+			errMsg:   "delta of DID " + alice.doc.ID + " not sent by the DID subject",
+		},
+		{
+			name:   "invalid change encoding",
+			update: func(d *Delta) { d.Change = "!" },
+			errMsg: "decode delta change",
+		},
+		{
+			name:   "invalid change document",
+			update: func(d *Delta) { d.Change = base64.URLEncoding.EncodeToString([]byte("{")) },
+			errMsg: "parse delta change",
+		},
+		{
+			name: "change of another DID",
+			update: func(d *Delta) {
+				b, e := bob.doc.JSONBytes()
+				require.NoError(t, e)
+
+				d.Change = base64.URLEncoding.EncodeToString(b)
+			},
+			errMsg: "delta change of DID " + alice.doc.ID + " updates DID " + bob.doc.ID,
+		},
+		{
+			name:   "not signed",
+			update: func(d *Delta) { d.ModifiedBy = nil },
+			errMsg: "delta is not signed",
+		},
+		{
+			name:   "signed by unknown key",
+			update: func(d *Delta) { d.ModifiedBy = []vdriapi.ModifiedBy{{Key: "key", Sig: d.ModifiedBy[0].Sig}} },
+			errMsg: "delta signed by key key not authenticating DID " + alice.doc.ID,
+		},
+		{
+			name:   "invalid signature encoding",
+			update: func(d *Delta) { d.ModifiedBy = []vdriapi.ModifiedBy{{Key: d.ModifiedBy[0].Key, Sig: "!"}} },
+			errMsg: "decode delta signature",
+		},
+		{
+			name: "invalid signature",
+			update: func(d *Delta) {
+				d.ModifiedBy = []vdriapi.ModifiedBy{{
+					Key: d.ModifiedBy[0].Key,
+					Sig: base64.URLEncoding.EncodeToString(change[:64]),
+				}}
+			},
+			errMsg: "delta signature verification failed",
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			d := *delta
+			d.ModifiedBy = append([]vdriapi.ModifiedBy(nil), delta.ModifiedBy...)
+
+			if tc.update != nil {
+				tc.update(&d)
+			}
+
+			theirDID := alice.doc.ID
+			if tc.theirDID != "" {
+				theirDID = tc.theirDID
+			}
+
+			err := bob.svc.handleDelta(service.NewDIDCommMsgMap(&d), theirDID)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+
+	t.Run("unknown DID", func(t *testing.T) {
+		_, carol := newAgents(t)
+
+		err := carol.svc.handleDelta(service.NewDIDCommMsgMap(delta), alice.doc.ID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "apply delta : delta data fetch from store failed")
+	})
+
+	t.Run("invalid message", func(t *testing.T) {
+		err := bob.svc.handleDelta(service.DIDCommMsgMap{"change": map[string]interface{}{}}, alice.doc.ID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "delta message unmarshal")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, bob.svc.handleDelta(service.NewDIDCommMsgMap(delta), alice.doc.ID))
+	})
+
+	t.Run("deactivated DID", func(t *testing.T) {
+		_, carol := newAgents(t)
+
+		peerVDRI, err := peer.New(carol.prov.StorageProviderValue)
+		require.NoError(t, err)
+		require.NoError(t, peerVDRI.Store(alice.doc, nil))
+		require.NoError(t, peerVDRI.Deactivate(alice.doc.ID, vdriapi.WithSigner(alice.kms)))
+
+		err = carol.svc.handleDelta(service.NewDIDCommMsgMap(delta), alice.doc.ID)
+		require.EqualError(t, err, "apply delta : peer DID "+alice.doc.ID+" is deactivated")
+	})
+}
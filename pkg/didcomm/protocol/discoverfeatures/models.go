@@ -0,0 +1,33 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+
+// Query is sent to learn which protocols are supported by the agent on the other end of the connection.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0031-discover-features#query-message-type
+type Query struct {
+	Type    string `json:"@type,omitempty"`
+	ID      string `json:"@id,omitempty"`
+	Query   string `json:"query"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// Disclose is the response to the query with the supported protocols.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0031-discover-features#disclose-message-type
+type Disclose struct {
+	Type      string                `json:"@type,omitempty"`
+	ID        string                `json:"@id,omitempty"`
+	Protocols []*ProtocolDescriptor `json:"protocols"`
+	Thread    *decorator.Thread     `json:"~thread,omitempty"`
+}
+
+// ProtocolDescriptor describes the supported protocol.
+type ProtocolDescriptor struct {
+	PID   string   `json:"pid"`
+	Roles []string `json:"roles,omitempty"`
+}
@@ -0,0 +1,316 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+var logger = log.New("aries-framework/discoverfeatures/service")
+
+// constants for discover features spec types
+const (
+	// DiscoverFeatures discover features protocol
+	DiscoverFeatures = "discoverfeatures"
+
+	// DiscoverFeaturesSpec defines the discover features spec
+	DiscoverFeaturesSpec = "https://didcomm.org/discover-features/1.0/"
+
+	// QueryMsgType defines the discover features query message type.
+	QueryMsgType = DiscoverFeaturesSpec + "query"
+
+	// DiscloseMsgType defines the discover features disclose message type.
+	DiscloseMsgType = DiscoverFeaturesSpec + "disclose"
+)
+
+const (
+	// wildcard used in the query
+	wildcard = "*"
+
+	queryTimeout = 5 * time.Second
+)
+
+// ErrConnectionNotFound connection not found error
+var ErrConnectionNotFound = errors.New("connection not found")
+
+// provider contains dependencies for the Discover Features protocol and is typically created by using aries.Context()
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	TransientStorageProvider() storage.Provider
+	AllServices() []dispatcher.ProtocolService
+	MessageServiceProvider() api.MessageServiceProvider
+}
+
+// specProvider is implemented by the services which are able to tell the specification URI of the protocol.
+type specProvider interface {
+	Spec() string
+}
+
+// Service for Discover Features protocol.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0031-discover-features
+type Service struct {
+	connectionLookup *connection.Lookup
+	outbound         dispatcher.Outbound
+	protocolServices func() []dispatcher.ProtocolService
+	msgSvcProvider   api.MessageServiceProvider
+	discloseMap      map[string]chan *Disclose
+	discloseMapLock  sync.RWMutex
+}
+
+// New return discover features service.
+func New(prov provider) (*Service, error) {
+	connectionLookup, err := connection.NewLookup(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		connectionLookup: connectionLookup,
+		outbound:         prov.OutboundDispatcher(),
+		// the protocol services are fetched on every query as the services are registered after this one
+		protocolServices: prov.AllServices,
+		msgSvcProvider:   prov.MessageServiceProvider(),
+		discloseMap:      make(map[string]chan *Disclose),
+	}, nil
+}
+
+// HandleInbound handles inbound discover features messages.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	// perform action on inbound message asynchronously
+	go func() {
+		var err error
+
+		switch msg.Type() {
+		case QueryMsgType:
+			err = s.handleQuery(msg, myDID, theirDID)
+		case DiscloseMsgType:
+			err = s.handleDisclose(msg)
+		}
+
+		if err != nil {
+			logutil.LogError(logger, DiscoverFeatures, "processMessage", err.Error(),
+				logutil.CreateKeyValueString("msgType", msg.Type()),
+				logutil.CreateKeyValueString("msgID", msg.ID()))
+		} else {
+			logutil.LogDebug(logger, DiscoverFeatures, "processMessage", "success",
+				logutil.CreateKeyValueString("msgType", msg.Type()),
+				logutil.CreateKeyValueString("msgID", msg.ID()))
+		}
+	}()
+
+	return msg.ID(), nil
+}
+
+// HandleOutbound handles outbound discover features messages.
+func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	return msgType == QueryMsgType || msgType == DiscloseMsgType
+}
+
+// Name of the service
+func (s *Service) Name() string {
+	return DiscoverFeatures
+}
+
+// Spec returns the specification URI of the protocol.
+func (s *Service) Spec() string {
+	return DiscoverFeaturesSpec
+}
+
+// Query sends the query to the agent on the other end of the connection identified by connectionID.
+// The query is the protocol identifier which may contain the '*' wildcard (e.g https://didcomm.org/*).
+// This method blocks until the disclose message is received or the timeout expires.
+func (s *Service) Query(connectionID, query string) (*Disclose, error) {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	msgID := uuid.New().String()
+
+	// register chan for callback processing
+	discloseCh := make(chan *Disclose, 1)
+	s.setDiscloseCh(msgID, discloseCh)
+
+	// remove the channel once its been processed
+	defer s.setDiscloseCh(msgID, nil)
+
+	q := &Query{
+		ID:    msgID,
+		Type:  QueryMsgType,
+		Query: query,
+	}
+
+	if err := s.outbound.SendToDID(q, conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send query : %w", err)
+	}
+
+	select {
+	case disclose := <-discloseCh:
+		return disclose, nil
+	case <-time.After(queryTimeout):
+		return nil, errors.New("timeout waiting for the disclose message")
+	}
+}
+
+func (s *Service) handleQuery(msg service.DIDCommMsg, myDID, theirDID string) error {
+	// unmarshal the payload
+	q := &Query{}
+
+	err := msg.Decode(q)
+	if err != nil {
+		return fmt.Errorf("query message unmarshal : %w", err)
+	}
+
+	disclose := &Disclose{
+		ID:        uuid.New().String(),
+		Type:      DiscloseMsgType,
+		Protocols: s.features(q.Query),
+		Thread:    &decorator.Thread{ID: q.ID},
+	}
+
+	if err := s.outbound.SendToDID(disclose, myDID, theirDID); err != nil {
+		return fmt.Errorf("send disclose : %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) handleDisclose(msg service.DIDCommMsg) error {
+	// unmarshal the payload
+	disclose := &Disclose{}
+
+	err := msg.Decode(disclose)
+	if err != nil {
+		return fmt.Errorf("disclose message unmarshal : %w", err)
+	}
+
+	if disclose.Thread == nil {
+		return errors.New("disclose message thread is missing")
+	}
+
+	// check if there are any channels registered for the thread ID, the duplicate disclosures are dropped
+	if discloseCh := s.getDiscloseCh(disclose.Thread.ID); discloseCh != nil {
+		select {
+		case discloseCh <- disclose:
+		default:
+			logger.Warnf("dropping duplicate disclose message of thread %s", disclose.Thread.ID)
+		}
+	}
+
+	return nil
+}
+
+// features returns the descriptors of the registered protocol and message services matching the query.
+// The service matches the query if its protocol identifier matches the query or the query is
+// the message type accepted by the service.
+func (s *Service) features(query string) []*ProtocolDescriptor {
+	// the query is quoted so only the wildcard has the special meaning
+	matcher := regexp.MustCompile("^" + strings.ReplaceAll(regexp.QuoteMeta(query), `\`+wildcard, ".*") + "$")
+
+	protocols := []*ProtocolDescriptor{}
+	disclosed := make(map[string]struct{})
+
+	add := func(pid string) {
+		if _, ok := disclosed[pid]; ok {
+			return
+		}
+
+		disclosed[pid] = struct{}{}
+
+		protocols = append(protocols, &ProtocolDescriptor{PID: pid})
+	}
+
+	for _, svc := range s.protocolServices() {
+		pid := protocolID(svc, svc.Name())
+
+		if matches(matcher, pid) || svc.Accept(query) {
+			add(pid)
+		}
+	}
+
+	if s.msgSvcProvider == nil {
+		return protocols
+	}
+
+	for _, svc := range s.msgSvcProvider.Services() {
+		pid := protocolID(svc, svc.Name())
+
+		if matches(matcher, pid) || svc.Accept(query, nil) {
+			add(pid)
+		}
+	}
+
+	return protocols
+}
+
+// protocolID returns the protocol identifier (without the trailing slash) of the service.
+// The name of the service is used if the service doesn't tell its specification URI.
+func protocolID(svc interface{}, name string) string {
+	if sp, ok := svc.(specProvider); ok {
+		return strings.TrimSuffix(sp.Spec(), "/")
+	}
+
+	return name
+}
+
+// matches checks the protocol identifier against the query with and without the trailing slash.
+func matches(matcher *regexp.Regexp, pid string) bool {
+	return matcher.MatchString(pid) || matcher.MatchString(pid+"/")
+}
+
+func (s *Service) getDiscloseCh(msgID string) chan *Disclose {
+	s.discloseMapLock.RLock()
+	defer s.discloseMapLock.RUnlock()
+
+	return s.discloseMap[msgID]
+}
+
+func (s *Service) setDiscloseCh(msgID string, discloseCh chan *Disclose) {
+	s.discloseMapLock.Lock()
+	defer s.discloseMapLock.Unlock()
+
+	if discloseCh == nil {
+		delete(s.discloseMap, msgID)
+	} else {
+		s.discloseMap[msgID] = discloseCh
+	}
+}
+
+func (s *Service) getConnection(connectionID string) (*connection.Record, error) {
+	conn, err := s.connectionLookup.GetConnectionRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrConnectionNotFound
+		}
+
+		return nil, fmt.Errorf("fetch connection record from store : %w", err)
+	}
+
+	return conn, nil
+}
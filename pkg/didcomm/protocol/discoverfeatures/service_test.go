@@ -0,0 +1,245 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/internal/mock/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/mock/didcomm/msghandler"
+	mockdidexchange "github.com/hyperledger/aries-framework-go/pkg/internal/mock/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/mock/didcomm/protocol/generic"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	myDID    = "did:example:alice"
+	theirDID = "did:example:bob"
+	connID   = "connID"
+
+	genericMsgType = "https://example.com/generic/1.0/message"
+)
+
+type mockProvider struct {
+	outbound       dispatcher.Outbound
+	store          storage.Provider
+	transientStore storage.Provider
+	services       []dispatcher.ProtocolService
+	msgSvcProvider api.MessageServiceProvider
+}
+
+func (p *mockProvider) OutboundDispatcher() dispatcher.Outbound {
+	return p.outbound
+}
+
+func (p *mockProvider) StorageProvider() storage.Provider {
+	return p.store
+}
+
+func (p *mockProvider) TransientStorageProvider() storage.Provider {
+	return p.transientStore
+}
+
+func (p *mockProvider) AllServices() []dispatcher.ProtocolService {
+	return p.services
+}
+
+func (p *mockProvider) MessageServiceProvider() api.MessageServiceProvider {
+	return p.msgSvcProvider
+}
+
+func newProvider() *mockProvider {
+	return &mockProvider{
+		outbound:       &mockdispatcher.MockOutbound{},
+		store:          mockstore.NewMockStoreProvider(),
+		transientStore: mockstore.NewMockStoreProvider(),
+	}
+}
+
+// newServices creates the discover features services of two agents connected to each other.
+// The responder has the mock protocol service and the generic message service registered.
+func newServices(t *testing.T) (requester, responder *Service) {
+	t.Helper()
+
+	requesterProv := newProvider()
+	responderProv := newProvider()
+
+	msgSvcProvider := msghandler.NewMockMsgServiceProvider()
+	require.NoError(t, msgSvcProvider.Register(generic.NewCustomMockMessageSvc(genericMsgType, "generic")))
+
+	responderProv.msgSvcProvider = msgSvcProvider
+
+	requesterProv.outbound = &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+			_, err := responder.HandleInbound(service.NewDIDCommMsgMap(msg), theirDID, myDID)
+
+			return err
+		},
+	}
+	responderProv.outbound = &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+			_, err := requester.HandleInbound(service.NewDIDCommMsgMap(msg), theirDID, myDID)
+
+			return err
+		},
+	}
+
+	recorder, err := connection.NewRecorder(requesterProv)
+	require.NoError(t, err)
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: connID,
+		ThreadID:     "thID",
+		State:        "completed",
+		MyDID:        myDID,
+		TheirDID:     theirDID,
+	}))
+
+	requester, err = New(requesterProv)
+	require.NoError(t, err)
+
+	responder, err = New(responderProv)
+	require.NoError(t, err)
+
+	// the services are registered after the discover features service
+	responderProv.services = []dispatcher.ProtocolService{responder, &mockdidexchange.MockDIDExchangeSvc{
+		ProtocolName: "mock",
+		AcceptFunc: func(msgType string) bool {
+			return msgType == "https://example.com/mock/1.0/message"
+		},
+	}}
+
+	return requester, responder
+}
+
+func pids(disclose *Disclose) []string {
+	var result []string
+	for _, p := range disclose.Protocols {
+		result = append(result, p.PID)
+	}
+
+	return result
+}
+
+func TestServiceNew(t *testing.T) {
+	t.Run("test error from open store", func(t *testing.T) {
+		prov := newProvider()
+		prov.store = &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("failed to open store")}
+
+		_, err := New(prov)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to open store")
+	})
+
+	t.Run("test service accept", func(t *testing.T) {
+		svc, err := New(newProvider())
+		require.NoError(t, err)
+
+		require.Equal(t, DiscoverFeatures, svc.Name())
+		require.Equal(t, DiscoverFeaturesSpec, svc.Spec())
+		require.True(t, svc.Accept(QueryMsgType))
+		require.True(t, svc.Accept(DiscloseMsgType))
+		require.False(t, svc.Accept("unsupported msg type"))
+
+		_, err = svc.HandleOutbound(service.NewDIDCommMsgMap(&Query{Type: QueryMsgType}), "", "")
+		require.EqualError(t, err, "not implemented")
+	})
+}
+
+func TestQuery(t *testing.T) {
+	t.Run("test query - wildcard", func(t *testing.T) {
+		requester, _ := newServices(t)
+
+		disclose, err := requester.Query(connID, "*")
+		require.NoError(t, err)
+		require.Equal(t, []string{"https://didcomm.org/discover-features/1.0", "mock", "generic"}, pids(disclose))
+	})
+
+	t.Run("test query - wildcard prefix", func(t *testing.T) {
+		requester, _ := newServices(t)
+
+		disclose, err := requester.Query(connID, "https://didcomm.org/discover-features/*")
+		require.NoError(t, err)
+		require.Equal(t, []string{"https://didcomm.org/discover-features/1.0"}, pids(disclose))
+
+		disclose, err = requester.Query(connID, "https://didcomm.org/discover-features/1.0/*")
+		require.NoError(t, err)
+		require.Equal(t, []string{"https://didcomm.org/discover-features/1.0"}, pids(disclose))
+	})
+
+	t.Run("test query - message type", func(t *testing.T) {
+		requester, _ := newServices(t)
+
+		disclose, err := requester.Query(connID, "https://example.com/mock/1.0/message")
+		require.NoError(t, err)
+		require.Equal(t, []string{"mock"}, pids(disclose))
+
+		disclose, err = requester.Query(connID, genericMsgType)
+		require.NoError(t, err)
+		require.Equal(t, []string{"generic"}, pids(disclose))
+	})
+
+	t.Run("test query - no match", func(t *testing.T) {
+		requester, _ := newServices(t)
+
+		disclose, err := requester.Query(connID, "https://didcomm.org/unknown/1.0")
+		require.NoError(t, err)
+		require.Empty(t, disclose.Protocols)
+	})
+
+	t.Run("test query - connection not found", func(t *testing.T) {
+		requester, _ := newServices(t)
+
+		_, err := requester.Query("unknown", "*")
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+	})
+
+	t.Run("test query - send error", func(t *testing.T) {
+		requester, _ := newServices(t)
+		requester.outbound = &mockdispatcher.MockOutbound{SendErr: errors.New("send error")}
+
+		_, err := requester.Query(connID, "*")
+		require.EqualError(t, err, "send query : send error")
+	})
+}
+
+func TestHandleMessages(t *testing.T) {
+	svc, err := New(newProvider())
+	require.NoError(t, err)
+
+	t.Run("test handle disclose - missing thread", func(t *testing.T) {
+		err = svc.handleDisclose(service.NewDIDCommMsgMap(&Disclose{Type: DiscloseMsgType}))
+		require.EqualError(t, err, "disclose message thread is missing")
+	})
+
+	t.Run("test handle disclose - duplicate disclose", func(t *testing.T) {
+		discloseCh := make(chan *Disclose, 1)
+		svc.setDiscloseCh("thID", discloseCh)
+		defer svc.setDiscloseCh("thID", nil)
+
+		msg := service.NewDIDCommMsgMap(&Disclose{Type: DiscloseMsgType, Thread: &decorator.Thread{ID: "thID"}})
+
+		require.NoError(t, svc.handleDisclose(msg))
+		require.NoError(t, svc.handleDisclose(msg))
+		require.Len(t, discloseCh, 1)
+	})
+
+	t.Run("test handle query - send error", func(t *testing.T) {
+		svc.outbound = &mockdispatcher.MockOutbound{SendErr: errors.New("send error")}
+
+		err = svc.handleQuery(service.NewDIDCommMsgMap(&Query{Type: QueryMsgType, Query: "*"}), myDID, theirDID)
+		require.EqualError(t, err, "send disclose : send error")
+	})
+}
@@ -720,6 +720,11 @@ func (s *Service) Name() string {
 	return Introduce
 }
 
+// Spec returns the specification URI of the protocol.
+func (s *Service) Spec() string {
+	return IntroduceSpec
+}
+
 // Accept msg checks the msg type
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
@@ -1275,6 +1275,7 @@ func TestService_Accept(t *testing.T) {
 
 func TestService_Name(t *testing.T) {
 	require.Equal(t, introduce.Introduce, (&introduce.Service{}).Name())
+	require.Equal(t, introduce.IntroduceSpec, (&introduce.Service{}).Spec())
 }
 
 func TestService_New(t *testing.T) {
@@ -0,0 +1,74 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const credentialMimeType = "application/ld+json"
+
+// NewCredentialAttachment creates an attachment which embeds the given verifiable credential as JSON.
+func NewCredentialAttachment(vc *verifiable.Credential) (decorator.Attachment, error) {
+	vcBytes, err := vc.MarshalJSON()
+	if err != nil {
+		return decorator.Attachment{}, fmt.Errorf("marshal credential: %w", err)
+	}
+
+	var vcMap map[string]interface{}
+	if err := json.Unmarshal(vcBytes, &vcMap); err != nil {
+		return decorator.Attachment{}, fmt.Errorf("unmarshal credential: %w", err)
+	}
+
+	return decorator.Attachment{
+		ID:       uuid.New().String(),
+		MimeType: credentialMimeType,
+		Data:     decorator.AttachmentData{JSON: vcMap},
+	}, nil
+}
+
+// Credentials decodes the verifiable credentials attached to the message.
+// The options are passed to the verifiable.NewCredential function, e.g to provide a public key fetcher.
+func (m *IssueCredential) Credentials(opts ...verifiable.CredentialOpt) ([]*verifiable.Credential, error) {
+	credentials := make([]*verifiable.Credential, len(m.CredentialsAttach))
+
+	for i, attachment := range m.CredentialsAttach {
+		raw, err := attachmentPayload(attachment)
+		if err != nil {
+			return nil, fmt.Errorf("attachment %d: %w", i, err)
+		}
+
+		credentials[i], _, err = verifiable.NewCredential(raw, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("new credential: %w", err)
+		}
+	}
+
+	return credentials, nil
+}
+
+// attachmentPayload returns the raw content of the attachment.
+// The credential might be embedded as JSON or base64 (e.g JWT).
+func attachmentPayload(attachment decorator.Attachment) ([]byte, error) {
+	if attachment.Data.JSON != nil {
+		return json.Marshal(attachment.Data.JSON)
+	}
+
+	if attachment.Data.Base64 != "" {
+		return base64.StdEncoding.DecodeString(attachment.Data.Base64)
+	}
+
+	return nil, errors.New("credential is not provided")
+}
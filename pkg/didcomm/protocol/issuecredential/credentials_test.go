@@ -0,0 +1,84 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const vcJSON = `{
+  "@context": ["https://www.w3.org/2018/credentials/v1"],
+  "id": "http://example.edu/credentials/1872",
+  "type": ["VerifiableCredential", "UniversityDegreeCredential"],
+  "credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"},
+  "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+  "issuanceDate": "2010-01-01T19:23:24Z"
+}`
+
+func TestIssueCredential_Credentials(t *testing.T) {
+	vc, _, err := verifiable.NewCredential([]byte(vcJSON))
+	require.NoError(t, err)
+
+	t.Run("JSON attachment", func(t *testing.T) {
+		attachment, err := NewCredentialAttachment(vc)
+		require.NoError(t, err)
+		require.NotEmpty(t, attachment.ID)
+		require.Equal(t, credentialMimeType, attachment.MimeType)
+
+		// the message goes through the DIDComm message map as it would be sent over the wire
+		msg := service.NewDIDCommMsgMap(IssueCredential{
+			Type:              IssueCredentialMsgType,
+			CredentialsAttach: []decorator.Attachment{attachment},
+		})
+
+		issue := IssueCredential{}
+		require.NoError(t, msg.Decode(&issue))
+
+		credentials, err := issue.Credentials()
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+		require.Equal(t, vc.ID, credentials[0].ID)
+		require.Equal(t, vc.Issuer, credentials[0].Issuer)
+	})
+
+	t.Run("Base64 attachment", func(t *testing.T) {
+		issue := IssueCredential{CredentialsAttach: []decorator.Attachment{{
+			Data: decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString([]byte(vcJSON))},
+		}}}
+
+		credentials, err := issue.Credentials()
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+		require.Equal(t, vc.ID, credentials[0].ID)
+	})
+
+	t.Run("No data", func(t *testing.T) {
+		issue := IssueCredential{CredentialsAttach: []decorator.Attachment{{}}}
+
+		credentials, err := issue.Credentials()
+		require.EqualError(t, err, "attachment 0: credential is not provided")
+		require.Nil(t, credentials)
+	})
+
+	t.Run("Invalid credential", func(t *testing.T) {
+		issue := IssueCredential{CredentialsAttach: []decorator.Attachment{{
+			Data: decorator.AttachmentData{JSON: map[string]interface{}{"id": "ID"}},
+		}}}
+
+		credentials, err := issue.Credentials()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "new credential")
+		require.Nil(t, credentials)
+	})
+}
@@ -0,0 +1,90 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+
+// ProposeCredential is an optional message sent by the potential Holder to the Issuer
+// to initiate the protocol or in response to a offer-credential message when the Holder
+// wants some adjustments made to the credential data offered by Issuer.
+type ProposeCredential struct {
+	Type string `json:"@type,omitempty"`
+	ID   string `json:"@id,omitempty"`
+	// Comment is an optional field that provides human readable information about this Credential Offer,
+	// so the offer can be evaluated by human judgment.
+	Comment string `json:"comment,omitempty"`
+	// CredentialProposal is an optional JSON-LD object that represents
+	// the credential data that the Prover wants to receive.
+	CredentialProposal *PreviewCredential `json:"credential_proposal,omitempty"`
+	// SchemaIssuerDID is an optional filter to request credential based on a particular schema issuer DID.
+	SchemaIssuerDID string `json:"schema_issuer_did,omitempty"`
+	// SchemaID is an optional filter to request credential based on a particular schema.
+	SchemaID string `json:"schema_id,omitempty"`
+	// SchemaName is an optional filter to request credential based on a particular schema name.
+	SchemaName string `json:"schema_name,omitempty"`
+	// SchemaVersion is an optional filter to request credential based on a particular schema version.
+	SchemaVersion string `json:"schema_version,omitempty"`
+	// CredDefID is an optional filter to request credential based on a particular credential definition.
+	CredDefID string `json:"cred_def_id,omitempty"`
+	// IssuerDID is an optional filter to request credential issued by the owner of a particular DID.
+	IssuerDID string `json:"issuer_did,omitempty"`
+}
+
+// OfferCredential is a message sent by the Issuer to the potential Holder,
+// describing the credential they intend to offer and possibly the price they expect to be paid.
+type OfferCredential struct {
+	Type string `json:"@type,omitempty"`
+	ID   string `json:"@id,omitempty"`
+	// Comment is an optional field that provides human readable information about this Credential Offer,
+	// so the offer can be evaluated by human judgment.
+	Comment string `json:"comment,omitempty"`
+	// CredentialPreview is a JSON-LD object that represents the credential data that Issuer is willing to issue.
+	CredentialPreview PreviewCredential `json:"credential_preview,omitempty"`
+	// OffersAttach is an array of attachments that further define the credential being offered.
+	// This might be used to clarify which formats or format versions will be issued.
+	OffersAttach []decorator.Attachment `json:"offers~attach,omitempty"`
+}
+
+// RequestCredential is a message sent by the potential Holder to the Issuer,
+// to request the issuance of a credential. Where circumstances do not require
+// a preceding Offer Credential message (e.g., there is no cost to issuance
+// that the Issuer needs to explain in advance, and there is no need for cryptographic negotiation),
+// this message initiates the protocol.
+type RequestCredential struct {
+	Type string `json:"@type,omitempty"`
+	ID   string `json:"@id,omitempty"`
+	// Comment is an optional field that provides human readable information about this Credential Request,
+	// so the request can be evaluated by human judgment.
+	Comment string `json:"comment,omitempty"`
+	// RequestsAttach is an array of attachments defining the requested formats for the credential.
+	RequestsAttach []decorator.Attachment `json:"requests~attach,omitempty"`
+}
+
+// IssueCredential contains as attached payload the credentials being issued and is
+// sent in response to a valid Request Credential message.
+type IssueCredential struct {
+	Type string `json:"@type,omitempty"`
+	ID   string `json:"@id,omitempty"`
+	// Comment is an optional field that provides human readable information about the issued credential,
+	// so it can be evaluated by human judgment.
+	Comment string `json:"comment,omitempty"`
+	// CredentialsAttach is a slice of attachments containing the issued credentials.
+	CredentialsAttach []decorator.Attachment `json:"credentials~attach,omitempty"`
+}
+
+// PreviewCredential is used to construct a preview of the data for the credential that is to be issued.
+type PreviewCredential struct {
+	Type       string      `json:"@type,omitempty"`
+	Attributes []Attribute `json:"attributes,omitempty"`
+}
+
+// Attribute describes an attribute for a Preview Credential.
+type Attribute struct {
+	Name     string `json:"name,omitempty"`
+	MimeType string `json:"mime-type,omitempty"`
+	Value    string `json:"value,omitempty"`
+}
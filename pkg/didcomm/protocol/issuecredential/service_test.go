@@ -0,0 +1,540 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	. "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+	issuecredentialMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/issuecredential"
+	storageMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+const (
+	Alice = "Alice"
+	Bob   = "Bob"
+)
+
+func newService(t *testing.T, ctrl *gomock.Controller, messenger service.Messenger) *Service {
+	t.Helper()
+
+	provider := issuecredentialMocks.NewMockProvider(ctrl)
+	provider.EXPECT().Messenger().Return(messenger)
+	provider.EXPECT().StorageProvider().Return(mem.NewProvider())
+
+	svc, err := New(provider)
+	require.NoError(t, err)
+
+	return svc
+}
+
+func withThread(msg service.DIDCommMsgMap, thID string) service.DIDCommMsgMap {
+	msg["~thread"] = map[string]interface{}{"thid": thID}
+
+	return msg
+}
+
+func waitForAction(t *testing.T, actions chan service.DIDCommAction) service.DIDCommAction {
+	t.Helper()
+
+	select {
+	case action := <-actions:
+		return action
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for action")
+	}
+
+	return service.DIDCommAction{}
+}
+
+func waitForDone(t *testing.T, done chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+}
+
+func TestService_New(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Error open store", func(t *testing.T) {
+		const errMsg = "error"
+
+		storageProvider := storageMocks.NewMockProvider(ctrl)
+		storageProvider.EXPECT().OpenStore(Name).Return(nil, errors.New(errMsg))
+
+		provider := issuecredentialMocks.NewMockProvider(ctrl)
+		provider.EXPECT().StorageProvider().Return(storageProvider)
+
+		svc, err := New(provider)
+		require.EqualError(t, err, errMsg)
+		require.Nil(t, svc)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		svc := newService(t, ctrl, nil)
+		require.NotNil(t, svc)
+	})
+}
+
+func TestService_Issuer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	done := make(chan struct{})
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+	messenger.EXPECT().ReplyTo("propose-id", gomock.Any()).
+		Do(func(_ string, msg service.DIDCommMsgMap) error {
+			require.Equal(t, OfferCredentialMsgType, msg.Type())
+
+			offer := OfferCredential{}
+			require.NoError(t, msg.Decode(&offer))
+			require.Equal(t, "offer", offer.Comment)
+
+			done <- struct{}{}
+
+			return nil
+		})
+	messenger.EXPECT().ReplyTo("request-id", gomock.Any()).
+		Do(func(_ string, msg service.DIDCommMsgMap) error {
+			require.Equal(t, IssueCredentialMsgType, msg.Type())
+
+			issue := IssueCredential{}
+			require.NoError(t, msg.Decode(&issue))
+			require.Len(t, issue.CredentialsAttach, 1)
+			require.Equal(t, "attach-id", issue.CredentialsAttach[0].ID)
+
+			done <- struct{}{}
+
+			return nil
+		})
+
+	svc := newService(t, ctrl, messenger)
+
+	actions := make(chan service.DIDCommAction, 1)
+	require.NoError(t, svc.RegisterActionEvent(actions))
+
+	// the Holder initiates the protocol by sending a proposal
+	_, err := svc.HandleInbound(service.NewDIDCommMsgMap(ProposeCredential{
+		Type: ProposeCredentialMsgType,
+		ID:   "propose-id",
+	}), Alice, Bob)
+	require.NoError(t, err)
+
+	action := waitForAction(t, actions)
+	require.Equal(t, Name, action.ProtocolName)
+	require.Equal(t, ProposeCredentialMsgType, action.Message.Type())
+	action.Continue(WithOfferCredential(&OfferCredential{Comment: "offer"}))
+	waitForDone(t, done)
+
+	// the Holder accepts the offer
+	piID, err := svc.HandleInbound(withThread(service.NewDIDCommMsgMap(RequestCredential{
+		Type: RequestCredentialMsgType,
+		ID:   "request-id",
+	}), "propose-id"), Alice, Bob)
+	require.NoError(t, err)
+	require.Equal(t, "propose-id", piID)
+
+	action = waitForAction(t, actions)
+	require.Equal(t, RequestCredentialMsgType, action.Message.Type())
+	action.Continue(WithIssueCredential(&IssueCredential{
+		CredentialsAttach: []decorator.Attachment{{ID: "attach-id"}},
+	}))
+	waitForDone(t, done)
+
+	// the Holder acknowledges the credential
+	_, err = svc.HandleInbound(service.NewDIDCommMsgMap(model.Ack{
+		Type:   AckMsgType,
+		ID:     "ack-id",
+		Thread: &decorator.Thread{ID: "propose-id"},
+	}), Alice, Bob)
+	require.NoError(t, err)
+
+	// the protocol is done, nothing can be received anymore
+	_, err = svc.HandleInbound(withThread(service.NewDIDCommMsgMap(RequestCredential{
+		Type: RequestCredentialMsgType,
+		ID:   "request-id-2",
+	}), "propose-id"), Alice, Bob)
+	require.Contains(t, err.Error(), "invalid state transition: done -> request-received")
+}
+
+func TestService_Holder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	done := make(chan struct{})
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+	messenger.EXPECT().Send(gomock.Any(), Alice, Bob).
+		Do(func(msg service.DIDCommMsgMap, _, _ string) error {
+			require.Equal(t, ProposeCredentialMsgType, msg.Type())
+			return nil
+		})
+	messenger.EXPECT().ReplyTo("offer-id", gomock.Any()).
+		Do(func(_ string, msg service.DIDCommMsgMap) error {
+			require.Equal(t, RequestCredentialMsgType, msg.Type())
+
+			done <- struct{}{}
+
+			return nil
+		})
+	messenger.EXPECT().ReplyTo("issue-id", gomock.Any()).
+		Do(func(_ string, msg service.DIDCommMsgMap) error {
+			require.Equal(t, AckMsgType, msg.Type())
+
+			done <- struct{}{}
+
+			return nil
+		})
+
+	svc := newService(t, ctrl, messenger)
+
+	actions := make(chan service.DIDCommAction, 1)
+	require.NoError(t, svc.RegisterActionEvent(actions))
+
+	events := make(chan service.StateMsg, 100)
+	require.NoError(t, svc.RegisterMsgEvent(events))
+
+	piID, err := svc.HandleOutbound(service.NewDIDCommMsgMap(ProposeCredential{
+		Type: ProposeCredentialMsgType,
+		ID:   "propose-id",
+	}), Alice, Bob)
+	require.NoError(t, err)
+	require.Equal(t, "propose-id", piID)
+
+	_, err = svc.HandleInbound(withThread(service.NewDIDCommMsgMap(OfferCredential{
+		Type: OfferCredentialMsgType,
+		ID:   "offer-id",
+	}), "propose-id"), Alice, Bob)
+	require.NoError(t, err)
+
+	waitForAction(t, actions).Continue(nil)
+	waitForDone(t, done)
+
+	_, err = svc.HandleInbound(withThread(service.NewDIDCommMsgMap(IssueCredential{
+		Type: IssueCredentialMsgType,
+		ID:   "issue-id",
+	}), "propose-id"), Alice, Bob)
+	require.NoError(t, err)
+
+	waitForAction(t, actions).Continue(nil)
+	waitForDone(t, done)
+
+	var states []string
+
+	for len(events) > 0 {
+		event := <-events
+		if event.Type == service.PostState {
+			states = append(states, event.StateID)
+		}
+	}
+
+	require.Equal(t, []string{
+		"proposal-sent", "offer-received", "request-sent", "credential-received", "done",
+	}, states)
+}
+
+func TestService_HolderProposesAfterOffer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	done := make(chan struct{})
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+	messenger.EXPECT().ReplyTo("offer-id", gomock.Any()).
+		Do(func(_ string, msg service.DIDCommMsgMap) error {
+			require.Equal(t, ProposeCredentialMsgType, msg.Type())
+
+			done <- struct{}{}
+
+			return nil
+		})
+
+	svc := newService(t, ctrl, messenger)
+
+	actions := make(chan service.DIDCommAction, 1)
+	require.NoError(t, svc.RegisterActionEvent(actions))
+
+	_, err := svc.HandleInbound(service.NewDIDCommMsgMap(OfferCredential{
+		Type: OfferCredentialMsgType,
+		ID:   "offer-id",
+	}), Alice, Bob)
+	require.NoError(t, err)
+
+	waitForAction(t, actions).Continue(WithProposeCredential(&ProposeCredential{Comment: "changes"}))
+	waitForDone(t, done)
+}
+
+func TestService_Stop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	done := make(chan struct{})
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+	messenger.EXPECT().ReplyTo("request-id", gomock.Any()).
+		Do(func(_ string, msg service.DIDCommMsgMap) error {
+			require.Equal(t, ProblemReportMsgType, msg.Type())
+
+			report := model.ProblemReport{}
+			require.NoError(t, msg.Decode(&report))
+			require.Equal(t, "rejected", report.Description.Code)
+
+			done <- struct{}{}
+
+			return nil
+		})
+	messenger.EXPECT().ReplyTo("request-id-2", gomock.Any()).
+		Do(func(_ string, msg service.DIDCommMsgMap) error {
+			require.Equal(t, ProblemReportMsgType, msg.Type())
+
+			report := model.ProblemReport{}
+			require.NoError(t, msg.Decode(&report))
+			require.Equal(t, "internal error", report.Description.Code)
+
+			done <- struct{}{}
+
+			return nil
+		})
+
+	svc := newService(t, ctrl, messenger)
+
+	actions := make(chan service.DIDCommAction, 1)
+	require.NoError(t, svc.RegisterActionEvent(actions))
+
+	t.Run("Stop by the user", func(t *testing.T) {
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(RequestCredential{
+			Type: RequestCredentialMsgType,
+			ID:   "request-id",
+		}), Alice, Bob)
+		require.NoError(t, err)
+
+		waitForAction(t, actions).Stop(errors.New("rejected"))
+		waitForDone(t, done)
+	})
+
+	t.Run("Credential was not provided", func(t *testing.T) {
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(RequestCredential{
+			Type: RequestCredentialMsgType,
+			ID:   "request-id-2",
+		}), Alice, Bob)
+		require.NoError(t, err)
+
+		waitForAction(t, actions).Continue(nil)
+		waitForDone(t, done)
+	})
+}
+
+func TestService_ActionsContinueStop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	done := make(chan struct{})
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+	messenger.EXPECT().ReplyTo("offer-id", gomock.Any()).
+		Do(func(_ string, msg service.DIDCommMsgMap) error {
+			require.Equal(t, RequestCredentialMsgType, msg.Type())
+
+			done <- struct{}{}
+
+			return nil
+		})
+	messenger.EXPECT().ReplyTo("offer-id-2", gomock.Any()).
+		Do(func(_ string, msg service.DIDCommMsgMap) error {
+			require.Equal(t, ProblemReportMsgType, msg.Type())
+
+			done <- struct{}{}
+
+			return nil
+		})
+
+	svc := newService(t, ctrl, messenger)
+
+	// the action channel must be registered but events are handled asynchronously
+	actions := make(chan service.DIDCommAction, 2)
+	require.NoError(t, svc.RegisterActionEvent(actions))
+
+	for _, id := range []string{"offer-id", "offer-id-2"} {
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(OfferCredential{
+			Type: OfferCredentialMsgType,
+			ID:   id,
+		}), Alice, Bob)
+		require.NoError(t, err)
+	}
+
+	list, err := svc.Actions()
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+
+	require.NoError(t, svc.Continue("offer-id", WithRequestCredential(&RequestCredential{Comment: "request"})))
+	waitForDone(t, done)
+
+	require.NoError(t, svc.Stop("offer-id-2", errors.New("rejected")))
+	waitForDone(t, done)
+
+	list, err = svc.Actions()
+	require.NoError(t, err)
+	require.Empty(t, list)
+
+	require.Contains(t, svc.Continue("offer-id", nil).Error(), "get transitional payload")
+	require.Contains(t, svc.Stop("offer-id", nil).Error(), "get transitional payload")
+}
+
+func TestService_HandleInbound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("No clients", func(t *testing.T) {
+		svc := newService(t, ctrl, nil)
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(OfferCredential{
+			Type: OfferCredentialMsgType,
+		}), Alice, Bob)
+		require.EqualError(t, err, "no clients are registered to handle the message")
+	})
+
+	t.Run("Unrecognized message type", func(t *testing.T) {
+		svc := newService(t, ctrl, nil)
+		require.NoError(t, svc.RegisterActionEvent(make(chan service.DIDCommAction)))
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(OfferCredential{
+			Type: "unknown",
+		}), Alice, Bob)
+		require.Contains(t, err.Error(), "unrecognized msgType: unknown")
+	})
+
+	t.Run("Invalid state transition", func(t *testing.T) {
+		svc := newService(t, ctrl, nil)
+		require.NoError(t, svc.RegisterActionEvent(make(chan service.DIDCommAction)))
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(IssueCredential{
+			Type: IssueCredentialMsgType,
+		}), Alice, Bob)
+		require.Contains(t, err.Error(), "invalid state transition: start -> credential-received")
+	})
+
+	t.Run("Problem report", func(t *testing.T) {
+		svc := newService(t, ctrl, nil)
+		require.NoError(t, svc.RegisterActionEvent(make(chan service.DIDCommAction)))
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(model.ProblemReport{
+			Type: ProblemReportMsgType,
+			ID:   "ID",
+		}), Alice, Bob)
+		require.NoError(t, err)
+	})
+
+	t.Run("Message of another connection", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(gomock.Any(), Alice, Bob).Return(nil)
+
+		svc := newService(t, ctrl, messenger)
+		require.NoError(t, svc.RegisterActionEvent(make(chan service.DIDCommAction)))
+
+		_, err := svc.HandleOutbound(service.NewDIDCommMsgMap(ProposeCredential{
+			Type: ProposeCredentialMsgType,
+			ID:   "propose-id",
+		}), Alice, Bob)
+		require.NoError(t, err)
+
+		// the thread of the proposal sent to Bob can't be continued by another DID
+		_, err = svc.HandleInbound(withThread(service.NewDIDCommMsgMap(OfferCredential{
+			Type: OfferCredentialMsgType,
+			ID:   "offer-id",
+		}), "propose-id"), Alice, "Mallory")
+		require.EqualError(t, err, "doHandle: thread propose-id belongs to another connection")
+	})
+}
+
+func TestService_HandleOutbound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Issue credential without request", func(t *testing.T) {
+		svc := newService(t, ctrl, nil)
+
+		_, err := svc.HandleOutbound(service.NewDIDCommMsgMap(IssueCredential{
+			Type: IssueCredentialMsgType,
+		}), Alice, Bob)
+		require.Contains(t, err.Error(), "issue-credential can be sent only as a response to the request")
+	})
+
+	t.Run("Send offer", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(gomock.Any(), Alice, Bob).Return(errors.New("send error"))
+
+		svc := newService(t, ctrl, messenger)
+
+		_, err := svc.HandleOutbound(service.NewDIDCommMsgMap(OfferCredential{
+			Type: OfferCredentialMsgType,
+		}), Alice, Bob)
+		require.EqualError(t, err, "send error")
+	})
+
+	t.Run("Send request", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(gomock.Any(), Alice, Bob).Return(nil)
+
+		svc := newService(t, ctrl, messenger)
+
+		piID, err := svc.HandleOutbound(service.NewDIDCommMsgMap(RequestCredential{
+			Type: RequestCredentialMsgType,
+		}), Alice, Bob)
+		require.NoError(t, err)
+		require.NotEmpty(t, piID)
+	})
+
+	t.Run("Message of another connection", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(gomock.Any(), Alice, Bob).Return(nil)
+
+		svc := newService(t, ctrl, messenger)
+
+		_, err := svc.HandleOutbound(service.NewDIDCommMsgMap(ProposeCredential{
+			Type: ProposeCredentialMsgType,
+			ID:   "propose-id",
+		}), Alice, Bob)
+		require.NoError(t, err)
+
+		// the thread of the proposal sent to Bob can't be continued with another DID
+		_, err = svc.HandleOutbound(withThread(service.NewDIDCommMsgMap(ProposeCredential{
+			Type: ProposeCredentialMsgType,
+			ID:   "propose-id-2",
+		}), "propose-id"), Alice, "Mallory")
+		require.EqualError(t, err, "doHandle: thread propose-id belongs to another connection")
+	})
+}
+
+func TestService_Accept(t *testing.T) {
+	svc := &Service{}
+
+	require.Equal(t, Name, svc.Name())
+	require.Equal(t, Spec, svc.Spec())
+	require.True(t, svc.Accept(ProposeCredentialMsgType))
+	require.True(t, svc.Accept(OfferCredentialMsgType))
+	require.True(t, svc.Accept(RequestCredentialMsgType))
+	require.True(t, svc.Accept(IssueCredentialMsgType))
+	require.True(t, svc.Accept(AckMsgType))
+	require.True(t, svc.Accept(ProblemReportMsgType))
+	require.False(t, svc.Accept("unknown"))
+}
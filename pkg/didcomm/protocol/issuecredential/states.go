@@ -0,0 +1,386 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"errors"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+const (
+	codeRejected      = "rejected"
+	codeInternalError = "internal error"
+)
+
+const (
+	// common states
+	stateNameNoop       = "noop"
+	stateNameStart      = "start"
+	stateNameAbandoning = "abandoning"
+	stateNameDone       = "done"
+
+	// states for Issuer
+	stateNameProposalReceived = "proposal-received"
+	stateNameOfferSent        = "offer-sent"
+	stateNameRequestReceived  = "request-received"
+	stateNameCredentialIssued = "credential-issued"
+
+	// states for Holder
+	stateNameProposalSent       = "proposal-sent"
+	stateNameOfferReceived      = "offer-received"
+	stateNameRequestSent        = "request-sent"
+	stateNameCredentialReceived = "credential-received"
+)
+
+// state action for network call
+type stateAction func() error
+
+// the protocol's state.
+type state interface {
+	// Name of this state.
+	Name() string
+	// CanTransitionTo checks whether this state allows transitioning into the next state.
+	CanTransitionTo(next state) bool
+	// ExecuteInbound/ExecuteOutbound execute this state, returning a followup state to be immediately executed as well.
+	// The 'noOp' state should be returned if the state has no followup.
+	ExecuteInbound(messenger service.Messenger, md *metaData) (state, stateAction, error)
+	ExecuteOutbound(messenger service.Messenger, md *metaData) (state, stateAction, error)
+}
+
+func zeroAction() error { return nil }
+
+// noOp state
+type noOp struct{}
+
+func (s *noOp) Name() string {
+	return stateNameNoop
+}
+
+func (s *noOp) CanTransitionTo(_ state) bool {
+	return false
+}
+
+func (s *noOp) ExecuteInbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("cannot execute no-op")
+}
+
+func (s *noOp) ExecuteOutbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("cannot execute no-op")
+}
+
+// start state
+type start struct{}
+
+func (s *start) Name() string {
+	return stateNameStart
+}
+
+func (s *start) CanTransitionTo(st state) bool {
+	switch st.Name() {
+	// Issuer starts
+	case stateNameProposalReceived, stateNameOfferSent, stateNameRequestReceived:
+		return true
+	// Holder starts
+	case stateNameProposalSent, stateNameOfferReceived, stateNameRequestSent:
+		return true
+	case stateNameAbandoning:
+		return true
+	}
+
+	return false
+}
+
+func (s *start) ExecuteInbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("start: ExecuteInbound function is not supposed to be used")
+}
+
+func (s *start) ExecuteOutbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("start: ExecuteOutbound function is not supposed to be used")
+}
+
+// abandoning state
+type abandoning struct {
+	Code string
+}
+
+func (s *abandoning) Name() string {
+	return stateNameAbandoning
+}
+
+func (s *abandoning) CanTransitionTo(st state) bool {
+	return st.Name() == stateNameDone
+}
+
+func (s *abandoning) ExecuteInbound(messenger service.Messenger, md *metaData) (state, stateAction, error) {
+	// if code is not provided it means we do not need to notify the another agent
+	if s.Code == "" {
+		return &done{}, zeroAction, nil
+	}
+
+	return &done{}, func() error {
+		return messenger.ReplyTo(md.Msg.ID(), service.NewDIDCommMsgMap(model.ProblemReport{
+			Type:        ProblemReportMsgType,
+			Description: model.Code{Code: s.Code},
+		}))
+	}, nil
+}
+
+func (s *abandoning) ExecuteOutbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("abandoning: ExecuteOutbound function is not supposed to be used")
+}
+
+// done state
+type done struct{}
+
+func (s *done) Name() string {
+	return stateNameDone
+}
+
+func (s *done) CanTransitionTo(_ state) bool {
+	// done is the last state there is no possibility for the next state
+	return false
+}
+
+func (s *done) ExecuteInbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return &noOp{}, zeroAction, nil
+}
+
+func (s *done) ExecuteOutbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("done: ExecuteOutbound function is not supposed to be used")
+}
+
+// proposalReceived the Issuer's state
+type proposalReceived struct{}
+
+func (s *proposalReceived) Name() string {
+	return stateNameProposalReceived
+}
+
+func (s *proposalReceived) CanTransitionTo(st state) bool {
+	return st.Name() == stateNameOfferSent || st.Name() == stateNameAbandoning
+}
+
+func (s *proposalReceived) ExecuteInbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return &offerSent{}, zeroAction, nil
+}
+
+func (s *proposalReceived) ExecuteOutbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("proposalReceived: ExecuteOutbound function is not supposed to be used")
+}
+
+// offerSent the Issuer's state
+type offerSent struct{}
+
+func (s *offerSent) Name() string {
+	return stateNameOfferSent
+}
+
+func (s *offerSent) CanTransitionTo(st state) bool {
+	return st.Name() == stateNameProposalReceived ||
+		st.Name() == stateNameRequestReceived ||
+		st.Name() == stateNameAbandoning
+}
+
+func (s *offerSent) ExecuteInbound(messenger service.Messenger, md *metaData) (state, stateAction, error) {
+	if md.offerCredential == nil {
+		return nil, nil, errors.New("offer credential was not provided")
+	}
+
+	// creates the state's action
+	action := func() error {
+		// sets message type
+		md.offerCredential.Type = OfferCredentialMsgType
+		return messenger.ReplyTo(md.Msg.ID(), service.NewDIDCommMsgMap(md.offerCredential))
+	}
+
+	return &noOp{}, action, nil
+}
+
+func (s *offerSent) ExecuteOutbound(messenger service.Messenger, md *metaData) (state, stateAction, error) {
+	// creates the state's action
+	action := func() error {
+		return messenger.Send(md.Msg, md.MyDID, md.TheirDID)
+	}
+
+	return &noOp{}, action, nil
+}
+
+// requestReceived the Issuer's state
+type requestReceived struct{}
+
+func (s *requestReceived) Name() string {
+	return stateNameRequestReceived
+}
+
+func (s *requestReceived) CanTransitionTo(st state) bool {
+	return st.Name() == stateNameCredentialIssued || st.Name() == stateNameAbandoning
+}
+
+func (s *requestReceived) ExecuteInbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return &credentialIssued{}, zeroAction, nil
+}
+
+func (s *requestReceived) ExecuteOutbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("requestReceived: ExecuteOutbound function is not supposed to be used")
+}
+
+// credentialIssued the Issuer's state
+type credentialIssued struct{}
+
+func (s *credentialIssued) Name() string {
+	return stateNameCredentialIssued
+}
+
+func (s *credentialIssued) CanTransitionTo(st state) bool {
+	return st.Name() == stateNameDone || st.Name() == stateNameAbandoning
+}
+
+func (s *credentialIssued) ExecuteInbound(messenger service.Messenger, md *metaData) (state, stateAction, error) {
+	if md.issueCredential == nil {
+		return nil, nil, errors.New("issue credential was not provided")
+	}
+
+	// creates the state's action
+	action := func() error {
+		// sets message type
+		md.issueCredential.Type = IssueCredentialMsgType
+		return messenger.ReplyTo(md.Msg.ID(), service.NewDIDCommMsgMap(md.issueCredential))
+	}
+
+	return &noOp{}, action, nil
+}
+
+func (s *credentialIssued) ExecuteOutbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("credentialIssued: ExecuteOutbound function is not supposed to be used")
+}
+
+// proposalSent the Holder's state
+type proposalSent struct{}
+
+func (s *proposalSent) Name() string {
+	return stateNameProposalSent
+}
+
+func (s *proposalSent) CanTransitionTo(st state) bool {
+	return st.Name() == stateNameOfferReceived || st.Name() == stateNameAbandoning
+}
+
+func (s *proposalSent) ExecuteInbound(messenger service.Messenger, md *metaData) (state, stateAction, error) {
+	if md.proposeCredential == nil {
+		return nil, nil, errors.New("propose credential was not provided")
+	}
+
+	// creates the state's action
+	action := func() error {
+		// sets message type
+		md.proposeCredential.Type = ProposeCredentialMsgType
+		return messenger.ReplyTo(md.Msg.ID(), service.NewDIDCommMsgMap(md.proposeCredential))
+	}
+
+	return &noOp{}, action, nil
+}
+
+func (s *proposalSent) ExecuteOutbound(messenger service.Messenger, md *metaData) (state, stateAction, error) {
+	// creates the state's action
+	action := func() error {
+		return messenger.Send(md.Msg, md.MyDID, md.TheirDID)
+	}
+
+	return &noOp{}, action, nil
+}
+
+// offerReceived the Holder's state
+type offerReceived struct{}
+
+func (s *offerReceived) Name() string {
+	return stateNameOfferReceived
+}
+
+func (s *offerReceived) CanTransitionTo(st state) bool {
+	return st.Name() == stateNameProposalSent ||
+		st.Name() == stateNameRequestSent ||
+		st.Name() == stateNameAbandoning
+}
+
+func (s *offerReceived) ExecuteInbound(_ service.Messenger, md *metaData) (state, stateAction, error) {
+	// proposal is provided it means the Holder wants to negotiate the offer
+	if md.proposeCredential != nil {
+		return &proposalSent{}, zeroAction, nil
+	}
+
+	return &requestSent{}, zeroAction, nil
+}
+
+func (s *offerReceived) ExecuteOutbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("offerReceived: ExecuteOutbound function is not supposed to be used")
+}
+
+// requestSent the Holder's state
+type requestSent struct{}
+
+func (s *requestSent) Name() string {
+	return stateNameRequestSent
+}
+
+func (s *requestSent) CanTransitionTo(st state) bool {
+	return st.Name() == stateNameCredentialReceived || st.Name() == stateNameAbandoning
+}
+
+func (s *requestSent) ExecuteInbound(messenger service.Messenger, md *metaData) (state, stateAction, error) {
+	request := md.requestCredential
+	// the request might be empty if the offer is accepted as is
+	if request == nil {
+		request = &RequestCredential{}
+	}
+
+	// creates the state's action
+	action := func() error {
+		// sets message type
+		request.Type = RequestCredentialMsgType
+		return messenger.ReplyTo(md.Msg.ID(), service.NewDIDCommMsgMap(request))
+	}
+
+	return &noOp{}, action, nil
+}
+
+func (s *requestSent) ExecuteOutbound(messenger service.Messenger, md *metaData) (state, stateAction, error) {
+	// creates the state's action
+	action := func() error {
+		return messenger.Send(md.Msg, md.MyDID, md.TheirDID)
+	}
+
+	return &noOp{}, action, nil
+}
+
+// credentialReceived the Holder's state
+type credentialReceived struct{}
+
+func (s *credentialReceived) Name() string {
+	return stateNameCredentialReceived
+}
+
+func (s *credentialReceived) CanTransitionTo(st state) bool {
+	return st.Name() == stateNameDone || st.Name() == stateNameAbandoning
+}
+
+func (s *credentialReceived) ExecuteInbound(messenger service.Messenger, md *metaData) (state, stateAction, error) {
+	// creates the state's action
+	action := func() error {
+		return messenger.ReplyTo(md.Msg.ID(), service.NewDIDCommMsgMap(model.Ack{
+			Type:   AckMsgType,
+			Status: "OK",
+		}))
+	}
+
+	return &done{}, action, nil
+}
+
+func (s *credentialReceived) ExecuteOutbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("credentialReceived: ExecuteOutbound function is not supposed to be used")
+}
@@ -0,0 +1,429 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+)
+
+func notTransition(t *testing.T, st state) {
+	t.Helper()
+
+	var allState = [...]state{
+		&noOp{}, &start{}, &abandoning{}, &done{},
+		&proposalReceived{}, &offerSent{}, &requestReceived{}, &credentialIssued{},
+		&proposalSent{}, &offerReceived{}, &requestSent{}, &credentialReceived{},
+	}
+
+	for _, s := range allState {
+		require.False(t, st.CanTransitionTo(s))
+	}
+}
+
+func TestStart_CanTransitionTo(t *testing.T) {
+	st := &start{}
+	require.Equal(t, stateNameStart, st.Name())
+
+	// common states
+	require.False(t, st.CanTransitionTo(&start{}))
+	require.True(t, st.CanTransitionTo(&abandoning{}))
+	require.False(t, st.CanTransitionTo(&done{}))
+	require.False(t, st.CanTransitionTo(&noOp{}))
+	// states for Issuer
+	require.True(t, st.CanTransitionTo(&proposalReceived{}))
+	require.True(t, st.CanTransitionTo(&offerSent{}))
+	require.True(t, st.CanTransitionTo(&requestReceived{}))
+	require.False(t, st.CanTransitionTo(&credentialIssued{}))
+	// states for Holder
+	require.True(t, st.CanTransitionTo(&proposalSent{}))
+	require.True(t, st.CanTransitionTo(&offerReceived{}))
+	require.True(t, st.CanTransitionTo(&requestSent{}))
+	require.False(t, st.CanTransitionTo(&credentialReceived{}))
+}
+
+func TestStart_Execute(t *testing.T) {
+	followup, action, err := (&start{}).ExecuteInbound(nil, &metaData{})
+	require.EqualError(t, err, "start: ExecuteInbound function is not supposed to be used")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+
+	followup, action, err = (&start{}).ExecuteOutbound(nil, &metaData{})
+	require.EqualError(t, err, "start: ExecuteOutbound function is not supposed to be used")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+}
+
+func TestNoOp_CanTransitionTo(t *testing.T) {
+	noop := &noOp{}
+	require.Equal(t, stateNameNoop, noop.Name())
+	notTransition(t, noop)
+}
+
+func TestNoOp_Execute(t *testing.T) {
+	followup, action, err := (&noOp{}).ExecuteInbound(nil, &metaData{})
+	require.EqualError(t, err, "cannot execute no-op")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+
+	followup, action, err = (&noOp{}).ExecuteOutbound(nil, &metaData{})
+	require.EqualError(t, err, "cannot execute no-op")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+}
+
+func TestDone_CanTransitionTo(t *testing.T) {
+	st := &done{}
+	require.Equal(t, stateNameDone, st.Name())
+	notTransition(t, st)
+}
+
+func TestDone_Execute(t *testing.T) {
+	followup, action, err := (&done{}).ExecuteInbound(nil, &metaData{})
+	require.NoError(t, err)
+	require.Equal(t, &noOp{}, followup)
+	require.NoError(t, action())
+
+	followup, action, err = (&done{}).ExecuteOutbound(nil, &metaData{})
+	require.EqualError(t, err, "done: ExecuteOutbound function is not supposed to be used")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+}
+
+func TestAbandoning_CanTransitionTo(t *testing.T) {
+	st := &abandoning{}
+	require.Equal(t, stateNameAbandoning, st.Name())
+
+	require.True(t, st.CanTransitionTo(&done{}))
+	require.False(t, st.CanTransitionTo(&abandoning{}))
+	require.False(t, st.CanTransitionTo(&offerSent{}))
+	require.False(t, st.CanTransitionTo(&requestSent{}))
+}
+
+func TestAbandoning_ExecuteInbound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("No code", func(t *testing.T) {
+		followup, action, err := (&abandoning{}).ExecuteInbound(nil, &metaData{})
+		require.NoError(t, err)
+		require.Equal(t, &done{}, followup)
+		require.NoError(t, action())
+	})
+
+	t.Run("Sends problem-report", func(t *testing.T) {
+		md := &metaData{}
+		md.Msg = service.NewDIDCommMsgMap(struct{}{})
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyTo(gomock.Any(), gomock.Any()).
+			Do(func(_ string, msg service.DIDCommMsgMap) error {
+				require.Equal(t, ProblemReportMsgType, msg.Type())
+				require.Equal(t, codeRejected, msg["description"].(map[string]interface{})["code"])
+
+				return nil
+			})
+
+		followup, action, err := (&abandoning{Code: codeRejected}).ExecuteInbound(messenger, md)
+		require.NoError(t, err)
+		require.Equal(t, &done{}, followup)
+		require.NoError(t, action())
+	})
+
+	followup, action, err := (&abandoning{}).ExecuteOutbound(nil, &metaData{})
+	require.EqualError(t, err, "abandoning: ExecuteOutbound function is not supposed to be used")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+}
+
+func TestProposalReceived(t *testing.T) {
+	st := &proposalReceived{}
+	require.Equal(t, stateNameProposalReceived, st.Name())
+
+	require.True(t, st.CanTransitionTo(&offerSent{}))
+	require.True(t, st.CanTransitionTo(&abandoning{}))
+	require.False(t, st.CanTransitionTo(&requestReceived{}))
+	require.False(t, st.CanTransitionTo(&done{}))
+
+	followup, action, err := st.ExecuteInbound(nil, &metaData{})
+	require.NoError(t, err)
+	require.Equal(t, &offerSent{}, followup)
+	require.NoError(t, action())
+
+	followup, action, err = st.ExecuteOutbound(nil, &metaData{})
+	require.EqualError(t, err, "proposalReceived: ExecuteOutbound function is not supposed to be used")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+}
+
+func TestOfferSent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := &offerSent{}
+	require.Equal(t, stateNameOfferSent, st.Name())
+
+	require.True(t, st.CanTransitionTo(&proposalReceived{}))
+	require.True(t, st.CanTransitionTo(&requestReceived{}))
+	require.True(t, st.CanTransitionTo(&abandoning{}))
+	require.False(t, st.CanTransitionTo(&credentialIssued{}))
+	require.False(t, st.CanTransitionTo(&done{}))
+
+	t.Run("Inbound without offer", func(t *testing.T) {
+		followup, action, err := st.ExecuteInbound(nil, &metaData{})
+		require.EqualError(t, err, "offer credential was not provided")
+		require.Nil(t, followup)
+		require.Nil(t, action)
+	})
+
+	t.Run("Inbound", func(t *testing.T) {
+		md := &metaData{offerCredential: &OfferCredential{}}
+		md.Msg = service.NewDIDCommMsgMap(struct{}{})
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyTo(gomock.Any(), gomock.Any()).
+			Do(func(_ string, msg service.DIDCommMsgMap) error {
+				require.Equal(t, OfferCredentialMsgType, msg.Type())
+				return nil
+			})
+
+		followup, action, err := st.ExecuteInbound(messenger, md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.NoError(t, action())
+	})
+
+	t.Run("Outbound", func(t *testing.T) {
+		md := &metaData{}
+		md.Msg = service.NewDIDCommMsgMap(OfferCredential{Type: OfferCredentialMsgType})
+		md.MyDID, md.TheirDID = "myDID", "theirDID"
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(md.Msg, "myDID", "theirDID").Return(errors.New("test error"))
+
+		followup, action, err := st.ExecuteOutbound(messenger, md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.EqualError(t, action(), "test error")
+	})
+}
+
+func TestRequestReceived(t *testing.T) {
+	st := &requestReceived{}
+	require.Equal(t, stateNameRequestReceived, st.Name())
+
+	require.True(t, st.CanTransitionTo(&credentialIssued{}))
+	require.True(t, st.CanTransitionTo(&abandoning{}))
+	require.False(t, st.CanTransitionTo(&offerSent{}))
+	require.False(t, st.CanTransitionTo(&done{}))
+
+	followup, action, err := st.ExecuteInbound(nil, &metaData{})
+	require.NoError(t, err)
+	require.Equal(t, &credentialIssued{}, followup)
+	require.NoError(t, action())
+
+	followup, action, err = st.ExecuteOutbound(nil, &metaData{})
+	require.EqualError(t, err, "requestReceived: ExecuteOutbound function is not supposed to be used")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+}
+
+func TestCredentialIssued(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := &credentialIssued{}
+	require.Equal(t, stateNameCredentialIssued, st.Name())
+
+	require.True(t, st.CanTransitionTo(&done{}))
+	require.True(t, st.CanTransitionTo(&abandoning{}))
+	require.False(t, st.CanTransitionTo(&requestReceived{}))
+
+	t.Run("Inbound without credential", func(t *testing.T) {
+		followup, action, err := st.ExecuteInbound(nil, &metaData{})
+		require.EqualError(t, err, "issue credential was not provided")
+		require.Nil(t, followup)
+		require.Nil(t, action)
+	})
+
+	t.Run("Inbound", func(t *testing.T) {
+		md := &metaData{issueCredential: &IssueCredential{}}
+		md.Msg = service.NewDIDCommMsgMap(struct{}{})
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyTo(gomock.Any(), gomock.Any()).
+			Do(func(_ string, msg service.DIDCommMsgMap) error {
+				require.Equal(t, IssueCredentialMsgType, msg.Type())
+				return nil
+			})
+
+		followup, action, err := st.ExecuteInbound(messenger, md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.NoError(t, action())
+	})
+
+	followup, action, err := st.ExecuteOutbound(nil, &metaData{})
+	require.EqualError(t, err, "credentialIssued: ExecuteOutbound function is not supposed to be used")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+}
+
+func TestProposalSent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := &proposalSent{}
+	require.Equal(t, stateNameProposalSent, st.Name())
+
+	require.True(t, st.CanTransitionTo(&offerReceived{}))
+	require.True(t, st.CanTransitionTo(&abandoning{}))
+	require.False(t, st.CanTransitionTo(&requestSent{}))
+	require.False(t, st.CanTransitionTo(&done{}))
+
+	t.Run("Inbound without proposal", func(t *testing.T) {
+		followup, action, err := st.ExecuteInbound(nil, &metaData{})
+		require.EqualError(t, err, "propose credential was not provided")
+		require.Nil(t, followup)
+		require.Nil(t, action)
+	})
+
+	t.Run("Inbound", func(t *testing.T) {
+		md := &metaData{proposeCredential: &ProposeCredential{}}
+		md.Msg = service.NewDIDCommMsgMap(struct{}{})
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyTo(gomock.Any(), gomock.Any()).
+			Do(func(_ string, msg service.DIDCommMsgMap) error {
+				require.Equal(t, ProposeCredentialMsgType, msg.Type())
+				return nil
+			})
+
+		followup, action, err := st.ExecuteInbound(messenger, md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.NoError(t, action())
+	})
+
+	t.Run("Outbound", func(t *testing.T) {
+		md := &metaData{}
+		md.Msg = service.NewDIDCommMsgMap(ProposeCredential{Type: ProposeCredentialMsgType})
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(md.Msg, gomock.Any(), gomock.Any()).Return(nil)
+
+		followup, action, err := st.ExecuteOutbound(messenger, md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.NoError(t, action())
+	})
+}
+
+func TestOfferReceived(t *testing.T) {
+	st := &offerReceived{}
+	require.Equal(t, stateNameOfferReceived, st.Name())
+
+	require.True(t, st.CanTransitionTo(&proposalSent{}))
+	require.True(t, st.CanTransitionTo(&requestSent{}))
+	require.True(t, st.CanTransitionTo(&abandoning{}))
+	require.False(t, st.CanTransitionTo(&credentialReceived{}))
+
+	followup, action, err := st.ExecuteInbound(nil, &metaData{})
+	require.NoError(t, err)
+	require.Equal(t, &requestSent{}, followup)
+	require.NoError(t, action())
+
+	followup, action, err = st.ExecuteInbound(nil, &metaData{proposeCredential: &ProposeCredential{}})
+	require.NoError(t, err)
+	require.Equal(t, &proposalSent{}, followup)
+	require.NoError(t, action())
+
+	followup, action, err = st.ExecuteOutbound(nil, &metaData{})
+	require.EqualError(t, err, "offerReceived: ExecuteOutbound function is not supposed to be used")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+}
+
+func TestRequestSent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := &requestSent{}
+	require.Equal(t, stateNameRequestSent, st.Name())
+
+	require.True(t, st.CanTransitionTo(&credentialReceived{}))
+	require.True(t, st.CanTransitionTo(&abandoning{}))
+	require.False(t, st.CanTransitionTo(&offerReceived{}))
+
+	t.Run("Inbound (default request)", func(t *testing.T) {
+		md := &metaData{}
+		md.Msg = service.NewDIDCommMsgMap(struct{}{})
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyTo(gomock.Any(), gomock.Any()).
+			Do(func(_ string, msg service.DIDCommMsgMap) error {
+				require.Equal(t, RequestCredentialMsgType, msg.Type())
+				return nil
+			})
+
+		followup, action, err := st.ExecuteInbound(messenger, md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.NoError(t, action())
+	})
+
+	t.Run("Outbound", func(t *testing.T) {
+		md := &metaData{}
+		md.Msg = service.NewDIDCommMsgMap(RequestCredential{Type: RequestCredentialMsgType})
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(md.Msg, gomock.Any(), gomock.Any()).Return(nil)
+
+		followup, action, err := st.ExecuteOutbound(messenger, md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.NoError(t, action())
+	})
+}
+
+func TestCredentialReceived(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := &credentialReceived{}
+	require.Equal(t, stateNameCredentialReceived, st.Name())
+
+	require.True(t, st.CanTransitionTo(&done{}))
+	require.True(t, st.CanTransitionTo(&abandoning{}))
+	require.False(t, st.CanTransitionTo(&requestSent{}))
+
+	md := &metaData{}
+	md.Msg = service.NewDIDCommMsgMap(struct{}{})
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+	messenger.EXPECT().ReplyTo(gomock.Any(), gomock.Any()).
+		Do(func(_ string, msg service.DIDCommMsgMap) error {
+			require.Equal(t, AckMsgType, msg.Type())
+			return nil
+		})
+
+	followup, action, err := st.ExecuteInbound(messenger, md)
+	require.NoError(t, err)
+	require.Equal(t, &done{}, followup)
+	require.NoError(t, action())
+
+	followup, action, err = st.ExecuteOutbound(nil, &metaData{})
+	require.EqualError(t, err, "credentialReceived: ExecuteOutbound function is not supposed to be used")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+}
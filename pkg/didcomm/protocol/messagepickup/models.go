@@ -0,0 +1,78 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package messagepickup
+
+import (
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// StatusRequest is sent by the recipient to learn about the messages queued by the mediator.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0212-pickup#status-request
+type StatusRequest struct {
+	Type   string            `json:"@type,omitempty"`
+	ID     string            `json:"@id,omitempty"`
+	Thread *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// Status details the messages queued for the recipient.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0212-pickup#status
+type Status struct {
+	Type              string            `json:"@type,omitempty"`
+	ID                string            `json:"@id,omitempty"`
+	MessageCount      int               `json:"message_count"`
+	DurationWaited    int               `json:"duration_waited,omitempty"`
+	LastAddedTime     time.Time         `json:"last_added_time,omitempty"`
+	LastDeliveredTime time.Time         `json:"last_delivered_time,omitempty"`
+	LastRemovedTime   time.Time         `json:"last_removed_time,omitempty"`
+	TotalSize         int               `json:"total_size,omitempty"`
+	Thread            *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// BatchPickup is sent by the recipient to request the delivery of the queued messages.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0212-pickup#batch-pickup
+type BatchPickup struct {
+	Type      string            `json:"@type,omitempty"`
+	ID        string            `json:"@id,omitempty"`
+	BatchSize int               `json:"batch_size"`
+	Thread    *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// Batch is the response to the batch pickup with the queued messages.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0212-pickup#batch
+type Batch struct {
+	Type     string            `json:"@type,omitempty"`
+	ID       string            `json:"@id,omitempty"`
+	Messages []*Message        `json:"messages~attach"`
+	Thread   *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// Message is the queued message (packed envelope).
+type Message struct {
+	ID        string          `json:"id"`
+	AddedTime time.Time       `json:"added_time"`
+	Message   *model.Envelope `json:"msg,omitempty"`
+}
+
+// Noop is sent by the recipient to allow the mediator to deliver the queued messages
+// over the return route of the transport (e.g WebSocket).
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0212-pickup#noop
+type Noop struct {
+	Type string `json:"@type,omitempty"`
+	ID   string `json:"@id,omitempty"`
+}
+
+// inbox keeps the messages queued for the recipient.
+type inbox struct {
+	DID               string     `json:"DID"`
+	LastAddedTime     time.Time  `json:"last_added_time,omitempty"`
+	LastDeliveredTime time.Time  `json:"last_delivered_time,omitempty"`
+	LastRemovedTime   time.Time  `json:"last_removed_time,omitempty"`
+	Messages          []*Message `json:"messages"`
+}
@@ -0,0 +1,536 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package messagepickup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	commontransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+var logger = log.New("aries-framework/messagepickup/service")
+
+// constants for message pickup spec types
+const (
+	// MessagePickup message pickup protocol
+	MessagePickup = "messagepickup"
+
+	// MessagePickupSpec defines the message pickup spec
+	MessagePickupSpec = "https://didcomm.org/messagepickup/1.0/"
+
+	// StatusMsgType defines the message pickup status message type.
+	StatusMsgType = MessagePickupSpec + "status"
+
+	// StatusRequestMsgType defines the message pickup status request message type.
+	StatusRequestMsgType = MessagePickupSpec + "status-request"
+
+	// BatchPickupMsgType defines the message pickup batch pickup message type.
+	BatchPickupMsgType = MessagePickupSpec + "batch-pickup"
+
+	// BatchMsgType defines the message pickup batch message type.
+	BatchMsgType = MessagePickupSpec + "batch"
+
+	// NoopMsgType defines the message pickup noop message type.
+	NoopMsgType = MessagePickupSpec + "noop"
+)
+
+const (
+	updateTimeout = 5 * time.Second
+)
+
+// ErrConnectionNotFound connection not found error
+var ErrConnectionNotFound = errors.New("connection not found")
+
+// provider contains dependencies for the Message Pickup protocol and is typically created by using aries.Context()
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	TransientStorageProvider() storage.Provider
+	Packager() commontransport.Packager
+	InboundMessageHandler() transport.InboundMessageHandler
+}
+
+// Service for Message Pickup protocol.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0212-pickup
+type Service struct {
+	inboxStore       storage.Store
+	inboxLock        sync.Mutex
+	connectionLookup *connection.Lookup
+	outbound         dispatcher.Outbound
+	packager         commontransport.Packager
+	inboundHandler   transport.InboundMessageHandler
+	statusMap        map[string]chan *Status
+	statusMapLock    sync.RWMutex
+	batchMap         map[string]chan *Batch
+	batchMapLock     sync.RWMutex
+}
+
+// New return message pickup service.
+func New(prov provider) (*Service, error) {
+	store, err := prov.StorageProvider().OpenStore(MessagePickup)
+	if err != nil {
+		return nil, fmt.Errorf("open message pickup store : %w", err)
+	}
+
+	connectionLookup, err := connection.NewLookup(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		inboxStore:       store,
+		connectionLookup: connectionLookup,
+		outbound:         prov.OutboundDispatcher(),
+		packager:         prov.Packager(),
+		inboundHandler:   prov.InboundMessageHandler(),
+		statusMap:        make(map[string]chan *Status),
+		batchMap:         make(map[string]chan *Batch),
+	}, nil
+}
+
+// HandleInbound handles inbound message pickup messages.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	// perform action on inbound message asynchronously
+	go func() {
+		var err error
+
+		switch msg.Type() {
+		case StatusRequestMsgType:
+			err = s.handleStatusRequest(msg, myDID, theirDID)
+		case StatusMsgType:
+			err = s.handleStatus(msg)
+		case BatchPickupMsgType:
+			err = s.handleBatchPickup(msg, myDID, theirDID)
+		case BatchMsgType:
+			err = s.handleBatch(msg)
+		case NoopMsgType:
+			err = s.handleNoop(myDID, theirDID)
+		}
+
+		if err != nil {
+			logutil.LogError(logger, MessagePickup, "processMessage", err.Error(),
+				logutil.CreateKeyValueString("msgType", msg.Type()),
+				logutil.CreateKeyValueString("msgID", msg.ID()))
+		} else {
+			logutil.LogDebug(logger, MessagePickup, "processMessage", "success",
+				logutil.CreateKeyValueString("msgType", msg.Type()),
+				logutil.CreateKeyValueString("msgID", msg.ID()))
+		}
+	}()
+
+	return msg.ID(), nil
+}
+
+// HandleOutbound handles outbound message pickup messages.
+func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	switch msgType {
+	case StatusRequestMsgType, StatusMsgType, BatchPickupMsgType, BatchMsgType, NoopMsgType:
+		return true
+	}
+
+	return false
+}
+
+// Name of the service
+func (s *Service) Name() string {
+	return MessagePickup
+}
+
+// Spec returns the specification URI of the protocol.
+func (s *Service) Spec() string {
+	return MessagePickupSpec
+}
+
+// AddMessage queues the message (packed envelope) for the recipient identified by theirDID.
+// The mediator queues the messages which can't be delivered to the recipient (e.g the edge agent is offline).
+func (s *Service) AddMessage(message *model.Envelope, theirDID string) error {
+	s.inboxLock.Lock()
+	defer s.inboxLock.Unlock()
+
+	box, err := s.getInbox(theirDID)
+	if err != nil {
+		return fmt.Errorf("get inbox : %w", err)
+	}
+
+	box.LastAddedTime = time.Now().UTC()
+	box.Messages = append(box.Messages, &Message{
+		ID:        uuid.New().String(),
+		AddedTime: box.LastAddedTime,
+		Message:   message,
+	})
+
+	return s.saveInbox(box)
+}
+
+// StatusRequest requests the status of the queued messages from the mediator on the other end of
+// the connection identified by connectionID. This method blocks until a response is received or it times out.
+func (s *Service) StatusRequest(connectionID string) (*Status, error) {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	msgID := uuid.New().String()
+
+	// register chan for callback processing
+	statusCh := make(chan *Status, 1)
+	s.setStatusCh(msgID, statusCh)
+
+	// remove the channel once its been processed
+	defer s.setStatusCh(msgID, nil)
+
+	req := &StatusRequest{
+		ID:     msgID,
+		Type:   StatusRequestMsgType,
+		Thread: &decorator.Thread{ID: msgID},
+	}
+
+	if err := s.outbound.SendToDID(req, conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send status request : %w", err)
+	}
+
+	select {
+	case status := <-statusCh:
+		return status, nil
+	case <-time.After(updateTimeout):
+		return nil, errors.New("timeout waiting for status from the mediator")
+	}
+}
+
+// BatchPickup requests up to batchSize queued messages from the mediator on the other end of the connection
+// identified by connectionID. The received messages are handled by the framework as any other inbound message.
+// This method blocks until the batch is received or it times out. Returns the number of received messages.
+func (s *Service) BatchPickup(connectionID string, batchSize int) (int, error) {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return 0, err
+	}
+
+	msgID := uuid.New().String()
+
+	// register chan for callback processing
+	batchCh := make(chan *Batch, 1)
+	s.setBatchCh(msgID, batchCh)
+
+	// remove the channel once its been processed
+	defer s.setBatchCh(msgID, nil)
+
+	req := &BatchPickup{
+		ID:        msgID,
+		Type:      BatchPickupMsgType,
+		BatchSize: batchSize,
+		Thread:    &decorator.Thread{ID: msgID},
+	}
+
+	if err := s.outbound.SendToDID(req, conn.MyDID, conn.TheirDID); err != nil {
+		return 0, fmt.Errorf("send batch pickup request : %w", err)
+	}
+
+	select {
+	case batch := <-batchCh:
+		return len(batch.Messages), nil
+	case <-time.After(updateTimeout):
+		return 0, errors.New("timeout waiting for batch from the mediator")
+	}
+}
+
+// Noop sends the noop message to the mediator on the other end of the connection identified by connectionID.
+// When the message is sent with the return route option (e.g over WebSocket), the mediator delivers
+// the queued messages implicitly over the same connection.
+func (s *Service) Noop(connectionID string) error {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return err
+	}
+
+	noop := &Noop{
+		ID:   uuid.New().String(),
+		Type: NoopMsgType,
+	}
+
+	if err := s.outbound.SendToDID(noop, conn.MyDID, conn.TheirDID); err != nil {
+		return fmt.Errorf("send noop request : %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) handleStatusRequest(msg service.DIDCommMsg, myDID, theirDID string) error {
+	// unmarshal the payload
+	request := &StatusRequest{}
+
+	err := msg.Decode(request)
+	if err != nil {
+		return fmt.Errorf("status request message unmarshal : %w", err)
+	}
+
+	s.inboxLock.Lock()
+	box, err := s.getInbox(theirDID)
+	s.inboxLock.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("get inbox : %w", err)
+	}
+
+	status := &Status{
+		Type:              StatusMsgType,
+		ID:                uuid.New().String(),
+		MessageCount:      len(box.Messages),
+		LastAddedTime:     box.LastAddedTime,
+		LastDeliveredTime: box.LastDeliveredTime,
+		LastRemovedTime:   box.LastRemovedTime,
+		TotalSize:         box.size(),
+		Thread:            &decorator.Thread{ID: request.ID},
+	}
+
+	if len(box.Messages) > 0 {
+		status.DurationWaited = int(time.Since(box.Messages[0].AddedTime).Seconds())
+	}
+
+	return s.outbound.SendToDID(status, myDID, theirDID)
+}
+
+func (s *Service) handleStatus(msg service.DIDCommMsg) error {
+	// unmarshal the payload
+	status := &Status{}
+
+	err := msg.Decode(status)
+	if err != nil {
+		return fmt.Errorf("status message unmarshal : %w", err)
+	}
+
+	if status.Thread == nil {
+		return errors.New("status message thread is missing")
+	}
+
+	// check if there are any channels registered for the thread ID, the duplicate statuses are dropped
+	if statusCh := s.getStatusCh(status.Thread.ID); statusCh != nil {
+		select {
+		case statusCh <- status:
+		default:
+			logger.Warnf("dropping duplicate status message of thread %s", status.Thread.ID)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) handleBatchPickup(msg service.DIDCommMsg, myDID, theirDID string) error {
+	// unmarshal the payload
+	request := &BatchPickup{}
+
+	err := msg.Decode(request)
+	if err != nil {
+		return fmt.Errorf("batch pickup message unmarshal : %w", err)
+	}
+
+	return s.deliver(request.ID, request.BatchSize, myDID, theirDID)
+}
+
+func (s *Service) handleNoop(myDID, theirDID string) error {
+	// deliver all the queued messages over the return route
+	return s.deliver("", 0, myDID, theirDID)
+}
+
+// deliver sends up to batchSize (all if zero) queued messages to the recipient in a batch.
+// The messages are removed from the inbox once they are sent.
+func (s *Service) deliver(thID string, batchSize int, myDID, theirDID string) error {
+	s.inboxLock.Lock()
+	defer s.inboxLock.Unlock()
+
+	box, err := s.getInbox(theirDID)
+	if err != nil {
+		return fmt.Errorf("get inbox : %w", err)
+	}
+
+	// there is nothing to deliver implicitly
+	if thID == "" && len(box.Messages) == 0 {
+		return nil
+	}
+
+	size := len(box.Messages)
+	if batchSize > 0 && batchSize < size {
+		size = batchSize
+	}
+
+	batch := &Batch{
+		Type:     BatchMsgType,
+		ID:       uuid.New().String(),
+		Messages: box.Messages[:size],
+	}
+
+	if thID != "" {
+		batch.Thread = &decorator.Thread{ID: thID}
+	}
+
+	if err = s.outbound.SendToDID(batch, myDID, theirDID); err != nil {
+		return fmt.Errorf("send batch : %w", err)
+	}
+
+	box.Messages = box.Messages[size:]
+	box.LastDeliveredTime = time.Now().UTC()
+	box.LastRemovedTime = box.LastDeliveredTime
+
+	return s.saveInbox(box)
+}
+
+func (s *Service) handleBatch(msg service.DIDCommMsg) error {
+	// unmarshal the payload
+	batch := &Batch{}
+
+	err := msg.Decode(batch)
+	if err != nil {
+		return fmt.Errorf("batch message unmarshal : %w", err)
+	}
+
+	for _, m := range batch.Messages {
+		if err := s.handleMessage(m); err != nil {
+			logutil.LogError(logger, MessagePickup, "handleBatch", err.Error(),
+				logutil.CreateKeyValueString("messageID", m.ID))
+		}
+	}
+
+	// check if there are any channels registered for the thread ID, the duplicate batches are dropped
+	if batch.Thread != nil {
+		if batchCh := s.getBatchCh(batch.Thread.ID); batchCh != nil {
+			select {
+			case batchCh <- batch:
+			default:
+				logger.Warnf("dropping duplicate batch message of thread %s", batch.Thread.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// handleMessage unpacks the queued message and passes it to the framework inbound message handler.
+func (s *Service) handleMessage(m *Message) error {
+	if m.Message == nil {
+		return errors.New("message is empty")
+	}
+
+	envelope, err := json.Marshal(m.Message)
+	if err != nil {
+		return fmt.Errorf("marshal envelope : %w", err)
+	}
+
+	unpackMsg, err := s.packager.UnpackMessage(envelope)
+	if err != nil {
+		return fmt.Errorf("unpack message : %w", err)
+	}
+
+	return s.inboundHandler(unpackMsg.Message, unpackMsg.ToDID, unpackMsg.FromDID)
+}
+
+func (s *Service) getInbox(theirDID string) (*inbox, error) {
+	src, err := s.inboxStore.Get(theirDID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return &inbox{DID: theirDID}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	box := &inbox{}
+	if err = json.Unmarshal(src, box); err != nil {
+		return nil, fmt.Errorf("unmarshal inbox : %w", err)
+	}
+
+	return box, nil
+}
+
+func (s *Service) saveInbox(box *inbox) error {
+	src, err := json.Marshal(box)
+	if err != nil {
+		return fmt.Errorf("marshal inbox : %w", err)
+	}
+
+	return s.inboxStore.Put(box.DID, src)
+}
+
+func (b *inbox) size() int {
+	var size int
+
+	for _, m := range b.Messages {
+		if m.Message != nil {
+			size += len(m.Message.CipherText)
+		}
+	}
+
+	return size
+}
+
+func (s *Service) getStatusCh(msgID string) chan *Status {
+	s.statusMapLock.RLock()
+	defer s.statusMapLock.RUnlock()
+
+	return s.statusMap[msgID]
+}
+
+func (s *Service) setStatusCh(msgID string, statusCh chan *Status) {
+	s.statusMapLock.Lock()
+	defer s.statusMapLock.Unlock()
+
+	if statusCh == nil {
+		delete(s.statusMap, msgID)
+	} else {
+		s.statusMap[msgID] = statusCh
+	}
+}
+
+func (s *Service) getBatchCh(msgID string) chan *Batch {
+	s.batchMapLock.RLock()
+	defer s.batchMapLock.RUnlock()
+
+	return s.batchMap[msgID]
+}
+
+func (s *Service) setBatchCh(msgID string, batchCh chan *Batch) {
+	s.batchMapLock.Lock()
+	defer s.batchMapLock.Unlock()
+
+	if batchCh == nil {
+		delete(s.batchMap, msgID)
+	} else {
+		s.batchMap[msgID] = batchCh
+	}
+}
+
+func (s *Service) getConnection(connectionID string) (*connection.Record, error) {
+	conn, err := s.connectionLookup.GetConnectionRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrConnectionNotFound
+		}
+
+		return nil, fmt.Errorf("fetch connection record from store : %w", err)
+	}
+
+	return conn, nil
+}
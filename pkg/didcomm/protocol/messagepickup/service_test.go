@@ -0,0 +1,334 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package messagepickup
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	commontransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/internal/mock/didcomm/dispatcher"
+	mockpackager "github.com/hyperledger/aries-framework-go/pkg/internal/mock/didcomm/packager"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	mediatorDID = "did:example:mediator"
+	edgeDID     = "did:example:edge"
+	connID      = "connID"
+)
+
+type mockProvider struct {
+	outbound       dispatcher.Outbound
+	store          storage.Provider
+	transientStore storage.Provider
+	packager       commontransport.Packager
+	inboundHandler transport.InboundMessageHandler
+}
+
+func (p *mockProvider) OutboundDispatcher() dispatcher.Outbound {
+	return p.outbound
+}
+
+func (p *mockProvider) StorageProvider() storage.Provider {
+	return p.store
+}
+
+func (p *mockProvider) TransientStorageProvider() storage.Provider {
+	return p.transientStore
+}
+
+func (p *mockProvider) Packager() commontransport.Packager {
+	return p.packager
+}
+
+func (p *mockProvider) InboundMessageHandler() transport.InboundMessageHandler {
+	return p.inboundHandler
+}
+
+func newProvider() *mockProvider {
+	return &mockProvider{
+		outbound:       &mockdispatcher.MockOutbound{},
+		store:          mockstore.NewMockStoreProvider(),
+		transientStore: mockstore.NewMockStoreProvider(),
+		packager:       &mockpackager.Packager{},
+	}
+}
+
+// newServices creates the mediator and the edge agent services connected to each other.
+func newServices(t *testing.T, inboundHandler transport.InboundMessageHandler) (mediator, edge *Service) {
+	t.Helper()
+
+	mediatorProv := newProvider()
+	edgeProv := newProvider()
+	edgeProv.inboundHandler = inboundHandler
+	edgeProv.packager = &mockpackager.Packager{UnpackValue: &commontransport.Envelope{
+		Message: []byte(`{"@type": "https://didcomm.org/test/1.0/message"}`),
+		ToDID:   edgeDID,
+		FromDID: "did:example:sender",
+	}}
+
+	mediatorProv.outbound = &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+			_, err := edge.HandleInbound(service.NewDIDCommMsgMap(msg), theirDID, myDID)
+
+			return err
+		},
+	}
+	edgeProv.outbound = &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+			_, err := mediator.HandleInbound(service.NewDIDCommMsgMap(msg), theirDID, myDID)
+
+			return err
+		},
+	}
+
+	recorder, err := connection.NewRecorder(edgeProv)
+	require.NoError(t, err)
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: connID,
+		ThreadID:     "thID",
+		State:        "completed",
+		MyDID:        edgeDID,
+		TheirDID:     mediatorDID,
+	}))
+
+	mediator, err = New(mediatorProv)
+	require.NoError(t, err)
+
+	edge, err = New(edgeProv)
+	require.NoError(t, err)
+
+	return mediator, edge
+}
+
+func TestServiceNew(t *testing.T) {
+	t.Run("test error from open store", func(t *testing.T) {
+		prov := newProvider()
+		prov.store = &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("failed to open store")}
+
+		_, err := New(prov)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open message pickup store")
+	})
+
+	t.Run("test error from open transient store", func(t *testing.T) {
+		prov := newProvider()
+		prov.transientStore = &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("failed to open store")}
+
+		_, err := New(prov)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to open transient store")
+	})
+
+	t.Run("test service accept", func(t *testing.T) {
+		svc, err := New(newProvider())
+		require.NoError(t, err)
+
+		require.Equal(t, MessagePickup, svc.Name())
+		require.Equal(t, MessagePickupSpec, svc.Spec())
+		require.True(t, svc.Accept(StatusRequestMsgType))
+		require.True(t, svc.Accept(StatusMsgType))
+		require.True(t, svc.Accept(BatchPickupMsgType))
+		require.True(t, svc.Accept(BatchMsgType))
+		require.True(t, svc.Accept(NoopMsgType))
+		require.False(t, svc.Accept("unsupported msg type"))
+
+		_, err = svc.HandleOutbound(service.NewDIDCommMsgMap(&Noop{Type: NoopMsgType}), "", "")
+		require.EqualError(t, err, "not implemented")
+	})
+}
+
+func TestStatusRequest(t *testing.T) {
+	t.Run("test status request - success", func(t *testing.T) {
+		mediator, edge := newServices(t, nil)
+
+		status, err := edge.StatusRequest(connID)
+		require.NoError(t, err)
+		require.Equal(t, 0, status.MessageCount)
+
+		require.NoError(t, mediator.AddMessage(&model.Envelope{CipherText: "abc"}, edgeDID))
+		require.NoError(t, mediator.AddMessage(&model.Envelope{CipherText: "de"}, edgeDID))
+
+		status, err = edge.StatusRequest(connID)
+		require.NoError(t, err)
+		require.Equal(t, 2, status.MessageCount)
+		require.Equal(t, 5, status.TotalSize)
+		require.False(t, status.LastAddedTime.IsZero())
+	})
+
+	t.Run("test status request - connection not found", func(t *testing.T) {
+		_, edge := newServices(t, nil)
+
+		_, err := edge.StatusRequest("unknown")
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+	})
+
+	t.Run("test status request - send error", func(t *testing.T) {
+		_, edge := newServices(t, nil)
+		edge.outbound = &mockdispatcher.MockOutbound{SendErr: errors.New("send error")}
+
+		_, err := edge.StatusRequest(connID)
+		require.EqualError(t, err, "send status request : send error")
+	})
+}
+
+func TestBatchPickup(t *testing.T) {
+	t.Run("test batch pickup - success", func(t *testing.T) {
+		received := make(chan []byte, 3)
+
+		mediator, edge := newServices(t, func(message []byte, myDID, theirDID string) error {
+			require.Equal(t, edgeDID, myDID)
+			received <- message
+
+			return nil
+		})
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, mediator.AddMessage(&model.Envelope{CipherText: "abc"}, edgeDID))
+		}
+
+		count, err := edge.BatchPickup(connID, 2)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+		require.Len(t, received, 2)
+
+		status, err := edge.StatusRequest(connID)
+		require.NoError(t, err)
+		require.Equal(t, 1, status.MessageCount)
+		require.False(t, status.LastDeliveredTime.IsZero())
+
+		count, err = edge.BatchPickup(connID, 2)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		// the inbox is empty
+		count, err = edge.BatchPickup(connID, 2)
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+	})
+
+	t.Run("test batch pickup - messages are kept when the batch is not sent", func(t *testing.T) {
+		mediator, _ := newServices(t, nil)
+		mediator.outbound = &mockdispatcher.MockOutbound{SendErr: errors.New("send error")}
+
+		require.NoError(t, mediator.AddMessage(&model.Envelope{CipherText: "abc"}, edgeDID))
+
+		err := mediator.deliver("thID", 0, mediatorDID, edgeDID)
+		require.EqualError(t, err, "send batch : send error")
+
+		box, err := mediator.getInbox(edgeDID)
+		require.NoError(t, err)
+		require.Len(t, box.Messages, 1)
+	})
+
+	t.Run("test batch pickup - connection not found", func(t *testing.T) {
+		_, edge := newServices(t, nil)
+
+		_, err := edge.BatchPickup("unknown", 1)
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+	})
+}
+
+func TestNoop(t *testing.T) {
+	t.Run("test noop - implicit delivery", func(t *testing.T) {
+		received := make(chan []byte)
+
+		mediator, edge := newServices(t, func(message []byte, myDID, theirDID string) error {
+			received <- message
+
+			return nil
+		})
+
+		require.NoError(t, mediator.AddMessage(&model.Envelope{CipherText: "abc"}, edgeDID))
+		require.NoError(t, edge.Noop(connID))
+
+		select {
+		case msg := <-received:
+			require.JSONEq(t, `{"@type": "https://didcomm.org/test/1.0/message"}`, string(msg))
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for the queued message")
+		}
+	})
+
+	t.Run("test noop - nothing to deliver", func(t *testing.T) {
+		mediator, _ := newServices(t, nil)
+		mediator.outbound = &mockdispatcher.MockOutbound{SendErr: errors.New("send error")}
+
+		require.NoError(t, mediator.handleNoop(mediatorDID, edgeDID))
+	})
+
+	t.Run("test noop - connection not found", func(t *testing.T) {
+		_, edge := newServices(t, nil)
+
+		err := edge.Noop("unknown")
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+	})
+}
+
+func TestHandleMessages(t *testing.T) {
+	svc, err := New(newProvider())
+	require.NoError(t, err)
+
+	t.Run("test handle status - missing thread", func(t *testing.T) {
+		err = svc.handleStatus(service.NewDIDCommMsgMap(&Status{Type: StatusMsgType}))
+		require.EqualError(t, err, "status message thread is missing")
+	})
+
+	t.Run("test handle status - duplicate status", func(t *testing.T) {
+		statusCh := make(chan *Status, 1)
+		svc.setStatusCh("thID", statusCh)
+		defer svc.setStatusCh("thID", nil)
+
+		msg := service.NewDIDCommMsgMap(&Status{Type: StatusMsgType, Thread: &decorator.Thread{ID: "thID"}})
+
+		require.NoError(t, svc.handleStatus(msg))
+		require.NoError(t, svc.handleStatus(msg))
+		require.Len(t, statusCh, 1)
+	})
+
+	t.Run("test handle batch - duplicate batch", func(t *testing.T) {
+		batchCh := make(chan *Batch, 1)
+		svc.setBatchCh("thID", batchCh)
+		defer svc.setBatchCh("thID", nil)
+
+		msg := service.NewDIDCommMsgMap(&Batch{Type: BatchMsgType, Thread: &decorator.Thread{ID: "thID"}})
+
+		require.NoError(t, svc.handleBatch(msg))
+		require.NoError(t, svc.handleBatch(msg))
+		require.Len(t, batchCh, 1)
+	})
+
+	t.Run("test handle message - empty", func(t *testing.T) {
+		err = svc.handleMessage(&Message{ID: "id"})
+		require.EqualError(t, err, "message is empty")
+	})
+
+	t.Run("test handle message - unpack error", func(t *testing.T) {
+		svc.packager = &mockpackager.Packager{UnpackErr: errors.New("unpack error")}
+
+		err = svc.handleMessage(&Message{ID: "id", Message: &model.Envelope{}})
+		require.EqualError(t, err, "unpack message : unpack error")
+	})
+
+	t.Run("test add message - store error", func(t *testing.T) {
+		svc.inboxStore = &mockstore.MockStore{Store: make(map[string][]byte), ErrGet: errors.New("get error")}
+
+		err = svc.AddMessage(&model.Envelope{}, edgeDID)
+		require.EqualError(t, err, "get inbox : get error")
+	})
+}
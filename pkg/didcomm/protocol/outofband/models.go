@@ -0,0 +1,41 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outofband
+
+import "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+
+// Invitation is the out-of-band invitation message.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0434-outofband#messages
+type Invitation struct {
+	ID    string `json:"@id,omitempty"`
+	Type  string `json:"@type,omitempty"`
+	Label string `json:"label,omitempty"`
+	// Goal is an optional self-attested string that the receiver may want to display to the user about
+	// the context-specific goal of the out-of-band message.
+	Goal string `json:"goal,omitempty"`
+	// GoalCode is an optional self-attested code the receiver may want to display to the user or use in
+	// automatically deciding what to do with the out-of-band message.
+	GoalCode string `json:"goal_code,omitempty"`
+	// HandshakeProtocols is an array of protocols in the order of preference of the sender that the receiver
+	// can use in responding to the message in order to create or reuse a connection with the sender.
+	HandshakeProtocols []string `json:"handshake_protocols,omitempty"`
+	// Requests is an array of attached messages that the sender wants the receiver to process
+	// (e.g an issue-credential offer or a present-proof request).
+	Requests []decorator.Attachment `json:"request~attach,omitempty"`
+	// Service is an array of items where each item is either a DID (string) or an inline block
+	// (*ServiceBlock, or its JSON representation) describing how to reach the sender.
+	Service []interface{} `json:"service,omitempty"`
+}
+
+// ServiceBlock is the inline service block of the invitation.
+type ServiceBlock struct {
+	ID              string   `json:"id,omitempty"`
+	Type            string   `json:"type,omitempty"`
+	RecipientKeys   []string `json:"recipientKeys,omitempty"`
+	RoutingKeys     []string `json:"routingKeys,omitempty"`
+	ServiceEndpoint string   `json:"serviceEndpoint,omitempty"`
+}
@@ -0,0 +1,405 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outofband
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	// Name of this protocol service.
+	Name = "outofband"
+	// Spec defines the out-of-band spec.
+	Spec = "https://didcomm.org/out-of-band/1.0/"
+	// InvitationMsgType defines the out-of-band invitation message type.
+	InvitationMsgType = Spec + "invitation"
+	// StateAccepted is the state name of the message event triggered after the invitation was accepted.
+	StateAccepted = "accepted"
+	// DIDCommServiceType is the type of the inline service block.
+	DIDCommServiceType = "did-communication"
+	// stateCompleted the didexchange protocol state name to determine that the connection is established
+	stateCompleted = "completed"
+)
+
+const requestsKey = "requests_%s"
+
+// ErrOutboundNotSupported is returned by HandleOutbound. The invitation is sent out of band
+// (e.g QR code or link) before any DIDComm connection exists, so there is nothing to send outbound;
+// use the out-of-band client to create the invitation.
+var ErrOutboundNotSupported = errors.New("out-of-band invitations are not sent as outbound messages")
+
+var logger = log.New("aries-framework/outofband/service")
+
+// Provider contains dependencies for the out-of-band protocol and is typically created by using aries.Context()
+type Provider interface {
+	Service(id string) (interface{}, error)
+	StorageProvider() storage.Provider
+	TransientStorageProvider() storage.Provider
+	InboundMessageHandler() transport.InboundMessageHandler
+	VDRIRegistry() vdriapi.Registry
+}
+
+// didExchangeService defines the functions of the didexchange service used by the out-of-band protocol.
+type didExchangeService interface {
+	service.InboundHandler
+	service.Event
+}
+
+// connectionIDProvider is implemented by the properties of the didexchange events.
+type connectionIDProvider interface {
+	ConnectionID() string
+}
+
+// event is the properties of the message event triggered after the invitation was accepted.
+type event struct {
+	connectionID string
+}
+
+// ConnectionID returns the ID of the connection created for the invitation.
+func (e *event) ConnectionID() string {
+	return e.connectionID
+}
+
+// Service for the out-of-band protocol
+type Service struct {
+	service.Action
+	service.Message
+	store          storage.Store
+	didSvc         didExchangeService
+	didEvent       chan service.StateMsg
+	callbacks      chan *Invitation
+	connections    *connection.Lookup
+	inboundHandler transport.InboundMessageHandler
+	vdriRegistry   vdriapi.Registry
+}
+
+// New returns the out-of-band service
+func New(p Provider) (*Service, error) {
+	raw, err := p.Service(didexchange.DIDExchange)
+	if err != nil {
+		return nil, fmt.Errorf("load the DIDExchange service: %w", err)
+	}
+
+	didSvc, ok := raw.(didExchangeService)
+	if !ok {
+		return nil, errors.New("cast service to DIDExchange service failed")
+	}
+
+	store, err := p.StorageProvider().OpenStore(Name)
+	if err != nil {
+		return nil, err
+	}
+
+	connections, err := connection.NewLookup(p)
+	if err != nil {
+		return nil, fmt.Errorf("new connection lookup: %w", err)
+	}
+
+	svc := &Service{
+		store:          store,
+		didSvc:         didSvc,
+		didEvent:       make(chan service.StateMsg),
+		callbacks:      make(chan *Invitation),
+		connections:    connections,
+		inboundHandler: p.InboundMessageHandler(),
+		vdriRegistry:   p.VDRIRegistry(),
+	}
+
+	if err = didSvc.RegisterMsgEvent(svc.didEvent); err != nil {
+		return nil, fmt.Errorf("did register msg event: %w", err)
+	}
+
+	// start the listener
+	go svc.startInternalListener()
+
+	return svc, nil
+}
+
+// startInternalListener listens to messages in gochannel for callback messages from clients.
+func (s *Service) startInternalListener() {
+	for {
+		select {
+		case inv := <-s.callbacks:
+			if _, err := s.AcceptInvitation(inv); err != nil {
+				logger.Errorf("listener accept invitation: %s", err)
+			}
+		case msg := <-s.didEvent:
+			if err := s.connectionCompleted(msg); err != nil {
+				logger.Errorf("listener connection completed: %s", err)
+			}
+		}
+	}
+}
+
+// HandleInbound handles inbound message (out-of-band protocol)
+func (s *Service) HandleInbound(msg service.DIDCommMsg, _, _ string) (string, error) {
+	aEvent := s.ActionEvent()
+
+	// throw error if there is no action event registered for inbound messages
+	if aEvent == nil {
+		return "", errors.New("no clients are registered to handle the message")
+	}
+
+	inv := &Invitation{}
+	if err := msg.Decode(inv); err != nil {
+		return "", fmt.Errorf("decode invitation: %w", err)
+	}
+
+	if err := validateInvitation(inv); err != nil {
+		return "", err
+	}
+
+	aEvent <- service.DIDCommAction{
+		ProtocolName: Name,
+		Message:      msg,
+		Continue: func(interface{}) {
+			s.callbacks <- inv
+		},
+		Stop: func(err error) {
+			logger.Infof("invitation %s was rejected: %v", inv.ID, err)
+		},
+	}
+
+	return inv.ID, nil
+}
+
+// HandleOutbound always returns ErrOutboundNotSupported, the invitation is sent out of band.
+func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, error) {
+	return "", ErrOutboundNotSupported
+}
+
+// Accept msg checks the msg type
+func (s *Service) Accept(msgType string) bool {
+	return msgType == InvitationMsgType
+}
+
+// Name returns service name
+func (s *Service) Name() string {
+	return Name
+}
+
+// Spec returns the specification URI of the protocol.
+func (s *Service) Spec() string {
+	return Spec
+}
+
+// AcceptInvitation starts the handshake protocol (didexchange) with the sender of the invitation.
+// The requests attached to the invitation are dispatched to the appropriate protocol services
+// as soon as the connection is established. Returns the ID of the connection.
+func (s *Service) AcceptInvitation(inv *Invitation) (string, error) {
+	if err := validateInvitation(inv); err != nil {
+		return "", err
+	}
+
+	didInv, err := s.didExchangeInvitation(inv)
+	if err != nil {
+		return "", err
+	}
+
+	connID, err := s.didSvc.HandleInbound(service.NewDIDCommMsgMap(didInv), "", "")
+	if err != nil {
+		return "", fmt.Errorf("didexchange handle inbound: %w", err)
+	}
+
+	if len(inv.Requests) > 0 {
+		src, err := json.Marshal(inv.Requests)
+		if err != nil {
+			return "", fmt.Errorf("marshal requests: %w", err)
+		}
+
+		if err = s.store.Put(fmt.Sprintf(requestsKey, connID), src); err != nil {
+			return "", fmt.Errorf("save requests: %w", err)
+		}
+	}
+
+	s.sendMsgEvents(&service.StateMsg{
+		ProtocolName: Name,
+		Type:         service.PostState,
+		StateID:      StateAccepted,
+		Msg:          service.NewDIDCommMsgMap(inv),
+		Properties:   &event{connectionID: connID},
+	})
+
+	return connID, nil
+}
+
+// sendMsgEvents triggers the message events.
+func (s *Service) sendMsgEvents(msg *service.StateMsg) {
+	for _, handler := range s.MsgEvents() {
+		handler <- *msg
+	}
+}
+
+// connectionCompleted dispatches the requests attached to the invitation
+// once the didexchange protocol established the connection.
+func (s *Service) connectionCompleted(msg service.StateMsg) error {
+	if msg.Type != service.PostState || msg.StateID != stateCompleted {
+		return nil
+	}
+
+	props, ok := msg.Properties.(connectionIDProvider)
+	if !ok {
+		return nil
+	}
+
+	key := fmt.Sprintf(requestsKey, props.ConnectionID())
+
+	src, err := s.store.Get(key)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("get requests: %w", err)
+	}
+
+	var requests []decorator.Attachment
+	if err = json.Unmarshal(src, &requests); err != nil {
+		return fmt.Errorf("unmarshal requests: %w", err)
+	}
+
+	record, err := s.connections.GetConnectionRecord(props.ConnectionID())
+	if err != nil {
+		return fmt.Errorf("get connection record: %w", err)
+	}
+
+	for i, request := range requests {
+		payload, err := attachmentPayload(request)
+		if err != nil {
+			return fmt.Errorf("request %d: %w", i, err)
+		}
+
+		if err = s.inboundHandler(payload, record.MyDID, record.TheirDID); err != nil {
+			return fmt.Errorf("request %d: dispatch: %w", i, err)
+		}
+	}
+
+	return s.store.Delete(key)
+}
+
+func validateInvitation(inv *Invitation) error {
+	if inv == nil {
+		return errors.New("invitation is not provided")
+	}
+
+	if len(inv.HandshakeProtocols) == 0 {
+		return errors.New("handshake protocols are not provided (connectionless requests are not supported)")
+	}
+
+	for _, protocol := range inv.HandshakeProtocols {
+		if strings.TrimSuffix(protocol, "/") == strings.TrimSuffix(didexchange.DIDExchangeSpec, "/") {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unsupported handshake protocols: %v", inv.HandshakeProtocols)
+}
+
+// didExchangeInvitation converts the out-of-band invitation to the didexchange invitation.
+// The first did-communication service entry of the invitation is used.
+func (s *Service) didExchangeInvitation(inv *Invitation) (*didexchange.Invitation, error) {
+	if len(inv.Service) == 0 {
+		return nil, errors.New("service is not provided")
+	}
+
+	didInv := &didexchange.Invitation{
+		ID:    inv.ID,
+		Type:  didexchange.InvitationMsgType,
+		Label: inv.Label,
+	}
+
+	for _, raw := range inv.Service {
+		if didID, ok := raw.(string); ok {
+			if s.hasDIDCommService(didID) {
+				didInv.DID = didID
+
+				return didInv, nil
+			}
+
+			continue
+		}
+
+		block, err := serviceBlock(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		if block.Type != DIDCommServiceType {
+			continue
+		}
+
+		if len(block.RecipientKeys) == 0 || block.ServiceEndpoint == "" {
+			return nil, errors.New("service block: recipient keys and service endpoint are required")
+		}
+
+		didInv.RecipientKeys = block.RecipientKeys
+		didInv.RoutingKeys = block.RoutingKeys
+		didInv.ServiceEndpoint = block.ServiceEndpoint
+
+		return didInv, nil
+	}
+
+	return nil, fmt.Errorf("no %s service is provided", DIDCommServiceType)
+}
+
+// hasDIDCommService checks whether the DID document of the given DID has the did-communication service.
+func (s *Service) hasDIDCommService(didID string) bool {
+	doc, err := s.vdriRegistry.Resolve(didID)
+	if err != nil {
+		logger.Warnf("resolve service DID %s: %s", didID, err)
+
+		return false
+	}
+
+	_, ok := did.LookupService(doc, DIDCommServiceType)
+
+	return ok
+}
+
+func serviceBlock(raw interface{}) (*ServiceBlock, error) {
+	block, ok := raw.(*ServiceBlock)
+	if !ok {
+		// the inline block is a JSON object when the invitation was unmarshalled
+		src, err := json.Marshal(raw)
+		if err != nil {
+			return nil, fmt.Errorf("marshal service: %w", err)
+		}
+
+		block = &ServiceBlock{}
+		if err = json.Unmarshal(src, block); err != nil {
+			return nil, fmt.Errorf("unmarshal service: %w", err)
+		}
+	}
+
+	return block, nil
+}
+
+func attachmentPayload(a decorator.Attachment) ([]byte, error) {
+	if a.Data.JSON != nil {
+		return json.Marshal(a.Data.JSON)
+	}
+
+	if a.Data.Base64 != "" {
+		return base64.StdEncoding.DecodeString(a.Data.Base64)
+	}
+
+	return nil, errors.New("request is not provided")
+}
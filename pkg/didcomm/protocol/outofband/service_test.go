@@ -0,0 +1,392 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outofband_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	. "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+	outofbandMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/outofband"
+	storageMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/storage"
+	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
+	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	myDID    = "did:example:alice"
+	theirDID = "did:example:bob"
+	connID   = "connID"
+)
+
+type storageProvider struct {
+	store     storage.Provider
+	transient storage.Provider
+}
+
+func (p *storageProvider) StorageProvider() storage.Provider {
+	return p.store
+}
+
+func (p *storageProvider) TransientStorageProvider() storage.Provider {
+	return p.transient
+}
+
+type didEventProps struct{}
+
+func (p *didEventProps) ConnectionID() string {
+	return connID
+}
+
+type fixture struct {
+	svc      *Service
+	didSvc   *serviceMocks.MockDIDComm
+	didEvent chan<- service.StateMsg
+	storage  *storageProvider
+}
+
+func newFixture(t *testing.T, ctrl *gomock.Controller, handler transport.InboundMessageHandler) *fixture {
+	t.Helper()
+
+	f := &fixture{
+		didSvc:  serviceMocks.NewMockDIDComm(ctrl),
+		storage: &storageProvider{store: mem.NewProvider(), transient: mem.NewProvider()},
+	}
+
+	f.didSvc.EXPECT().RegisterMsgEvent(gomock.Any()).DoAndReturn(func(ch chan<- service.StateMsg) error {
+		f.didEvent = ch
+
+		return nil
+	})
+
+	provider := outofbandMocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(didexchange.DIDExchange).Return(f.didSvc, nil)
+	provider.EXPECT().StorageProvider().Return(f.storage.store).AnyTimes()
+	provider.EXPECT().TransientStorageProvider().Return(f.storage.transient).AnyTimes()
+	provider.EXPECT().InboundMessageHandler().Return(handler)
+	provider.EXPECT().VDRIRegistry().Return(&mockvdri.MockVDRIRegistry{
+		ResolveFunc: func(didID string, _ ...vdriapi.ResolveOpts) (*did.Doc, error) {
+			switch didID {
+			case theirDID:
+				return mockdiddoc.GetMockDIDDoc(), nil
+			case myDID:
+				return &did.Doc{ID: myDID}, nil
+			default:
+				return nil, vdriapi.ErrNotFound
+			}
+		},
+	})
+
+	svc, err := New(provider)
+	require.NoError(t, err)
+
+	f.svc = svc
+
+	return f
+}
+
+func newInvitation() *Invitation {
+	return &Invitation{
+		ID:                 "invitationID",
+		Type:               InvitationMsgType,
+		Label:              "Bob",
+		HandshakeProtocols: []string{didexchange.DIDExchangeSpec},
+		Service: []interface{}{&ServiceBlock{
+			ID:              "#inline",
+			Type:            DIDCommServiceType,
+			RecipientKeys:   []string{"key"},
+			ServiceEndpoint: "http://example.com",
+		}},
+	}
+}
+
+func TestNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("get didexchange service error", func(t *testing.T) {
+		provider := outofbandMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(didexchange.DIDExchange).Return(nil, errors.New("test error"))
+
+		_, err := New(provider)
+		require.EqualError(t, err, "load the DIDExchange service: test error")
+	})
+
+	t.Run("cast didexchange service error", func(t *testing.T) {
+		provider := outofbandMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(didexchange.DIDExchange).Return(nil, nil)
+
+		_, err := New(provider)
+		require.EqualError(t, err, "cast service to DIDExchange service failed")
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		sp := storageMocks.NewMockProvider(ctrl)
+		sp.EXPECT().OpenStore(Name).Return(nil, errors.New("test error"))
+
+		provider := outofbandMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(didexchange.DIDExchange).Return(serviceMocks.NewMockDIDComm(ctrl), nil)
+		provider.EXPECT().StorageProvider().Return(sp)
+
+		_, err := New(provider)
+		require.EqualError(t, err, "test error")
+	})
+
+	t.Run("register msg event error", func(t *testing.T) {
+		didSvc := serviceMocks.NewMockDIDComm(ctrl)
+		didSvc.EXPECT().RegisterMsgEvent(gomock.Any()).Return(errors.New("test error"))
+
+		provider := outofbandMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(didexchange.DIDExchange).Return(didSvc, nil)
+		provider.EXPECT().StorageProvider().Return(mem.NewProvider()).AnyTimes()
+		provider.EXPECT().TransientStorageProvider().Return(mem.NewProvider())
+		provider.EXPECT().InboundMessageHandler().Return(nil)
+		provider.EXPECT().VDRIRegistry().Return(&mockvdri.MockVDRIRegistry{})
+
+		_, err := New(provider)
+		require.EqualError(t, err, "did register msg event: test error")
+	})
+}
+
+func TestService_Accept(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := newFixture(t, ctrl, nil).svc
+
+	require.Equal(t, Name, svc.Name())
+	require.Equal(t, Spec, svc.Spec())
+	require.True(t, svc.Accept(InvitationMsgType))
+	require.False(t, svc.Accept(didexchange.InvitationMsgType))
+
+	_, err := svc.HandleOutbound(service.NewDIDCommMsgMap(newInvitation()), myDID, theirDID)
+	require.True(t, errors.Is(err, ErrOutboundNotSupported))
+}
+
+func TestService_AcceptInvitation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("inline service", func(t *testing.T) {
+		f := newFixture(t, ctrl, nil)
+		f.didSvc.EXPECT().HandleInbound(gomock.Any(), "", "").
+			DoAndReturn(func(msg service.DIDCommMsg, _, _ string) (string, error) {
+				inv := &didexchange.Invitation{}
+				require.NoError(t, msg.Decode(inv))
+				require.Equal(t, didexchange.InvitationMsgType, inv.Type)
+				require.Equal(t, "invitationID", inv.ID)
+				require.Equal(t, "Bob", inv.Label)
+				require.Equal(t, []string{"key"}, inv.RecipientKeys)
+				require.Equal(t, "http://example.com", inv.ServiceEndpoint)
+
+				return connID, nil
+			})
+
+		events := make(chan service.StateMsg, 1)
+		require.NoError(t, f.svc.RegisterMsgEvent(events))
+
+		id, err := f.svc.AcceptInvitation(newInvitation())
+		require.NoError(t, err)
+		require.Equal(t, connID, id)
+
+		event := <-events
+		require.Equal(t, StateAccepted, event.StateID)
+		require.Equal(t, connID, event.Properties.(interface{ ConnectionID() string }).ConnectionID())
+	})
+
+	t.Run("DID service", func(t *testing.T) {
+		f := newFixture(t, ctrl, nil)
+		f.didSvc.EXPECT().HandleInbound(gomock.Any(), "", "").
+			DoAndReturn(func(msg service.DIDCommMsg, _, _ string) (string, error) {
+				inv := &didexchange.Invitation{}
+				require.NoError(t, msg.Decode(inv))
+				require.Equal(t, theirDID, inv.DID)
+
+				return connID, nil
+			})
+
+		inv := newInvitation()
+		inv.Service = []interface{}{theirDID}
+
+		_, err := f.svc.AcceptInvitation(inv)
+		require.NoError(t, err)
+	})
+
+	t.Run("service selected by type", func(t *testing.T) {
+		f := newFixture(t, ctrl, nil)
+		f.didSvc.EXPECT().HandleInbound(gomock.Any(), "", "").
+			DoAndReturn(func(msg service.DIDCommMsg, _, _ string) (string, error) {
+				inv := &didexchange.Invitation{}
+				require.NoError(t, msg.Decode(inv))
+				require.Empty(t, inv.DID)
+				require.Equal(t, []string{"key"}, inv.RecipientKeys)
+
+				return connID, nil
+			})
+
+		inv := newInvitation()
+		inv.Service = append([]interface{}{
+			"did:example:unknown",
+			myDID,
+			map[string]interface{}{"type": "other", "serviceEndpoint": "http://example.com/other"},
+		}, inv.Service...)
+
+		_, err := f.svc.AcceptInvitation(inv)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid invitations", func(t *testing.T) {
+		svc := newFixture(t, ctrl, nil).svc
+
+		_, err := svc.AcceptInvitation(nil)
+		require.EqualError(t, err, "invitation is not provided")
+
+		inv := newInvitation()
+		inv.HandshakeProtocols = nil
+		_, err = svc.AcceptInvitation(inv)
+		require.Contains(t, err.Error(), "handshake protocols are not provided")
+
+		inv.HandshakeProtocols = []string{"https://didcomm.org/connections/1.0"}
+		_, err = svc.AcceptInvitation(inv)
+		require.Contains(t, err.Error(), "unsupported handshake protocols")
+
+		inv = newInvitation()
+		inv.Service = nil
+		_, err = svc.AcceptInvitation(inv)
+		require.EqualError(t, err, "service is not provided")
+
+		inv.Service = []interface{}{map[string]interface{}{"type": DIDCommServiceType}}
+		_, err = svc.AcceptInvitation(inv)
+		require.Contains(t, err.Error(), "recipient keys and service endpoint are required")
+
+		inv.Service = []interface{}{myDID, &ServiceBlock{Type: "other"}}
+		_, err = svc.AcceptInvitation(inv)
+		require.EqualError(t, err, "no did-communication service is provided")
+	})
+
+	t.Run("didexchange error", func(t *testing.T) {
+		f := newFixture(t, ctrl, nil)
+		f.didSvc.EXPECT().HandleInbound(gomock.Any(), "", "").Return("", errors.New("test error"))
+
+		_, err := f.svc.AcceptInvitation(newInvitation())
+		require.EqualError(t, err, "didexchange handle inbound: test error")
+	})
+}
+
+func TestService_HandleInbound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("no clients", func(t *testing.T) {
+		svc := newFixture(t, ctrl, nil).svc
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(newInvitation()), "", "")
+		require.EqualError(t, err, "no clients are registered to handle the message")
+	})
+
+	t.Run("invalid invitation", func(t *testing.T) {
+		svc := newFixture(t, ctrl, nil).svc
+		require.NoError(t, svc.RegisterActionEvent(make(chan service.DIDCommAction)))
+
+		inv := newInvitation()
+		inv.HandshakeProtocols = nil
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(inv), "", "")
+		require.Contains(t, err.Error(), "handshake protocols are not provided")
+	})
+
+	t.Run("continue", func(t *testing.T) {
+		f := newFixture(t, ctrl, nil)
+
+		done := make(chan struct{})
+
+		f.didSvc.EXPECT().HandleInbound(gomock.Any(), "", "").
+			DoAndReturn(func(service.DIDCommMsg, string, string) (string, error) {
+				close(done)
+
+				return connID, nil
+			})
+
+		actions := make(chan service.DIDCommAction, 1)
+		require.NoError(t, f.svc.RegisterActionEvent(actions))
+
+		id, err := f.svc.HandleInbound(service.NewDIDCommMsgMap(newInvitation()), "", "")
+		require.NoError(t, err)
+		require.Equal(t, "invitationID", id)
+
+		action := <-actions
+		require.Equal(t, Name, action.ProtocolName)
+		action.Continue(nil)
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for the invitation to be accepted")
+		}
+	})
+}
+
+func TestService_DispatchRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dispatched := make(chan []byte)
+
+	f := newFixture(t, ctrl, func(message []byte, my, their string) error {
+		require.Equal(t, myDID, my)
+		require.Equal(t, theirDID, their)
+
+		dispatched <- message
+
+		return nil
+	})
+	f.didSvc.EXPECT().HandleInbound(gomock.Any(), "", "").Return(connID, nil)
+
+	recorder, err := connection.NewRecorder(f.storage)
+	require.NoError(t, err)
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: connID,
+		ThreadID:     "thID",
+		State:        "completed",
+		MyDID:        myDID,
+		TheirDID:     theirDID,
+	}))
+
+	inv := newInvitation()
+	inv.Requests = []decorator.Attachment{{
+		ID:   "request",
+		Data: decorator.AttachmentData{JSON: map[string]interface{}{"@type": "https://didcomm.org/test/1.0/request"}},
+	}}
+
+	_, err = f.svc.AcceptInvitation(inv)
+	require.NoError(t, err)
+
+	// events that are not about the completed connection are ignored
+	f.didEvent <- service.StateMsg{Type: service.PreState, StateID: "completed", Properties: &didEventProps{}}
+	f.didEvent <- service.StateMsg{Type: service.PostState, StateID: "completed", Properties: &didEventProps{}}
+
+	select {
+	case msg := <-dispatched:
+		require.JSONEq(t, `{"@type": "https://didcomm.org/test/1.0/request"}`, string(msg))
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for the request to be dispatched")
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+
+// ProposePresentation is an optional message sent by the Prover to the verifier to initiate a proof
+// presentation process, or in response to a request-presentation message when the Prover wants to
+// propose using a different presentation format.
+type ProposePresentation struct {
+	Type string `json:"@type,omitempty"`
+	ID   string `json:"@id,omitempty"`
+	// Comment is an optional field that provides human readable information about the proposed presentation.
+	Comment string `json:"comment,omitempty"`
+	// PresentationProposal is a JSON-LD object that represents the presentation example that Prover wants to provide.
+	PresentationProposal PresentationPreview `json:"presentation_proposal,omitempty"`
+}
+
+// RequestPresentation describes values that need to be revealed and predicates that need to be fulfilled.
+type RequestPresentation struct {
+	Type string `json:"@type,omitempty"`
+	ID   string `json:"@id,omitempty"`
+	// Comment is an optional field that provides human readable information about the request.
+	Comment string `json:"comment,omitempty"`
+	// RequestPresentationsAttach is an array of attachments containing the acceptable verifiable presentation requests.
+	RequestPresentationsAttach []decorator.Attachment `json:"request_presentations~attach,omitempty"`
+}
+
+// Presentation is a response to a RequestPresentation message and contains signed presentations.
+type Presentation struct {
+	Type string `json:"@type,omitempty"`
+	ID   string `json:"@id,omitempty"`
+	// Comment is an optional field that provides human readable information about the presentation.
+	Comment string `json:"comment,omitempty"`
+	// PresentationsAttach is an array of attachments containing the presentation in the requested format(s).
+	PresentationsAttach []decorator.Attachment `json:"presentations~attach,omitempty"`
+}
+
+// PresentationPreview is used to construct a preview of the data for the presentation.
+type PresentationPreview struct {
+	Type       string      `json:"@type,omitempty"`
+	Attributes []Attribute `json:"attributes,omitempty"`
+	Predicates []Predicate `json:"predicates,omitempty"`
+}
+
+// Attribute describes an attribute for a Presentation Preview.
+type Attribute struct {
+	Name      string `json:"name,omitempty"`
+	CredDefID string `json:"cred_def_id,omitempty"`
+	MimeType  string `json:"mime-type,omitempty"`
+	Value     string `json:"value,omitempty"`
+	Referent  string `json:"referent,omitempty"`
+}
+
+// Predicate describes a predicate for a Presentation Preview.
+type Predicate struct {
+	Name      string `json:"name,omitempty"`
+	CredDefID string `json:"cred_def_id,omitempty"`
+	Predicate string `json:"predicate,omitempty"`
+	Threshold int    `json:"threshold,omitempty"`
+}
@@ -0,0 +1,73 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const presentationMimeType = "application/ld+json"
+
+// NewPresentationAttachment creates an attachment which embeds the given verifiable presentation.
+// The presentation in JSON format is embedded as is, otherwise (e.g JWS) it is embedded as base64.
+func NewPresentationAttachment(vp []byte) decorator.Attachment {
+	attachment := decorator.Attachment{ID: uuid.New().String()}
+
+	var vpMap map[string]interface{}
+	if err := json.Unmarshal(vp, &vpMap); err == nil {
+		attachment.MimeType = presentationMimeType
+		attachment.Data.JSON = vpMap
+
+		return attachment
+	}
+
+	attachment.Data.Base64 = base64.StdEncoding.EncodeToString(vp)
+
+	return attachment
+}
+
+// Presentations decodes the verifiable presentations attached to the message.
+// The options are passed to the verifiable.NewPresentation function, e.g to provide a public key fetcher.
+func (m *Presentation) Presentations(opts ...verifiable.PresentationOpt) ([]*verifiable.Presentation, error) {
+	presentations := make([]*verifiable.Presentation, len(m.PresentationsAttach))
+
+	for i, attachment := range m.PresentationsAttach {
+		raw, err := attachmentPayload(attachment)
+		if err != nil {
+			return nil, fmt.Errorf("attachment %d: %w", i, err)
+		}
+
+		presentations[i], err = verifiable.NewPresentation(raw, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("new presentation: %w", err)
+		}
+	}
+
+	return presentations, nil
+}
+
+// attachmentPayload returns the raw content of the attachment.
+// The presentation might be embedded as JSON or base64 (e.g JWS).
+func attachmentPayload(attachment decorator.Attachment) ([]byte, error) {
+	if attachment.Data.JSON != nil {
+		return json.Marshal(attachment.Data.JSON)
+	}
+
+	if attachment.Data.Base64 != "" {
+		return base64.StdEncoding.DecodeString(attachment.Data.Base64)
+	}
+
+	return nil, errors.New("presentation is not provided")
+}
@@ -0,0 +1,76 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+const vpJSON = `{
+  "@context": ["https://www.w3.org/2018/credentials/v1"],
+  "type": ["VerifiablePresentation"],
+  "verifiableCredential": [{
+    "@context": ["https://www.w3.org/2018/credentials/v1"],
+    "id": "http://example.edu/credentials/1872",
+    "type": ["VerifiableCredential", "UniversityDegreeCredential"],
+    "credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"},
+    "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+    "issuanceDate": "2010-01-01T19:23:24Z"
+  }],
+  "holder": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+  "proof": {"type": "Ed25519Signature2018"}
+}`
+
+func TestPresentation_Presentations(t *testing.T) {
+	t.Run("JSON attachment", func(t *testing.T) {
+		attachment := NewPresentationAttachment([]byte(vpJSON))
+		require.NotEmpty(t, attachment.ID)
+		require.Equal(t, presentationMimeType, attachment.MimeType)
+		require.NotNil(t, attachment.Data.JSON)
+
+		// the message goes through the DIDComm message map as it would be sent over the wire
+		msg := service.NewDIDCommMsgMap(Presentation{
+			Type:                PresentationMsgType,
+			PresentationsAttach: []decorator.Attachment{attachment},
+		})
+
+		presentation := Presentation{}
+		require.NoError(t, msg.Decode(&presentation))
+
+		presentations, err := presentation.Presentations()
+		require.NoError(t, err)
+		require.Len(t, presentations, 1)
+		require.Equal(t, "did:example:ebfeb1f712ebc6f1c276e12ec21", presentations[0].Holder)
+		require.Len(t, presentations[0].Credentials(), 1)
+	})
+
+	t.Run("Base64 attachment", func(t *testing.T) {
+		attachment := NewPresentationAttachment([]byte("header.payload.signature"))
+		require.Empty(t, attachment.MimeType)
+		require.NotEmpty(t, attachment.Data.Base64)
+
+		presentation := Presentation{PresentationsAttach: []decorator.Attachment{attachment}}
+
+		presentations, err := presentation.Presentations()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "new presentation")
+		require.Nil(t, presentations)
+	})
+
+	t.Run("No data", func(t *testing.T) {
+		presentation := Presentation{PresentationsAttach: []decorator.Attachment{{}}}
+
+		presentations, err := presentation.Presentations()
+		require.EqualError(t, err, "attachment 0: presentation is not provided")
+		require.Nil(t, presentations)
+	})
+}
@@ -0,0 +1,664 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	// Name defines the protocol name
+	Name = "present-proof"
+	// Spec defines the protocol spec
+	Spec = "https://didcomm.org/present-proof/1.0/"
+	// ProposePresentationMsgType defines the protocol propose-presentation message type.
+	ProposePresentationMsgType = Spec + "propose-presentation"
+	// RequestPresentationMsgType defines the protocol request-presentation message type.
+	RequestPresentationMsgType = Spec + "request-presentation"
+	// PresentationMsgType defines the protocol presentation message type.
+	PresentationMsgType = Spec + "presentation"
+	// AckMsgType defines the protocol ack message type.
+	AckMsgType = Spec + "ack"
+	// ProblemReportMsgType defines the protocol problem-report message type.
+	ProblemReportMsgType = Spec + "problem-report"
+	// PresentationPreviewMsgType defines the protocol presentation-preview inner object type.
+	PresentationPreviewMsgType = Spec + "presentation-preview"
+)
+
+const (
+	stateNameKey           = "state_name_"
+	transitionalPayloadKey = "transitionalPayload_%s"
+)
+
+var logger = log.New("aries-framework/presentproof/service")
+
+// customError is a wrapper to determine custom error against internal error
+type customError struct{ error }
+
+// verificationError is a wrapper to determine that the received presentation is not valid
+type verificationError struct{ error }
+
+// Action contains helpful information about action
+type Action struct {
+	// Protocol instance ID
+	PIID string
+	Msg  service.DIDCommMsgMap
+}
+
+// transitionalPayload keeps payload needed for Continue function to proceed with the action
+type transitionalPayload struct {
+	// Protocol instance ID
+	PIID      string
+	StateName string
+	Msg       service.DIDCommMsgMap
+	MyDID     string
+	TheirDID  string
+}
+
+// stateRecord is the current state of a protocol instance, bound to the connection of the instance
+type stateRecord struct {
+	StateName string `json:"state_name"`
+	MyDID     string `json:"my_did"`
+	TheirDID  string `json:"their_did"`
+}
+
+// metaData type to store data for internal usage
+type metaData struct {
+	transitionalPayload
+	state    state
+	msgClone service.DIDCommMsg
+	inbound  bool
+
+	// keeps a message provided by the user (Continue function)
+	proposePresentation *ProposePresentation
+	requestPresentation *RequestPresentation
+	presentation        *Presentation
+
+	// err is used to determine whether callback was stopped
+	// e.g the user received an action event and executes Stop(err) function
+	// in that case `err` is equal to `err` which was passing to Stop function
+	err error
+}
+
+// Opt describes option signature for the Continue function
+type Opt func(md *metaData)
+
+// WithPresentation allows providing Presentation message
+// USAGE: This message should be provided after receiving a RequestPresentation message
+func WithPresentation(msg *Presentation) Opt {
+	return func(md *metaData) {
+		md.presentation = msg
+	}
+}
+
+// WithProposePresentation allows providing ProposePresentation message
+// USAGE: This message should be provided after receiving a RequestPresentation message
+func WithProposePresentation(msg *ProposePresentation) Opt {
+	return func(md *metaData) {
+		md.proposePresentation = msg
+	}
+}
+
+// WithRequestPresentation allows providing RequestPresentation message
+// USAGE: This message should be provided after receiving a ProposePresentation message
+func WithRequestPresentation(msg *RequestPresentation) Opt {
+	return func(md *metaData) {
+		md.requestPresentation = msg
+	}
+}
+
+// Provider contains dependencies for the protocol and is typically created by using aries.Context()
+type Provider interface {
+	Messenger() service.Messenger
+	StorageProvider() storage.Provider
+	VDRIRegistry() vdriapi.Registry
+}
+
+// Service for the presentproof protocol
+type Service struct {
+	service.Action
+	service.Message
+	store        storage.Store
+	callbacks    chan *metaData
+	messenger    service.Messenger
+	vdriRegistry vdriapi.Registry
+}
+
+// New returns the presentproof service
+func New(p Provider) (*Service, error) {
+	store, err := p.StorageProvider().OpenStore(Name)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := &Service{
+		messenger:    p.Messenger(),
+		store:        store,
+		callbacks:    make(chan *metaData),
+		vdriRegistry: p.VDRIRegistry(),
+	}
+
+	// start the listener
+	go svc.startInternalListener()
+
+	return svc, nil
+}
+
+// startInternalListener listens to messages in gochannel for callback messages from clients.
+func (s *Service) startInternalListener() {
+	for msg := range s.callbacks {
+		// if no error - do handle
+		if msg.err == nil {
+			msg.err = s.handle(msg)
+		}
+
+		// no error - continue
+		if msg.err == nil {
+			continue
+		}
+
+		logInternalError(msg.err)
+
+		msg.state = &abandoning{Code: codeInternalError}
+
+		// if the protocol was stopped by the user we need to use the rejected error code
+		if errors.As(msg.err, &customError{}) {
+			msg.state = &abandoning{Code: codeRejected}
+		}
+
+		// if the presentation is not valid we need to use the invalid presentation error code
+		if errors.As(msg.err, &verificationError{}) {
+			msg.state = &abandoning{Code: codeInvalidPresentation}
+		}
+
+		if err := s.handle(msg); err != nil {
+			logger.Errorf("listener handle: %s", err)
+		}
+	}
+}
+
+func logInternalError(err error) {
+	if _, ok := err.(customError); !ok {
+		logger.Errorf("go to abandoning: %v", err)
+	}
+}
+
+func threadID(msg service.DIDCommMsg) (string, error) {
+	thID, err := msg.ThreadID()
+	if errors.Is(err, service.ErrThreadIDNotFound) {
+		msg.(service.DIDCommMsgMap)["@id"] = uuid.New().String()
+		return msg.(service.DIDCommMsgMap)["@id"].(string), nil
+	}
+
+	return thID, err
+}
+
+func (s *Service) doHandle(msg service.DIDCommMsg, outbound bool, myDID, theirDID string) (*metaData, error) {
+	piID, err := threadID(msg)
+	if err != nil {
+		return nil, fmt.Errorf("threadID: %w", err)
+	}
+
+	record, err := s.currentState(piID)
+	if err != nil {
+		return nil, fmt.Errorf("currentState: %w", err)
+	}
+
+	// the messages of a protocol instance are exchanged on the connection the instance was started on
+	if record.StateName != stateNameStart && (record.MyDID != myDID || record.TheirDID != theirDID) {
+		return nil, fmt.Errorf("thread %s belongs to another connection", piID)
+	}
+
+	current := stateFromName(record.StateName)
+
+	next, err := nextState(msg, outbound)
+	if err != nil {
+		return nil, fmt.Errorf("nextState: %w", err)
+	}
+
+	if !current.CanTransitionTo(next) {
+		return nil, fmt.Errorf("invalid state transition: %s -> %s", current.Name(), next.Name())
+	}
+
+	return &metaData{
+		transitionalPayload: transitionalPayload{
+			StateName: next.Name(),
+			Msg:       msg.(service.DIDCommMsgMap),
+			PIID:      piID,
+			MyDID:     myDID,
+			TheirDID:  theirDID,
+		},
+		state:    next,
+		msgClone: msg.Clone(),
+	}, nil
+}
+
+// HandleInbound handles inbound message (presentproof protocol)
+func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	aEvent := s.ActionEvent()
+
+	// throw error if there is no action event registered for inbound messages
+	if aEvent == nil {
+		return "", errors.New("no clients are registered to handle the message")
+	}
+
+	md, err := s.doHandle(msg, false, myDID, theirDID)
+	if err != nil {
+		return "", fmt.Errorf("doHandle: %w", err)
+	}
+
+	// sets inbound payload
+	md.inbound = true
+
+	// the received presentation needs to be verified before the action event is triggered
+	if msg.Type() == PresentationMsgType {
+		if err := s.verifyPresentation(msg); err != nil {
+			md.err = verificationError{error: err}
+			s.processCallback(md)
+
+			return md.PIID, nil
+		}
+	}
+
+	// trigger action event based on message type for inbound messages
+	if canTriggerActionEvents(msg) {
+		err = s.saveTransitionalPayload(md.PIID, md.transitionalPayload)
+		if err != nil {
+			return "", fmt.Errorf("save transitional payload: %w", err)
+		}
+
+		aEvent <- s.newDIDCommActionMsg(md)
+
+		return md.PIID, nil
+	}
+
+	// if no action event is triggered, continue the execution
+	return md.PIID, s.handle(md)
+}
+
+// HandleOutbound handles outbound message (presentproof protocol)
+func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	md, err := s.doHandle(msg, true, myDID, theirDID)
+	if err != nil {
+		return "", fmt.Errorf("doHandle: %w", err)
+	}
+
+	return md.PIID, s.handle(md)
+}
+
+// sendMsgEvents triggers the message events.
+func (s *Service) sendMsgEvents(msg *service.StateMsg) {
+	// trigger the message events
+	for _, handler := range s.MsgEvents() {
+		handler <- *msg
+	}
+}
+
+// newDIDCommActionMsg creates new DIDCommAction message
+func (s *Service) newDIDCommActionMsg(md *metaData) service.DIDCommAction {
+	// create the message for the channel
+	// trigger the registered action event
+	return service.DIDCommAction{
+		ProtocolName: Name,
+		Message:      md.msgClone,
+		Continue: func(opt interface{}) {
+			if fn, ok := opt.(Opt); ok {
+				fn(md)
+			}
+
+			if err := s.deleteTransitionalPayload(md.PIID); err != nil {
+				logger.Errorf("delete transitional payload: %v", err)
+			}
+
+			s.processCallback(md)
+		},
+		Stop: func(err error) {
+			if err := s.deleteTransitionalPayload(md.PIID); err != nil {
+				logger.Errorf("delete transitional payload: %v", err)
+			}
+
+			md.err = customError{error: err}
+			s.processCallback(md)
+		},
+	}
+}
+
+// Continue allows proceeding with the action by the piID
+func (s *Service) Continue(piID string, opt Opt) error {
+	md, err := s.metaDataByPIID(piID)
+	if err != nil {
+		return err
+	}
+
+	if opt != nil {
+		opt(md)
+	}
+
+	if err := s.deleteTransitionalPayload(md.PIID); err != nil {
+		return fmt.Errorf("delete transitional payload: %w", err)
+	}
+
+	s.processCallback(md)
+
+	return nil
+}
+
+// Stop allows stopping the action by the piID
+func (s *Service) Stop(piID string, cErr error) error {
+	md, err := s.metaDataByPIID(piID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.deleteTransitionalPayload(md.PIID); err != nil {
+		return fmt.Errorf("delete transitional payload: %w", err)
+	}
+
+	md.err = customError{error: cErr}
+	s.processCallback(md)
+
+	return nil
+}
+
+func (s *Service) metaDataByPIID(piID string) (*metaData, error) {
+	tPayload, err := s.getTransitionalPayload(piID)
+	if err != nil {
+		return nil, fmt.Errorf("get transitional payload: %w", err)
+	}
+
+	return &metaData{
+		transitionalPayload: *tPayload,
+		state:               stateFromName(tPayload.StateName),
+		msgClone:            tPayload.Msg.Clone(),
+		inbound:             true,
+	}, nil
+}
+
+func (s *Service) processCallback(msg *metaData) {
+	// pass the callback data to internal channel. This is created to unblock consumer go routine and wrap the callback
+	// channel internally.
+	s.callbacks <- msg
+}
+
+func nextState(msg service.DIDCommMsg, outbound bool) (state, error) {
+	switch msg.Type() {
+	case RequestPresentationMsgType:
+		if outbound {
+			return &requestSent{}, nil
+		}
+
+		return &requestReceived{}, nil
+	case ProposePresentationMsgType:
+		if outbound {
+			return &proposalSent{}, nil
+		}
+
+		return &proposalReceived{}, nil
+	case PresentationMsgType:
+		if outbound {
+			return nil, errors.New("presentation can be sent only as a response to the request")
+		}
+
+		return &presentationReceived{}, nil
+	case ProblemReportMsgType:
+		return &abandoning{}, nil
+	case AckMsgType:
+		return &done{}, nil
+	default:
+		return nil, fmt.Errorf("unrecognized msgType: %s", msg.Type())
+	}
+}
+
+// verifyPresentation checks the presentations attached to the message.
+// Public keys are resolved by the DID of the Prover through the VDRI registry.
+func (s *Service) verifyPresentation(msg service.DIDCommMsg) error {
+	presentation := Presentation{}
+
+	if err := msg.(service.DIDCommMsgMap).Decode(&presentation); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+
+	if len(presentation.PresentationsAttach) == 0 {
+		return errors.New("presentation is not provided")
+	}
+
+	_, err := presentation.Presentations(verifiable.WithPresPublicKeyFetcher(
+		verifiable.NewDIDKeyResolver(s.vdriRegistry).PublicKeyFetcher(),
+	))
+
+	return err
+}
+
+func (s *Service) currentState(piID string) (*stateRecord, error) {
+	src, err := s.store.Get(stateNameKey + piID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return &stateRecord{StateName: stateNameStart}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	record := &stateRecord{}
+
+	err = json.Unmarshal(src, record)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal state: %w", err)
+	}
+
+	return record, nil
+}
+
+// Actions returns actions for the async usage
+func (s *Service) Actions() ([]Action, error) {
+	records := s.store.Iterator(
+		fmt.Sprintf(transitionalPayloadKey, ""),
+		fmt.Sprintf(transitionalPayloadKey, "~"),
+	)
+	defer records.Release()
+
+	var actions []Action
+
+	for records.Next() {
+		if records.Error() != nil {
+			return nil, records.Error()
+		}
+
+		var action Action
+		if err := json.Unmarshal(records.Value(), &action); err != nil {
+			return nil, fmt.Errorf("unmarshal: %w", err)
+		}
+
+		actions = append(actions, action)
+	}
+
+	return actions, nil
+}
+
+func (s *Service) deleteTransitionalPayload(id string) error {
+	return s.store.Delete(fmt.Sprintf(transitionalPayloadKey, id))
+}
+
+func (s *Service) saveTransitionalPayload(id string, data transitionalPayload) error {
+	src, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("marshal transitional payload: %w", err)
+	}
+
+	return s.store.Put(fmt.Sprintf(transitionalPayloadKey, id), src)
+}
+
+func (s *Service) getTransitionalPayload(id string) (*transitionalPayload, error) {
+	src, err := s.store.Get(fmt.Sprintf(transitionalPayloadKey, id))
+	if err != nil {
+		return nil, fmt.Errorf("store get: %w", err)
+	}
+
+	t := &transitionalPayload{}
+
+	err = json.Unmarshal(src, t)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal transitional payload: %w", err)
+	}
+
+	return t, err
+}
+
+func (s *Service) saveState(piID string, record *stateRecord) error {
+	src, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal state: %w", err)
+	}
+
+	return s.store.Put(stateNameKey+piID, src)
+}
+
+// stateFromName returns the state by given name.
+func stateFromName(name string) state {
+	switch name {
+	case stateNameNoop:
+		return &noOp{}
+	case stateNameStart:
+		return &start{}
+	case stateNameAbandoning:
+		return &abandoning{}
+	case stateNameDone:
+		return &done{}
+	case stateNameRequestSent:
+		return &requestSent{}
+	case stateNameProposalReceived:
+		return &proposalReceived{}
+	case stateNamePresentationReceived:
+		return &presentationReceived{}
+	case stateNameRequestReceived:
+		return &requestReceived{}
+	case stateNameProposalSent:
+		return &proposalSent{}
+	case stateNamePresentationSent:
+		return &presentationSent{}
+	default:
+		return &noOp{}
+	}
+}
+
+// canTriggerActionEvents checks if the incoming message can trigger an action event
+func canTriggerActionEvents(msg service.DIDCommMsg) bool {
+	switch msg.Type() {
+	case ProposePresentationMsgType, RequestPresentationMsgType, PresentationMsgType:
+		return true
+	}
+
+	return false
+}
+
+func isNoOp(s state) bool {
+	_, ok := s.(*noOp)
+	return ok
+}
+
+func (s *Service) handle(md *metaData) error {
+	var (
+		current   = md.state
+		actions   []stateAction
+		stateName string
+	)
+
+	for !isNoOp(current) {
+		stateName = current.Name()
+
+		next, action, err := s.execute(current, md)
+		if err != nil {
+			return fmt.Errorf("execute: %w", err)
+		}
+
+		actions = append(actions, action)
+
+		if !isNoOp(next) && !current.CanTransitionTo(next) {
+			return fmt.Errorf("invalid state transition: %s --> %s", current.Name(), next.Name())
+		}
+
+		current = next
+	}
+
+	err := s.saveState(md.PIID, &stateRecord{StateName: stateName, MyDID: md.MyDID, TheirDID: md.TheirDID})
+	if err != nil {
+		return fmt.Errorf("failed to persist state %s: %w", stateName, err)
+	}
+
+	for _, action := range actions {
+		if err := action(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) execute(next state, md *metaData) (state, stateAction, error) {
+	s.sendMsgEvents(&service.StateMsg{
+		ProtocolName: Name,
+		Type:         service.PreState,
+		Msg:          md.msgClone,
+		StateID:      next.Name(),
+	})
+
+	var (
+		followup state
+		err      error
+		action   stateAction
+	)
+
+	if md.inbound {
+		followup, action, err = next.ExecuteInbound(s.messenger, md)
+	} else {
+		followup, action, err = next.ExecuteOutbound(s.messenger, md)
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("execute state %s %w", next.Name(), err)
+	}
+
+	s.sendMsgEvents(&service.StateMsg{
+		ProtocolName: Name,
+		Type:         service.PostState,
+		Msg:          md.msgClone,
+		StateID:      next.Name(),
+	})
+
+	return followup, action, nil
+}
+
+// Name returns service name
+func (s *Service) Name() string {
+	return Name
+}
+
+// Spec returns the specification URI of the protocol.
+func (s *Service) Spec() string {
+	return Spec
+}
+
+// Accept msg checks the msg type
+func (s *Service) Accept(msgType string) bool {
+	switch msgType {
+	case ProposePresentationMsgType, RequestPresentationMsgType,
+		PresentationMsgType, AckMsgType, ProblemReportMsgType:
+		return true
+	}
+
+	return false
+}
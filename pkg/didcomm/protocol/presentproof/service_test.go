@@ -0,0 +1,602 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	. "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+	presentproofMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/presentproof"
+	storageMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/storage"
+	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+const (
+	Alice = "Alice"
+	Bob   = "Bob"
+)
+
+const vcJSON = `{
+  "@context": ["https://www.w3.org/2018/credentials/v1"],
+  "id": "http://example.edu/credentials/1872",
+  "type": ["VerifiableCredential", "UniversityDegreeCredential"],
+  "credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"},
+  "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+  "issuanceDate": "2010-01-01T19:23:24Z"
+}`
+
+func newService(t *testing.T, ctrl *gomock.Controller, messenger service.Messenger) *Service {
+	t.Helper()
+
+	provider := presentproofMocks.NewMockProvider(ctrl)
+	provider.EXPECT().Messenger().Return(messenger)
+	provider.EXPECT().StorageProvider().Return(mem.NewProvider())
+	provider.EXPECT().VDRIRegistry().Return(&mockvdri.MockVDRIRegistry{ResolveErr: errors.New("not found")})
+
+	svc, err := New(provider)
+	require.NoError(t, err)
+
+	return svc
+}
+
+func withThread(msg service.DIDCommMsgMap, thID string) service.DIDCommMsgMap {
+	msg["~thread"] = map[string]interface{}{"thid": thID}
+
+	return msg
+}
+
+func waitForAction(t *testing.T, actions chan service.DIDCommAction) service.DIDCommAction {
+	t.Helper()
+
+	select {
+	case action := <-actions:
+		return action
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for action")
+	}
+
+	return service.DIDCommAction{}
+}
+
+func waitForDone(t *testing.T, done chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+}
+
+// jsonPresentation returns the presentation with an embedded proof.
+func jsonPresentation(t *testing.T) []byte {
+	t.Helper()
+
+	vc, _, err := verifiable.NewCredential([]byte(vcJSON))
+	require.NoError(t, err)
+
+	vp, err := vc.Presentation()
+	require.NoError(t, err)
+
+	vp.Proofs = []verifiable.Proof{{"type": "Ed25519Signature2018"}}
+
+	vpBytes, err := vp.MarshalJSON()
+	require.NoError(t, err)
+
+	return vpBytes
+}
+
+// jwsPresentation returns the presentation signed by the holder whose DID cannot be resolved.
+func jwsPresentation(t *testing.T) []byte {
+	t.Helper()
+
+	vc, _, err := verifiable.NewCredential([]byte(vcJSON))
+	require.NoError(t, err)
+
+	vp, err := vc.Presentation()
+	require.NoError(t, err)
+
+	vp.Holder = "did:example:holder"
+
+	claims, err := vp.JWTClaims(nil, false)
+	require.NoError(t, err)
+
+	_, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	jws, err := claims.MarshalJWS(verifiable.EdDSA, privKey, "did:example:holder#key-1")
+	require.NoError(t, err)
+
+	return []byte(jws)
+}
+
+func TestService_New(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Error open store", func(t *testing.T) {
+		const errMsg = "error"
+
+		storageProvider := storageMocks.NewMockProvider(ctrl)
+		storageProvider.EXPECT().OpenStore(Name).Return(nil, errors.New(errMsg))
+
+		provider := presentproofMocks.NewMockProvider(ctrl)
+		provider.EXPECT().StorageProvider().Return(storageProvider)
+
+		svc, err := New(provider)
+		require.EqualError(t, err, errMsg)
+		require.Nil(t, svc)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		svc := newService(t, ctrl, nil)
+		require.NotNil(t, svc)
+	})
+}
+
+func TestService_Verifier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	done := make(chan struct{})
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+	messenger.EXPECT().Send(gomock.Any(), Alice, Bob).
+		Do(func(msg service.DIDCommMsgMap, _, _ string) error {
+			require.Equal(t, RequestPresentationMsgType, msg.Type())
+			return nil
+		})
+	messenger.EXPECT().ReplyTo("presentation-id", gomock.Any()).
+		Do(func(_ string, msg service.DIDCommMsgMap) error {
+			require.Equal(t, AckMsgType, msg.Type())
+
+			done <- struct{}{}
+
+			return nil
+		})
+
+	svc := newService(t, ctrl, messenger)
+
+	actions := make(chan service.DIDCommAction, 1)
+	require.NoError(t, svc.RegisterActionEvent(actions))
+
+	events := make(chan service.StateMsg, 100)
+	require.NoError(t, svc.RegisterMsgEvent(events))
+
+	piID, err := svc.HandleOutbound(service.NewDIDCommMsgMap(RequestPresentation{
+		Type: RequestPresentationMsgType,
+		ID:   "request-id",
+	}), Alice, Bob)
+	require.NoError(t, err)
+	require.Equal(t, "request-id", piID)
+
+	_, err = svc.HandleInbound(withThread(service.NewDIDCommMsgMap(Presentation{
+		Type:                PresentationMsgType,
+		ID:                  "presentation-id",
+		PresentationsAttach: []decorator.Attachment{NewPresentationAttachment(jsonPresentation(t))},
+	}), "request-id"), Alice, Bob)
+	require.NoError(t, err)
+
+	action := waitForAction(t, actions)
+	require.Equal(t, Name, action.ProtocolName)
+	require.Equal(t, PresentationMsgType, action.Message.Type())
+	action.Continue(nil)
+	waitForDone(t, done)
+
+	var states []string
+
+	for len(events) > 0 {
+		event := <-events
+		if event.Type == service.PostState {
+			states = append(states, event.StateID)
+		}
+	}
+
+	require.Equal(t, []string{"request-sent", "presentation-received", "done"}, states)
+}
+
+func TestService_VerifierInvalidPresentation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	done := make(chan struct{})
+
+	expectInvalidPresentation := func(_ string, msg service.DIDCommMsgMap) error {
+		require.Equal(t, ProblemReportMsgType, msg.Type())
+
+		report := model.ProblemReport{}
+		require.NoError(t, msg.Decode(&report))
+		require.Equal(t, "invalid presentation", report.Description.Code)
+
+		done <- struct{}{}
+
+		return nil
+	}
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+	messenger.EXPECT().Send(gomock.Any(), Alice, Bob).Return(nil).Times(3)
+	messenger.EXPECT().ReplyTo("no-attachments-id", gomock.Any()).Do(expectInvalidPresentation)
+	messenger.EXPECT().ReplyTo("unresolved-holder-id", gomock.Any()).Do(expectInvalidPresentation)
+	messenger.EXPECT().ReplyTo("no-proof-id", gomock.Any()).Do(expectInvalidPresentation)
+
+	svc := newService(t, ctrl, messenger)
+	require.NoError(t, svc.RegisterActionEvent(make(chan service.DIDCommAction)))
+
+	unsigned, err := verifiable.NewPresentation(jsonPresentation(t))
+	require.NoError(t, err)
+
+	unsigned.Proofs = nil
+
+	unsignedBytes, err := unsigned.MarshalJSON()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		id          string
+		attachments []decorator.Attachment
+	}{
+		{name: "No attachments", id: "no-attachments-id"},
+		{
+			name:        "Holder DID is not resolved",
+			id:          "unresolved-holder-id",
+			attachments: []decorator.Attachment{NewPresentationAttachment(jwsPresentation(t))},
+		},
+		{
+			name:        "Embedded proof is missing",
+			id:          "no-proof-id",
+			attachments: []decorator.Attachment{NewPresentationAttachment(unsignedBytes)},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := svc.HandleOutbound(service.NewDIDCommMsgMap(RequestPresentation{
+				Type: RequestPresentationMsgType,
+				ID:   "request-" + tc.id,
+			}), Alice, Bob)
+			require.NoError(t, err)
+
+			_, err = svc.HandleInbound(withThread(service.NewDIDCommMsgMap(Presentation{
+				Type:                PresentationMsgType,
+				ID:                  tc.id,
+				PresentationsAttach: tc.attachments,
+			}), "request-"+tc.id), Alice, Bob)
+			require.NoError(t, err)
+
+			waitForDone(t, done)
+		})
+	}
+}
+
+func TestService_VerifierReceivesProposal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	done := make(chan struct{})
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+	messenger.EXPECT().ReplyTo("propose-id", gomock.Any()).
+		Do(func(_ string, msg service.DIDCommMsgMap) error {
+			require.Equal(t, RequestPresentationMsgType, msg.Type())
+
+			request := RequestPresentation{}
+			require.NoError(t, msg.Decode(&request))
+			require.Equal(t, "request", request.Comment)
+
+			done <- struct{}{}
+
+			return nil
+		})
+
+	svc := newService(t, ctrl, messenger)
+
+	actions := make(chan service.DIDCommAction, 1)
+	require.NoError(t, svc.RegisterActionEvent(actions))
+
+	_, err := svc.HandleInbound(service.NewDIDCommMsgMap(ProposePresentation{
+		Type: ProposePresentationMsgType,
+		ID:   "propose-id",
+	}), Alice, Bob)
+	require.NoError(t, err)
+
+	waitForAction(t, actions).Continue(WithRequestPresentation(&RequestPresentation{Comment: "request"}))
+	waitForDone(t, done)
+}
+
+func TestService_Prover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	done := make(chan struct{})
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+	messenger.EXPECT().ReplyTo("request-id", gomock.Any()).
+		Do(func(_ string, msg service.DIDCommMsgMap) error {
+			require.Equal(t, PresentationMsgType, msg.Type())
+
+			presentation := Presentation{}
+			require.NoError(t, msg.Decode(&presentation))
+			require.Len(t, presentation.PresentationsAttach, 1)
+
+			done <- struct{}{}
+
+			return nil
+		})
+
+	svc := newService(t, ctrl, messenger)
+
+	actions := make(chan service.DIDCommAction, 1)
+	require.NoError(t, svc.RegisterActionEvent(actions))
+
+	_, err := svc.HandleInbound(service.NewDIDCommMsgMap(RequestPresentation{
+		Type: RequestPresentationMsgType,
+		ID:   "request-id",
+	}), Alice, Bob)
+	require.NoError(t, err)
+
+	action := waitForAction(t, actions)
+	require.Equal(t, RequestPresentationMsgType, action.Message.Type())
+	action.Continue(WithPresentation(&Presentation{
+		PresentationsAttach: []decorator.Attachment{NewPresentationAttachment(jsonPresentation(t))},
+	}))
+	waitForDone(t, done)
+
+	// the Verifier acknowledges the presentation
+	_, err = svc.HandleInbound(service.NewDIDCommMsgMap(model.Ack{
+		Type:   AckMsgType,
+		ID:     "ack-id",
+		Thread: &decorator.Thread{ID: "request-id"},
+	}), Alice, Bob)
+	require.NoError(t, err)
+
+	// the protocol is done, nothing can be received anymore
+	_, err = svc.HandleInbound(withThread(service.NewDIDCommMsgMap(RequestPresentation{
+		Type: RequestPresentationMsgType,
+		ID:   "request-id-2",
+	}), "request-id"), Alice, Bob)
+	require.Contains(t, err.Error(), "invalid state transition: done -> request-received")
+}
+
+func TestService_ProverProposesAfterRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	done := make(chan struct{})
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+	messenger.EXPECT().ReplyTo("request-id", gomock.Any()).
+		Do(func(_ string, msg service.DIDCommMsgMap) error {
+			require.Equal(t, ProposePresentationMsgType, msg.Type())
+
+			done <- struct{}{}
+
+			return nil
+		})
+
+	svc := newService(t, ctrl, messenger)
+
+	actions := make(chan service.DIDCommAction, 1)
+	require.NoError(t, svc.RegisterActionEvent(actions))
+
+	_, err := svc.HandleInbound(service.NewDIDCommMsgMap(RequestPresentation{
+		Type: RequestPresentationMsgType,
+		ID:   "request-id",
+	}), Alice, Bob)
+	require.NoError(t, err)
+
+	waitForAction(t, actions).Continue(WithProposePresentation(&ProposePresentation{Comment: "changes"}))
+	waitForDone(t, done)
+}
+
+func TestService_ActionsContinueStop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	done := make(chan struct{})
+
+	expectProblemReport := func(code string) func(_ string, msg service.DIDCommMsgMap) error {
+		return func(_ string, msg service.DIDCommMsgMap) error {
+			require.Equal(t, ProblemReportMsgType, msg.Type())
+
+			report := model.ProblemReport{}
+			require.NoError(t, msg.Decode(&report))
+			require.Equal(t, code, report.Description.Code)
+
+			done <- struct{}{}
+
+			return nil
+		}
+	}
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+	// the presentation was not provided
+	messenger.EXPECT().ReplyTo("request-id", gomock.Any()).Do(expectProblemReport("internal error"))
+	messenger.EXPECT().ReplyTo("request-id-2", gomock.Any()).Do(expectProblemReport("rejected"))
+
+	svc := newService(t, ctrl, messenger)
+
+	// the action channel must be registered but events are handled asynchronously
+	actions := make(chan service.DIDCommAction, 2)
+	require.NoError(t, svc.RegisterActionEvent(actions))
+
+	for _, id := range []string{"request-id", "request-id-2"} {
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(RequestPresentation{
+			Type: RequestPresentationMsgType,
+			ID:   id,
+		}), Alice, Bob)
+		require.NoError(t, err)
+	}
+
+	list, err := svc.Actions()
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+
+	require.NoError(t, svc.Continue("request-id", nil))
+	waitForDone(t, done)
+
+	require.NoError(t, svc.Stop("request-id-2", errors.New("rejected")))
+	waitForDone(t, done)
+
+	list, err = svc.Actions()
+	require.NoError(t, err)
+	require.Empty(t, list)
+
+	require.Contains(t, svc.Continue("request-id", nil).Error(), "get transitional payload")
+	require.Contains(t, svc.Stop("request-id", nil).Error(), "get transitional payload")
+}
+
+func TestService_HandleInbound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("No clients", func(t *testing.T) {
+		svc := newService(t, ctrl, nil)
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(RequestPresentation{
+			Type: RequestPresentationMsgType,
+		}), Alice, Bob)
+		require.EqualError(t, err, "no clients are registered to handle the message")
+	})
+
+	t.Run("Unrecognized message type", func(t *testing.T) {
+		svc := newService(t, ctrl, nil)
+		require.NoError(t, svc.RegisterActionEvent(make(chan service.DIDCommAction)))
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(RequestPresentation{
+			Type: "unknown",
+		}), Alice, Bob)
+		require.Contains(t, err.Error(), "unrecognized msgType: unknown")
+	})
+
+	t.Run("Invalid state transition", func(t *testing.T) {
+		svc := newService(t, ctrl, nil)
+		require.NoError(t, svc.RegisterActionEvent(make(chan service.DIDCommAction)))
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(Presentation{
+			Type: PresentationMsgType,
+		}), Alice, Bob)
+		require.Contains(t, err.Error(), "invalid state transition: start -> presentation-received")
+	})
+
+	t.Run("Problem report", func(t *testing.T) {
+		svc := newService(t, ctrl, nil)
+		require.NoError(t, svc.RegisterActionEvent(make(chan service.DIDCommAction)))
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(model.ProblemReport{
+			Type: ProblemReportMsgType,
+			ID:   "ID",
+		}), Alice, Bob)
+		require.NoError(t, err)
+	})
+
+	t.Run("Message of another connection", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(gomock.Any(), Alice, Bob).Return(nil)
+
+		svc := newService(t, ctrl, messenger)
+		require.NoError(t, svc.RegisterActionEvent(make(chan service.DIDCommAction)))
+
+		_, err := svc.HandleOutbound(service.NewDIDCommMsgMap(RequestPresentation{
+			Type: RequestPresentationMsgType,
+			ID:   "request-id",
+		}), Alice, Bob)
+		require.NoError(t, err)
+
+		// the presentation requested to Bob can't be sent by another DID
+		_, err = svc.HandleInbound(withThread(service.NewDIDCommMsgMap(Presentation{
+			Type: PresentationMsgType,
+			ID:   "presentation-id",
+		}), "request-id"), Alice, "Mallory")
+		require.EqualError(t, err, "doHandle: thread request-id belongs to another connection")
+	})
+}
+
+func TestService_HandleOutbound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Presentation without request", func(t *testing.T) {
+		svc := newService(t, ctrl, nil)
+
+		_, err := svc.HandleOutbound(service.NewDIDCommMsgMap(Presentation{
+			Type: PresentationMsgType,
+		}), Alice, Bob)
+		require.Contains(t, err.Error(), "presentation can be sent only as a response to the request")
+	})
+
+	t.Run("Send request", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(gomock.Any(), Alice, Bob).Return(errors.New("send error"))
+
+		svc := newService(t, ctrl, messenger)
+
+		_, err := svc.HandleOutbound(service.NewDIDCommMsgMap(RequestPresentation{
+			Type: RequestPresentationMsgType,
+		}), Alice, Bob)
+		require.EqualError(t, err, "send error")
+	})
+
+	t.Run("Send proposal", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(gomock.Any(), Alice, Bob).Return(nil)
+
+		svc := newService(t, ctrl, messenger)
+
+		piID, err := svc.HandleOutbound(service.NewDIDCommMsgMap(ProposePresentation{
+			Type: ProposePresentationMsgType,
+		}), Alice, Bob)
+		require.NoError(t, err)
+		require.NotEmpty(t, piID)
+	})
+
+	t.Run("Message of another connection", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(gomock.Any(), Alice, Bob).Return(nil)
+
+		svc := newService(t, ctrl, messenger)
+
+		_, err := svc.HandleOutbound(service.NewDIDCommMsgMap(ProposePresentation{
+			Type: ProposePresentationMsgType,
+			ID:   "propose-id",
+		}), Alice, Bob)
+		require.NoError(t, err)
+
+		// the thread of the proposal sent to Bob can't be continued with another DID
+		_, err = svc.HandleOutbound(withThread(service.NewDIDCommMsgMap(ProposePresentation{
+			Type: ProposePresentationMsgType,
+			ID:   "propose-id-2",
+		}), "propose-id"), Alice, "Mallory")
+		require.EqualError(t, err, "doHandle: thread propose-id belongs to another connection")
+	})
+}
+
+func TestService_Accept(t *testing.T) {
+	svc := &Service{}
+
+	require.Equal(t, Name, svc.Name())
+	require.Equal(t, Spec, svc.Spec())
+	require.True(t, svc.Accept(ProposePresentationMsgType))
+	require.True(t, svc.Accept(RequestPresentationMsgType))
+	require.True(t, svc.Accept(PresentationMsgType))
+	require.True(t, svc.Accept(AckMsgType))
+	require.True(t, svc.Accept(ProblemReportMsgType))
+	require.False(t, svc.Accept("unknown"))
+}
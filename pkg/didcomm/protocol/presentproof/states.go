@@ -0,0 +1,329 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"errors"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+const (
+	codeRejected            = "rejected"
+	codeInternalError       = "internal error"
+	codeInvalidPresentation = "invalid presentation"
+)
+
+const (
+	// common states
+	stateNameNoop       = "noop"
+	stateNameStart      = "start"
+	stateNameAbandoning = "abandoning"
+	stateNameDone       = "done"
+
+	// states for Verifier
+	stateNameRequestSent          = "request-sent"
+	stateNameProposalReceived     = "proposal-received"
+	stateNamePresentationReceived = "presentation-received"
+
+	// states for Prover
+	stateNameRequestReceived  = "request-received"
+	stateNameProposalSent     = "proposal-sent"
+	stateNamePresentationSent = "presentation-sent"
+)
+
+// state action for network call
+type stateAction func() error
+
+// the protocol's state.
+type state interface {
+	// Name of this state.
+	Name() string
+	// CanTransitionTo checks whether this state allows transitioning into the next state.
+	CanTransitionTo(next state) bool
+	// ExecuteInbound/ExecuteOutbound execute this state, returning a followup state to be immediately executed as well.
+	// The 'noOp' state should be returned if the state has no followup.
+	ExecuteInbound(messenger service.Messenger, md *metaData) (state, stateAction, error)
+	ExecuteOutbound(messenger service.Messenger, md *metaData) (state, stateAction, error)
+}
+
+func zeroAction() error { return nil }
+
+// noOp state
+type noOp struct{}
+
+func (s *noOp) Name() string {
+	return stateNameNoop
+}
+
+func (s *noOp) CanTransitionTo(_ state) bool {
+	return false
+}
+
+func (s *noOp) ExecuteInbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("cannot execute no-op")
+}
+
+func (s *noOp) ExecuteOutbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("cannot execute no-op")
+}
+
+// start state
+type start struct{}
+
+func (s *start) Name() string {
+	return stateNameStart
+}
+
+func (s *start) CanTransitionTo(st state) bool {
+	switch st.Name() {
+	// Verifier starts
+	case stateNameRequestSent, stateNameProposalReceived:
+		return true
+	// Prover starts
+	case stateNameProposalSent, stateNameRequestReceived:
+		return true
+	case stateNameAbandoning:
+		return true
+	}
+
+	return false
+}
+
+func (s *start) ExecuteInbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("start: ExecuteInbound function is not supposed to be used")
+}
+
+func (s *start) ExecuteOutbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("start: ExecuteOutbound function is not supposed to be used")
+}
+
+// abandoning state
+type abandoning struct {
+	Code string
+}
+
+func (s *abandoning) Name() string {
+	return stateNameAbandoning
+}
+
+func (s *abandoning) CanTransitionTo(st state) bool {
+	return st.Name() == stateNameDone
+}
+
+func (s *abandoning) ExecuteInbound(messenger service.Messenger, md *metaData) (state, stateAction, error) {
+	// if code is not provided it means we do not need to notify the another agent
+	if s.Code == "" {
+		return &done{}, zeroAction, nil
+	}
+
+	return &done{}, func() error {
+		return messenger.ReplyTo(md.Msg.ID(), service.NewDIDCommMsgMap(model.ProblemReport{
+			Type:        ProblemReportMsgType,
+			Description: model.Code{Code: s.Code},
+		}))
+	}, nil
+}
+
+func (s *abandoning) ExecuteOutbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("abandoning: ExecuteOutbound function is not supposed to be used")
+}
+
+// done state
+type done struct{}
+
+func (s *done) Name() string {
+	return stateNameDone
+}
+
+func (s *done) CanTransitionTo(_ state) bool {
+	// done is the last state there is no possibility for the next state
+	return false
+}
+
+func (s *done) ExecuteInbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return &noOp{}, zeroAction, nil
+}
+
+func (s *done) ExecuteOutbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("done: ExecuteOutbound function is not supposed to be used")
+}
+
+// requestSent the Verifier's state
+type requestSent struct{}
+
+func (s *requestSent) Name() string {
+	return stateNameRequestSent
+}
+
+func (s *requestSent) CanTransitionTo(st state) bool {
+	return st.Name() == stateNamePresentationReceived ||
+		st.Name() == stateNameProposalReceived ||
+		st.Name() == stateNameAbandoning
+}
+
+func (s *requestSent) ExecuteInbound(messenger service.Messenger, md *metaData) (state, stateAction, error) {
+	if md.requestPresentation == nil {
+		return nil, nil, errors.New("request presentation was not provided")
+	}
+
+	// creates the state's action
+	action := func() error {
+		// sets message type
+		md.requestPresentation.Type = RequestPresentationMsgType
+		return messenger.ReplyTo(md.Msg.ID(), service.NewDIDCommMsgMap(md.requestPresentation))
+	}
+
+	return &noOp{}, action, nil
+}
+
+func (s *requestSent) ExecuteOutbound(messenger service.Messenger, md *metaData) (state, stateAction, error) {
+	// creates the state's action
+	action := func() error {
+		return messenger.Send(md.Msg, md.MyDID, md.TheirDID)
+	}
+
+	return &noOp{}, action, nil
+}
+
+// proposalReceived the Verifier's state
+type proposalReceived struct{}
+
+func (s *proposalReceived) Name() string {
+	return stateNameProposalReceived
+}
+
+func (s *proposalReceived) CanTransitionTo(st state) bool {
+	return st.Name() == stateNameRequestSent || st.Name() == stateNameAbandoning
+}
+
+func (s *proposalReceived) ExecuteInbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return &requestSent{}, zeroAction, nil
+}
+
+func (s *proposalReceived) ExecuteOutbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("proposalReceived: ExecuteOutbound function is not supposed to be used")
+}
+
+// presentationReceived the Verifier's state
+type presentationReceived struct{}
+
+func (s *presentationReceived) Name() string {
+	return stateNamePresentationReceived
+}
+
+func (s *presentationReceived) CanTransitionTo(st state) bool {
+	return st.Name() == stateNameDone || st.Name() == stateNameAbandoning
+}
+
+func (s *presentationReceived) ExecuteInbound(messenger service.Messenger, md *metaData) (state, stateAction, error) {
+	// creates the state's action
+	action := func() error {
+		return messenger.ReplyTo(md.Msg.ID(), service.NewDIDCommMsgMap(model.Ack{
+			Type:   AckMsgType,
+			Status: "OK",
+		}))
+	}
+
+	return &done{}, action, nil
+}
+
+func (s *presentationReceived) ExecuteOutbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("presentationReceived: ExecuteOutbound function is not supposed to be used")
+}
+
+// requestReceived the Prover's state
+type requestReceived struct{}
+
+func (s *requestReceived) Name() string {
+	return stateNameRequestReceived
+}
+
+func (s *requestReceived) CanTransitionTo(st state) bool {
+	return st.Name() == stateNamePresentationSent ||
+		st.Name() == stateNameProposalSent ||
+		st.Name() == stateNameAbandoning
+}
+
+func (s *requestReceived) ExecuteInbound(_ service.Messenger, md *metaData) (state, stateAction, error) {
+	// proposal is provided it means the Prover wants to negotiate the request
+	if md.proposePresentation != nil {
+		return &proposalSent{}, zeroAction, nil
+	}
+
+	return &presentationSent{}, zeroAction, nil
+}
+
+func (s *requestReceived) ExecuteOutbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("requestReceived: ExecuteOutbound function is not supposed to be used")
+}
+
+// proposalSent the Prover's state
+type proposalSent struct{}
+
+func (s *proposalSent) Name() string {
+	return stateNameProposalSent
+}
+
+func (s *proposalSent) CanTransitionTo(st state) bool {
+	return st.Name() == stateNameRequestReceived || st.Name() == stateNameAbandoning
+}
+
+func (s *proposalSent) ExecuteInbound(messenger service.Messenger, md *metaData) (state, stateAction, error) {
+	if md.proposePresentation == nil {
+		return nil, nil, errors.New("propose presentation was not provided")
+	}
+
+	// creates the state's action
+	action := func() error {
+		// sets message type
+		md.proposePresentation.Type = ProposePresentationMsgType
+		return messenger.ReplyTo(md.Msg.ID(), service.NewDIDCommMsgMap(md.proposePresentation))
+	}
+
+	return &noOp{}, action, nil
+}
+
+func (s *proposalSent) ExecuteOutbound(messenger service.Messenger, md *metaData) (state, stateAction, error) {
+	// creates the state's action
+	action := func() error {
+		return messenger.Send(md.Msg, md.MyDID, md.TheirDID)
+	}
+
+	return &noOp{}, action, nil
+}
+
+// presentationSent the Prover's state
+type presentationSent struct{}
+
+func (s *presentationSent) Name() string {
+	return stateNamePresentationSent
+}
+
+func (s *presentationSent) CanTransitionTo(st state) bool {
+	return st.Name() == stateNameDone || st.Name() == stateNameAbandoning
+}
+
+func (s *presentationSent) ExecuteInbound(messenger service.Messenger, md *metaData) (state, stateAction, error) {
+	if md.presentation == nil {
+		return nil, nil, errors.New("presentation was not provided")
+	}
+
+	// creates the state's action
+	action := func() error {
+		// sets message type
+		md.presentation.Type = PresentationMsgType
+		return messenger.ReplyTo(md.Msg.ID(), service.NewDIDCommMsgMap(md.presentation))
+	}
+
+	return &noOp{}, action, nil
+}
+
+func (s *presentationSent) ExecuteOutbound(_ service.Messenger, _ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("presentationSent: ExecuteOutbound function is not supposed to be used")
+}
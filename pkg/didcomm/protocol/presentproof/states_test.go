@@ -0,0 +1,365 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+)
+
+func notTransition(t *testing.T, st state) {
+	t.Helper()
+
+	var allState = [...]state{
+		&noOp{}, &start{}, &abandoning{}, &done{},
+		&requestSent{}, &proposalReceived{}, &presentationReceived{},
+		&requestReceived{}, &proposalSent{}, &presentationSent{},
+	}
+
+	for _, s := range allState {
+		require.False(t, st.CanTransitionTo(s))
+	}
+}
+
+func TestStart_CanTransitionTo(t *testing.T) {
+	st := &start{}
+	require.Equal(t, stateNameStart, st.Name())
+
+	// common states
+	require.False(t, st.CanTransitionTo(&start{}))
+	require.True(t, st.CanTransitionTo(&abandoning{}))
+	require.False(t, st.CanTransitionTo(&done{}))
+	require.False(t, st.CanTransitionTo(&noOp{}))
+	// states for Verifier
+	require.True(t, st.CanTransitionTo(&requestSent{}))
+	require.True(t, st.CanTransitionTo(&proposalReceived{}))
+	require.False(t, st.CanTransitionTo(&presentationReceived{}))
+	// states for Prover
+	require.True(t, st.CanTransitionTo(&requestReceived{}))
+	require.True(t, st.CanTransitionTo(&proposalSent{}))
+	require.False(t, st.CanTransitionTo(&presentationSent{}))
+}
+
+func TestStart_Execute(t *testing.T) {
+	followup, action, err := (&start{}).ExecuteInbound(nil, &metaData{})
+	require.EqualError(t, err, "start: ExecuteInbound function is not supposed to be used")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+
+	followup, action, err = (&start{}).ExecuteOutbound(nil, &metaData{})
+	require.EqualError(t, err, "start: ExecuteOutbound function is not supposed to be used")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+}
+
+func TestNoOp_CanTransitionTo(t *testing.T) {
+	noop := &noOp{}
+	require.Equal(t, stateNameNoop, noop.Name())
+	notTransition(t, noop)
+}
+
+func TestNoOp_Execute(t *testing.T) {
+	followup, action, err := (&noOp{}).ExecuteInbound(nil, &metaData{})
+	require.EqualError(t, err, "cannot execute no-op")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+
+	followup, action, err = (&noOp{}).ExecuteOutbound(nil, &metaData{})
+	require.EqualError(t, err, "cannot execute no-op")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+}
+
+func TestDone_CanTransitionTo(t *testing.T) {
+	st := &done{}
+	require.Equal(t, stateNameDone, st.Name())
+	notTransition(t, st)
+}
+
+func TestDone_Execute(t *testing.T) {
+	followup, action, err := (&done{}).ExecuteInbound(nil, &metaData{})
+	require.NoError(t, err)
+	require.Equal(t, &noOp{}, followup)
+	require.NoError(t, action())
+
+	followup, action, err = (&done{}).ExecuteOutbound(nil, &metaData{})
+	require.EqualError(t, err, "done: ExecuteOutbound function is not supposed to be used")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+}
+
+func TestAbandoning_CanTransitionTo(t *testing.T) {
+	st := &abandoning{}
+	require.Equal(t, stateNameAbandoning, st.Name())
+
+	require.True(t, st.CanTransitionTo(&done{}))
+	require.False(t, st.CanTransitionTo(&abandoning{}))
+	require.False(t, st.CanTransitionTo(&requestSent{}))
+	require.False(t, st.CanTransitionTo(&presentationSent{}))
+}
+
+func TestAbandoning_ExecuteInbound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("No code", func(t *testing.T) {
+		followup, action, err := (&abandoning{}).ExecuteInbound(nil, &metaData{})
+		require.NoError(t, err)
+		require.Equal(t, &done{}, followup)
+		require.NoError(t, action())
+	})
+
+	t.Run("Sends problem-report", func(t *testing.T) {
+		md := &metaData{}
+		md.Msg = service.NewDIDCommMsgMap(struct{}{})
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyTo(gomock.Any(), gomock.Any()).
+			Do(func(_ string, msg service.DIDCommMsgMap) error {
+				require.Equal(t, ProblemReportMsgType, msg.Type())
+				require.Equal(t, codeRejected, msg["description"].(map[string]interface{})["code"])
+
+				return nil
+			})
+
+		followup, action, err := (&abandoning{Code: codeRejected}).ExecuteInbound(messenger, md)
+		require.NoError(t, err)
+		require.Equal(t, &done{}, followup)
+		require.NoError(t, action())
+	})
+
+	followup, action, err := (&abandoning{}).ExecuteOutbound(nil, &metaData{})
+	require.EqualError(t, err, "abandoning: ExecuteOutbound function is not supposed to be used")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+}
+
+func TestRequestSent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := &requestSent{}
+	require.Equal(t, stateNameRequestSent, st.Name())
+
+	require.True(t, st.CanTransitionTo(&presentationReceived{}))
+	require.True(t, st.CanTransitionTo(&proposalReceived{}))
+	require.True(t, st.CanTransitionTo(&abandoning{}))
+	require.False(t, st.CanTransitionTo(&requestReceived{}))
+	require.False(t, st.CanTransitionTo(&done{}))
+
+	t.Run("Inbound without request", func(t *testing.T) {
+		followup, action, err := st.ExecuteInbound(nil, &metaData{})
+		require.EqualError(t, err, "request presentation was not provided")
+		require.Nil(t, followup)
+		require.Nil(t, action)
+	})
+
+	t.Run("Inbound", func(t *testing.T) {
+		md := &metaData{requestPresentation: &RequestPresentation{}}
+		md.Msg = service.NewDIDCommMsgMap(struct{}{})
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyTo(gomock.Any(), gomock.Any()).
+			Do(func(_ string, msg service.DIDCommMsgMap) error {
+				require.Equal(t, RequestPresentationMsgType, msg.Type())
+				return nil
+			})
+
+		followup, action, err := st.ExecuteInbound(messenger, md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.NoError(t, action())
+	})
+
+	t.Run("Outbound", func(t *testing.T) {
+		md := &metaData{}
+		md.Msg = service.NewDIDCommMsgMap(RequestPresentation{Type: RequestPresentationMsgType})
+		md.MyDID, md.TheirDID = "myDID", "theirDID"
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(md.Msg, "myDID", "theirDID").Return(errors.New("test error"))
+
+		followup, action, err := st.ExecuteOutbound(messenger, md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.EqualError(t, action(), "test error")
+	})
+}
+
+func TestProposalReceived(t *testing.T) {
+	st := &proposalReceived{}
+	require.Equal(t, stateNameProposalReceived, st.Name())
+
+	require.True(t, st.CanTransitionTo(&requestSent{}))
+	require.True(t, st.CanTransitionTo(&abandoning{}))
+	require.False(t, st.CanTransitionTo(&presentationReceived{}))
+	require.False(t, st.CanTransitionTo(&done{}))
+
+	followup, action, err := st.ExecuteInbound(nil, &metaData{})
+	require.NoError(t, err)
+	require.Equal(t, &requestSent{}, followup)
+	require.NoError(t, action())
+
+	followup, action, err = st.ExecuteOutbound(nil, &metaData{})
+	require.EqualError(t, err, "proposalReceived: ExecuteOutbound function is not supposed to be used")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+}
+
+func TestPresentationReceived(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := &presentationReceived{}
+	require.Equal(t, stateNamePresentationReceived, st.Name())
+
+	require.True(t, st.CanTransitionTo(&done{}))
+	require.True(t, st.CanTransitionTo(&abandoning{}))
+	require.False(t, st.CanTransitionTo(&requestSent{}))
+
+	md := &metaData{}
+	md.Msg = service.NewDIDCommMsgMap(struct{}{})
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+	messenger.EXPECT().ReplyTo(gomock.Any(), gomock.Any()).
+		Do(func(_ string, msg service.DIDCommMsgMap) error {
+			require.Equal(t, AckMsgType, msg.Type())
+			return nil
+		})
+
+	followup, action, err := st.ExecuteInbound(messenger, md)
+	require.NoError(t, err)
+	require.Equal(t, &done{}, followup)
+	require.NoError(t, action())
+
+	followup, action, err = st.ExecuteOutbound(nil, &metaData{})
+	require.EqualError(t, err, "presentationReceived: ExecuteOutbound function is not supposed to be used")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+}
+
+func TestRequestReceived(t *testing.T) {
+	st := &requestReceived{}
+	require.Equal(t, stateNameRequestReceived, st.Name())
+
+	require.True(t, st.CanTransitionTo(&presentationSent{}))
+	require.True(t, st.CanTransitionTo(&proposalSent{}))
+	require.True(t, st.CanTransitionTo(&abandoning{}))
+	require.False(t, st.CanTransitionTo(&done{}))
+
+	followup, action, err := st.ExecuteInbound(nil, &metaData{})
+	require.NoError(t, err)
+	require.Equal(t, &presentationSent{}, followup)
+	require.NoError(t, action())
+
+	followup, action, err = st.ExecuteInbound(nil, &metaData{proposePresentation: &ProposePresentation{}})
+	require.NoError(t, err)
+	require.Equal(t, &proposalSent{}, followup)
+	require.NoError(t, action())
+
+	followup, action, err = st.ExecuteOutbound(nil, &metaData{})
+	require.EqualError(t, err, "requestReceived: ExecuteOutbound function is not supposed to be used")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+}
+
+func TestProposalSent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := &proposalSent{}
+	require.Equal(t, stateNameProposalSent, st.Name())
+
+	require.True(t, st.CanTransitionTo(&requestReceived{}))
+	require.True(t, st.CanTransitionTo(&abandoning{}))
+	require.False(t, st.CanTransitionTo(&presentationSent{}))
+	require.False(t, st.CanTransitionTo(&done{}))
+
+	t.Run("Inbound without proposal", func(t *testing.T) {
+		followup, action, err := st.ExecuteInbound(nil, &metaData{})
+		require.EqualError(t, err, "propose presentation was not provided")
+		require.Nil(t, followup)
+		require.Nil(t, action)
+	})
+
+	t.Run("Inbound", func(t *testing.T) {
+		md := &metaData{proposePresentation: &ProposePresentation{}}
+		md.Msg = service.NewDIDCommMsgMap(struct{}{})
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyTo(gomock.Any(), gomock.Any()).
+			Do(func(_ string, msg service.DIDCommMsgMap) error {
+				require.Equal(t, ProposePresentationMsgType, msg.Type())
+				return nil
+			})
+
+		followup, action, err := st.ExecuteInbound(messenger, md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.NoError(t, action())
+	})
+
+	t.Run("Outbound", func(t *testing.T) {
+		md := &metaData{}
+		md.Msg = service.NewDIDCommMsgMap(ProposePresentation{Type: ProposePresentationMsgType})
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(md.Msg, gomock.Any(), gomock.Any()).Return(nil)
+
+		followup, action, err := st.ExecuteOutbound(messenger, md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.NoError(t, action())
+	})
+}
+
+func TestPresentationSent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := &presentationSent{}
+	require.Equal(t, stateNamePresentationSent, st.Name())
+
+	require.True(t, st.CanTransitionTo(&done{}))
+	require.True(t, st.CanTransitionTo(&abandoning{}))
+	require.False(t, st.CanTransitionTo(&requestReceived{}))
+
+	t.Run("Inbound without presentation", func(t *testing.T) {
+		followup, action, err := st.ExecuteInbound(nil, &metaData{})
+		require.EqualError(t, err, "presentation was not provided")
+		require.Nil(t, followup)
+		require.Nil(t, action)
+	})
+
+	t.Run("Inbound", func(t *testing.T) {
+		md := &metaData{presentation: &Presentation{}}
+		md.Msg = service.NewDIDCommMsgMap(struct{}{})
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyTo(gomock.Any(), gomock.Any()).
+			Do(func(_ string, msg service.DIDCommMsgMap) error {
+				require.Equal(t, PresentationMsgType, msg.Type())
+				return nil
+			})
+
+		followup, action, err := st.ExecuteInbound(messenger, md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.NoError(t, action())
+	})
+
+	followup, action, err := st.ExecuteOutbound(nil, &metaData{})
+	require.EqualError(t, err, "presentationSent: ExecuteOutbound function is not supposed to be used")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/messagepickup"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/legacykms"
@@ -85,6 +86,7 @@ var ErrRouterNotRegistered = errors.New("router not registered")
 
 // provider contains dependencies for the Routing protocol and is typically created by using aries.Context()
 type provider interface {
+	Service(id string) (interface{}, error)
 	OutboundDispatcher() dispatcher.Outbound
 	StorageProvider() storage.Provider
 	TransientStorageProvider() storage.Provider
@@ -93,6 +95,11 @@ type provider interface {
 	VDRIRegistry() vdri.Registry
 }
 
+// messagePickup queues the messages for the recipients which can't be reached.
+type messagePickup interface {
+	AddMessage(message *model.Envelope, theirDID string) error
+}
+
 // Service for Route Coordination protocol.
 // https://github.com/hyperledger/aries-rfcs/tree/master/features/0211-route-coordination
 type Service struct {
@@ -104,6 +111,7 @@ type Service struct {
 	endpoint                 string
 	kms                      legacykms.KeyManager
 	vdRegistry               vdri.Registry
+	messagePickup            messagePickup
 	routeRegistrationMap     map[string]chan Grant
 	routeRegistrationMapLock sync.RWMutex
 	keylistUpdateMap         map[string]chan *KeylistUpdateResponse
@@ -122,6 +130,12 @@ func New(prov provider) (*Service, error) {
 		return nil, err
 	}
 
+	// the message pickup service is optional, the undelivered messages are not queued without it
+	var pickup messagePickup
+	if svc, err := prov.Service(messagepickup.MessagePickup); err == nil {
+		pickup, _ = svc.(messagePickup)
+	}
+
 	return &Service{
 		routeStore:           store,
 		outbound:             prov.OutboundDispatcher(),
@@ -129,6 +143,7 @@ func New(prov provider) (*Service, error) {
 		kms:                  prov.LegacyKMS(),
 		vdRegistry:           prov.VDRIRegistry(),
 		connectionLookup:     connectionLookup,
+		messagePickup:        pickup,
 		routeRegistrationMap: make(map[string]chan Grant),
 		keylistUpdateMap:     make(map[string]chan *KeylistUpdateResponse),
 	}, nil
@@ -194,6 +209,11 @@ func (s *Service) Name() string {
 	return Coordination
 }
 
+// Spec returns the specification URI of the protocol.
+func (s *Service) Spec() string {
+	return CoordinationSpec
+}
+
 func (s *Service) handleRequest(msg service.DIDCommMsg, myDID, theirDID string) error {
 	// unmarshal the payload
 	request := &Request{}
@@ -336,7 +356,15 @@ func (s *Service) handleForward(msg service.DIDCommMsg) error {
 		return fmt.Errorf("get destination : %w", err)
 	}
 
-	return s.outbound.Forward(forward.Msg, dest)
+	err = s.outbound.Forward(forward.Msg, dest)
+	if err != nil && s.messagePickup != nil {
+		// the recipient can't be reached (e.g the edge agent is offline), queue the message for the pickup
+		logger.Debugf("queue the message for %s : %v", string(theirDID), err)
+
+		return s.messagePickup.AddMessage(forward.Msg, string(theirDID))
+	}
+
+	return err
 }
 
 // Register registers the agent with the router on the other end of the connection identified by
@@ -17,6 +17,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/messagepickup"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/internal/mock/didcomm/dispatcher"
@@ -47,6 +48,7 @@ func TestServiceNew(t *testing.T) {
 		})
 		require.NoError(t, err)
 		require.Equal(t, Coordination, svc.Name())
+		require.Equal(t, CoordinationSpec, svc.Spec())
 	})
 
 	t.Run("test new service name - failure", func(t *testing.T) {
@@ -415,6 +417,60 @@ func TestServiceForwardMsg(t *testing.T) {
 	})
 }
 
+func TestServiceForwardMsgPickup(t *testing.T) {
+	to := randomID()
+	theirDID := "did:example:123"
+	content := &model.Envelope{CipherText: "qQyzvajdvCDJbwxM"}
+
+	pickup := &mockMessagePickup{}
+
+	svc, err := New(&mockprovider.Provider{
+		ServiceMap:                    map[string]interface{}{messagepickup.MessagePickup: pickup},
+		StorageProviderValue:          mockstore.NewMockStoreProvider(),
+		TransientStorageProviderValue: mockstore.NewMockStoreProvider(),
+		KMSValue:                      &mockkms.CloseableKMS{},
+		OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+			ValidateForward: func(msg interface{}, des *service.Destination) error {
+				return errors.New("recipient is offline")
+			},
+		},
+		VDRIRegistryValue: &mockvdri.MockVDRIRegistry{
+			ResolveValue: mockdiddoc.GetMockDIDDoc(),
+		},
+	})
+	require.NoError(t, err)
+
+	err = svc.routeStore.Put(dataKey(to), []byte(theirDID))
+	require.NoError(t, err)
+
+	t.Run("test service handle forward msg - queue the message", func(t *testing.T) {
+		err = svc.handleForward(generateForwardMsgPayload(t, randomID(), to, content))
+		require.NoError(t, err)
+		require.Equal(t, theirDID, pickup.theirDID)
+		require.Equal(t, content, pickup.message)
+	})
+
+	t.Run("test service handle forward msg - queue the message error", func(t *testing.T) {
+		pickup.err = errors.New("queue error")
+
+		err = svc.handleForward(generateForwardMsgPayload(t, randomID(), to, content))
+		require.EqualError(t, err, "queue error")
+	})
+}
+
+type mockMessagePickup struct {
+	message  *model.Envelope
+	theirDID string
+	err      error
+}
+
+func (m *mockMessagePickup) AddMessage(message *model.Envelope, theirDID string) error {
+	m.message = message
+	m.theirDID = theirDID
+
+	return m.err
+}
+
 func TestRegister(t *testing.T) {
 	t.Run("test register route - success", func(t *testing.T) {
 		msgID := make(chan string)
@@ -0,0 +1,27 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+
+// Ping trust ping message.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0048-trust-ping#messages
+type Ping struct {
+	Type              string `json:"@type,omitempty"`
+	ID                string `json:"@id,omitempty"`
+	Comment           string `json:"comment,omitempty"`
+	ResponseRequested bool   `json:"response_requested"`
+}
+
+// PingResponse trust ping response message.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0048-trust-ping#messages
+type PingResponse struct {
+	Type    string            `json:"@type,omitempty"`
+	ID      string            `json:"@id,omitempty"`
+	Comment string            `json:"comment,omitempty"`
+	Thread  *decorator.Thread `json:"~thread,omitempty"`
+}
@@ -0,0 +1,261 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+var logger = log.New("aries-framework/trustping/service")
+
+// constants for trust ping spec types
+const (
+	// TrustPing trust ping protocol
+	TrustPing = "trustping"
+
+	// TrustPingSpec defines the trust ping spec
+	TrustPingSpec = "https://didcomm.org/trust_ping/1.0/"
+
+	// PingMsgType defines the trust ping message type.
+	PingMsgType = TrustPingSpec + "ping"
+
+	// PingResponseMsgType defines the trust ping response message type.
+	PingResponseMsgType = TrustPingSpec + "ping_response"
+)
+
+const (
+	// DefaultTimeout is used when the timeout of the ping is not provided
+	DefaultTimeout = 5 * time.Second
+)
+
+// ErrConnectionNotFound connection not found error
+var ErrConnectionNotFound = errors.New("connection not found")
+
+// provider contains dependencies for the Trust Ping protocol and is typically created by using aries.Context()
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	TransientStorageProvider() storage.Provider
+}
+
+// Service for Trust Ping protocol.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0048-trust-ping
+type Service struct {
+	connectionLookup *connection.Lookup
+	outbound         dispatcher.Outbound
+	pendingPings     map[string]*pendingPing
+	pendingPingsLock sync.RWMutex
+}
+
+// pendingPing is a ping waiting for its response from the pinged DID
+type pendingPing struct {
+	theirDID   string
+	responseCh chan *PingResponse
+}
+
+// New return trust ping service.
+func New(prov provider) (*Service, error) {
+	connectionLookup, err := connection.NewLookup(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		connectionLookup: connectionLookup,
+		outbound:         prov.OutboundDispatcher(),
+		pendingPings:     make(map[string]*pendingPing),
+	}, nil
+}
+
+// HandleInbound handles inbound trust ping messages.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	// perform action on inbound message asynchronously
+	go func() {
+		var err error
+
+		switch msg.Type() {
+		case PingMsgType:
+			err = s.handlePing(msg, myDID, theirDID)
+		case PingResponseMsgType:
+			err = s.handlePingResponse(msg, theirDID)
+		}
+
+		if err != nil {
+			logutil.LogError(logger, TrustPing, "processMessage", err.Error(),
+				logutil.CreateKeyValueString("msgType", msg.Type()),
+				logutil.CreateKeyValueString("msgID", msg.ID()))
+		} else {
+			logutil.LogDebug(logger, TrustPing, "processMessage", "success",
+				logutil.CreateKeyValueString("msgType", msg.Type()),
+				logutil.CreateKeyValueString("msgID", msg.ID()))
+		}
+	}()
+
+	return msg.ID(), nil
+}
+
+// HandleOutbound handles outbound trust ping messages.
+func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	return msgType == PingMsgType || msgType == PingResponseMsgType
+}
+
+// Name of the service
+func (s *Service) Name() string {
+	return TrustPing
+}
+
+// Spec returns the specification URI of the protocol.
+func (s *Service) Spec() string {
+	return TrustPingSpec
+}
+
+// Ping sends the ping message (with the response requested) to the agent on the other end of the connection
+// identified by connectionID. This method blocks until the response is received or the timeout expires.
+// The DefaultTimeout is used if the timeout is not provided.
+func (s *Service) Ping(connectionID string, timeout time.Duration) (*PingResponse, error) {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	msgID := uuid.New().String()
+
+	// register the ping for callback processing, only the pinged DID can respond to it
+	pending := &pendingPing{theirDID: conn.TheirDID, responseCh: make(chan *PingResponse, 1)}
+	s.setPendingPing(msgID, pending)
+
+	// remove the ping once its been processed
+	defer s.setPendingPing(msgID, nil)
+
+	ping := &Ping{
+		ID:                msgID,
+		Type:              PingMsgType,
+		ResponseRequested: true,
+	}
+
+	if err := s.outbound.SendToDID(ping, conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send ping : %w", err)
+	}
+
+	select {
+	case resp := <-pending.responseCh:
+		return resp, nil
+	case <-time.After(timeout):
+		return nil, errors.New("timeout waiting for the ping response")
+	}
+}
+
+func (s *Service) handlePing(msg service.DIDCommMsg, myDID, theirDID string) error {
+	// unmarshal the payload
+	ping := &Ping{}
+
+	err := msg.Decode(ping)
+	if err != nil {
+		return fmt.Errorf("ping message unmarshal : %w", err)
+	}
+
+	if !ping.ResponseRequested {
+		return nil
+	}
+
+	resp := &PingResponse{
+		ID:     uuid.New().String(),
+		Type:   PingResponseMsgType,
+		Thread: &decorator.Thread{ID: ping.ID},
+	}
+
+	if err := s.outbound.SendToDID(resp, myDID, theirDID); err != nil {
+		return fmt.Errorf("send ping response : %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) handlePingResponse(msg service.DIDCommMsg, theirDID string) error {
+	// unmarshal the payload
+	resp := &PingResponse{}
+
+	err := msg.Decode(resp)
+	if err != nil {
+		return fmt.Errorf("ping response message unmarshal : %w", err)
+	}
+
+	if resp.Thread == nil {
+		return errors.New("ping response message thread is missing")
+	}
+
+	// check if there is any ping registered for the thread ID, the duplicate responses are dropped
+	pending := s.getPendingPing(resp.Thread.ID)
+	if pending == nil {
+		return nil
+	}
+
+	if pending.theirDID != theirDID {
+		return fmt.Errorf("ping response of thread %s not sent by the pinged DID", resp.Thread.ID)
+	}
+
+	select {
+	case pending.responseCh <- resp:
+	default:
+		logger.Warnf("dropping duplicate ping response of thread %s", resp.Thread.ID)
+	}
+
+	return nil
+}
+
+func (s *Service) getPendingPing(msgID string) *pendingPing {
+	s.pendingPingsLock.RLock()
+	defer s.pendingPingsLock.RUnlock()
+
+	return s.pendingPings[msgID]
+}
+
+func (s *Service) setPendingPing(msgID string, pending *pendingPing) {
+	s.pendingPingsLock.Lock()
+	defer s.pendingPingsLock.Unlock()
+
+	if pending == nil {
+		delete(s.pendingPings, msgID)
+	} else {
+		s.pendingPings[msgID] = pending
+	}
+}
+
+func (s *Service) getConnection(connectionID string) (*connection.Record, error) {
+	conn, err := s.connectionLookup.GetConnectionRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrConnectionNotFound
+		}
+
+		return nil, fmt.Errorf("fetch connection record from store : %w", err)
+	}
+
+	return conn, nil
+}
@@ -0,0 +1,201 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/internal/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/internal/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	aliceDID = "did:example:alice"
+	bobDID   = "did:example:bob"
+	connID   = "connID"
+)
+
+// newServices creates the services of the agents connected to each other.
+func newServices(t *testing.T) (alice, bob *Service) {
+	t.Helper()
+
+	aliceProv := &mockprovider.Provider{
+		StorageProviderValue:          mockstore.NewMockStoreProvider(),
+		TransientStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				_, err := bob.HandleInbound(service.NewDIDCommMsgMap(msg), theirDID, myDID)
+
+				return err
+			},
+		},
+	}
+
+	bobProv := &mockprovider.Provider{
+		StorageProviderValue:          mockstore.NewMockStoreProvider(),
+		TransientStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				_, err := alice.HandleInbound(service.NewDIDCommMsgMap(msg), theirDID, myDID)
+
+				return err
+			},
+		},
+	}
+
+	recorder, err := connection.NewRecorder(aliceProv)
+	require.NoError(t, err)
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: connID,
+		ThreadID:     "thID",
+		State:        "completed",
+		MyDID:        aliceDID,
+		TheirDID:     bobDID,
+	}))
+
+	alice, err = New(aliceProv)
+	require.NoError(t, err)
+
+	bob, err = New(bobProv)
+	require.NoError(t, err)
+
+	return alice, bob
+}
+
+func TestServiceNew(t *testing.T) {
+	t.Run("test error from open transient store", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			TransientStorageProviderValue: &mockstore.MockStoreProvider{
+				ErrOpenStoreHandle: errors.New("failed to open store")},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to open transient store")
+	})
+
+	t.Run("test service accept", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			StorageProviderValue:          mockstore.NewMockStoreProvider(),
+			TransientStorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, TrustPing, svc.Name())
+		require.Equal(t, TrustPingSpec, svc.Spec())
+		require.True(t, svc.Accept(PingMsgType))
+		require.True(t, svc.Accept(PingResponseMsgType))
+		require.False(t, svc.Accept("unsupported msg type"))
+
+		_, err = svc.HandleOutbound(service.NewDIDCommMsgMap(&Ping{Type: PingMsgType}), "", "")
+		require.EqualError(t, err, "not implemented")
+	})
+}
+
+func TestPing(t *testing.T) {
+	t.Run("test ping - success", func(t *testing.T) {
+		alice, _ := newServices(t)
+
+		resp, err := alice.Ping(connID, 0)
+		require.NoError(t, err)
+		require.Equal(t, PingResponseMsgType, resp.Type)
+		require.NotEmpty(t, resp.Thread.ID)
+	})
+
+	t.Run("test ping - connection not found", func(t *testing.T) {
+		alice, _ := newServices(t)
+
+		_, err := alice.Ping("unknown", 0)
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+	})
+
+	t.Run("test ping - send error", func(t *testing.T) {
+		alice, _ := newServices(t)
+		alice.outbound = &mockdispatcher.MockOutbound{SendErr: errors.New("send error")}
+
+		_, err := alice.Ping(connID, 0)
+		require.EqualError(t, err, "send ping : send error")
+	})
+
+	t.Run("test ping - timeout", func(t *testing.T) {
+		alice, _ := newServices(t)
+		alice.outbound = &mockdispatcher.MockOutbound{}
+
+		_, err := alice.Ping(connID, 10*time.Millisecond)
+		require.EqualError(t, err, "timeout waiting for the ping response")
+	})
+}
+
+func TestHandlePing(t *testing.T) {
+	svc, err := New(&mockprovider.Provider{
+		StorageProviderValue:          mockstore.NewMockStoreProvider(),
+		TransientStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue:       &mockdispatcher.MockOutbound{SendErr: errors.New("send error")},
+	})
+	require.NoError(t, err)
+
+	t.Run("test handle ping - response is not requested", func(t *testing.T) {
+		err = svc.handlePing(service.NewDIDCommMsgMap(&Ping{Type: PingMsgType, ID: "id"}), aliceDID, bobDID)
+		require.NoError(t, err)
+	})
+
+	t.Run("test handle ping - send error", func(t *testing.T) {
+		err = svc.handlePing(service.NewDIDCommMsgMap(&Ping{
+			Type:              PingMsgType,
+			ID:                "id",
+			ResponseRequested: true,
+		}), aliceDID, bobDID)
+		require.EqualError(t, err, "send ping response : send error")
+	})
+
+	t.Run("test handle ping response - missing thread", func(t *testing.T) {
+		err = svc.handlePingResponse(service.NewDIDCommMsgMap(&PingResponse{Type: PingResponseMsgType}), bobDID)
+		require.EqualError(t, err, "ping response message thread is missing")
+	})
+
+	t.Run("test handle ping response - duplicate response", func(t *testing.T) {
+		pending := &pendingPing{theirDID: bobDID, responseCh: make(chan *PingResponse, 1)}
+		svc.setPendingPing("thID", pending)
+
+		defer svc.setPendingPing("thID", nil)
+
+		resp := &PingResponse{Type: PingResponseMsgType, ID: "id", Thread: &decorator.Thread{ID: "thID"}}
+
+		// the duplicate response is dropped instead of blocking the handler
+		for i := 0; i < 2; i++ {
+			require.NoError(t, svc.handlePingResponse(service.NewDIDCommMsgMap(resp), bobDID))
+		}
+
+		require.Len(t, pending.responseCh, 1)
+	})
+
+	t.Run("test handle ping response - not sent by the pinged DID", func(t *testing.T) {
+		pending := &pendingPing{theirDID: bobDID, responseCh: make(chan *PingResponse, 1)}
+		svc.setPendingPing("thID", pending)
+
+		defer svc.setPendingPing("thID", nil)
+
+		resp := &PingResponse{Type: PingResponseMsgType, ID: "id", Thread: &decorator.Thread{ID: "thID"}}
+
+		err = svc.handlePingResponse(service.NewDIDCommMsgMap(resp), "did:example:mallory")
+		require.EqualError(t, err, "ping response of thread thID not sent by the pinged DID")
+		require.Empty(t, pending.responseCh)
+	})
+
+	t.Run("test handle ping response - unknown thread", func(t *testing.T) {
+		resp := &PingResponse{Type: PingResponseMsgType, ID: "id", Thread: &decorator.Thread{ID: "unknown"}}
+
+		require.NoError(t, svc.handlePingResponse(service.NewDIDCommMsgMap(resp), bobDID))
+	})
+}
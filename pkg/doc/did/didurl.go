@@ -0,0 +1,112 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	didURLPrefix       = "did:"
+	fragmentSeparator  = "#"
+	querySeparator     = "?"
+	pathSeparator      = "/"
+	didMethodSeparator = ":"
+)
+
+// ErrInvalidDIDURL is returned when the DID URL is not valid
+var ErrInvalidDIDURL = errors.New("invalid DID URL")
+
+// DIDURL is a parsed DID URL (https://w3c.github.io/did-core/#did-url-syntax), the DID with an optional path,
+// query and fragment
+type DIDURL struct {
+	DID      string
+	Path     string
+	Queries  url.Values
+	Fragment string
+}
+
+// ParseDIDURL parses the DID URL into its DID, path, query and fragment
+func ParseDIDURL(didURL string) (*DIDURL, error) {
+	u := &DIDURL{}
+
+	rest := didURL
+
+	if i := strings.Index(rest, fragmentSeparator); i >= 0 {
+		rest, u.Fragment = rest[:i], rest[i+1:]
+	}
+
+	rawQuery := ""
+
+	if i := strings.Index(rest, querySeparator); i >= 0 {
+		rest, rawQuery = rest[:i], rest[i+1:]
+	}
+
+	if i := strings.Index(rest, pathSeparator); i >= 0 {
+		rest, u.Path = rest[:i], rest[i:]
+	}
+
+	parts := strings.SplitN(rest, didMethodSeparator, 3)
+	if !strings.HasPrefix(rest, didURLPrefix) || len(parts) < 3 || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("%w '%s': wrong format DID", ErrInvalidDIDURL, didURL)
+	}
+
+	u.DID = rest
+
+	queries, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("%w '%s': %s", ErrInvalidDIDURL, didURL, err.Error())
+	}
+
+	u.Queries = queries
+
+	return u, nil
+}
+
+// VerificationMethodByFragment returns the public key of the DID document identified by the fragment of
+// a DID URL, among the public keys, the verification methods and the verification methods of the
+// verification relationships.
+func (doc *Doc) VerificationMethodByFragment(fragment string) (*PublicKey, bool) {
+	for _, pks := range [][]PublicKey{doc.PublicKey, doc.VerificationMethod} {
+		for i := range pks {
+			if doc.matchesFragment(pks[i].ID, fragment) {
+				return &pks[i], true
+			}
+		}
+	}
+
+	for _, relationship := range []VerificationRelationship{Authentication, AssertionMethod, KeyAgreement,
+		CapabilityInvocation, CapabilityDelegation} {
+		vms := doc.VerificationMethods(relationship)
+
+		for i := range vms {
+			if doc.matchesFragment(vms[i].PublicKey.ID, fragment) {
+				return &vms[i].PublicKey, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// ServiceByFragment returns the service of the DID document identified by the fragment of a DID URL
+func (doc *Doc) ServiceByFragment(fragment string) (*Service, bool) {
+	for i := range doc.Service {
+		if doc.matchesFragment(doc.Service[i].ID, fragment) {
+			return &doc.Service[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// matchesFragment checks if the id, either absolute or relative to the DID document, has the fragment
+func (doc *Doc) matchesFragment(id, fragment string) bool {
+	return fragment != "" && sameID(doc.ID, id, fragmentSeparator+fragment)
+}
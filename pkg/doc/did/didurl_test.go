@@ -0,0 +1,89 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDIDURL(t *testing.T) {
+	t.Run("test valid DID URLs", func(t *testing.T) {
+		tests := []struct {
+			didURL   string
+			expected DIDURL
+		}{
+			{
+				didURL:   "did:example:123",
+				expected: DIDURL{DID: "did:example:123", Queries: url.Values{}},
+			},
+			{
+				didURL:   "did:example:123#key-1",
+				expected: DIDURL{DID: "did:example:123", Queries: url.Values{}, Fragment: "key-1"},
+			},
+			{
+				didURL: "did:example:123/path/to?service=agent&relativeRef=%2Fmsg#frag",
+				expected: DIDURL{
+					DID:      "did:example:123",
+					Path:     "/path/to",
+					Queries:  url.Values{"service": {"agent"}, "relativeRef": {"/msg"}},
+					Fragment: "frag",
+				},
+			},
+			{
+				didURL:   "did:web:example.com:user?versionId=2",
+				expected: DIDURL{DID: "did:web:example.com:user", Queries: url.Values{"versionId": {"2"}}},
+			},
+		}
+
+		for _, tc := range tests {
+			didURL, err := ParseDIDURL(tc.didURL)
+			require.NoError(t, err, tc.didURL)
+			require.Equal(t, &tc.expected, didURL)
+		}
+	})
+
+	t.Run("test invalid DID URLs", func(t *testing.T) {
+		for _, didURL := range []string{"", "#key-1", "example:123", "did:example", "did::123", "did:example:#key"} {
+			_, err := ParseDIDURL(didURL)
+			require.True(t, errors.Is(err, ErrInvalidDIDURL), didURL)
+		}
+
+		_, err := ParseDIDURL("did:example:123?service=%zz")
+		require.True(t, errors.Is(err, ErrInvalidDIDURL))
+	})
+}
+
+func TestDoc_ByFragment(t *testing.T) {
+	doc := &Doc{
+		ID:             "did:example:123",
+		PublicKey:      []PublicKey{{ID: "did:example:123#key-1"}},
+		Authentication: []VerificationMethod{{PublicKey: PublicKey{ID: "#key-2"}}},
+		KeyAgreement:   []VerificationMethod{{PublicKey: PublicKey{ID: "did:example:123#key-3"}}},
+		Service:        []Service{{ID: "#agent"}},
+	}
+
+	for _, fragment := range []string{"key-1", "key-2", "key-3"} {
+		key, ok := doc.VerificationMethodByFragment(fragment)
+		require.True(t, ok, fragment)
+		require.Contains(t, key.ID, fragment)
+	}
+
+	service, ok := doc.ServiceByFragment("agent")
+	require.True(t, ok)
+	require.Equal(t, "#agent", service.ID)
+
+	for _, fragment := range []string{"", "key-4", "agent"} {
+		_, ok = doc.VerificationMethodByFragment(fragment)
+		require.False(t, ok, fragment)
+	}
+
+	_, ok = doc.ServiceByFragment("key-1")
+	require.False(t, ok)
+}
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcutil/base58"
+	"github.com/multiformats/go-multibase"
 	"github.com/xeipuuv/gojsonschema"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
@@ -22,7 +23,9 @@ import (
 
 const (
 	// Context of the DID document
-	Context             = "https://w3id.org/did/v1"
+	Context = "https://w3id.org/did/v1"
+	// CoreContext of the DID document of the DID Core data model (https://w3c.github.io/did-core)
+	CoreContext         = "https://www.w3.org/ns/did/v1"
 	jsonldType          = "type"
 	jsonldID            = "id"
 	jsonldServicePoint  = "serviceEndpoint"
@@ -31,6 +34,12 @@ const (
 	jsonldPriority      = "priority"
 	jsonldController    = "controller"
 
+	jsonldAuthentication       = "authentication"
+	jsonldAssertionMethod      = "assertionMethod"
+	jsonldKeyAgreement         = "keyAgreement"
+	jsonldCapabilityInvocation = "capabilityInvocation"
+	jsonldCapabilityDelegation = "capabilityDelegation"
+
 	jsonldCreator    = "creator"
 	jsonldCreated    = "created"
 	jsonldProofValue = "proofValue"
@@ -52,7 +61,7 @@ const (
       "items": [
         {
           "type": "string",
-          "pattern": "^https://w3id.org/did/v1$"
+          "pattern": "^(https://w3id.org/did/v1|https://www.w3.org/ns/did/v1)$"
         }
       ],
       "additionalItems": {
@@ -63,25 +72,52 @@ const (
     "id": {
       "type": "string"
     },
+    "controller": {
+      "oneOf": [
+        {
+          "type": "string"
+        },
+        {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      ]
+    },
+    "alsoKnownAs": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      }
+    },
     "publicKey": {
       "type": "array",
       "items": {
         "$ref": "#/definitions/publicKey"
       }
     },
-    "authentication": {
+    "verificationMethod": {
       "type": "array",
       "items": {
-        "oneOf": [
-          {
-            "$ref": "#/definitions/publicKey"
-          },
-          {
-            "type": "string"
-          }
-        ]
+        "$ref": "#/definitions/publicKey"
       }
     },
+    "authentication": {
+      "$ref": "#/definitions/verificationRelationship"
+    },
+    "assertionMethod": {
+      "$ref": "#/definitions/verificationRelationship"
+    },
+    "keyAgreement": {
+      "$ref": "#/definitions/verificationRelationship"
+    },
+    "capabilityInvocation": {
+      "$ref": "#/definitions/verificationRelationship"
+    },
+    "capabilityDelegation": {
+      "$ref": "#/definitions/verificationRelationship"
+    },
     "service": {
       "type": "array",
       "items": {
@@ -102,6 +138,19 @@ const (
     }
   },
   "definitions": {
+    "verificationRelationship": {
+      "type": "array",
+      "items": {
+        "oneOf": [
+          {
+            "$ref": "#/definitions/publicKey"
+          },
+          {
+            "type": "string"
+          }
+        ]
+      }
+    },
 	"proof": {
       "type": "object",
       "required": [ "type", "creator", "created", "proofValue"],
@@ -182,10 +231,28 @@ type Doc struct {
 	PublicKey      []PublicKey
 	Service        []Service
 	Authentication []VerificationMethod
-	Created        *time.Time
-	Updated        *time.Time
-	Proof          []Proof
-}
+	// the DID Core properties are omitted when empty to keep the numeric basis of existing peer DIDs,
+	// computed on the marshaled Doc
+	KeyAgreement         []VerificationMethod `json:",omitempty"`
+	Controller           []string             `json:",omitempty"`
+	AlsoKnownAs          []string             `json:",omitempty"`
+	VerificationMethod   []PublicKey          `json:",omitempty"`
+	AssertionMethod      []VerificationMethod `json:",omitempty"`
+	CapabilityInvocation []VerificationMethod `json:",omitempty"`
+	CapabilityDelegation []VerificationMethod `json:",omitempty"`
+	Created              *time.Time
+	Updated              *time.Time
+	Proof                []Proof
+}
+
+// Encodings of the public key values in the DID document, besides the default publicKeyBase58
+// (https://w3c.github.io/did-spec-registries/#verification-method-properties)
+const (
+	// PublicKeyJwk the public key is a JSON web key, its raw value is decoded from the JWK
+	PublicKeyJwk = "publicKeyJwk"
+	// PublicKeyMultibase the public key value is multibase encoded
+	PublicKeyMultibase = "publicKeyMultibase"
+)
 
 // PublicKey DID doc public key
 type PublicKey struct {
@@ -193,6 +260,10 @@ type PublicKey struct {
 	Type       string
 	Controller string
 	Value      []byte
+	// Encoding of the value in the DID document (PublicKeyJwk or PublicKeyMultibase), publicKeyBase58 if empty
+	Encoding string `json:",omitempty"`
+	// JWK is the JSON web key of the PublicKeyJwk encoded keys
+	JWK json.RawMessage `json:",omitempty"`
 }
 
 // Service DID doc service
@@ -206,20 +277,29 @@ type Service struct {
 	Properties      map[string]interface{}
 }
 
-// VerificationMethod authentication verification method
+// VerificationMethod verification method of a verification relationship (e.g authentication)
 type VerificationMethod struct {
 	PublicKey PublicKey
+	// Referenced is set when the verification method is referenced by its ID instead of embedded
+	Referenced bool `json:",omitempty"`
 }
 
 type rawDoc struct {
-	Context        []string                 `json:"@context,omitempty"`
-	ID             string                   `json:"id,omitempty"`
-	PublicKey      []map[string]interface{} `json:"publicKey,omitempty"`
-	Service        []map[string]interface{} `json:"service,omitempty"`
-	Authentication []interface{}            `json:"authentication,omitempty"`
-	Created        *time.Time               `json:"created,omitempty"`
-	Updated        *time.Time               `json:"updated,omitempty"`
-	Proof          []interface{}            `json:"proof,omitempty"`
+	Context              []string                 `json:"@context,omitempty"`
+	ID                   string                   `json:"id,omitempty"`
+	Controller           interface{}              `json:"controller,omitempty"`
+	AlsoKnownAs          []string                 `json:"alsoKnownAs,omitempty"`
+	PublicKey            []map[string]interface{} `json:"publicKey,omitempty"`
+	VerificationMethod   []map[string]interface{} `json:"verificationMethod,omitempty"`
+	Service              []map[string]interface{} `json:"service,omitempty"`
+	Authentication       []interface{}            `json:"authentication,omitempty"`
+	AssertionMethod      []interface{}            `json:"assertionMethod,omitempty"`
+	KeyAgreement         []interface{}            `json:"keyAgreement,omitempty"`
+	CapabilityInvocation []interface{}            `json:"capabilityInvocation,omitempty"`
+	CapabilityDelegation []interface{}            `json:"capabilityDelegation,omitempty"`
+	Created              *time.Time               `json:"created,omitempty"`
+	Updated              *time.Time               `json:"updated,omitempty"`
+	Proof                []interface{}            `json:"proof,omitempty"`
 }
 
 // Proof is cryptographic proof of the integrity of the DID Document
@@ -246,30 +326,67 @@ func ParseDocument(data []byte) (*Doc, error) {
 		return nil, fmt.Errorf("JSON marshalling of did doc bytes bytes failed: %w", err)
 	}
 
-	publicKeys, err := populatePublicKeys(raw.PublicKey)
+	doc := &Doc{Context: raw.Context,
+		ID:          raw.ID,
+		Controller:  stringOrArray(raw.Controller),
+		AlsoKnownAs: raw.AlsoKnownAs,
+		Service:     populateServices(raw.Service),
+		Created:     raw.Created,
+		Updated:     raw.Updated,
+	}
+
+	doc.PublicKey, err = populatePublicKeys(raw.PublicKey)
 	if err != nil {
 		return nil, fmt.Errorf("populate public keys failed: %w", err)
 	}
 
-	authPKs, err := populateAuthentications(raw.Authentication, publicKeys)
+	doc.VerificationMethod, err = populatePublicKeys(raw.VerificationMethod)
 	if err != nil {
-		return nil, fmt.Errorf("populate authentications failed: %w", err)
+		return nil, fmt.Errorf("populate verification methods failed: %w", err)
+	}
+
+	err = populateVerificationRelationships(doc, raw)
+	if err != nil {
+		return nil, err
 	}
 
-	proofs, err := populateProofs(raw.Proof)
+	doc.Proof, err = populateProofs(raw.Proof)
 	if err != nil {
 		return nil, fmt.Errorf("populate proofs failed: %w", err)
 	}
 
-	return &Doc{Context: raw.Context,
-		ID:             raw.ID,
-		PublicKey:      publicKeys,
-		Service:        populateServices(raw.Service),
-		Authentication: authPKs,
-		Created:        raw.Created,
-		Updated:        raw.Updated,
-		Proof:          proofs,
-	}, nil
+	return doc, nil
+}
+
+// populateVerificationRelationships populates the verification methods of the verification relationships
+// of the DID document
+func populateVerificationRelationships(doc *Doc, raw *rawDoc) error {
+	// the verification methods are referenced among the public keys and the DID Core verification methods
+	pks := append(append([]PublicKey{}, doc.PublicKey...), doc.VerificationMethod...)
+
+	relationships := []struct {
+		name   string
+		raw    []interface{}
+		vms    *[]VerificationMethod
+		plural string
+	}{
+		{jsonldAuthentication, raw.Authentication, &doc.Authentication, "authentications"},
+		{jsonldAssertionMethod, raw.AssertionMethod, &doc.AssertionMethod, "assertion methods"},
+		{jsonldKeyAgreement, raw.KeyAgreement, &doc.KeyAgreement, "key agreements"},
+		{jsonldCapabilityInvocation, raw.CapabilityInvocation, &doc.CapabilityInvocation, "capability invocations"},
+		{jsonldCapabilityDelegation, raw.CapabilityDelegation, &doc.CapabilityDelegation, "capability delegations"},
+	}
+
+	for _, r := range relationships {
+		vms, err := populateVerificationMethods(r.name, r.raw, doc.ID, pks)
+		if err != nil {
+			return fmt.Errorf("populate %s failed: %w", r.plural, err)
+		}
+
+		*r.vms = vms
+	}
+
+	return nil
 }
 
 func populateProofs(rawProofs []interface{}) ([]Proof, error) {
@@ -338,17 +455,20 @@ func populateServices(rawServices []map[string]interface{}) []Service {
 	return services
 }
 
-func populateAuthentications(rawAuthentications []interface{}, pks []PublicKey) ([]VerificationMethod, error) {
+// populateVerificationMethods populates the verification methods of the relationship (e.g authentication),
+// referenced by their ID, absolute or relative to the DID didID, in the public keys pks or embedded
+func populateVerificationMethods(relationship string, rawVMs []interface{}, didID string,
+	pks []PublicKey) ([]VerificationMethod, error) {
 	var vms []VerificationMethod
 
-	for _, rawAuthentication := range rawAuthentications {
-		valueString, ok := rawAuthentication.(string)
+	for _, rawVM := range rawVMs {
+		valueString, ok := rawVM.(string)
 		if ok {
 			keyExist := false
 
 			for _, pk := range pks {
-				if pk.ID == valueString {
-					vms = append(vms, VerificationMethod{pk})
+				if sameID(didID, pk.ID, valueString) {
+					vms = append(vms, VerificationMethod{PublicKey: pk, Referenced: true})
 					keyExist = true
 
 					break
@@ -356,15 +476,15 @@ func populateAuthentications(rawAuthentications []interface{}, pks []PublicKey)
 			}
 
 			if !keyExist {
-				return nil, fmt.Errorf("authentication key %s not exist in did doc public key", valueString)
+				return nil, fmt.Errorf("%s key %s not exist in did doc public key", relationship, valueString)
 			}
 
 			continue
 		}
 
-		valuePK, ok := rawAuthentication.(map[string]interface{})
+		valuePK, ok := rawVM.(map[string]interface{})
 		if !ok {
-			return nil, errors.New("rawAuthentication is not map[string]interface{}")
+			return nil, fmt.Errorf("raw %s is not map[string]interface{}", relationship)
 		}
 
 		pk, err := populatePublicKeys([]map[string]interface{}{valuePK})
@@ -372,7 +492,7 @@ func populateAuthentications(rawAuthentications []interface{}, pks []PublicKey)
 			return nil, err
 		}
 
-		vms = append(vms, VerificationMethod{pk[0]})
+		vms = append(vms, VerificationMethod{PublicKey: pk[0]})
 	}
 
 	return vms, nil
@@ -382,13 +502,37 @@ func populatePublicKeys(rawPKs []map[string]interface{}) ([]PublicKey, error) {
 	var publicKeys []PublicKey
 
 	for _, rawPK := range rawPKs {
-		decodeValue, err := decodePK(rawPK)
+		publicKey := PublicKey{ID: stringEntry(rawPK[jsonldID]), Type: stringEntry(rawPK[jsonldType]),
+			Controller: stringEntry(rawPK[jsonldController])}
+
+		var err error
+
+		switch {
+		case rawPK[PublicKeyJwk] != nil:
+			publicKey.Encoding = PublicKeyJwk
+
+			publicKey.JWK, err = json.Marshal(rawPK[PublicKeyJwk])
+			if err != nil {
+				return nil, fmt.Errorf("marshal public key JWK failed: %w", err)
+			}
+
+			publicKey.Value, err = decodeJWK(publicKey.JWK)
+		case stringEntry(rawPK[PublicKeyMultibase]) != "":
+			publicKey.Encoding = PublicKeyMultibase
+
+			_, publicKey.Value, err = multibase.Decode(stringEntry(rawPK[PublicKeyMultibase]))
+			if err != nil {
+				err = fmt.Errorf("decode public key multibase failed: %w", err)
+			}
+		default:
+			publicKey.Value, err = decodePK(rawPK)
+		}
+
 		if err != nil {
 			return nil, err
 		}
 
-		publicKeys = append(publicKeys, PublicKey{ID: stringEntry(rawPK[jsonldID]), Type: stringEntry(rawPK[jsonldType]),
-			Controller: stringEntry(rawPK[jsonldController]), Value: decodeValue})
+		publicKeys = append(publicKeys, publicKey)
 	}
 
 	return publicKeys, nil
@@ -460,6 +604,15 @@ func uintEntry(entry interface{}) uint {
 	return uint(entry.(float64))
 }
 
+// stringOrArray
+func stringOrArray(entry interface{}) []string {
+	if s, ok := entry.(string); ok {
+		return []string{s}
+	}
+
+	return stringArray(entry)
+}
+
 // stringArray
 func stringArray(entry interface{}) []string {
 	if entry == nil {
@@ -485,14 +638,21 @@ func stringArray(entry interface{}) []string {
 // JSONBytes converts document to json bytes
 func (doc *Doc) JSONBytes() ([]byte, error) {
 	raw := &rawDoc{
-		Context:        doc.Context,
-		ID:             doc.ID,
-		PublicKey:      populateRawPublicKeys(doc.PublicKey),
-		Authentication: populateRawAuthentications(doc.Authentication),
-		Service:        populateRawServices(doc.Service),
-		Created:        doc.Created,
-		Proof:          populateRawProofs(doc.Proof),
-		Updated:        doc.Updated,
+		Context:              doc.Context,
+		ID:                   doc.ID,
+		Controller:           populateRawController(doc.Controller),
+		AlsoKnownAs:          doc.AlsoKnownAs,
+		PublicKey:            populateRawPublicKeys(doc.PublicKey),
+		VerificationMethod:   populateRawPublicKeys(doc.VerificationMethod),
+		Authentication:       populateRawVerificationMethods(doc.Authentication),
+		AssertionMethod:      populateRawVerificationMethods(doc.AssertionMethod),
+		KeyAgreement:         populateRawVerificationMethods(doc.KeyAgreement),
+		CapabilityInvocation: populateRawVerificationMethods(doc.CapabilityInvocation),
+		CapabilityDelegation: populateRawVerificationMethods(doc.CapabilityDelegation),
+		Service:              populateRawServices(doc.Service),
+		Created:              doc.Created,
+		Proof:                populateRawProofs(doc.Proof),
+		Updated:              doc.Updated,
 	}
 
 	byteDoc, err := json.Marshal(raw)
@@ -530,7 +690,7 @@ func (doc *Doc) VerifyProof(suite signatureSuite) error {
 		return err
 	}
 
-	v := verifier.New(&didKeyResolver{doc.PublicKey}, suite)
+	v := verifier.New(&didKeyResolver{doc}, suite)
 
 	return v.Verify(docBytes)
 }
@@ -538,19 +698,24 @@ func (doc *Doc) VerifyProof(suite signatureSuite) error {
 // ErrProofNotFound is returned when proof is not found
 var ErrProofNotFound = errors.New("proof not found")
 
-// didKeyResolver implements public key resolution for DID public keys
+// didKeyResolver implements public key resolution for DID public keys, dereferencing the DID URL of the key
+// against the DID document
 type didKeyResolver struct {
-	PubKeys []PublicKey
+	doc *Doc
 }
 
 func (r *didKeyResolver) Resolve(id string) ([]byte, error) {
-	for _, key := range r.PubKeys {
-		if key.ID == id {
-			return key.Value, nil
-		}
+	didURL, err := ParseDIDURL(id)
+	if err != nil || didURL.DID != r.doc.ID {
+		return nil, ErrKeyNotFound
 	}
 
-	return nil, ErrKeyNotFound
+	key, ok := r.doc.VerificationMethodByFragment(didURL.Fragment)
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	return key.Value, nil
 }
 
 // ErrKeyNotFound is returned when key is not found
@@ -594,21 +759,45 @@ func populateRawPublicKey(pk PublicKey) map[string]interface{} {
 	rawPK[jsonldType] = pk.Type
 	rawPK[jsonldController] = pk.Controller
 
-	if pk.Value != nil {
+	switch {
+	case pk.Encoding == PublicKeyJwk:
+		rawPK[PublicKeyJwk] = pk.JWK
+	case pk.Encoding == PublicKeyMultibase && pk.Value != nil:
+		// the base58 encoding can't fail
+		rawPK[PublicKeyMultibase], _ = multibase.Encode(multibase.Base58BTC, pk.Value) // nolint:errcheck
+	case pk.Value != nil:
 		rawPK[jsonldPublicKeyBase58] = base58.Encode(pk.Value)
 	}
 
 	return rawPK
 }
 
-func populateRawAuthentications(vms []VerificationMethod) []interface{} {
-	var rawAuthentications []interface{}
+func populateRawVerificationMethods(vms []VerificationMethod) []interface{} {
+	var rawVMs []interface{}
 
 	for _, vm := range vms {
-		rawAuthentications = append(rawAuthentications, populateRawPublicKey(vm.PublicKey))
+		if vm.Referenced {
+			rawVMs = append(rawVMs, vm.PublicKey.ID)
+
+			continue
+		}
+
+		rawVMs = append(rawVMs, populateRawPublicKey(vm.PublicKey))
 	}
 
-	return rawAuthentications
+	return rawVMs
+}
+
+// populateRawController populates the controller, a single controller is a string
+func populateRawController(controller []string) interface{} {
+	switch len(controller) {
+	case 0:
+		return nil
+	case 1:
+		return controller[0]
+	default:
+		return controller
+	}
 }
 
 func populateRawProofs(proofs []Proof) []interface{} {
@@ -644,6 +833,55 @@ func WithAuthentication(auth []VerificationMethod) DocOption {
 	}
 }
 
+// WithKeyAgreement DID doc KeyAgreement.
+func WithKeyAgreement(keyAgreement []VerificationMethod) DocOption {
+	return func(opts *Doc) {
+		opts.KeyAgreement = keyAgreement
+	}
+}
+
+// WithVerificationMethod DID doc VerificationMethod.
+func WithVerificationMethod(verificationMethod []PublicKey) DocOption {
+	return func(opts *Doc) {
+		opts.VerificationMethod = verificationMethod
+	}
+}
+
+// WithAssertionMethod DID doc AssertionMethod.
+func WithAssertionMethod(assertionMethod []VerificationMethod) DocOption {
+	return func(opts *Doc) {
+		opts.AssertionMethod = assertionMethod
+	}
+}
+
+// WithCapabilityInvocation DID doc CapabilityInvocation.
+func WithCapabilityInvocation(capabilityInvocation []VerificationMethod) DocOption {
+	return func(opts *Doc) {
+		opts.CapabilityInvocation = capabilityInvocation
+	}
+}
+
+// WithCapabilityDelegation DID doc CapabilityDelegation.
+func WithCapabilityDelegation(capabilityDelegation []VerificationMethod) DocOption {
+	return func(opts *Doc) {
+		opts.CapabilityDelegation = capabilityDelegation
+	}
+}
+
+// WithController DID doc controllers.
+func WithController(controller []string) DocOption {
+	return func(opts *Doc) {
+		opts.Controller = controller
+	}
+}
+
+// WithAlsoKnownAs DID doc alsoKnownAs identifiers.
+func WithAlsoKnownAs(alsoKnownAs []string) DocOption {
+	return func(opts *Doc) {
+		opts.AlsoKnownAs = alsoKnownAs
+	}
+}
+
 // WithService DID doc services.
 func WithService(svc []Service) DocOption {
 	return func(opts *Doc) {
@@ -108,7 +108,8 @@ func TestValid(t *testing.T) {
 			ID:         "did:example:123456789abcdefghi#keys-1",
 			Controller: "did:example:123456789abcdefghi",
 			Type:       "Secp256k1VerificationKey2018",
-			Value:      base58.Decode("H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV")}},
+			Value:      base58.Decode("H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV")},
+			Referenced: true},
 		{PublicKey: PublicKey{
 			ID:         "did:example:123456789abcdefghs#key3",
 			Controller: "did:example:123456789abcdefghs",
@@ -225,6 +226,53 @@ func TestPopulateAuthentications(t *testing.T) {
 	})
 }
 
+func TestKeyAgreement(t *testing.T) {
+	pk := PublicKey{
+		ID:         "did:example:123#key-agreement-1",
+		Type:       "X25519KeyAgreementKey2019",
+		Controller: "did:example:123",
+		Value:      []byte("key agreement key"),
+	}
+
+	t.Run("test embedded key agreement", func(t *testing.T) {
+		doc := BuildDoc(WithKeyAgreement([]VerificationMethod{{PublicKey: pk}}))
+		doc.ID = "did:example:123"
+
+		bytes, err := doc.JSONBytes()
+		require.NoError(t, err)
+		require.Contains(t, string(bytes), `"keyAgreement"`)
+
+		parsed, err := ParseDocument(bytes)
+		require.NoError(t, err)
+		require.Equal(t, []VerificationMethod{{PublicKey: pk}}, parsed.KeyAgreement)
+	})
+
+	t.Run("test key agreement referenced by ID", func(t *testing.T) {
+		raw := &rawDoc{}
+		require.NoError(t, json.Unmarshal([]byte(validDoc), &raw))
+		raw.KeyAgreement = []interface{}{raw.PublicKey[0][jsonldID]}
+		bytes, err := json.Marshal(raw)
+		require.NoError(t, err)
+
+		doc, err := ParseDocument(bytes)
+		require.NoError(t, err)
+		require.Equal(t, []VerificationMethod{{PublicKey: doc.PublicKey[0], Referenced: true}}, doc.KeyAgreement)
+	})
+
+	t.Run("test key not exist", func(t *testing.T) {
+		raw := &rawDoc{}
+		require.NoError(t, json.Unmarshal([]byte(validDoc), &raw))
+		raw.KeyAgreement = []interface{}{"did:example:123456789abcdefghs#key4"}
+		bytes, err := json.Marshal(raw)
+		require.NoError(t, err)
+		_, err = ParseDocument(bytes)
+		require.Error(t, err)
+
+		expected := "keyAgreement key did:example:123456789abcdefghs#key4 not exist in did doc public key"
+		require.Contains(t, err.Error(), expected)
+	})
+}
+
 func TestPublicKeys(t *testing.T) {
 	t.Run("test failed to decode PEM block", func(t *testing.T) {
 		raw := &rawDoc{}
@@ -241,13 +289,190 @@ func TestPublicKeys(t *testing.T) {
 		raw := &rawDoc{}
 		require.NoError(t, json.Unmarshal([]byte(validDoc), &raw))
 		delete(raw.PublicKey[1], jsonldPublicKeyPem)
-		raw.PublicKey[1]["publicKeyMultibase"] = "wrongData"
+		raw.PublicKey[1]["publicKeyUnknown"] = "wrongData"
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
 		_, err = ParseDocument(bytes)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "public key encoding not supported")
 	})
+
+	t.Run("test public key multibase decode failed", func(t *testing.T) {
+		raw := &rawDoc{}
+		require.NoError(t, json.Unmarshal([]byte(validDoc), &raw))
+		delete(raw.PublicKey[1], jsonldPublicKeyPem)
+		raw.PublicKey[1][PublicKeyMultibase] = "wrongData"
+		bytes, err := json.Marshal(raw)
+		require.NoError(t, err)
+		_, err = ParseDocument(bytes)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode public key multibase failed")
+	})
+}
+
+const coreDoc = `{
+  "@context": ["https://www.w3.org/ns/did/v1"],
+  "id": "did:example:123",
+  "controller": "did:example:456",
+  "alsoKnownAs": ["https://example.com/user"],
+  "verificationMethod": [
+    {
+      "id": "did:example:123#key-1",
+      "type": "Ed25519VerificationKey2018",
+      "controller": "did:example:123",
+      "publicKeyBase58": "H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"
+    },
+    {
+      "id": "#key-2",
+      "type": "JsonWebKey2020",
+      "controller": "did:example:123",
+      "publicKeyJwk": {"kty": "OKP", "crv": "Ed25519", "x": "7kqc5NnojHJHZ11Ec5cGCLMIKgJVDBKhrAbu9YrfVFg"}
+    },
+    {
+      "id": "did:example:123#key-3",
+      "type": "X25519KeyAgreementKey2019",
+      "controller": "did:example:123",
+      "publicKeyMultibase": "zH3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"
+    }
+  ],
+  "authentication": ["did:example:123#key-1"],
+  "assertionMethod": ["did:example:123#key-1", "#key-2"],
+  "keyAgreement": ["did:example:123#key-3"],
+  "capabilityInvocation": [
+    {
+      "id": "did:example:123#key-4",
+      "type": "Ed25519VerificationKey2018",
+      "controller": "did:example:123",
+      "publicKeyBase58": "H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"
+    }
+  ],
+  "capabilityDelegation": ["did:example:123#key-1"]
+}`
+
+func TestParseDocument_DIDCore(t *testing.T) {
+	doc, err := ParseDocument([]byte(coreDoc))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{CoreContext}, doc.Context)
+	require.Equal(t, []string{"did:example:456"}, doc.Controller)
+	require.Equal(t, []string{"https://example.com/user"}, doc.AlsoKnownAs)
+	require.Empty(t, doc.PublicKey)
+	require.Len(t, doc.VerificationMethod, 3)
+
+	ed25519Value := base58.Decode("H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV")
+	require.Equal(t, ed25519Value, doc.VerificationMethod[0].Value)
+	require.Empty(t, doc.VerificationMethod[0].Encoding)
+
+	jwkKey := doc.VerificationMethod[1]
+	require.Equal(t, PublicKeyJwk, jwkKey.Encoding)
+	require.Equal(t, ed25519Value, jwkKey.Value)
+	require.JSONEq(t, `{"kty":"OKP","crv":"Ed25519","x":"7kqc5NnojHJHZ11Ec5cGCLMIKgJVDBKhrAbu9YrfVFg"}`,
+		string(jwkKey.JWK))
+
+	require.Equal(t, PublicKeyMultibase, doc.VerificationMethod[2].Encoding)
+	require.Equal(t, ed25519Value, doc.VerificationMethod[2].Value)
+
+	// the verification relationships reference the verification methods or embed them
+	require.Equal(t, []VerificationMethod{{PublicKey: doc.VerificationMethod[0], Referenced: true}},
+		doc.Authentication)
+	require.Equal(t, []VerificationMethod{
+		{PublicKey: doc.VerificationMethod[0], Referenced: true},
+		{PublicKey: doc.VerificationMethod[1], Referenced: true},
+	}, doc.AssertionMethod)
+	require.Equal(t, []VerificationMethod{{PublicKey: doc.VerificationMethod[2], Referenced: true}}, doc.KeyAgreement)
+	require.Len(t, doc.CapabilityInvocation, 1)
+	require.False(t, doc.CapabilityInvocation[0].Referenced)
+	require.Equal(t, doc.Authentication, doc.CapabilityDelegation)
+
+	key, ok := doc.LookupVerificationMethod("#key-2", AssertionMethod)
+	require.True(t, ok)
+	require.Equal(t, "#key-2", key.ID)
+
+	_, ok = doc.LookupVerificationMethod("#key-2", Authentication)
+	require.False(t, ok)
+
+	key, ok = doc.VerificationMethodByFragment("key-4")
+	require.True(t, ok)
+	require.Equal(t, "did:example:123#key-4", key.ID)
+
+	t.Run("test round trip", func(t *testing.T) {
+		bytes, err := doc.JSONBytes()
+		require.NoError(t, err)
+		require.JSONEq(t, coreDoc, string(bytes))
+
+		parsed, err := ParseDocument(bytes)
+		require.NoError(t, err)
+		require.Equal(t, doc, parsed)
+	})
+
+	t.Run("test references relative to the DID", func(t *testing.T) {
+		raw := &rawDoc{}
+		require.NoError(t, json.Unmarshal([]byte(coreDoc), &raw))
+		raw.Authentication = []interface{}{"#key-1", "did:example:123#key-2"}
+		bytes, err := json.Marshal(raw)
+		require.NoError(t, err)
+
+		parsed, err := ParseDocument(bytes)
+		require.NoError(t, err)
+		require.Equal(t, []VerificationMethod{
+			{PublicKey: doc.VerificationMethod[0], Referenced: true},
+			{PublicKey: doc.VerificationMethod[1], Referenced: true},
+		}, parsed.Authentication)
+	})
+
+	t.Run("test several controllers", func(t *testing.T) {
+		raw := &rawDoc{}
+		require.NoError(t, json.Unmarshal([]byte(coreDoc), &raw))
+		raw.Controller = []string{"did:example:456", "did:example:789"}
+		bytes, err := json.Marshal(raw)
+		require.NoError(t, err)
+
+		parsed, err := ParseDocument(bytes)
+		require.NoError(t, err)
+		require.Equal(t, []string{"did:example:456", "did:example:789"}, parsed.Controller)
+
+		bytes, err = parsed.JSONBytes()
+		require.NoError(t, err)
+		require.Contains(t, string(bytes), `"controller":["did:example:456","did:example:789"]`)
+	})
+
+	t.Run("test verification method errors", func(t *testing.T) {
+		tests := []struct {
+			update func(raw *rawDoc)
+			err    string
+		}{
+			{
+				update: func(raw *rawDoc) {
+					raw.VerificationMethod[1][PublicKeyJwk] = map[string]interface{}{"kty": "oct"}
+				},
+				err: "populate verification methods failed: public key JWK key type 'oct' not supported",
+			},
+			{
+				update: func(raw *rawDoc) { raw.AssertionMethod = []interface{}{"#key-5"} },
+				err:    "populate assertion methods failed: assertionMethod key #key-5 not exist",
+			},
+			{
+				update: func(raw *rawDoc) { raw.CapabilityInvocation = []interface{}{"#key-5"} },
+				err:    "populate capability invocations failed",
+			},
+			{
+				update: func(raw *rawDoc) { raw.CapabilityDelegation = []interface{}{"#key-5"} },
+				err:    "populate capability delegations failed",
+			},
+		}
+
+		for _, tc := range tests {
+			raw := &rawDoc{}
+			require.NoError(t, json.Unmarshal([]byte(coreDoc), &raw))
+			tc.update(raw)
+			bytes, err := json.Marshal(raw)
+			require.NoError(t, err)
+
+			_, err = ParseDocument(bytes)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		}
+	})
 }
 
 func TestParseDocument(t *testing.T) {
@@ -277,7 +502,8 @@ func TestValidateDidDocContext(t *testing.T) {
 		require.NoError(t, err)
 		err = validate(bytes)
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "Does not match pattern '^https://w3id.org/did/v1$'")
+		require.Contains(t, err.Error(),
+			"Does not match pattern '^(https://w3id.org/did/v1|https://www.w3.org/ns/did/v1)$'")
 	})
 }
 
@@ -648,22 +874,29 @@ func TestVerifyProof(t *testing.T) {
 
 func TestDidKeyResolver_Resolve(t *testing.T) {
 	// error - key not found
-	keyResolver := didKeyResolver{}
-	key, err := keyResolver.Resolve("id")
+	keyResolver := didKeyResolver{doc: &Doc{ID: "did:example:123"}}
+	key, err := keyResolver.Resolve("did:example:123#key-1")
 	require.Equal(t, ErrKeyNotFound, err)
 	require.Nil(t, key)
 
 	testKeyVal := []byte("pub key")
 	pubKeys := []PublicKey{{
-		ID:    "id",
+		ID:    "#key-1",
 		Value: testKeyVal,
 	}}
 
 	// happy path - key found
-	keyResolver = didKeyResolver{PubKeys: pubKeys}
-	key, err = keyResolver.Resolve("id")
+	keyResolver = didKeyResolver{doc: &Doc{ID: "did:example:123", PublicKey: pubKeys}}
+	key, err = keyResolver.Resolve("did:example:123#key-1")
 	require.NoError(t, err)
 	require.Equal(t, testKeyVal, key)
+
+	// error - key of another DID or invalid key ID
+	for _, id := range []string{"did:example:456#key-1", "key-1"} {
+		key, err = keyResolver.Resolve(id)
+		require.Equal(t, ErrKeyNotFound, err)
+		require.Nil(t, key)
+	}
 }
 
 func TestBuildDoc(t *testing.T) {
@@ -671,6 +904,16 @@ func TestBuildDoc(t *testing.T) {
 	doc := BuildDoc(WithPublicKey([]PublicKey{{}}), WithService([]Service{{}, {}}),
 		WithAuthentication([]VerificationMethod{{}}), WithCreatedTime(ti), WithUpdatedTime(ti))
 	require.NotEmpty(t, doc)
+
+	coreDoc := BuildDoc(WithVerificationMethod([]PublicKey{{}}), WithAssertionMethod([]VerificationMethod{{}}),
+		WithCapabilityInvocation([]VerificationMethod{{}}), WithCapabilityDelegation([]VerificationMethod{{}}),
+		WithController([]string{"did:example:456"}), WithAlsoKnownAs([]string{"https://example.com"}))
+	require.Len(t, coreDoc.VerificationMethod, 1)
+	require.Len(t, coreDoc.AssertionMethod, 1)
+	require.Len(t, coreDoc.CapabilityInvocation, 1)
+	require.Len(t, coreDoc.CapabilityDelegation, 1)
+	require.Equal(t, []string{"did:example:456"}, coreDoc.Controller)
+	require.Equal(t, []string{"https://example.com"}, coreDoc.AlsoKnownAs)
 	require.Equal(t, 1, len(doc.PublicKey))
 	require.Equal(t, 2, len(doc.Service))
 	require.Equal(t, 1, len(doc.Authentication))
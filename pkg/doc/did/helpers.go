@@ -30,7 +30,8 @@ func LookupService(didDoc *Doc, serviceType string) (*Service, bool) {
 }
 
 // LookupRecipientKeys gets the recipient keys from the did doc which match the given parameters.
-func LookupRecipientKeys(didDoc *Doc, serviceType, keyType string) ([]string, bool) {
+// The recipient keys of any of the keyTypes are returned.
+func LookupRecipientKeys(didDoc *Doc, serviceType string, keyTypes ...string) ([]string, bool) {
 	didCommService, ok := LookupService(didDoc, serviceType)
 	if !ok {
 		return nil, false
@@ -48,7 +49,7 @@ func LookupRecipientKeys(didDoc *Doc, serviceType, keyType string) ([]string, bo
 			return nil, false
 		}
 
-		if key.Type == keyType {
+		if containsKeyType(keyTypes, key.Type) {
 			// TODO fix hardcode base58 https://github.com/hyperledger/aries-framework-go/issues/1207
 			recipientKeys = append(recipientKeys, base58.Encode(key.Value))
 		}
@@ -71,3 +72,13 @@ func LookupPublicKey(id string, didDoc *Doc) (*PublicKey, bool) {
 
 	return nil, false
 }
+
+func containsKeyType(keyTypes []string, keyType string) bool {
+	for _, t := range keyTypes {
+		if t == keyType {
+			return true
+		}
+	}
+
+	return false
+}
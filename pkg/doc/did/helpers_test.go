@@ -26,6 +26,21 @@ func TestGetRecipientKeys(t *testing.T) {
 		require.Equal(t, 1, len(recipientKeys))
 	})
 
+	t.Run("successfully getting recipient keys of several key types", func(t *testing.T) {
+		didDoc := mockdiddoc.GetMockDIDDoc()
+		didDoc.PublicKey[0].Type = "EcdsaSecp256r1VerificationKey2019"
+		didDoc.Service[0].RecipientKeys = append(didDoc.Service[0].RecipientKeys, didDoc.PublicKey[0].ID)
+
+		recipientKeys, ok := LookupRecipientKeys(didDoc, didCommServiceType, ed25519KeyType)
+		require.True(t, ok)
+		require.Equal(t, 1, len(recipientKeys))
+
+		recipientKeys, ok = LookupRecipientKeys(didDoc, didCommServiceType, ed25519KeyType,
+			"EcdsaSecp256r1VerificationKey2019")
+		require.True(t, ok)
+		require.Equal(t, 2, len(recipientKeys))
+	})
+
 	t.Run("error due to missing did-communication service", func(t *testing.T) {
 		didDoc := mockdiddoc.GetMockDIDDoc()
 		didDoc.Service = nil
@@ -0,0 +1,144 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// VerificationRelationship is a relationship between the DID subject and verification methods
+// (https://w3c.github.io/did-core/#verification-relationships)
+type VerificationRelationship int
+
+const (
+	// Authentication verification relationship, to authenticate as the DID subject
+	Authentication VerificationRelationship = iota
+	// AssertionMethod verification relationship, to issue verifiable credentials
+	AssertionMethod
+	// KeyAgreement verification relationship, to encrypt messages to the DID subject
+	KeyAgreement
+	// CapabilityInvocation verification relationship, to invoke cryptographic capabilities
+	CapabilityInvocation
+	// CapabilityDelegation verification relationship, to delegate cryptographic capabilities
+	CapabilityDelegation
+)
+
+// VerificationMethods returns the verification methods of the DID document for the relationship
+func (doc *Doc) VerificationMethods(relationship VerificationRelationship) []VerificationMethod {
+	switch relationship {
+	case Authentication:
+		return doc.Authentication
+	case AssertionMethod:
+		return doc.AssertionMethod
+	case KeyAgreement:
+		return doc.KeyAgreement
+	case CapabilityInvocation:
+		return doc.CapabilityInvocation
+	case CapabilityDelegation:
+		return doc.CapabilityDelegation
+	default:
+		return nil
+	}
+}
+
+// LookupVerificationMethod returns the public key with the id, absolute or relative to the DID document,
+// among the verification methods of the relationship
+func (doc *Doc) LookupVerificationMethod(id string, relationship VerificationRelationship) (*PublicKey, bool) {
+	vms := doc.VerificationMethods(relationship)
+
+	for i := range vms {
+		if sameID(doc.ID, vms[i].PublicKey.ID, id) {
+			return &vms[i].PublicKey, true
+		}
+	}
+
+	return nil, false
+}
+
+// sameID checks if the IDs are the same, one of them being possibly relative to the DID didID
+func sameID(didID, id, other string) bool {
+	return absoluteID(didID, id) == absoluteID(didID, other)
+}
+
+func absoluteID(didID, id string) string {
+	if strings.HasPrefix(id, fragmentSeparator) {
+		return didID + id
+	}
+
+	return id
+}
+
+// jwk is the public key part of a JSON web key
+type jwk struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// decodeJWK decodes the raw public key of the JSON web key, the x coordinate of the OKP keys, the
+// uncompressed point of the EC keys and the PKIX DER encoding of the RSA keys (as publicKeyPem)
+func decodeJWK(rawJWK []byte) ([]byte, error) {
+	key := &jwk{}
+
+	err := json.Unmarshal(rawJWK, key)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal public key JWK failed: %w", err)
+	}
+
+	x, err := base64.RawURLEncoding.DecodeString(key.X)
+	if err != nil {
+		return nil, fmt.Errorf("decode public key JWK x failed: %w", err)
+	}
+
+	switch key.Kty {
+	case "OKP":
+		return x, nil
+	case "EC":
+		y, err := base64.RawURLEncoding.DecodeString(key.Y)
+		if err != nil {
+			return nil, fmt.Errorf("decode public key JWK y failed: %w", err)
+		}
+
+		const uncompressedPoint = 0x04
+
+		return append(append([]byte{uncompressedPoint}, x...), y...), nil
+	case "RSA":
+		return decodeRSAJWK(key)
+	default:
+		return nil, fmt.Errorf("public key JWK key type '%s' not supported", key.Kty)
+	}
+}
+
+func decodeRSAJWK(key *jwk) ([]byte, error) {
+	n, err := base64.RawURLEncoding.DecodeString(key.N)
+	if err != nil {
+		return nil, fmt.Errorf("decode public key JWK n failed: %w", err)
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(key.E)
+	if err != nil {
+		return nil, fmt.Errorf("decode public key JWK e failed: %w", err)
+	}
+
+	value, err := x509.MarshalPKIXPublicKey(&rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal public key JWK failed: %w", err)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoc_VerificationMethods(t *testing.T) {
+	doc := &Doc{
+		ID:                   "did:example:123",
+		Authentication:       []VerificationMethod{{PublicKey: PublicKey{ID: "#auth"}}},
+		AssertionMethod:      []VerificationMethod{{PublicKey: PublicKey{ID: "did:example:123#assert"}}},
+		KeyAgreement:         []VerificationMethod{{PublicKey: PublicKey{ID: "#agree"}}},
+		CapabilityInvocation: []VerificationMethod{{PublicKey: PublicKey{ID: "#invoke"}}},
+		CapabilityDelegation: []VerificationMethod{{PublicKey: PublicKey{ID: "#delegate"}}},
+	}
+
+	tests := []struct {
+		relationship VerificationRelationship
+		id           string
+	}{
+		{Authentication, "did:example:123#auth"},
+		{AssertionMethod, "#assert"},
+		{KeyAgreement, "#agree"},
+		{CapabilityInvocation, "did:example:123#invoke"},
+		{CapabilityDelegation, "#delegate"},
+	}
+
+	for _, tc := range tests {
+		require.Len(t, doc.VerificationMethods(tc.relationship), 1)
+
+		key, ok := doc.LookupVerificationMethod(tc.id, tc.relationship)
+		require.True(t, ok, tc.id)
+		require.Equal(t, doc.VerificationMethods(tc.relationship)[0].PublicKey, *key)
+
+		// the verification method is not used for the other relationships
+		_, ok = doc.LookupVerificationMethod(tc.id, (tc.relationship+1)%(CapabilityDelegation+1))
+		require.False(t, ok, tc.id)
+	}
+
+	require.Nil(t, doc.VerificationMethods(VerificationRelationship(-1)))
+}
+
+func TestDecodeJWK(t *testing.T) {
+	t.Run("test OKP key", func(t *testing.T) {
+		value, err := decodeJWK([]byte(`{"kty":"OKP","crv":"Ed25519","x":"AQID"}`))
+		require.NoError(t, err)
+		require.Equal(t, []byte{1, 2, 3}, value)
+	})
+
+	t.Run("test EC key", func(t *testing.T) {
+		value, err := decodeJWK([]byte(`{"kty":"EC","crv":"P-256","x":"AQI","y":"AwQ"}`))
+		require.NoError(t, err)
+		require.Equal(t, []byte{4, 1, 2, 3, 4}, value)
+	})
+
+	t.Run("test RSA key", func(t *testing.T) {
+		privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		n := base64.RawURLEncoding.EncodeToString(privKey.N.Bytes())
+		value, err := decodeJWK([]byte(`{"kty":"RSA","n":"` + n + `","e":"AQAB"}`))
+		require.NoError(t, err)
+
+		pubKey, err := x509.ParsePKIXPublicKey(value)
+		require.NoError(t, err)
+		require.Equal(t, &privKey.PublicKey, pubKey)
+	})
+
+	t.Run("test errors", func(t *testing.T) {
+		tests := []struct {
+			jwk string
+			err string
+		}{
+			{`[]`, "unmarshal public key JWK failed"},
+			{`{"kty":"OKP","x":"!"}`, "decode public key JWK x failed"},
+			{`{"kty":"EC","x":"AQI","y":"!"}`, "decode public key JWK y failed"},
+			{`{"kty":"RSA","n":"!","e":"AQAB"}`, "decode public key JWK n failed"},
+			{`{"kty":"RSA","n":"AQI","e":"!"}`, "decode public key JWK e failed"},
+			{`{"kty":"oct","k":"AQI"}`, "public key JWK key type 'oct' not supported"},
+		}
+
+		for _, tc := range tests {
+			_, err := decodeJWK([]byte(tc.jwk))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		}
+	})
+}
@@ -0,0 +1,37 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbsblssignature2020
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+)
+
+// Signer signs with a BBS+ BLS12-381 private key
+type Signer struct {
+	privateKey *bbs12381g2pub.PrivateKey
+}
+
+// NewSigner returns a new Signer
+func NewSigner(privateKey *bbs12381g2pub.PrivateKey) *Signer {
+	return &Signer{privateKey: privateKey}
+}
+
+// Sign signs the N-Quad statements of data (see SplitMessages) at once
+func (s *Signer) Sign(data []byte) ([]byte, error) {
+	privKeyBytes, err := s.privateKey.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("bbs: %w", err)
+	}
+
+	signature, err := bbs12381g2pub.New().Sign(SplitMessages(data), privKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("bbs: %w", err)
+	}
+
+	return signature, nil
+}
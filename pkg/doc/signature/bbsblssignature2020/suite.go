@@ -0,0 +1,125 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package bbsblssignature2020 implements the BbsBlsSignature2020 signature suite
+// (https://w3c-ccg.github.io/ldp-bbs2020) for the Linked Data Signatures specification.
+// It uses the RDF Dataset Normalization Algorithm [RDF-DATASET-NORMALIZATION]
+// to transform the input document into its canonical form.
+// The N-Quad statements of the canonical proof options and document are the messages of
+// a BBS+ signature with a BLS12-381 G2 public key (Bls12381G2Key2020), there is no message digest.
+// The signatures allow the selective disclosure of the statements with the BbsBlsSignatureProof2020 proofs.
+// The BBS+ signatures are those of the bbs12381g2pub package, they are not interoperable with the other
+// implementations of the suite.
+package bbsblssignature2020
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+)
+
+// SignatureSuite implements BbsBlsSignature2020 signature suite
+type SignatureSuite struct {
+	signer signer
+}
+
+const (
+	signatureType = "BbsBlsSignature2020"
+	format        = "application/n-quads"
+)
+
+type signer interface {
+	// Sign will sign document and return signature
+	Sign(data []byte) ([]byte, error)
+}
+
+// SuiteOpt is the SignatureSuite option.
+type SuiteOpt func(opts *SignatureSuite)
+
+// WithSigner defines a signer for the Signature Suite.
+func WithSigner(s signer) SuiteOpt {
+	return func(opts *SignatureSuite) {
+		opts.signer = s
+	}
+}
+
+// New an instance of BbsBlsSignature2020 signature suite
+func New(opts ...SuiteOpt) *SignatureSuite {
+	suite := &SignatureSuite{}
+
+	for _, opt := range opts {
+		opt(suite)
+	}
+
+	return suite
+}
+
+// GetCanonicalDocument will return normalized/canonical version of the document
+// BbsBlsSignature2020 signature SignatureSuite uses RDF Dataset Normalization as canonicalization algorithm
+func (s *SignatureSuite) GetCanonicalDocument(doc map[string]interface{}) ([]byte, error) {
+	proc := ld.NewJsonLdProcessor()
+	options := ld.NewJsonLdOptions("")
+	options.ProcessingMode = ld.JsonLd_1_1
+	options.Format = format
+	options.ProduceGeneralizedRdf = true
+
+	canonicalDoc, err := proc.Normalize(doc, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(canonicalDoc.(string)), nil
+}
+
+// GetDigest returns the document as is, the N-Quad statements of the document are signed
+func (s *SignatureSuite) GetDigest(doc []byte) []byte {
+	return doc
+}
+
+// Verify will verify the BBS+ signature of the N-Quad statements of the document.
+// The public key is the compressed BLS12-381 G2 point.
+func (s *SignatureSuite) Verify(pubKey, doc, signature []byte) error {
+	err := bbs12381g2pub.New().Verify(SplitMessages(doc), signature, pubKey)
+	if err != nil {
+		return fmt.Errorf("bbs: %w", err)
+	}
+
+	return nil
+}
+
+// Sign will sign input data.
+func (s *SignatureSuite) Sign(data []byte) ([]byte, error) {
+	if s.signer == nil {
+		return nil, ErrSignerNotDefined
+	}
+
+	return s.signer.Sign(data)
+}
+
+// Accept will accept only BbsBlsSignature2020 signature type
+func (s *SignatureSuite) Accept(t string) bool {
+	return t == signatureType
+}
+
+// SplitMessages splits the N-Quads of the canonical proof options and document into the signed
+// messages, one message per statement
+func SplitMessages(doc []byte) [][]byte {
+	var messages [][]byte
+
+	for _, statement := range bytes.Split(doc, []byte("\n")) {
+		if len(statement) > 0 {
+			messages = append(messages, statement)
+		}
+	}
+
+	return messages
+}
+
+// ErrSignerNotDefined is returned when Sign() is called but signer option is not defined.
+var ErrSignerNotDefined = errors.New("signer is not defined")
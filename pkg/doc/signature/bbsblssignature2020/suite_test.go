@@ -0,0 +1,131 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbsblssignature2020
+
+import (
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+)
+
+const testDoc = `<http://example.org/fact1> <http://purl.org/dc/terms/title> "Hello World!" .
+<http://example.org/fact1> <http://purl.org/dc/terms/creator> "Alice" .
+`
+
+func TestSignatureSuite_Sign(t *testing.T) {
+	doc := []byte(testDoc)
+
+	ss := New(WithSigner(&mockSigner{
+		signature: []byte("test signature"),
+	}))
+	bytes, err := ss.Sign(doc)
+	require.NoError(t, err)
+	require.NotEmpty(t, bytes)
+
+	ss = New(WithSigner(&mockSigner{
+		err: errors.New("signature error"),
+	}))
+	bytes, err = ss.Sign(doc)
+	require.Error(t, err)
+	require.EqualError(t, err, "signature error")
+	require.Empty(t, bytes)
+
+	ss = New()
+	bytes, err = ss.Sign(doc)
+	require.Error(t, err)
+	require.Equal(t, ErrSignerNotDefined, err)
+	require.Empty(t, bytes)
+}
+
+func TestSignatureSuite_SignAndVerify(t *testing.T) {
+	pubKey, privKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+	require.NoError(t, err)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(t, err)
+
+	doc := []byte(testDoc)
+
+	ss := New(WithSigner(NewSigner(privKey)))
+
+	signature, err := ss.Sign(doc)
+	require.NoError(t, err)
+	require.Len(t, signature, bbs12381g2pub.SignatureSize)
+
+	require.NoError(t, ss.Verify(pubKeyBytes, doc, signature))
+
+	// the statements are the signed messages
+	require.NoError(t, bbs12381g2pub.New().Verify([][]byte{
+		[]byte(`<http://example.org/fact1> <http://purl.org/dc/terms/title> "Hello World!" .`),
+		[]byte(`<http://example.org/fact1> <http://purl.org/dc/terms/creator> "Alice" .`),
+	}, signature, pubKeyBytes))
+
+	// test different message
+	err = ss.Verify(pubKeyBytes, []byte(testDoc+`<http://example.org/fact1> <http://purl.org/dc/terms/date> "2020" .`),
+		signature)
+	require.EqualError(t, err, "bbs: invalid BBS+ signature")
+
+	// test different signature
+	err = ss.Verify(pubKeyBytes, doc, []byte("signature"))
+	require.EqualError(t, err, "bbs: parse signature: invalid size of signature")
+
+	// test invalid public key
+	err = ss.Verify([]byte("key"), doc, signature)
+	require.EqualError(t, err, "bbs: parse public key: invalid size of public key")
+
+	// test no messages
+	_, err = ss.Sign(nil)
+	require.EqualError(t, err, "bbs: messages are not defined")
+}
+
+func TestSignatureSuite_GetCanonicalDocument(t *testing.T) {
+	doc, err := New().GetCanonicalDocument(map[string]interface{}{
+		"@context": map[string]interface{}{
+			"dc": "http://purl.org/dc/terms/",
+		},
+		"@id":      "http://example.org/fact1",
+		"dc:title": "Hello World!",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "<http://example.org/fact1> <http://purl.org/dc/terms/title> \"Hello World!\" .\n", string(doc))
+
+	_, err = New().GetCanonicalDocument(map[string]interface{}{"@context": 1})
+	require.Error(t, err)
+}
+
+func TestSignatureSuite_GetDigest(t *testing.T) {
+	digest := New().GetDigest([]byte("test doc"))
+	require.Equal(t, []byte("test doc"), digest)
+}
+
+func TestSignatureSuite_Accept(t *testing.T) {
+	ss := New()
+	require.True(t, ss.Accept("BbsBlsSignature2020"))
+	require.False(t, ss.Accept("BbsBlsSignatureProof2020"))
+}
+
+func TestSplitMessages(t *testing.T) {
+	require.Equal(t, [][]byte{[]byte("statement 1"), []byte("statement 2")},
+		SplitMessages([]byte("statement 1\nstatement 2\n")))
+	require.Empty(t, SplitMessages([]byte("\n")))
+}
+
+type mockSigner struct {
+	signature []byte
+	err       error
+}
+
+func (s *mockSigner) Sign(_ []byte) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return s.signature, nil
+}
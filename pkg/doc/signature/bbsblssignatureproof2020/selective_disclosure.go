@@ -0,0 +1,197 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbsblssignatureproof2020
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+)
+
+// nolint:gochecknoglobals
+var blankNode = regexp.MustCompile(`_:c14n[0-9]+`)
+
+// keyResolver encapsulates key resolution
+type keyResolver interface {
+
+	// Resolve will return public key bytes
+	Resolve(id string) ([]byte, error)
+}
+
+// SelectiveDisclosure derives from the BbsBlsSignature2020 proofs of the document the BbsBlsSignatureProof2020
+// proofs, bound to the nonce, of the document revealed by the JSON-LD frame revealDoc. The other proofs of the
+// document are dropped. The public keys of the signatures are resolved from the creators of the proofs.
+func (s *SignatureSuite) SelectiveDisclosure(doc, revealDoc map[string]interface{}, nonce []byte,
+	resolver keyResolver) (map[string]interface{}, error) {
+	proofs, err := proof.GetProofs(doc)
+	if err != nil {
+		return nil, fmt.Errorf("get proofs: %w", err)
+	}
+
+	signatureSuite := bbsblssignature2020.New()
+
+	var signatureProofs []*proof.Proof
+
+	for _, p := range proofs {
+		if signatureSuite.Accept(p.Type) {
+			signatureProofs = append(signatureProofs, p)
+		}
+	}
+
+	if len(signatureProofs) == 0 {
+		return nil, errors.New("no BbsBlsSignature2020 proof present")
+	}
+
+	canonicalDoc, err := signatureSuite.GetCanonicalDocument(proof.GetCopyWithoutProof(doc))
+	if err != nil {
+		return nil, fmt.Errorf("canonicalize document: %w", err)
+	}
+
+	revealedDoc, err := frame(blankNode.ReplaceAll(canonicalDoc, []byte("<urn:bnid:$0>")), revealDoc)
+	if err != nil {
+		return nil, fmt.Errorf("frame document: %w", err)
+	}
+
+	canonicalRevealedDoc, err := s.GetCanonicalDocument(revealedDoc)
+	if err != nil {
+		return nil, fmt.Errorf("canonicalize revealed document: %w", err)
+	}
+
+	docStatements := bbsblssignature2020.SplitMessages(canonicalDoc)
+
+	revealedIndexes, err := statementIndexes(bbsblssignature2020.SplitMessages(canonicalRevealedDoc), docStatements)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range signatureProofs {
+		derivedProof, err := deriveProof(doc, p, len(docStatements), revealedIndexes, nonce, resolver)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := proof.AddProof(revealedDoc, derivedProof); err != nil {
+			return nil, err
+		}
+	}
+
+	return revealedDoc, nil
+}
+
+// deriveProof derives the proof revealing the proof options and the statements of the document at revealedIndexes
+func deriveProof(doc map[string]interface{}, p *proof.Proof, docStatementsCount int, revealedIndexes []int,
+	nonce []byte, resolver keyResolver) (*proof.Proof, error) {
+	if p.SignatureRepresentation != proof.SignatureProofValue {
+		return nil, errors.New("BbsBlsSignature2020 proof value is not defined")
+	}
+
+	if len(p.Nonce) > 0 {
+		return nil, errors.New("BbsBlsSignature2020 proof with nonce is not supported")
+	}
+
+	publicKeyID, err := p.PublicKeyID()
+	if err != nil {
+		return nil, fmt.Errorf("resolve public key of BbsBlsSignature2020 proof: %w", err)
+	}
+
+	pubKey, err := resolver.Resolve(publicKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve public key of BbsBlsSignature2020 proof: %w", err)
+	}
+
+	verifyData, err := proof.CreateVerifyData(bbsblssignature2020.New(), doc, p)
+	if err != nil {
+		return nil, fmt.Errorf("create verify data of BbsBlsSignature2020 proof: %w", err)
+	}
+
+	messages := bbsblssignature2020.SplitMessages(verifyData)
+
+	// the statements of the proof options are always revealed
+	proofStatementsCount := len(messages) - docStatementsCount
+	indexes := make([]int, 0, proofStatementsCount+len(revealedIndexes))
+
+	for i := 0; i < proofStatementsCount; i++ {
+		indexes = append(indexes, i)
+	}
+
+	for _, i := range revealedIndexes {
+		indexes = append(indexes, proofStatementsCount+i)
+	}
+
+	proofValue, err := bbs12381g2pub.New().DeriveProof(messages, p.ProofValue, nonce, pubKey, indexes)
+	if err != nil {
+		return nil, fmt.Errorf("derive BBS+ proof: %w", err)
+	}
+
+	return &proof.Proof{
+		Type:                    signatureType,
+		Created:                 p.Created,
+		Creator:                 p.Creator,
+		VerificationMethod:      p.VerificationMethod,
+		ProofValue:              proofValue,
+		ProofPurpose:            p.ProofPurpose,
+		Domain:                  p.Domain,
+		Challenge:               p.Challenge,
+		Nonce:                   nonce,
+		SignatureRepresentation: proof.SignatureProofValue,
+	}, nil
+}
+
+// frame frames the N-Quads with the JSON-LD frame revealDoc
+func frame(nquads []byte, revealDoc map[string]interface{}) (map[string]interface{}, error) {
+	proc := ld.NewJsonLdProcessor()
+	options := ld.NewJsonLdOptions("")
+	options.ProcessingMode = ld.JsonLd_1_1
+	options.Format = format
+
+	expandedDoc, err := proc.FromRDF(string(nquads), options)
+	if err != nil {
+		return nil, err
+	}
+
+	options.OmitGraph = true
+
+	framedDoc, err := proc.Frame(expandedDoc, revealDoc, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// the framed document is compacted with the context of the frame but holds the processed context,
+	// keep the context as defined in the frame
+	if context, ok := revealDoc["@context"]; ok {
+		framedDoc["@context"] = context
+	}
+
+	return framedDoc, nil
+}
+
+// statementIndexes returns the indexes of the revealed statements in the statements of the signed document
+func statementIndexes(revealedStatements, docStatements [][]byte) ([]int, error) {
+	indexes := make(map[string]int, len(docStatements))
+
+	for i, statement := range docStatements {
+		indexes[string(statement)] = i
+	}
+
+	revealedIndexes := make([]int, len(revealedStatements))
+
+	for i, statement := range revealedStatements {
+		index, ok := indexes[string(statement)]
+		if !ok {
+			return nil, fmt.Errorf("revealed statement not found in the document: %s", statement)
+		}
+
+		revealedIndexes[i] = index
+	}
+
+	return revealedIndexes, nil
+}
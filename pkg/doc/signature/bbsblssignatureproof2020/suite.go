@@ -0,0 +1,107 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package bbsblssignatureproof2020 implements the BbsBlsSignatureProof2020 signature suite
+// (https://w3c-ccg.github.io/ldp-bbs2020) for the Linked Data Signatures specification.
+// The BbsBlsSignatureProof2020 proofs are zero-knowledge proofs of knowledge of BbsBlsSignature2020 signatures
+// revealing only some of the N-Quad statements of the signed document, they are derived by the holder of the
+// document with SelectiveDisclosure and bound to a nonce.
+//
+// The blank nodes of the revealed document are identified by their canonical labels in the signed document,
+// as "urn:bnid:_:c14n<n>" IRIs, so that its canonical statements are the revealed statements of the signed document.
+package bbsblssignatureproof2020
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/bbsblssignature2020"
+)
+
+// SignatureSuite implements BbsBlsSignatureProof2020 signature suite
+type SignatureSuite struct{}
+
+const (
+	signatureType = "BbsBlsSignatureProof2020"
+	format        = "application/n-quads"
+)
+
+// nolint:gochecknoglobals
+var skolemizedBlankNode = regexp.MustCompile(`<urn:bnid:(_:c14n[0-9]+)>`)
+
+// New an instance of BbsBlsSignatureProof2020 signature suite
+func New() *SignatureSuite {
+	return &SignatureSuite{}
+}
+
+// GetCanonicalDocument will return normalized/canonical version of the document
+// BbsBlsSignatureProof2020 signature SignatureSuite uses RDF Dataset Normalization as canonicalization algorithm,
+// the "urn:bnid:" IRIs of the blank nodes are replaced by their labels and the statements are sorted
+// as in the signed document
+func (s *SignatureSuite) GetCanonicalDocument(doc map[string]interface{}) ([]byte, error) {
+	canonicalDoc, err := normalize(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	statements := bbsblssignature2020.SplitMessages(skolemizedBlankNode.ReplaceAll(canonicalDoc, []byte("$1")))
+
+	sort.Slice(statements, func(i, j int) bool {
+		return bytes.Compare(statements[i], statements[j]) < 0
+	})
+
+	var sorted []byte
+	for _, statement := range statements {
+		sorted = append(append(sorted, statement...), '\n')
+	}
+
+	return sorted, nil
+}
+
+// GetDigest returns the document as is, the N-Quad statements of the document are the revealed messages
+func (s *SignatureSuite) GetDigest(doc []byte) []byte {
+	return doc
+}
+
+// Verify will verify a proof without nonce, see VerifyProof
+func (s *SignatureSuite) Verify(pubKey, doc, proof []byte) error {
+	return s.VerifyProof(pubKey, doc, proof, nil)
+}
+
+// VerifyProof will verify the BBS+ signature proof bound to the nonce revealing the N-Quad statements
+// of the document. The public key is the compressed BLS12-381 G2 point of the BbsBlsSignature2020 signature.
+func (s *SignatureSuite) VerifyProof(pubKey, doc, proof, nonce []byte) error {
+	err := bbs12381g2pub.New().VerifyProof(bbsblssignature2020.SplitMessages(doc), proof, nonce, pubKey)
+	if err != nil {
+		return fmt.Errorf("bbs: %w", err)
+	}
+
+	return nil
+}
+
+// Accept will accept only BbsBlsSignatureProof2020 signature type
+func (s *SignatureSuite) Accept(t string) bool {
+	return t == signatureType
+}
+
+func normalize(doc interface{}) ([]byte, error) {
+	proc := ld.NewJsonLdProcessor()
+	options := ld.NewJsonLdOptions("")
+	options.ProcessingMode = ld.JsonLd_1_1
+	options.Format = format
+	options.ProduceGeneralizedRdf = true
+
+	canonicalDoc, err := proc.Normalize(doc, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(canonicalDoc.(string)), nil
+}
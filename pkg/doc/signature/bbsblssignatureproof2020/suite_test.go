@@ -0,0 +1,393 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbsblssignatureproof2020
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+const creator = "did:example:alice#key-1"
+
+//nolint:lll
+const testContext = `{
+  "ex": "http://example.org/vocab#",
+  "sec": "https://w3id.org/security#",
+  "xsd": "http://www.w3.org/2001/XMLSchema#",
+  "id": "@id",
+  "type": "@type",
+  "Person": "ex:Person",
+  "name": "ex:name",
+  "email": "ex:email",
+  "birthDate": {"@id": "ex:birthDate", "@type": "xsd:date"},
+  "address": "ex:address",
+  "city": "ex:city",
+  "street": "ex:street",
+  "creator": {"@id": "http://purl.org/dc/terms/creator", "@type": "@id"},
+  "verificationMethod": {"@id": "sec:verificationMethod", "@type": "@id"},
+  "created": {"@id": "http://purl.org/dc/terms/created", "@type": "xsd:dateTime"},
+  "proofPurpose": "sec:proofPurpose",
+  "challenge": "sec:challenge"
+}`
+
+const testDoc = `{
+  "@context": ` + testContext + `,
+  "id": "did:example:alice",
+  "type": "Person",
+  "name": "Alice",
+  "email": "alice@example.org",
+  "birthDate": "1990-01-01",
+  "address": {
+    "street": "1 Main Street",
+    "city": "Springfield"
+  }
+}`
+
+const testRevealDoc = `{
+  "@context": ` + testContext + `,
+  "type": "Person",
+  "@explicit": true,
+  "name": {},
+  "address": {
+    "@explicit": true,
+    "city": {}
+  }
+}`
+
+func TestSignatureSuite_SelectiveDisclosure(t *testing.T) {
+	pubKey, privKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+	require.NoError(t, err)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(t, err)
+
+	resolver := &testKeyResolver{keys: map[string][]byte{creator: pubKeyBytes}}
+
+	signedDoc := signDoc(t, privKey)
+	revealDoc := toMap(t, testRevealDoc)
+	nonce := []byte("nonce")
+
+	s := New()
+
+	revealedDoc, err := s.SelectiveDisclosure(signedDoc, revealDoc, nonce, resolver)
+	require.NoError(t, err)
+
+	require.Equal(t, "did:example:alice", revealedDoc["id"])
+	require.Equal(t, "Alice", revealedDoc["name"])
+	require.NotContains(t, revealedDoc, "email")
+	require.NotContains(t, revealedDoc, "birthDate")
+
+	address, ok := revealedDoc["address"].(map[string]interface{})
+	require.True(t, ok)
+	require.Equal(t, "Springfield", address["city"])
+	require.NotContains(t, address, "street")
+	// the blank node is identified by its canonical label in the signed document
+	require.Regexp(t, "^urn:bnid:_:c14n[0-9]+$", address["id"])
+
+	proofs, err := proof.GetProofs(revealedDoc)
+	require.NoError(t, err)
+	require.Len(t, proofs, 1)
+	require.Equal(t, "BbsBlsSignatureProof2020", proofs[0].Type)
+	require.Equal(t, creator, proofs[0].Creator)
+	require.Equal(t, nonce, proofs[0].Nonce)
+
+	revealedDocBytes, err := json.Marshal(revealedDoc)
+	require.NoError(t, err)
+
+	t.Run("verify derived proof", func(t *testing.T) {
+		require.NoError(t, verifier.New(resolver, s).Verify(revealedDocBytes))
+	})
+
+	t.Run("verify derived proof of modified document", func(t *testing.T) {
+		modifiedDoc := toMap(t, string(revealedDocBytes))
+		modifiedDoc["name"] = "Bob"
+
+		modifiedDocBytes, err := json.Marshal(modifiedDoc)
+		require.NoError(t, err)
+
+		err = verifier.New(resolver, s).Verify(modifiedDocBytes)
+		require.EqualError(t, err, "bbs: invalid BBS+ signature proof")
+	})
+
+	t.Run("verify derived proof with other nonce", func(t *testing.T) {
+		proofs[0].Nonce = []byte("other nonce")
+
+		modifiedDoc := toMap(t, string(revealedDocBytes))
+		modifiedDoc["proof"] = proofs[0].JSONLdObject()
+
+		modifiedDocBytes, err := json.Marshal(modifiedDoc)
+		require.NoError(t, err)
+
+		err = verifier.New(resolver, s).Verify(modifiedDocBytes)
+		require.EqualError(t, err, "bbs: invalid BBS+ signature proof")
+
+		// Verify checks proofs without nonce
+		err = s.Verify(pubKeyBytes, []byte("statement\n"), proofs[0].ProofValue)
+		require.Error(t, err)
+	})
+
+	t.Run("verify derived proof without nonce", func(t *testing.T) {
+		proofs[0].Nonce = nil
+
+		modifiedDoc := toMap(t, string(revealedDocBytes))
+		modifiedDoc["proof"] = proofs[0].JSONLdObject()
+		require.NotContains(t, modifiedDoc["proof"], "nonce")
+
+		modifiedDocBytes, err := json.Marshal(modifiedDoc)
+		require.NoError(t, err)
+
+		err = verifier.New(resolver, s).Verify(modifiedDocBytes)
+		require.EqualError(t, err, "bbs: invalid BBS+ signature proof")
+	})
+
+	t.Run("reveal all statements", func(t *testing.T) {
+		allDoc, err := s.SelectiveDisclosure(signedDoc, toMap(t, `{"@context": `+testContext+`}`), nonce, resolver)
+		require.NoError(t, err)
+
+		allDocBytes, err := json.Marshal(allDoc)
+		require.NoError(t, err)
+
+		require.NoError(t, verifier.New(resolver, s).Verify(allDocBytes))
+	})
+
+	t.Run("derive proof with challenge", func(t *testing.T) {
+		challengedDoc := signDocWithContext(t, privKey, &signer.Context{
+			SignatureType: "BbsBlsSignature2020",
+			Creator:       creator,
+			ProofPurpose:  "assertionMethod",
+			Challenge:     "challenge",
+		})
+
+		derivedDoc, err := s.SelectiveDisclosure(challengedDoc, revealDoc, nonce, resolver)
+		require.NoError(t, err)
+
+		derivedProofs, err := proof.GetProofs(derivedDoc)
+		require.NoError(t, err)
+		require.Equal(t, "challenge", derivedProofs[0].Challenge)
+
+		derivedDocBytes, err := json.Marshal(derivedDoc)
+		require.NoError(t, err)
+
+		require.NoError(t, verifier.New(resolver, s).Verify(derivedDocBytes, verifier.WithChallenge("challenge")))
+
+		err = verifier.New(resolver, s).Verify(derivedDocBytes, verifier.WithChallenge("other"))
+		require.EqualError(t, err, "proof challenge does not match the expected challenge")
+	})
+
+	t.Run("derive proof with verification method", func(t *testing.T) {
+		vmDoc := signDocWithContext(t, privKey, &signer.Context{
+			SignatureType:      "BbsBlsSignature2020",
+			VerificationMethod: creator,
+			ProofPurpose:       "assertionMethod",
+		})
+
+		vmProofs, err := proof.GetProofs(vmDoc)
+		require.NoError(t, err)
+		require.Equal(t, creator, vmProofs[0].VerificationMethod)
+		require.Empty(t, vmProofs[0].Creator)
+
+		derivedDoc, err := s.SelectiveDisclosure(vmDoc, revealDoc, nonce, resolver)
+		require.NoError(t, err)
+
+		derivedProofs, err := proof.GetProofs(derivedDoc)
+		require.NoError(t, err)
+		require.Equal(t, creator, derivedProofs[0].VerificationMethod)
+		require.Empty(t, derivedProofs[0].Creator)
+
+		derivedDocBytes, err := json.Marshal(derivedDoc)
+		require.NoError(t, err)
+
+		validator := &testProofPurposeValidator{}
+
+		require.NoError(t, verifier.New(resolver, s).Verify(derivedDocBytes,
+			verifier.WithProofPurpose("assertionMethod", validator)))
+		require.Equal(t, creator, validator.verificationMethod)
+	})
+}
+
+func TestSignatureSuite_SelectiveDisclosure_Failure(t *testing.T) {
+	pubKey, privKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+	require.NoError(t, err)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(t, err)
+
+	resolver := &testKeyResolver{keys: map[string][]byte{creator: pubKeyBytes}}
+	revealDoc := toMap(t, testRevealDoc)
+	nonce := []byte("nonce")
+
+	s := New()
+
+	t.Run("no proof", func(t *testing.T) {
+		_, err := s.SelectiveDisclosure(toMap(t, testDoc), revealDoc, nonce, resolver)
+		require.EqualError(t, err, "get proofs: proof not found")
+	})
+
+	t.Run("no BbsBlsSignature2020 proof", func(t *testing.T) {
+		doc := toMap(t, testDoc)
+		doc["proof"] = map[string]interface{}{
+			"type":       "Ed25519Signature2018",
+			"created":    "2020-01-01T00:00:00Z",
+			"proofValue": "c2lnbmF0dXJl",
+		}
+
+		_, err := s.SelectiveDisclosure(doc, revealDoc, nonce, resolver)
+		require.EqualError(t, err, "no BbsBlsSignature2020 proof present")
+	})
+
+	t.Run("public key not resolved", func(t *testing.T) {
+		_, err := s.SelectiveDisclosure(signDoc(t, privKey), revealDoc, nonce, &testKeyResolver{})
+		require.EqualError(t, err, "resolve public key of BbsBlsSignature2020 proof: key not found")
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		otherPubKey, _, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+		require.NoError(t, err)
+
+		otherPubKeyBytes, err := otherPubKey.Marshal()
+		require.NoError(t, err)
+
+		_, err = s.SelectiveDisclosure(signDoc(t, privKey), revealDoc, nonce,
+			&testKeyResolver{keys: map[string][]byte{creator: otherPubKeyBytes}})
+		require.EqualError(t, err, "derive BBS+ proof: invalid BBS+ signature")
+	})
+
+	t.Run("invalid frame", func(t *testing.T) {
+		_, err := s.SelectiveDisclosure(signDoc(t, privKey), map[string]interface{}{"@context": 1}, nonce, resolver)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "frame document")
+	})
+
+	t.Run("invalid document", func(t *testing.T) {
+		doc := signDoc(t, privKey)
+		doc["@context"] = 1
+
+		_, err := s.SelectiveDisclosure(doc, revealDoc, nonce, resolver)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "canonicalize document")
+	})
+
+	t.Run("proof with JWS or nonce", func(t *testing.T) {
+		doc := signDoc(t, privKey)
+
+		proofs, err := proof.GetProofs(doc)
+		require.NoError(t, err)
+
+		proofs[0].Nonce = []byte("nonce")
+		doc["proof"] = proofs[0].JSONLdObject()
+
+		_, err = s.SelectiveDisclosure(doc, revealDoc, nonce, resolver)
+		require.EqualError(t, err, "BbsBlsSignature2020 proof with nonce is not supported")
+
+		proofs[0].ProofValue = nil
+		proofs[0].JWS = "header..signature"
+		doc["proof"] = proofs[0].JSONLdObject()
+
+		_, err = s.SelectiveDisclosure(doc, revealDoc, nonce, resolver)
+		require.EqualError(t, err, "BbsBlsSignature2020 proof value is not defined")
+	})
+}
+
+func TestStatementIndexes(t *testing.T) {
+	indexes, err := statementIndexes([][]byte{[]byte("b"), []byte("c")}, [][]byte{[]byte("a"), []byte("b"), []byte("c")})
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2}, indexes)
+
+	_, err = statementIndexes([][]byte{[]byte("d")}, [][]byte{[]byte("a")})
+	require.EqualError(t, err, "revealed statement not found in the document: d")
+}
+
+func TestSignatureSuite_GetCanonicalDocument(t *testing.T) {
+	doc, err := New().GetCanonicalDocument(map[string]interface{}{
+		"@context": map[string]interface{}{
+			"dc": "http://purl.org/dc/terms/",
+		},
+		"@id":      "urn:bnid:_:c14n0",
+		"dc:title": "Hello World!",
+		"dc:creator": map[string]interface{}{
+			"@id":     "http://example.org/alice",
+			"dc:name": "Alice",
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "<http://example.org/alice> <http://purl.org/dc/terms/name> \"Alice\" .\n"+
+		"_:c14n0 <http://purl.org/dc/terms/creator> <http://example.org/alice> .\n"+
+		"_:c14n0 <http://purl.org/dc/terms/title> \"Hello World!\" .\n", string(doc))
+
+	_, err = New().GetCanonicalDocument(map[string]interface{}{"@context": 1})
+	require.Error(t, err)
+}
+
+func TestSignatureSuite_GetDigest(t *testing.T) {
+	digest := New().GetDigest([]byte("test doc"))
+	require.Equal(t, []byte("test doc"), digest)
+}
+
+func TestSignatureSuite_Accept(t *testing.T) {
+	ss := New()
+	require.True(t, ss.Accept("BbsBlsSignatureProof2020"))
+	require.False(t, ss.Accept("BbsBlsSignature2020"))
+}
+
+func signDoc(t *testing.T, privKey *bbs12381g2pub.PrivateKey) map[string]interface{} {
+	return signDocWithContext(t, privKey, &signer.Context{
+		SignatureType: "BbsBlsSignature2020",
+		Creator:       creator,
+	})
+}
+
+func signDocWithContext(t *testing.T, privKey *bbs12381g2pub.PrivateKey,
+	context *signer.Context) map[string]interface{} {
+	s := signer.New(ed25519signature2018.New(),
+		bbsblssignature2020.New(bbsblssignature2020.WithSigner(bbsblssignature2020.NewSigner(privKey))))
+
+	signedDocBytes, err := s.Sign(context, []byte(testDoc))
+	require.NoError(t, err)
+
+	return toMap(t, string(signedDocBytes))
+}
+
+func toMap(t *testing.T, doc string) map[string]interface{} {
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(doc), &m))
+
+	return m
+}
+
+type testKeyResolver struct {
+	keys map[string][]byte
+}
+
+func (r *testKeyResolver) Resolve(id string) ([]byte, error) {
+	key, ok := r.keys[id]
+	if !ok {
+		return nil, errors.New("key not found")
+	}
+
+	return key, nil
+}
+
+// testProofPurposeValidator records the verification method of the validated proof
+type testProofPurposeValidator struct {
+	verificationMethod string
+}
+
+func (v *testProofPurposeValidator) ValidateProofPurpose(verificationMethod, _ string) error {
+	v.verificationMethod = verificationMethod
+
+	return nil
+}
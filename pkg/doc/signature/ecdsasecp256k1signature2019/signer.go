@@ -0,0 +1,35 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdsasecp256k1signature2019
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"errors"
+
+	"github.com/hyperledger/aries-framework-go/pkg/internal/secp256k1"
+)
+
+// Signer signs with a secp256k1 private key (see S256)
+type Signer struct {
+	privateKey *ecdsa.PrivateKey
+}
+
+// NewSigner returns a new Signer
+func NewSigner(privateKey *ecdsa.PrivateKey) *Signer {
+	return &Signer{privateKey: privateKey}
+}
+
+// Sign signs the SHA-256 digest of data, the signature is the concatenation of R and S
+func (s *Signer) Sign(data []byte) ([]byte, error) {
+	if s.privateKey.Curve != secp256k1.S256() {
+		return nil, errors.New("ecdsa: private key is not a secp256k1 key")
+	}
+
+	digest := sha256.Sum256(data)
+
+	return secp256k1.Sign(s.privateKey, digest[:])
+}
@@ -0,0 +1,129 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ecdsasecp256k1signature2019 implements the EcdsaSecp256k1Signature2019 signature suite
+// (https://w3c-ccg.github.io/lds-ecdsa-secp256k1-2019) for the Linked Data Signatures specification.
+// It uses the RDF Dataset Normalization Algorithm [RDF-DATASET-NORMALIZATION]
+// to transform the input document into its canonical form.
+// It uses SHA-256 [RFC6234] as the message digest algorithm and ECDSA with the secp256k1 curve
+// (the ES256K JWS algorithm) as the signature algorithm.
+package ecdsasecp256k1signature2019
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/internal/secp256k1"
+)
+
+// SignatureSuite implements EcdsaSecp256k1Signature2019 signature suite
+type SignatureSuite struct {
+	signer signer
+}
+
+const (
+	signatureType = "EcdsaSecp256k1Signature2019"
+	format        = "application/n-quads"
+
+	// jwsAlgorithm is the algorithm of the detached JWS of the proofs
+	jwsAlgorithm = "ES256K"
+)
+
+type signer interface {
+	// Sign will sign document and return signature
+	Sign(data []byte) ([]byte, error)
+}
+
+// SuiteOpt is the SignatureSuite option.
+type SuiteOpt func(opts *SignatureSuite)
+
+// WithSigner defines a signer for the Signature Suite.
+func WithSigner(s signer) SuiteOpt {
+	return func(opts *SignatureSuite) {
+		opts.signer = s
+	}
+}
+
+// New an instance of EcdsaSecp256k1Signature2019 signature suite
+func New(opts ...SuiteOpt) *SignatureSuite {
+	suite := &SignatureSuite{}
+
+	for _, opt := range opts {
+		opt(suite)
+	}
+
+	return suite
+}
+
+// S256 returns the secp256k1 curve of the keys of the suite
+func S256() elliptic.Curve {
+	return secp256k1.S256()
+}
+
+// GetCanonicalDocument will return normalized/canonical version of the document
+// EcdsaSecp256k1Signature2019 signature SignatureSuite uses RDF Dataset Normalization as canonicalization algorithm
+func (s *SignatureSuite) GetCanonicalDocument(doc map[string]interface{}) ([]byte, error) {
+	proc := ld.NewJsonLdProcessor()
+	options := ld.NewJsonLdOptions("")
+	options.ProcessingMode = ld.JsonLd_1_1
+	options.Format = format
+	options.ProduceGeneralizedRdf = true
+
+	canonicalDoc, err := proc.Normalize(doc, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(canonicalDoc.(string)), nil
+}
+
+// GetDigest returns document digest
+func (s *SignatureSuite) GetDigest(doc []byte) []byte {
+	digest := sha256.Sum256(doc)
+	return digest[:]
+}
+
+// Verify will verify a signature. The public key is the uncompressed or compressed secp256k1 point,
+// the signature is the concatenation of R and S.
+func (s *SignatureSuite) Verify(pubKey, doc, signature []byte) error {
+	key, err := secp256k1.ParsePublicKey(pubKey)
+	if err != nil {
+		return fmt.Errorf("ecdsa: %w", err)
+	}
+
+	digest := sha256.Sum256(doc)
+
+	if !secp256k1.Verify(key, digest[:], signature) {
+		return errors.New("signature doesn't match")
+	}
+
+	return nil
+}
+
+// Sign will sign input data.
+func (s *SignatureSuite) Sign(data []byte) ([]byte, error) {
+	if s.signer == nil {
+		return nil, ErrSignerNotDefined
+	}
+
+	return s.signer.Sign(data)
+}
+
+// JWSAlgorithm returns ES256K, the algorithm of the JWS signed by the suite
+func (s *SignatureSuite) JWSAlgorithm() string {
+	return jwsAlgorithm
+}
+
+// Accept will accept only EcdsaSecp256k1Signature2019 signature type
+func (s *SignatureSuite) Accept(t string) bool {
+	return t == signatureType
+}
+
+// ErrSignerNotDefined is returned when Sign() is called but signer option is not defined.
+var ErrSignerNotDefined = errors.New("signer is not defined")
@@ -0,0 +1,127 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdsasecp256k1signature2019
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/internal/secp256k1"
+)
+
+func TestSignatureSuite_Sign(t *testing.T) {
+	doc := []byte("test doc")
+
+	ss := New(WithSigner(&mockSigner{
+		signature: []byte("test signature"),
+	}))
+	bytes, err := ss.Sign(doc)
+	require.NoError(t, err)
+	require.NotEmpty(t, bytes)
+
+	ss = New(WithSigner(&mockSigner{
+		err: errors.New("signature error"),
+	}))
+	bytes, err = ss.Sign(doc)
+	require.Error(t, err)
+	require.EqualError(t, err, "signature error")
+	require.Empty(t, bytes)
+
+	ss = New()
+	bytes, err = ss.Sign(doc)
+	require.Error(t, err)
+	require.Equal(t, ErrSignerNotDefined, err)
+	require.Empty(t, bytes)
+}
+
+func TestSignatureSuite_SignAndVerify(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(S256(), rand.Reader)
+	require.NoError(t, err)
+
+	doc := []byte("test doc")
+
+	ss := New(WithSigner(NewSigner(privKey)))
+
+	signature, err := ss.Sign(doc)
+	require.NoError(t, err)
+	require.Len(t, signature, secp256k1.SignatureSize)
+
+	pubKey := secp256k1.MarshalPublicKey(&privKey.PublicKey)
+	require.NoError(t, ss.Verify(pubKey, doc, signature))
+
+	// the compressed public key
+	prefix := byte(0x02)
+	if privKey.Y.Bit(0) == 1 {
+		prefix = 0x03
+	}
+
+	require.NoError(t, ss.Verify(append([]byte{prefix}, pubKey[1:33]...), doc, signature))
+
+	// test different message
+	err = ss.Verify(pubKey, []byte("different doc"), signature)
+	require.EqualError(t, err, "signature doesn't match")
+
+	// test different signature
+	err = ss.Verify(pubKey, doc, []byte("signature"))
+	require.EqualError(t, err, "signature doesn't match")
+
+	// test invalid public key
+	err = ss.Verify([]byte("key"), doc, signature)
+	require.EqualError(t, err, "ecdsa: secp256k1: invalid public key encoding")
+}
+
+func TestSigner(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	_, err = NewSigner(privKey).Sign([]byte("test doc"))
+	require.EqualError(t, err, "ecdsa: private key is not a secp256k1 key")
+}
+
+func TestSignatureSuite_GetCanonicalDocument(t *testing.T) {
+	doc, err := New().GetCanonicalDocument(map[string]interface{}{
+		"@context": map[string]interface{}{
+			"dc": "http://purl.org/dc/terms/",
+		},
+		"@id":      "http://example.org/fact1",
+		"dc:title": "Hello World!",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "<http://example.org/fact1> <http://purl.org/dc/terms/title> \"Hello World!\" .\n", string(doc))
+
+	_, err = New().GetCanonicalDocument(map[string]interface{}{"@context": 1})
+	require.Error(t, err)
+}
+
+func TestSignatureSuite_GetDigest(t *testing.T) {
+	digest := New().GetDigest([]byte("test doc"))
+	require.Len(t, digest, 32)
+}
+
+func TestSignatureSuite_Accept(t *testing.T) {
+	ss := New()
+	require.True(t, ss.Accept("EcdsaSecp256k1Signature2019"))
+	require.False(t, ss.Accept("Ed25519Signature2018"))
+	require.Equal(t, "ES256K", ss.JWSAlgorithm())
+}
+
+type mockSigner struct {
+	signature []byte
+	err       error
+}
+
+func (s *mockSigner) Sign(_ []byte) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return s.signature, nil
+}
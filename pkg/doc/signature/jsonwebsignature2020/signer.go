@@ -0,0 +1,121 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jsonwebsignature2020
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+
+	"github.com/hyperledger/aries-framework-go/pkg/internal/secp256k1"
+)
+
+const (
+	uncompressedPoint = 0x04
+	cryptoHash        = crypto.SHA256
+)
+
+// Ed25519Signer signs the EdDSA JWS with an Ed25519 private key
+type Ed25519Signer struct {
+	privateKey ed25519.PrivateKey
+}
+
+// NewEd25519Signer returns a new Ed25519Signer
+func NewEd25519Signer(privateKey ed25519.PrivateKey) *Ed25519Signer {
+	return &Ed25519Signer{privateKey: privateKey}
+}
+
+// Sign signs data
+func (s *Ed25519Signer) Sign(data []byte) ([]byte, error) {
+	if len(s.privateKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("ed25519: bad private key length")
+	}
+
+	return ed25519.Sign(s.privateKey, data), nil
+}
+
+// Alg returns EdDSA
+func (s *Ed25519Signer) Alg() string {
+	return EdDSA
+}
+
+// ECDSASigner signs the ES256 or ES256K JWS with a P-256 or secp256k1 private key
+type ECDSASigner struct {
+	privateKey *ecdsa.PrivateKey
+	alg        string
+}
+
+// NewECDSASigner returns a new ECDSASigner, the JWS algorithm is ES256 for P-256 keys and ES256K for
+// secp256k1 keys
+func NewECDSASigner(privateKey *ecdsa.PrivateKey) (*ECDSASigner, error) {
+	switch privateKey.Curve {
+	case elliptic.P256():
+		return &ECDSASigner{privateKey: privateKey, alg: ES256}, nil
+	case secp256k1.S256():
+		return &ECDSASigner{privateKey: privateKey, alg: ES256K}, nil
+	default:
+		return nil, errors.New("ecdsa: curve not supported")
+	}
+}
+
+// Sign signs the SHA-256 digest of data, the signature is the concatenation of R and S
+func (s *ECDSASigner) Sign(data []byte) ([]byte, error) {
+	digest := sha256.Sum256(data)
+
+	if s.alg == ES256K {
+		return secp256k1.Sign(s.privateKey, digest[:])
+	}
+
+	r, sig, err := ecdsa.Sign(rand.Reader, s.privateKey, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	size := (s.privateKey.Curve.Params().BitSize + 7) / 8 // nolint:gomnd
+
+	return append(padLeft(r.Bytes(), size), padLeft(sig.Bytes(), size)...), nil
+}
+
+// padLeft pads b with leading zeros to size bytes
+func padLeft(b []byte, size int) []byte {
+	padded := make([]byte, size)
+	copy(padded[size-len(b):], b)
+
+	return padded
+}
+
+// Alg returns ES256 or ES256K
+func (s *ECDSASigner) Alg() string {
+	return s.alg
+}
+
+// RSASigner signs the PS256 JWS with a RSA private key
+type RSASigner struct {
+	privateKey *rsa.PrivateKey
+}
+
+// NewRSASigner returns a new RSASigner
+func NewRSASigner(privateKey *rsa.PrivateKey) *RSASigner {
+	return &RSASigner{privateKey: privateKey}
+}
+
+// Sign signs the SHA-256 digest of data with RSASSA-PSS
+func (s *RSASigner) Sign(data []byte) ([]byte, error) {
+	digest := sha256.Sum256(data)
+
+	return rsa.SignPSS(rand.Reader, s.privateKey, cryptoHash, digest[:],
+		&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+}
+
+// Alg returns PS256
+func (s *RSASigner) Alg() string {
+	return PS256
+}
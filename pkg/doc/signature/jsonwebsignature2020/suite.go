@@ -0,0 +1,312 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package jsonwebsignature2020 implements the JsonWebSignature2020 signature suite
+// (https://github.com/transmute-industries/lds-jws2020) for the Linked Data Signatures specification.
+// It uses the RDF Dataset Normalization Algorithm [RDF-DATASET-NORMALIZATION]
+// to transform the input document into its canonical form.
+// It uses SHA-256 [RFC6234] as the message digest algorithm, and signs the proofs with detached
+// JSON Web Signatures [RFC7797] of the EdDSA, ES256, ES256K or PS256 algorithm.
+package jsonwebsignature2020
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/internal/secp256k1"
+)
+
+// SignatureSuite implements JsonWebSignature2020 signature suite
+type SignatureSuite struct {
+	signer signer
+}
+
+const (
+	signatureType = "JsonWebSignature2020"
+	format        = "application/n-quads"
+	p256KeySize   = 32
+)
+
+// JWS algorithms of the suite
+const (
+	// EdDSA is the Ed25519 signature algorithm
+	EdDSA = "EdDSA"
+	// ES256 is the ECDSA signature algorithm with the P-256 curve and SHA-256
+	ES256 = "ES256"
+	// ES256K is the ECDSA signature algorithm with the secp256k1 curve and SHA-256
+	ES256K = "ES256K"
+	// PS256 is the RSASSA-PSS signature algorithm with SHA-256
+	PS256 = "PS256"
+)
+
+type signer interface {
+	// Sign will sign document and return signature
+	Sign(data []byte) ([]byte, error)
+
+	// Alg returns the JWS algorithm of the signatures
+	Alg() string
+}
+
+// SuiteOpt is the SignatureSuite option.
+type SuiteOpt func(opts *SignatureSuite)
+
+// WithSigner defines a signer for the Signature Suite.
+func WithSigner(s signer) SuiteOpt {
+	return func(opts *SignatureSuite) {
+		opts.signer = s
+	}
+}
+
+// New an instance of JsonWebSignature2020 signature suite
+func New(opts ...SuiteOpt) *SignatureSuite {
+	suite := &SignatureSuite{}
+
+	for _, opt := range opts {
+		opt(suite)
+	}
+
+	return suite
+}
+
+// GetCanonicalDocument will return normalized/canonical version of the document
+// JsonWebSignature2020 signature SignatureSuite uses RDF Dataset Normalization as canonicalization algorithm
+func (s *SignatureSuite) GetCanonicalDocument(doc map[string]interface{}) ([]byte, error) {
+	proc := ld.NewJsonLdProcessor()
+	options := ld.NewJsonLdOptions("")
+	options.ProcessingMode = ld.JsonLd_1_1
+	options.Format = format
+	options.ProduceGeneralizedRdf = true
+
+	canonicalDoc, err := proc.Normalize(doc, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(canonicalDoc.(string)), nil
+}
+
+// GetDigest returns document digest
+func (s *SignatureSuite) GetDigest(doc []byte) []byte {
+	digest := sha256.Sum256(doc)
+	return digest[:]
+}
+
+// Verify will verify a signature. The doc is the signing input of the detached JWS of the proof,
+// the signature is verified with the algorithm of the public key, the algorithm of the JWS header
+// must be the same.
+func (s *SignatureSuite) Verify(pubKey, doc, signature []byte) error {
+	alg, err := headerAlgorithm(doc)
+	if err != nil {
+		return err
+	}
+
+	switch alg {
+	case EdDSA, ES256, ES256K, PS256:
+	default:
+		return fmt.Errorf("JWS algorithm '%s' not supported", alg)
+	}
+
+	keyAlg, err := keyAlgorithm(pubKey)
+	if err != nil {
+		return err
+	}
+
+	if alg != keyAlg {
+		return fmt.Errorf("JWS algorithm '%s' does not match the %s public key", alg, keyAlg)
+	}
+
+	switch keyAlg {
+	case EdDSA:
+		return verifyEd25519(pubKey, doc, signature)
+	case ES256:
+		return verifyES256(pubKey, doc, signature)
+	case ES256K:
+		return verifyES256K(pubKey, doc, signature)
+	default:
+		return verifyPS256(pubKey, doc, signature)
+	}
+}
+
+// Sign will sign input data.
+func (s *SignatureSuite) Sign(data []byte) ([]byte, error) {
+	if s.signer == nil {
+		return nil, ErrSignerNotDefined
+	}
+
+	return s.signer.Sign(data)
+}
+
+// JWSAlgorithm returns the algorithm of the JWS signed by the suite, empty if the signer is not defined
+func (s *SignatureSuite) JWSAlgorithm() string {
+	if s.signer == nil {
+		return ""
+	}
+
+	return s.signer.Alg()
+}
+
+// Accept will accept only JsonWebSignature2020 signature type
+func (s *SignatureSuite) Accept(t string) bool {
+	return t == signatureType
+}
+
+// ErrSignerNotDefined is returned when Sign() is called but signer option is not defined.
+var ErrSignerNotDefined = errors.New("signer is not defined")
+
+// headerAlgorithm returns the algorithm of the JWS header of the signing input
+func headerAlgorithm(signingInput []byte) (string, error) {
+	i := strings.IndexByte(string(signingInput), '.')
+	if i < 0 {
+		return "", errors.New("JWS header is missing")
+	}
+
+	headerBytes, err := base64.RawURLEncoding.DecodeString(string(signingInput[:i]))
+	if err != nil {
+		return "", fmt.Errorf("decode JWS header: %w", err)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+
+	err = json.Unmarshal(headerBytes, &header)
+	if err != nil {
+		return "", fmt.Errorf("unmarshal JWS header: %w", err)
+	}
+
+	return header.Alg, nil
+}
+
+// keyAlgorithm returns the JWS algorithm of the public key, the raw value of the verification method as
+// decoded from its JWK: EdDSA for Ed25519 keys (OKP), ES256 for P-256 points and ES256K for secp256k1
+// points (EC) and PS256 for PKIX or PKCS #1 DER keys (RSA)
+func keyAlgorithm(pubKey []byte) (string, error) {
+	switch {
+	case len(pubKey) == ed25519.PublicKeySize:
+		return EdDSA, nil
+	case len(pubKey) == 1+2*p256KeySize && pubKey[0] == uncompressedPoint:
+		if x, _ := elliptic.Unmarshal(elliptic.P256(), pubKey); x != nil {
+			return ES256, nil
+		}
+
+		if _, err := secp256k1.ParsePublicKey(pubKey); err == nil {
+			return ES256K, nil
+		}
+
+		return "", errors.New("ecdsa: invalid public key")
+	}
+
+	if _, err := secp256k1.ParsePublicKey(pubKey); err == nil {
+		return ES256K, nil
+	}
+
+	if _, err := parseRSAPublicKey(pubKey); err == nil {
+		return PS256, nil
+	}
+
+	return "", errors.New("public key type not supported")
+}
+
+func verifyEd25519(pubKey, doc, signature []byte) error {
+	// ed25519 panics if key size is wrong
+	if len(pubKey) != ed25519.PublicKeySize {
+		return errors.New("ed25519: bad public key length")
+	}
+
+	if !ed25519.Verify(pubKey, doc, signature) {
+		return errors.New("signature doesn't match")
+	}
+
+	return nil
+}
+
+// verifyES256 verifies the R || S signature of doc with the public key, an uncompressed point of the P-256 curve
+func verifyES256(pubKey, doc, signature []byte) error {
+	x, y := elliptic.Unmarshal(elliptic.P256(), pubKey)
+	if x == nil {
+		return errors.New("ecdsa: invalid public key")
+	}
+
+	if len(signature) != 2*p256KeySize {
+		return errors.New("ecdsa: invalid signature size")
+	}
+
+	r := new(big.Int).SetBytes(signature[:p256KeySize])
+	s := new(big.Int).SetBytes(signature[p256KeySize:])
+	digest := sha256.Sum256(doc)
+
+	if !ecdsa.Verify(&ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, digest[:], r, s) {
+		return errors.New("signature doesn't match")
+	}
+
+	return nil
+}
+
+// verifyES256K verifies the R || S signature of doc with the public key, a point of the secp256k1 curve
+func verifyES256K(pubKey, doc, signature []byte) error {
+	key, err := secp256k1.ParsePublicKey(pubKey)
+	if err != nil {
+		return err
+	}
+
+	if len(signature) != secp256k1.SignatureSize {
+		return errors.New("ecdsa: invalid signature size")
+	}
+
+	digest := sha256.Sum256(doc)
+
+	if !secp256k1.Verify(key, digest[:], signature) {
+		return errors.New("signature doesn't match")
+	}
+
+	return nil
+}
+
+// verifyPS256 verifies the RSASSA-PSS signature of doc with the public key, a PKIX or PKCS #1 DER RSA public key
+func verifyPS256(pubKey, doc, signature []byte) error {
+	key, err := parseRSAPublicKey(pubKey)
+	if err != nil {
+		return err
+	}
+
+	digest := sha256.Sum256(doc)
+
+	err = rsa.VerifyPSS(key, cryptoHash, digest[:], signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	if err != nil {
+		return errors.New("signature doesn't match")
+	}
+
+	return nil
+}
+
+func parseRSAPublicKey(pubKey []byte) (*rsa.PublicKey, error) {
+	if key, err := x509.ParsePKCS1PublicKey(pubKey); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKIXPublicKey(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("rsa: invalid public key: %w", err)
+	}
+
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("rsa: invalid public key")
+	}
+
+	return rsaKey, nil
+}
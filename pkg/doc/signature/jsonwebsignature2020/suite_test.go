@@ -0,0 +1,293 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jsonwebsignature2020
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/secp256k1"
+)
+
+func TestSignatureSuite_Sign(t *testing.T) {
+	doc := []byte("test doc")
+
+	ss := New(WithSigner(&mockSigner{
+		signature: []byte("test signature"),
+	}))
+	bytes, err := ss.Sign(doc)
+	require.NoError(t, err)
+	require.NotEmpty(t, bytes)
+
+	ss = New(WithSigner(&mockSigner{
+		err: errors.New("signature error"),
+	}))
+	bytes, err = ss.Sign(doc)
+	require.Error(t, err)
+	require.EqualError(t, err, "signature error")
+	require.Empty(t, bytes)
+
+	ss = New()
+	bytes, err = ss.Sign(doc)
+	require.Error(t, err)
+	require.Equal(t, ErrSignerNotDefined, err)
+	require.Empty(t, bytes)
+}
+
+func TestSignatureSuite_SignAndVerify(t *testing.T) {
+	edPubKey, edPrivKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	p256Signer, err := NewECDSASigner(p256Key)
+	require.NoError(t, err)
+
+	k1Key, err := ecdsa.GenerateKey(secp256k1.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	k1Signer, err := NewECDSASigner(k1Key)
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	rsaPubKey, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		signer signer
+		pubKey []byte
+		alg    string
+	}{
+		{"EdDSA", NewEd25519Signer(edPrivKey), edPubKey, EdDSA},
+		{"ES256", p256Signer, elliptic.Marshal(elliptic.P256(), p256Key.X, p256Key.Y), ES256},
+		{"ES256K", k1Signer, marshalPoint(k1Key), ES256K},
+		{"PS256 with PKIX key", NewRSASigner(rsaKey), rsaPubKey, PS256},
+		{"PS256 with PKCS #1 key", NewRSASigner(rsaKey), x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey), PS256},
+	}
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			ss := New(WithSigner(tc.signer))
+			require.Equal(t, tc.alg, ss.JWSAlgorithm())
+
+			doc := signingInput(tc.alg, "test doc")
+
+			signature, err := ss.Sign(doc)
+			require.NoError(t, err)
+
+			verifier := New()
+			require.NoError(t, verifier.Verify(tc.pubKey, doc, signature))
+
+			// test different message
+			err = verifier.Verify(tc.pubKey, signingInput(tc.alg, "different doc"), signature)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "signature doesn't match")
+		})
+	}
+}
+
+func TestSignatureSuite_VerifyErrors(t *testing.T) {
+	ss := New()
+
+	tests := []struct {
+		name   string
+		pubKey []byte
+		doc    []byte
+		sig    []byte
+		errMsg string
+	}{
+		{"missing JWS header", nil, []byte("test doc"), nil, "JWS header is missing"},
+		{"invalid JWS header encoding", nil, []byte("!.test doc"), nil, "decode JWS header"},
+		{"invalid JWS header", nil, []byte(base64.RawURLEncoding.EncodeToString([]byte("{")) + ".test doc"), nil,
+			"unmarshal JWS header"},
+		{"unsupported algorithm", nil, signingInput("RS256", "test doc"), nil, "JWS algorithm 'RS256' not supported"},
+		{"ed25519 key size", []byte("key"), signingInput(EdDSA, "test doc"), nil, "public key type not supported"},
+		{"ecdsa key size", []byte("key"), signingInput(ES256, "test doc"), nil, "public key type not supported"},
+		{"ecdsa point not on curve", append([]byte{uncompressedPoint}, make([]byte, 64)...),
+			signingInput(ES256K, "test doc"), nil, "ecdsa: invalid public key"},
+		{"rsa key", []byte("key"), signingInput(PS256, "test doc"), nil, "public key type not supported"},
+	}
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			err := ss.Verify(tc.pubKey, tc.doc, tc.sig)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+
+	t.Run("ecdsa signature size", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		err = ss.Verify(elliptic.Marshal(elliptic.P256(), key.X, key.Y), signingInput(ES256, "test doc"),
+			[]byte("signature"))
+		require.EqualError(t, err, "ecdsa: invalid signature size")
+	})
+
+	t.Run("rsa key of other type", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		pubKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		require.NoError(t, err)
+
+		err = ss.Verify(pubKey, signingInput(PS256, "test doc"), []byte("signature"))
+		require.EqualError(t, err, "public key type not supported")
+	})
+
+	t.Run("secp256k1 signature size", func(t *testing.T) {
+		key, err := secp256k1.GenerateKey()
+		require.NoError(t, err)
+
+		err = ss.Verify(secp256k1.MarshalPublicKey(&key.PublicKey), signingInput(ES256K, "test doc"),
+			[]byte("signature"))
+		require.EqualError(t, err, "ecdsa: invalid signature size")
+	})
+}
+
+func TestSignatureSuite_VerifyAlgorithmOfKey(t *testing.T) {
+	edPubKey, edPrivKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	p256PubKey := elliptic.Marshal(elliptic.P256(), p256Key.X, p256Key.Y)
+
+	k1Key, err := secp256k1.GenerateKey()
+	require.NoError(t, err)
+
+	k1PubKey := secp256k1.MarshalPublicKey(&k1Key.PublicKey)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		pubKey    []byte
+		headerAlg string
+		errMsg    string
+	}{
+		{"ES256 header with Ed25519 key", edPubKey, ES256, "JWS algorithm 'ES256' does not match the EdDSA public key"},
+		{"EdDSA header with P-256 key", p256PubKey, EdDSA, "JWS algorithm 'EdDSA' does not match the ES256 public key"},
+		{"ES256 header with secp256k1 key", k1PubKey, ES256, "JWS algorithm 'ES256' does not match the ES256K public key"},
+		{"ES256K header with P-256 key", p256PubKey, ES256K, "JWS algorithm 'ES256K' does not match the ES256 public key"},
+		{"EdDSA header with RSA key", x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey), EdDSA,
+			"JWS algorithm 'EdDSA' does not match the PS256 public key"},
+	}
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			err := New().Verify(tc.pubKey, signingInput(tc.headerAlg, "test doc"), []byte("signature"))
+			require.EqualError(t, err, tc.errMsg)
+		})
+	}
+
+	t.Run("signature of another algorithm with the header of the key", func(t *testing.T) {
+		// an Ed25519 signature replayed with the ES256 header of a P-256 key
+		signature, err := NewEd25519Signer(edPrivKey).Sign(signingInput(ES256, "test doc"))
+		require.NoError(t, err)
+
+		err = New().Verify(p256PubKey, signingInput(ES256, "test doc"), signature)
+		require.EqualError(t, err, "signature doesn't match")
+	})
+
+	t.Run("compressed secp256k1 key", func(t *testing.T) {
+		k1Signer, err := NewECDSASigner(k1Key)
+		require.NoError(t, err)
+
+		doc := signingInput(ES256K, "test doc")
+
+		signature, err := k1Signer.Sign(doc)
+		require.NoError(t, err)
+
+		require.NoError(t, New().Verify(secp256k1.MarshalCompressedPublicKey(&k1Key.PublicKey), doc, signature))
+	})
+}
+
+func TestNewECDSASigner(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	_, err = NewECDSASigner(key)
+	require.EqualError(t, err, "ecdsa: curve not supported")
+}
+
+func TestEd25519Signer(t *testing.T) {
+	_, err := NewEd25519Signer([]byte("key")).Sign([]byte("test doc"))
+	require.EqualError(t, err, "ed25519: bad private key length")
+}
+
+func TestSignatureSuite_GetCanonicalDocument(t *testing.T) {
+	doc, err := New().GetCanonicalDocument(map[string]interface{}{
+		"@context": map[string]interface{}{
+			"dc": "http://purl.org/dc/terms/",
+		},
+		"@id":      "http://example.org/fact1",
+		"dc:title": "Hello World!",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "<http://example.org/fact1> <http://purl.org/dc/terms/title> \"Hello World!\" .\n", string(doc))
+
+	_, err = New().GetCanonicalDocument(map[string]interface{}{"@context": 1})
+	require.Error(t, err)
+}
+
+func TestSignatureSuite_GetDigest(t *testing.T) {
+	digest := New().GetDigest([]byte("test doc"))
+	require.Len(t, digest, 32)
+}
+
+func TestSignatureSuite_Accept(t *testing.T) {
+	ss := New()
+	require.True(t, ss.Accept("JsonWebSignature2020"))
+	require.False(t, ss.Accept("Ed25519Signature2018"))
+	require.Empty(t, ss.JWSAlgorithm())
+}
+
+// signingInput returns the signing input of a detached JWS of the algorithm
+func signingInput(alg, doc string) []byte {
+	return []byte(proof.CreateDetachedJWTHeader(alg) + "." + doc)
+}
+
+func marshalPoint(key *ecdsa.PrivateKey) []byte {
+	return append(append([]byte{uncompressedPoint}, padLeft(key.X.Bytes(), 32)...), padLeft(key.Y.Bytes(), 32)...)
+}
+
+type mockSigner struct {
+	signature []byte
+	err       error
+}
+
+func (s *mockSigner) Sign(_ []byte) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return s.signature, nil
+}
+
+func (s *mockSigner) Alg() string {
+	return EdDSA
+}
@@ -10,7 +10,13 @@ import (
 	"fmt"
 )
 
-const jsonldContext = "@context"
+const (
+	jsonldContext = "@context"
+
+	// bbsBlsSignatureProof2020 is the type of the BBS+ selective disclosure proofs derived from the
+	// BbsBlsSignature2020 proofs, the nonce of the derived proofs is not part of the signed proof options
+	bbsBlsSignatureProof2020 = "BbsBlsSignatureProof2020"
+)
 
 // signatureSuite encapsulates signature suite methods required for normalizing document
 type signatureSuite interface {
@@ -35,12 +41,20 @@ const (
 
 // CreateVerifyData creates data that is used to generate or verify a digital signature.
 // It depends on the signature value holder type.
-// In case of "proofValue", the standard Create Verify Hash algorithm is used.
+// In case of "proofValue", the standard Create Verify Hash algorithm is used, the nonce of the
+// BbsBlsSignatureProof2020 proofs is excluded from the proof options: the derived proofs reveal the
+// proof options signed without nonce, the nonce is bound to the proof value and verified by the suite.
 // In case of "jws", verify data is built as JSON Web Signature (JWS) with detached payload.
 func CreateVerifyData(suite signatureSuite, jsonldDoc map[string]interface{}, proof *Proof) ([]byte, error) {
 	switch proof.SignatureRepresentation {
 	case SignatureProofValue:
-		return CreateVerifyHash(suite, jsonldDoc, proof.JSONLdObject())
+		proofOptions := proof.JSONLdObject()
+
+		if proof.Type == bbsBlsSignatureProof2020 {
+			delete(proofOptions, jsonldNonce)
+		}
+
+		return CreateVerifyHash(suite, jsonldDoc, proofOptions)
 	case SignatureJWS:
 		return createVerifyJWS(suite, jsonldDoc, proof)
 	}
@@ -90,6 +90,20 @@ func TestCreateVerifyData(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, normalizedDoc)
 
+	// the nonce of the derived BBS+ proofs is not part of the proof options
+	p.Nonce = []byte("nonce")
+	withNonce, err := CreateVerifyData(&mockSignatureSuite{}, doc, p)
+	require.NoError(t, err)
+	require.NotEqual(t, normalizedDoc, withNonce)
+
+	p.Type = bbsBlsSignatureProof2020
+	derivedProofDoc, err := CreateVerifyData(&mockSignatureSuite{}, doc, p)
+	require.NoError(t, err)
+	require.Equal(t, normalizedDoc, derivedProofDoc)
+
+	p.Type = "type"
+	p.Nonce = nil
+
 	p.SignatureRepresentation = SignatureJWS
 	p.JWS = "jws header.."
 	normalizedDoc, err = CreateVerifyData(&mockSignatureSuite{}, doc, p)
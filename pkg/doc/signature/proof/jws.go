@@ -22,10 +22,10 @@ const (
 	jwtSignaturePart = 2
 )
 
-// CreateDetachedJWTHeader creates detached JWT header.
-func CreateDetachedJWTHeader(p *Proof) string {
+// CreateDetachedJWTHeader creates detached JWT header of the algorithm alg.
+func CreateDetachedJWTHeader(alg string) string {
 	jwtHeaderMap := map[string]interface{}{
-		"alg":  p.Type,
+		"alg":  alg,
 		"b64":  false,
 		"crit": []string{"b64"},
 	}
@@ -16,10 +16,14 @@ const (
 	jsonldType = "type"
 	// jsonldCreator is key for creator
 	jsonldCreator = "creator"
+	// jsonldVerificationMethod is key for verification method
+	jsonldVerificationMethod = "verificationMethod"
 	// jsonldCreated is key for time proof created
 	jsonldCreated = "created"
 	// jsonldDomain is key for domain name
 	jsonldDomain = "domain"
+	// jsonldChallenge is key for challenge
+	jsonldChallenge = "challenge"
 	// jsonldNonce is key for nonce
 	jsonldNonce = "nonce"
 	// jsonldProofValue is key for proof value
@@ -35,10 +39,12 @@ type Proof struct {
 	Type                    string
 	Created                 *time.Time
 	Creator                 string
+	VerificationMethod      string
 	ProofValue              []byte
 	JWS                     string
 	ProofPurpose            string
 	Domain                  string
+	Challenge               string
 	Nonce                   []byte
 	SignatureRepresentation SignatureRepresentation
 }
@@ -83,15 +89,31 @@ func NewProof(emap map[string]interface{}) (*Proof, error) {
 		Type:                    stringEntry(emap[jsonldType]),
 		Created:                 &timeValue,
 		Creator:                 stringEntry(emap[jsonldCreator]),
+		VerificationMethod:      stringEntry(emap[jsonldVerificationMethod]),
 		ProofValue:              proofValue,
 		SignatureRepresentation: proofHolder,
 		JWS:                     jws,
 		ProofPurpose:            stringEntry(emap[jsonldProofPurpose]),
 		Domain:                  stringEntry(emap[jsonldDomain]),
+		Challenge:               stringEntry(emap[jsonldChallenge]),
 		Nonce:                   nonce,
 	}, nil
 }
 
+// PublicKeyID returns the ID of the public key verifying the proof, the verification method of the proof or its
+// creator if the verification method is not defined
+func (p *Proof) PublicKeyID() (string, error) {
+	if p.VerificationMethod != "" {
+		return p.VerificationMethod, nil
+	}
+
+	if p.Creator != "" {
+		return p.Creator, nil
+	}
+
+	return "", errors.New("no public key ID")
+}
+
 // stringEntry
 func stringEntry(entry interface{}) string {
 	if entry == nil {
@@ -110,6 +132,10 @@ func (p *Proof) JSONLdObject() map[string]interface{} {
 		emap[jsonldCreator] = p.Creator
 	}
 
+	if p.VerificationMethod != "" {
+		emap[jsonldVerificationMethod] = p.VerificationMethod
+	}
+
 	if p.Created != nil {
 		emap[jsonldCreated] = p.Created.Format(time.RFC3339)
 	}
@@ -126,6 +152,10 @@ func (p *Proof) JSONLdObject() map[string]interface{} {
 		emap[jsonldDomain] = p.Domain
 	}
 
+	if p.Challenge != "" {
+		emap[jsonldChallenge] = p.Challenge
+	}
+
 	if len(p.Nonce) > 0 {
 		emap[jsonldNonce] = base64.RawURLEncoding.EncodeToString(p.Nonce)
 	}
@@ -21,6 +21,7 @@ func TestProof(t *testing.T) {
 		"creator":    "didID",
 		"created":    "2018-03-15T00:00:00Z",
 		"domain":     "abc.com",
+		"challenge":  "challenge",
 		"nonce":      "",
 		"proofValue": proofValueBase64,
 	})
@@ -37,10 +38,49 @@ func TestProof(t *testing.T) {
 	require.Equal(t, "didID", p.Creator)
 	require.Equal(t, &created, p.Created)
 	require.Equal(t, "abc.com", p.Domain)
+	require.Equal(t, "challenge", p.Challenge)
 	require.Equal(t, []byte(""), p.Nonce)
 	require.Equal(t, proofValueBytes, p.ProofValue)
 }
 
+func TestProof_VerificationMethod(t *testing.T) {
+	p, err := NewProof(map[string]interface{}{
+		"type":               "JsonWebSignature2020",
+		"verificationMethod": "did:example:123#key-1",
+		"created":            "2018-03-15T00:00:00Z",
+		"proofPurpose":       "assertionMethod",
+		"jws":                "header..signature",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "did:example:123#key-1", p.VerificationMethod)
+	require.Empty(t, p.Creator)
+
+	publicKeyID, err := p.PublicKeyID()
+	require.NoError(t, err)
+	require.Equal(t, "did:example:123#key-1", publicKeyID)
+
+	pJSONLd := p.JSONLdObject()
+	require.Equal(t, "did:example:123#key-1", pJSONLd["verificationMethod"])
+	require.NotContains(t, pJSONLd, "creator")
+
+	t.Run("creator as fallback", func(t *testing.T) {
+		publicKeyID, err := (&Proof{Creator: "did:example:123#key-2"}).PublicKeyID()
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123#key-2", publicKeyID)
+
+		// the verification method has precedence over the creator
+		publicKeyID, err = (&Proof{Creator: "did:example:123#key-2", VerificationMethod: "did:example:123#key-1"}).
+			PublicKeyID()
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123#key-1", publicKeyID)
+	})
+
+	t.Run("no public key ID", func(t *testing.T) {
+		_, err := (&Proof{}).PublicKeyID()
+		require.EqualError(t, err, "no public key ID")
+	})
+}
+
 func TestInvalidProofValue(t *testing.T) {
 	// invalid proof value
 	p, err := NewProof(map[string]interface{}{
@@ -98,6 +138,7 @@ func TestProof_JSONLdObject(t *testing.T) {
 		JWS:          "test.jws.value",
 		ProofPurpose: "assertionMethod",
 		Domain:       "internal",
+		Challenge:    "challenge",
 		Nonce:        nonceBase64,
 	}
 
@@ -109,5 +150,6 @@ func TestProof_JSONLdObject(t *testing.T) {
 	r.Equal("test.jws.value", pJSONLd["jws"])
 	r.Equal("assertionMethod", pJSONLd["proofPurpose"])
 	r.Equal("internal", pJSONLd["domain"])
+	r.Equal("challenge", pJSONLd["challenge"])
 	r.Equal("abc", pJSONLd["nonce"])
 }
@@ -31,6 +31,14 @@ type signatureSuite interface {
 	Sign(doc []byte) ([]byte, error)
 }
 
+// jwsAlgorithmSuite is implemented by the signature suites defining the algorithm of the detached JWS they sign,
+// the signature type is the algorithm of the JWS otherwise
+type jwsAlgorithmSuite interface {
+
+	// JWSAlgorithm returns the algorithm of the JWS header
+	JWSAlgorithm() string
+}
+
 // DocumentSigner implements signing of JSONLD documents
 type DocumentSigner struct {
 	signatureSuites []signatureSuite
@@ -39,11 +47,14 @@ type DocumentSigner struct {
 // Context holds signing options and private key
 type Context struct {
 	SignatureType           string                        // required
-	Creator                 string                        // required
+	Creator                 string                        // required if VerificationMethod is not defined
+	VerificationMethod      string                        // optional
 	SignatureRepresentation proof.SignatureRepresentation // optional
 	Created                 *time.Time                    // optional
 	Domain                  string                        // optional
 	Nonce                   []byte                        // optional
+	ProofPurpose            string                        // optional
+	Challenge               string                        // optional
 }
 
 // New returns new instance of document verifier
@@ -94,13 +105,16 @@ func (signer *DocumentSigner) signObject(context *Context, jsonLdObject map[stri
 		Type:                    context.SignatureType,
 		SignatureRepresentation: context.SignatureRepresentation,
 		Creator:                 context.Creator,
+		VerificationMethod:      context.VerificationMethod,
 		Created:                 created,
 		Domain:                  context.Domain,
 		Nonce:                   context.Nonce,
+		ProofPurpose:            context.ProofPurpose,
+		Challenge:               context.Challenge,
 	}
 
 	if context.SignatureRepresentation == proof.SignatureJWS {
-		p.JWS = proof.CreateDetachedJWTHeader(p) + ".."
+		p.JWS = proof.CreateDetachedJWTHeader(jwsAlgorithm(suite, p.Type)) + ".."
 	}
 
 	message, err := proof.CreateVerifyData(suite, jsonLdObject, p)
@@ -127,6 +141,15 @@ func (signer *DocumentSigner) applySignatureValue(context *Context, p *proof.Pro
 	}
 }
 
+// jwsAlgorithm returns the JWS algorithm of the suite, the signature type if not defined by the suite
+func jwsAlgorithm(suite signatureSuite, signatureType string) string {
+	if s, ok := suite.(jwsAlgorithmSuite); ok && s.JWSAlgorithm() != "" {
+		return s.JWSAlgorithm()
+	}
+
+	return signatureType
+}
+
 // getSignatureSuite returns signature suite based on signature type
 func (signer *DocumentSigner) getSignatureSuite(signatureType string) (signatureSuite, error) {
 	for _, s := range signer.signatureSuites {
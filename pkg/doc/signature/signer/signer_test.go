@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonwebsignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
 )
 
@@ -34,6 +35,22 @@ func TestDocumentSigner_Sign(t *testing.T) {
 	signedJWSDoc, err := s.Sign(context, []byte(validDoc))
 	require.NoError(t, err)
 	require.NotNil(t, signedJWSDoc)
+
+	context.ProofPurpose = "authentication"
+	context.Challenge = "challenge"
+	context.Domain = "example.com"
+	signedDoc, err = s.Sign(context, []byte(validDoc))
+	require.NoError(t, err)
+
+	var signedDocMap map[string]interface{}
+	require.NoError(t, json.Unmarshal(signedDoc, &signedDocMap))
+
+	proofs, err := proof.GetProofs(signedDocMap)
+	require.NoError(t, err)
+	require.Len(t, proofs, 1)
+	require.Equal(t, "authentication", proofs[0].ProofPurpose)
+	require.Equal(t, "challenge", proofs[0].Challenge)
+	require.Equal(t, "example.com", proofs[0].Domain)
 }
 
 func TestDocumentSigner_SignErrors(t *testing.T) {
@@ -94,6 +111,16 @@ func TestDocumentSigner_isValidContext(t *testing.T) {
 	require.Contains(t, err.Error(), "signature type is missing")
 }
 
+func TestJWSAlgorithm(t *testing.T) {
+	suite := jsonwebsignature2020.New(jsonwebsignature2020.WithSigner(
+		jsonwebsignature2020.NewEd25519Signer(generatePrivateKey())))
+	require.Equal(t, jsonwebsignature2020.EdDSA, jwsAlgorithm(suite, "JsonWebSignature2020"))
+
+	// the signature type is the algorithm of the suites not defining one
+	require.Equal(t, "JsonWebSignature2020", jwsAlgorithm(jsonwebsignature2020.New(), "JsonWebSignature2020"))
+	require.Equal(t, signatureType, jwsAlgorithm(ed25519signature2018.New(), signatureType))
+}
+
 func getSignatureContext() *Context {
 	return &Context{Creator: "creator",
 		SignatureType: signatureType}
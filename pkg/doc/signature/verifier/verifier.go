@@ -7,7 +7,9 @@ package verifier
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
@@ -29,6 +31,14 @@ type signatureSuite interface {
 	Accept(signatureType string) bool
 }
 
+// proofNonceSuite is implemented by the signature suites verifying proofs bound to the nonce of the proof,
+// e.g. the BbsBlsSignatureProof2020 selective disclosure proofs
+type proofNonceSuite interface {
+
+	// VerifyProof will verify the proof bound to the nonce against public key
+	VerifyProof(pubKey []byte, doc []byte, proof []byte, nonce []byte) error
+}
+
 // keyResolver encapsulates key resolution
 type keyResolver interface {
 
@@ -36,6 +46,60 @@ type keyResolver interface {
 	Resolve(id string) ([]byte, error)
 }
 
+// proofPurposeValidator encapsulates the check of the verification relationships
+type proofPurposeValidator interface {
+
+	// ValidateProofPurpose checks that the verification method is authorized for the proof purpose
+	ValidateProofPurpose(verificationMethod, purpose string) error
+}
+
+// verificationOpts holds the checks of the proofs made besides the signature verification
+type verificationOpts struct {
+	purpose          string
+	purposeValidator proofPurposeValidator
+	challenge        string
+	domain           string
+	checkCreated     bool
+	maxAge           time.Duration
+	clockSkew        time.Duration
+}
+
+// VerificationOpt is the option of the document proofs verification
+type VerificationOpt func(opts *verificationOpts)
+
+// WithProofPurpose requires the proofs to have the proof purpose (e.g. assertionMethod or authentication).
+// The verification method of the proofs is checked to be authorized for the purpose by the validator if defined.
+func WithProofPurpose(purpose string, validator proofPurposeValidator) VerificationOpt {
+	return func(opts *verificationOpts) {
+		opts.purpose = purpose
+		opts.purposeValidator = validator
+	}
+}
+
+// WithChallenge requires the proofs to have the challenge.
+func WithChallenge(challenge string) VerificationOpt {
+	return func(opts *verificationOpts) {
+		opts.challenge = challenge
+	}
+}
+
+// WithDomain requires the proofs to have the domain.
+func WithDomain(domain string) VerificationOpt {
+	return func(opts *verificationOpts) {
+		opts.domain = domain
+	}
+}
+
+// WithProofTimeWindow requires the proofs to be created at most maxAge ago, and not later than clockSkew
+// in the future. The age of the proofs is not limited if maxAge is 0.
+func WithProofTimeWindow(maxAge, clockSkew time.Duration) VerificationOpt {
+	return func(opts *verificationOpts) {
+		opts.checkCreated = true
+		opts.maxAge = maxAge
+		opts.clockSkew = clockSkew
+	}
+}
+
 // DocumentVerifier implements JSON LD document proof verification
 type DocumentVerifier struct {
 	signatureSuites []signatureSuite
@@ -51,8 +115,9 @@ func New(resolver keyResolver, signatureSuites ...signatureSuite) *DocumentVerif
 	return &DocumentVerifier{signatureSuites: signatureSuites, pkResolver: resolver}
 }
 
-// Verify will verify document proofs
-func (dv *DocumentVerifier) Verify(jsonLdDoc []byte) error {
+// Verify will verify document proofs, the options define the checks of the proofs made besides
+// the signature verification
+func (dv *DocumentVerifier) Verify(jsonLdDoc []byte, opts ...VerificationOpt) error {
 	var jsonLdObject map[string]interface{}
 
 	err := json.Unmarshal(jsonLdDoc, &jsonLdObject)
@@ -60,18 +125,34 @@ func (dv *DocumentVerifier) Verify(jsonLdDoc []byte) error {
 		return fmt.Errorf("failed to unmarshal json ld document: %w", err)
 	}
 
-	return dv.verifyObject(jsonLdObject)
+	vOpts := &verificationOpts{}
+
+	for _, opt := range opts {
+		opt(vOpts)
+	}
+
+	return dv.verifyObject(jsonLdObject, vOpts)
 }
 
 // verifyObject will verify document proofs for JSON LD object
-func (dv *DocumentVerifier) verifyObject(jsonLdObject map[string]interface{}) error {
+func (dv *DocumentVerifier) verifyObject(jsonLdObject map[string]interface{}, opts *verificationOpts) error {
 	proofs, err := proof.GetProofs(jsonLdObject)
 	if err != nil {
 		return err
 	}
 
 	for _, p := range proofs {
-		publicKey, err := dv.pkResolver.Resolve(p.Creator)
+		err = checkProofOptions(p, opts)
+		if err != nil {
+			return err
+		}
+
+		publicKeyID, err := p.PublicKeyID()
+		if err != nil {
+			return err
+		}
+
+		publicKey, err := dv.pkResolver.Resolve(publicKeyID)
 		if err != nil {
 			return err
 		}
@@ -91,7 +172,7 @@ func (dv *DocumentVerifier) verifyObject(jsonLdObject map[string]interface{}) er
 			return err
 		}
 
-		err = suite.Verify(publicKey, message, signature)
+		err = verifySignature(suite, p, publicKey, message, signature)
 		if err != nil {
 			return err
 		}
@@ -100,6 +181,60 @@ func (dv *DocumentVerifier) verifyObject(jsonLdObject map[string]interface{}) er
 	return nil
 }
 
+// checkProofOptions checks the purpose, challenge, domain and creation time of the proof
+func checkProofOptions(p *proof.Proof, opts *verificationOpts) error {
+	if opts.purpose != "" {
+		if p.ProofPurpose != opts.purpose {
+			return fmt.Errorf("proof purpose %s does not match the expected purpose %s", p.ProofPurpose, opts.purpose)
+		}
+
+		if opts.purposeValidator != nil {
+			publicKeyID, err := p.PublicKeyID()
+			if err != nil {
+				return fmt.Errorf("validate proof purpose: %w", err)
+			}
+
+			err = opts.purposeValidator.ValidateProofPurpose(publicKeyID, p.ProofPurpose)
+			if err != nil {
+				return fmt.Errorf("validate proof purpose: %w", err)
+			}
+		}
+	}
+
+	if opts.challenge != "" && p.Challenge != opts.challenge {
+		return errors.New("proof challenge does not match the expected challenge")
+	}
+
+	if opts.domain != "" && p.Domain != opts.domain {
+		return fmt.Errorf("proof domain %s does not match the expected domain %s", p.Domain, opts.domain)
+	}
+
+	if opts.checkCreated {
+		return checkProofCreated(p.Created, opts.maxAge, opts.clockSkew)
+	}
+
+	return nil
+}
+
+// checkProofCreated checks that the proof was created within the time window
+func checkProofCreated(created *time.Time, maxAge, clockSkew time.Duration) error {
+	if created == nil {
+		return errors.New("proof creation time is not defined")
+	}
+
+	now := time.Now()
+
+	if created.After(now.Add(clockSkew)) {
+		return fmt.Errorf("proof created in the future: %s", created.Format(time.RFC3339))
+	}
+
+	if maxAge > 0 && created.Before(now.Add(-maxAge)) {
+		return fmt.Errorf("proof expired, created at %s", created.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
 // getSignatureSuite returns signature suite based on signature type
 func (dv *DocumentVerifier) getSignatureSuite(signatureType string) (signatureSuite, error) {
 	for _, s := range dv.signatureSuites {
@@ -111,6 +246,16 @@ func (dv *DocumentVerifier) getSignatureSuite(signatureType string) (signatureSu
 	return nil, fmt.Errorf("signature type %s not supported", signatureType)
 }
 
+// verifySignature verifies the signature of the message, bound to the nonce of the proof if the suite
+// verifies such proofs
+func verifySignature(suite signatureSuite, p *proof.Proof, publicKey, message, signature []byte) error {
+	if s, ok := suite.(proofNonceSuite); ok {
+		return s.VerifyProof(publicKey, message, signature, p.Nonce)
+	}
+
+	return suite.Verify(publicKey, message, signature)
+}
+
 func getProofVerifyValue(p *proof.Proof) ([]byte, error) {
 	switch p.SignatureRepresentation {
 	case proof.SignatureProofValue:
@@ -11,7 +11,9 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -70,7 +72,7 @@ func TestVerifyObject(t *testing.T) {
 
 	// happy path
 	v := New(tkr, suite)
-	err = v.verifyObject(jsonLdObject)
+	err = v.verifyObject(jsonLdObject, &verificationOpts{})
 	require.Nil(t, err)
 
 	// test invalid signature suite
@@ -81,13 +83,13 @@ func TestVerifyObject(t *testing.T) {
 	err = proof.AddProof(jsonLdObject, proofs[0])
 	require.NoError(t, err)
 
-	err = v.verifyObject(jsonLdObject)
+	err = v.verifyObject(jsonLdObject, &verificationOpts{})
 	require.NotNil(t, err)
 	require.Contains(t, err.Error(), "signature type non-existent not supported")
 
 	// test key resolver error - key not found
 	v = New(&testKeyResolver{}, suite)
-	err = v.verifyObject(jsonLdObject)
+	err = v.verifyObject(jsonLdObject, &verificationOpts{})
 	require.NotNil(t, err)
 	require.Contains(t, err.Error(), "key not found")
 
@@ -101,7 +103,7 @@ func TestVerifyObject(t *testing.T) {
 	require.NoError(t, err)
 
 	v = New(tkr, suite)
-	err = v.verifyObject(jsonLdObject)
+	err = v.verifyObject(jsonLdObject, &verificationOpts{})
 	require.NotNil(t, err)
 	require.Contains(t, err.Error(), "signature doesn't match")
 }
@@ -114,7 +116,7 @@ func TestVerifyJWSObject(t *testing.T) {
 
 	// happy path
 	v := New(tkr)
-	err = v.verifyObject(jsonLdObject)
+	err = v.verifyObject(jsonLdObject, &verificationOpts{})
 	require.Nil(t, err)
 
 	// test invalid signature suite
@@ -126,11 +128,135 @@ func TestVerifyJWSObject(t *testing.T) {
 	err = proof.AddProof(jsonLdObject, proofs[0])
 	require.NoError(t, err)
 
-	err = v.verifyObject(jsonLdObject)
+	err = v.verifyObject(jsonLdObject, &verificationOpts{})
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "invalid JWT")
 }
 
+func TestVerifyWithOptions(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	const creator = "did:example:123456789abcdefghi#key-1"
+
+	docBytes, err := json.Marshal(getDefaultDoc())
+	require.NoError(t, err)
+
+	s := signer.New(ed25519signature2018.New(ed25519signature2018.WithSigner(getSigner(privKey))))
+
+	signedDocBytes, err := s.Sign(&signer.Context{
+		Creator:       creator,
+		SignatureType: "Ed25519Signature2018",
+		ProofPurpose:  "authentication",
+		Challenge:     "challenge",
+		Domain:        "example.com",
+	}, docBytes)
+	require.NoError(t, err)
+
+	v := New(&testKeyResolver{Keys: map[string][]byte{creator: pubKey}})
+
+	validator := &testProofPurposeValidator{purposes: map[string]string{creator: "authentication"}}
+
+	err = v.Verify(signedDocBytes,
+		WithProofPurpose("authentication", validator),
+		WithChallenge("challenge"),
+		WithDomain("example.com"),
+		WithProofTimeWindow(time.Minute, time.Minute))
+	require.NoError(t, err)
+
+	err = v.Verify(signedDocBytes, WithChallenge("other challenge"))
+	require.EqualError(t, err, "proof challenge does not match the expected challenge")
+
+	err = v.Verify(signedDocBytes, WithDomain("other.com"))
+	require.EqualError(t, err, "proof domain example.com does not match the expected domain other.com")
+
+	err = v.Verify(signedDocBytes, WithProofPurpose("assertionMethod", nil))
+	require.EqualError(t, err,
+		"proof purpose authentication does not match the expected purpose assertionMethod")
+
+	err = v.Verify(signedDocBytes, WithProofPurpose("authentication", &testProofPurposeValidator{}))
+	require.EqualError(t, err, "validate proof purpose: verification method "+creator+
+		" is not authorized for authentication")
+
+	t.Run("proof with verification method", func(t *testing.T) {
+		vmDocBytes, err := s.Sign(&signer.Context{
+			VerificationMethod: creator,
+			SignatureType:      "Ed25519Signature2018",
+			ProofPurpose:       "authentication",
+		}, docBytes)
+		require.NoError(t, err)
+
+		err = v.Verify(vmDocBytes, WithProofPurpose("authentication", validator))
+		require.NoError(t, err)
+
+		err = v.Verify(vmDocBytes, WithProofPurpose("authentication", &testProofPurposeValidator{}))
+		require.EqualError(t, err, "validate proof purpose: verification method "+creator+
+			" is not authorized for authentication")
+	})
+}
+
+func Test_checkProofOptions(t *testing.T) {
+	now := time.Now()
+
+	p := &proof.Proof{
+		Creator:      "did:example:123#key-1",
+		Created:      &now,
+		ProofPurpose: "assertionMethod",
+		Challenge:    "challenge",
+		Domain:       "example.com",
+	}
+
+	checkOptions := func(opts ...VerificationOpt) error {
+		vOpts := &verificationOpts{}
+
+		for _, opt := range opts {
+			opt(vOpts)
+		}
+
+		return checkProofOptions(p, vOpts)
+	}
+
+	require.NoError(t, checkOptions())
+	require.NoError(t, checkOptions(WithProofPurpose("assertionMethod", nil), WithChallenge("challenge"),
+		WithDomain("example.com"), WithProofTimeWindow(0, 0)))
+
+	t.Run("proof time window", func(t *testing.T) {
+		require.NoError(t, checkOptions(WithProofTimeWindow(time.Hour, time.Minute)))
+
+		created := now.Add(-2 * time.Hour)
+		p.Created = &created
+
+		err := checkOptions(WithProofTimeWindow(time.Hour, time.Minute))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "proof expired")
+
+		// the age of the proof is not limited
+		require.NoError(t, checkOptions(WithProofTimeWindow(0, time.Minute)))
+
+		created = now.Add(time.Hour)
+		p.Created = &created
+
+		err = checkOptions(WithProofTimeWindow(0, time.Minute))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "proof created in the future")
+
+		p.Created = nil
+
+		err = checkOptions(WithProofTimeWindow(0, time.Minute))
+		require.EqualError(t, err, "proof creation time is not defined")
+
+		// the creation time is not checked without time window
+		require.NoError(t, checkOptions())
+	})
+
+	t.Run("proof purpose without public key ID", func(t *testing.T) {
+		p.Creator = ""
+
+		err := checkOptions(WithProofPurpose("assertionMethod", &testProofPurposeValidator{}))
+		require.EqualError(t, err, "validate proof purpose: no public key ID")
+	})
+}
+
 func Test_getProofVerifyValue(t *testing.T) {
 	jwsSignature := base64.RawURLEncoding.EncodeToString([]byte("signature"))
 
@@ -241,6 +367,18 @@ func (r *testKeyResolver) Resolve(id string) ([]byte, error) {
 	return key, nil
 }
 
+type testProofPurposeValidator struct {
+	purposes map[string]string
+}
+
+func (v *testProofPurposeValidator) ValidateProofPurpose(verificationMethod, purpose string) error {
+	if v.purposes[verificationMethod] != purpose {
+		return fmt.Errorf("verification method %s is not authorized for %s", verificationMethod, purpose)
+	}
+
+	return nil
+}
+
 //nolint:lll
 const validDoc = `{
   "@context": ["https://w3id.org/did/v1"],
@@ -17,15 +17,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/square/go-jose/v3"
 	"github.com/xeipuuv/gojsonschema"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	vdripkg "github.com/hyperledger/aries-framework-go/pkg/vdri"
 )
 
-// TODO https://github.com/square/go-jose/issues/263 support ES256K
-
 // JWSAlgorithm defines JWT signature algorithms of Verifiable Credential
 type JWSAlgorithm int
 
@@ -35,6 +36,9 @@ const (
 
 	// EdDSA JWT Algorithm
 	EdDSA
+
+	// ES256K JWT Algorithm, ECDSA with the secp256k1 curve (see ecdsasecp256k1signature2019.S256)
+	ES256K
 )
 
 // jose converts JWSAlgorithm to JOSE one.
@@ -44,6 +48,8 @@ func (ja JWSAlgorithm) jose() (jose.SignatureAlgorithm, error) {
 		return jose.RS256, nil
 	case EdDSA:
 		return jose.EdDSA, nil
+	case ES256K:
+		return joseES256K, nil
 	default:
 		return "", fmt.Errorf("unsupported algorithm: %v", ja)
 	}
@@ -65,27 +71,35 @@ func SingleKey(pubKey interface{}) PublicKeyFetcher {
 // A source of DID could be issuer of VC or holder of VP. It can be also obtained from
 // JWS "issuer" claim or "verificationMethod" of Linked Data Proof.
 type DIDKeyResolver struct {
-	vdriRegistry vdri.Registry
+	dereferencer *vdripkg.Dereferencer
 }
 
 // NewDIDKeyResolver creates DIDKeyResolver.
 func NewDIDKeyResolver(vdriRegistry vdri.Registry) *DIDKeyResolver {
-	return &DIDKeyResolver{vdriRegistry: vdriRegistry}
+	return &DIDKeyResolver{dereferencer: vdripkg.NewDereferencer(vdriRegistry)}
 }
 
 func (r *DIDKeyResolver) resolvePublicKey(issuerDID, keyID string) (interface{}, error) {
-	doc, err := r.vdriRegistry.Resolve(issuerDID)
-	if err != nil {
+	result, err := r.dereferencer.Dereference(keyURL(issuerDID, keyID))
+
+	switch {
+	case errors.Is(err, did.ErrInvalidDIDURL), errors.Is(err, vdripkg.ErrFragmentNotFound),
+		err == nil && result.VerificationMethod == nil:
+		return nil, fmt.Errorf("public key with KID %s is not found for DID %s", keyID, issuerDID)
+	case err != nil:
 		return nil, fmt.Errorf("resolve DID %s: %w", issuerDID, err)
 	}
 
-	for _, key := range doc.PublicKey {
-		if key.ID == keyID {
-			return key.Value, nil
-		}
+	return result.VerificationMethod.Value, nil
+}
+
+// keyURL returns the DID URL of the key, the key ID is either a DID URL or a fragment relative to the DID
+func keyURL(issuerDID, keyID string) string {
+	if strings.HasPrefix(keyID, "did:") {
+		return keyID
 	}
 
-	return nil, fmt.Errorf("public key with KID %s is not found for DID %s", keyID, issuerDID)
+	return issuerDID + "#" + strings.TrimPrefix(keyID, "#")
 }
 
 // PublicKeyFetcher returns Public Key Fetcher via DID resolution mechanism.
@@ -93,6 +107,47 @@ func (r *DIDKeyResolver) PublicKeyFetcher() PublicKeyFetcher {
 	return r.resolvePublicKey
 }
 
+// ValidateProofPurpose checks that the verification method, a DID URL, is authorized for the proof purpose
+// by the verification relationships of the DID document (e.g. assertionMethod or authentication).
+func (r *DIDKeyResolver) ValidateProofPurpose(verificationMethod, purpose string) error {
+	relationship, err := proofPurposeRelationship(purpose)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.dereferencer.Dereference(verificationMethod)
+	if err != nil {
+		return fmt.Errorf("resolve verification method %s: %w", verificationMethod, err)
+	}
+
+	if result.VerificationMethod == nil {
+		return fmt.Errorf("verification method %s is not found", verificationMethod)
+	}
+
+	if _, ok := result.Document.LookupVerificationMethod("#"+result.DIDURL.Fragment, relationship); !ok {
+		return fmt.Errorf("verification method %s is not authorized for proof purpose %s", verificationMethod, purpose)
+	}
+
+	return nil
+}
+
+// proofPurposeRelationship returns the verification relationship authorizing the verification methods
+// for the proof purpose
+func proofPurposeRelationship(purpose string) (did.VerificationRelationship, error) {
+	switch purpose {
+	case "assertionMethod":
+		return did.AssertionMethod, nil
+	case "authentication":
+		return did.Authentication, nil
+	case "capabilityInvocation":
+		return did.CapabilityInvocation, nil
+	case "capabilityDelegation":
+		return did.CapabilityDelegation, nil
+	default:
+		return -1, fmt.Errorf("unsupported proof purpose %s", purpose)
+	}
+}
+
 // Proof defines embedded proof of Verifiable Credential
 type Proof map[string]interface{}
 
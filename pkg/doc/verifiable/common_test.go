@@ -19,9 +19,11 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/internal/mock/provider"
 	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/vdri/key"
 )
 
 func TestJwtAlgorithm_Jose(t *testing.T) {
@@ -33,6 +35,10 @@ func TestJwtAlgorithm_Jose(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, jose.EdDSA, joseAlg)
 
+	joseAlg, err = ES256K.jose()
+	require.NoError(t, err)
+	require.Equal(t, jose.SignatureAlgorithm("ES256K"), joseAlg)
+
 	// not supported alg
 	sa, err := JWSAlgorithm(-1).jose()
 	require.Error(t, err)
@@ -254,6 +260,16 @@ func TestDIDKeyResolver_Resolve(t *testing.T) {
 	r.NoError(err)
 	r.Equal(publicKey.Value, pubKey)
 
+	// key ID relative to the DID
+	pubKey, err = resolver.PublicKeyFetcher()(didDoc.ID, "#keys-1")
+	r.NoError(err)
+	r.Equal(publicKey.Value, pubKey)
+
+	// the fragment of a service is not a public key
+	pubKey, err = resolver.PublicKeyFetcher()(didDoc.ID, didDoc.Service[0].ID)
+	r.EqualError(err, fmt.Sprintf("public key with KID %s is not found for DID %s", didDoc.Service[0].ID, didDoc.ID))
+	r.Nil(pubKey)
+
 	pubKey, err = resolver.PublicKeyFetcher()(didDoc.ID, "invalid key")
 	r.Error(err)
 	r.EqualError(err, fmt.Sprintf("public key with KID invalid key is not found for DID %s", didDoc.ID))
@@ -266,6 +282,57 @@ func TestDIDKeyResolver_Resolve(t *testing.T) {
 	r.Nil(pubKey)
 }
 
+func TestDIDKeyResolver_ValidateProofPurpose(t *testing.T) {
+	r := require.New(t)
+
+	didDoc := createDIDDoc()
+	publicKey := didDoc.PublicKey[0]
+	didDoc.AssertionMethod = []did.VerificationMethod{{PublicKey: publicKey}}
+
+	v := &mockvdri.MockVDRIRegistry{
+		ResolveValue: didDoc,
+	}
+
+	resolver := NewDIDKeyResolver(v)
+
+	r.NoError(resolver.ValidateProofPurpose(publicKey.ID, "assertionMethod"))
+
+	err := resolver.ValidateProofPurpose(publicKey.ID, "authentication")
+	r.EqualError(err, fmt.Sprintf("verification method %s is not authorized for proof purpose authentication",
+		publicKey.ID))
+
+	err = resolver.ValidateProofPurpose(publicKey.ID, "keyAgreement")
+	r.EqualError(err, "unsupported proof purpose keyAgreement")
+
+	err = resolver.ValidateProofPurpose(didDoc.ID, "assertionMethod")
+	r.EqualError(err, fmt.Sprintf("verification method %s is not found", didDoc.ID))
+
+	err = resolver.ValidateProofPurpose(didDoc.ID+"#unknown", "assertionMethod")
+	r.Error(err)
+	r.Contains(err.Error(), "resolve verification method")
+
+	v.ResolveErr = errors.New("resolver error")
+	err = resolver.ValidateProofPurpose(publicKey.ID, "assertionMethod")
+	r.EqualError(err, fmt.Sprintf("resolve verification method %s: resolver error", publicKey.ID))
+
+	t.Run("did:key verification method", func(t *testing.T) {
+		const didKey = "did:key:z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+
+		resolver := NewDIDKeyResolver(&mockvdri.MockVDRIRegistry{
+			ResolveFunc: func(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error) {
+				return key.New().Read(didID, opts...)
+			},
+		})
+
+		verificationMethod := didKey + "#z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+
+		for _, purpose := range []string{"assertionMethod", "authentication", "capabilityInvocation",
+			"capabilityDelegation"} {
+			require.NoError(t, resolver.ValidateProofPurpose(verificationMethod, purpose))
+		}
+	})
+}
+
 func createDIDDoc() *did.Doc {
 	pubKey, _ := generateKeyPair()
 	return createDIDDocWithKey(pubKey)
@@ -18,6 +18,7 @@ import (
 	"github.com/xeipuuv/gojsonschema"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 )
 
 //go:generate testdata/scripts/openssl_env.sh testdata/scripts/generate_test_keys.sh
@@ -480,9 +481,11 @@ type credentialOpts struct {
 	allowedCustomContexts map[string]bool
 	allowedCustomTypes    map[string]bool
 	disabledProofCheck    bool
+	requiredProof         bool
 	jsonldDocumentLoader  ld.DocumentLoader
 	strictValidation      bool
-	ldpSuite              verifierSignatureSuite
+	ldpSuites             []verifierSignatureSuite
+	ldpVerificationOpts   []verifier.VerificationOpt
 }
 
 // CredentialOpt is the Verifiable Credential decoding option
@@ -570,10 +573,48 @@ func WithStrictValidation() CredentialOpt {
 	}
 }
 
-// WithEmbeddedSignatureSuites defines the suite which is used to check embedded linked data proof of VC.
-func WithEmbeddedSignatureSuites(suite verifierSignatureSuite) CredentialOpt {
+// withRequiredProof rejects the credentials without a proof, JWS or embedded one.
+func withRequiredProof() CredentialOpt {
 	return func(opts *credentialOpts) {
-		opts.ldpSuite = suite
+		opts.requiredProof = true
+	}
+}
+
+// WithEmbeddedSignatureSuites defines the suites which are used to check embedded linked data proof of VC.
+func WithEmbeddedSignatureSuites(suites ...verifierSignatureSuite) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.ldpSuites = suites
+	}
+}
+
+// WithProofPurpose requires the embedded linked data proof of VC to have the proof purpose
+// (e.g. assertionMethod). The verification method of the proof is checked to be authorized for the purpose
+// by the validator if defined (e.g. DIDKeyResolver).
+func WithProofPurpose(purpose string, validator proofPurposeValidator) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.ldpVerificationOpts = append(opts.ldpVerificationOpts, verifier.WithProofPurpose(purpose, validator))
+	}
+}
+
+// WithProofChallenge requires the embedded linked data proof of VC to have the challenge.
+func WithProofChallenge(challenge string) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.ldpVerificationOpts = append(opts.ldpVerificationOpts, verifier.WithChallenge(challenge))
+	}
+}
+
+// WithProofDomain requires the embedded linked data proof of VC to have the domain.
+func WithProofDomain(domain string) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.ldpVerificationOpts = append(opts.ldpVerificationOpts, verifier.WithDomain(domain))
+	}
+}
+
+// WithProofTimeWindow requires the embedded linked data proof of VC to be created at most maxAge ago,
+// and not later than clockSkew in the future. The age of the proof is not limited if maxAge is 0.
+func WithProofTimeWindow(maxAge, clockSkew time.Duration) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.ldpVerificationOpts = append(opts.ldpVerificationOpts, verifier.WithProofTimeWindow(maxAge, clockSkew))
 	}
 }
 
@@ -0,0 +1,71 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/bbsblssignatureproof2020"
+)
+
+// GenerateBBSSelectiveDisclosure derives from the BbsBlsSignature2020 proofs of the Verifiable Credential
+// a new Verifiable Credential revealing only the fields selected by the JSON-LD frame revealDoc.
+// The derived credential holds BbsBlsSignatureProof2020 proofs bound to the nonce.
+// The public keys of the signatures are resolved with the public key fetcher defined in the options.
+func (vc *Credential) GenerateBBSSelectiveDisclosure(revealDoc map[string]interface{},
+	nonce []byte, opts ...CredentialOpt) (*Credential, error) {
+	if len(vc.Proofs) == 0 {
+		return nil, errors.New("expected at least one proof present")
+	}
+
+	vcOpts := parseCredentialOpts(opts)
+
+	if vcOpts.publicKeyFetcher == nil {
+		return nil, errors.New("public key fetcher is not defined")
+	}
+
+	vcDoc, err := toMap(vc)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := &keyResolverAdapter{vcOpts.publicKeyFetcher}
+
+	vcWithSelectiveDisclosureDoc, err := bbsblssignatureproof2020.New().SelectiveDisclosure(vcDoc, revealDoc,
+		nonce, resolver)
+	if err != nil {
+		return nil, fmt.Errorf("create VC selective disclosure: %w", err)
+	}
+
+	vcWithSelectiveDisclosureBytes, err := json.Marshal(vcWithSelectiveDisclosureDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	vcWithSelectiveDisclosure, err := NewUnverifiedCredential(vcWithSelectiveDisclosureBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON-LD framing sorts the types, the base type of the credential is expected to be the first one
+	vcWithSelectiveDisclosure.Types = baseTypeFirst(vcWithSelectiveDisclosure.Types)
+
+	return vcWithSelectiveDisclosure, nil
+}
+
+func baseTypeFirst(types []string) []string {
+	sortedTypes := []string{vcType}
+
+	for _, t := range types {
+		if t != vcType {
+			sortedTypes = append(sortedTypes, t)
+		}
+	}
+
+	return sortedTypes
+}
@@ -0,0 +1,155 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/bbsblssignatureproof2020"
+)
+
+//nolint:lll
+const bbsCredential = `{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "https://www.w3.org/2018/credentials/examples/v1"
+  ],
+  "id": "http://example.edu/credentials/1872",
+  "type": [
+    "VerifiableCredential",
+    "UniversityDegreeCredential"
+  ],
+  "credentialSubject": {
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+    "name": "Jayden Doe",
+    "spouse": "did:example:c276e12ec21ebfeb1f712ebc6f1",
+    "degree": {
+      "type": "BachelorDegree",
+      "university": "MIT"
+    }
+  },
+  "issuer": {
+    "id": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+    "name": "Example University"
+  },
+  "issuanceDate": "2010-01-01T19:23:24Z"
+}`
+
+//nolint:lll
+const bbsRevealDoc = `{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "https://www.w3.org/2018/credentials/examples/v1"
+  ],
+  "type": [
+    "VerifiableCredential",
+    "UniversityDegreeCredential"
+  ],
+  "@explicit": true,
+  "id": {},
+  "issuer": {},
+  "issuanceDate": {},
+  "credentialSubject": {
+    "@explicit": true,
+    "degree": {}
+  }
+}`
+
+func TestCredential_GenerateBBSSelectiveDisclosure(t *testing.T) {
+	r := require.New(t)
+
+	pubKey, privKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+	r.NoError(err)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	r.NoError(err)
+
+	vc, _, err := NewCredential([]byte(bbsCredential))
+	r.NoError(err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "BbsBlsSignature2020",
+		SignatureRepresentation: SignatureProofValue,
+		Suite: bbsblssignature2020.New(
+			bbsblssignature2020.WithSigner(bbsblssignature2020.NewSigner(privKey))),
+		VerificationMethod: "did:example:76e12ec712ebc6f1c221ebfeb1f#keys-1",
+	})
+	r.NoError(err)
+
+	vcBytes, err := json.Marshal(vc)
+	r.NoError(err)
+
+	vc, _, err = NewCredential(vcBytes,
+		WithEmbeddedSignatureSuites(bbsblssignature2020.New()),
+		WithPublicKeyFetcher(SingleKey(pubKeyBytes)))
+	r.NoError(err)
+
+	revealDoc, err := toMap(bbsRevealDoc)
+	r.NoError(err)
+
+	nonce := []byte("nonce")
+
+	vcWithSelectiveDisclosure, err := vc.GenerateBBSSelectiveDisclosure(revealDoc, nonce,
+		WithPublicKeyFetcher(SingleKey(pubKeyBytes)))
+	r.NoError(err)
+	r.Len(vcWithSelectiveDisclosure.Proofs, 1)
+	r.Equal("BbsBlsSignatureProof2020", vcWithSelectiveDisclosure.Proofs[0]["type"])
+
+	subject, ok := vcWithSelectiveDisclosure.Subject.(map[string]interface{})
+	r.True(ok)
+	r.Equal("did:example:ebfeb1f712ebc6f1c276e12ec21", subject["id"])
+	r.NotContains(subject, "name")
+	r.NotContains(subject, "spouse")
+	r.Contains(subject, "degree")
+
+	vcWithSelectiveDisclosureBytes, err := json.Marshal(vcWithSelectiveDisclosure)
+	r.NoError(err)
+
+	_, _, err = NewCredential(vcWithSelectiveDisclosureBytes,
+		WithEmbeddedSignatureSuites(bbsblssignatureproof2020.New()),
+		WithPublicKeyFetcher(SingleKey(pubKeyBytes)))
+	r.NoError(err)
+
+	t.Run("derived credential is bound to its content", func(t *testing.T) {
+		vcMap, err := toMap(vcWithSelectiveDisclosure)
+		require.NoError(t, err)
+
+		vcMap["issuanceDate"] = "2011-01-01T19:23:24Z"
+
+		modifiedVCBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		_, _, err = NewCredential(modifiedVCBytes,
+			WithEmbeddedSignatureSuites(bbsblssignatureproof2020.New()),
+			WithPublicKeyFetcher(SingleKey(pubKeyBytes)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid BBS+ signature proof")
+	})
+
+	t.Run("public key fetcher is not defined", func(t *testing.T) {
+		_, err := vc.GenerateBBSSelectiveDisclosure(revealDoc, nonce)
+		require.EqualError(t, err, "public key fetcher is not defined")
+	})
+
+	t.Run("no BbsBlsSignature2020 proof", func(t *testing.T) {
+		vcWithoutProofs, _, err := NewCredential([]byte(bbsCredential))
+		require.NoError(t, err)
+
+		_, err = vcWithoutProofs.GenerateBBSSelectiveDisclosure(revealDoc, nonce,
+			WithPublicKeyFetcher(SingleKey(pubKeyBytes)))
+		require.EqualError(t, err, "expected at least one proof present")
+
+		_, err = vcWithSelectiveDisclosure.GenerateBBSSelectiveDisclosure(revealDoc, nonce,
+			WithPublicKeyFetcher(SingleKey(pubKeyBytes)))
+		require.EqualError(t, err, "create VC selective disclosure: no BbsBlsSignature2020 proof present")
+	})
+}
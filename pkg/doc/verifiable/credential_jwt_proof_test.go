@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/rand"
 	"errors"
@@ -17,6 +18,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/ecdsasecp256k1signature2019"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/secp256k1"
 	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
 )
 
@@ -141,6 +144,45 @@ func TestNewCredentialFromJWS_EdDSA(t *testing.T) {
 	require.Equal(t, vc, vcFromJWS)
 }
 
+func TestNewCredentialFromJWS_ES256K(t *testing.T) {
+	vcBytes := []byte(jwtTestCredential)
+
+	privKey, err := ecdsa.GenerateKey(ecdsasecp256k1signature2019.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	vc, _, err := NewCredential(vcBytes)
+	require.NoError(t, err)
+
+	jwtClaims, err := vc.JWTClaims(false)
+	require.NoError(t, err)
+
+	vcJWS, err := jwtClaims.MarshalJWS(ES256K, privKey, vc.Issuer.ID+"#keys-"+keyID)
+	require.NoError(t, err)
+
+	t.Run("verify with ECDSA public key", func(t *testing.T) {
+		vcFromJWS, _, err := NewCredential([]byte(vcJWS), WithPublicKeyFetcher(SingleKey(&privKey.PublicKey)))
+		require.NoError(t, err)
+		require.Equal(t, vc, vcFromJWS)
+	})
+
+	t.Run("verify with public key of DID document", func(t *testing.T) {
+		pubKey := secp256k1.MarshalPublicKey(&privKey.PublicKey)
+
+		vcFromJWS, _, err := NewCredential([]byte(vcJWS), WithPublicKeyFetcher(SingleKey(pubKey)))
+		require.NoError(t, err)
+		require.Equal(t, vc, vcFromJWS)
+	})
+
+	t.Run("verify with other key", func(t *testing.T) {
+		otherKey, err := ecdsa.GenerateKey(ecdsasecp256k1signature2019.S256(), rand.Reader)
+		require.NoError(t, err)
+
+		_, _, err = NewCredential([]byte(vcJWS), WithPublicKeyFetcher(SingleKey(&otherKey.PublicKey)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "JWS decoding: unmarshal VC JWT claims")
+	})
+}
+
 func TestNewCredentialFromUnsecuredJWT(t *testing.T) {
 	testCred := []byte(jwtTestCredential)
 
@@ -10,10 +10,13 @@ import (
 	"crypto/rand"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/ed25519signature2018"
+	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
 )
 
 func TestNewCredentialFromLinkedDataProof(t *testing.T) {
@@ -54,6 +57,104 @@ func TestNewCredentialFromLinkedDataProof(t *testing.T) {
 	r.Equal(vcMap, vcWithLdpMap)
 }
 
+func TestNewCredentialFromLinkedDataProof_ProofOptions(t *testing.T) {
+	r := require.New(t)
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	r.NoError(err)
+
+	didDoc := createDIDDoc()
+	didDoc.AssertionMethod = []did.VerificationMethod{{PublicKey: didDoc.PublicKey[0]}}
+	didResolver := NewDIDKeyResolver(&mockvdri.MockVDRIRegistry{ResolveValue: didDoc})
+
+	suite := ed25519signature2018.New(ed25519signature2018.WithSigner(getSigner(privKey)))
+
+	vc, _, err := NewCredential([]byte(validCredential))
+	r.NoError(err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   suite,
+		VerificationMethod:      didDoc.PublicKey[0].ID,
+		Purpose:                 "assertionMethod",
+		Domain:                  "example.com",
+		PurposeValidator:        didResolver,
+	})
+	r.NoError(err)
+
+	vcBytes, err := json.Marshal(vc)
+	r.NoError(err)
+
+	_, _, err = NewCredential(vcBytes,
+		WithEmbeddedSignatureSuites(suite),
+		WithPublicKeyFetcher(SingleKey([]byte(pubKey))),
+		WithProofPurpose("assertionMethod", didResolver),
+		WithProofDomain("example.com"),
+		WithProofTimeWindow(time.Hour, time.Minute))
+	r.NoError(err)
+
+	t.Run("proof options are not expected", func(t *testing.T) {
+		_, _, err := NewCredential(vcBytes,
+			WithEmbeddedSignatureSuites(suite),
+			WithPublicKeyFetcher(SingleKey([]byte(pubKey))),
+			WithProofPurpose("authentication", nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(),
+			"proof purpose assertionMethod does not match the expected purpose authentication")
+
+		_, _, err = NewCredential(vcBytes,
+			WithEmbeddedSignatureSuites(suite),
+			WithPublicKeyFetcher(SingleKey([]byte(pubKey))),
+			WithProofDomain("other.com"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "proof domain example.com does not match the expected domain other.com")
+
+		_, _, err = NewCredential(vcBytes,
+			WithEmbeddedSignatureSuites(suite),
+			WithPublicKeyFetcher(SingleKey([]byte(pubKey))),
+			WithProofChallenge("challenge"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "proof challenge does not match the expected challenge")
+	})
+
+	t.Run("proof is stripped", func(t *testing.T) {
+		vcWithoutProof := *vc
+		vcWithoutProof.Proofs = nil
+
+		vcWithoutProofBytes, err := json.Marshal(&vcWithoutProof)
+		require.NoError(t, err)
+
+		_, _, err = NewCredential(vcWithoutProofBytes,
+			WithEmbeddedSignatureSuites(suite),
+			WithPublicKeyFetcher(SingleKey([]byte(pubKey))),
+			WithProofDomain("example.com"))
+		require.EqualError(t, err, "decode new credential: embedded proof is missing")
+	})
+
+	t.Run("verification method is not authorized for the purpose", func(t *testing.T) {
+		didDoc.AssertionMethod = nil
+
+		_, _, err := NewCredential(vcBytes,
+			WithEmbeddedSignatureSuites(suite),
+			WithPublicKeyFetcher(SingleKey([]byte(pubKey))),
+			WithProofPurpose("assertionMethod", didResolver))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is not authorized for proof purpose assertionMethod")
+
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   suite,
+			VerificationMethod:      didDoc.PublicKey[0].ID,
+			Purpose:                 "assertionMethod",
+			PurposeValidator:        didResolver,
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "add linked data proof: validate proof purpose")
+	})
+}
+
 func addDummyCreatorToProof(vc *Credential, r *require.Assertions) map[string]interface{} {
 	vcMap, err := toMap(vc)
 	r.NoError(err)
@@ -802,7 +802,7 @@ func TestWithEmbeddedSignatureSuites(t *testing.T) {
 
 	opts := &credentialOpts{}
 	credentialOpt(opts)
-	require.Equal(t, suite, opts.ldpSuite)
+	require.Equal(t, []verifierSignatureSuite{suite}, opts.ldpSuites)
 }
 
 func TestCustomCredentialJsonSchemaValidator2018(t *testing.T) {
@@ -19,7 +19,11 @@ const (
 
 // nolint:gochecknoglobals
 var proofTypesMapping = map[string]embeddedProofType{
-	"Ed25519Signature2018": linkedDataProof,
+	"Ed25519Signature2018":        linkedDataProof,
+	"EcdsaSecp256k1Signature2019": linkedDataProof,
+	"JsonWebSignature2020":        linkedDataProof,
+	"BbsBlsSignature2020":         linkedDataProof,
+	"BbsBlsSignatureProof2020":    linkedDataProof,
 }
 
 func parseEmbeddedProof(proofMap map[string]interface{}) (embeddedProofType, error) {
@@ -50,6 +54,11 @@ func checkEmbeddedProof(docBytes []byte, vcOpts *credentialOpts) ([]byte, error)
 
 	proofElement, ok := jsonldDoc["proof"]
 	if !ok || proofElement == nil {
+		// the proof is required to check its purpose, challenge or domain
+		if vcOpts.requiredProof || len(vcOpts.ldpVerificationOpts) > 0 {
+			return nil, errors.New("embedded proof is missing")
+		}
+
 		// do not make a check if there is no proof defined as proof presence is not mandatory
 		return docBytes, nil
 	}
@@ -66,7 +75,8 @@ func checkEmbeddedProof(docBytes []byte, vcOpts *credentialOpts) ([]byte, error)
 
 	switch proofType {
 	case linkedDataProof:
-		err = checkLinkedDataProof(docBytes, vcOpts.ldpSuite, vcOpts.publicKeyFetcher)
+		err = checkLinkedDataProof(docBytes, safeStringValue(proofMap["type"]), vcOpts.ldpSuites, vcOpts.publicKeyFetcher,
+			vcOpts.ldpVerificationOpts...)
 	default:
 		err = fmt.Errorf("unsupported proof type: %v", proofType)
 	}
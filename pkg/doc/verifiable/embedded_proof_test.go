@@ -9,6 +9,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 )
 
 func Test_parseEmbeddedProof(t *testing.T) {
@@ -20,6 +23,38 @@ func Test_parseEmbeddedProof(t *testing.T) {
 		require.Equal(t, linkedDataProof, proofType)
 	})
 
+	t.Run("parse linked data proof with \"EcdsaSecp256k1Signature2019\" proof type", func(t *testing.T) {
+		proofType, err := parseEmbeddedProof(map[string]interface{}{
+			"type": "EcdsaSecp256k1Signature2019",
+		})
+		require.NoError(t, err)
+		require.Equal(t, linkedDataProof, proofType)
+	})
+
+	t.Run("parse linked data proof with \"JsonWebSignature2020\" proof type", func(t *testing.T) {
+		proofType, err := parseEmbeddedProof(map[string]interface{}{
+			"type": "JsonWebSignature2020",
+		})
+		require.NoError(t, err)
+		require.Equal(t, linkedDataProof, proofType)
+	})
+
+	t.Run("parse linked data proof with \"BbsBlsSignature2020\" proof type", func(t *testing.T) {
+		proofType, err := parseEmbeddedProof(map[string]interface{}{
+			"type": "BbsBlsSignature2020",
+		})
+		require.NoError(t, err)
+		require.Equal(t, linkedDataProof, proofType)
+	})
+
+	t.Run("parse linked data proof with \"BbsBlsSignatureProof2020\" proof type", func(t *testing.T) {
+		proofType, err := parseEmbeddedProof(map[string]interface{}{
+			"type": "BbsBlsSignatureProof2020",
+		})
+		require.NoError(t, err)
+		require.Equal(t, linkedDataProof, proofType)
+	})
+
 	t.Run("parse embedded proof without \"type\" element", func(t *testing.T) {
 		_, err := parseEmbeddedProof(map[string]interface{}{})
 		require.Error(t, err)
@@ -62,6 +97,17 @@ func Test_checkEmbeddedProof(t *testing.T) {
 		r.NotNil(docBytes)
 	})
 
+	t.Run("error on missing \"proof\" element with proof verification options", func(t *testing.T) {
+		docWithoutProof := `{
+  "@context": "https://www.w3.org/2018/credentials/v1"
+}`
+		docBytes, err := checkEmbeddedProof([]byte(docWithoutProof), &credentialOpts{
+			ldpVerificationOpts: []verifier.VerificationOpt{verifier.WithChallenge("challenge")},
+		})
+		r.EqualError(err, "embedded proof is missing")
+		r.Nil(docBytes)
+	})
+
 	t.Run("error on not map \"proof\" element", func(t *testing.T) {
 		docWithNotMapProof := `{
   "@context": "https://www.w3.org/2018/credentials/v1",
@@ -86,6 +132,25 @@ func Test_checkEmbeddedProof(t *testing.T) {
 		r.Nil(docBytes)
 	})
 
+	t.Run("error on Linked Data embedded proof without suite", func(t *testing.T) {
+		docWithJWSProof := `{
+  "@context": "https://www.w3.org/2018/credentials/v1",
+  "proof": {
+	"type": "JsonWebSignature2020",
+    "created": "2020-01-21T12:59:31+02:00",
+    "creator": "John",
+    "jws": "eyJhbGciOiJFUzI1NiIsImI2NCI6ZmFsc2UsImNyaXQiOlsiYjY0Il19..c2ln"
+  }
+}`
+		docBytes, err := checkEmbeddedProof([]byte(docWithJWSProof), &credentialOpts{
+			ldpSuites: []verifierSignatureSuite{ed25519signature2018.New()},
+		})
+		r.Error(err)
+		r.EqualError(err, "check embedded proof: check linked data proof: "+
+			"signature type JsonWebSignature2020 not supported")
+		r.Nil(docBytes)
+	})
+
 	t.Run("error on invalid proof of Linked Data embedded proof", func(t *testing.T) {
 		docWithNotSupportedProof := `{
   "@context": "https://www.w3.org/2018/credentials/v1",
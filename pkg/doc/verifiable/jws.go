@@ -6,15 +6,25 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"crypto/ecdsa"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/square/go-jose/v3"
 	"github.com/square/go-jose/v3/jwt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/internal/secp256k1"
 )
 
+// joseES256K is the ES256K algorithm (RFC 8812), not supported by go-jose
+// (https://github.com/square/go-jose/issues/263), the JWS are signed and verified with the opaque
+// signer and verifier of go-jose.
+const joseES256K = jose.SignatureAlgorithm("ES256K")
+
 // MarshalJWS serializes JWT presentation claims into signed form (JWS)
 // todo refactor, do not pass privateKey (https://github.com/hyperledger/aries-framework-go/issues/339)
 func marshalJWS(jwtClaims interface{}, signatureAlg JWSAlgorithm, privateKey interface{}, keyID string) (string, error) { //nolint:lll
@@ -23,6 +33,13 @@ func marshalJWS(jwtClaims interface{}, signatureAlg JWSAlgorithm, privateKey int
 		return "", err
 	}
 
+	if joseAlg == joseES256K {
+		privateKey, err = newES256KSigner(privateKey)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	key := jose.SigningKey{Algorithm: joseAlg, Key: privateKey}
 
 	var signerOpts = &jose.SignerOptions{}
@@ -62,6 +79,13 @@ func verifyJWTSignature(token *jwt.JSONWebToken, fetcher PublicKeyFetcher, issue
 		return fmt.Errorf("get public key for JWT signature verification: %w", err)
 	}
 
+	if len(token.Headers) > 0 && token.Headers[0].Algorithm == string(joseES256K) {
+		publicKey, err = newES256KVerifier(publicKey)
+		if err != nil {
+			return fmt.Errorf("verify JWT signature: %w", err)
+		}
+	}
+
 	if err = token.Claims(publicKey, jwtClaims); err != nil {
 		return fmt.Errorf("verify JWT signature: %w", err)
 	}
@@ -89,3 +113,76 @@ func isJWS(data []byte) bool {
 		isValidJSON(parts[1]) &&
 		parts[2] != ""
 }
+
+// es256kSigner signs the ES256K JWS with a secp256k1 private key, it implements jose.OpaqueSigner
+type es256kSigner struct {
+	privateKey *ecdsa.PrivateKey
+}
+
+func newES256KSigner(privateKey interface{}) (*es256kSigner, error) {
+	key, ok := privateKey.(*ecdsa.PrivateKey)
+	if !ok || key.Curve != secp256k1.S256() {
+		return nil, errors.New("ES256K signing requires a secp256k1 ECDSA private key")
+	}
+
+	return &es256kSigner{privateKey: key}, nil
+}
+
+// Public returns nil, the public key is not embedded in the JWS header
+func (s *es256kSigner) Public() *jose.JSONWebKey {
+	return nil
+}
+
+// Algs returns ES256K
+func (s *es256kSigner) Algs() []jose.SignatureAlgorithm {
+	return []jose.SignatureAlgorithm{joseES256K}
+}
+
+// SignPayload signs the SHA-256 digest of the payload, the signature is the concatenation of R and S
+func (s *es256kSigner) SignPayload(payload []byte, _ jose.SignatureAlgorithm) ([]byte, error) {
+	digest := sha256.Sum256(payload)
+
+	return secp256k1.Sign(s.privateKey, digest[:])
+}
+
+// es256kVerifier verifies the ES256K JWS with a secp256k1 public key, it implements jose.OpaqueVerifier
+type es256kVerifier struct {
+	publicKey *ecdsa.PublicKey
+}
+
+// newES256KVerifier returns the verifier of the public key, either a secp256k1 ECDSA public key
+// or its SEC 1 encoding (the raw value of the public keys of DID documents)
+func newES256KVerifier(publicKey interface{}) (*es256kVerifier, error) {
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if key.Curve != secp256k1.S256() {
+			return nil, errors.New("ES256K verification requires a secp256k1 public key")
+		}
+
+		return &es256kVerifier{publicKey: key}, nil
+	case []byte:
+		pubKey, err := secp256k1.ParsePublicKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		return &es256kVerifier{publicKey: pubKey}, nil
+	default:
+		return nil, errors.New("ES256K verification requires a secp256k1 public key")
+	}
+}
+
+// VerifyPayload verifies the R || S signature of the SHA-256 digest of the payload
+func (v *es256kVerifier) VerifyPayload(payload, signature []byte, alg jose.SignatureAlgorithm) error {
+	if alg != joseES256K {
+		return jose.ErrUnsupportedAlgorithm
+	}
+
+	digest := sha256.Sum256(payload)
+
+	if !secp256k1.Verify(v.publicKey, digest[:], signature) {
+		return errors.New("ES256K signature doesn't match")
+	}
+
+	return nil
+}
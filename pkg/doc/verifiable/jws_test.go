@@ -6,12 +6,19 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"testing"
 
+	"github.com/square/go-jose/v3"
+	"github.com/square/go-jose/v3/jwt"
 	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/internal/secp256k1"
 )
 
 func Test_isJWS(t *testing.T) {
@@ -76,3 +83,64 @@ func Test_isJWS(t *testing.T) {
 		})
 	}
 }
+
+func TestES256K(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(secp256k1.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	claims := &jwt.Claims{Issuer: "did:example:76e12ec712ebc6f1c221ebfeb1f"}
+
+	jws, err := marshalJWS(claims, ES256K, privKey, "#keys-1")
+	require.NoError(t, err)
+
+	token, err := jwt.ParseSigned(jws)
+	require.NoError(t, err)
+	require.Equal(t, "ES256K", token.Headers[0].Algorithm)
+
+	t.Run("test verify", func(t *testing.T) {
+		for _, pubKey := range []interface{}{&privKey.PublicKey, secp256k1.MarshalPublicKey(&privKey.PublicKey)} {
+			verified := new(jwt.Claims)
+
+			err = verifyJWTSignature(token, SingleKey(pubKey), claims.Issuer, verified)
+			require.NoError(t, err)
+			require.Equal(t, claims.Issuer, verified.Issuer)
+		}
+	})
+
+	t.Run("test verify with invalid public keys", func(t *testing.T) {
+		otherKey, err := ecdsa.GenerateKey(secp256k1.S256(), rand.Reader)
+		require.NoError(t, err)
+
+		err = verifyJWTSignature(token, SingleKey(&otherKey.PublicKey), claims.Issuer, new(jwt.Claims))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify JWT signature")
+
+		err = verifyJWTSignature(token, SingleKey(&p256Key.PublicKey), claims.Issuer, new(jwt.Claims))
+		require.EqualError(t, err, "verify JWT signature: ES256K verification requires a secp256k1 public key")
+
+		err = verifyJWTSignature(token, SingleKey("key"), claims.Issuer, new(jwt.Claims))
+		require.EqualError(t, err, "verify JWT signature: ES256K verification requires a secp256k1 public key")
+
+		err = verifyJWTSignature(token, SingleKey([]byte("key")), claims.Issuer, new(jwt.Claims))
+		require.EqualError(t, err, "verify JWT signature: secp256k1: invalid public key encoding")
+	})
+
+	t.Run("test sign with invalid private keys", func(t *testing.T) {
+		_, err := marshalJWS(claims, ES256K, p256Key, "#keys-1")
+		require.EqualError(t, err, "ES256K signing requires a secp256k1 ECDSA private key")
+
+		_, err = marshalJWS(claims, ES256K, "key", "#keys-1")
+		require.EqualError(t, err, "ES256K signing requires a secp256k1 ECDSA private key")
+	})
+
+	t.Run("test verify other algorithm", func(t *testing.T) {
+		v, err := newES256KVerifier(&privKey.PublicKey)
+		require.NoError(t, err)
+
+		err = v.VerifyPayload([]byte("payload"), []byte("signature"), jose.ES256)
+		require.Equal(t, jose.ErrUnsupportedAlgorithm, err)
+	})
+}
@@ -48,6 +48,13 @@ type signerSignatureSuite interface {
 	Sign(jsonLdDoc []byte) ([]byte, error)
 }
 
+// proofPurposeValidator encapsulates the check of the verification relationships
+type proofPurposeValidator interface {
+
+	// ValidateProofPurpose checks that the verification method is authorized for the proof purpose
+	ValidateProofPurpose(verificationMethod, purpose string) error
+}
+
 type keyResolverAdapter struct {
 	pubKeyFetcher PublicKeyFetcher
 }
@@ -85,19 +92,31 @@ const (
 )
 
 // LinkedDataProofContext holds options needed to build a Linked Data Proof.
+// The verification method is checked to be authorized for the purpose by the purpose validator if defined.
 type LinkedDataProofContext struct {
 	SignatureType           string                  // required
 	Suite                   signerSignatureSuite    // required
 	SignatureRepresentation SignatureRepresentation // required
 	Created                 *time.Time              // optional
+	VerificationMethod      string                  // optional
+	Purpose                 string                  // optional
+	Challenge               string                  // optional
+	Domain                  string                  // optional
+	PurposeValidator        proofPurposeValidator   // optional
 }
 
-func checkLinkedDataProof(jsonldBytes []byte, suite verifierSignatureSuite, pubKeyFetcher PublicKeyFetcher) error {
+func checkLinkedDataProof(jsonldBytes []byte, proofType string, suites []verifierSignatureSuite,
+	pubKeyFetcher PublicKeyFetcher, opts ...verifier.VerificationOpt) error {
+	suite, err := getLinkedDataProofSuite(suites, proofType)
+	if err != nil {
+		return fmt.Errorf("check linked data proof: %w", err)
+	}
+
 	documentVerifier := verifier.New(
 		&keyResolverAdapter{pubKeyFetcher},
 		suite)
 
-	err := documentVerifier.Verify(jsonldBytes)
+	err = documentVerifier.Verify(jsonldBytes, opts...)
 	if err != nil {
 		return fmt.Errorf("check linked data proof: %w", err)
 	}
@@ -105,6 +124,17 @@ func checkLinkedDataProof(jsonldBytes []byte, suite verifierSignatureSuite, pubK
 	return nil
 }
 
+// getLinkedDataProofSuite returns the first suite accepting the proof type
+func getLinkedDataProofSuite(suites []verifierSignatureSuite, proofType string) (verifierSignatureSuite, error) {
+	for _, s := range suites {
+		if s.Accept(proofType) {
+			return s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("signature type %s not supported", proofType)
+}
+
 type rawProof struct {
 	Proof json.RawMessage `json:"proof,omitempty"`
 }
@@ -112,6 +142,13 @@ type rawProof struct {
 // addLinkedDataProof adds a new proof to the JSON-LD document (VC or VP). It returns a slice
 // of the proofs which were already present appended with a newly created proof.
 func addLinkedDataProof(context *LinkedDataProofContext, jsonldBytes []byte) ([]Proof, error) {
+	if context.PurposeValidator != nil {
+		err := context.PurposeValidator.ValidateProofPurpose(context.VerificationMethod, context.Purpose)
+		if err != nil {
+			return nil, fmt.Errorf("add linked data proof: validate proof purpose: %w", err)
+		}
+	}
+
 	documentSigner := signer.New(context.Suite)
 
 	vcWithNewProofBytes, err := documentSigner.Sign(mapContext(context), jsonldBytes)
@@ -140,5 +177,9 @@ func mapContext(context *LinkedDataProofContext) *signer.Context {
 		SignatureType:           context.SignatureType,
 		SignatureRepresentation: proof.SignatureRepresentation(context.SignatureRepresentation),
 		Created:                 context.Created,
+		VerificationMethod:      context.VerificationMethod,
+		ProofPurpose:            context.Purpose,
+		Challenge:               context.Challenge,
+		Domain:                  context.Domain,
 	}
 }
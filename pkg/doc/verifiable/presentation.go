@@ -9,8 +9,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 )
 
 const basePresentationSchema = `
@@ -263,7 +266,10 @@ type rawPresentation struct {
 type presentationOpts struct {
 	publicKeyFetcher   PublicKeyFetcher
 	disabledProofCheck bool
-	ldpSuite           verifierSignatureSuite
+	ldpSuites          []verifierSignatureSuite
+
+	// the embedded linked data proof of VP is checked when its verification options are defined
+	ldpVerificationOpts []verifier.VerificationOpt
 }
 
 // PresentationOpt is the Verifiable Presentation decoding option
@@ -277,10 +283,44 @@ func WithPresPublicKeyFetcher(fetcher PublicKeyFetcher) PresentationOpt {
 	}
 }
 
-// WithPresEmbeddedSignatureSuites defines the suite which is used to check embedded linked data proof of VP.
-func WithPresEmbeddedSignatureSuites(suite verifierSignatureSuite) PresentationOpt {
+// WithPresEmbeddedSignatureSuites defines the suites which are used to check embedded linked data proof of VP.
+func WithPresEmbeddedSignatureSuites(suites ...verifierSignatureSuite) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.ldpSuites = suites
+	}
+}
+
+// WithPresProofPurpose requires the embedded linked data proof of VP to have the proof purpose
+// (e.g. authentication). The verification method of the proof is checked to be authorized for the purpose
+// by the validator if defined (e.g. DIDKeyResolver). The embedded linked data proof of VP is then checked.
+func WithPresProofPurpose(purpose string, validator proofPurposeValidator) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.ldpVerificationOpts = append(opts.ldpVerificationOpts, verifier.WithProofPurpose(purpose, validator))
+	}
+}
+
+// WithPresProofChallenge requires the embedded linked data proof of VP to have the challenge.
+// The embedded linked data proof of VP is then checked.
+func WithPresProofChallenge(challenge string) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.ldpVerificationOpts = append(opts.ldpVerificationOpts, verifier.WithChallenge(challenge))
+	}
+}
+
+// WithPresProofDomain requires the embedded linked data proof of VP to have the domain.
+// The embedded linked data proof of VP is then checked.
+func WithPresProofDomain(domain string) PresentationOpt {
 	return func(opts *presentationOpts) {
-		opts.ldpSuite = suite
+		opts.ldpVerificationOpts = append(opts.ldpVerificationOpts, verifier.WithDomain(domain))
+	}
+}
+
+// WithPresProofTimeWindow requires the embedded linked data proof of VP to be created at most maxAge ago,
+// and not later than clockSkew in the future. The age of the proof is not limited if maxAge is 0.
+// The embedded linked data proof of VP is then checked.
+func WithPresProofTimeWindow(maxAge, clockSkew time.Duration) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.ldpVerificationOpts = append(opts.ldpVerificationOpts, verifier.WithProofTimeWindow(maxAge, clockSkew))
 	}
 }
 
@@ -393,7 +433,7 @@ func mapOpts(vpOpts *presentationOpts) *credentialOpts {
 	return &credentialOpts{
 		publicKeyFetcher:   vpOpts.publicKeyFetcher,
 		disabledProofCheck: vpOpts.disabledProofCheck,
-		ldpSuite:           vpOpts.ldpSuite,
+		ldpSuites:          vpOpts.ldpSuites,
 	}
 }
 
@@ -446,9 +486,31 @@ func decodeRawPresentation(vpData []byte, vpOpts *presentationOpts) ([]byte, *ra
 		return nil, nil, errors.New("embedded proof is missing")
 	}
 
+	if !vpOpts.disabledProofCheck && len(vpOpts.ldpVerificationOpts) > 0 {
+		err = checkPresentationLinkedDataProof(vpBytes, vpOpts)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	return vpBytes, vpRaw, err
 }
 
+// checkPresentationLinkedDataProof checks the embedded linked data proof of VP with its verification options
+func checkPresentationLinkedDataProof(vpBytes []byte, vpOpts *presentationOpts) error {
+	if vpOpts.publicKeyFetcher == nil {
+		return errors.New("public key fetcher is not defined")
+	}
+
+	_, err := checkEmbeddedProof(vpBytes, &credentialOpts{
+		publicKeyFetcher:    vpOpts.publicKeyFetcher,
+		ldpSuites:           vpOpts.ldpSuites,
+		ldpVerificationOpts: vpOpts.ldpVerificationOpts,
+	})
+
+	return err
+}
+
 func decodeVPFromJSON(vpData []byte) ([]byte, *rawPresentation, error) {
 	// unmarshal VP from JSON
 	raw := new(rawPresentation)
@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/rand"
 	"errors"
@@ -14,6 +15,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/ecdsasecp256k1signature2019"
 )
 
 func TestNewPresentationFromJWS(t *testing.T) {
@@ -109,6 +112,32 @@ func TestNewPresentationFromJWS_EdDSA(t *testing.T) {
 	require.Equal(t, vp, vpFromJWS)
 }
 
+func TestNewPresentationFromJWS_ES256K(t *testing.T) {
+	vpBytes := []byte(validPresentation)
+
+	privKey, err := ecdsa.GenerateKey(ecdsasecp256k1signature2019.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	vp, err := NewPresentation(vpBytes)
+	require.NoError(t, err)
+
+	// marshal presentation into JWS using ES256K (ECDSA secp256k1 signature algorithm).
+	jwtClaims, err := vp.JWTClaims([]string{}, false)
+	require.NoError(t, err)
+
+	vpJWSStr, err := jwtClaims.MarshalJWS(ES256K, privKey, vp.Holder+"#keys-"+keyID)
+	require.NoError(t, err)
+
+	// unmarshal presentation from JWS
+	vpFromJWS, err := NewPresentation(
+		[]byte(vpJWSStr),
+		WithPresPublicKeyFetcher(SingleKey(&privKey.PublicKey)))
+	require.NoError(t, err)
+
+	// unmarshalled presentation must be the same as original one
+	require.Equal(t, vp, vpFromJWS)
+}
+
 func TestNewPresentationFromUnsecuredJWT(t *testing.T) {
 	vpBytes := []byte(validPresentation)
 
@@ -10,6 +10,7 @@ import (
 	"crypto/rand"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -48,6 +49,89 @@ func TestNewPresentationFromLinkedDataProof(t *testing.T) {
 	r.Equal(vc, vcWithLdp)
 }
 
+func TestNewPresentationFromLinkedDataProof_ProofOptions(t *testing.T) {
+	r := require.New(t)
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	r.NoError(err)
+
+	suite := ed25519signature2018.New(ed25519signature2018.WithSigner(getSigner(privKey)))
+
+	vp, err := NewPresentation([]byte(validPresentation))
+	r.NoError(err)
+
+	vp.Proofs = nil
+
+	err = vp.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   suite,
+		VerificationMethod:      "did:example:ebfeb1f712ebc6f1c276e12ec21#keys-1",
+		Purpose:                 "authentication",
+		Challenge:               "challenge",
+		Domain:                  "example.com",
+	})
+	r.NoError(err)
+
+	vpBytes, err := json.Marshal(vp)
+	r.NoError(err)
+
+	_, err = NewPresentation(vpBytes,
+		WithPresEmbeddedSignatureSuites(suite),
+		WithPresPublicKeyFetcher(SingleKey([]byte(pubKey))),
+		WithPresProofPurpose("authentication", nil),
+		WithPresProofChallenge("challenge"),
+		WithPresProofDomain("example.com"),
+		WithPresProofTimeWindow(time.Hour, time.Minute))
+	r.NoError(err)
+
+	t.Run("presentation is replayed", func(t *testing.T) {
+		_, err := NewPresentation(vpBytes,
+			WithPresEmbeddedSignatureSuites(suite),
+			WithPresPublicKeyFetcher(SingleKey([]byte(pubKey))),
+			WithPresProofChallenge("other challenge"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "proof challenge does not match the expected challenge")
+
+		_, err = NewPresentation(vpBytes,
+			WithPresEmbeddedSignatureSuites(suite),
+			WithPresPublicKeyFetcher(SingleKey([]byte(pubKey))),
+			WithPresProofDomain("other.com"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "proof domain example.com does not match the expected domain other.com")
+
+		_, err = NewPresentation(vpBytes,
+			WithPresEmbeddedSignatureSuites(suite),
+			WithPresPublicKeyFetcher(SingleKey([]byte(pubKey))),
+			WithPresProofPurpose("assertionMethod", nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(),
+			"proof purpose authentication does not match the expected purpose assertionMethod")
+	})
+
+	t.Run("proof is checked", func(t *testing.T) {
+		vpMap, err := toMap(vpBytes)
+		require.NoError(t, err)
+
+		vpMap["holder"] = "did:example:other"
+
+		modifiedVPBytes, err := json.Marshal(vpMap)
+		require.NoError(t, err)
+
+		_, err = NewPresentation(modifiedVPBytes,
+			WithPresEmbeddedSignatureSuites(suite),
+			WithPresPublicKeyFetcher(SingleKey([]byte(pubKey))),
+			WithPresProofChallenge("challenge"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "check embedded proof")
+
+		_, err = NewPresentation(vpBytes,
+			WithPresEmbeddedSignatureSuites(suite),
+			WithPresProofChallenge("challenge"))
+		require.EqualError(t, err, "public key fetcher is not defined")
+	})
+}
+
 func TestPresentation_AddLinkedDataProof(t *testing.T) {
 	r := require.New(t)
 
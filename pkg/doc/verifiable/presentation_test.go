@@ -396,5 +396,5 @@ func TestWithPresEmbeddedSignatureSuites(t *testing.T) {
 
 	opts := &presentationOpts{}
 	vpOpt(opts)
-	require.Equal(t, suite, opts.ldpSuite)
+	require.Equal(t, []verifierSignatureSuite{suite}, opts.ldpSuites)
 }
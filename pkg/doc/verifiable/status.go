@@ -0,0 +1,500 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Types of the credential statuses and of the status list credentials of the RevocationList2020
+// (https://w3c-ccg.github.io/vc-status-rl-2020) and StatusList2021 (https://w3c-ccg.github.io/vc-status-list-2021)
+// status methods.
+const (
+	// RevocationList2020Status is the type of the credential status of an index in a revocation list
+	RevocationList2020Status = "RevocationList2020Status"
+	// RevocationList2020Credential is the type of the revocation list credentials
+	RevocationList2020Credential = "RevocationList2020Credential"
+	// StatusList2021Entry is the type of the credential status of an index in a status list
+	StatusList2021Entry = "StatusList2021Entry"
+	// StatusList2021Credential is the type of the status list credentials
+	StatusList2021Credential = "StatusList2021Credential"
+
+	// StatusListSize is the number of credential statuses of the status lists created,
+	// the minimum size (16KB) of the bitstring for the privacy of the holders
+	StatusListSize = 131072
+
+	revocationList2020Context = "https://w3id.org/vc-revocation-list-2020/v1"
+	statusList2021Context     = "https://w3id.org/vc/status-list/2021/v1"
+
+	revocationList2020 = "RevocationList2020"
+	statusList2021     = "StatusList2021"
+
+	revocationListIndex      = "revocationListIndex"
+	revocationListCredential = "revocationListCredential"
+	statusListIndex          = "statusListIndex"
+	statusListCredential     = "statusListCredential"
+	statusPurpose            = "statusPurpose"
+	encodedList              = "encodedList"
+
+	bitsPerByte = 8
+
+	// maxStatusListSize limits the size of the decompressed bitstring of the status lists (16M credentials)
+	maxStatusListSize = 2 << 20
+	// maxStatusListCredentialSize limits the size of the status list credentials downloaded
+	maxStatusListCredentialSize = 4 << 20
+)
+
+// StatusPurpose is the purpose of a status list, to revoke or to suspend the credentials.
+type StatusPurpose string
+
+const (
+	// StatusPurposeRevocation the credentials of the status list are revoked
+	StatusPurposeRevocation StatusPurpose = "revocation"
+	// StatusPurposeSuspension the credentials of the status list are suspended
+	StatusPurposeSuspension StatusPurpose = "suspension"
+)
+
+// CredentialStatus is the status of a credential, checked with its status list credential.
+type CredentialStatus int
+
+const (
+	// CredentialActive the credential is neither revoked nor suspended
+	CredentialActive CredentialStatus = iota
+	// CredentialRevoked the credential is revoked
+	CredentialRevoked
+	// CredentialSuspended the credential is suspended
+	CredentialSuspended
+)
+
+// StatusListFetcher fetches the status list credential, in JSON or JWS form, from its URL.
+type StatusListFetcher func(url string) ([]byte, error)
+
+// HTTPStatusListFetcher returns the status list fetcher downloading the status list credentials
+// with the HTTP client, http.DefaultClient if the client is nil.
+func HTTPStatusListFetcher(client *http.Client) StatusListFetcher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return func(url string) ([]byte, error) {
+		resp, err := client.Get(url)
+		if err != nil {
+			return nil, fmt.Errorf("load status list credential: %w", err)
+		}
+
+		defer func() {
+			e := resp.Body.Close()
+			if e != nil {
+				logger.Errorf("closing response body failed [%v]", e)
+			}
+		}()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("status list credential endpoint HTTP failure [%v]", resp.StatusCode)
+		}
+
+		body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxStatusListCredentialSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("status list credential: read response body: %w", err)
+		}
+
+		if len(body) > maxStatusListCredentialSize {
+			return nil, errors.New("status list credential exceeds the maximum size")
+		}
+
+		return body, nil
+	}
+}
+
+// StatusChecker checks the status of the credentials with their status list credentials.
+type StatusChecker struct {
+	fetcher StatusListFetcher
+	opts    []CredentialOpt
+}
+
+// NewStatusChecker creates a StatusChecker fetching the status list credentials with the fetcher.
+// The status list credentials are decoded and their proofs checked with the options (e.g. WithPublicKeyFetcher),
+// a status list credential without a proof (JWS or embedded linked data proof) is rejected.
+func NewStatusChecker(fetcher StatusListFetcher, opts ...CredentialOpt) *StatusChecker {
+	return &StatusChecker{fetcher: fetcher, opts: opts}
+}
+
+// CheckStatus checks the status of the credential at the index of its credential status in the status list
+// credential. The status list credential must be issued by the issuer of the credential and not be expired.
+func (c *StatusChecker) CheckStatus(vc *Credential) (CredentialStatus, error) {
+	if vc.Status == nil {
+		return 0, errors.New("credential status is not defined")
+	}
+
+	entry, err := parseStatusEntry(vc.Status)
+	if err != nil {
+		return 0, err
+	}
+
+	statusListBytes, err := c.fetcher(entry.statusListCredential)
+	if err != nil {
+		return 0, fmt.Errorf("fetch status list credential: %w", err)
+	}
+
+	statusListVC, err := c.decodeStatusListCredential(statusListBytes)
+	if err != nil {
+		return 0, fmt.Errorf("decode status list credential: %w", err)
+	}
+
+	if statusListVC.ID != entry.statusListCredential {
+		return 0, fmt.Errorf("status list credential %s does not match status list credential %s of credential",
+			statusListVC.ID, entry.statusListCredential)
+	}
+
+	if statusListVC.Issuer.ID != vc.Issuer.ID {
+		return 0, fmt.Errorf("issuer %s of status list credential does not match issuer %s of credential",
+			statusListVC.Issuer.ID, vc.Issuer.ID)
+	}
+
+	if statusListVC.Expired != nil && !statusListVC.Expired.After(time.Now()) {
+		return 0, fmt.Errorf("status list credential %s expired at %s", statusListVC.ID,
+			statusListVC.Expired.Format(time.RFC3339))
+	}
+
+	subject, err := entry.statusListSubject(statusListVC)
+	if err != nil {
+		return 0, err
+	}
+
+	bitstring, err := decodeStatusList(subject[encodedList])
+	if err != nil {
+		return 0, err
+	}
+
+	set, err := statusBit(bitstring, entry.index)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case !set:
+		return CredentialActive, nil
+	case entry.purpose == StatusPurposeSuspension:
+		return CredentialSuspended, nil
+	default:
+		return CredentialRevoked, nil
+	}
+}
+
+// decodeStatusListCredential decodes the status list credential, requiring its proof to be checked
+func (c *StatusChecker) decodeStatusListCredential(statusListBytes []byte) (*Credential, error) {
+	opts := make([]CredentialOpt, 0, len(c.opts)+1)
+	opts = append(opts, c.opts...)
+	opts = append(opts, withRequiredProof())
+
+	if vcOpts := parseCredentialOpts(opts); vcOpts.disabledProofCheck {
+		return nil, errors.New("proof check of status list credential must not be disabled")
+	}
+
+	statusListVC, _, err := NewCredential(statusListBytes, opts...)
+
+	return statusListVC, err
+}
+
+// NewRevocationList2020Status creates the credential status of the index in the revocation list credential.
+func NewRevocationList2020Status(revocationListCredentialID string, index int) *TypedID {
+	return &TypedID{
+		ID:   revocationListCredentialID + "#" + strconv.Itoa(index),
+		Type: RevocationList2020Status,
+		CustomFields: CustomFields{
+			revocationListIndex:      strconv.Itoa(index),
+			revocationListCredential: revocationListCredentialID,
+		},
+	}
+}
+
+// NewStatusList2021Entry creates the credential status of the index in the status list credential
+// of the purpose.
+func NewStatusList2021Entry(statusListCredentialID string, purpose StatusPurpose, index int) *TypedID {
+	return &TypedID{
+		ID:   statusListCredentialID + "#" + strconv.Itoa(index),
+		Type: StatusList2021Entry,
+		CustomFields: CustomFields{
+			statusPurpose:        string(purpose),
+			statusListIndex:      strconv.Itoa(index),
+			statusListCredential: statusListCredentialID,
+		},
+	}
+}
+
+// NewRevocationList2020Credential creates the revocation list credential of StatusListSize credentials,
+// none of them revoked. The issuer signs the credential before publishing it at its ID.
+func NewRevocationList2020Credential(id string, issuer Issuer, issued time.Time) (*Credential, error) {
+	return newStatusListCredential(id, issuer, issued, revocationList2020Context, RevocationList2020Credential,
+		map[string]interface{}{"type": revocationList2020})
+}
+
+// NewStatusList2021Credential creates the status list credential of the purpose of StatusListSize credentials,
+// none of them revoked or suspended. The issuer signs the credential before publishing it at its ID.
+func NewStatusList2021Credential(id string, issuer Issuer, purpose StatusPurpose,
+	issued time.Time) (*Credential, error) {
+	return newStatusListCredential(id, issuer, issued, statusList2021Context, StatusList2021Credential,
+		map[string]interface{}{"type": statusList2021, statusPurpose: string(purpose)})
+}
+
+func newStatusListCredential(id string, issuer Issuer, issued time.Time, context, credentialType string,
+	subject map[string]interface{}) (*Credential, error) {
+	list, err := encodeStatusList(make([]byte, StatusListSize/bitsPerByte))
+	if err != nil {
+		return nil, err
+	}
+
+	subject["id"] = id + "#list"
+	subject[encodedList] = list
+
+	return &Credential{
+		Context: []string{baseContext, context},
+		ID:      id,
+		Types:   []string{vcType, credentialType},
+		Subject: subject,
+		Issuer:  issuer,
+		Issued:  &issued,
+	}, nil
+}
+
+// SetStatusListBit sets the status of the index in the status list credential, e.g. to revoke the credential
+// of the index, or clears it. The proofs of the status list credential are removed, the issuer signs it again
+// before publishing it.
+func SetStatusListBit(statusListVC *Credential, index int, set bool) error {
+	subject, err := singleSubject(statusListVC.Subject)
+	if err != nil {
+		return err
+	}
+
+	bitstring, err := decodeStatusList(subject[encodedList])
+	if err != nil {
+		return err
+	}
+
+	if index < 0 || index >= len(bitstring)*bitsPerByte {
+		return fmt.Errorf("status list index %d out of range", index)
+	}
+
+	mask := byte(1) << (bitsPerByte - 1 - index%bitsPerByte)
+
+	if set {
+		bitstring[index/bitsPerByte] |= mask
+	} else {
+		bitstring[index/bitsPerByte] &^= mask
+	}
+
+	list, err := encodeStatusList(bitstring)
+	if err != nil {
+		return err
+	}
+
+	subject[encodedList] = list
+	statusListVC.Proofs = nil
+
+	return nil
+}
+
+// statusEntry is the index of a credential in the status list credential of the purpose
+type statusEntry struct {
+	statusListCredential string
+	index                int
+	purpose              StatusPurpose
+
+	credentialType string
+	subjectType    string
+}
+
+func parseStatusEntry(status *TypedID) (*statusEntry, error) {
+	var (
+		entry          *statusEntry
+		index          interface{}
+		listCredential interface{}
+	)
+
+	switch status.Type {
+	case RevocationList2020Status:
+		entry = &statusEntry{
+			purpose:        StatusPurposeRevocation,
+			credentialType: RevocationList2020Credential,
+			subjectType:    revocationList2020,
+		}
+		index, listCredential = status.CustomFields[revocationListIndex], status.CustomFields[revocationListCredential]
+	case StatusList2021Entry:
+		entry = &statusEntry{
+			purpose:        StatusPurpose(stringValue(status.CustomFields[statusPurpose])),
+			credentialType: StatusList2021Credential,
+			subjectType:    statusList2021,
+		}
+		index, listCredential = status.CustomFields[statusListIndex], status.CustomFields[statusListCredential]
+
+		if entry.purpose != StatusPurposeRevocation && entry.purpose != StatusPurposeSuspension {
+			return nil, fmt.Errorf("unsupported status purpose %s", entry.purpose)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported credential status type %s", status.Type)
+	}
+
+	entry.statusListCredential = stringValue(listCredential)
+	if entry.statusListCredential == "" {
+		return nil, errors.New("status list credential of credential status is not defined")
+	}
+
+	var err error
+
+	entry.index, err = statusIndex(index)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// statusListSubject returns the subject of the status list credential, checking the status list
+// credential is of the type and purpose of the status entry
+func (e *statusEntry) statusListSubject(statusListVC *Credential) (map[string]interface{}, error) {
+	if !containsType(statusListVC.Types, e.credentialType) {
+		return nil, fmt.Errorf("status list credential is not of type %s", e.credentialType)
+	}
+
+	subject, err := singleSubject(statusListVC.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if subject["type"] != e.subjectType {
+		return nil, fmt.Errorf("status list credential subject is not of type %s", e.subjectType)
+	}
+
+	if e.subjectType == statusList2021 && subject[statusPurpose] != string(e.purpose) {
+		return nil, fmt.Errorf("status list credential is not of status purpose %s", e.purpose)
+	}
+
+	return subject, nil
+}
+
+// statusIndex parses the index of the credential status, a string or a number
+func statusIndex(v interface{}) (int, error) {
+	var (
+		index int
+		err   error
+	)
+
+	switch value := v.(type) {
+	case string:
+		index, err = strconv.Atoi(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid status list index %s", value)
+		}
+	case float64:
+		index = int(value)
+		if float64(index) != value {
+			return 0, fmt.Errorf("invalid status list index %v", value)
+		}
+	default:
+		return 0, errors.New("status list index of credential status is not defined")
+	}
+
+	if index < 0 {
+		return 0, fmt.Errorf("invalid status list index %d", index)
+	}
+
+	return index, nil
+}
+
+// singleSubject returns the single subject of the credential as JSON object
+func singleSubject(subject Subject) (map[string]interface{}, error) {
+	if subjects, ok := subject.([]interface{}); ok && len(subjects) == 1 {
+		subject = subjects[0]
+	}
+
+	subjectMap, ok := subject.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("status list credential subject is not a single object")
+	}
+
+	return subjectMap, nil
+}
+
+// decodeStatusList decodes the bitstring of the status list, GZIP compressed and base64url encoded
+func decodeStatusList(v interface{}) ([]byte, error) {
+	list, ok := v.(string)
+	if !ok || list == "" {
+		return nil, errors.New("encoded list of status list credential is not defined")
+	}
+
+	compressed, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(list, "="))
+	if err != nil {
+		return nil, fmt.Errorf("decode status list: %w", err)
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("decompress status list: %w", err)
+	}
+
+	bitstring, err := ioutil.ReadAll(io.LimitReader(reader, maxStatusListSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("decompress status list: %w", err)
+	}
+
+	if len(bitstring) > maxStatusListSize {
+		return nil, errors.New("status list exceeds the maximum size")
+	}
+
+	return bitstring, nil
+}
+
+func encodeStatusList(bitstring []byte) (string, error) {
+	var buf bytes.Buffer
+
+	writer := gzip.NewWriter(&buf)
+
+	if _, err := writer.Write(bitstring); err != nil {
+		return "", fmt.Errorf("compress status list: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("compress status list: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// statusBit returns the bit of the index in the bitstring, the first index is the most significant bit
+// of the first byte
+func statusBit(bitstring []byte, index int) (bool, error) {
+	if index >= len(bitstring)*bitsPerByte {
+		return false, fmt.Errorf("status list index %d out of range", index)
+	}
+
+	return bitstring[index/bitsPerByte]&(1<<(bitsPerByte-1-index%bitsPerByte)) != 0, nil
+}
+
+func containsType(types []string, t string) bool {
+	for _, vcType := range types {
+		if vcType == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+func stringValue(v interface{}) string {
+	s, _ := v.(string) // nolint:errcheck
+
+	return s
+}
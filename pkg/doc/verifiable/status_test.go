@@ -0,0 +1,402 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	statusListIssuer       = "did:example:76e12ec712ebc6f1c221ebfeb1f"
+	revocationListURL      = "https://example.com/credentials/status/3"
+	suspensionListURL      = "https://example.com/credentials/status/4"
+	revocationList2021URL  = "https://example.com/credentials/status/5"
+	unsignedListURL        = "https://example.com/credentials/status/unsigned"
+	movedListURL           = "https://example.com/credentials/status/6"
+	expiredListURL         = "https://example.com/credentials/status/7"
+	revokedCredentialIndex = 94567
+)
+
+func TestStatusChecker_CheckStatus(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	issuer := Issuer{ID: statusListIssuer}
+	issued := time.Now()
+
+	revocationList, err := NewRevocationList2020Credential(revocationListURL, issuer, issued)
+	require.NoError(t, err)
+	require.NoError(t, SetStatusListBit(revocationList, revokedCredentialIndex, true))
+
+	suspensionList, err := NewStatusList2021Credential(suspensionListURL, issuer, StatusPurposeSuspension, issued)
+	require.NoError(t, err)
+	require.NoError(t, SetStatusListBit(suspensionList, revokedCredentialIndex, true))
+
+	revocationList2021, err := NewStatusList2021Credential(revocationList2021URL, issuer,
+		StatusPurposeRevocation, issued)
+	require.NoError(t, err)
+
+	expiredRevocationList, err := NewRevocationList2020Credential(expiredListURL, issuer, issued.Add(-48*time.Hour))
+	require.NoError(t, err)
+
+	expired := issued.Add(-24 * time.Hour).Truncate(time.Second).UTC()
+	expiredRevocationList.Expired = &expired
+
+	unsignedRevocationList, err := json.Marshal(revocationList)
+	require.NoError(t, err)
+
+	statusLists := map[string][]byte{
+		revocationListURL:     createStatusListJWS(t, revocationList, privKey),
+		suspensionListURL:     createStatusListJWS(t, suspensionList, privKey),
+		revocationList2021URL: createStatusListJWS(t, revocationList2021, privKey),
+		unsignedListURL:       unsignedRevocationList,
+		movedListURL:          createStatusListJWS(t, revocationList, privKey),
+		expiredListURL:        createStatusListJWS(t, expiredRevocationList, privKey),
+	}
+
+	fetcher := func(url string) ([]byte, error) {
+		statusList, ok := statusLists[url]
+		if !ok {
+			return nil, fmt.Errorf("status list %s not found", url)
+		}
+
+		return statusList, nil
+	}
+
+	checker := NewStatusChecker(fetcher, statusListCredentialOpts(pubKey)...)
+
+	t.Run("check status of revocation list 2020", func(t *testing.T) {
+		status, err := checker.CheckStatus(&Credential{
+			Issuer: issuer,
+			Status: NewRevocationList2020Status(revocationListURL, revokedCredentialIndex),
+		})
+		require.NoError(t, err)
+		require.Equal(t, CredentialRevoked, status)
+
+		status, err = checker.CheckStatus(&Credential{
+			Issuer: issuer,
+			Status: NewRevocationList2020Status(revocationListURL, revokedCredentialIndex+1),
+		})
+		require.NoError(t, err)
+		require.Equal(t, CredentialActive, status)
+	})
+
+	t.Run("check status of status list 2021", func(t *testing.T) {
+		status, err := checker.CheckStatus(&Credential{
+			Issuer: issuer,
+			Status: NewStatusList2021Entry(suspensionListURL, StatusPurposeSuspension, revokedCredentialIndex),
+		})
+		require.NoError(t, err)
+		require.Equal(t, CredentialSuspended, status)
+
+		status, err = checker.CheckStatus(&Credential{
+			Issuer: issuer,
+			Status: NewStatusList2021Entry(revocationList2021URL, StatusPurposeRevocation, revokedCredentialIndex),
+		})
+		require.NoError(t, err)
+		require.Equal(t, CredentialActive, status)
+	})
+
+	t.Run("index as number", func(t *testing.T) {
+		status, err := checker.CheckStatus(&Credential{
+			Issuer: issuer,
+			Status: &TypedID{
+				ID:   revocationListURL + "#94567",
+				Type: RevocationList2020Status,
+				CustomFields: CustomFields{
+					"revocationListIndex":      float64(revokedCredentialIndex),
+					"revocationListCredential": revocationListURL,
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, CredentialRevoked, status)
+	})
+
+	t.Run("status list credential of other issuer", func(t *testing.T) {
+		_, err := checker.CheckStatus(&Credential{
+			Issuer: Issuer{ID: "did:example:other"},
+			Status: NewRevocationList2020Status(revocationListURL, revokedCredentialIndex),
+		})
+		require.EqualError(t, err, "issuer did:example:76e12ec712ebc6f1c221ebfeb1f of status list credential "+
+			"does not match issuer did:example:other of credential")
+	})
+
+	t.Run("status list credential signed by other key", func(t *testing.T) {
+		otherPubKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		_, err = NewStatusChecker(fetcher, statusListCredentialOpts(otherPubKey)...).CheckStatus(&Credential{
+			Issuer: issuer,
+			Status: NewRevocationList2020Status(revocationListURL, revokedCredentialIndex),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode status list credential")
+	})
+
+	t.Run("status list credential without proof", func(t *testing.T) {
+		_, err := checker.CheckStatus(&Credential{
+			Issuer: issuer,
+			Status: NewRevocationList2020Status(unsignedListURL, revokedCredentialIndex),
+		})
+		require.EqualError(t, err, "decode status list credential: decode new credential: embedded proof is missing")
+	})
+
+	t.Run("expired status list credential", func(t *testing.T) {
+		_, err := checker.CheckStatus(&Credential{
+			Issuer: issuer,
+			Status: NewRevocationList2020Status(expiredListURL, revokedCredentialIndex),
+		})
+		require.EqualError(t, err, "status list credential "+expiredListURL+" expired at "+
+			expired.Format(time.RFC3339))
+	})
+
+	t.Run("status list credential of other URL", func(t *testing.T) {
+		_, err := checker.CheckStatus(&Credential{
+			Issuer: issuer,
+			Status: NewRevocationList2020Status(movedListURL, revokedCredentialIndex),
+		})
+		require.EqualError(t, err, "status list credential https://example.com/credentials/status/3 does not match "+
+			"status list credential https://example.com/credentials/status/6 of credential")
+	})
+
+	t.Run("status list credential of other type or purpose", func(t *testing.T) {
+		_, err := checker.CheckStatus(&Credential{
+			Issuer: issuer,
+			Status: NewRevocationList2020Status(suspensionListURL, revokedCredentialIndex),
+		})
+		require.EqualError(t, err, "status list credential is not of type RevocationList2020Credential")
+
+		_, err = checker.CheckStatus(&Credential{
+			Issuer: issuer,
+			Status: NewStatusList2021Entry(revocationListURL, StatusPurposeRevocation, revokedCredentialIndex),
+		})
+		require.EqualError(t, err, "status list credential is not of type StatusList2021Credential")
+
+		_, err = checker.CheckStatus(&Credential{
+			Issuer: issuer,
+			Status: NewStatusList2021Entry(suspensionListURL, StatusPurposeRevocation, revokedCredentialIndex),
+		})
+		require.EqualError(t, err, "status list credential is not of status purpose revocation")
+	})
+
+	t.Run("invalid credential status", func(t *testing.T) {
+		_, err := checker.CheckStatus(&Credential{Issuer: issuer})
+		require.EqualError(t, err, "credential status is not defined")
+
+		_, err = checker.CheckStatus(&Credential{
+			Issuer: issuer,
+			Status: &TypedID{ID: "https://example.edu/status/24", Type: "CredentialStatusList2017"},
+		})
+		require.EqualError(t, err, "unsupported credential status type CredentialStatusList2017")
+
+		_, err = checker.CheckStatus(&Credential{
+			Issuer: issuer,
+			Status: NewStatusList2021Entry(suspensionListURL, "other", revokedCredentialIndex),
+		})
+		require.EqualError(t, err, "unsupported status purpose other")
+
+		_, err = checker.CheckStatus(&Credential{
+			Issuer: issuer,
+			Status: &TypedID{ID: revocationListURL + "#1", Type: RevocationList2020Status,
+				CustomFields: CustomFields{"revocationListIndex": "1"}},
+		})
+		require.EqualError(t, err, "status list credential of credential status is not defined")
+
+		_, err = checker.CheckStatus(&Credential{
+			Issuer: issuer,
+			Status: &TypedID{ID: revocationListURL, Type: RevocationList2020Status,
+				CustomFields: CustomFields{"revocationListCredential": revocationListURL}},
+		})
+		require.EqualError(t, err, "status list index of credential status is not defined")
+
+		_, err = checker.CheckStatus(&Credential{
+			Issuer: issuer,
+			Status: &TypedID{ID: revocationListURL + "#a", Type: RevocationList2020Status,
+				CustomFields: CustomFields{"revocationListIndex": "a", "revocationListCredential": revocationListURL}},
+		})
+		require.EqualError(t, err, "invalid status list index a")
+
+		_, err = checker.CheckStatus(&Credential{
+			Issuer: issuer,
+			Status: NewRevocationList2020Status(revocationListURL, -1),
+		})
+		require.EqualError(t, err, "invalid status list index -1")
+
+		_, err = checker.CheckStatus(&Credential{
+			Issuer: issuer,
+			Status: NewRevocationList2020Status(revocationListURL, StatusListSize),
+		})
+		require.EqualError(t, err, "status list index 131072 out of range")
+	})
+
+	t.Run("fetch status list credential error", func(t *testing.T) {
+		_, err := checker.CheckStatus(&Credential{
+			Issuer: issuer,
+			Status: NewRevocationList2020Status("https://example.com/credentials/status/unknown", 1),
+		})
+		require.EqualError(t, err, "fetch status list credential: "+
+			"status list https://example.com/credentials/status/unknown not found")
+	})
+}
+
+func TestSetStatusListBit(t *testing.T) {
+	statusList, err := NewRevocationList2020Credential(revocationListURL, Issuer{ID: statusListIssuer}, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, []string{baseContext, "https://w3id.org/vc-revocation-list-2020/v1"}, statusList.Context)
+	require.Equal(t, []string{vcType, RevocationList2020Credential}, statusList.Types)
+
+	statusList.Proofs = []Proof{{"type": "Ed25519Signature2018"}}
+
+	require.NoError(t, SetStatusListBit(statusList, 0, true))
+	require.NoError(t, SetStatusListBit(statusList, 9, true))
+	require.Empty(t, statusList.Proofs)
+
+	subject, ok := statusList.Subject.(map[string]interface{})
+	require.True(t, ok)
+	require.Equal(t, revocationListURL+"#list", subject["id"])
+	require.Equal(t, "RevocationList2020", subject["type"])
+
+	bitstring, err := decodeStatusList(subject["encodedList"])
+	require.NoError(t, err)
+	require.Len(t, bitstring, StatusListSize/8)
+	require.Equal(t, []byte{0x80, 0x40, 0}, bitstring[:3])
+
+	require.NoError(t, SetStatusListBit(statusList, 0, false))
+
+	bitstring, err = decodeStatusList(subject["encodedList"])
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 0x40, 0}, bitstring[:3])
+
+	err = SetStatusListBit(statusList, StatusListSize, true)
+	require.EqualError(t, err, "status list index 131072 out of range")
+
+	err = SetStatusListBit(statusList, -1, true)
+	require.EqualError(t, err, "status list index -1 out of range")
+
+	err = SetStatusListBit(&Credential{Subject: "did:example:ebfeb1f712ebc6f1c276e12ec21"}, 1, true)
+	require.EqualError(t, err, "status list credential subject is not a single object")
+
+	err = SetStatusListBit(&Credential{Subject: map[string]interface{}{"encodedList": "invalid"}}, 1, true)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "decompress status list")
+
+	err = SetStatusListBit(&Credential{Subject: map[string]interface{}{}}, 1, true)
+	require.EqualError(t, err, "encoded list of status list credential is not defined")
+}
+
+func Test_decodeStatusList(t *testing.T) {
+	// the encoded list of the RevocationList2020 specification example, 100000 active credentials
+	bitstring, err := decodeStatusList("H4sIAAAAAAAAA-3BMQEAAADCoPVPbQsvoAAAAAAAAAAAAAAAAP4GcwM92tQwAAA")
+	require.NoError(t, err)
+	require.Len(t, bitstring, 12500)
+
+	set, err := statusBit(bitstring, 99999)
+	require.NoError(t, err)
+	require.False(t, set)
+
+	_, err = statusBit(bitstring, 100000)
+	require.EqualError(t, err, "status list index 100000 out of range")
+
+	_, err = decodeStatusList("not base64!")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "decode status list")
+
+	t.Run("gzip bomb", func(t *testing.T) {
+		// 32MB of zeros compresses into about 32KB
+		var buf bytes.Buffer
+
+		writer := gzip.NewWriter(&buf)
+
+		zeros := make([]byte, 1<<20)
+		for i := 0; i < 32; i++ {
+			_, err := writer.Write(zeros)
+			require.NoError(t, err)
+		}
+
+		require.NoError(t, writer.Close())
+
+		_, err := decodeStatusList(base64.RawURLEncoding.EncodeToString(buf.Bytes()))
+		require.EqualError(t, err, "status list exceeds the maximum size")
+	})
+}
+
+func TestHTTPStatusListFetcher(t *testing.T) {
+	statusList := []byte("status list credential")
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/status/large" {
+			res.WriteHeader(http.StatusOK)
+			_, err := res.Write(make([]byte, maxStatusListCredentialSize+1))
+			require.NoError(t, err)
+
+			return
+		}
+
+		if req.URL.Path != "/status/3" {
+			res.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		res.WriteHeader(http.StatusOK)
+		_, err := res.Write(statusList)
+		require.NoError(t, err)
+	}))
+	defer testServer.Close()
+
+	fetcher := HTTPStatusListFetcher(&http.Client{})
+
+	fetched, err := fetcher(testServer.URL + "/status/3")
+	require.NoError(t, err)
+	require.Equal(t, statusList, fetched)
+
+	_, err = fetcher(testServer.URL + "/status/4")
+	require.EqualError(t, err, "status list credential endpoint HTTP failure [404]")
+
+	_, err = fetcher(testServer.URL + "/status/large")
+	require.EqualError(t, err, "status list credential exceeds the maximum size")
+
+	_, err = fetcher("http://invalid.example.com\x7f")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "load status list credential")
+
+	// the default HTTP client is used without client
+	fetched, err = HTTPStatusListFetcher(nil)(testServer.URL + "/status/3")
+	require.NoError(t, err)
+	require.Equal(t, statusList, fetched)
+}
+
+func createStatusListJWS(t *testing.T, statusList *Credential, privKey ed25519.PrivateKey) []byte {
+	jwtClaims, err := statusList.JWTClaims(false)
+	require.NoError(t, err)
+
+	jws, err := jwtClaims.MarshalJWS(EdDSA, privKey, statusList.Issuer.ID+"#keys-"+keyID)
+	require.NoError(t, err)
+
+	return []byte(jws)
+}
+
+func statusListCredentialOpts(pubKey ed25519.PublicKey) []CredentialOpt {
+	return []CredentialOpt{
+		WithPublicKeyFetcher(SingleKey(pubKey)),
+		WithBaseContextExtendedValidation(
+			[]string{"https://w3id.org/vc-revocation-list-2020/v1", "https://w3id.org/vc/status-list/2021/v1"},
+			[]string{RevocationList2020Credential, StatusList2021Credential}),
+	}
+}
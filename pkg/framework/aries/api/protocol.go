@@ -13,7 +13,9 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	didcommtransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/legacykms"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
@@ -27,8 +29,11 @@ type Provider interface {
 	OutboundDispatcher() dispatcher.Outbound
 	Messenger() service.Messenger
 	Service(id string) (interface{}, error)
+	AllServices() []dispatcher.ProtocolService
+	MessageServiceProvider() MessageServiceProvider
 	StorageProvider() storage.Provider
 	LegacyKMS() legacykms.KeyManager
+	KMS() kms.KeyManager
 	SecretLock() secretlock.Service
 	Crypto() crypto.Crypto
 	Packager() transport.Packager
@@ -37,6 +42,7 @@ type Provider interface {
 	VDRIRegistry() vdriapi.Registry
 	Signer() legacykms.Signer
 	TransientStorageProvider() storage.Provider
+	InboundMessageHandler() didcommtransport.InboundMessageHandler
 }
 
 // ProtocolSvcCreator method to create new protocol service
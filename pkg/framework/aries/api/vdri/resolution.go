@@ -0,0 +1,103 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdri
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+// DIDDocumentContentType is the content type of the resolved DID documents
+const DIDDocumentContentType = "application/did+ld+json"
+
+// Errors of the DID resolution metadata (https://w3c-ccg.github.io/did-resolution/#errors)
+const (
+	// InvalidDIDError the DID is not valid
+	InvalidDIDError = "invalidDid"
+	// NotFoundError the DID or its requested version is not found
+	NotFoundError = "notFound"
+	// MethodNotSupportedError the DID method is not supported
+	MethodNotSupportedError = "methodNotSupported"
+)
+
+// ResultResolver is implemented by the VDRIs resolving the DID documents with their metadata,
+// the registry builds the metadata of the DID documents read by the other VDRIs.
+type ResultResolver interface {
+	ReadResult(did string, opts ...ResolveOpts) (*DIDResolutionResult, error)
+}
+
+// DIDResolutionResult DID resolution result (https://w3c-ccg.github.io/did-resolution/#did-resolution-result)
+type DIDResolutionResult struct {
+	DIDDocument        *did.Doc
+	ResolutionMetadata ResolutionMetadata
+	DocumentMetadata   DocumentMetadata
+}
+
+// ResolutionMetadata metadata of the DID resolution process
+type ResolutionMetadata struct {
+	ContentType string `json:"contentType,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// DocumentMetadata metadata of the resolved DID document
+type DocumentMetadata struct {
+	Created     *time.Time `json:"created,omitempty"`
+	Updated     *time.Time `json:"updated,omitempty"`
+	VersionID   string     `json:"versionId,omitempty"`
+	Deactivated bool       `json:"deactivated,omitempty"`
+}
+
+type rawDIDResolutionResult struct {
+	DIDDocument        json.RawMessage    `json:"didDocument,omitempty"`
+	ResolutionMetadata ResolutionMetadata `json:"didResolutionMetadata"`
+	DocumentMetadata   DocumentMetadata   `json:"didDocumentMetadata"`
+}
+
+// MarshalJSON marshals the DID resolution result, with the JSON-LD DID document.
+func (r *DIDResolutionResult) MarshalJSON() ([]byte, error) {
+	raw := rawDIDResolutionResult{
+		ResolutionMetadata: r.ResolutionMetadata,
+		DocumentMetadata:   r.DocumentMetadata,
+	}
+
+	if r.DIDDocument != nil {
+		docBytes, err := r.DIDDocument.JSONBytes()
+		if err != nil {
+			return nil, fmt.Errorf("marshal DID document: %w", err)
+		}
+
+		raw.DIDDocument = docBytes
+	}
+
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON unmarshals the DID resolution result, the DID document is parsed with did.ParseDocument.
+func (r *DIDResolutionResult) UnmarshalJSON(data []byte) error {
+	raw := rawDIDResolutionResult{}
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	r.ResolutionMetadata = raw.ResolutionMetadata
+	r.DocumentMetadata = raw.DocumentMetadata
+	r.DIDDocument = nil
+
+	if len(raw.DIDDocument) > 0 && string(raw.DIDDocument) != "null" {
+		r.DIDDocument, err = did.ParseDocument(raw.DIDDocument)
+		if err != nil {
+			return fmt.Errorf("parse DID document: %w", err)
+		}
+	}
+
+	return nil
+}
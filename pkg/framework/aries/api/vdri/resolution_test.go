@@ -0,0 +1,64 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdri
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+func TestDIDResolutionResult_JSON(t *testing.T) {
+	t.Run("test result with document", func(t *testing.T) {
+		created := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+
+		result := &DIDResolutionResult{
+			DIDDocument:        &did.Doc{Context: []string{did.Context}, ID: "did:example:123"},
+			ResolutionMetadata: ResolutionMetadata{ContentType: DIDDocumentContentType},
+			DocumentMetadata:   DocumentMetadata{Created: &created, VersionID: "1"},
+		}
+
+		b, err := json.Marshal(result)
+		require.NoError(t, err)
+
+		raw := map[string]json.RawMessage{}
+		require.NoError(t, json.Unmarshal(b, &raw))
+		require.Contains(t, raw, "didDocument")
+		require.JSONEq(t, `{"contentType":"application/did+ld+json"}`, string(raw["didResolutionMetadata"]))
+		require.JSONEq(t, `{"created":"2020-05-01T10:00:00Z","versionId":"1"}`, string(raw["didDocumentMetadata"]))
+
+		parsed := &DIDResolutionResult{}
+		require.NoError(t, json.Unmarshal(b, parsed))
+		require.Equal(t, "did:example:123", parsed.DIDDocument.ID)
+		require.Equal(t, result.ResolutionMetadata, parsed.ResolutionMetadata)
+		require.Equal(t, result.DocumentMetadata, parsed.DocumentMetadata)
+	})
+
+	t.Run("test result with error", func(t *testing.T) {
+		result := &DIDResolutionResult{ResolutionMetadata: ResolutionMetadata{Error: NotFoundError}}
+
+		b, err := json.Marshal(result)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"didResolutionMetadata":{"error":"notFound"},"didDocumentMetadata":{}}`, string(b))
+
+		parsed := &DIDResolutionResult{}
+		require.NoError(t, json.Unmarshal(b, parsed))
+		require.Equal(t, result, parsed)
+	})
+
+	t.Run("test unmarshal errors", func(t *testing.T) {
+		require.Error(t, json.Unmarshal([]byte(`{"didDocumentMetadata":""}`), &DIDResolutionResult{}))
+
+		err := json.Unmarshal([]byte(`{"didDocument":{"id":1}}`), &DIDResolutionResult{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse DID document")
+	})
+}
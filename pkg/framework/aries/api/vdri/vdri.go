@@ -12,19 +12,26 @@ import (
 	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/legacykms"
 )
 
 // ErrNotFound is returned when a DID resolver does not find the DID.
 var ErrNotFound = errors.New("DID not found")
 
+// ErrNotSupported is returned when the DID method does not support the operation (e.g updating a did:key).
+var ErrNotSupported = errors.New("operation not supported by the DID method")
+
 // DIDCommServiceType default DID Communication service endpoint type
 const DIDCommServiceType = "did-communication"
 
 // Registry vdri registry
 type Registry interface {
 	Resolve(did string, opts ...ResolveOpts) (*did.Doc, error)
+	ResolveResult(did string, opts ...ResolveOpts) (*DIDResolutionResult, error)
 	Store(doc *did.Doc) error
 	Create(method string, opts ...DocOpts) (*did.Doc, error)
+	Update(doc *did.Doc, opts ...UpdateOpts) error
+	Deactivate(did string, opts ...UpdateOpts) error
 	Close() error
 }
 
@@ -33,6 +40,8 @@ type VDRI interface {
 	Read(did string, opts ...ResolveOpts) (*did.Doc, error)
 	Store(doc *did.Doc, by *[]ModifiedBy) error
 	Build(pubKey *PubKey, opts ...DocOpts) (*did.Doc, error)
+	Update(doc *did.Doc, opts ...UpdateOpts) error
+	Deactivate(did string, opts ...UpdateOpts) error
 	Accept(method string) bool
 	Close() error
 }
@@ -43,7 +52,7 @@ type ResultType int
 const (
 	// DidDocumentResult Request a DID Document as output
 	DidDocumentResult ResultType = iota
-	// ResolutionResult Request a DID Resolution Result
+	// ResolutionResult Request a DID Resolution Result (see Registry.ResolveResult)
 	ResolutionResult
 )
 
@@ -134,6 +143,30 @@ func WithRequestBuilder(builder func(payload []byte) (io.Reader, error)) DocOpts
 	}
 }
 
+// UpdateDIDOpts holds the options for updating and deactivating DIDs
+type UpdateDIDOpts struct {
+	Signer         legacykms.Signer
+	RequestBuilder func([]byte) (io.Reader, error)
+}
+
+// UpdateOpts is an update or deactivate DID option
+type UpdateOpts func(opts *UpdateDIDOpts)
+
+// WithSigner allows for setting the signer of the operation, signing with the keys of the current DID document
+func WithSigner(signer legacykms.Signer) UpdateOpts {
+	return func(opts *UpdateDIDOpts) {
+		opts.Signer = signer
+	}
+}
+
+// WithUpdateRequestBuilder allows to supply request builder
+// which can be used to add headers to request stream to be sent to HTTP binding URL
+func WithUpdateRequestBuilder(builder func(payload []byte) (io.Reader, error)) UpdateOpts {
+	return func(opts *UpdateDIDOpts) {
+		opts.RequestBuilder = builder
+	}
+}
+
 // PubKey contains public key type and value
 type PubKey struct {
 	Value string // base58 encoded
@@ -10,21 +10,35 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packager"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/jwe/anoncrypt"
 	jwe "github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/jwe/authcrypt"
 	legacy "github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/legacy/authcrypt"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didsync"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/introduce"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/messagepickup"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/route"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
 	arieshttp "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/http"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/legacykms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
 )
 
+var logger = log.New("aries-framework/framework")
+
 // defFrameworkOpts provides default framework options
 func defFrameworkOpts(frameworkOpts *Aries) error {
 	// TODO https://github.com/hyperledger/aries-framework-go/issues/209 Move default providers to the sub-package
@@ -46,9 +60,11 @@ func defFrameworkOpts(frameworkOpts *Aries) error {
 		frameworkOpts.storeProvider = storeProv
 	}
 
-	// order is important as DIDExchange service depends on Route service and Introduce depends on DIDExchange
+	// order is important as Route service depends on MessagePickup service, DIDExchange service depends on
+	// Route service, Introduce and OutOfBand depend on DIDExchange
 	frameworkOpts.protocolSvcCreators = append(frameworkOpts.protocolSvcCreators,
-		newRouteSvc(), newExchangeSvc(), newIntroduceSvc())
+		newMessagePickupSvc(), newRouteSvc(), newExchangeSvc(), newIntroduceSvc(), newIssueCredentialSvc(),
+		newPresentProofSvc(), newOutOfBandSvc(), newTrustPingSvc(), newDiscoverFeaturesSvc(), newDIDSyncSvc())
 
 	return setAdditionalDefaultOpts(frameworkOpts)
 }
@@ -65,6 +81,48 @@ func newIntroduceSvc() api.ProtocolSvcCreator {
 	}
 }
 
+func newIssueCredentialSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return issuecredential.New(prv)
+	}
+}
+
+func newPresentProofSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return presentproof.New(prv)
+	}
+}
+
+func newMessagePickupSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return messagepickup.New(prv)
+	}
+}
+
+func newOutOfBandSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return outofband.New(prv)
+	}
+}
+
+func newTrustPingSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return trustping.New(prv)
+	}
+}
+
+func newDiscoverFeaturesSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return discoverfeatures.New(prv)
+	}
+}
+
+func newDIDSyncSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return didsync.New(prv)
+	}
+}
+
 func newRouteSvc() api.ProtocolSvcCreator {
 	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
 		return route.New(prv)
@@ -74,10 +132,31 @@ func newRouteSvc() api.ProtocolSvcCreator {
 func setAdditionalDefaultOpts(frameworkOpts *Aries) error {
 	if frameworkOpts.kmsCreator == nil {
 		frameworkOpts.kmsCreator = func(provider api.Provider) (api.CloseableKMS, error) {
+			// the key sets created at runtime (DIDs, connections, routes) are written through to the KMS,
+			// the packers find the keys of the recipients and senders as key handles of the KMS
+			if importer, ok := provider.KMS().(legacykms.KeyImporter); ok {
+				return legacykms.New(provider, legacykms.WithKeyImporter(importer))
+			}
+
 			return legacykms.New(provider)
 		}
 	}
 
+	if frameworkOpts.secretLock == nil {
+		// TODO add SecretLock creation here.. #1150
+		// WARNING: the keys of the default local kms are stored unencrypted (in plaintext) with the no-op secret
+		// lock, a secret lock service must be set with WithSecretLock() in production.
+		logger.Warnf("no secret lock service is set, the keys of the KMS are stored unencrypted")
+
+		frameworkOpts.secretLock = &noop.NoLock{}
+	}
+
+	if frameworkOpts.keyManagerCreator == nil {
+		frameworkOpts.keyManagerCreator = func(provider kms.Provider) (kms.KeyManager, error) {
+			return localkms.New(defaultMasterKeyURI, provider)
+		}
+	}
+
 	if frameworkOpts.crypto == nil {
 		// create default tink crypto if not passed in frameworkOpts
 		cr, err := tinkcrypto.New()
@@ -100,6 +179,9 @@ func setAdditionalDefaultOpts(frameworkOpts *Aries) error {
 			func(provider packer.Provider) (packer.Packer, error) {
 				return jwe.New(provider, jwe.XC20P)
 			},
+			func(provider packer.Provider) (packer.Packer, error) {
+				return anoncrypt.New(provider, anoncrypt.XC20P)
+			},
 		}
 	}
 
@@ -122,8 +204,6 @@ func setAdditionalDefaultOpts(frameworkOpts *Aries) error {
 		frameworkOpts.msgSvcProvider = &noOpMessageServiceProvider{}
 	}
 
-	// TODO add SecretLock creation here.. #1150
-
 	return nil
 }
 
@@ -25,9 +25,11 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
 	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/vdri/key"
 	"github.com/hyperledger/aries-framework-go/pkg/vdri/peer"
 )
 
@@ -35,6 +37,9 @@ const (
 	// TODO https://github.com/hyperledger/aries-framework-go/issues/837 - If inbound not present, the endpoint
 	//  should be of routing agent
 	defaultEndpoint = "routing:endpoint"
+
+	// defaultMasterKeyURI is the master key used by the default local kms to protect its keys.
+	defaultMasterKeyURI = "local-lock://default/master/key/"
 )
 
 // Aries provides access to the context being managed by the framework. The context can be used to create aries clients.
@@ -51,6 +56,8 @@ type Aries struct {
 	inboundTransports      []transport.InboundTransport
 	kmsCreator             api.KMSCreator
 	kms                    api.CloseableKMS
+	keyManagerCreator      kms.Creator
+	keyManager             kms.KeyManager
 	secretLock             secretlock.Service
 	crypto                 crypto.Crypto
 	packagerCreator        packager.Creator
@@ -61,6 +68,7 @@ type Aries struct {
 	packers                []packer.Packer
 	vdriRegistry           vdriapi.Registry
 	vdri                   []vdriapi.VDRI
+	resolutionCache        vdri.ResolutionCache
 	transportReturnRoute   string
 	id                     string
 }
@@ -213,7 +221,17 @@ func WithLegacyKMS(k api.KMSCreator) Option {
 	}
 }
 
-// WithSecretLock injects a SecretLock service to the Aries framework
+// WithKMS injects a KMS service to the Aries framework.
+func WithKMS(k kms.Creator) Option {
+	return func(opts *Aries) error {
+		opts.keyManagerCreator = k
+		return nil
+	}
+}
+
+// WithSecretLock injects a SecretLock service to the Aries framework.
+// WARNING: this option must be set in production. Without it, the default local KMS uses a no-op secret lock
+// and stores the private keys unencrypted (in plaintext) in the storage provider.
 func WithSecretLock(s secretlock.Service) Option {
 	return func(opts *Aries) error {
 		opts.secretLock = s
@@ -237,6 +255,15 @@ func WithVDRI(v vdriapi.VDRI) Option {
 	}
 }
 
+// WithResolutionCache injects a cache of the DID resolutions (e.g vdri.NewLRUCache()) to the VDRI registry
+// of the Aries framework.
+func WithResolutionCache(c vdri.ResolutionCache) Option {
+	return func(opts *Aries) error {
+		opts.resolutionCache = c
+		return nil
+	}
+}
+
 // WithMessageServiceProvider injects a message service provider to the Aries framework.
 // Message service provider returns list of message services which can be used to provide custom handle
 // functionality based on incoming messages type and purpose.
@@ -267,6 +294,7 @@ func (a *Aries) Context() (*context.Provider, error) {
 		context.WithOutboundTransports(a.outboundTransports...),
 		context.WithProtocolServices(a.services...),
 		context.WithLegacyKMS(a.kms),
+		context.WithKMS(a.keyManager),
 		context.WithSecretLock(a.secretLock),
 		context.WithCrypto(a.crypto),
 		context.WithServiceEndpoint(serviceEndpoint(a)),
@@ -333,19 +361,43 @@ func (a *Aries) closeVDRI() error {
 func createKMS(frameworkOpts *Aries) error {
 	ctx, err := context.New(
 		context.WithStorageProvider(frameworkOpts.storeProvider),
+		context.WithSecretLock(frameworkOpts.secretLock),
 	)
 	if err != nil {
 		return fmt.Errorf("create context failed: %w", err)
 	}
 
+	frameworkOpts.keyManager, err = frameworkOpts.keyManagerCreator(ctx)
+	if err != nil {
+		return fmt.Errorf("create key manager failed: %w", err)
+	}
+
+	// the legacy kms is created with the key manager, so it can write its new key sets through to it
+	err = context.WithKMS(frameworkOpts.keyManager)(ctx)
+	if err != nil {
+		return fmt.Errorf("create context failed: %w", err)
+	}
+
 	frameworkOpts.kms, err = frameworkOpts.kmsCreator(ctx)
 	if err != nil {
 		return fmt.Errorf("create kms failed: %w", err)
 	}
 
+	// import the keys created by the legacy kms so the existing DIDs remain usable
+	if importer, ok := frameworkOpts.keyManager.(legacyKeyImporter); ok {
+		if _, err = importer.ImportLegacyKeys(frameworkOpts.storeProvider); err != nil {
+			return fmt.Errorf("import legacy keys failed: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// legacyKeyImporter is implemented by the key managers which can import the legacy kms key pairs.
+type legacyKeyImporter interface {
+	ImportLegacyKeys(p storage.Provider) ([]string, error)
+}
+
 func createVDRI(frameworkOpts *Aries) error {
 	ctx, err := context.New(
 		context.WithLegacyKMS(frameworkOpts.kms),
@@ -369,10 +421,15 @@ func createVDRI(frameworkOpts *Aries) error {
 
 	opts = append(opts,
 		vdri.WithVDRI(p),
+		vdri.WithVDRI(key.New()),
 		vdri.WithDefaultServiceType(vdriapi.DIDCommServiceType),
 		vdri.WithDefaultServiceEndpoint(ctx.ServiceEndpoint()),
 	)
 
+	if frameworkOpts.resolutionCache != nil {
+		opts = append(opts, vdri.WithResolutionCache(frameworkOpts.resolutionCache))
+	}
+
 	frameworkOpts.vdriRegistry = vdri.New(ctx, opts...)
 
 	return nil
@@ -457,6 +514,7 @@ func loadServices(frameworkOpts *Aries) error {
 		context.WithServiceEndpoint(serviceEndpoint(frameworkOpts)),
 		context.WithRouterEndpoint(routingEndpoint(frameworkOpts)),
 		context.WithVDRIRegistry(frameworkOpts.vdriRegistry),
+		context.WithMessageServiceProvider(frameworkOpts.msgSvcProvider),
 	)
 
 	if err != nil {
@@ -483,6 +541,7 @@ func loadServices(frameworkOpts *Aries) error {
 func createPackersAndPackager(frameworkOpts *Aries) error {
 	ctx, err := context.New(
 		context.WithLegacyKMS(frameworkOpts.kms),
+		context.WithKMS(frameworkOpts.keyManager),
 		context.WithCrypto(frameworkOpts.crypto),
 	)
 	if err != nil {
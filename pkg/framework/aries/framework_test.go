@@ -37,7 +37,11 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/internal/mock/didcomm/msghandler"
 	mockdidexchange "github.com/hyperledger/aries-framework-go/pkg/internal/mock/didcomm/protocol/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/mock/didcomm/protocol/generic"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/internal/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/legacykms"
 	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
+	mockkeymanager "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms/legacykms"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
@@ -45,6 +49,8 @@ import (
 	locallock "github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/hkdf"
 	"github.com/hyperledger/aries-framework-go/pkg/storage/leveldb"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+	vdripkg "github.com/hyperledger/aries-framework-go/pkg/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/vdri/peer"
 )
 
@@ -144,9 +150,34 @@ func TestFramework(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("test vdri - with resolution cache", func(t *testing.T) {
+		path, cleanup := generateTempDir(t)
+		defer cleanup()
+		dbPath = path
+
+		cache := vdripkg.NewLRUCache()
+		aries, err := New(WithResolutionCache(cache), WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+		require.NotEmpty(t, aries)
+
+		keyDID := "did:key:z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+		resolvedDoc, err := aries.vdriRegistry.Resolve(keyDID)
+		require.NoError(t, err)
+
+		entry, ok := cache.Get(keyDID)
+		require.True(t, ok)
+		require.Equal(t, resolvedDoc, entry.Result.DIDDocument)
+
+		err = aries.Close()
+		require.NoError(t, err)
+	})
+
 	t.Run("test error create vdri", func(t *testing.T) {
 		_, err := New(
-			WithStoreProvider(&storage.MockStoreProvider{FailNamespace: peer.StoreNamespace}),
+			WithStoreProvider(&storage.MockStoreProvider{
+				Store:         &storage.MockStore{Store: make(map[string][]byte)},
+				FailNamespace: peer.StoreNamespace,
+			}),
 			WithInboundTransport(&mockInboundTransport{}))
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "create new vdri peer failed")
@@ -189,6 +220,13 @@ func TestFramework(t *testing.T) {
 		resolvedDoc, err := aries.vdriRegistry.Resolve(peerDID)
 		require.NoError(t, err)
 		require.Equal(t, originalDoc, resolvedDoc)
+
+		// did:key is resolved by default
+		keyDID := "did:key:z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+		resolvedDoc, err = aries.vdriRegistry.Resolve(keyDID)
+		require.NoError(t, err)
+		require.Equal(t, keyDID, resolvedDoc.ID)
+
 		err = aries.Close()
 		require.NoError(t, err)
 	})
@@ -389,7 +427,7 @@ func TestFramework(t *testing.T) {
 
 	t.Run("test error from legacy kms svc", func(t *testing.T) {
 		// with custom legacy kms
-		_, err := New(WithInboundTransport(&mockInboundTransport{}),
+		_, err := New(WithInboundTransport(&mockInboundTransport{}), WithStoreProvider(mem.NewProvider()),
 			WithLegacyKMS(func(ctx api.Provider) (api.CloseableKMS, error) {
 				return nil, fmt.Errorf("error from kms")
 			}))
@@ -397,6 +435,59 @@ func TestFramework(t *testing.T) {
 		require.Contains(t, err.Error(), "error from kms")
 	})
 
+	t.Run("test new with custom kms svc", func(t *testing.T) {
+		keyManager := &mockkeymanager.KeyManager{CreateKeyID: "keyID"}
+
+		aries, err := New(WithInboundTransport(&mockInboundTransport{}),
+			WithKMS(func(ctx kms.Provider) (kms.KeyManager, error) {
+				return keyManager, nil
+			}))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, keyManager, ctx.KMS())
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test error from kms svc", func(t *testing.T) {
+		_, err := New(WithInboundTransport(&mockInboundTransport{}), WithStoreProvider(mem.NewProvider()),
+			WithKMS(func(ctx kms.Provider) (kms.KeyManager, error) {
+				return nil, fmt.Errorf("error from kms")
+			}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create key manager failed: error from kms")
+	})
+
+	t.Run("test default kms svc imports the legacy kms keys", func(t *testing.T) {
+		storeProvider := mem.NewProvider()
+
+		legacyKMS, err := legacykms.New(&mockprovider.Provider{StorageProviderValue: storeProvider})
+		require.NoError(t, err)
+
+		_, sigKey, err := legacyKMS.CreateKeySet()
+		require.NoError(t, err)
+
+		aries, err := New(WithInboundTransport(&mockInboundTransport{}), WithStoreProvider(storeProvider))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+
+		kh, err := ctx.KMS().Get(sigKey)
+		require.NoError(t, err)
+		require.NotNil(t, kh)
+
+		// the key sets created at runtime are written through to the KMS
+		_, sigKey, err = ctx.LegacyKMS().CreateKeySet()
+		require.NoError(t, err)
+
+		kh, err = ctx.KMS().Get(sigKey)
+		require.NoError(t, err)
+		require.NotNil(t, kh)
+		require.NoError(t, aries.Close())
+	})
+
 	t.Run("test new with custom kms svc and secret lock svc", func(t *testing.T) {
 		// pre steps (preparation), create a protected master key and store it in a local file
 		masterKeyFilePath := "masterKey_aries.txt"
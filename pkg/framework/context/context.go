@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
 	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/legacykms"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
@@ -29,6 +30,7 @@ type Provider struct {
 	storeProvider          storage.Provider
 	transientStoreProvider storage.Provider
 	kms                    legacykms.KMS
+	keyManager             kms.KeyManager
 	secretLock             secretlock.Service
 	crypto                 crypto.Crypto
 	packager               commontransport.Packager
@@ -79,11 +81,26 @@ func (p *Provider) Service(id string) (interface{}, error) {
 	return nil, api.ErrSvcNotFound
 }
 
+// AllServices returns all the registered protocol services.
+func (p *Provider) AllServices() []dispatcher.ProtocolService {
+	return p.services
+}
+
+// MessageServiceProvider returns a provider of the message services.
+func (p *Provider) MessageServiceProvider() api.MessageServiceProvider {
+	return p.msgSvcProvider
+}
+
 // LegacyKMS returns a kms service.
 func (p *Provider) LegacyKMS() legacykms.KeyManager {
 	return p.kms
 }
 
+// KMS returns a Key Manager service.
+func (p *Provider) KMS() kms.KeyManager {
+	return p.keyManager
+}
+
 // SecretLock returns a secret lock service
 func (p *Provider) SecretLock() secretlock.Service {
 	return p.secretLock
@@ -255,6 +272,14 @@ func WithLegacyKMS(w legacykms.KMS) ProviderOption {
 	}
 }
 
+// WithKMS injects a Key Manager service into the context.
+func WithKMS(k kms.KeyManager) ProviderOption {
+	return func(opts *Provider) error {
+		opts.keyManager = k
+		return nil
+	}
+}
+
 // WithSecretLock injects a secret lock service into the context
 func WithSecretLock(s secretlock.Service) ProviderOption {
 	return func(opts *Provider) error {
@@ -27,6 +27,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/internal/mock/didcomm/protocol/generic"
 	mocklock "github.com/hyperledger/aries-framework-go/pkg/internal/mock/secretlock"
 	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
+	mockkeymanager "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms/legacykms"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
@@ -69,6 +70,8 @@ func TestNewProvider(t *testing.T) {
 
 		_, err = prov.Service("mockProtocolSvc1")
 		require.Error(t, err)
+
+		require.Len(t, prov.AllServices(), 1)
 	})
 
 	t.Run("test inbound message handlers/dispatchers", func(t *testing.T) {
@@ -185,6 +188,7 @@ func TestNewProvider(t *testing.T) {
 		mockMsgHandler := msghandler.NewMockMsgServiceProvider()
 		prov, err := New(WithMessageServiceProvider(mockMsgHandler), WithMessengerHandler(messenger))
 		require.NoError(t, err)
+		require.Equal(t, mockMsgHandler, prov.MessageServiceProvider())
 
 		err = mockMsgHandler.Register(&generic.MockMessageSvc{
 			HandleFunc: func(*service.DIDCommMsg) (string, error) {
@@ -260,6 +264,13 @@ func TestNewProvider(t *testing.T) {
 		require.Equal(t, mCrypto, prov.Crypto())
 	})
 
+	t.Run("test new with kms service", func(t *testing.T) {
+		mKMS := &mockkeymanager.KeyManager{}
+		prov, err := New(WithKMS(mKMS))
+		require.NoError(t, err)
+		require.Equal(t, mKMS, prov.KMS())
+	})
+
 	t.Run("test new with secret lock service", func(t *testing.T) {
 		mSecLck := &mocklock.MockSecretLock{}
 		prov, err := New(WithSecretLock(mSecLck))
@@ -0,0 +1,203 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hyperledger/aries-framework-go/pkg/client/outofband (interfaces: Provider,ProtocolService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	service "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	outofband "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
+	legacykms "github.com/hyperledger/aries-framework-go/pkg/kms/legacykms"
+	storage "github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// MockProvider is a mock of Provider interface.
+type MockProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockProviderMockRecorder
+}
+
+// MockProviderMockRecorder is the mock recorder for MockProvider.
+type MockProviderMockRecorder struct {
+	mock *MockProvider
+}
+
+// NewMockProvider creates a new mock instance.
+func NewMockProvider(ctrl *gomock.Controller) *MockProvider {
+	mock := &MockProvider{ctrl: ctrl}
+	mock.recorder = &MockProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProvider) EXPECT() *MockProviderMockRecorder {
+	return m.recorder
+}
+
+// LegacyKMS mocks base method.
+func (m *MockProvider) LegacyKMS() legacykms.KeyManager {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LegacyKMS")
+	ret0, _ := ret[0].(legacykms.KeyManager)
+	return ret0
+}
+
+// LegacyKMS indicates an expected call of LegacyKMS.
+func (mr *MockProviderMockRecorder) LegacyKMS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LegacyKMS", reflect.TypeOf((*MockProvider)(nil).LegacyKMS))
+}
+
+// Service mocks base method.
+func (m *MockProvider) Service(arg0 string) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Service", arg0)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Service indicates an expected call of Service.
+func (mr *MockProviderMockRecorder) Service(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Service", reflect.TypeOf((*MockProvider)(nil).Service), arg0)
+}
+
+// ServiceEndpoint mocks base method.
+func (m *MockProvider) ServiceEndpoint() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServiceEndpoint")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ServiceEndpoint indicates an expected call of ServiceEndpoint.
+func (mr *MockProviderMockRecorder) ServiceEndpoint() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceEndpoint", reflect.TypeOf((*MockProvider)(nil).ServiceEndpoint))
+}
+
+// StorageProvider mocks base method.
+func (m *MockProvider) StorageProvider() storage.Provider {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorageProvider")
+	ret0, _ := ret[0].(storage.Provider)
+	return ret0
+}
+
+// StorageProvider indicates an expected call of StorageProvider.
+func (mr *MockProviderMockRecorder) StorageProvider() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorageProvider", reflect.TypeOf((*MockProvider)(nil).StorageProvider))
+}
+
+// TransientStorageProvider mocks base method.
+func (m *MockProvider) TransientStorageProvider() storage.Provider {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransientStorageProvider")
+	ret0, _ := ret[0].(storage.Provider)
+	return ret0
+}
+
+// TransientStorageProvider indicates an expected call of TransientStorageProvider.
+func (mr *MockProviderMockRecorder) TransientStorageProvider() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransientStorageProvider", reflect.TypeOf((*MockProvider)(nil).TransientStorageProvider))
+}
+
+// MockProtocolService is a mock of ProtocolService interface.
+type MockProtocolService struct {
+	ctrl     *gomock.Controller
+	recorder *MockProtocolServiceMockRecorder
+}
+
+// MockProtocolServiceMockRecorder is the mock recorder for MockProtocolService.
+type MockProtocolServiceMockRecorder struct {
+	mock *MockProtocolService
+}
+
+// NewMockProtocolService creates a new mock instance.
+func NewMockProtocolService(ctrl *gomock.Controller) *MockProtocolService {
+	mock := &MockProtocolService{ctrl: ctrl}
+	mock.recorder = &MockProtocolServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProtocolService) EXPECT() *MockProtocolServiceMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockProtocolService) AcceptInvitation(arg0 *outofband.Invitation) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockProtocolServiceMockRecorder) AcceptInvitation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockProtocolService)(nil).AcceptInvitation), arg0)
+}
+
+// RegisterActionEvent mocks base method.
+func (m *MockProtocolService) RegisterActionEvent(arg0 chan<- service.DIDCommAction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterActionEvent", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterActionEvent indicates an expected call of RegisterActionEvent.
+func (mr *MockProtocolServiceMockRecorder) RegisterActionEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterActionEvent", reflect.TypeOf((*MockProtocolService)(nil).RegisterActionEvent), arg0)
+}
+
+// RegisterMsgEvent mocks base method.
+func (m *MockProtocolService) RegisterMsgEvent(arg0 chan<- service.StateMsg) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterMsgEvent", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterMsgEvent indicates an expected call of RegisterMsgEvent.
+func (mr *MockProtocolServiceMockRecorder) RegisterMsgEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterMsgEvent", reflect.TypeOf((*MockProtocolService)(nil).RegisterMsgEvent), arg0)
+}
+
+// UnregisterActionEvent mocks base method.
+func (m *MockProtocolService) UnregisterActionEvent(arg0 chan<- service.DIDCommAction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnregisterActionEvent", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnregisterActionEvent indicates an expected call of UnregisterActionEvent.
+func (mr *MockProtocolServiceMockRecorder) UnregisterActionEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnregisterActionEvent", reflect.TypeOf((*MockProtocolService)(nil).UnregisterActionEvent), arg0)
+}
+
+// UnregisterMsgEvent mocks base method.
+func (m *MockProtocolService) UnregisterMsgEvent(arg0 chan<- service.StateMsg) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnregisterMsgEvent", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnregisterMsgEvent indicates an expected call of UnregisterMsgEvent.
+func (mr *MockProtocolServiceMockRecorder) UnregisterMsgEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnregisterMsgEvent", reflect.TypeOf((*MockProtocolService)(nil).UnregisterMsgEvent), arg0)
+}
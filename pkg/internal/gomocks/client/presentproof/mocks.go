@@ -0,0 +1,218 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hyperledger/aries-framework-go/pkg/client/presentproof (interfaces: Provider,ProtocolService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	service "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	presentproof "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	storage "github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// MockProvider is a mock of Provider interface.
+type MockProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockProviderMockRecorder
+}
+
+// MockProviderMockRecorder is the mock recorder for MockProvider.
+type MockProviderMockRecorder struct {
+	mock *MockProvider
+}
+
+// NewMockProvider creates a new mock instance.
+func NewMockProvider(ctrl *gomock.Controller) *MockProvider {
+	mock := &MockProvider{ctrl: ctrl}
+	mock.recorder = &MockProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProvider) EXPECT() *MockProviderMockRecorder {
+	return m.recorder
+}
+
+// Service mocks base method.
+func (m *MockProvider) Service(arg0 string) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Service", arg0)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Service indicates an expected call of Service.
+func (mr *MockProviderMockRecorder) Service(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Service", reflect.TypeOf((*MockProvider)(nil).Service), arg0)
+}
+
+// StorageProvider mocks base method.
+func (m *MockProvider) StorageProvider() storage.Provider {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorageProvider")
+	ret0, _ := ret[0].(storage.Provider)
+	return ret0
+}
+
+// StorageProvider indicates an expected call of StorageProvider.
+func (mr *MockProviderMockRecorder) StorageProvider() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorageProvider", reflect.TypeOf((*MockProvider)(nil).StorageProvider))
+}
+
+// MockProtocolService is a mock of ProtocolService interface.
+type MockProtocolService struct {
+	ctrl     *gomock.Controller
+	recorder *MockProtocolServiceMockRecorder
+}
+
+// MockProtocolServiceMockRecorder is the mock recorder for MockProtocolService.
+type MockProtocolServiceMockRecorder struct {
+	mock *MockProtocolService
+}
+
+// NewMockProtocolService creates a new mock instance.
+func NewMockProtocolService(ctrl *gomock.Controller) *MockProtocolService {
+	mock := &MockProtocolService{ctrl: ctrl}
+	mock.recorder = &MockProtocolServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProtocolService) EXPECT() *MockProtocolServiceMockRecorder {
+	return m.recorder
+}
+
+// Actions mocks base method.
+func (m *MockProtocolService) Actions() ([]presentproof.Action, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Actions")
+	ret0, _ := ret[0].([]presentproof.Action)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Actions indicates an expected call of Actions.
+func (mr *MockProtocolServiceMockRecorder) Actions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Actions", reflect.TypeOf((*MockProtocolService)(nil).Actions))
+}
+
+// Continue mocks base method.
+func (m *MockProtocolService) Continue(arg0 string, arg1 presentproof.Opt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Continue", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Continue indicates an expected call of Continue.
+func (mr *MockProtocolServiceMockRecorder) Continue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Continue", reflect.TypeOf((*MockProtocolService)(nil).Continue), arg0, arg1)
+}
+
+// HandleInbound mocks base method.
+func (m *MockProtocolService) HandleInbound(arg0 service.DIDCommMsg, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleInbound", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleInbound indicates an expected call of HandleInbound.
+func (mr *MockProtocolServiceMockRecorder) HandleInbound(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleInbound", reflect.TypeOf((*MockProtocolService)(nil).HandleInbound), arg0, arg1, arg2)
+}
+
+// HandleOutbound mocks base method.
+func (m *MockProtocolService) HandleOutbound(arg0 service.DIDCommMsg, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleOutbound", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleOutbound indicates an expected call of HandleOutbound.
+func (mr *MockProtocolServiceMockRecorder) HandleOutbound(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleOutbound", reflect.TypeOf((*MockProtocolService)(nil).HandleOutbound), arg0, arg1, arg2)
+}
+
+// RegisterActionEvent mocks base method.
+func (m *MockProtocolService) RegisterActionEvent(arg0 chan<- service.DIDCommAction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterActionEvent", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterActionEvent indicates an expected call of RegisterActionEvent.
+func (mr *MockProtocolServiceMockRecorder) RegisterActionEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterActionEvent", reflect.TypeOf((*MockProtocolService)(nil).RegisterActionEvent), arg0)
+}
+
+// RegisterMsgEvent mocks base method.
+func (m *MockProtocolService) RegisterMsgEvent(arg0 chan<- service.StateMsg) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterMsgEvent", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterMsgEvent indicates an expected call of RegisterMsgEvent.
+func (mr *MockProtocolServiceMockRecorder) RegisterMsgEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterMsgEvent", reflect.TypeOf((*MockProtocolService)(nil).RegisterMsgEvent), arg0)
+}
+
+// Stop mocks base method.
+func (m *MockProtocolService) Stop(arg0 string, arg1 error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockProtocolServiceMockRecorder) Stop(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockProtocolService)(nil).Stop), arg0, arg1)
+}
+
+// UnregisterActionEvent mocks base method.
+func (m *MockProtocolService) UnregisterActionEvent(arg0 chan<- service.DIDCommAction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnregisterActionEvent", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnregisterActionEvent indicates an expected call of UnregisterActionEvent.
+func (mr *MockProtocolServiceMockRecorder) UnregisterActionEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnregisterActionEvent", reflect.TypeOf((*MockProtocolService)(nil).UnregisterActionEvent), arg0)
+}
+
+// UnregisterMsgEvent mocks base method.
+func (m *MockProtocolService) UnregisterMsgEvent(arg0 chan<- service.StateMsg) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnregisterMsgEvent", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnregisterMsgEvent indicates an expected call of UnregisterMsgEvent.
+func (mr *MockProtocolServiceMockRecorder) UnregisterMsgEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnregisterMsgEvent", reflect.TypeOf((*MockProtocolService)(nil).UnregisterMsgEvent), arg0)
+}
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband (interfaces: Provider)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	transport "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	vdri "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	storage "github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// MockProvider is a mock of Provider interface.
+type MockProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockProviderMockRecorder
+}
+
+// MockProviderMockRecorder is the mock recorder for MockProvider.
+type MockProviderMockRecorder struct {
+	mock *MockProvider
+}
+
+// NewMockProvider creates a new mock instance.
+func NewMockProvider(ctrl *gomock.Controller) *MockProvider {
+	mock := &MockProvider{ctrl: ctrl}
+	mock.recorder = &MockProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProvider) EXPECT() *MockProviderMockRecorder {
+	return m.recorder
+}
+
+// InboundMessageHandler mocks base method.
+func (m *MockProvider) InboundMessageHandler() transport.InboundMessageHandler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InboundMessageHandler")
+	ret0, _ := ret[0].(transport.InboundMessageHandler)
+	return ret0
+}
+
+// InboundMessageHandler indicates an expected call of InboundMessageHandler.
+func (mr *MockProviderMockRecorder) InboundMessageHandler() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InboundMessageHandler", reflect.TypeOf((*MockProvider)(nil).InboundMessageHandler))
+}
+
+// Service mocks base method.
+func (m *MockProvider) Service(arg0 string) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Service", arg0)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Service indicates an expected call of Service.
+func (mr *MockProviderMockRecorder) Service(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Service", reflect.TypeOf((*MockProvider)(nil).Service), arg0)
+}
+
+// StorageProvider mocks base method.
+func (m *MockProvider) StorageProvider() storage.Provider {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorageProvider")
+	ret0, _ := ret[0].(storage.Provider)
+	return ret0
+}
+
+// StorageProvider indicates an expected call of StorageProvider.
+func (mr *MockProviderMockRecorder) StorageProvider() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorageProvider", reflect.TypeOf((*MockProvider)(nil).StorageProvider))
+}
+
+// TransientStorageProvider mocks base method.
+func (m *MockProvider) TransientStorageProvider() storage.Provider {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransientStorageProvider")
+	ret0, _ := ret[0].(storage.Provider)
+	return ret0
+}
+
+// TransientStorageProvider indicates an expected call of TransientStorageProvider.
+func (mr *MockProviderMockRecorder) TransientStorageProvider() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransientStorageProvider", reflect.TypeOf((*MockProvider)(nil).TransientStorageProvider))
+}
+
+// VDRIRegistry mocks base method.
+func (m *MockProvider) VDRIRegistry() vdri.Registry {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VDRIRegistry")
+	ret0, _ := ret[0].(vdri.Registry)
+	return ret0
+}
+
+// VDRIRegistry indicates an expected call of VDRIRegistry.
+func (mr *MockProviderMockRecorder) VDRIRegistry() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VDRIRegistry", reflect.TypeOf((*MockProvider)(nil).VDRIRegistry))
+}
@@ -0,0 +1,192 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package jcs implements the JSON Canonicalization Scheme (https://tools.ietf.org/html/rfc8785).
+package jcs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"unicode/utf16"
+)
+
+// Marshal returns the canonical JSON encoding of v, marshaled with json.Marshal
+func Marshal(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return Transform(data)
+}
+
+// Transform canonicalizes the JSON data
+func Transform(data []byte) ([]byte, error) {
+	var v interface{}
+
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return nil, fmt.Errorf("jcs: unmarshal JSON: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+
+	err = write(buf, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func write(buf *bytes.Buffer, v interface{}) error {
+	switch value := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(value))
+	case float64:
+		return writeNumber(buf, value)
+	case string:
+		writeString(buf, value)
+	case []interface{}:
+		buf.WriteByte('[')
+
+		for i, e := range value {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := write(buf, e); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		return writeObject(buf, value)
+	}
+
+	return nil
+}
+
+// writeObject writes the object properties sorted by the UTF-16 code units of their names
+func writeObject(buf *bytes.Buffer, object map[string]interface{}) error {
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return lessUTF16(names[i], names[j])
+	})
+
+	buf.WriteByte('{')
+
+	for i, name := range names {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		writeString(buf, name)
+		buf.WriteByte(':')
+
+		if err := write(buf, object[name]); err != nil {
+			return err
+		}
+	}
+
+	buf.WriteByte('}')
+
+	return nil
+}
+
+func lessUTF16(a, b string) bool {
+	ua, ub := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+
+	return len(ua) < len(ub)
+}
+
+// writeNumber writes the number serialized as in ECMAScript (https://tools.ietf.org/html/rfc8785#section-3.2.2.3)
+func writeNumber(buf *bytes.Buffer, f float64) error {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return fmt.Errorf("jcs: unsupported number %v", f)
+	}
+
+	if f == 0 {
+		buf.WriteByte('0')
+
+		return nil
+	}
+
+	format := byte('f')
+	if abs := math.Abs(f); abs < 1e-6 || abs >= 1e21 {
+		format = 'e'
+	}
+
+	b := strconv.AppendFloat(nil, f, format, -1, 64)
+
+	if format == 'e' {
+		// clean up e-09 to e-9
+		n := len(b)
+		if n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+
+	buf.Write(b)
+
+	return nil
+}
+
+// writeString writes the string with the minimal JSON escaping (https://tools.ietf.org/html/rfc8785#section-3.2.2.2)
+func writeString(buf *bytes.Buffer, s string) {
+	const hex = "0123456789abcdef"
+
+	buf.WriteByte('"')
+
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hex[r>>4])
+				buf.WriteByte(hex[r&0xf])
+
+				continue
+			}
+
+			buf.WriteRune(r)
+		}
+	}
+
+	buf.WriteByte('"')
+}
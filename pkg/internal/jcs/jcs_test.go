@@ -0,0 +1,76 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jcs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransform(t *testing.T) {
+	t.Run("test RFC 8785 sample", func(t *testing.T) {
+		// https://tools.ietf.org/html/rfc8785#section-3.2.2
+		input := `{
+  "numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+  "string": "\u20ac$\u000F\u000aA'B\u0022\u005c\\\u0022\/",
+  "literals": [null, true, false]
+}`
+		expected := `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],` +
+			`"string":"€$\u000f\nA'B\"\\\\\"/"}`
+
+		canonical, err := Transform([]byte(input))
+		require.NoError(t, err)
+		require.Equal(t, expected, string(canonical))
+	})
+
+	t.Run("test property sorting by UTF-16 code units", func(t *testing.T) {
+		// https://tools.ietf.org/html/rfc8785#section-3.2.3
+		input := `{"€":"Euro Sign","\r":"Carriage Return","דּ":"Hebrew Letter Dalet With Dagesh",` +
+			`"1":"One","😀":"Emoji: Grinning Face","\u0080":"Control","ö":"Latin Small Letter O With Diaeresis"}`
+		expected := `{"\r":"Carriage Return","1":"One","` + "\u0080" + `":"Control","ö":"Latin Small Letter O With Diaeresis",` +
+			`"€":"Euro Sign","😀":"Emoji: Grinning Face","` + "\ufb33" + `":"Hebrew Letter Dalet With Dagesh"}`
+
+		canonical, err := Transform([]byte(input))
+		require.NoError(t, err)
+		require.Equal(t, expected, string(canonical))
+	})
+
+	t.Run("test control characters and nested values", func(t *testing.T) {
+		canonical, err := Transform([]byte(`{"b":[{"z":1,"a":"\b\f\t\r"}],"a":{}}`))
+		require.NoError(t, err)
+		require.Equal(t, `{"a":{},"b":[{"a":"\b\f\t\r","z":1}]}`, string(canonical))
+	})
+
+	t.Run("test invalid JSON", func(t *testing.T) {
+		_, err := Transform([]byte(`{`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "jcs: unmarshal JSON")
+	})
+}
+
+func TestMarshal(t *testing.T) {
+	canonical, err := Marshal(struct {
+		B string  `json:"b"`
+		A float64 `json:"a"`
+	}{B: "<&>", A: 0})
+	require.NoError(t, err)
+	require.Equal(t, `{"a":0,"b":"<&>"}`, string(canonical))
+
+	_, err = Marshal(func() {})
+	require.Error(t, err)
+
+	_, err = Marshal(math.Inf(1))
+	require.Error(t, err)
+}
+
+func TestWriteNumber(t *testing.T) {
+	for _, f := range []float64{math.Inf(1), math.NaN()} {
+		require.Error(t, write(nil, f))
+	}
+}
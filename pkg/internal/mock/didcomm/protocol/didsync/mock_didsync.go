@@ -0,0 +1,59 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didsync
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didsync"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+// MockDIDSyncSvc mock DID sync service
+type MockDIDSyncSvc struct {
+	UpdateDIDFunc     func(connectionID string, doc *did.Doc) error
+	DeactivateDIDFunc func(connectionID string) error
+}
+
+// HandleInbound msg
+func (m *MockDIDSyncSvc) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	return uuid.New().String(), nil
+}
+
+// HandleOutbound msg
+func (m *MockDIDSyncSvc) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	return "", nil
+}
+
+// Accept msg checks the msg type
+func (m *MockDIDSyncSvc) Accept(msgType string) bool {
+	return true
+}
+
+// Name return service name
+func (m *MockDIDSyncSvc) Name() string {
+	return didsync.DIDSync
+}
+
+// UpdateDID updates the DID of the connection.
+func (m *MockDIDSyncSvc) UpdateDID(connectionID string, doc *did.Doc) error {
+	if m.UpdateDIDFunc != nil {
+		return m.UpdateDIDFunc(connectionID, doc)
+	}
+
+	return nil
+}
+
+// DeactivateDID deactivates the DID of the connection.
+func (m *MockDIDSyncSvc) DeactivateDID(connectionID string) error {
+	if m.DeactivateDIDFunc != nil {
+		return m.DeactivateDIDFunc(connectionID)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+)
+
+// MockDiscoverFeaturesSvc mock discover features service
+type MockDiscoverFeaturesSvc struct {
+	QueryFunc func(connectionID, query string) (*discoverfeatures.Disclose, error)
+}
+
+// HandleInbound msg
+func (m *MockDiscoverFeaturesSvc) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	return uuid.New().String(), nil
+}
+
+// HandleOutbound msg
+func (m *MockDiscoverFeaturesSvc) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	return "", nil
+}
+
+// Accept msg checks the msg type
+func (m *MockDiscoverFeaturesSvc) Accept(msgType string) bool {
+	return true
+}
+
+// Name return service name
+func (m *MockDiscoverFeaturesSvc) Name() string {
+	return discoverfeatures.DiscoverFeatures
+}
+
+// Query sends the query message.
+func (m *MockDiscoverFeaturesSvc) Query(connectionID, query string) (*discoverfeatures.Disclose, error) {
+	if m.QueryFunc != nil {
+		return m.QueryFunc(connectionID, query)
+	}
+
+	return &discoverfeatures.Disclose{ID: uuid.New().String(), Type: discoverfeatures.DiscloseMsgType}, nil
+}
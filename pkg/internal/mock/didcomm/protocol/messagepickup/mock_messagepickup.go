@@ -0,0 +1,65 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package messagepickup
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/messagepickup"
+)
+
+// MockMessagePickupSvc mock message pickup service
+type MockMessagePickupSvc struct {
+	StatusValue    *messagepickup.Status
+	StatusErr      error
+	BatchSizeValue int
+	BatchErr       error
+	NoopErr        error
+	AddMessageErr  error
+}
+
+// HandleInbound msg
+func (m *MockMessagePickupSvc) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	return uuid.New().String(), nil
+}
+
+// HandleOutbound msg
+func (m *MockMessagePickupSvc) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	return "", nil
+}
+
+// Accept msg checks the msg type
+func (m *MockMessagePickupSvc) Accept(msgType string) bool {
+	return true
+}
+
+// Name return service name
+func (m *MockMessagePickupSvc) Name() string {
+	return messagepickup.MessagePickup
+}
+
+// AddMessage queues the message for the recipient.
+func (m *MockMessagePickupSvc) AddMessage(message *model.Envelope, theirDID string) error {
+	return m.AddMessageErr
+}
+
+// StatusRequest requests the status of the queued messages.
+func (m *MockMessagePickupSvc) StatusRequest(connectionID string) (*messagepickup.Status, error) {
+	return m.StatusValue, m.StatusErr
+}
+
+// BatchPickup requests the queued messages.
+func (m *MockMessagePickupSvc) BatchPickup(connectionID string, batchSize int) (int, error) {
+	return m.BatchSizeValue, m.BatchErr
+}
+
+// Noop sends the noop message.
+func (m *MockMessagePickupSvc) Noop(connectionID string) error {
+	return m.NoopErr
+}
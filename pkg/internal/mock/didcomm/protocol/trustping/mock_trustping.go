@@ -0,0 +1,50 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+)
+
+// MockTrustPingSvc mock trust ping service
+type MockTrustPingSvc struct {
+	PingFunc func(connectionID string, timeout time.Duration) (*trustping.PingResponse, error)
+}
+
+// HandleInbound msg
+func (m *MockTrustPingSvc) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	return uuid.New().String(), nil
+}
+
+// HandleOutbound msg
+func (m *MockTrustPingSvc) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	return "", nil
+}
+
+// Accept msg checks the msg type
+func (m *MockTrustPingSvc) Accept(msgType string) bool {
+	return true
+}
+
+// Name return service name
+func (m *MockTrustPingSvc) Name() string {
+	return trustping.TrustPing
+}
+
+// Ping sends the ping message.
+func (m *MockTrustPingSvc) Ping(connectionID string, timeout time.Duration) (*trustping.PingResponse, error) {
+	if m.PingFunc != nil {
+		return m.PingFunc(connectionID, timeout)
+	}
+
+	return &trustping.PingResponse{ID: uuid.New().String(), Type: trustping.PingResponseMsgType}, nil
+}
@@ -6,10 +6,13 @@ SPDX-License-Identifier: Apache-2.0
 package provider
 
 import (
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/legacykms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 )
 
@@ -19,6 +22,10 @@ type Provider struct {
 	ServiceErr                    error
 	ServiceMap                    map[string]interface{}
 	KMSValue                      legacykms.KeyManager
+	SignerValue                   legacykms.Signer
+	KeyManagerValue               kms.KeyManager
+	CryptoValue                   crypto.Crypto
+	SecretLockValue               secretlock.Service
 	ServiceEndpointValue          string
 	StorageProviderValue          storage.Provider
 	TransientStorageProviderValue storage.Provider
@@ -46,6 +53,26 @@ func (p *Provider) LegacyKMS() legacykms.KeyManager {
 	return p.KMSValue
 }
 
+// Signer returns a legacy KMS signer
+func (p *Provider) Signer() legacykms.Signer {
+	return p.SignerValue
+}
+
+// KMS returns a Key Manager instance
+func (p *Provider) KMS() kms.KeyManager {
+	return p.KeyManagerValue
+}
+
+// Crypto returns a Crypto instance
+func (p *Provider) Crypto() crypto.Crypto {
+	return p.CryptoValue
+}
+
+// SecretLock returns a SecretLock instance
+func (p *Provider) SecretLock() secretlock.Service {
+	return p.SecretLockValue
+}
+
 // ServiceEndpoint returns the service endpoint
 func (p *Provider) ServiceEndpoint() string {
 	return p.ServiceEndpointValue
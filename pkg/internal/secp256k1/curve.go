@@ -0,0 +1,24 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package secp256k1 provides the secp256k1 elliptic curve of SEC 2 (https://www.secg.org/sec2-v2.pdf),
+// the curve of the ES256K JWS algorithm (RFC 8812), with the encodings of its keys and of the R || S ECDSA
+// signatures.
+//
+// The curve, the point encodings and the signatures are those of the decred secp256k1 package, whose field and
+// scalar arithmetic is constant time. The signature nonces are deterministic (RFC 6979).
+package secp256k1
+
+import (
+	"crypto/elliptic"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// S256 returns the secp256k1 curve
+func S256() elliptic.Curve {
+	return secp256k1.S256()
+}
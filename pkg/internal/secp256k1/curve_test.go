@@ -0,0 +1,52 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurve(t *testing.T) {
+	c := S256()
+	params := c.Params()
+
+	require.Equal(t, "secp256k1", params.Name)
+	require.True(t, c.IsOnCurve(params.Gx, params.Gy))
+	require.False(t, c.IsOnCurve(params.Gx, new(big.Int).Add(params.Gy, big.NewInt(1))))
+
+	// 3G of secp256k1
+	x3, ok := new(big.Int).SetString("F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9", 16)
+	require.True(t, ok)
+
+	y3, ok := new(big.Int).SetString("388F7B0F632DE8140FE337E62A37F3566500A99934C2231B6CB9FD7584B8E672", 16)
+	require.True(t, ok)
+
+	x, y := c.ScalarBaseMult([]byte{3})
+	require.Equal(t, x3, x)
+	require.Equal(t, y3, y)
+}
+
+func TestECDSA(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(S256(), rand.Reader)
+	require.NoError(t, err)
+	require.True(t, S256().IsOnCurve(privKey.X, privKey.Y))
+
+	digest := sha256.Sum256([]byte("test message"))
+
+	r, s, err := ecdsa.Sign(rand.Reader, privKey, digest[:])
+	require.NoError(t, err)
+	require.True(t, ecdsa.Verify(&privKey.PublicKey, digest[:], r, s))
+
+	other := sha256.Sum256([]byte("other message"))
+	require.False(t, ecdsa.Verify(&privKey.PublicKey, other[:], r, s))
+}
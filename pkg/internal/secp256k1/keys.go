@@ -0,0 +1,112 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"crypto/ecdsa"
+	"errors"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	decredecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+const (
+	// KeySize is the size in bytes of the private keys and of the coordinates of the public keys
+	KeySize = 32
+	// SignatureSize is the size in bytes of the R || S signatures
+	SignatureSize = 2 * KeySize
+
+	compressedEven    = 0x02
+	compressedOdd     = 0x03
+	uncompressedPoint = 0x04
+)
+
+// GenerateKey generates a new secp256k1 private key
+func GenerateKey() (*ecdsa.PrivateKey, error) {
+	key, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	defer key.Zero()
+
+	return key.ToECDSA(), nil
+}
+
+// ParsePublicKey parses the SEC 1 encoding of a public key, either the uncompressed point (0x04 || X || Y)
+// or the compressed point (0x02 or 0x03 || X)
+func ParsePublicKey(b []byte) (*ecdsa.PublicKey, error) {
+	switch {
+	case len(b) == 1+2*KeySize && b[0] == uncompressedPoint:
+	case len(b) == 1+KeySize && (b[0] == compressedEven || b[0] == compressedOdd):
+	default:
+		return nil, errors.New("secp256k1: invalid public key encoding")
+	}
+
+	key, err := secp256k1.ParsePubKey(b)
+	if err != nil {
+		return nil, errors.New("secp256k1: invalid public key")
+	}
+
+	return key.ToECDSA(), nil
+}
+
+// MarshalPublicKey returns the uncompressed point (0x04 || X || Y) of the public key
+func MarshalPublicKey(key *ecdsa.PublicKey) []byte {
+	return publicKey(key).SerializeUncompressed()
+}
+
+// MarshalCompressedPublicKey returns the compressed point (0x02 or 0x03 || X) of the public key
+func MarshalCompressedPublicKey(key *ecdsa.PublicKey) []byte {
+	return publicKey(key).SerializeCompressed()
+}
+
+// Sign signs the digest with the private key, the signature is the concatenation of R and S
+func Sign(key *ecdsa.PrivateKey, digest []byte) ([]byte, error) {
+	if key.Curve != S256() {
+		return nil, errors.New("secp256k1: not a secp256k1 private key")
+	}
+
+	privKey := secp256k1.PrivKeyFromBytes(padLeft(key.D.Bytes(), KeySize))
+	defer privKey.Zero()
+
+	// the compact signature is the recovery code followed by R and S
+	return decredecdsa.SignCompact(privKey, digest, false)[1:], nil
+}
+
+// Verify reports whether the R || S signature of the digest is valid for the public key
+func Verify(key *ecdsa.PublicKey, digest, signature []byte) bool {
+	if len(signature) != SignatureSize || key.Curve != S256() || !S256().IsOnCurve(key.X, key.Y) {
+		return false
+	}
+
+	var r, s secp256k1.ModNScalar
+
+	if r.SetByteSlice(signature[:KeySize]) || s.SetByteSlice(signature[KeySize:]) {
+		return false
+	}
+
+	return decredecdsa.NewSignature(&r, &s).Verify(digest, publicKey(key))
+}
+
+// publicKey converts the public key to the decred public key, the point is expected to be on the curve
+func publicKey(key *ecdsa.PublicKey) *secp256k1.PublicKey {
+	var x, y secp256k1.FieldVal
+
+	x.SetByteSlice(padLeft(key.X.Bytes(), KeySize))
+	y.SetByteSlice(padLeft(key.Y.Bytes(), KeySize))
+
+	return secp256k1.NewPublicKey(&x, &y)
+}
+
+// padLeft pads b with leading zeros to size bytes
+func padLeft(b []byte, size int) []byte {
+	padded := make([]byte, size)
+	copy(padded[size-len(b):], b)
+
+	return padded
+}
@@ -0,0 +1,106 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePublicKey(t *testing.T) {
+	privKey, err := GenerateKey()
+	require.NoError(t, err)
+	require.Equal(t, S256(), privKey.Curve)
+	require.True(t, S256().IsOnCurve(privKey.X, privKey.Y))
+
+	t.Run("test uncompressed point", func(t *testing.T) {
+		pubKey, err := ParsePublicKey(MarshalPublicKey(&privKey.PublicKey))
+		require.NoError(t, err)
+		require.Equal(t, privKey.X, pubKey.X)
+		require.Equal(t, privKey.Y, pubKey.Y)
+		require.Equal(t, S256(), pubKey.Curve)
+	})
+
+	t.Run("test compressed point", func(t *testing.T) {
+		compressed := MarshalCompressedPublicKey(&privKey.PublicKey)
+		require.Len(t, compressed, 1+KeySize)
+		require.Equal(t, byte(compressedEven+privKey.Y.Bit(0)), compressed[0])
+
+		pubKey, err := ParsePublicKey(compressed)
+		require.NoError(t, err)
+		require.Equal(t, privKey.X, pubKey.X)
+		require.Equal(t, privKey.Y, pubKey.Y)
+
+		g, err := hex.DecodeString("0279BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798")
+		require.NoError(t, err)
+
+		pubKey, err = ParsePublicKey(g)
+		require.NoError(t, err)
+		require.Equal(t, S256().Params().Gy, pubKey.Y)
+	})
+
+	t.Run("test invalid public keys", func(t *testing.T) {
+		_, err := ParsePublicKey([]byte{uncompressedPoint, 1, 2})
+		require.EqualError(t, err, "secp256k1: invalid public key encoding")
+
+		invalid := MarshalPublicKey(&privKey.PublicKey)
+		invalid[len(invalid)-1]++
+
+		_, err = ParsePublicKey(invalid)
+		require.EqualError(t, err, "secp256k1: invalid public key")
+
+		// the hybrid encoding is not supported
+		hybrid := MarshalPublicKey(&privKey.PublicKey)
+		hybrid[0] = byte(0x06 + privKey.Y.Bit(0))
+
+		_, err = ParsePublicKey(hybrid)
+		require.EqualError(t, err, "secp256k1: invalid public key encoding")
+
+		// x = 5 has no point on the curve, 5³ + 7 is not a square
+		_, err = ParsePublicKey(append([]byte{compressedEven}, padLeft([]byte{5}, KeySize)...))
+		require.EqualError(t, err, "secp256k1: invalid public key")
+	})
+}
+
+func TestSignVerify(t *testing.T) {
+	privKey, err := GenerateKey()
+	require.NoError(t, err)
+
+	digest := sha256.Sum256([]byte("test message"))
+
+	signature, err := Sign(privKey, digest[:])
+	require.NoError(t, err)
+	require.Len(t, signature, SignatureSize)
+	require.True(t, Verify(&privKey.PublicKey, digest[:], signature))
+
+	// the signature is valid ECDSA signature of the curve
+	r := new(big.Int).SetBytes(signature[:KeySize])
+	s := new(big.Int).SetBytes(signature[KeySize:])
+	require.True(t, ecdsa.Verify(&privKey.PublicKey, digest[:], r, s))
+
+	other := sha256.Sum256([]byte("other message"))
+	require.False(t, Verify(&privKey.PublicKey, other[:], signature))
+	require.False(t, Verify(&privKey.PublicKey, digest[:], signature[1:]))
+
+	// R >= N
+	overflow := append(padLeft(S256().Params().N.Bytes(), KeySize), signature[KeySize:]...)
+	require.False(t, Verify(&privKey.PublicKey, digest[:], overflow))
+
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	_, err = Sign(p256Key, digest[:])
+	require.EqualError(t, err, "secp256k1: not a secp256k1 private key")
+	require.False(t, Verify(&p256Key.PublicKey, digest[:], signature))
+}
@@ -23,6 +23,8 @@ import (
 
 const (
 	keyStoreNamespace = "keystore"
+	// importedKeyType is the key type of the encryption private keys imported with the KeyImporter
+	importedKeyType = "ECDHX25519"
 )
 
 // provider contains dependencies for the base LegacyKMS and is typically created by using aries.Context()
@@ -30,19 +32,43 @@ type provider interface {
 	StorageProvider() storage.Provider
 }
 
+// KeyImporter imports a raw private key of key type kt with the given keyID, it is implemented by localkms.
+type KeyImporter interface {
+	ImportPrivateKey(keyID, kt string, privKey []byte) (string, interface{}, error)
+}
+
 // BaseKMS Base Key Management Service implementation
 type BaseKMS struct {
-	keystore storage.Store
+	keystore    storage.Store
+	keyImporter KeyImporter
+}
+
+// Option configures the LegacyKMS
+type Option func(k *BaseKMS)
+
+// WithKeyImporter option writes the encryption key pairs created by CreateKeySet through to importer as
+// ECDHX25519 keys identified by their base58 encoded signing (verification) public key, so the key handles of
+// the new DIDs are found in the KMS (eg. by the JWE packers).
+func WithKeyImporter(importer KeyImporter) Option {
+	return func(k *BaseKMS) {
+		k.keyImporter = importer
+	}
 }
 
 // New return new instance of LegacyKMS implementation
-func New(ctx provider) (*BaseKMS, error) {
+func New(ctx provider, opts ...Option) (*BaseKMS, error) {
 	ks, err := ctx.StorageProvider().OpenStore(keyStoreNamespace)
 	if err != nil {
 		return nil, fmt.Errorf("failed to OpenStore for '%s', cause: %w", keyStoreNamespace, err)
 	}
 
-	return &BaseKMS{keystore: ks}, nil
+	k := &BaseKMS{keystore: ks}
+
+	for _, opt := range opts {
+		opt(k)
+	}
+
+	return k, nil
 }
 
 // CreateKeySet creates a new public/private encryption and signature keypairs combo.
@@ -81,6 +107,12 @@ func (w *BaseKMS) CreateKeySet() (string, string, error) {
 		return "", "", er
 	}
 
+	if w.keyImporter != nil {
+		if _, _, er := w.keyImporter.ImportPrivateKey(sigBase58Pub, importedKeyType, encKp.Priv); er != nil {
+			return "", "", fmt.Errorf("failed to import key set: %w", er)
+		}
+	}
+
 	return encBase58Pub, sigBase58Pub, nil
 }
 
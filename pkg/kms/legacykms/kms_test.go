@@ -54,6 +54,27 @@ func TestBaseKMS_CreateKey(t *testing.T) {
 		require.Contains(t, err.Error(), "put error")
 	})
 
+	t.Run("test key set written through to the key importer", func(t *testing.T) {
+		importer := &mockKeyImporter{}
+
+		k, err := New(newMockKMSProvider(&mockstorage.MockStoreProvider{Store: &mockstorage.MockStore{
+			Store: make(map[string][]byte),
+		}}), WithKeyImporter(importer))
+		require.NoError(t, err)
+		_, verKey, err := k.CreateKeySet()
+		require.NoError(t, err)
+
+		kpb, err := k.getKeyPairSet(verKey)
+		require.NoError(t, err)
+		require.Equal(t, verKey, importer.keyID)
+		require.Equal(t, "ECDHX25519", importer.kt)
+		require.Equal(t, kpb.EncKeyPair.Priv, importer.privKey)
+
+		importer.err = fmt.Errorf("import error")
+		_, _, err = k.CreateKeySet()
+		require.EqualError(t, err, "failed to import key set: import error")
+	})
+
 	t.Run("test error from createEncKeyPair", func(t *testing.T) {
 		k, err := New(newMockKMSProvider(&mockstorage.MockStoreProvider{Store: &mockstorage.MockStore{
 			Store: make(map[string][]byte),
@@ -391,3 +412,21 @@ type mockProvider struct {
 func (m *mockProvider) StorageProvider() storage.Provider {
 	return m.storage
 }
+
+// mockKeyImporter records the last imported private key
+type mockKeyImporter struct {
+	keyID   string
+	kt      string
+	privKey []byte
+	err     error
+}
+
+func (m *mockKeyImporter) ImportPrivateKey(keyID, kt string, privKey []byte) (string, interface{}, error) {
+	if m.err != nil {
+		return "", nil, m.err
+	}
+
+	m.keyID, m.kt, m.privKey = keyID, kt, privKey
+
+	return keyID, nil, nil
+}
@@ -0,0 +1,163 @@
+/*
+ Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/tink/go/aead"
+	"github.com/google/tink/go/keyset"
+	"github.com/google/tink/go/subtle/random"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms/internal/keywrapper"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/hkdf"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	// bundleKeyURI is the key URI of the bundle key wrapping the exported keysets
+	bundleKeyURI = keywrapper.LocalKeyURIPrefix + "kms/backup/bundle/key"
+	// bundleSaltSize is the size of the random salt used to expand the passphrase
+	bundleSaltSize = 16
+)
+
+// bundle is the backup bundle of exported keysets. The keysets are encrypted with a random bundle key, itself
+// encrypted by a master lock expanded from the passphrase with HKDF (see hkdf.NewMasterLock).
+type bundle struct {
+	Salt    []byte         `json:"salt"`
+	Key     string         `json:"key"`
+	Keysets []bundleKeyset `json:"keysets"`
+}
+
+// bundleKeyset is an exported keyset with its ID in the KMS
+type bundleKeyset struct {
+	ID     string          `json:"id"`
+	Keyset json.RawMessage `json:"keyset"`
+}
+
+// ExportKeys exports the keysets with ids keyIDs into a portable backup bundle encrypted with passphrase.
+// The bundle can be imported in another agent with ImportKeys using the same passphrase.
+func (l *LocalKMS) ExportKeys(passphrase string, keyIDs ...string) ([]byte, error) {
+	if len(keyIDs) == 0 {
+		return nil, errors.New("export keys: missing key IDs")
+	}
+
+	salt := random.GetRandomBytes(bundleSaltSize)
+
+	masterLock, err := hkdf.NewMasterLock(passphrase, sha256.New, salt)
+	if err != nil {
+		return nil, fmt.Errorf("export keys: %w", err)
+	}
+
+	// the bundle key must match the size of the hash used by the master lock
+	encKey, err := masterLock.Encrypt("", &secretlock.EncryptRequest{
+		Plaintext: string(random.GetRandomBytes(uint32(sha256.Size))),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("export keys: encrypt bundle key: %w", err)
+	}
+
+	bundleAEAD, err := newBundleAEAD(encKey.Ciphertext, masterLock)
+	if err != nil {
+		return nil, fmt.Errorf("export keys: %w", err)
+	}
+
+	b := &bundle{Salt: salt, Key: encKey.Ciphertext}
+
+	for _, keyID := range keyIDs {
+		kh, e := l.getKeySet(keyID)
+		if e != nil {
+			return nil, fmt.Errorf("export keys: get keyset '%s': %w", keyID, e)
+		}
+
+		buf := new(bytes.Buffer)
+
+		e = kh.Write(keyset.NewJSONWriter(buf), bundleAEAD)
+		if e != nil {
+			return nil, fmt.Errorf("export keys: write keyset '%s': %w", keyID, e)
+		}
+
+		b.Keysets = append(b.Keysets, bundleKeyset{ID: keyID, Keyset: buf.Bytes()})
+	}
+
+	return json.Marshal(b)
+}
+
+// ImportKeys imports the keysets of the backup bundle exported by ExportKeys and encrypted with passphrase.
+// The keysets are stored with their original IDs, the import fails without storing any keyset if one of
+// these IDs is already used in this KMS. It returns the IDs of the imported keysets.
+func (l *LocalKMS) ImportKeys(passphrase string, bundleBytes []byte) ([]string, error) {
+	b := &bundle{}
+
+	err := json.Unmarshal(bundleBytes, b)
+	if err != nil {
+		return nil, fmt.Errorf("import keys: unmarshal bundle: %w", err)
+	}
+
+	masterLock, err := hkdf.NewMasterLock(passphrase, sha256.New, b.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("import keys: %w", err)
+	}
+
+	bundleAEAD, err := newBundleAEAD(b.Key, masterLock)
+	if err != nil {
+		return nil, fmt.Errorf("import keys: %w", err)
+	}
+
+	handles := make([]*keyset.Handle, len(b.Keysets))
+
+	for i, ks := range b.Keysets {
+		handles[i], err = keyset.Read(keyset.NewJSONReader(bytes.NewReader(ks.Keyset)), bundleAEAD)
+		if err != nil {
+			return nil, fmt.Errorf("import keys: read keyset '%s': %w", ks.ID, err)
+		}
+
+		_, err = l.store.Get(ks.ID)
+		if err == nil {
+			return nil, fmt.Errorf("import keys: keyset ID '%s' already exists", ks.ID)
+		}
+
+		if !errors.Is(err, storage.ErrDataNotFound) {
+			return nil, fmt.Errorf("import keys: get keyset '%s': %w", ks.ID, err)
+		}
+	}
+
+	var imported []string
+
+	for i, ks := range b.Keysets {
+		if _, err = l.storeKeySetWithID(handles[i], ks.ID); err != nil {
+			return nil, fmt.Errorf("import keys: store keyset '%s': %w", ks.ID, err)
+		}
+
+		imported = append(imported, ks.ID)
+	}
+
+	return imported, nil
+}
+
+// newBundleAEAD creates the AEAD wrapping the keysets of a bundle with the bundle key encKey protected by
+// masterLock, the same way LocalKMS wraps its keysets with its own secret lock
+func newBundleAEAD(encKey string, masterLock secretlock.Service) (*aead.KMSEnvelopeAEAD, error) {
+	bundleLock, err := local.NewService(strings.NewReader(encKey), masterLock)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt bundle key: %w", err)
+	}
+
+	kw, err := keywrapper.New(bundleLock, bundleKeyURI)
+	if err != nil {
+		return nil, err
+	}
+
+	return aead.NewKMSEnvelopeAEAD(*aead.AES256GCMKeyTemplate(), kw), nil
+}
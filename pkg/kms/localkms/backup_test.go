@@ -0,0 +1,177 @@
+/*
+ Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/tink/go/keyset"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+const testPassphrase = "backup passphrase"
+
+func newTestKMS(t *testing.T) *LocalKMS {
+	t.Helper()
+
+	k, err := New(testMasterKeyURI, &mockProvider{
+		storage:    mockstorage.NewMockStoreProvider(),
+		secretLock: &noop.NoLock{},
+	})
+	require.NoError(t, err)
+
+	return k
+}
+
+func TestLocalKMS_ExportImportKeys(t *testing.T) {
+	source := newTestKMS(t)
+
+	sigKID, sigKH, err := source.Create("ED25519")
+	require.NoError(t, err)
+
+	aeadKID, _, err := source.Create("AES256GCM")
+	require.NoError(t, err)
+
+	// a key not selected for the export
+	otherKID, _, err := source.Create("ECDHX25519")
+	require.NoError(t, err)
+
+	b, err := source.ExportKeys(testPassphrase, sigKID, aeadKID)
+	require.NoError(t, err)
+
+	// the keys are not exported in clear
+	require.NotContains(t, string(b), "privateKey")
+
+	target := newTestKMS(t)
+
+	imported, err := target.ImportKeys(testPassphrase, b)
+	require.NoError(t, err)
+	require.Equal(t, []string{sigKID, aeadKID}, imported)
+
+	_, err = target.Get(otherKID)
+	require.Error(t, err)
+
+	// the imported signing key verifies signatures of the original key
+	kh, err := target.Get(sigKID)
+	require.NoError(t, err)
+
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	msg := []byte("test message")
+
+	sig, err := c.Sign(msg, sigKH)
+	require.NoError(t, err)
+
+	pubKH, err := kh.(*keyset.Handle).Public()
+	require.NoError(t, err)
+	require.NoError(t, c.Verify(sig, msg, pubKH))
+
+	t.Run("import fails when the keyset ID already exists", func(t *testing.T) {
+		_, err = target.ImportKeys(testPassphrase, b)
+		require.EqualError(t, err, "import keys: keyset ID '"+sigKID+"' already exists")
+	})
+
+	t.Run("import fails with a wrong passphrase", func(t *testing.T) {
+		_, err = newTestKMS(t).ImportKeys("wrong passphrase", b)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "import keys: decrypt bundle key")
+	})
+
+	t.Run("import fails with a tampered keyset", func(t *testing.T) {
+		bndl := &bundle{}
+		require.NoError(t, json.Unmarshal(b, bndl))
+
+		bndl.Keysets[1].Keyset = bndl.Keysets[0].Keyset
+		bndl.Keysets[0].Keyset = json.RawMessage(`{}`)
+
+		tampered, e := json.Marshal(bndl)
+		require.NoError(t, e)
+
+		k := newTestKMS(t)
+
+		_, e = k.ImportKeys(testPassphrase, tampered)
+		require.Error(t, e)
+		require.Contains(t, e.Error(), "import keys: read keyset '"+sigKID+"'")
+
+		// no keyset is stored when the import fails
+		_, e = k.Get(sigKID)
+		require.Error(t, e)
+	})
+}
+
+func TestLocalKMS_ExportKeys_Failure(t *testing.T) {
+	k := newTestKMS(t)
+
+	kid, _, err := k.Create("ED25519")
+	require.NoError(t, err)
+
+	t.Run("missing key IDs", func(t *testing.T) {
+		_, err = k.ExportKeys(testPassphrase)
+		require.EqualError(t, err, "export keys: missing key IDs")
+	})
+
+	t.Run("empty passphrase", func(t *testing.T) {
+		_, err = k.ExportKeys("", kid)
+		require.EqualError(t, err, "export keys: passphrase is empty")
+	})
+
+	t.Run("keyset not found", func(t *testing.T) {
+		_, err = k.ExportKeys(testPassphrase, kid, "unknown")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "export keys: get keyset 'unknown'")
+	})
+}
+
+func TestLocalKMS_ImportKeys_Failure(t *testing.T) {
+	k := newTestKMS(t)
+
+	kid, _, err := k.Create("ED25519")
+	require.NoError(t, err)
+
+	b, err := k.ExportKeys(testPassphrase, kid)
+	require.NoError(t, err)
+
+	t.Run("invalid bundle", func(t *testing.T) {
+		_, err = newTestKMS(t).ImportKeys(testPassphrase, []byte("{"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "import keys: unmarshal bundle")
+	})
+
+	t.Run("empty passphrase", func(t *testing.T) {
+		_, err = newTestKMS(t).ImportKeys("", b)
+		require.EqualError(t, err, "import keys: passphrase is empty")
+	})
+
+	t.Run("store get error", func(t *testing.T) {
+		target := newTestKMS(t)
+		target.store = &mockstorage.MockStore{
+			Store:  map[string][]byte{},
+			ErrGet: errors.New("get error"),
+		}
+
+		_, err = target.ImportKeys(testPassphrase, b)
+		require.EqualError(t, err, "import keys: get keyset '"+kid+"': get error")
+	})
+
+	t.Run("store put error", func(t *testing.T) {
+		target := newTestKMS(t)
+		target.store = &mockstorage.MockStore{
+			Store:  map[string][]byte{},
+			ErrPut: errors.New("put error"),
+		}
+
+		_, err = target.ImportKeys(testPassphrase, b)
+		require.EqualError(t, err, "import keys: store keyset '"+kid+"': put error")
+	})
+}
@@ -0,0 +1,76 @@
+/*
+ Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	// legacyKeyStoreNamespace is the store namespace of the key pairs created by legacykms
+	legacyKeyStoreNamespace = "keystore"
+	// legacyKeyType is the key type of the imported legacykms encryption key pairs
+	legacyKeyType = "ECDHX25519"
+)
+
+// ImportLegacyKeys imports the key pairs created by legacykms and found in the storage provider p
+// into ECDHX25519 keysets. The keyset ID of an imported key is its base58 encoded signing (verification)
+// public key, as found in DID documents, so the existing DIDs can be used with key handles from this KMS.
+// Keys already imported are skipped, so it is safe to call it multiple times. It returns the IDs of the
+// keysets imported by this call.
+func (l *LocalKMS) ImportLegacyKeys(p storage.Provider) ([]string, error) {
+	legacyStore, err := p.OpenStore(legacyKeyStoreNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("import legacy keys: open store: %w", err)
+	}
+
+	// base58 keys of legacykms are stored between "" and "~"
+	itr := legacyStore.Iterator("", "~")
+	defer itr.Release()
+
+	var imported []string
+
+	for itr.Next() {
+		kp := &cryptoutil.MessagingKeys{}
+
+		if err = json.Unmarshal(itr.Value(), kp); err != nil {
+			return nil, fmt.Errorf("import legacy keys: unmarshal key pairs: %w", err)
+		}
+
+		if kp.SigKeyPair == nil || kp.EncKeyPair == nil || !cryptoutil.IsMessagingKeysValid(kp) {
+			return nil, fmt.Errorf("import legacy keys: invalid key pairs '%s'", itr.Key())
+		}
+
+		// the key pairs are stored twice by legacykms (with the encryption and the signing public keys)
+		keyID := base58.Encode(kp.SigKeyPair.Pub)
+		if string(itr.Key()) != keyID {
+			continue
+		}
+
+		if _, err = l.store.Get(keyID); err == nil {
+			continue
+		}
+
+		if _, _, err = l.ImportPrivateKey(keyID, legacyKeyType, kp.EncKeyPair.Priv); err != nil {
+			return nil, fmt.Errorf("import legacy keys: %w", err)
+		}
+
+		imported = append(imported, keyID)
+	}
+
+	if err = itr.Error(); err != nil {
+		return nil, fmt.Errorf("import legacy keys: iterate store: %w", err)
+	}
+
+	return imported, nil
+}
@@ -0,0 +1,114 @@
+/*
+ Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/google/tink/go/keyset"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/ecdh"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/legacykms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+func TestLocalKMS_ImportLegacyKeys(t *testing.T) {
+	storeProvider := mem.NewProvider()
+
+	legacyKMS, err := legacykms.New(&legacyProvider{storage: storeProvider})
+	require.NoError(t, err)
+
+	kmsStorage, err := New(testMasterKeyURI, &mockProvider{
+		storage:    mockstorage.NewMockStoreProvider(),
+		secretLock: &noop.NoLock{},
+	})
+	require.NoError(t, err)
+
+	encKey1, sigKey1, err := legacyKMS.CreateKeySet()
+	require.NoError(t, err)
+
+	encKey2, sigKey2, err := legacyKMS.CreateKeySet()
+	require.NoError(t, err)
+
+	imported, err := kmsStorage.ImportLegacyKeys(storeProvider)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{sigKey1, sigKey2}, imported)
+
+	for sigKey, encKey := range map[string]string{sigKey1: encKey1, sigKey2: encKey2} {
+		kh, e := kmsStorage.Get(sigKey)
+		require.NoError(t, e)
+
+		pub, e := ecdh.PublicKey(kh.(*keyset.Handle))
+		require.NoError(t, e)
+		require.Equal(t, ecdh.X25519, pub.Curve)
+		require.Equal(t, encKey, base58.Encode(pub.X))
+	}
+
+	// the imported keys are skipped
+	encKey3, sigKey3, err := legacyKMS.CreateKeySet()
+	require.NoError(t, err)
+
+	imported, err = kmsStorage.ImportLegacyKeys(storeProvider)
+	require.NoError(t, err)
+	require.Equal(t, []string{sigKey3}, imported)
+
+	kh, err := kmsStorage.Get(sigKey3)
+	require.NoError(t, err)
+
+	pub, err := ecdh.PublicKey(kh.(*keyset.Handle))
+	require.NoError(t, err)
+	require.Equal(t, encKey3, base58.Encode(pub.X))
+}
+
+func TestLocalKMS_ImportLegacyKeys_Failure(t *testing.T) {
+	kmsStorage, err := New(testMasterKeyURI, &mockProvider{
+		storage:    mockstorage.NewMockStoreProvider(),
+		secretLock: &noop.NoLock{},
+	})
+	require.NoError(t, err)
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err = kmsStorage.ImportLegacyKeys(&mockstorage.MockStoreProvider{
+			ErrOpenStoreHandle: errors.New("open store error"),
+		})
+		require.EqualError(t, err, "import legacy keys: open store: open store error")
+	})
+
+	t.Run("iterator error", func(t *testing.T) {
+		_, err = kmsStorage.ImportLegacyKeys(mockstorage.NewCustomMockStoreProvider(&mockstorage.MockStore{
+			Store:  map[string][]byte{},
+			ErrItr: errors.New("iterator error"),
+		}))
+		require.EqualError(t, err, "import legacy keys: iterate store: iterator error")
+	})
+
+	t.Run("invalid key pairs", func(t *testing.T) {
+		for _, v := range []string{"invalid", "{}"} {
+			_, err = kmsStorage.ImportLegacyKeys(newLegacyStoreProvider(map[string][]byte{"key": []byte(v)}))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "import legacy keys")
+		}
+	})
+}
+
+func newLegacyStoreProvider(store map[string][]byte) storage.Provider {
+	return mockstorage.NewCustomMockStoreProvider(&mockstorage.MockStore{Store: store})
+}
+
+type legacyProvider struct {
+	storage storage.Provider
+}
+
+func (p *legacyProvider) StorageProvider() storage.Provider {
+	return p.storage
+}
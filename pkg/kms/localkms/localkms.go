@@ -15,6 +15,9 @@ import (
 	"github.com/google/tink/go/signature"
 	tinkpb "github.com/google/tink/proto/tink_go_proto"
 
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/ecdh"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms/internal/keywrapper"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
@@ -127,6 +130,44 @@ func (l *LocalKMS) Rotate(kt, keyID string) (string, interface{}, error) {
 	return newID, updatedKH, nil
 }
 
+// ImportPrivateKey imports the raw private key privKey of key type kt and stores it as a new keyset
+// with the given keyID (a new random ID is generated if keyID is empty). Only ECDH key types
+// (ECDHX25519, ECDHP256, ECDHP384 and ECDHP521) are supported. It returns the keyset ID and key handle.
+func (l *LocalKMS) ImportPrivateKey(keyID, kt string, privKey []byte) (string, interface{}, error) {
+	curve, err := ecdhCurve(kt)
+	if err != nil {
+		return "", nil, fmt.Errorf("import private key: %w", err)
+	}
+
+	kh, err := ecdh.NewKeysetHandle(curve, privKey)
+	if err != nil {
+		return "", nil, fmt.Errorf("import private key: %w", err)
+	}
+
+	kID, err := l.storeKeySetWithID(kh, keyID)
+	if err != nil {
+		return "", nil, fmt.Errorf("import private key: %w", err)
+	}
+
+	return kID, kh, nil
+}
+
+// ecdhCurve returns the curve of the ECDH key type kt
+func ecdhCurve(kt string) (string, error) {
+	switch kt {
+	case "ECDHX25519":
+		return ecdh.X25519, nil
+	case "ECDHP256":
+		return ecdh.P256, nil
+	case "ECDHP384":
+		return ecdh.P384, nil
+	case "ECDHP521":
+		return ecdh.P521, nil
+	default:
+		return "", fmt.Errorf("key type '%s' not supported", kt)
+	}
+}
+
 // nolint:gocyclo
 func getKeyTemplate(keyType string) (*tinkpb.KeyTemplate, error) {
 	switch keyType {
@@ -147,15 +188,33 @@ func getKeyTemplate(keyType string) (*tinkpb.KeyTemplate, error) {
 		return signature.ECDSAP384KeyTemplate(), nil
 	case "ECDSAP521":
 		return signature.ECDSAP521KeyTemplate(), nil
+	case "ECDSASecp256k1":
+		return secp256k1.ECDSASecp256k1KeyTemplate(), nil
 	case "ED25519":
 		return signature.ED25519KeyTemplate(), nil
+	case "BLS12381G2":
+		return bbs.BLS12381G2KeyTemplate(), nil
+	case "ECDHX25519":
+		return ecdh.X25519KWKeyTemplate(), nil
+	case "ECDHP256":
+		return ecdh.NISTP256KWKeyTemplate(), nil
+	case "ECDHP384":
+		return ecdh.NISTP384KWKeyTemplate(), nil
+	case "ECDHP521":
+		return ecdh.NISTP521KWKeyTemplate(), nil
 	default:
 		return nil, fmt.Errorf("key type unrecognized")
 	}
 }
 
 func (l *LocalKMS) storeKeySet(kh *keyset.Handle) (string, error) {
+	return l.storeKeySetWithID(kh, "")
+}
+
+// storeKeySetWithID stores kh with keyID, a random keyset ID is generated if keyID is empty
+func (l *LocalKMS) storeKeySetWithID(kh *keyset.Handle, keyID string) (string, error) {
 	w := newWriter(l.store, l.masterKeyURI)
+	w.KeysetID = keyID
 
 	buf := new(bytes.Buffer)
 	jsonKeysetWriter := keyset.NewJSONWriter(buf)
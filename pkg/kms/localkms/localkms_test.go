@@ -18,12 +18,16 @@ import (
 	"github.com/google/tink/go/subtle/random"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/ecdh"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms/internal/keywrapper"
 	mocksecretlock "github.com/hyperledger/aries-framework-go/pkg/mock/secretlock"
 	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/hkdf"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 )
 
@@ -200,7 +204,13 @@ func TestLocalKMS_Success(t *testing.T) {
 		"ECDSAP256",
 		"ECDSAP384",
 		"ECDSAP521",
+		"ECDSASecp256k1",
 		"ED25519",
+		"BLS12381G2",
+		"ECDHX25519",
+		"ECDHP256",
+		"ECDHP384",
+		"ECDHP521",
 	}
 
 	for _, v := range keyTemplates {
@@ -250,6 +260,47 @@ func TestLocalKMS_Success(t *testing.T) {
 	}
 }
 
+func TestLocalKMS_WrapUnwrapKey(t *testing.T) {
+	kmsStorage, err := New(testMasterKeyURI, &mockProvider{
+		storage:    mockstorage.NewMockStoreProvider(),
+		secretLock: createMasterKeyAndSecretLock(t),
+	})
+	require.NoError(t, err)
+
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	cek := random.GetRandomBytes(32)
+
+	for _, v := range []string{"ECDHX25519", "ECDHP256", "ECDHP384", "ECDHP521"} {
+		senderKID, _, e := kmsStorage.Create(v)
+		require.NoError(t, e)
+
+		recKID, recKH, e := kmsStorage.Create(v)
+		require.NoError(t, e)
+
+		recPubKey, e := ecdh.PublicKey(recKH.(*keyset.Handle))
+		require.NoError(t, e)
+
+		// key handles loaded from the store are used without exposing the private keys
+		senderKH, e := kmsStorage.Get(senderKID)
+		require.NoError(t, e)
+
+		wk, e := c.WrapKey(cek, nil, nil, recPubKey, crypto.WithSender(senderKH))
+		require.NoError(t, e)
+
+		senderPubKey, e := ecdh.PublicKey(senderKH.(*keyset.Handle))
+		require.NoError(t, e)
+
+		loadedRecKH, e := kmsStorage.Get(recKID)
+		require.NoError(t, e)
+
+		unwrapped, e := c.UnwrapKey(wk, loadedRecKH, crypto.WithSender(senderPubKey))
+		require.NoError(t, e)
+		require.Equal(t, cek, unwrapped)
+	}
+}
+
 func createMasterKeyAndSecretLock(t *testing.T) secretlock.Service {
 	t.Helper()
 
@@ -317,3 +368,54 @@ func (m *mockProvider) StorageProvider() storage.Provider {
 func (m *mockProvider) SecretLock() secretlock.Service {
 	return m.secretLock
 }
+
+func TestLocalKMS_ImportPrivateKey(t *testing.T) {
+	kmsStorage, err := New(testMasterKeyURI, &mockProvider{
+		storage:    mockstorage.NewMockStoreProvider(),
+		secretLock: &noop.NoLock{},
+	})
+	require.NoError(t, err)
+
+	t.Run("import without keyID", func(t *testing.T) {
+		privKey := make([]byte, 32)
+		privKey[0] = 1
+
+		keyID, kh, err := kmsStorage.ImportPrivateKey("", "ECDHX25519", privKey)
+		require.NoError(t, err)
+		require.NotEmpty(t, keyID)
+
+		pub, err := ecdh.PublicKey(kh.(*keyset.Handle))
+		require.NoError(t, err)
+		require.Equal(t, ecdh.X25519, pub.Curve)
+
+		storedKH, err := kmsStorage.Get(keyID)
+		require.NoError(t, err)
+
+		storedPub, err := ecdh.PublicKey(storedKH.(*keyset.Handle))
+		require.NoError(t, err)
+		require.Equal(t, pub, storedPub)
+	})
+
+	t.Run("import with keyID", func(t *testing.T) {
+		privKey := make([]byte, 32)
+		privKey[0] = 2
+
+		keyID, _, err := kmsStorage.ImportPrivateKey("myKeyID", "ECDHX25519", privKey)
+		require.NoError(t, err)
+		require.Equal(t, "myKeyID", keyID)
+
+		_, _, err = kmsStorage.ImportPrivateKey("myKeyID", "ECDHX25519", privKey)
+		require.EqualError(t, err, "import private key: keyset ID 'myKeyID' already exists")
+	})
+
+	t.Run("unsupported key type", func(t *testing.T) {
+		_, _, err = kmsStorage.ImportPrivateKey("", "ED25519", []byte("key"))
+		require.EqualError(t, err, "import private key: key type 'ED25519' not supported")
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, _, err = kmsStorage.ImportPrivateKey("", "ECDHX25519", []byte("key"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "import private key")
+	})
+}
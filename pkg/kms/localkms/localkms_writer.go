@@ -33,7 +33,8 @@ func newWriter(kmsStore storage.Store, masterKeyURI string) *storeWriter {
 type storeWriter struct {
 	storage      storage.Store
 	masterKeyURI string
-	// KeysetID is set when Write() is called
+	// KeysetID is set when Write() is called. If it is set prior to calling Write(), the keyset is stored
+	// with this ID instead of a randomly generated one
 	KeysetID string
 }
 
@@ -43,32 +44,52 @@ func (l *storeWriter) Write(p []byte) (int, error) {
 		return 0, fmt.Errorf("master key is not set")
 	}
 
+	ksID, err := l.keysetID()
+	if err != nil {
+		return 0, err
+	}
+
+	err = l.storage.Put(ksID, p)
+	if err != nil {
+		return 0, err
+	}
+
+	l.KeysetID = ksID
+
+	return len(p), nil
+}
+
+// keysetID returns the requested KeysetID if it's not used yet, or a new random ID otherwise
+func (l *storeWriter) keysetID() (string, error) {
+	if l.KeysetID != "" {
+		_, err := l.storage.Get(l.KeysetID)
+		if err == nil {
+			return "", fmt.Errorf("keyset ID '%s' already exists", l.KeysetID)
+		}
+
+		if err != storage.ErrDataNotFound {
+			return "", err
+		}
+
+		return l.KeysetID, nil
+	}
+
 	const keySetIDLength = 32
 
 	baseID := l.masterKeyURI
-	ksID := ""
 
 	for {
 		// generate random ID prefixed with masterKeyURI
-		ksID = baseID + base64.URLEncoding.EncodeToString(random.GetRandomBytes(keySetIDLength))
+		ksID := baseID + base64.URLEncoding.EncodeToString(random.GetRandomBytes(keySetIDLength))
 
 		// ensure ksID is not already used
 		_, e := l.storage.Get(ksID)
 		if e != nil {
 			if e == storage.ErrDataNotFound {
-				break
+				return ksID, nil
 			}
 
-			return 0, e
+			return "", e
 		}
 	}
-
-	err := l.storage.Put(ksID, p)
-	if err != nil {
-		return 0, err
-	}
-
-	l.KeysetID = ksID
-
-	return len(p), nil
 }
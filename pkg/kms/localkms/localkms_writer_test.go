@@ -77,4 +77,39 @@ func TestLocalKMSWriter(t *testing.T) {
 		require.EqualError(t, err, getError.Error())
 		require.Equal(t, 0, n)
 	})
+
+	t.Run("success case - store a key with a requested keyset ID", func(t *testing.T) {
+		storeMap := map[string][]byte{}
+		mockStore := &mockstorage.MockStore{Store: storeMap}
+
+		l := newWriter(mockStore, masterKeyURI)
+		l.KeysetID = "requestedID"
+		someKey := []byte("someKeyData")
+		n, err := l.Write(someKey)
+		require.NoError(t, err)
+		require.Equal(t, len(someKey), n)
+		require.Equal(t, "requestedID", l.KeysetID)
+		require.Equal(t, someKey, storeMap["requestedID"])
+
+		// the requested keyset ID is already used
+		l = newWriter(mockStore, masterKeyURI)
+		l.KeysetID = "requestedID"
+		n, err = l.Write(someKey)
+		require.EqualError(t, err, "keyset ID 'requestedID' already exists")
+		require.Equal(t, 0, n)
+	})
+
+	t.Run("error case - store a key with a requested keyset ID using a bad storeReader", func(t *testing.T) {
+		getError := fmt.Errorf("failed to get data")
+		mockStore := &mockstorage.MockStore{
+			Store:  map[string][]byte{},
+			ErrGet: getError,
+		}
+
+		l := newWriter(mockStore, masterKeyURI)
+		l.KeysetID = "requestedID"
+		n, err := l.Write([]byte("someKeyData"))
+		require.EqualError(t, err, getError.Error())
+		require.Equal(t, 0, n)
+	})
 }
@@ -6,6 +6,10 @@ SPDX-License-Identifier: Apache-2.0
 
 package crypto
 
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+)
+
 // Crypto mock
 type Crypto struct {
 	EncryptValue      []byte
@@ -16,6 +20,16 @@ type Crypto struct {
 	SignValue         []byte
 	SignErr           error
 	VerifyErr         error
+	SignMultiValue    []byte
+	SignMultiErr      error
+	VerifyMultiErr    error
+	DeriveProofValue  []byte
+	DeriveProofErr    error
+	VerifyProofErr    error
+	WrapValue         *crypto.RecipientWrappedKey
+	WrapError         error
+	UnwrapValue       []byte
+	UnwrapError       error
 }
 
 // Encrypt mocked value
@@ -37,3 +51,36 @@ func (c *Crypto) Sign(msg []byte, kh interface{}) ([]byte, error) {
 func (c *Crypto) Verify(signature, msg []byte, kh interface{}) error {
 	return c.VerifyErr
 }
+
+// SignMulti mocked value
+func (c *Crypto) SignMulti(messages [][]byte, kh interface{}) ([]byte, error) {
+	return c.SignMultiValue, c.SignMultiErr
+}
+
+// VerifyMulti mocked value
+func (c *Crypto) VerifyMulti(messages [][]byte, signature []byte, kh interface{}) error {
+	return c.VerifyMultiErr
+}
+
+// DeriveProof mocked value
+func (c *Crypto) DeriveProof(messages [][]byte, signature, nonce []byte, revealedIndexes []int,
+	kh interface{}) ([]byte, error) {
+	return c.DeriveProofValue, c.DeriveProofErr
+}
+
+// VerifyProof mocked value
+func (c *Crypto) VerifyProof(revealedMessages [][]byte, proof, nonce []byte, kh interface{}) error {
+	return c.VerifyProofErr
+}
+
+// WrapKey mocked value
+func (c *Crypto) WrapKey(cek, apu, apv []byte, recPubKey *crypto.PublicKey,
+	opts ...crypto.WrapKeyOpts) (*crypto.RecipientWrappedKey, error) {
+	return c.WrapValue, c.WrapError
+}
+
+// UnwrapKey mocked value
+func (c *Crypto) UnwrapKey(recWK *crypto.RecipientWrappedKey, kh interface{},
+	opts ...crypto.WrapKeyOpts) ([]byte, error) {
+	return c.UnwrapValue, c.UnwrapError
+}
@@ -18,13 +18,30 @@ import (
 // MockVDRIRegistry mock implementation of vdri
 // to be used only for unit tests
 type MockVDRIRegistry struct {
-	CreateErr    error
-	CreateValue  *did.Doc
-	MemStore     map[string]*did.Doc
-	PutErr       error
-	ResolveErr   error
-	ResolveValue *did.Doc
-	ResolveFunc  func(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error)
+	CreateErr      error
+	CreateValue    *did.Doc
+	MemStore       map[string]*did.Doc
+	PutErr         error
+	ResolveErr     error
+	ResolveValue   *did.Doc
+	ResolveFunc    func(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error)
+	UpdateErr      error
+	UpdateFunc     func(doc *did.Doc, opts ...vdriapi.UpdateOpts) error
+	DeactivateFunc func(didID string, opts ...vdriapi.UpdateOpts) error
+}
+
+// ResolveResult resolves the DID resolution result of the did document
+func (m *MockVDRIRegistry) ResolveResult(didID string, opts ...vdriapi.ResolveOpts) (*vdriapi.DIDResolutionResult,
+	error) {
+	doc, err := m.Resolve(didID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vdriapi.DIDResolutionResult{
+		DIDDocument:        doc,
+		ResolutionMetadata: vdriapi.ResolutionMetadata{ContentType: vdriapi.DIDDocumentContentType},
+	}, nil
 }
 
 // Store stores the key and the record
@@ -54,6 +71,24 @@ func (m *MockVDRIRegistry) Create(method string, opts ...vdriapi.DocOpts) (*did.
 	return doc, nil
 }
 
+// Update mock implementation of update DID
+func (m *MockVDRIRegistry) Update(doc *did.Doc, opts ...vdriapi.UpdateOpts) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(doc, opts...)
+	}
+
+	return m.UpdateErr
+}
+
+// Deactivate mock implementation of deactivate DID
+func (m *MockVDRIRegistry) Deactivate(didID string, opts ...vdriapi.UpdateOpts) error {
+	if m.DeactivateFunc != nil {
+		return m.DeactivateFunc(didID, opts...)
+	}
+
+	return m.UpdateErr
+}
+
 // Resolve did document
 func (m *MockVDRIRegistry) Resolve(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error) {
 	if m.ResolveFunc != nil {
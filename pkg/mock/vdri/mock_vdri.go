@@ -14,11 +14,13 @@ import (
 // MockVDRI mock implementation of vdri
 // to be used only for unit tests
 type MockVDRI struct {
-	AcceptValue bool
-	StoreErr    error
-	ReadFunc    func(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error)
-	BuildFunc   func(pubKey *vdriapi.PubKey, opts ...vdriapi.DocOpts) (*did.Doc, error)
-	CloseErr    error
+	AcceptValue    bool
+	StoreErr       error
+	ReadFunc       func(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error)
+	BuildFunc      func(pubKey *vdriapi.PubKey, opts ...vdriapi.DocOpts) (*did.Doc, error)
+	UpdateFunc     func(doc *did.Doc, opts ...vdriapi.UpdateOpts) error
+	DeactivateFunc func(didID string, opts ...vdriapi.UpdateOpts) error
+	CloseErr       error
 }
 
 // Read did
@@ -44,6 +46,24 @@ func (m *MockVDRI) Build(pubKey *vdriapi.PubKey, opts ...vdriapi.DocOpts) (*did.
 	return nil, nil
 }
 
+// Update did
+func (m *MockVDRI) Update(doc *did.Doc, opts ...vdriapi.UpdateOpts) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(doc, opts...)
+	}
+
+	return nil
+}
+
+// Deactivate did
+func (m *MockVDRI) Deactivate(didID string, opts ...vdriapi.UpdateOpts) error {
+	if m.DeactivateFunc != nil {
+		return m.DeactivateFunc(didID, opts...)
+	}
+
+	return nil
+}
+
 // Accept did
 func (m *MockVDRI) Accept(method string) bool {
 	return m.AcceptValue
@@ -0,0 +1,27 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package noop provides a noop secret lock service. It does not encrypt the keys, it is used by default
+// when no secret lock service is set. This is not a secure option and must only be used for testing
+// and development purposes, a secret lock service (eg. local secret lock) must be used in production.
+package noop
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+)
+
+// NoLock is a secret lock service that returns the plaintext as is (no encryption)
+type NoLock struct{}
+
+// Encrypt returns the plaintext of req as the ciphertext
+func (s *NoLock) Encrypt(keyURI string, req *secretlock.EncryptRequest) (*secretlock.EncryptResponse, error) {
+	return &secretlock.EncryptResponse{Ciphertext: req.Plaintext}, nil
+}
+
+// Decrypt returns the ciphertext of req as the plaintext
+func (s *NoLock) Decrypt(keyURI string, req *secretlock.DecryptRequest) (*secretlock.DecryptResponse, error) {
+	return &secretlock.DecryptResponse{Plaintext: req.Ciphertext}, nil
+}
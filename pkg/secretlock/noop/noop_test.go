@@ -0,0 +1,27 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package noop
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+)
+
+func TestNoLock(t *testing.T) {
+	var s secretlock.Service = &NoLock{}
+
+	enc, err := s.Encrypt("", &secretlock.EncryptRequest{Plaintext: "plaintext"})
+	require.NoError(t, err)
+	require.Equal(t, "plaintext", enc.Ciphertext)
+
+	dec, err := s.Decrypt("", &secretlock.DecryptRequest{Ciphertext: enc.Ciphertext})
+	require.NoError(t, err)
+	require.Equal(t, "plaintext", dec.Plaintext)
+}
@@ -0,0 +1,227 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdri
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+const (
+	defaultCacheTTL         = 5 * time.Minute
+	defaultCacheNotFoundTTL = time.Minute
+	defaultCacheMaxSize     = 1000
+)
+
+// CacheEntry is a cached DID resolution, either the DID resolution result or the DID not found.
+type CacheEntry struct {
+	Result   *vdriapi.DIDResolutionResult
+	NotFound bool
+}
+
+// ResolutionCache caches the DID resolutions of the registry (see WithResolutionCache).
+// The cache decides of the expiry of the entries. The registry caches copies of the DID resolution results
+// and returns copies of the cached ones, so the cached results are never shared with the callers.
+type ResolutionCache interface {
+	// Get returns the cached resolution of did, false if there is none
+	Get(did string) (*CacheEntry, bool)
+	// Put caches the resolution of did
+	Put(did string, entry *CacheEntry)
+	// Delete removes the cached resolution of did
+	Delete(did string)
+}
+
+// CacheOption is a LRUCache option
+type CacheOption func(opts *LRUCache)
+
+// WithCacheTTL option is for the time to live of the resolved DID documents, unless the DID method has its own
+// (see WithMethodCacheTTL). The documents are not cached with a zero TTL.
+func WithCacheTTL(ttl time.Duration) CacheOption {
+	return func(opts *LRUCache) {
+		opts.ttl = ttl
+	}
+}
+
+// WithMethodCacheTTL option is for the time to live of the resolved DID documents of the DID method.
+// The documents of the method are not cached with a zero TTL.
+func WithMethodCacheTTL(method string, ttl time.Duration) CacheOption {
+	return func(opts *LRUCache) {
+		opts.methodTTL[method] = ttl
+	}
+}
+
+// WithNotFoundCacheTTL option is for the time to live of the DIDs not found (negative caching).
+// The DIDs not found are not cached with a zero TTL.
+func WithNotFoundCacheTTL(ttl time.Duration) CacheOption {
+	return func(opts *LRUCache) {
+		opts.notFoundTTL = ttl
+	}
+}
+
+// WithCacheMaxSize option is for the maximum number of cached resolutions, the least recently used ones are
+// evicted above.
+func WithCacheMaxSize(size int) CacheOption {
+	return func(opts *LRUCache) {
+		opts.maxSize = size
+	}
+}
+
+// LRUCache is an in-memory ResolutionCache with time to live per DID method and a size limit.
+type LRUCache struct {
+	ttl         time.Duration
+	methodTTL   map[string]time.Duration
+	notFoundTTL time.Duration
+	maxSize     int
+	entries     map[string]*list.Element
+	lru         *list.List
+	lock        sync.Mutex
+	now         func() time.Time
+}
+
+type lruEntry struct {
+	did     string
+	entry   *CacheEntry
+	expires time.Time
+}
+
+// NewLRUCache returns new instance of LRUCache. By default the documents are cached for 5 minutes,
+// the DIDs not found for 1 minute and up to 1000 resolutions are cached.
+func NewLRUCache(opts ...CacheOption) *LRUCache {
+	c := &LRUCache{
+		ttl:         defaultCacheTTL,
+		methodTTL:   make(map[string]time.Duration),
+		notFoundTTL: defaultCacheNotFoundTTL,
+		maxSize:     defaultCacheMaxSize,
+		entries:     make(map[string]*list.Element),
+		lru:         list.New(),
+		now:         time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Get returns the cached resolution of did, false if there is none or it expired
+func (c *LRUCache) Get(did string) (*CacheEntry, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	elem, ok := c.entries[did]
+	if !ok {
+		return nil, false
+	}
+
+	e := elem.Value.(*lruEntry)
+
+	if !c.now().Before(e.expires) {
+		c.remove(elem)
+
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+
+	return e.entry, true
+}
+
+// Put caches the resolution of did for the time to live of its DID method, or of the DIDs not found
+func (c *LRUCache) Put(did string, entry *CacheEntry) {
+	ttl := c.ttlOf(did, entry)
+	if ttl <= 0 || c.maxSize <= 0 {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if elem, ok := c.entries[did]; ok {
+		c.remove(elem)
+	}
+
+	c.entries[did] = c.lru.PushFront(&lruEntry{did: did, entry: entry, expires: c.now().Add(ttl)})
+
+	for c.lru.Len() > c.maxSize {
+		c.remove(c.lru.Back())
+	}
+}
+
+// Delete removes the cached resolution of did
+func (c *LRUCache) Delete(did string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if elem, ok := c.entries[did]; ok {
+		c.remove(elem)
+	}
+}
+
+func (c *LRUCache) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*lruEntry).did)
+}
+
+func (c *LRUCache) ttlOf(did string, entry *CacheEntry) time.Duration {
+	if entry.NotFound {
+		return c.notFoundTTL
+	}
+
+	method, err := getDidMethod(did)
+	if err != nil {
+		return 0
+	}
+
+	if ttl, ok := c.methodTTL[method]; ok {
+		return ttl
+	}
+
+	return c.ttl
+}
+
+// copyResult deep copies the DID resolution result
+func copyResult(result *vdriapi.DIDResolutionResult) (*vdriapi.DIDResolutionResult, error) {
+	resultCopy := *result
+	resultCopy.DocumentMetadata.Created = copyTime(result.DocumentMetadata.Created)
+	resultCopy.DocumentMetadata.Updated = copyTime(result.DocumentMetadata.Updated)
+
+	if result.DIDDocument == nil {
+		return &resultCopy, nil
+	}
+
+	// the Go structure of the document is marshalled as is, unlike its JSON-LD form
+	docBytes, err := json.Marshal(result.DIDDocument)
+	if err != nil {
+		return nil, fmt.Errorf("copy DID document: %w", err)
+	}
+
+	resultCopy.DIDDocument = &diddoc.Doc{}
+
+	err = json.Unmarshal(docBytes, resultCopy.DIDDocument)
+	if err != nil {
+		return nil, fmt.Errorf("copy DID document: %w", err)
+	}
+
+	return &resultCopy, nil
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	tCopy := *t
+
+	return &tCopy
+}
@@ -0,0 +1,120 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdri
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+func TestLRUCache(t *testing.T) {
+	t.Run("test defaults", func(t *testing.T) {
+		c := NewLRUCache()
+		require.Equal(t, defaultCacheTTL, c.ttl)
+		require.Equal(t, defaultCacheNotFoundTTL, c.notFoundTTL)
+		require.Equal(t, defaultCacheMaxSize, c.maxSize)
+	})
+
+	t.Run("test put, get and delete", func(t *testing.T) {
+		c := NewLRUCache()
+
+		_, ok := c.Get("did:example:1")
+		require.False(t, ok)
+
+		doc := &did.Doc{ID: "did:example:1"}
+		c.Put(doc.ID, &CacheEntry{Result: &vdriapi.DIDResolutionResult{DIDDocument: doc}})
+
+		entry, ok := c.Get(doc.ID)
+		require.True(t, ok)
+		require.Equal(t, doc, entry.Result.DIDDocument)
+
+		// the entry is replaced
+		c.Put(doc.ID, &CacheEntry{NotFound: true})
+
+		entry, ok = c.Get(doc.ID)
+		require.True(t, ok)
+		require.True(t, entry.NotFound)
+
+		c.Delete(doc.ID)
+		c.Delete("did:example:2")
+
+		_, ok = c.Get(doc.ID)
+		require.False(t, ok)
+	})
+
+	t.Run("test time to live", func(t *testing.T) {
+		now := time.Now()
+
+		c := NewLRUCache(WithCacheTTL(time.Minute), WithMethodCacheTTL("peer", time.Hour),
+			WithMethodCacheTTL("key", 0), WithNotFoundCacheTTL(time.Second))
+		c.now = func() time.Time { return now }
+
+		c.Put("did:example:1", &CacheEntry{Result: &vdriapi.DIDResolutionResult{}})
+		c.Put("did:peer:1", &CacheEntry{Result: &vdriapi.DIDResolutionResult{}})
+		c.Put("did:key:1", &CacheEntry{Result: &vdriapi.DIDResolutionResult{}})
+		c.Put("did:example:2", &CacheEntry{NotFound: true})
+		c.Put("invalid", &CacheEntry{Result: &vdriapi.DIDResolutionResult{}})
+
+		_, ok := c.Get("did:key:1")
+		require.False(t, ok)
+
+		_, ok = c.Get("invalid")
+		require.False(t, ok)
+
+		now = now.Add(time.Second)
+
+		_, ok = c.Get("did:example:2")
+		require.False(t, ok)
+
+		_, ok = c.Get("did:example:1")
+		require.True(t, ok)
+
+		now = now.Add(time.Minute)
+
+		_, ok = c.Get("did:example:1")
+		require.False(t, ok)
+
+		_, ok = c.Get("did:peer:1")
+		require.True(t, ok)
+
+		// the expired entries are removed
+		require.Equal(t, 1, c.lru.Len())
+	})
+
+	t.Run("test max size", func(t *testing.T) {
+		c := NewLRUCache(WithCacheMaxSize(2))
+
+		c.Put("did:example:1", &CacheEntry{Result: &vdriapi.DIDResolutionResult{}})
+		c.Put("did:example:2", &CacheEntry{Result: &vdriapi.DIDResolutionResult{}})
+
+		// did:example:1 becomes the most recently used
+		_, ok := c.Get("did:example:1")
+		require.True(t, ok)
+
+		c.Put("did:example:3", &CacheEntry{Result: &vdriapi.DIDResolutionResult{}})
+
+		_, ok = c.Get("did:example:2")
+		require.False(t, ok)
+
+		_, ok = c.Get("did:example:1")
+		require.True(t, ok)
+
+		_, ok = c.Get("did:example:3")
+		require.True(t, ok)
+
+		c = NewLRUCache(WithCacheMaxSize(0))
+		c.Put("did:example:1", &CacheEntry{Result: &vdriapi.DIDResolutionResult{}})
+
+		_, ok = c.Get("did:example:1")
+		require.False(t, ok)
+	})
+}
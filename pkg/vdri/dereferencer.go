@@ -0,0 +1,149 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdri
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+// DID URL query parameters (https://w3c.github.io/did-core/#did-parameters)
+const (
+	serviceParam     = "service"
+	relativeRefParam = "relativeRef"
+	versionIDParam   = "versionId"
+	versionTimeParam = "versionTime"
+)
+
+// Errors of the DID URL dereferencing, the DID URL is invalid when did.ErrInvalidDIDURL is returned and the
+// DID or its version is not found when vdri.ErrNotFound is returned.
+var (
+	// ErrFragmentNotFound is returned when no verification method or service matches the DID URL fragment
+	ErrFragmentNotFound = errors.New("DID URL fragment not found")
+	// ErrServiceNotFound is returned when no service matches the DID URL service parameter
+	ErrServiceNotFound = errors.New("DID URL service not found")
+	// ErrPathNotSupported is returned when the DID URL has a path without service parameter
+	ErrPathNotSupported = errors.New("DID URL path not supported without service")
+)
+
+// DereferenceResult is the resource identified by a DID URL
+type DereferenceResult struct {
+	DIDURL *diddoc.DIDURL
+	// Document is the resolved DID document, of the version requested by the versionId or versionTime parameter
+	Document         *diddoc.Doc
+	DocumentMetadata vdriapi.DocumentMetadata
+	// VerificationMethod is the public key identified by the DID URL fragment
+	VerificationMethod *diddoc.PublicKey
+	// Service is the service identified by the DID URL fragment or selected by the service parameter
+	Service *diddoc.Service
+	// ServiceEndpoint is the endpoint of Service, with the DID URL path and relativeRef parameter appended
+	ServiceEndpoint string
+}
+
+// Dereferencer dereferences DID URLs (https://w3c-ccg.github.io/did-resolution/#dereferencing) by resolving
+// their DID with the VDRI registry.
+type Dereferencer struct {
+	registry vdriapi.Registry
+}
+
+// NewDereferencer returns a new DID URL dereferencer resolving the DIDs with registry
+func NewDereferencer(registry vdriapi.Registry) *Dereferencer {
+	return &Dereferencer{registry: registry}
+}
+
+// Dereference dereferences the DID URL into the DID document, the verification method or service identified
+// by its fragment, or the service endpoint selected by its service parameter.
+func (d *Dereferencer) Dereference(didURL string) (*DereferenceResult, error) {
+	u, err := diddoc.ParseDIDURL(didURL)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := resolveOpts(u)
+	if err != nil {
+		return nil, err
+	}
+
+	resolution, err := d.registry.ResolveResult(u.DID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if resolution.DIDDocument == nil {
+		return nil, fmt.Errorf("%w: %s", vdriapi.ErrNotFound, u.DID)
+	}
+
+	result := &DereferenceResult{
+		DIDURL:           u,
+		Document:         resolution.DIDDocument,
+		DocumentMetadata: resolution.DocumentMetadata,
+	}
+
+	if serviceID, ok := u.Queries[serviceParam]; ok {
+		service, found := result.Document.ServiceByFragment(serviceID[0])
+		if !found {
+			return nil, fmt.Errorf("%w: '%s' in %s", ErrServiceNotFound, serviceID[0], didURL)
+		}
+
+		result.Service = service
+		result.ServiceEndpoint = service.ServiceEndpoint + u.Path + u.Queries.Get(relativeRefParam)
+
+		// the fragment identifies a secondary resource of the service endpoint
+		if u.Fragment != "" {
+			result.ServiceEndpoint += "#" + u.Fragment
+		}
+
+		return result, nil
+	}
+
+	if u.Path != "" {
+		return nil, fmt.Errorf("%w: %s", ErrPathNotSupported, didURL)
+	}
+
+	if u.Fragment == "" {
+		return result, nil
+	}
+
+	if key, ok := result.Document.VerificationMethodByFragment(u.Fragment); ok {
+		result.VerificationMethod = key
+
+		return result, nil
+	}
+
+	if service, ok := result.Document.ServiceByFragment(u.Fragment); ok {
+		result.Service = service
+		result.ServiceEndpoint = service.ServiceEndpoint
+
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("%w: '%s' in %s", ErrFragmentNotFound, u.Fragment, didURL)
+}
+
+// resolveOpts returns the options resolving the DID document version requested by the DID URL
+func resolveOpts(u *diddoc.DIDURL) ([]vdriapi.ResolveOpts, error) {
+	var opts []vdriapi.ResolveOpts
+
+	if versionID := u.Queries.Get(versionIDParam); versionID != "" {
+		opts = append(opts, vdriapi.WithVersionID(versionID))
+	}
+
+	if versionTime := u.Queries.Get(versionTimeParam); versionTime != "" {
+		t, err := time.Parse(time.RFC3339, versionTime)
+		if err != nil {
+			return nil, fmt.Errorf("%w: versionTime: %s", diddoc.ErrInvalidDIDURL, err.Error())
+		}
+
+		opts = append(opts, vdriapi.WithVersionTime(t))
+	}
+
+	return opts, nil
+}
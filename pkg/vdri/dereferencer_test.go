@@ -0,0 +1,112 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdri
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
+)
+
+func TestDereferencer_Dereference(t *testing.T) {
+	doc := &did.Doc{
+		ID:        "did:example:123",
+		PublicKey: []did.PublicKey{{ID: "did:example:123#key-1", Value: []byte("key")}},
+		Service:   []did.Service{{ID: "did:example:123#agent", ServiceEndpoint: "https://example.com/agent"}},
+	}
+
+	var resolveOpts *vdriapi.ResolveDIDOpts
+
+	d := NewDereferencer(&mockvdri.MockVDRIRegistry{
+		ResolveFunc: func(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error) {
+			resolveOpts = &vdriapi.ResolveDIDOpts{}
+			for _, opt := range opts {
+				opt(resolveOpts)
+			}
+
+			if didID != doc.ID {
+				return nil, vdriapi.ErrNotFound
+			}
+
+			return doc, nil
+		},
+	})
+
+	t.Run("test document", func(t *testing.T) {
+		result, err := d.Dereference("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, doc, result.Document)
+		require.Nil(t, result.VerificationMethod)
+		require.Nil(t, result.Service)
+	})
+
+	t.Run("test document version", func(t *testing.T) {
+		_, err := d.Dereference("did:example:123?versionId=2")
+		require.NoError(t, err)
+		require.Equal(t, "2", resolveOpts.VersionID)
+
+		_, err = d.Dereference("did:example:123?versionTime=2020-05-01T10:00:00Z")
+		require.NoError(t, err)
+		require.Equal(t, "2020-05-01T10:00:00Z", resolveOpts.VersionTime)
+
+		_, err = d.Dereference("did:example:123?versionTime=yesterday")
+		require.True(t, errors.Is(err, did.ErrInvalidDIDURL))
+	})
+
+	t.Run("test verification method", func(t *testing.T) {
+		result, err := d.Dereference("did:example:123#key-1")
+		require.NoError(t, err)
+		require.Equal(t, &doc.PublicKey[0], result.VerificationMethod)
+	})
+
+	t.Run("test service fragment", func(t *testing.T) {
+		result, err := d.Dereference("did:example:123#agent")
+		require.NoError(t, err)
+		require.Equal(t, &doc.Service[0], result.Service)
+		require.Equal(t, "https://example.com/agent", result.ServiceEndpoint)
+	})
+
+	t.Run("test service parameter", func(t *testing.T) {
+		result, err := d.Dereference("did:example:123/inbox?service=agent&relativeRef=%3Fid%3D1#msg")
+		require.NoError(t, err)
+		require.Equal(t, &doc.Service[0], result.Service)
+		require.Equal(t, "https://example.com/agent/inbox?id=1#msg", result.ServiceEndpoint)
+	})
+
+	t.Run("test errors", func(t *testing.T) {
+		tests := []struct {
+			didURL string
+			err    error
+		}{
+			{didURL: "example:123#key-1", err: did.ErrInvalidDIDURL},
+			{didURL: "did:example:456#key-1", err: vdriapi.ErrNotFound},
+			{didURL: "did:example:123#key-2", err: ErrFragmentNotFound},
+			{didURL: "did:example:123?service=inbox", err: ErrServiceNotFound},
+			{didURL: "did:example:123/inbox", err: ErrPathNotSupported},
+		}
+
+		for _, tc := range tests {
+			result, err := d.Dereference(tc.didURL)
+			require.True(t, errors.Is(err, tc.err), tc.didURL)
+			require.Nil(t, result)
+		}
+	})
+
+	t.Run("test DID without document", func(t *testing.T) {
+		_, err := NewDereferencer(&mockvdri.MockVDRIRegistry{
+			ResolveFunc: func(string, ...vdriapi.ResolveOpts) (*did.Doc, error) {
+				return nil, nil
+			},
+		}).Dereference("did:example:123")
+		require.True(t, errors.Is(err, vdriapi.ErrNotFound))
+	})
+}
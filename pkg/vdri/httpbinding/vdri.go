@@ -14,6 +14,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"path"
 	"time"
 
 	"github.com/btcsuite/btcutil/base58"
@@ -29,6 +30,7 @@ const (
 	pubKeyIndex1      = "#key-1"
 	pubKeyController  = "controller"
 	svcEndpointIndex1 = "#endpoint-1"
+	deactivatePath    = "deactivate"
 )
 
 // VDRI via HTTP(s) endpoint
@@ -170,6 +172,75 @@ func (v *VDRI) sendCreateRequest(req io.Reader) (*did.Doc, error) {
 	return didDoc, nil
 }
 
+// Update posts the updated DID document to the binding URL of the DID (the endpoint URL followed by the DID),
+// the request body is built with the request builder option if set
+func (v *VDRI) Update(doc *did.Doc, opts ...vdriapi.UpdateOpts) error {
+	docBytes, err := doc.JSONBytes()
+	if err != nil {
+		return fmt.Errorf("failed to get document bytes : %w", err)
+	}
+
+	err = v.sendUpdateRequest(doc.ID, "", docBytes, opts)
+	if err != nil {
+		return fmt.Errorf("failed to send update DID request: %w", err)
+	}
+
+	return nil
+}
+
+// Deactivate posts the deactivation of the DID to the binding URL of the DID followed by "/deactivate",
+// the request body is built with the request builder option if set (with an empty payload)
+func (v *VDRI) Deactivate(didID string, opts ...vdriapi.UpdateOpts) error {
+	err := v.sendUpdateRequest(didID, deactivatePath, nil, opts)
+	if err != nil {
+		return fmt.Errorf("failed to send deactivate DID request: %w", err)
+	}
+
+	return nil
+}
+
+// sendUpdateRequest posts the payload to the binding URL of the DID followed by subPath
+func (v *VDRI) sendUpdateRequest(didID, subPath string, payload []byte, opts []vdriapi.UpdateOpts) error {
+	updateOpts := &vdriapi.UpdateDIDOpts{}
+
+	for _, opt := range opts {
+		opt(updateOpts)
+	}
+
+	reqURL, err := url.ParseRequestURI(v.endpointURL)
+	if err != nil {
+		return fmt.Errorf("url parse request uri failed: %w", err)
+	}
+
+	reqURL.Path = path.Join(reqURL.Path, didID, subPath)
+
+	var reqBody io.Reader = bytes.NewReader(payload)
+
+	if updateOpts.RequestBuilder != nil {
+		reqBody, err = updateOpts.RequestBuilder(payload)
+		if err != nil {
+			return fmt.Errorf("failed to build request : %w", err)
+		}
+	}
+
+	resp, err := v.client.Post(reqURL.String(), "application/json", reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	defer closeResponseBody(resp.Body)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", vdriapi.ErrNotFound, didID)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("got unexpected response status '%d'", resp.StatusCode)
+	}
+
+	return nil
+}
+
 // Close frees resources being maintained by vdri.
 func (v *VDRI) Close() error {
 	return nil
@@ -8,14 +8,17 @@ package httpbinding
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
 )
@@ -145,3 +148,108 @@ func TestVDRI_Build(t *testing.T) {
 		}
 	})
 }
+
+func TestVDRI_Update(t *testing.T) {
+	didDoc, err := did.ParseDocument([]byte(doc))
+	require.NoError(t, err)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		require.Equal(t, http.MethodPost, req.Method)
+
+		switch req.URL.Path {
+		case "/did:peer:21tDAKCERh95uGgKbJNHYp":
+			body, e := ioutil.ReadAll(req.Body)
+			require.NoError(t, e)
+
+			updated, e := did.ParseDocument(body)
+			require.NoError(t, e)
+			require.Equal(t, didDoc.ID, updated.ID)
+
+			res.WriteHeader(http.StatusOK)
+		case "/did:example:404":
+			res.WriteHeader(http.StatusNotFound)
+		default:
+			res.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+
+	defer testServer.Close()
+
+	t.Run("test update", func(t *testing.T) {
+		v, err := New(testServer.URL)
+		require.NoError(t, err)
+		require.NoError(t, v.Update(didDoc))
+	})
+
+	t.Run("test update with request builder", func(t *testing.T) {
+		v, err := New(testServer.URL)
+		require.NoError(t, err)
+
+		err = v.Update(didDoc, vdriapi.WithUpdateRequestBuilder(func(b []byte) (io.Reader, error) {
+			return bytes.NewReader(b), nil
+		}))
+		require.NoError(t, err)
+
+		err = v.Update(didDoc, vdriapi.WithUpdateRequestBuilder(func(b []byte) (io.Reader, error) {
+			return nil, fmt.Errorf("sample-error")
+		}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to build request : sample-error")
+	})
+
+	t.Run("test update of DID not found", func(t *testing.T) {
+		v, err := New(testServer.URL)
+		require.NoError(t, err)
+
+		err = v.Update(&did.Doc{ID: "did:example:404"})
+		require.Error(t, err)
+		require.True(t, errors.Is(err, vdriapi.ErrNotFound))
+	})
+
+	t.Run("test update rejected", func(t *testing.T) {
+		v, err := New(testServer.URL)
+		require.NoError(t, err)
+
+		err = v.Update(&did.Doc{ID: "did:example:400"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "got unexpected response status '400'")
+	})
+
+	t.Run("test update with invalid endpoint", func(t *testing.T) {
+		v, err := New("localhost:8080")
+		require.NoError(t, err)
+
+		err = v.Update(didDoc)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to send update DID request")
+	})
+}
+
+func TestVDRI_Deactivate(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		require.Equal(t, http.MethodPost, req.Method)
+
+		if req.URL.Path == "/did:example:334455/deactivate" {
+			res.WriteHeader(http.StatusOK)
+			return
+		}
+
+		res.WriteHeader(http.StatusNotFound)
+	}))
+
+	defer testServer.Close()
+
+	v, err := New(testServer.URL)
+	require.NoError(t, err)
+
+	t.Run("test deactivate", func(t *testing.T) {
+		require.NoError(t, v.Deactivate("did:example:334455"))
+	})
+
+	t.Run("test deactivate of DID not found", func(t *testing.T) {
+		err := v.Deactivate("did:example:404")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, vdriapi.ErrNotFound))
+		require.Contains(t, err.Error(), "failed to send deactivate DID request")
+	})
+}
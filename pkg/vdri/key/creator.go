@@ -0,0 +1,72 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package key
+
+import (
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/secp256k1"
+)
+
+// Build builds the did:key DID Document of pubKey. Supported key types are Ed25519VerificationKey2018,
+// X25519KeyAgreementKey2019, EcdsaSecp256r1VerificationKey2019 and Secp256k1VerificationKey2018, the EC keys
+// are either compressed or uncompressed.
+// The DID Document is fully derived from the key, so the service options are ignored.
+func (v *VDRI) Build(pubKey *vdriapi.PubKey, _ ...vdriapi.DocOpts) (*did.Doc, error) {
+	fp, err := fingerprintOf(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("build did:key: %w", err)
+	}
+
+	didDoc, err := createDIDDoc(fp)
+	if err != nil {
+		return nil, fmt.Errorf("build did:key: %w", err)
+	}
+
+	return didDoc, nil
+}
+
+// fingerprintOf returns the did:key fingerprint of pubKey
+func fingerprintOf(pubKey *vdriapi.PubKey) (string, error) {
+	// TODO fix hardcode base58 https://github.com/hyperledger/aries-framework-go/issues/1207
+	value := base58.Decode(pubKey.Value)
+
+	switch pubKey.Type {
+	case ed25519KeyType, x25519KeyType:
+		if len(value) != ed25519.PublicKeySize {
+			return "", fmt.Errorf("invalid %s key size %d", pubKey.Type, len(value))
+		}
+
+		code := uint64(ed25519PubKeyMultiCodec)
+		if pubKey.Type == x25519KeyType {
+			code = x25519PubKeyMultiCodec
+		}
+
+		return fingerprint(code, value), nil
+	case p256KeyType:
+		compressed, err := compressPoint(p256Curve(), value)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s key: %w", pubKey.Type, err)
+		}
+
+		return fingerprint(p256PubKeyMultiCodec, compressed), nil
+	case secp256k1KeyType:
+		key, err := secp256k1.ParsePublicKey(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s key: %w", pubKey.Type, err)
+		}
+
+		return fingerprint(secp256k1PubKeyMultiCodec, secp256k1.MarshalCompressedPublicKey(key)), nil
+	default:
+		return "", fmt.Errorf("key type '%s' not supported", pubKey.Type)
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package key
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/secp256k1"
+)
+
+const (
+	ed25519DIDKey    = "did:key:z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+	ed25519PubKey    = "B12NYF8RrR3h41TDCTJojY59usg3mbtbjnFs7Eud1Y6u"
+	x25519KeyID      = ed25519DIDKey + "#z6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc"
+	x25519PubKey     = "JhNWeSVLMYccCk7iopQW4guaSJTojqpMEELgSLhKwRr"
+	secp256k1DIDKey  = "did:key:zQ3shokFTS3brHcDQrn82RUDfCZESWL1ZdCEJwekUDPQiYBme"
+	secp256k1PubKey  = "23o6Sau8NxxzXcgSc3PLcNxrzrZpbLeBn1izfv3jbKhuv"
+	x25519OnlyPubKey = "4Dy8E9UaZscuPUf2GLxV44RCNL7oxmEXXkgWXaug1WKV"
+)
+
+func TestBuild(t *testing.T) {
+	v := New()
+
+	t.Run("test build ed25519 did:key", func(t *testing.T) {
+		doc, err := v.Build(&vdriapi.PubKey{Value: ed25519PubKey, Type: ed25519KeyType},
+			vdriapi.WithServiceType("did-communication"))
+		require.NoError(t, err)
+		require.Equal(t, ed25519DIDKey, doc.ID)
+
+		// the DID Document is fully derived from the key
+		require.Empty(t, doc.Service)
+		require.Nil(t, doc.Created)
+
+		require.Len(t, doc.PublicKey, 1)
+		require.Equal(t, ed25519DIDKey+"#"+strings.TrimPrefix(ed25519DIDKey, "did:key:"), doc.PublicKey[0].ID)
+		require.Equal(t, ed25519DIDKey, doc.PublicKey[0].Controller)
+		require.Equal(t, ed25519KeyType, doc.PublicKey[0].Type)
+		require.Equal(t, ed25519PubKey, base58.Encode(doc.PublicKey[0].Value))
+
+		require.Len(t, doc.Authentication, 1)
+		require.Equal(t, doc.PublicKey[0], doc.Authentication[0].PublicKey)
+
+		require.Len(t, doc.KeyAgreement, 1)
+		require.Equal(t, x25519KeyID, doc.KeyAgreement[0].PublicKey.ID)
+		require.Equal(t, x25519KeyType, doc.KeyAgreement[0].PublicKey.Type)
+		require.Equal(t, x25519PubKey, base58.Encode(doc.KeyAgreement[0].PublicKey.Value))
+	})
+
+	t.Run("test build x25519 did:key", func(t *testing.T) {
+		doc, err := v.Build(&vdriapi.PubKey{Value: x25519OnlyPubKey, Type: x25519KeyType})
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(doc.ID, "did:key:z6LS"))
+
+		require.Empty(t, doc.Authentication)
+		require.Len(t, doc.KeyAgreement, 1)
+		require.Equal(t, x25519KeyType, doc.KeyAgreement[0].PublicKey.Type)
+		require.Equal(t, x25519OnlyPubKey, base58.Encode(doc.KeyAgreement[0].PublicKey.Value))
+	})
+
+	t.Run("test build secp256k1 did:key", func(t *testing.T) {
+		doc, err := v.Build(&vdriapi.PubKey{Value: secp256k1PubKey, Type: secp256k1KeyType})
+		require.NoError(t, err)
+		require.Equal(t, secp256k1DIDKey, doc.ID)
+
+		require.Len(t, doc.Authentication, 1)
+		require.Equal(t, secp256k1KeyType, doc.Authentication[0].PublicKey.Type)
+		require.Equal(t, secp256k1PubKey, base58.Encode(doc.Authentication[0].PublicKey.Value))
+		require.Empty(t, doc.KeyAgreement)
+
+		// the uncompressed key gives the same DID
+		key, err := secp256k1.ParsePublicKey(base58.Decode(secp256k1PubKey))
+		require.NoError(t, err)
+
+		doc2, err := v.Build(&vdriapi.PubKey{
+			Value: base58.Encode(secp256k1.MarshalPublicKey(key)),
+			Type:  secp256k1KeyType,
+		})
+		require.NoError(t, err)
+		require.Equal(t, doc.ID, doc2.ID)
+	})
+
+	t.Run("test build P-256 did:key", func(t *testing.T) {
+		priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		pub := elliptic.Marshal(elliptic.P256(), priv.X, priv.Y)
+
+		doc, err := v.Build(&vdriapi.PubKey{Value: base58.Encode(pub), Type: p256KeyType})
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(doc.ID, "did:key:zDn"))
+
+		// the P-256 keys are expanded into the uncompressed form
+		require.Len(t, doc.Authentication, 1)
+		require.Equal(t, pub, doc.Authentication[0].PublicKey.Value)
+		require.Len(t, doc.KeyAgreement, 1)
+		require.Equal(t, pub, doc.KeyAgreement[0].PublicKey.Value)
+
+		// the compressed key gives the same DID
+		compressed, err := compressPoint(p256Curve(), pub)
+		require.NoError(t, err)
+
+		doc2, err := v.Build(&vdriapi.PubKey{Value: base58.Encode(compressed), Type: p256KeyType})
+		require.NoError(t, err)
+		require.Equal(t, doc.ID, doc2.ID)
+	})
+
+	t.Run("test build failures", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			pubKey *vdriapi.PubKey
+			errMsg string
+		}{
+			{
+				name:   "unsupported key type",
+				pubKey: &vdriapi.PubKey{Value: ed25519PubKey, Type: "RsaVerificationKey2018"},
+				errMsg: "build did:key: key type 'RsaVerificationKey2018' not supported",
+			},
+			{
+				name:   "invalid ed25519 key",
+				pubKey: &vdriapi.PubKey{Value: base58.Encode([]byte("invalid")), Type: ed25519KeyType},
+				errMsg: "build did:key: invalid Ed25519VerificationKey2018 key size 7",
+			},
+			{
+				name:   "invalid P-256 key",
+				pubKey: &vdriapi.PubKey{Value: base58.Encode([]byte("invalid")), Type: p256KeyType},
+				errMsg: "build did:key: invalid EcdsaSecp256r1VerificationKey2019 key: invalid point size 7",
+			},
+			{
+				name:   "invalid secp256k1 key",
+				pubKey: &vdriapi.PubKey{Value: base58.Encode(make([]byte, 33)), Type: secp256k1KeyType},
+				errMsg: "build did:key: invalid Secp256k1VerificationKey2018 key: secp256k1: invalid public key encoding",
+			},
+		}
+
+		for _, tt := range tests {
+			tc := tt
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := v.Build(tc.pubKey)
+				require.EqualError(t, err, tc.errMsg)
+			})
+		}
+	})
+}
@@ -0,0 +1,145 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package key
+
+import (
+	"crypto/elliptic"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/multiformats/go-multibase"
+)
+
+// multicodec codes of the public keys (https://github.com/multiformats/multicodec/blob/master/table.csv)
+const (
+	ed25519PubKeyMultiCodec   = 0xed
+	x25519PubKeyMultiCodec    = 0xec
+	secp256k1PubKeyMultiCodec = 0xe7
+	p256PubKeyMultiCodec      = 0x1200
+)
+
+const (
+	// sizes of the coordinates and compressed points of the supported EC curves
+	coordinateSize     = 32
+	compressedSize     = coordinateSize + 1
+	uncompressedSize   = 2*coordinateSize + 1
+	uncompressedPrefix = 0x04
+)
+
+// fingerprint returns the did:key fingerprint of the public key value with the multicodec code, that is the
+// base58btc multibase encoding of the varint encoded code followed by the key
+func fingerprint(code uint64, value []byte) string {
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(buf, code)
+
+	// the encoding can't fail with a supported base
+	fp, _ := multibase.Encode(multibase.Base58BTC, append(buf[:n], value...)) // nolint:errcheck
+
+	return fp
+}
+
+// parseFingerprint returns the multicodec code and the public key value of the did:key fingerprint fp
+func parseFingerprint(fp string) (uint64, []byte, error) {
+	enc, data, err := multibase.Decode(fp)
+	if err != nil {
+		return 0, nil, fmt.Errorf("decode fingerprint: %w", err)
+	}
+
+	if enc != multibase.Base58BTC {
+		return 0, nil, fmt.Errorf("unsupported fingerprint encoding '%c'", enc)
+	}
+
+	code, n := binary.Uvarint(data)
+	if n <= 0 {
+		return 0, nil, errors.New("invalid fingerprint multicodec code")
+	}
+
+	return code, data[n:], nil
+}
+
+// curve holds the parameters of a short Weierstrass curve y² = x³ + ax + b over the prime field p.
+// The secp256k1 points are encoded by the secp256k1 package.
+type curve struct {
+	p, a, b *big.Int
+}
+
+func p256Curve() *curve {
+	params := elliptic.P256().Params()
+
+	return &curve{p: params.P, a: big.NewInt(-3), b: params.B}
+}
+
+// y2 returns x³ + ax + b mod p
+func (c *curve) y2(x *big.Int) *big.Int {
+	y2 := new(big.Int).Exp(x, big.NewInt(3), c.p) // nolint:gomnd
+	y2.Add(y2, new(big.Int).Mul(c.a, x))
+	y2.Add(y2, c.b)
+
+	return y2.Mod(y2, c.p)
+}
+
+// compressPoint returns the compressed form of the point in value, which is either compressed or uncompressed
+func compressPoint(c *curve, value []byte) ([]byte, error) {
+	switch len(value) {
+	case compressedSize:
+		if _, err := decompressPoint(c, value); err != nil {
+			return nil, err
+		}
+
+		return value, nil
+	case uncompressedSize:
+		if value[0] != uncompressedPrefix {
+			return nil, errors.New("invalid uncompressed point prefix")
+		}
+
+		x := new(big.Int).SetBytes(value[1 : 1+coordinateSize])
+		y := new(big.Int).SetBytes(value[1+coordinateSize:])
+
+		if x.Cmp(c.p) >= 0 || y.Cmp(c.p) >= 0 || new(big.Int).Exp(y, big.NewInt(2), c.p).Cmp(c.y2(x)) != 0 {
+			return nil, errors.New("point not on curve")
+		}
+
+		compressed := make([]byte, compressedSize)
+		compressed[0] = byte(2 + y.Bit(0)) // nolint:gomnd
+		copy(compressed[1:], value[1:1+coordinateSize])
+
+		return compressed, nil
+	default:
+		return nil, fmt.Errorf("invalid point size %d", len(value))
+	}
+}
+
+// decompressPoint returns the uncompressed form (0x04 || X || Y) of the compressed point in value
+func decompressPoint(c *curve, value []byte) ([]byte, error) {
+	if len(value) != compressedSize || (value[0] != 2 && value[0] != 3) {
+		return nil, errors.New("invalid compressed point")
+	}
+
+	x := new(big.Int).SetBytes(value[1:])
+	if x.Cmp(c.p) >= 0 {
+		return nil, errors.New("point not on curve")
+	}
+
+	y := new(big.Int).ModSqrt(c.y2(x), c.p)
+	if y == nil {
+		return nil, errors.New("point not on curve")
+	}
+
+	if y.Bit(0) != uint(value[0]&1) {
+		y.Sub(c.p, y)
+	}
+
+	uncompressed := make([]byte, uncompressedSize)
+	uncompressed[0] = uncompressedPrefix
+	copy(uncompressed[1:], value[1:])
+	yBytes := y.Bytes()
+	copy(uncompressed[uncompressedSize-len(yBytes):], yBytes)
+
+	return uncompressed, nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package key
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/secp256k1"
+)
+
+// Read expands the did:key didKey into its DID Document
+// (https://w3c-ccg.github.io/did-method-key/#read-verify)
+func (v *VDRI) Read(didKey string, _ ...vdriapi.ResolveOpts) (*did.Doc, error) {
+	const numPartsDID = 3
+
+	parts := strings.SplitN(didKey, ":", numPartsDID)
+	if len(parts) != numPartsDID || parts[0] != "did" || parts[1] != didMethod {
+		return nil, fmt.Errorf("invalid did:key: %s", didKey)
+	}
+
+	didDoc, err := createDIDDoc(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("read did:key: %w", err)
+	}
+
+	return didDoc, nil
+}
+
+// createDIDDoc expands the did:key fingerprint fp into its DID Document. Ed25519 keys are used for
+// signing and converted into X25519 keys for key agreement, X25519 keys for key agreement only,
+// P-256 keys for both and secp256k1 keys for signing only. The signing keys are authorized for
+// authentication, assertion and capability invocation and delegation.
+// The P-256 keys are expanded into the uncompressed form used by the DIDComm packers.
+func createDIDDoc(fp string) (*did.Doc, error) {
+	code, value, err := parseFingerprint(fp)
+	if err != nil {
+		return nil, err
+	}
+
+	didKey := "did:key:" + fp
+
+	switch code {
+	case ed25519PubKeyMultiCodec:
+		pk := publicKey(didKey, fp, ed25519KeyType, value)
+
+		x25519, e := cryptoutil.PublicEd25519toCurve25519(value)
+		if e != nil {
+			return nil, fmt.Errorf("convert ed25519 key: %w", e)
+		}
+
+		kaPK := publicKey(didKey, fingerprint(x25519PubKeyMultiCodec, x25519), x25519KeyType, x25519)
+
+		return newDoc(didKey, pk, []did.VerificationMethod{{PublicKey: pk}},
+			[]did.VerificationMethod{{PublicKey: kaPK}}), nil
+	case x25519PubKeyMultiCodec:
+		pk := publicKey(didKey, fp, x25519KeyType, value)
+
+		return newDoc(didKey, pk, nil, []did.VerificationMethod{{PublicKey: pk}}), nil
+	case p256PubKeyMultiCodec:
+		uncompressed, e := decompressPoint(p256Curve(), value)
+		if e != nil {
+			return nil, fmt.Errorf("invalid P-256 key: %w", e)
+		}
+
+		pk := publicKey(didKey, fp, p256KeyType, uncompressed)
+
+		return newDoc(didKey, pk, []did.VerificationMethod{{PublicKey: pk}},
+			[]did.VerificationMethod{{PublicKey: pk}}), nil
+	case secp256k1PubKeyMultiCodec:
+		if len(value) != compressedSize {
+			return nil, errors.New("invalid secp256k1 key: invalid compressed point")
+		}
+
+		if _, e := secp256k1.ParsePublicKey(value); e != nil {
+			return nil, fmt.Errorf("invalid secp256k1 key: %w", e)
+		}
+
+		pk := publicKey(didKey, fp, secp256k1KeyType, value)
+
+		return newDoc(didKey, pk, []did.VerificationMethod{{PublicKey: pk}}, nil), nil
+	default:
+		return nil, fmt.Errorf("unsupported key multicodec code 0x%x", code)
+	}
+}
+
+func publicKey(didKey, fp, keyType string, value []byte) did.PublicKey {
+	return did.PublicKey{
+		ID:         didKey + "#" + fp,
+		Type:       keyType,
+		Controller: didKey,
+		Value:      value,
+	}
+}
+
+func newDoc(didKey string, pk did.PublicKey, signing, keyAgreement []did.VerificationMethod) *did.Doc {
+	doc := did.BuildDoc(
+		did.WithPublicKey([]did.PublicKey{pk}),
+		did.WithAuthentication(signing),
+		did.WithAssertionMethod(signing),
+		did.WithCapabilityInvocation(signing),
+		did.WithCapabilityDelegation(signing),
+		did.WithKeyAgreement(keyAgreement),
+	)
+	doc.ID = didKey
+
+	return doc
+}
@@ -0,0 +1,123 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package key
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+func TestRead(t *testing.T) {
+	v := New()
+
+	t.Run("test read ed25519 did:key", func(t *testing.T) {
+		doc, err := v.Read(ed25519DIDKey)
+		require.NoError(t, err)
+		require.Equal(t, ed25519DIDKey, doc.ID)
+		require.Equal(t, ed25519PubKey, base58.Encode(doc.Authentication[0].PublicKey.Value))
+		require.Equal(t, x25519PubKey, base58.Encode(doc.KeyAgreement[0].PublicKey.Value))
+
+		// the signing key is authorized for all the verification relationships but key agreement
+		require.Equal(t, doc.Authentication, doc.AssertionMethod)
+		require.Equal(t, doc.Authentication, doc.CapabilityInvocation)
+		require.Equal(t, doc.Authentication, doc.CapabilityDelegation)
+
+		// the document is a valid DID document
+		docBytes, err := doc.JSONBytes()
+		require.NoError(t, err)
+
+		parsed, err := did.ParseDocument(docBytes)
+		require.NoError(t, err)
+		require.Equal(t, doc.KeyAgreement, parsed.KeyAgreement)
+		require.Equal(t, doc.AssertionMethod, parsed.AssertionMethod)
+	})
+
+	t.Run("test read the built did:key", func(t *testing.T) {
+		pub, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		built, err := v.Build(&vdriapi.PubKey{Value: base58.Encode(pub), Type: ed25519KeyType})
+		require.NoError(t, err)
+
+		doc, err := v.Read(built.ID)
+		require.NoError(t, err)
+		require.Equal(t, built, doc)
+	})
+
+	t.Run("test read secp256k1 did:key", func(t *testing.T) {
+		doc, err := v.Read(secp256k1DIDKey)
+		require.NoError(t, err)
+		require.Equal(t, secp256k1PubKey, base58.Encode(doc.PublicKey[0].Value))
+		require.Equal(t, doc.Authentication, doc.AssertionMethod)
+		require.Empty(t, doc.KeyAgreement)
+	})
+
+	t.Run("test read failures", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			didKey string
+			errMsg string
+		}{
+			{
+				name:   "invalid DID",
+				didKey: "did:peer:123",
+				errMsg: "invalid did:key: did:peer:123",
+			},
+			{
+				name:   "invalid fingerprint",
+				didKey: "did:key:!",
+				errMsg: "read did:key: decode fingerprint",
+			},
+			{
+				name:   "unsupported fingerprint encoding",
+				didKey: "did:key:f" + "ed01",
+				errMsg: "read did:key: unsupported fingerprint encoding 'f'",
+			},
+			{
+				name:   "unsupported multicodec",
+				didKey: "did:key:" + fingerprint(0x12, make([]byte, 32)),
+				errMsg: "read did:key: unsupported key multicodec code 0x12",
+			},
+			{
+				name:   "invalid multicodec",
+				didKey: "did:key:z" + base58.Encode([]byte{0x80}),
+				errMsg: "read did:key: invalid fingerprint multicodec code",
+			},
+			{
+				name:   "invalid ed25519 key",
+				didKey: "did:key:" + fingerprint(ed25519PubKeyMultiCodec, []byte("invalid")),
+				errMsg: "read did:key: convert ed25519 key",
+			},
+			{
+				name:   "invalid P-256 key",
+				didKey: "did:key:" + fingerprint(p256PubKeyMultiCodec, []byte("invalid")),
+				errMsg: "read did:key: invalid P-256 key: invalid compressed point",
+			},
+			{
+				name:   "invalid secp256k1 key",
+				didKey: "did:key:" + fingerprint(secp256k1PubKeyMultiCodec, []byte("invalid")),
+				errMsg: "read did:key: invalid secp256k1 key: invalid compressed point",
+			},
+		}
+
+		for _, tt := range tests {
+			tc := tt
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := v.Read(tc.didKey)
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+}
@@ -0,0 +1,59 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package key
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+const (
+	didMethod = "key"
+
+	// key types of the DID documents public keys
+	ed25519KeyType   = "Ed25519VerificationKey2018"
+	x25519KeyType    = "X25519KeyAgreementKey2019"
+	p256KeyType      = "EcdsaSecp256r1VerificationKey2019"
+	secp256k1KeyType = "Secp256k1VerificationKey2018"
+)
+
+// VDRI implements the did:key method (https://w3c-ccg.github.io/did-method-key/).
+// The DID documents are expanded from the public key encoded in the DID itself, so no storage is needed.
+type VDRI struct {
+}
+
+// New returns new instance of key vdri
+func New() *VDRI {
+	return &VDRI{}
+}
+
+// Accept did method
+func (v *VDRI) Accept(method string) bool {
+	return method == didMethod
+}
+
+// Store is a no-op, the did:key documents are never stored
+func (v *VDRI) Store(doc *did.Doc, by *[]vdriapi.ModifiedBy) error {
+	return nil
+}
+
+// Update is not supported, the did:key documents are derived from the key of the DID
+func (v *VDRI) Update(doc *did.Doc, _ ...vdriapi.UpdateOpts) error {
+	return fmt.Errorf("update %s: %w", doc.ID, vdriapi.ErrNotSupported)
+}
+
+// Deactivate is not supported, the did:key documents are derived from the key of the DID
+func (v *VDRI) Deactivate(didID string, _ ...vdriapi.UpdateOpts) error {
+	return fmt.Errorf("deactivate %s: %w", didID, vdriapi.ErrNotSupported)
+}
+
+// Close frees resources being maintained by vdri
+func (v *VDRI) Close() error {
+	return nil
+}
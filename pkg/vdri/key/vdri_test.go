@@ -0,0 +1,39 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package key
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+func TestVDRI(t *testing.T) {
+	v := New()
+
+	require.True(t, v.Accept("key"))
+	require.False(t, v.Accept("peer"))
+
+	require.NoError(t, v.Store(nil, nil))
+	require.NoError(t, v.Close())
+}
+
+func TestVDRI_Update(t *testing.T) {
+	v := New()
+
+	err := v.Update(&did.Doc{ID: "did:key:123"})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, vdriapi.ErrNotSupported))
+
+	err = v.Deactivate("did:key:123")
+	require.Error(t, err)
+	require.True(t, errors.Is(err, vdriapi.ErrNotSupported))
+}
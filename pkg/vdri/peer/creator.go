@@ -16,7 +16,8 @@ import (
 	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 )
 
-// Build builds new DID Document
+// Build builds new DID Document with the numeric algorithm of the vdri.
+// The numalgo 0 DID Documents are derived from the key only, so the service options are ignored.
 func (v *VDRI) Build(pubKey *vdriapi.PubKey, opts ...vdriapi.DocOpts) (*did.Doc, error) {
 	docOpts := &vdriapi.CreateDIDOpts{}
 	// Apply options
@@ -24,7 +25,20 @@ func (v *VDRI) Build(pubKey *vdriapi.PubKey, opts ...vdriapi.DocOpts) (*did.Doc,
 		opt(docOpts)
 	}
 
-	didDoc, err := build(pubKey, docOpts)
+	var (
+		didDoc *did.Doc
+		err    error
+	)
+
+	switch v.numAlgo {
+	case NumAlgo0:
+		didDoc, err = buildNumAlgo0(pubKey)
+	case NumAlgo2:
+		didDoc, err = buildNumAlgo2(pubKey, docOpts)
+	default:
+		didDoc, err = build(pubKey, docOpts)
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("create peer DID : %w", err)
 	}
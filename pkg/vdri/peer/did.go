@@ -91,14 +91,21 @@ func validateDID(doc *did.Doc) error {
 
 	// genesis version(no did) of the peer DID doc
 	genesisDoc := &did.Doc{
-		Context:        doc.Context,
-		ID:             "",
-		PublicKey:      doc.PublicKey,
-		Service:        doc.Service,
-		Authentication: doc.Authentication,
-		Created:        doc.Created,
-		Updated:        doc.Updated,
-		Proof:          doc.Proof,
+		Context:              doc.Context,
+		ID:                   "",
+		PublicKey:            doc.PublicKey,
+		Service:              doc.Service,
+		Authentication:       doc.Authentication,
+		KeyAgreement:         doc.KeyAgreement,
+		Controller:           doc.Controller,
+		AlsoKnownAs:          doc.AlsoKnownAs,
+		VerificationMethod:   doc.VerificationMethod,
+		AssertionMethod:      doc.AssertionMethod,
+		CapabilityInvocation: doc.CapabilityInvocation,
+		CapabilityDelegation: doc.CapabilityDelegation,
+		Created:              doc.Created,
+		Updated:              doc.Updated,
+		Proof:                doc.Proof,
 	}
 
 	// calculate the encnumbasis of the genesis version of the peer DID doc
@@ -0,0 +1,222 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/vdri/key"
+)
+
+// Numeric algorithms of the peer DIDs.
+// Reference: https://identity.foundation/peer-did-method-spec/#generation-method
+const (
+	// NumAlgo0 peer DIDs are made of a single inception key, their document is derived like a did:key document
+	NumAlgo0 = 0
+	// NumAlgo1 peer DIDs are the hash of their genesis document, the default numeric algorithm
+	NumAlgo1 = 1
+	// NumAlgo2 peer DIDs inline their keys and services
+	NumAlgo2 = 2
+)
+
+const (
+	numAlgo0Prefix = peerPrefix + "0z"
+	numAlgo2Prefix = peerPrefix + "2."
+	didKeyPrefix   = "did:key:"
+
+	// purpose codes of the numalgo 2 elements
+	purposeKeyAgreement   = 'E'
+	purposeAuthentication = 'V'
+	purposeService        = 'S'
+
+	numAlgo2KeyID     = "#key-%d"
+	numAlgo2ServiceID = "#service"
+)
+
+// abbreviated service of numalgo 2 peer DIDs
+type numAlgo2Service struct {
+	Type            string   `json:"t"`
+	ServiceEndpoint string   `json:"s,omitempty"`
+	RoutingKeys     []string `json:"r,omitempty"`
+}
+
+// isStateless checks if the document of the peer DID is derived from the DID itself (numalgo 0 and 2),
+// rather than stored with its deltas (numalgo 1)
+func isStateless(didID string) bool {
+	return strings.HasPrefix(didID, numAlgo0Prefix) || strings.HasPrefix(didID, numAlgo2Prefix)
+}
+
+// buildNumAlgo0 builds the numalgo 0 peer DID Document with the inception key pubKey
+func buildNumAlgo0(pubKey *vdriapi.PubKey) (*did.Doc, error) {
+	keyDoc, err := key.New().Build(pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveNumAlgo0(peerPrefix + "0" + strings.TrimPrefix(keyDoc.ID, didKeyPrefix))
+}
+
+// resolveNumAlgo0 derives the document of the numalgo 0 peer DID from its inception key, the same way as
+// the document of the did:key with the same key
+func resolveNumAlgo0(didID string) (*did.Doc, error) {
+	didKey := didKeyPrefix + strings.TrimPrefix(didID, peerPrefix+"0")
+
+	keyDoc, err := key.New().Read(didKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid inception key: %w", err)
+	}
+
+	rename := func(pk did.PublicKey) did.PublicKey {
+		pk.ID = strings.Replace(pk.ID, didKey, didID, 1)
+		pk.Controller = didID
+
+		return pk
+	}
+
+	doc := &did.Doc{Context: keyDoc.Context, ID: didID}
+
+	for _, pk := range keyDoc.PublicKey {
+		doc.PublicKey = append(doc.PublicKey, rename(pk))
+	}
+
+	for _, vm := range keyDoc.Authentication {
+		doc.Authentication = append(doc.Authentication, did.VerificationMethod{PublicKey: rename(vm.PublicKey)})
+	}
+
+	for _, vm := range keyDoc.KeyAgreement {
+		doc.KeyAgreement = append(doc.KeyAgreement, did.VerificationMethod{PublicKey: rename(vm.PublicKey)})
+	}
+
+	return doc, nil
+}
+
+// buildNumAlgo2 builds the numalgo 2 peer DID Document of pubKey, inlining its key agreement and
+// authentication keys (expanded like in a did:key document) and the service of docOpts
+func buildNumAlgo2(pubKey *vdriapi.PubKey, docOpts *vdriapi.CreateDIDOpts) (*did.Doc, error) {
+	keyDoc, err := key.New().Build(pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	elements := []string{peerPrefix + "2"}
+
+	for _, vm := range keyDoc.KeyAgreement {
+		elements = append(elements, string(purposeKeyAgreement)+fingerprintOf(vm.PublicKey))
+	}
+
+	for _, vm := range keyDoc.Authentication {
+		elements = append(elements, string(purposeAuthentication)+fingerprintOf(vm.PublicKey))
+	}
+
+	if docOpts.ServiceType != "" {
+		svc, e := json.Marshal(&numAlgo2Service{
+			Type:            docOpts.ServiceType,
+			ServiceEndpoint: docOpts.ServiceEndpoint,
+			RoutingKeys:     docOpts.RoutingKeys,
+		})
+		if e != nil {
+			return nil, fmt.Errorf("marshal service: %w", e)
+		}
+
+		elements = append(elements, string(purposeService)+base64.RawURLEncoding.EncodeToString(svc))
+	}
+
+	return resolveNumAlgo2(strings.Join(elements, "."))
+}
+
+// resolveNumAlgo2 derives the document of the numalgo 2 peer DID from its inlined keys and services.
+// The keys are numbered in the order of the DID, the DIDComm services use the authentication keys
+// as recipient keys.
+func resolveNumAlgo2(didID string) (*did.Doc, error) {
+	doc := did.BuildDoc()
+	doc.ID = didID
+
+	var services []did.Service
+
+	for _, element := range strings.Split(strings.TrimPrefix(didID, numAlgo2Prefix), ".") {
+		if element == "" {
+			return nil, errors.New("empty element")
+		}
+
+		switch element[0] {
+		case purposeKeyAgreement, purposeAuthentication:
+			keyDoc, err := key.New().Read(didKeyPrefix + element[1:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid key %s: %w", element, err)
+			}
+
+			pk := keyDoc.PublicKey[0]
+			pk.ID = fmt.Sprintf(numAlgo2KeyID, len(doc.PublicKey)+1)
+			pk.Controller = didID
+
+			doc.PublicKey = append(doc.PublicKey, pk)
+
+			if element[0] == purposeKeyAgreement {
+				doc.KeyAgreement = append(doc.KeyAgreement, did.VerificationMethod{PublicKey: pk})
+			} else {
+				doc.Authentication = append(doc.Authentication, did.VerificationMethod{PublicKey: pk})
+			}
+		case purposeService:
+			svc, err := decodeService(element[1:])
+			if err != nil {
+				return nil, err
+			}
+
+			services = append(services, *svc)
+		default:
+			return nil, fmt.Errorf("unsupported purpose code '%c'", element[0])
+		}
+	}
+
+	for i := range services {
+		services[i].ID = numAlgo2ServiceID
+		if i > 0 {
+			services[i].ID += fmt.Sprintf("-%d", i)
+		}
+
+		if services[i].Type == vdriapi.DIDCommServiceType {
+			for _, vm := range doc.Authentication {
+				services[i].RecipientKeys = append(services[i].RecipientKeys, vm.PublicKey.ID)
+			}
+		}
+	}
+
+	doc.Service = services
+
+	return doc, nil
+}
+
+func decodeService(encoded string) (*did.Service, error) {
+	b, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode service: %w", err)
+	}
+
+	svc := &numAlgo2Service{}
+
+	err = json.Unmarshal(b, svc)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal service: %w", err)
+	}
+
+	return &did.Service{
+		Type:            svc.Type,
+		ServiceEndpoint: svc.ServiceEndpoint,
+		RoutingKeys:     svc.RoutingKeys,
+	}, nil
+}
+
+// fingerprintOf returns the fingerprint of a key expanded from a did:key (i.e the fragment of its ID)
+func fingerprintOf(pk did.PublicKey) string {
+	return pk.ID[strings.LastIndex(pk.ID, "#")+1:]
+}
@@ -0,0 +1,194 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	api "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+const (
+	x25519KeyType = "X25519KeyAgreementKey2019"
+
+	// Ed25519 key and X25519 key converted from it
+	ed25519PubKey = "B12NYF8RrR3h41TDCTJojY59usg3mbtbjnFs7Eud1Y6u"
+	ed25519FP     = "z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+	x25519PubKey  = "JhNWeSVLMYccCk7iopQW4guaSJTojqpMEELgSLhKwRr"
+	x25519FP      = "z6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc"
+
+	// numalgo 2 peer DID of the peer DID method specification
+	//nolint:lll
+	specNumAlgo2DID = "did:peer:2.Ez6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc.Vz6MkqRYqQiSgvZQdnBytw86Qbs2ZWUkGv22od935YF4s8M7V.Vz6MkgoLTnTypo3tDRwCkZXSccTPHRLhF4ZnjhueYAFpEX6vg.SeyJ0IjoiZG0iLCJzIjoiaHR0cHM6Ly9leGFtcGxlLmNvbS9lbmRwb2ludCIsInIiOlsiZGlkOmV4YW1wbGU6c29tZW1lZGlhdG9yI3NvbWVrZXkiXSwiYSI6WyJkaWRjb21tL3YyIiwiZGlkY29tbS9haXAyO2Vudj1yZmM1ODciXX0"
+)
+
+func TestNumAlgo0(t *testing.T) {
+	v, err := New(storage.NewMockStoreProvider(), WithNumAlgo(NumAlgo0))
+	require.NoError(t, err)
+
+	didDoc, err := v.Build(&api.PubKey{Value: ed25519PubKey, Type: ed25519KeyType},
+		api.WithServiceType(api.DIDCommServiceType))
+	require.NoError(t, err)
+
+	peerDID := "did:peer:0" + ed25519FP
+	require.Equal(t, peerDID, didDoc.ID)
+	require.Empty(t, didDoc.Service)
+
+	require.Len(t, didDoc.PublicKey, 1)
+	require.Equal(t, peerDID+"#"+ed25519FP, didDoc.PublicKey[0].ID)
+	require.Equal(t, peerDID, didDoc.PublicKey[0].Controller)
+	require.Equal(t, base58.Decode(ed25519PubKey), didDoc.PublicKey[0].Value)
+	require.Len(t, didDoc.Authentication, 1)
+	require.Equal(t, didDoc.PublicKey[0], didDoc.Authentication[0].PublicKey)
+	require.Len(t, didDoc.KeyAgreement, 1)
+	require.Equal(t, peerDID+"#"+x25519FP, didDoc.KeyAgreement[0].PublicKey.ID)
+	require.Equal(t, base58.Decode(x25519PubKey), didDoc.KeyAgreement[0].PublicKey.Value)
+
+	// the document is not stored but derived from the DID
+	require.NoError(t, v.Store(didDoc, nil))
+
+	resolved, err := v.Read(peerDID)
+	require.NoError(t, err)
+	require.Equal(t, didDoc, resolved)
+
+	t.Run("test invalid inception key", func(t *testing.T) {
+		_, err = v.Read("did:peer:0z6Mk")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resolve numalgo 0 peer DID : invalid inception key")
+
+		_, err = v.Build(&api.PubKey{Value: ed25519PubKey, Type: keyType})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create peer DID")
+	})
+}
+
+func TestNumAlgo2(t *testing.T) {
+	t.Run("test build and read", func(t *testing.T) {
+		v, err := New(storage.NewMockStoreProvider(), WithNumAlgo(NumAlgo2))
+		require.NoError(t, err)
+
+		didDoc, err := v.Build(&api.PubKey{Value: ed25519PubKey, Type: ed25519KeyType},
+			api.WithServiceType(api.DIDCommServiceType),
+			api.WithServiceEndpoint("https://example.com/endpoint"),
+			api.WithRoutingKeys([]string{"routing-key"}))
+		require.NoError(t, err)
+
+		require.True(t, strings.HasPrefix(didDoc.ID, "did:peer:2.E"+x25519FP+".V"+ed25519FP+".S"))
+
+		require.Len(t, didDoc.PublicKey, 2)
+		require.Equal(t, "#key-1", didDoc.PublicKey[0].ID)
+		require.Equal(t, x25519KeyType, didDoc.PublicKey[0].Type)
+		require.Equal(t, "#key-2", didDoc.PublicKey[1].ID)
+		require.Equal(t, ed25519KeyType, didDoc.PublicKey[1].Type)
+		require.Equal(t, didDoc.PublicKey[0], didDoc.KeyAgreement[0].PublicKey)
+		require.Equal(t, didDoc.PublicKey[1], didDoc.Authentication[0].PublicKey)
+
+		require.Len(t, didDoc.Service, 1)
+		require.Equal(t, "#service", didDoc.Service[0].ID)
+		require.Equal(t, "https://example.com/endpoint", didDoc.Service[0].ServiceEndpoint)
+		require.Equal(t, []string{"routing-key"}, didDoc.Service[0].RoutingKeys)
+
+		recipientKeys, ok := did.LookupRecipientKeys(didDoc, api.DIDCommServiceType, ed25519KeyType)
+		require.True(t, ok)
+		require.Equal(t, []string{ed25519PubKey}, recipientKeys)
+
+		// the document is not stored but derived from the DID
+		require.NoError(t, v.Store(didDoc, nil))
+
+		resolved, err := v.Read(didDoc.ID)
+		require.NoError(t, err)
+		require.Equal(t, didDoc, resolved)
+
+		// the document is valid
+		docBytes, err := resolved.JSONBytes()
+		require.NoError(t, err)
+
+		_, err = did.ParseDocument(docBytes)
+		require.NoError(t, err)
+	})
+
+	t.Run("test read peer DID of the specification", func(t *testing.T) {
+		v, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		didDoc, err := v.Read(specNumAlgo2DID)
+		require.NoError(t, err)
+
+		require.Len(t, didDoc.PublicKey, 3)
+		require.Len(t, didDoc.KeyAgreement, 1)
+		require.Equal(t, base58.Decode(x25519PubKey), didDoc.KeyAgreement[0].PublicKey.Value)
+		require.Len(t, didDoc.Authentication, 2)
+		require.Equal(t, "#key-3", didDoc.Authentication[1].PublicKey.ID)
+
+		require.Len(t, didDoc.Service, 1)
+		require.Equal(t, "dm", didDoc.Service[0].Type)
+		require.Equal(t, "https://example.com/endpoint", didDoc.Service[0].ServiceEndpoint)
+		require.Equal(t, []string{"did:example:somemediator#somekey"}, didDoc.Service[0].RoutingKeys)
+		require.Empty(t, didDoc.Service[0].RecipientKeys)
+	})
+
+	t.Run("test build with several services", func(t *testing.T) {
+		svc := "S" + encodeTestService(t, `{"t":"a"}`)
+
+		didDoc, err := resolveNumAlgo2("did:peer:2.V" + ed25519FP + "." + svc + "." + svc)
+		require.NoError(t, err)
+		require.Len(t, didDoc.Service, 2)
+		require.Equal(t, "#service", didDoc.Service[0].ID)
+		require.Equal(t, "#service-1", didDoc.Service[1].ID)
+	})
+
+	t.Run("test read invalid peer DIDs", func(t *testing.T) {
+		v, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		for _, tc := range []struct {
+			did    string
+			errMsg string
+		}{
+			{did: "did:peer:2.", errMsg: "empty element"},
+			{did: "did:peer:2.Vz6Mk", errMsg: "invalid key Vz6Mk"},
+			{did: "did:peer:2.X" + ed25519FP, errMsg: "unsupported purpose code 'X'"},
+			{did: "did:peer:2.S!", errMsg: "decode service"},
+			{did: "did:peer:2.S" + encodeTestService(t, "{"), errMsg: "unmarshal service"},
+		} {
+			_, err = v.Read(tc.did)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "resolve numalgo 2 peer DID : "+tc.errMsg)
+		}
+	})
+
+	t.Run("test build with unsupported key", func(t *testing.T) {
+		v, err := New(storage.NewMockStoreProvider(), WithNumAlgo(NumAlgo2))
+		require.NoError(t, err)
+
+		_, err = v.Build(&api.PubKey{Value: ed25519PubKey, Type: keyType})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create peer DID")
+	})
+}
+
+func TestWithNumAlgo(t *testing.T) {
+	v, err := New(storage.NewMockStoreProvider())
+	require.NoError(t, err)
+	require.Equal(t, NumAlgo1, v.numAlgo)
+
+	_, err = New(storage.NewMockStoreProvider(), WithNumAlgo(3))
+	require.EqualError(t, err, "unsupported numalgo 3")
+}
+
+func encodeTestService(t *testing.T, svc string) string {
+	t.Helper()
+
+	return base64.RawURLEncoding.EncodeToString([]byte(svc))
+}
@@ -7,23 +7,173 @@ SPDX-License-Identifier: Apache-2.0
 package peer
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
 )
 
+// statelessVersionID is the single version of the numalgo 0 and 2 peer DIDs
+const statelessVersionID = 1
+
 // Read implements didresolver.DidMethod.Read interface (https://w3c-ccg.github.io/did-resolution/#resolving-input)
-func (v *VDRI) Read(didID string, _ ...vdriapi.ResolveOpts) (*did.Doc, error) {
-	// get the document from the store
-	doc, err := v.Get(didID)
+func (v *VDRI) Read(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error) {
+	result, err := v.ReadResult(didID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.DIDDocument, nil
+}
+
+// ReadResult resolves the DID resolution result of the peer DID along with its document metadata.
+// Each stored delta is a version of the document, the version is selected with the VersionID option (the
+// 1-based index of the delta) or the VersionTime option (the latest delta at that time), otherwise the latest
+// version is resolved. The numalgo 0 and 2 peer DIDs have the single version "1".
+func (v *VDRI) ReadResult(didID string, opts ...vdriapi.ResolveOpts) (*vdriapi.DIDResolutionResult, error) {
+	resolveOpts := &vdriapi.ResolveDIDOpts{}
+	// Apply options
+	for _, opt := range opts {
+		opt(resolveOpts)
+	}
+
+	if isStateless(didID) {
+		return readStateless(didID, resolveOpts)
+	}
+
+	if didID == "" {
+		return nil, errors.New("ID is mandatory")
+	}
+
+	deltas, err := v.getDeltas(didID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, fmt.Errorf("%s: %w", didID, vdriapi.ErrNotFound)
+	} else if err != nil {
+		return nil, fmt.Errorf("delta data fetch from store failed: %w", err)
+	}
+
+	if len(deltas) == 0 {
+		return nil, errors.New("delta data fetch from store failed: no delta")
+	}
+
+	index, err := versionIndex(deltas, resolveOpts)
 	if err != nil {
-		return nil, fmt.Errorf("fetching data from store failed: %w", err)
+		return nil, err
+	}
+
+	doc, err := deltas[index].document()
+	if err != nil {
+		return nil, err
+	}
+
+	created := deltas[0].ModifiedAt
+	metadata := vdriapi.DocumentMetadata{
+		Created:     &created,
+		VersionID:   strconv.Itoa(index + 1),
+		Deactivated: deltas[index].Deactivated,
+	}
+
+	if index > 0 {
+		updated := deltas[index].ModifiedAt
+		metadata.Updated = &updated
+	}
+
+	return &vdriapi.DIDResolutionResult{
+		DIDDocument:        doc,
+		ResolutionMetadata: vdriapi.ResolutionMetadata{ContentType: vdriapi.DIDDocumentContentType},
+		DocumentMetadata:   metadata,
+	}, nil
+}
+
+func readStateless(didID string, opts *vdriapi.ResolveDIDOpts) (*vdriapi.DIDResolutionResult, error) {
+	var (
+		doc *did.Doc
+		err error
+	)
+
+	if strings.HasPrefix(didID, numAlgo0Prefix) {
+		doc, err = resolveNumAlgo0(didID)
+		if err != nil {
+			return nil, fmt.Errorf("resolve numalgo 0 peer DID : %w", err)
+		}
+	} else {
+		doc, err = resolveNumAlgo2(didID)
+		if err != nil {
+			return nil, fmt.Errorf("resolve numalgo 2 peer DID : %w", err)
+		}
+	}
+
+	if opts.VersionID != nil {
+		version, e := versionNumber(opts.VersionID)
+		if e != nil {
+			return nil, e
+		}
+
+		if version != statelessVersionID {
+			return nil, fmt.Errorf("version %d of %s: %w", version, didID, vdriapi.ErrNotFound)
+		}
+	}
+
+	return &vdriapi.DIDResolutionResult{
+		DIDDocument:        doc,
+		ResolutionMetadata: vdriapi.ResolutionMetadata{ContentType: vdriapi.DIDDocumentContentType},
+		DocumentMetadata:   vdriapi.DocumentMetadata{VersionID: strconv.Itoa(statelessVersionID)},
+	}, nil
+}
+
+// versionIndex returns the index of the delta of the version requested by opts, the latest one by default
+func versionIndex(deltas []docDelta, opts *vdriapi.ResolveDIDOpts) (int, error) {
+	if opts.VersionID != nil {
+		version, err := versionNumber(opts.VersionID)
+		if err != nil {
+			return 0, err
+		}
+
+		if version < 1 || version > len(deltas) {
+			return 0, fmt.Errorf("version %d: %w", version, vdriapi.ErrNotFound)
+		}
+
+		return version - 1, nil
 	}
 
-	if doc == nil {
-		return nil, vdriapi.ErrNotFound
+	index := len(deltas) - 1
+
+	if opts.VersionTime != "" {
+		versionTime, err := time.Parse(time.RFC3339, opts.VersionTime)
+		if err != nil {
+			return 0, fmt.Errorf("invalid version time: %w", err)
+		}
+
+		for index >= 0 && deltas[index].ModifiedAt.After(versionTime) {
+			index--
+		}
+
+		if index < 0 {
+			return 0, fmt.Errorf("version at %s: %w", opts.VersionTime, vdriapi.ErrNotFound)
+		}
 	}
 
-	return doc, nil
+	return index, nil
+}
+
+// versionNumber returns the version number of the VersionID option, either an integer or its string
+func versionNumber(versionID interface{}) (int, error) {
+	switch id := versionID.(type) {
+	case int:
+		return id, nil
+	case string:
+		version, err := strconv.Atoi(id)
+		if err != nil {
+			return 0, fmt.Errorf("invalid version ID '%s': %w", id, err)
+		}
+
+		return version, nil
+	default:
+		return 0, fmt.Errorf("invalid version ID type %T", versionID)
+	}
 }
@@ -7,11 +7,17 @@ SPDX-License-Identifier: Apache-2.0
 package peer
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 )
 
@@ -39,4 +45,141 @@ func TestPeerDIDResolver(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "ID is mandatory")
 	})
+	t.Run("test unknown DID", func(t *testing.T) {
+		vdri, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+		_, err = vdri.Read(peerDID)
+		require.True(t, errors.Is(err, vdriapi.ErrNotFound))
+		require.EqualError(t, err, "did:peer:1234: DID not found")
+	})
+}
+
+func TestPeerDIDResolver_ReadResult(t *testing.T) {
+	context := []string{"https://w3id.org/did/v1"}
+	genesis := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+	update := genesis.Add(time.Hour)
+
+	vdri, err := New(storage.NewMockStoreProvider())
+	require.NoError(t, err)
+
+	var deltas []docDelta
+
+	for i, modifiedAt := range []time.Time{genesis, update} {
+		doc := &did.Doc{Context: context, ID: peerDID, Service: []did.Service{{
+			ID: fmt.Sprintf("%s#service-%d", peerDID, i), Type: "did-communication", ServiceEndpoint: "https://example.com",
+		}}}
+
+		jsonDoc, e := doc.JSONBytes()
+		require.NoError(t, e)
+
+		deltas = append(deltas, docDelta{Change: base64.URLEncoding.EncodeToString(jsonDoc), ModifiedAt: modifiedAt})
+	}
+
+	val, err := json.Marshal(deltas)
+	require.NoError(t, err)
+	require.NoError(t, vdri.store.Put(peerDID, val))
+
+	t.Run("test latest version", func(t *testing.T) {
+		result, err := vdri.ReadResult(peerDID)
+		require.NoError(t, err)
+		require.Equal(t, peerDID+"#service-1", result.DIDDocument.Service[0].ID)
+		require.Equal(t, vdriapi.DIDDocumentContentType, result.ResolutionMetadata.ContentType)
+		require.Equal(t, "2", result.DocumentMetadata.VersionID)
+		require.True(t, genesis.Equal(*result.DocumentMetadata.Created))
+		require.True(t, update.Equal(*result.DocumentMetadata.Updated))
+	})
+
+	t.Run("test version ID", func(t *testing.T) {
+		for _, versionID := range []interface{}{1, "1"} {
+			result, err := vdri.ReadResult(peerDID, vdriapi.WithVersionID(versionID))
+			require.NoError(t, err)
+			require.Equal(t, peerDID+"#service-0", result.DIDDocument.Service[0].ID)
+			require.Equal(t, "1", result.DocumentMetadata.VersionID)
+			require.Nil(t, result.DocumentMetadata.Updated)
+		}
+
+		doc, err := vdri.Read(peerDID, vdriapi.WithVersionID(2))
+		require.NoError(t, err)
+		require.Equal(t, peerDID+"#service-1", doc.Service[0].ID)
+	})
+
+	t.Run("test version time", func(t *testing.T) {
+		result, err := vdri.ReadResult(peerDID, vdriapi.WithVersionTime(genesis.Add(time.Minute)))
+		require.NoError(t, err)
+		require.Equal(t, "1", result.DocumentMetadata.VersionID)
+
+		result, err = vdri.ReadResult(peerDID, vdriapi.WithVersionTime(update))
+		require.NoError(t, err)
+		require.Equal(t, "2", result.DocumentMetadata.VersionID)
+	})
+
+	t.Run("test version not found", func(t *testing.T) {
+		for _, opt := range []vdriapi.ResolveOpts{
+			vdriapi.WithVersionID(0),
+			vdriapi.WithVersionID("3"),
+			vdriapi.WithVersionTime(genesis.Add(-time.Minute)),
+		} {
+			_, err := vdri.ReadResult(peerDID, opt)
+			require.True(t, errors.Is(err, vdriapi.ErrNotFound))
+		}
+	})
+
+	t.Run("test invalid version", func(t *testing.T) {
+		_, err := vdri.ReadResult(peerDID, vdriapi.WithVersionID("first"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid version ID 'first'")
+
+		_, err = vdri.ReadResult(peerDID, vdriapi.WithVersionID(1.5))
+		require.EqualError(t, err, "invalid version ID type float64")
+
+		_, err = vdri.ReadResult(peerDID, func(opts *vdriapi.ResolveDIDOpts) {
+			opts.VersionTime = "yesterday"
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid version time")
+	})
+
+	t.Run("test invalid delta", func(t *testing.T) {
+		require.NoError(t, vdri.store.Put("did:peer:invalid", []byte(`[{"change":"!"}]`)))
+
+		_, err := vdri.ReadResult("did:peer:invalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decoding of document delta failed")
+
+		require.NoError(t, vdri.store.Put("did:peer:empty", []byte(`[]`)))
+
+		_, err = vdri.ReadResult("did:peer:empty")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no delta")
+	})
+}
+
+func TestPeerDIDResolver_ReadResultStateless(t *testing.T) {
+	vdri, err := New(storage.NewMockStoreProvider(), WithNumAlgo(NumAlgo2))
+	require.NoError(t, err)
+
+	doc, err := vdri.Build(&vdriapi.PubKey{Type: ed25519KeyType, Value: ed25519PubKey})
+	require.NoError(t, err)
+
+	result, err := vdri.ReadResult(doc.ID)
+	require.NoError(t, err)
+	require.Equal(t, doc.ID, result.DIDDocument.ID)
+	require.Equal(t, "1", result.DocumentMetadata.VersionID)
+
+	_, err = vdri.ReadResult(doc.ID, vdriapi.WithVersionID("1"))
+	require.NoError(t, err)
+
+	_, err = vdri.ReadResult(doc.ID, vdriapi.WithVersionID(2))
+	require.True(t, errors.Is(err, vdriapi.ErrNotFound))
+
+	_, err = vdri.ReadResult(doc.ID, vdriapi.WithVersionID("two"))
+	require.Error(t, err)
+
+	_, err = vdri.ReadResult(numAlgo2Prefix + "invalid")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "resolve numalgo 2 peer DID")
+
+	_, err = vdri.ReadResult(numAlgo0Prefix + "invalid")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "resolve numalgo 0 peer DID")
 }
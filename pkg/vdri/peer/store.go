@@ -15,23 +15,40 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
 )
 
 type docDelta struct {
-	Change     string                `json:"change,omitempty"`
-	ModifiedBy *[]vdriapi.ModifiedBy `json:"by,omitempty"`
-	ModifiedAt time.Time             `json:"when,omitempty"`
+	Change      string                `json:"change,omitempty"`
+	ModifiedBy  *[]vdriapi.ModifiedBy `json:"by,omitempty"`
+	ModifiedAt  time.Time             `json:"when,omitempty"`
+	Deactivated bool                  `json:"deactivated,omitempty"`
 }
 
-// Store saves Peer DID Document along with user key/signature.
+// Store saves Peer DID Document along with user key/signature. The first stored document is the genesis
+// version of the DID, the next ones are stored as deltas updating it. The documents of the deactivated DIDs
+// can't be stored. The signatures of the deltas are not verified, the deltas received from the other agents
+// (DID sync protocol) are stored with ApplyDelta, which requires them to be signed by the current DID document.
+// The documents of the numalgo 0 and 2 peer DIDs are derived from the DIDs, so they are not stored.
 func (v *VDRI) Store(doc *did.Doc, by *[]vdriapi.ModifiedBy) error {
 	if doc == nil || doc.ID == "" {
 		return errors.New("DID and document are mandatory")
 	}
 
-	var deltas []docDelta
+	if isStateless(doc.ID) {
+		return nil
+	}
+
+	deltas, err := v.getDeltas(doc.ID)
+	if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		return fmt.Errorf("delta data fetch from store failed: %w", err)
+	}
 
-	// For now, assume the doc is a genesis document
+	if len(deltas) > 0 && deltas[len(deltas)-1].Deactivated {
+		return fmt.Errorf("peer DID %s is deactivated", doc.ID)
+	}
+
+	// deltas contain the whole document
 	jsonDoc, err := doc.JSONBytes()
 	if err != nil {
 		return fmt.Errorf("JSON marshalling of document failed: %w", err)
@@ -43,17 +60,20 @@ func (v *VDRI) Store(doc *did.Doc, by *[]vdriapi.ModifiedBy) error {
 		ModifiedAt: time.Now(),
 	}
 
-	deltas = append(deltas, *docDelta)
+	return v.putDeltas(doc.ID, append(deltas, *docDelta))
+}
 
+// putDeltas stores the deltas of the peer DID id
+func (v *VDRI) putDeltas(id string, deltas []docDelta) error {
 	val, err := json.Marshal(deltas)
 	if err != nil {
 		return fmt.Errorf("JSON marshalling of document deltas failed: %w", err)
 	}
 
-	return v.store.Put(doc.ID, val)
+	return v.store.Put(id, val)
 }
 
-// Get returns Peer DID Document
+// Get returns Peer DID Document, with the latest delta applied
 func (v *VDRI) Get(id string) (*did.Doc, error) {
 	if id == "" {
 		return nil, errors.New("ID is mandatory")
@@ -64,14 +84,30 @@ func (v *VDRI) Get(id string) (*did.Doc, error) {
 		return nil, fmt.Errorf("delta data fetch from store failed: %w", err)
 	}
 
-	// For now, assume storage contains only one delta(genesis document)
-	delta := deltas[0]
+	if len(deltas) == 0 {
+		return nil, errors.New("delta data fetch from store failed: no delta")
+	}
 
-	doc, err := base64.URLEncoding.DecodeString(delta.Change)
+	return deltas[len(deltas)-1].document()
+}
+
+// decodedChange decodes the JSON document of the delta
+func (d *docDelta) decodedChange() ([]byte, error) {
+	doc, err := base64.URLEncoding.DecodeString(d.Change)
 	if err != nil {
 		return nil, fmt.Errorf("decoding of document delta failed: %w", err)
 	}
 
+	return doc, nil
+}
+
+// document decodes the document of the delta
+func (d *docDelta) document() (*did.Doc, error) {
+	doc, err := d.decodedChange()
+	if err != nil {
+		return nil, err
+	}
+
 	document, err := did.ParseDocument(doc)
 	if err != nil {
 		return nil, fmt.Errorf("document ParseDocument() failed: %w", err)
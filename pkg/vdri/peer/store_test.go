@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 )
 
@@ -70,6 +71,68 @@ func TestPeerDIDStore(t *testing.T) {
 	require.Contains(t, err.Error(), "delta data fetch from store failed")
 }
 
+func TestPeerDIDStore_Update(t *testing.T) {
+	store, err := New(storage.NewMockStoreProvider())
+	require.NoError(t, err)
+
+	context := []string{"https://w3id.org/did/v1"}
+	peerDID := "did:peer:1234"
+
+	err = store.Store(&did.Doc{Context: context, ID: peerDID}, nil)
+	require.NoError(t, err)
+
+	updated := &did.Doc{Context: context, ID: peerDID, Service: []did.Service{{
+		ID: "#agent", Type: "type", ServiceEndpoint: "https://example.com",
+	}}}
+	by := &[]vdriapi.ModifiedBy{{Key: "key", Sig: "sig"}}
+
+	err = store.Store(updated, by)
+	require.NoError(t, err)
+
+	// the latest delta is applied
+	doc, err := store.Get(peerDID)
+	require.NoError(t, err)
+	require.Len(t, doc.Service, 1)
+	require.Equal(t, "https://example.com", doc.Service[0].ServiceEndpoint)
+
+	deltas, err := store.getDeltas(peerDID)
+	require.NoError(t, err)
+	require.Len(t, deltas, 2)
+	require.Nil(t, deltas[0].ModifiedBy)
+	require.Equal(t, by, deltas[1].ModifiedBy)
+
+	t.Run("test store of deactivated DID", func(t *testing.T) {
+		require.NoError(t, store.putDeltas(peerDID, append(deltas, docDelta{
+			Change:      deltas[0].Change,
+			ModifiedBy:  by,
+			Deactivated: true,
+		})))
+
+		err = store.Store(updated, by)
+		require.EqualError(t, err, "peer DID did:peer:1234 is deactivated")
+	})
+
+	t.Run("test store fails to get deltas", func(t *testing.T) {
+		s, err := New(&storage.MockStoreProvider{Store: &storage.MockStore{
+			Store:  map[string][]byte{},
+			ErrGet: fmt.Errorf("get error"),
+		}})
+		require.NoError(t, err)
+
+		err = s.Store(updated, by)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get error")
+	})
+
+	t.Run("test get without deltas", func(t *testing.T) {
+		require.NoError(t, store.store.Put("did:peer:empty", []byte("[]")))
+
+		_, err = store.Get("did:peer:empty")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no delta")
+	})
+}
+
 func TestVDRI_Close(t *testing.T) {
 	t.Run("test success", func(t *testing.T) {
 		v, err := New(&storage.MockStoreProvider{})
@@ -0,0 +1,328 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+const ed25519KeyType = "Ed25519VerificationKey2018"
+
+// deactivationMarker prefixes the change signed by a deactivation, the signatures of the updates and the
+// deactivations can't be replayed as each other
+const deactivationMarker = "deactivate:"
+
+// Update stores doc as a new version of the peer DID, in a delta signed by the first Ed25519 authentication
+// key of the current DID document (see WithSigner). The deltas have the format of the DID sync protocol.
+// The update is stored locally only, the other agents of the relationship are not notified: the DIDs of
+// the connections are updated with the DID sync protocol (didsync.Service.UpdateDID).
+// The numalgo 0 and 2 peer DIDs can't be updated, their documents are derived from the DIDs.
+func (v *VDRI) Update(doc *did.Doc, opts ...vdriapi.UpdateOpts) error {
+	if doc == nil || doc.ID == "" {
+		return errors.New("DID and document are mandatory")
+	}
+
+	deltas, err := v.activeDeltas(doc.ID)
+	if err != nil {
+		return fmt.Errorf("update peer DID: %w", err)
+	}
+
+	change, err := doc.JSONBytes()
+	if err != nil {
+		return fmt.Errorf("update peer DID: JSON marshalling of document failed: %w", err)
+	}
+
+	by, err := signChange(&deltas[len(deltas)-1], change, false, opts)
+	if err != nil {
+		return fmt.Errorf("update peer DID: %w", err)
+	}
+
+	return v.putDeltas(doc.ID, append(deltas, docDelta{
+		Change:     base64.URLEncoding.EncodeToString(change),
+		ModifiedBy: by,
+		ModifiedAt: time.Now(),
+	}))
+}
+
+// Deactivate deactivates the peer DID with a delta signed by the first Ed25519 authentication key of the
+// current DID document (see WithSigner). The delta keeps the current document, which is resolved with the
+// deactivated metadata, and the DID can't be updated anymore. As for Update, the other agents of the
+// relationship are not notified: the DIDs of the connections are deactivated with the DID sync protocol
+// (didsync.Service.DeactivateDID).
+func (v *VDRI) Deactivate(didID string, opts ...vdriapi.UpdateOpts) error {
+	deltas, err := v.activeDeltas(didID)
+	if err != nil {
+		return fmt.Errorf("deactivate peer DID: %w", err)
+	}
+
+	latest := &deltas[len(deltas)-1]
+
+	change, err := latest.decodedChange()
+	if err != nil {
+		return fmt.Errorf("deactivate peer DID: %w", err)
+	}
+
+	by, err := signChange(latest, change, true, opts)
+	if err != nil {
+		return fmt.Errorf("deactivate peer DID: %w", err)
+	}
+
+	return v.putDeltas(didID, append(deltas, docDelta{
+		Change:      latest.Change,
+		ModifiedBy:  by,
+		ModifiedAt:  time.Now(),
+		Deactivated: true,
+	}))
+}
+
+// SignDelta signs change, the JSON of the document updating the peer DID didID, with the first Ed25519
+// authentication key of the current DID document (see WithSigner). The returned signatures are those of
+// the delta stored with ApplyDelta, e.g. once it was sent to the other agents of the relationship.
+func (v *VDRI) SignDelta(didID string, change []byte, opts ...vdriapi.UpdateOpts) ([]vdriapi.ModifiedBy, error) {
+	deltas, err := v.activeDeltas(didID)
+	if err != nil {
+		return nil, err
+	}
+
+	by, err := signChange(&deltas[len(deltas)-1], change, false, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return *by, nil
+}
+
+// SignDeactivation signs the deactivation of the peer DID didID with the first Ed25519 authentication key of
+// the current DID document (see WithSigner). The returned signatures are those of the deactivation stored with
+// ApplyDeactivation, e.g. once it was sent to the other agents of the relationship.
+func (v *VDRI) SignDeactivation(didID string, opts ...vdriapi.UpdateOpts) ([]vdriapi.ModifiedBy, error) {
+	deltas, err := v.activeDeltas(didID)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := &deltas[len(deltas)-1]
+
+	change, err := latest.decodedChange()
+	if err != nil {
+		return nil, err
+	}
+
+	by, err := signChange(latest, change, true, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return *by, nil
+}
+
+// ApplyDelta stores the delta updating the peer DID didID, e.g. received from the DID subject with the DID sync
+// protocol. The change is the base64url encoded JSON of the updated document, signed by Ed25519 authentication
+// keys of the current DID document. The updated document is returned.
+func (v *VDRI) ApplyDelta(didID, change string, by []vdriapi.ModifiedBy) (*did.Doc, error) {
+	deltas, err := v.activeDeltas(didID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := deltas[len(deltas)-1].document()
+	if err != nil {
+		return nil, err
+	}
+
+	jsonDoc, err := base64.URLEncoding.DecodeString(change)
+	if err != nil {
+		return nil, fmt.Errorf("decode delta change: %w", err)
+	}
+
+	doc, err := did.ParseDocument(jsonDoc)
+	if err != nil {
+		return nil, fmt.Errorf("parse delta change: %w", err)
+	}
+
+	if doc.ID != didID {
+		return nil, fmt.Errorf("delta change of DID %s updates DID %s", didID, doc.ID)
+	}
+
+	if err = verifyChange(current, jsonDoc, false, by); err != nil {
+		return nil, err
+	}
+
+	err = v.putDeltas(didID, append(deltas, docDelta{
+		Change:     change,
+		ModifiedBy: &by,
+		ModifiedAt: time.Now(),
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// ApplyDeactivation stores the deactivation of the peer DID didID, e.g. received from the DID subject with the
+// DID sync protocol. The deactivation is signed by Ed25519 authentication keys of the current DID document.
+func (v *VDRI) ApplyDeactivation(didID string, by []vdriapi.ModifiedBy) error {
+	deltas, err := v.activeDeltas(didID)
+	if err != nil {
+		return err
+	}
+
+	latest := &deltas[len(deltas)-1]
+
+	current, err := latest.document()
+	if err != nil {
+		return err
+	}
+
+	change, err := latest.decodedChange()
+	if err != nil {
+		return err
+	}
+
+	if err = verifyChange(current, change, true, by); err != nil {
+		return err
+	}
+
+	return v.putDeltas(didID, append(deltas, docDelta{
+		Change:      latest.Change,
+		ModifiedBy:  &by,
+		ModifiedAt:  time.Now(),
+		Deactivated: true,
+	}))
+}
+
+// activeDeltas returns the deltas of the peer DID didID, which must not be deactivated
+func (v *VDRI) activeDeltas(didID string) ([]docDelta, error) {
+	if didID == "" {
+		return nil, errors.New("ID is mandatory")
+	}
+
+	if isStateless(didID) {
+		return nil, fmt.Errorf("%s: %w", didID, vdriapi.ErrNotSupported)
+	}
+
+	deltas, err := v.getDeltas(didID)
+	if err != nil {
+		return nil, fmt.Errorf("delta data fetch from store failed: %w", err)
+	}
+
+	if len(deltas) == 0 {
+		return nil, errors.New("delta data fetch from store failed: no delta")
+	}
+
+	if deltas[len(deltas)-1].Deactivated {
+		return nil, fmt.Errorf("peer DID %s is deactivated", didID)
+	}
+
+	return deltas, nil
+}
+
+// signChange signs the change, or its deactivation, with the first Ed25519 authentication key of the document
+// of the latest delta
+func signChange(latest *docDelta, change []byte, deactivated bool,
+	opts []vdriapi.UpdateOpts) (*[]vdriapi.ModifiedBy, error) {
+	updateOpts := &vdriapi.UpdateDIDOpts{}
+	// Apply options
+	for _, opt := range opts {
+		opt(updateOpts)
+	}
+
+	if updateOpts.Signer == nil {
+		return nil, errors.New("signer is mandatory")
+	}
+
+	current, err := latest.document()
+	if err != nil {
+		return nil, err
+	}
+
+	verKeys := authenticationKeys(current)
+	if len(verKeys) == 0 {
+		return nil, errors.New("current DID document has no authentication key")
+	}
+
+	verKey := verKeys[0]
+
+	sig, err := updateOpts.Signer.SignMessage(signedPayload(change, deactivated), verKey)
+	if err != nil {
+		return nil, fmt.Errorf("sign delta: %w", err)
+	}
+
+	return &[]vdriapi.ModifiedBy{{Key: verKey, Sig: base64.URLEncoding.EncodeToString(sig)}}, nil
+}
+
+// verifyChange verifies the signatures of the change, or of its deactivation, made by Ed25519 authentication
+// keys of the current DID document
+func verifyChange(current *did.Doc, change []byte, deactivated bool, by []vdriapi.ModifiedBy) error {
+	if len(by) == 0 {
+		return errors.New("delta is not signed")
+	}
+
+	verKeys := authenticationKeys(current)
+	payload := signedPayload(change, deactivated)
+
+	for _, modifiedBy := range by {
+		if !contains(verKeys, modifiedBy.Key) {
+			return fmt.Errorf("delta signed by key %s not authenticating DID %s", modifiedBy.Key, current.ID)
+		}
+
+		sig, err := base64.URLEncoding.DecodeString(modifiedBy.Sig)
+		if err != nil {
+			return fmt.Errorf("decode delta signature: %w", err)
+		}
+
+		if !ed25519.Verify(base58.Decode(modifiedBy.Key), payload, sig) {
+			return errors.New("delta signature verification failed")
+		}
+	}
+
+	return nil
+}
+
+// signedPayload returns the payload signed by the delta of the change, the change of a deactivation is
+// prefixed with the deactivation marker
+func signedPayload(change []byte, deactivated bool) []byte {
+	if !deactivated {
+		return change
+	}
+
+	return append([]byte(deactivationMarker), change...)
+}
+
+// authenticationKeys returns the base58 encoded Ed25519 authentication keys of doc
+func authenticationKeys(doc *did.Doc) []string {
+	var keys []string
+
+	for _, vm := range doc.Authentication {
+		if vm.PublicKey.Type == ed25519KeyType && len(vm.PublicKey.Value) == ed25519.PublicKeySize {
+			// TODO fix hardcode base58 https://github.com/hyperledger/aries-framework-go/issues/1207
+			keys = append(keys, base58.Encode(vm.PublicKey.Value))
+		}
+	}
+
+	return keys
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
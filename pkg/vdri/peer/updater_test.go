@@ -0,0 +1,266 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/internal/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/legacykms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms/legacykms"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+func TestPeerDIDUpdater(t *testing.T) {
+	kms, err := legacykms.New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()})
+	require.NoError(t, err)
+
+	// newDID builds and stores a numalgo 1 peer DID authenticated by a key of the KMS
+	newDID := func(t *testing.T, v *VDRI) *did.Doc {
+		_, verKey, err := kms.CreateKeySet()
+		require.NoError(t, err)
+
+		didDoc, err := v.Build(&vdriapi.PubKey{Value: verKey, Type: ed25519KeyType},
+			vdriapi.WithServiceType(vdriapi.DIDCommServiceType), vdriapi.WithServiceEndpoint("https://example.com"))
+		require.NoError(t, err)
+		require.NoError(t, v.Store(didDoc, nil))
+
+		return didDoc
+	}
+
+	t.Run("test update", func(t *testing.T) {
+		v, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		didDoc := newDID(t, v)
+		didDoc.Service[0].ServiceEndpoint = "https://example.com/updated"
+
+		require.NoError(t, v.Update(didDoc, vdriapi.WithSigner(kms)))
+
+		result, err := v.ReadResult(didDoc.ID)
+		require.NoError(t, err)
+		require.Equal(t, "2", result.DocumentMetadata.VersionID)
+		require.Equal(t, "https://example.com/updated", result.DIDDocument.Service[0].ServiceEndpoint)
+
+		// the delta is signed by the authentication key of the previous version
+		deltas, err := v.getDeltas(didDoc.ID)
+		require.NoError(t, err)
+		require.Len(t, deltas, 2)
+		require.Len(t, *deltas[1].ModifiedBy, 1)
+
+		by := (*deltas[1].ModifiedBy)[0]
+		require.Equal(t, base58.Encode(didDoc.Authentication[0].PublicKey.Value), by.Key)
+
+		change, err := base64.URLEncoding.DecodeString(deltas[1].Change)
+		require.NoError(t, err)
+
+		sig, err := base64.URLEncoding.DecodeString(by.Sig)
+		require.NoError(t, err)
+		require.True(t, ed25519.Verify(base58.Decode(by.Key), change, sig))
+	})
+
+	t.Run("test deactivate", func(t *testing.T) {
+		v, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		didDoc := newDID(t, v)
+
+		require.NoError(t, v.Deactivate(didDoc.ID, vdriapi.WithSigner(kms)))
+
+		result, err := v.ReadResult(didDoc.ID)
+		require.NoError(t, err)
+		require.True(t, result.DocumentMetadata.Deactivated)
+		require.Equal(t, "2", result.DocumentMetadata.VersionID)
+		require.Equal(t, didDoc.Service[0].ServiceEndpoint, result.DIDDocument.Service[0].ServiceEndpoint)
+
+		// the previous version is not deactivated
+		result, err = v.ReadResult(didDoc.ID, vdriapi.WithVersionID("1"))
+		require.NoError(t, err)
+		require.False(t, result.DocumentMetadata.Deactivated)
+
+		// the deactivation signs the current document prefixed with the deactivation marker
+		deltas, err := v.getDeltas(didDoc.ID)
+		require.NoError(t, err)
+		require.Len(t, deltas, 2)
+
+		change, err := base64.URLEncoding.DecodeString(deltas[1].Change)
+		require.NoError(t, err)
+
+		by := (*deltas[1].ModifiedBy)[0]
+
+		sig, err := base64.URLEncoding.DecodeString(by.Sig)
+		require.NoError(t, err)
+		require.False(t, ed25519.Verify(base58.Decode(by.Key), change, sig))
+		require.True(t, ed25519.Verify(base58.Decode(by.Key), append([]byte(deactivationMarker), change...), sig))
+
+		err = v.Update(didDoc, vdriapi.WithSigner(kms))
+		require.EqualError(t, err, "update peer DID: peer DID "+didDoc.ID+" is deactivated")
+
+		err = v.Deactivate(didDoc.ID, vdriapi.WithSigner(kms))
+		require.EqualError(t, err, "deactivate peer DID: peer DID "+didDoc.ID+" is deactivated")
+	})
+
+	t.Run("test sign and apply delta", func(t *testing.T) {
+		v, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		didDoc := newDID(t, v)
+		didDoc.Service[0].ServiceEndpoint = "https://example.com/updated"
+
+		change, err := didDoc.JSONBytes()
+		require.NoError(t, err)
+
+		by, err := v.SignDelta(didDoc.ID, change, vdriapi.WithSigner(kms))
+		require.NoError(t, err)
+		require.Len(t, by, 1)
+
+		_, err = v.ApplyDelta(didDoc.ID, base64.URLEncoding.EncodeToString(change), nil)
+		require.EqualError(t, err, "delta is not signed")
+
+		_, err = v.ApplyDelta(didDoc.ID, base64.URLEncoding.EncodeToString(change),
+			[]vdriapi.ModifiedBy{{Key: by[0].Key, Sig: base64.URLEncoding.EncodeToString(change[:64])}})
+		require.EqualError(t, err, "delta signature verification failed")
+
+		doc, err := v.ApplyDelta(didDoc.ID, base64.URLEncoding.EncodeToString(change), by)
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/updated", doc.Service[0].ServiceEndpoint)
+
+		result, err := v.ReadResult(didDoc.ID)
+		require.NoError(t, err)
+		require.Equal(t, "2", result.DocumentMetadata.VersionID)
+		require.Equal(t, "https://example.com/updated", result.DIDDocument.Service[0].ServiceEndpoint)
+
+		// the deactivated DID can't be changed anymore
+		require.NoError(t, v.Deactivate(didDoc.ID, vdriapi.WithSigner(kms)))
+
+		_, err = v.SignDelta(didDoc.ID, change, vdriapi.WithSigner(kms))
+		require.EqualError(t, err, "peer DID "+didDoc.ID+" is deactivated")
+
+		_, err = v.ApplyDelta(didDoc.ID, base64.URLEncoding.EncodeToString(change), by)
+		require.EqualError(t, err, "peer DID "+didDoc.ID+" is deactivated")
+
+		err = v.Store(didDoc, nil)
+		require.EqualError(t, err, "peer DID "+didDoc.ID+" is deactivated")
+	})
+
+	t.Run("test sign and apply deactivation", func(t *testing.T) {
+		v, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		didDoc := newDID(t, v)
+
+		change, err := didDoc.JSONBytes()
+		require.NoError(t, err)
+
+		// the deltas are stored by another agent of the relationship
+		other, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+		require.NoError(t, other.Store(didDoc, nil))
+
+		by, err := v.SignDeactivation(didDoc.ID, vdriapi.WithSigner(kms))
+		require.NoError(t, err)
+		require.Len(t, by, 1)
+
+		err = other.ApplyDeactivation(didDoc.ID, nil)
+		require.EqualError(t, err, "delta is not signed")
+
+		// the signature of an update can't be replayed as a deactivation
+		updateBy, err := v.SignDelta(didDoc.ID, change, vdriapi.WithSigner(kms))
+		require.NoError(t, err)
+
+		err = other.ApplyDeactivation(didDoc.ID, updateBy)
+		require.EqualError(t, err, "delta signature verification failed")
+
+		// the signature of a deactivation can't be replayed as an update
+		_, err = other.ApplyDelta(didDoc.ID, base64.URLEncoding.EncodeToString(change), by)
+		require.EqualError(t, err, "delta signature verification failed")
+
+		require.NoError(t, other.ApplyDeactivation(didDoc.ID, by))
+
+		result, err := other.ReadResult(didDoc.ID)
+		require.NoError(t, err)
+		require.True(t, result.DocumentMetadata.Deactivated)
+		require.Equal(t, "2", result.DocumentMetadata.VersionID)
+
+		err = other.ApplyDeactivation(didDoc.ID, by)
+		require.EqualError(t, err, "peer DID "+didDoc.ID+" is deactivated")
+
+		_, err = other.SignDeactivation(didDoc.ID, vdriapi.WithSigner(kms))
+		require.EqualError(t, err, "peer DID "+didDoc.ID+" is deactivated")
+
+		_, err = v.SignDeactivation(didDoc.ID)
+		require.EqualError(t, err, "signer is mandatory")
+	})
+
+	t.Run("test update errors", func(t *testing.T) {
+		v, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		didDoc := newDID(t, v)
+
+		err = v.Update(nil)
+		require.EqualError(t, err, "DID and document are mandatory")
+
+		err = v.Update(didDoc)
+		require.EqualError(t, err, "update peer DID: signer is mandatory")
+
+		err = v.Update(didDoc, vdriapi.WithSigner(&mockkms.CloseableKMS{SignMessageErr: errors.New("sign error")}))
+		require.EqualError(t, err, "update peer DID: sign delta: sign error")
+
+		err = v.Update(&did.Doc{ID: "did:peer:123"}, vdriapi.WithSigner(kms))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "update peer DID: delta data fetch from store failed")
+	})
+
+	t.Run("test deactivate errors", func(t *testing.T) {
+		v, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		err = v.Deactivate("")
+		require.EqualError(t, err, "deactivate peer DID: ID is mandatory")
+
+		err = v.Deactivate("did:peer:123", vdriapi.WithSigner(kms))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "deactivate peer DID: delta data fetch from store failed")
+	})
+
+	t.Run("test update of document without authentication key", func(t *testing.T) {
+		v, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		didDoc := &did.Doc{Context: []string{did.Context}, ID: peerDID}
+		require.NoError(t, v.Store(didDoc, nil))
+
+		err = v.Update(didDoc, vdriapi.WithSigner(kms))
+		require.EqualError(t, err, "update peer DID: current DID document has no authentication key")
+	})
+
+	t.Run("test update of stateless peer DID", func(t *testing.T) {
+		v, err := New(storage.NewMockStoreProvider(), WithNumAlgo(NumAlgo0))
+		require.NoError(t, err)
+
+		didDoc, err := v.Build(&vdriapi.PubKey{Value: ed25519PubKey, Type: ed25519KeyType})
+		require.NoError(t, err)
+
+		err = v.Update(didDoc, vdriapi.WithSigner(kms))
+		require.Error(t, err)
+		require.True(t, errors.Is(err, vdriapi.ErrNotSupported))
+
+		err = v.Deactivate(didDoc.ID, vdriapi.WithSigner(kms))
+		require.Error(t, err)
+		require.True(t, errors.Is(err, vdriapi.ErrNotSupported))
+	})
+}
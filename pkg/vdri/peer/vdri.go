@@ -19,17 +19,39 @@ const (
 
 // VDRI implements building new peer dids
 type VDRI struct {
-	store storage.Store
+	store   storage.Store
+	numAlgo int
+}
+
+// Option configures the peer vdri
+type Option func(opts *VDRI)
+
+// WithNumAlgo option is for the numeric algorithm of the peer DIDs built by the vdri (NumAlgo1 by default).
+// The peer DIDs of any numeric algorithm are resolved.
+func WithNumAlgo(numAlgo int) Option {
+	return func(opts *VDRI) {
+		opts.numAlgo = numAlgo
+	}
 }
 
 // New return new instance of peer vdri
-func New(s storage.Provider) (*VDRI, error) {
+func New(s storage.Provider, opts ...Option) (*VDRI, error) {
 	didDBStore, err := s.OpenStore(StoreNamespace)
 	if err != nil {
 		return nil, fmt.Errorf("open store : %w", err)
 	}
 
-	return &VDRI{store: didDBStore}, nil
+	v := &VDRI{store: didDBStore, numAlgo: NumAlgo1}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	if v.numAlgo != NumAlgo0 && v.numAlgo != NumAlgo1 && v.numAlgo != NumAlgo2 {
+		return nil, fmt.Errorf("unsupported numalgo %d", v.numAlgo)
+	}
+
+	return v, nil
 }
 
 // Accept did method
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync/atomic"
 
 	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
@@ -26,19 +27,38 @@ type Option func(opts *Registry)
 // provider contains dependencies for the did creator
 type provider interface {
 	LegacyKMS() legacykms.KeyManager
+	Signer() legacykms.Signer
 }
 
 // Registry vdri registry
 type Registry struct {
 	vdri               []vdriapi.VDRI
 	crypto             legacykms.KeyManager
+	signer             legacykms.Signer
 	defServiceEndpoint string
 	defServiceType     string
+	cache              ResolutionCache
+	cacheHits          uint64
+	cacheMisses        uint64
+}
+
+// CacheStats are the hit and miss counters of the resolution cache of the registry
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+// CacheStatsProvider is implemented by the registries reporting the counters of their resolution cache.
+// The vdriapi.Registry of the framework context is asserted to it to get the counters:
+//
+//	stats := ctx.VDRIRegistry().(vdri.CacheStatsProvider).CacheStats()
+type CacheStatsProvider interface {
+	CacheStats() CacheStats
 }
 
 // New return new instance of vdri
 func New(ctx provider, opts ...Option) *Registry {
-	baseVDRI := &Registry{crypto: ctx.LegacyKMS()}
+	baseVDRI := &Registry{crypto: ctx.LegacyKMS(), signer: ctx.Signer()}
 
 	// Apply options
 	for _, opt := range opts {
@@ -48,7 +68,7 @@ func New(ctx provider, opts ...Option) *Registry {
 	return baseVDRI
 }
 
-// Resolve did document
+// Resolve did document. The DID resolution result, with the metadata, is resolved by ResolveResult.
 func (r *Registry) Resolve(did string, opts ...vdriapi.ResolveOpts) (*diddoc.Doc, error) {
 	resolveOpts := &vdriapi.ResolveDIDOpts{}
 	// Apply options
@@ -56,33 +76,118 @@ func (r *Registry) Resolve(did string, opts ...vdriapi.ResolveOpts) (*diddoc.Doc
 		opt(resolveOpts)
 	}
 
-	didMethod, err := getDidMethod(did)
+	if resolveOpts.ResultType == vdriapi.ResolutionResult {
+		return nil, errors.New("result type 'resolution-result' not supported by Resolve, use ResolveResult")
+	}
+
+	result, err := r.ResolveResult(did, opts...)
 	if err != nil {
 		return nil, err
 	}
 
+	return result.DIDDocument, nil
+}
+
+// ResolveResult resolves the DID resolution result of did, with the metadata of the resolution and of the
+// DID document. When the DID is invalid, not found or its method is not supported, the result holding the
+// error in its resolution metadata is returned along with the error.
+func (r *Registry) ResolveResult(did string, opts ...vdriapi.ResolveOpts) (*vdriapi.DIDResolutionResult, error) {
+	resolveOpts := &vdriapi.ResolveDIDOpts{}
+	// Apply options
+	for _, opt := range opts {
+		opt(resolveOpts)
+	}
+
+	didMethod, err := getDidMethod(did)
+	if err != nil {
+		return errorResult(vdriapi.InvalidDIDError), err
+	}
+
 	// resolve did method
 	method, err := r.resolveVDRI(didMethod)
 	if err != nil {
-		return nil, err
+		return errorResult(vdriapi.MethodNotSupportedError), err
+	}
+
+	// only the latest versions of the DID documents are cached
+	useCache := r.cache != nil && resolveOpts.VersionID == nil && resolveOpts.VersionTime == ""
+
+	if useCache && !resolveOpts.NoCache {
+		if entry, ok := r.cache.Get(did); ok {
+			atomic.AddUint64(&r.cacheHits, 1)
+
+			if entry.NotFound {
+				return errorResult(vdriapi.NotFoundError), vdriapi.ErrNotFound
+			}
+
+			// the cached result is copied so that the callers do not modify it
+			return copyResult(entry.Result)
+		}
+
+		atomic.AddUint64(&r.cacheMisses, 1)
 	}
 
 	// Obtain the DID Document
-	didDoc, err := method.Read(did, opts...)
+	result, err := read(method, did, opts...)
 	if err != nil {
 		if errors.Is(err, vdriapi.ErrNotFound) {
-			return nil, err
+			if useCache {
+				r.cache.Put(did, &CacheEntry{NotFound: true})
+			}
+
+			return errorResult(vdriapi.NotFoundError), err
 		}
 
 		return nil, fmt.Errorf("did method read failed failed: %w", err)
 	}
 
-	if resolveOpts.ResultType == vdriapi.ResolutionResult {
-		// TODO https://github.com/hyperledger/aries-framework-go/issues/745 Support resolution-result
-		return nil, errors.New("result type 'resolution-result' not supported")
+	if useCache && result.DIDDocument != nil {
+		cached, err := copyResult(result)
+		if err != nil {
+			return nil, err
+		}
+
+		r.cache.Put(did, &CacheEntry{Result: cached})
+	}
+
+	return result, nil
+}
+
+// read reads the DID resolution result from the VDRI, the metadata of the DID document is taken from the
+// document itself unless the VDRI resolves it
+func read(method vdriapi.VDRI, did string, opts ...vdriapi.ResolveOpts) (*vdriapi.DIDResolutionResult, error) {
+	if resolver, ok := method.(vdriapi.ResultResolver); ok {
+		return resolver.ReadResult(did, opts...)
+	}
+
+	didDoc, err := method.Read(did, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &vdriapi.DIDResolutionResult{
+		DIDDocument:        didDoc,
+		ResolutionMetadata: vdriapi.ResolutionMetadata{ContentType: vdriapi.DIDDocumentContentType},
+	}
+
+	if didDoc != nil {
+		result.DocumentMetadata.Created = didDoc.Created
+		result.DocumentMetadata.Updated = didDoc.Updated
 	}
 
-	return didDoc, nil
+	return result, nil
+}
+
+func errorResult(resolutionError string) *vdriapi.DIDResolutionResult {
+	return &vdriapi.DIDResolutionResult{ResolutionMetadata: vdriapi.ResolutionMetadata{Error: resolutionError}}
+}
+
+// CacheStats returns the hit and miss counters of the resolution cache
+func (r *Registry) CacheStats() CacheStats {
+	return CacheStats{
+		Hits:   atomic.LoadUint64(&r.cacheHits),
+		Misses: atomic.LoadUint64(&r.cacheMisses),
+	}
 }
 
 // Create returns new DID Document
@@ -129,7 +234,7 @@ func (r *Registry) applyDefaultDocOpts(docOpts *vdriapi.CreateDIDOpts, opts ...v
 	return opts
 }
 
-// Store did store
+// Store did store, the cached resolution of the DID is invalidated
 func (r *Registry) Store(doc *diddoc.Doc) error {
 	didMethod, err := getDidMethod(doc.ID)
 	if err != nil {
@@ -141,9 +246,60 @@ func (r *Registry) Store(doc *diddoc.Doc) error {
 		return err
 	}
 
+	if r.cache != nil {
+		defer r.cache.Delete(doc.ID)
+	}
+
 	return method.Store(doc, nil)
 }
 
+// Update updates the DID document of doc.ID with doc, the update is signed with the signer of the agent unless
+// the WithSigner option is set. The cached resolution of the DID is invalidated.
+// The updates of peer DIDs are stored locally only: the DIDs of connections are updated with the DID sync
+// protocol (didsync.Service.UpdateDID), which sends the update to the other agent of the connection.
+func (r *Registry) Update(doc *diddoc.Doc, opts ...vdriapi.UpdateOpts) error {
+	method, err := r.methodOf(doc.ID)
+	if err != nil {
+		return err
+	}
+
+	if r.cache != nil {
+		defer r.cache.Delete(doc.ID)
+	}
+
+	return method.Update(doc, r.applyDefaultUpdateOpts(opts)...)
+}
+
+// Deactivate deactivates the DID did, the deactivation is signed with the signer of the agent unless the
+// WithSigner option is set. The cached resolution of the DID is invalidated.
+func (r *Registry) Deactivate(did string, opts ...vdriapi.UpdateOpts) error {
+	method, err := r.methodOf(did)
+	if err != nil {
+		return err
+	}
+
+	if r.cache != nil {
+		defer r.cache.Delete(did)
+	}
+
+	return method.Deactivate(did, r.applyDefaultUpdateOpts(opts)...)
+}
+
+// applyDefaultUpdateOpts sets the signer of the agent before the update options
+func (r *Registry) applyDefaultUpdateOpts(opts []vdriapi.UpdateOpts) []vdriapi.UpdateOpts {
+	return append([]vdriapi.UpdateOpts{vdriapi.WithSigner(r.signer)}, opts...)
+}
+
+// methodOf returns the VDRI of the method of did
+func (r *Registry) methodOf(did string) (vdriapi.VDRI, error) {
+	didMethod, err := getDidMethod(did)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.resolveVDRI(didMethod)
+}
+
 // Close frees resources being maintained by vdri.
 func (r *Registry) Close() error {
 	for _, v := range r.vdri {
@@ -172,6 +328,14 @@ func WithVDRI(method vdriapi.VDRI) Option {
 	}
 }
 
+// WithResolutionCache enables the cache of the resolved DID documents (e.g LRUCache).
+// The cache is bypassed by the resolutions with the no-cache option, which still refresh it.
+func WithResolutionCache(cache ResolutionCache) Option {
+	return func(opts *Registry) {
+		opts.cache = cache
+	}
+}
+
 // WithDefaultServiceType is default service type for this creator
 func WithDefaultServiceType(serviceType string) Option {
 	return func(opts *Registry) {
@@ -7,8 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package vdri
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -16,7 +18,9 @@ import (
 	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/internal/mock/provider"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms/legacykms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/vdri/peer"
 )
 
 func TestRegistry_New(t *testing.T) {
@@ -118,6 +122,217 @@ func TestRegistry_Resolve(t *testing.T) {
 	})
 }
 
+func TestRegistry_ResolveResult(t *testing.T) {
+	t.Run("test invalid did input", func(t *testing.T) {
+		registry := New(&mockprovider.Provider{})
+		result, err := registry.ResolveResult("id")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "wrong format did input")
+		require.Equal(t, vdriapi.InvalidDIDError, result.ResolutionMetadata.Error)
+		require.Nil(t, result.DIDDocument)
+	})
+
+	t.Run("test did method not supported", func(t *testing.T) {
+		registry := New(&mockprovider.Provider{}, WithVDRI(&mockvdri.MockVDRI{AcceptValue: false}))
+		result, err := registry.ResolveResult("1:id:123")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "did method id not supported for vdri")
+		require.Equal(t, vdriapi.MethodNotSupportedError, result.ResolutionMetadata.Error)
+	})
+
+	t.Run("test DID not found", func(t *testing.T) {
+		registry := New(&mockprovider.Provider{}, WithVDRI(&mockvdri.MockVDRI{
+			AcceptValue: true, ReadFunc: func(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error) {
+				return nil, vdriapi.ErrNotFound
+			}}))
+		result, err := registry.ResolveResult("1:id:123")
+		require.True(t, errors.Is(err, vdriapi.ErrNotFound))
+		require.Equal(t, vdriapi.NotFoundError, result.ResolutionMetadata.Error)
+	})
+
+	t.Run("test error from resolve did", func(t *testing.T) {
+		registry := New(&mockprovider.Provider{}, WithVDRI(&mockvdri.MockVDRI{
+			AcceptValue: true, ReadFunc: func(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error) {
+				return nil, fmt.Errorf("read error")
+			}}))
+		result, err := registry.ResolveResult("1:id:123")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "read error")
+		require.Nil(t, result)
+	})
+
+	t.Run("test metadata from document", func(t *testing.T) {
+		created := time.Now().Add(-time.Hour)
+		updated := time.Now()
+
+		registry := New(&mockprovider.Provider{}, WithVDRI(&mockvdri.MockVDRI{
+			AcceptValue: true, ReadFunc: func(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error) {
+				return &did.Doc{ID: didID, Created: &created, Updated: &updated}, nil
+			}}))
+		result, err := registry.ResolveResult("1:id:123")
+		require.NoError(t, err)
+		require.Equal(t, "1:id:123", result.DIDDocument.ID)
+		require.Equal(t, vdriapi.DIDDocumentContentType, result.ResolutionMetadata.ContentType)
+		require.Empty(t, result.ResolutionMetadata.Error)
+		require.Equal(t, &created, result.DocumentMetadata.Created)
+		require.Equal(t, &updated, result.DocumentMetadata.Updated)
+	})
+
+	t.Run("test metadata from vdri", func(t *testing.T) {
+		expected := &vdriapi.DIDResolutionResult{
+			DIDDocument:      &did.Doc{ID: "1:id:123"},
+			DocumentMetadata: vdriapi.DocumentMetadata{VersionID: "2"},
+		}
+
+		registry := New(&mockprovider.Provider{}, WithResolutionCache(NewLRUCache()), WithVDRI(&mockResultVDRI{
+			MockVDRI: mockvdri.MockVDRI{AcceptValue: true},
+			result:   expected,
+		}))
+
+		for i := 0; i < 2; i++ {
+			result, err := registry.ResolveResult("1:id:123")
+			require.NoError(t, err)
+			require.Equal(t, expected, result)
+		}
+
+		require.Equal(t, CacheStats{Hits: 1, Misses: 1}, registry.CacheStats())
+
+		// the cached DID not found has the resolution error
+		registry.cache.Put("1:id:456", &CacheEntry{NotFound: true})
+
+		result, err := registry.ResolveResult("1:id:456")
+		require.True(t, errors.Is(err, vdriapi.ErrNotFound))
+		require.Equal(t, vdriapi.NotFoundError, result.ResolutionMetadata.Error)
+	})
+}
+
+type mockResultVDRI struct {
+	mockvdri.MockVDRI
+	result *vdriapi.DIDResolutionResult
+}
+
+func (m *mockResultVDRI) ReadResult(string, ...vdriapi.ResolveOpts) (*vdriapi.DIDResolutionResult, error) {
+	return m.result, nil
+}
+
+func TestRegistry_ResolveCache(t *testing.T) {
+	newRegistry := func(reads *int, readErr error) *Registry {
+		return New(&mockprovider.Provider{}, WithResolutionCache(NewLRUCache()), WithVDRI(&mockvdri.MockVDRI{
+			AcceptValue: true, ReadFunc: func(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error) {
+				*reads++
+				if readErr != nil {
+					return nil, readErr
+				}
+
+				return &did.Doc{ID: didID}, nil
+			}}))
+	}
+
+	t.Run("test resolution is cached", func(t *testing.T) {
+		var reads int
+
+		registry := newRegistry(&reads, nil)
+
+		for i := 0; i < 3; i++ {
+			doc, err := registry.Resolve("did:example:123")
+			require.NoError(t, err)
+			require.Equal(t, "did:example:123", doc.ID)
+		}
+
+		require.Equal(t, 1, reads)
+		require.Equal(t, CacheStats{Hits: 2, Misses: 1}, registry.CacheStats())
+
+		// no-cache resolution refreshes the cache
+		_, err := registry.Resolve("did:example:123", vdriapi.WithNoCache(true))
+		require.NoError(t, err)
+		require.Equal(t, 2, reads)
+
+		// the versions are not cached
+		_, err = registry.Resolve("did:example:123", vdriapi.WithVersionID("1"))
+		require.NoError(t, err)
+		require.Equal(t, 3, reads)
+
+		_, err = registry.Resolve("did:example:123", vdriapi.WithVersionTime(time.Now()))
+		require.NoError(t, err)
+		require.Equal(t, 4, reads)
+
+		require.Equal(t, CacheStats{Hits: 2, Misses: 1}, registry.CacheStats())
+
+		// store invalidates the cached resolution
+		require.NoError(t, registry.Store(&did.Doc{ID: "did:example:123"}))
+
+		_, err = registry.Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, 5, reads)
+		require.Equal(t, CacheStats{Hits: 2, Misses: 2}, registry.CacheStats())
+	})
+
+	t.Run("test cached resolution is not shared", func(t *testing.T) {
+		var reads int
+
+		registry := newRegistry(&reads, nil)
+
+		// the resolved result and the cached one are modified by the callers
+		for i := 0; i < 3; i++ {
+			result, err := registry.ResolveResult("did:example:123")
+			require.NoError(t, err)
+			require.Equal(t, "did:example:123", result.DIDDocument.ID)
+			require.Empty(t, result.DIDDocument.Service)
+			require.Empty(t, result.DocumentMetadata.VersionID)
+
+			result.DIDDocument.ID = "did:example:modified"
+			result.DIDDocument.Service = append(result.DIDDocument.Service, did.Service{ID: "#modified"})
+			result.DocumentMetadata.VersionID = "modified"
+		}
+
+		require.Equal(t, 1, reads)
+	})
+
+	t.Run("test DID not found is cached", func(t *testing.T) {
+		var reads int
+
+		registry := newRegistry(&reads, vdriapi.ErrNotFound)
+
+		for i := 0; i < 2; i++ {
+			_, err := registry.Resolve("did:example:123")
+			require.True(t, errors.Is(err, vdriapi.ErrNotFound))
+		}
+
+		require.Equal(t, 1, reads)
+		require.Equal(t, CacheStats{Hits: 1, Misses: 1}, registry.CacheStats())
+	})
+
+	t.Run("test peer DID not found is cached", func(t *testing.T) {
+		peerVDRI, err := peer.New(mockstorage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		registry := New(&mockprovider.Provider{}, WithResolutionCache(NewLRUCache()), WithVDRI(peerVDRI))
+
+		for i := 0; i < 2; i++ {
+			result, err := registry.ResolveResult("did:peer:123")
+			require.True(t, errors.Is(err, vdriapi.ErrNotFound))
+			require.Equal(t, vdriapi.NotFoundError, result.ResolutionMetadata.Error)
+		}
+
+		require.Equal(t, CacheStats{Hits: 1, Misses: 1}, registry.CacheStats())
+	})
+
+	t.Run("test read error is not cached", func(t *testing.T) {
+		var reads int
+
+		registry := newRegistry(&reads, fmt.Errorf("read error"))
+
+		for i := 0; i < 2; i++ {
+			_, err := registry.Resolve("did:example:123")
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "read error")
+		}
+
+		require.Equal(t, 2, reads)
+		require.Equal(t, CacheStats{Hits: 0, Misses: 2}, registry.CacheStats())
+	})
+}
+
 func TestRegistry_Store(t *testing.T) {
 	t.Run("test invalid did input", func(t *testing.T) {
 		registry := New(&mockprovider.Provider{})
@@ -204,3 +419,118 @@ func TestRegistry_Create(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestRegistry_Update(t *testing.T) {
+	t.Run("test invalid did input", func(t *testing.T) {
+		registry := New(&mockprovider.Provider{})
+		err := registry.Update(&did.Doc{ID: "id"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "wrong format did input")
+	})
+	t.Run("test did method not supported", func(t *testing.T) {
+		registry := New(&mockprovider.Provider{}, WithVDRI(&mockvdri.MockVDRI{AcceptValue: false}))
+		err := registry.Update(&did.Doc{ID: "1:id:123"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "did method id not supported for vdri")
+	})
+	t.Run("test update with the signer of the agent", func(t *testing.T) {
+		signer := &mockkms.CloseableKMS{}
+		registry := New(&mockprovider.Provider{SignerValue: signer},
+			WithVDRI(&mockvdri.MockVDRI{AcceptValue: true,
+				UpdateFunc: func(doc *did.Doc, opts ...vdriapi.UpdateOpts) error {
+					updateOpts := &vdriapi.UpdateDIDOpts{}
+					for _, opt := range opts {
+						opt(updateOpts)
+					}
+					require.Equal(t, "1:id:123", doc.ID)
+					require.Equal(t, signer, updateOpts.Signer)
+					return nil
+				}}))
+		require.NoError(t, registry.Update(&did.Doc{ID: "1:id:123"}))
+	})
+	t.Run("test update with signer option", func(t *testing.T) {
+		signer := &mockkms.CloseableKMS{}
+		registry := New(&mockprovider.Provider{SignerValue: &mockkms.CloseableKMS{}},
+			WithVDRI(&mockvdri.MockVDRI{AcceptValue: true,
+				UpdateFunc: func(doc *did.Doc, opts ...vdriapi.UpdateOpts) error {
+					updateOpts := &vdriapi.UpdateDIDOpts{}
+					for _, opt := range opts {
+						opt(updateOpts)
+					}
+					require.True(t, signer == updateOpts.Signer)
+					return nil
+				}}))
+		require.NoError(t, registry.Update(&did.Doc{ID: "1:id:123"}, vdriapi.WithSigner(signer)))
+	})
+	t.Run("test update error", func(t *testing.T) {
+		registry := New(&mockprovider.Provider{}, WithVDRI(&mockvdri.MockVDRI{AcceptValue: true,
+			UpdateFunc: func(doc *did.Doc, opts ...vdriapi.UpdateOpts) error {
+				return vdriapi.ErrNotSupported
+			}}))
+		err := registry.Update(&did.Doc{ID: "1:id:123"})
+		require.True(t, errors.Is(err, vdriapi.ErrNotSupported))
+	})
+}
+
+func TestRegistry_Deactivate(t *testing.T) {
+	t.Run("test invalid did input", func(t *testing.T) {
+		registry := New(&mockprovider.Provider{})
+		err := registry.Deactivate("id")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "wrong format did input")
+	})
+	t.Run("test did method not supported", func(t *testing.T) {
+		registry := New(&mockprovider.Provider{}, WithVDRI(&mockvdri.MockVDRI{AcceptValue: false}))
+		err := registry.Deactivate("1:id:123")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "did method id not supported for vdri")
+	})
+	t.Run("test deactivate with the signer of the agent", func(t *testing.T) {
+		signer := &mockkms.CloseableKMS{}
+		registry := New(&mockprovider.Provider{SignerValue: signer},
+			WithVDRI(&mockvdri.MockVDRI{AcceptValue: true,
+				DeactivateFunc: func(didID string, opts ...vdriapi.UpdateOpts) error {
+					updateOpts := &vdriapi.UpdateDIDOpts{}
+					for _, opt := range opts {
+						opt(updateOpts)
+					}
+					require.Equal(t, "1:id:123", didID)
+					require.Equal(t, signer, updateOpts.Signer)
+					return nil
+				}}))
+		require.NoError(t, registry.Deactivate("1:id:123"))
+	})
+	t.Run("test deactivate error", func(t *testing.T) {
+		registry := New(&mockprovider.Provider{}, WithVDRI(&mockvdri.MockVDRI{AcceptValue: true,
+			DeactivateFunc: func(didID string, opts ...vdriapi.UpdateOpts) error {
+				return fmt.Errorf("deactivate error")
+			}}))
+		err := registry.Deactivate("1:id:123")
+		require.EqualError(t, err, "deactivate error")
+	})
+}
+
+func TestRegistry_UpdateInvalidatesCache(t *testing.T) {
+	var reads int
+
+	registry := New(&mockprovider.Provider{}, WithResolutionCache(NewLRUCache()), WithVDRI(&mockvdri.MockVDRI{
+		AcceptValue: true, ReadFunc: func(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error) {
+			reads++
+			return &did.Doc{ID: didID}, nil
+		}}))
+
+	_, err := registry.Resolve("did:example:123")
+	require.NoError(t, err)
+
+	require.NoError(t, registry.Update(&did.Doc{ID: "did:example:123"}))
+
+	_, err = registry.Resolve("did:example:123")
+	require.NoError(t, err)
+	require.Equal(t, 2, reads)
+
+	require.NoError(t, registry.Deactivate("did:example:123"))
+
+	_, err = registry.Resolve("did:example:123")
+	require.NoError(t, err)
+	require.Equal(t, 3, reads)
+}
@@ -0,0 +1,155 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// Build creates a new Sidetree DID with the public key, and a service with the service type and endpoint
+// options. The create operation is sent to the Sidetree node, the KMS keys of the next update and recover
+// operations are created and kept in the store. It returns the DID document of the short-form DID, the
+// long-form DID resolves before the operation is anchored (see LongFormDID).
+func (v *VDRI) Build(pubKey *vdriapi.PubKey, opts ...vdriapi.DocOpts) (*did.Doc, error) {
+	if pubKey == nil || pubKey.Value == "" {
+		return nil, errors.New("build sidetree DID: public key is empty")
+	}
+
+	docOpts := &vdriapi.CreateDIDOpts{}
+
+	for _, opt := range opts {
+		opt(docOpts)
+	}
+
+	doc := newDocument(pubKey, docOpts)
+
+	keys, err := v.newOperationKeys("")
+	if err != nil {
+		return nil, fmt.Errorf("build sidetree DID: %w", err)
+	}
+
+	req, err := newCreateRequest(doc, keys)
+	if err != nil {
+		return nil, fmt.Errorf("build sidetree DID: %w", err)
+	}
+
+	suffix, err := canonicalHash(req.SuffixData)
+	if err != nil {
+		return nil, fmt.Errorf("build sidetree DID: %w", err)
+	}
+
+	err = v.send(req)
+	if err != nil {
+		return nil, fmt.Errorf("build sidetree DID: %w", err)
+	}
+
+	err = v.putKeys(suffix, keys)
+	if err != nil {
+		return nil, fmt.Errorf("build sidetree DID: %w", err)
+	}
+
+	err = v.putInitialState(suffix, &initialState{SuffixData: req.SuffixData, Delta: req.Delta})
+	if err != nil {
+		return nil, fmt.Errorf("build sidetree DID: %w", err)
+	}
+
+	didDoc, err := didDocument(v.prefix()+suffix, doc)
+	if err != nil {
+		return nil, fmt.Errorf("build sidetree DID: %w", err)
+	}
+
+	return didDoc, nil
+}
+
+// LongFormDID returns the long-form DID of the Sidetree DID built by this vdri, it resolves to the initial
+// DID document before the create operation is anchored
+func (v *VDRI) LongFormDID(didID string) (string, error) {
+	suffix, state, err := v.parseDID(didID)
+	if err != nil {
+		return "", err
+	}
+
+	if state != nil {
+		return didID, nil
+	}
+
+	data, err := v.store.Get(initialStateKey(suffix))
+	if err != nil {
+		return "", fmt.Errorf("get initial state of '%s': %w", didID, err)
+	}
+
+	state = &initialState{}
+
+	err = json.Unmarshal(data, state)
+	if err != nil {
+		return "", fmt.Errorf("unmarshal initial state of '%s': %w", didID, err)
+	}
+
+	return v.longFormDID(suffix, state)
+}
+
+// putInitialState stores the initial state of the DID suffix, for its long-form DID
+func (v *VDRI) putInitialState(suffix string, state *initialState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal initial state: %w", err)
+	}
+
+	err = v.store.Put(initialStateKey(suffix), data)
+	if err != nil {
+		return fmt.Errorf("store initial state: %w", err)
+	}
+
+	return nil
+}
+
+// initialStateKey returns the store key of the initial state of the DID suffix
+func initialStateKey(suffix string) string {
+	return "initialstate_" + suffix
+}
+
+// putKeys stores the public keys of the KMS keys of the next operations of the DID suffix
+func (v *VDRI) putKeys(suffix string, keys *operationKeys) error {
+	data, err := json.Marshal(keys)
+	if err != nil {
+		return fmt.Errorf("marshal operation keys: %w", err)
+	}
+
+	err = v.store.Put(suffix, data)
+	if err != nil {
+		return fmt.Errorf("store operation keys: %w", err)
+	}
+
+	return nil
+}
+
+// getKeys returns the public keys of the KMS keys of the next operations of the DID suffix
+func (v *VDRI) getKeys(suffix string) (*operationKeys, error) {
+	data, err := v.store.Get(suffix)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, fmt.Errorf("operation keys of DID suffix '%s' not found: %w", suffix, err)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get operation keys: %w", err)
+	}
+
+	keys := &operationKeys{}
+
+	err = json.Unmarshal(data, keys)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal operation keys: %w", err)
+	}
+
+	return keys, nil
+}
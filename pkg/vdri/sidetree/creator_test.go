@@ -0,0 +1,175 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms/legacykms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	testPubKey = "B12NYF8RrR3h41TDCTJojY59usg3mbtbjnFs7Eud1Y6u"
+	ed25519Key = "Ed25519VerificationKey2018"
+)
+
+func TestBuild(t *testing.T) {
+	node := newTestNode()
+	defer node.Close()
+
+	t.Run("test build", func(t *testing.T) {
+		v, err := New(node.URL, newTestProvider(t))
+		require.NoError(t, err)
+
+		didDoc, err := v.Build(&vdriapi.PubKey{Value: testPubKey, Type: ed25519Key})
+		require.NoError(t, err)
+
+		require.True(t, strings.HasPrefix(didDoc.ID, "did:sidetree:"))
+		require.Equal(t, []string{did.CoreContext}, didDoc.Context)
+		require.Len(t, didDoc.VerificationMethod, 1)
+		require.Equal(t, didDoc.ID+"#key-1", didDoc.VerificationMethod[0].ID)
+		require.Equal(t, ed25519Key, didDoc.VerificationMethod[0].Type)
+		require.Equal(t, did.PublicKeyJwk, didDoc.VerificationMethod[0].Encoding)
+		require.Equal(t, base58.Decode(testPubKey), didDoc.VerificationMethod[0].Value)
+		require.Len(t, didDoc.Authentication, 1)
+		require.Equal(t, didDoc.VerificationMethod[0], didDoc.Authentication[0].PublicKey)
+		require.Len(t, didDoc.AssertionMethod, 1)
+		require.Empty(t, didDoc.Service)
+
+		// the create operation is anchored by the node
+		resolved, err := v.Read(didDoc.ID)
+		require.NoError(t, err)
+		require.Equal(t, didDoc.ID, resolved.ID)
+		require.Equal(t, didDoc.VerificationMethod, resolved.VerificationMethod)
+
+		// the operation keys are stored
+		suffix := strings.TrimPrefix(didDoc.ID, "did:sidetree:")
+		keys, err := v.getKeys(suffix)
+		require.NoError(t, err)
+		require.NotEmpty(t, keys.UpdateKey)
+		require.NotEmpty(t, keys.RecoveryKey)
+		require.NotEqual(t, keys.UpdateKey, keys.RecoveryKey)
+	})
+
+	t.Run("test build with service", func(t *testing.T) {
+		v, err := New(node.URL, newTestProvider(t))
+		require.NoError(t, err)
+
+		didDoc, err := v.Build(&vdriapi.PubKey{Value: testPubKey, Type: ed25519Key},
+			vdriapi.WithServiceType(vdriapi.DIDCommServiceType),
+			vdriapi.WithServiceEndpoint("https://example.com/didcomm"))
+		require.NoError(t, err)
+
+		require.Len(t, didDoc.Service, 1)
+		require.Equal(t, didDoc.ID+"#endpoint-1", didDoc.Service[0].ID)
+		require.Equal(t, vdriapi.DIDCommServiceType, didDoc.Service[0].Type)
+		require.Equal(t, "https://example.com/didcomm", didDoc.Service[0].ServiceEndpoint)
+	})
+
+	t.Run("test build with empty public key", func(t *testing.T) {
+		v, err := New(node.URL, newTestProvider(t))
+		require.NoError(t, err)
+
+		_, err = v.Build(&vdriapi.PubKey{Type: ed25519Key})
+		require.EqualError(t, err, "build sidetree DID: public key is empty")
+	})
+
+	t.Run("test build with KMS error", func(t *testing.T) {
+		p := newTestProvider(t)
+		p.KMSValue = &mockkms.CloseableKMS{CreateKeyErr: errors.New("create key error")}
+
+		v, err := New(node.URL, p)
+		require.NoError(t, err)
+
+		_, err = v.Build(&vdriapi.PubKey{Value: testPubKey, Type: ed25519Key})
+		require.EqualError(t, err, "build sidetree DID: create update key: create key error")
+	})
+
+	t.Run("test build with rejected operation", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "invalid operation", http.StatusBadRequest)
+		}))
+		defer srv.Close()
+
+		v, err := New(srv.URL, newTestProvider(t))
+		require.NoError(t, err)
+
+		_, err = v.Build(&vdriapi.PubKey{Value: testPubKey, Type: ed25519Key})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create operation rejected with status '400': invalid operation")
+	})
+
+	t.Run("test build with unreachable node", func(t *testing.T) {
+		v, err := New("http://localhost:1", newTestProvider(t))
+		require.NoError(t, err)
+
+		_, err = v.Build(&vdriapi.PubKey{Value: testPubKey, Type: ed25519Key})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "HTTP Post request failed")
+	})
+
+	t.Run("test build with store error", func(t *testing.T) {
+		p := newTestProvider(t)
+		p.StorageProviderValue = &mockstorage.MockStoreProvider{Store: &mockstorage.MockStore{
+			Store: map[string][]byte{}, ErrPut: errors.New("put error"),
+		}}
+
+		v, err := New(node.URL, p)
+		require.NoError(t, err)
+
+		_, err = v.Build(&vdriapi.PubKey{Value: testPubKey, Type: ed25519Key})
+		require.EqualError(t, err, "build sidetree DID: store operation keys: put error")
+	})
+}
+
+func TestLongFormDID(t *testing.T) {
+	node := newTestNode()
+	defer node.Close()
+
+	v, err := New(node.URL, newTestProvider(t))
+	require.NoError(t, err)
+
+	didDoc, err := v.Build(&vdriapi.PubKey{Value: testPubKey, Type: ed25519Key})
+	require.NoError(t, err)
+
+	longForm, err := v.LongFormDID(didDoc.ID)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(longForm, didDoc.ID+":"))
+
+	suffix, state, err := v.parseDID(longForm)
+	require.NoError(t, err)
+	require.Equal(t, strings.TrimPrefix(didDoc.ID, "did:sidetree:"), suffix)
+	require.NotNil(t, state)
+
+	t.Run("test long-form DID of a long-form DID", func(t *testing.T) {
+		same, e := v.LongFormDID(longForm)
+		require.NoError(t, e)
+		require.Equal(t, longForm, same)
+	})
+
+	t.Run("test long-form DID not built by the vdri", func(t *testing.T) {
+		_, e := v.LongFormDID("did:sidetree:EiDahaOGH-liLLdDtTxEAdc8i-cfCz-WUcQdRJheMVNn3A")
+		require.Error(t, e)
+		require.True(t, errors.Is(e, storage.ErrDataNotFound))
+	})
+
+	t.Run("test long-form DID of invalid DID", func(t *testing.T) {
+		_, e := v.LongFormDID("did:ion:EiDahaOGH-liLLdDtTxEAdc8i-cfCz-WUcQdRJheMVNn3A")
+		require.EqualError(t, e, "invalid did:sidetree: did:ion:EiDahaOGH-liLLdDtTxEAdc8i-cfCz-WUcQdRJheMVNn3A")
+	})
+}
@@ -0,0 +1,343 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+const (
+	pubKeyIndex1      = "key-1"
+	svcEndpointIndex1 = "endpoint-1"
+
+	ed25519KeySize = 32
+)
+
+// newDocument returns the document state of a new DID with the public key, and the service of the options
+func newDocument(pubKey *vdriapi.PubKey, docOpts *vdriapi.CreateDIDOpts) *Document {
+	doc := &Document{
+		PublicKeys: []PublicKey{{
+			ID:       pubKeyIndex1,
+			Type:     pubKey.Type,
+			JWK:      ed25519JWK(pubKey.Value),
+			Purposes: []string{PurposeAuthentication, PurposeAssertionMethod},
+		}},
+	}
+
+	if docOpts.ServiceType != "" {
+		doc.Services = []Service{{
+			ID:              svcEndpointIndex1,
+			Type:            docOpts.ServiceType,
+			ServiceEndpoint: docOpts.ServiceEndpoint,
+		}}
+	}
+
+	return doc
+}
+
+// applyPatches returns the document state with the patches applied
+// (https://identity.foundation/sidetree/spec/#did-state-patches)
+func applyPatches(doc *Document, patches []Patch) (*Document, error) {
+	result := &Document{}
+
+	if doc != nil {
+		result.PublicKeys = append(result.PublicKeys, doc.PublicKeys...)
+		result.Services = append(result.Services, doc.Services...)
+	}
+
+	for _, patch := range patches {
+		switch patch.Action {
+		case replaceAction:
+			result = &Document{}
+
+			if patch.Document != nil {
+				result.PublicKeys = append(result.PublicKeys, patch.Document.PublicKeys...)
+				result.Services = append(result.Services, patch.Document.Services...)
+			}
+		case addPublicKeysAction:
+			for _, pk := range patch.PublicKeys {
+				result.PublicKeys = append(removePublicKey(result.PublicKeys, pk.ID), pk)
+			}
+		case removePublicKeysAction:
+			for _, id := range patch.IDs {
+				result.PublicKeys = removePublicKey(result.PublicKeys, id)
+			}
+		case addServicesAction:
+			for _, svc := range patch.Services {
+				result.Services = append(removeService(result.Services, svc.ID), svc)
+			}
+		case removeServicesAction:
+			for _, id := range patch.IDs {
+				result.Services = removeService(result.Services, id)
+			}
+		default:
+			return nil, fmt.Errorf("patch action '%s' not supported", patch.Action)
+		}
+	}
+
+	return result, nil
+}
+
+func removePublicKey(publicKeys []PublicKey, id string) []PublicKey {
+	var result []PublicKey
+
+	for _, pk := range publicKeys {
+		if pk.ID != id {
+			result = append(result, pk)
+		}
+	}
+
+	return result
+}
+
+func removeService(services []Service, id string) []Service {
+	var result []Service
+
+	for _, svc := range services {
+		if svc.ID != id {
+			result = append(result, svc)
+		}
+	}
+
+	return result
+}
+
+// rawDIDDocument returns the JSON DID document of the DID didID with the document state. The public keys are
+// verification methods with publicKeyJwk values, referenced by the verification relationships of their purposes.
+func rawDIDDocument(didID string, doc *Document) map[string]interface{} {
+	raw := map[string]interface{}{
+		"@context": []string{did.CoreContext},
+		"id":       didID,
+	}
+
+	if doc == nil {
+		return raw
+	}
+
+	var methods []map[string]interface{}
+
+	for _, pk := range doc.PublicKeys {
+		id := didID + "#" + pk.ID
+
+		methods = append(methods, map[string]interface{}{
+			"id":           id,
+			"type":         pk.Type,
+			"controller":   didID,
+			"publicKeyJwk": pk.JWK,
+		})
+
+		for _, purpose := range pk.Purposes {
+			refs, _ := raw[purpose].([]string)
+			raw[purpose] = append(refs, id)
+		}
+	}
+
+	if len(methods) > 0 {
+		raw["verificationMethod"] = methods
+	}
+
+	var services []map[string]interface{}
+
+	for _, svc := range doc.Services {
+		services = append(services, map[string]interface{}{
+			"id":              didID + "#" + svc.ID,
+			"type":            svc.Type,
+			"serviceEndpoint": svc.ServiceEndpoint,
+		})
+	}
+
+	if len(services) > 0 {
+		raw["service"] = services
+	}
+
+	return raw
+}
+
+// didDocument returns the DID document of the DID didID with the document state
+func didDocument(didID string, doc *Document) (*did.Doc, error) {
+	data, err := json.Marshal(rawDIDDocument(didID, doc))
+	if err != nil {
+		return nil, fmt.Errorf("marshal DID document: %w", err)
+	}
+
+	didDoc, err := did.ParseDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse DID document: %w", err)
+	}
+
+	return didDoc, nil
+}
+
+// fromDIDDocument returns the document state of the DID document doc. The purposes of the public keys are the
+// verification relationships referencing or embedding them.
+func fromDIDDocument(doc *did.Doc) (*Document, error) {
+	result := &Document{}
+	purposes := map[string][]string{}
+
+	relationships := []struct {
+		purpose string
+		methods []did.VerificationMethod
+	}{
+		{PurposeAuthentication, doc.Authentication},
+		{PurposeAssertionMethod, doc.AssertionMethod},
+		{PurposeKeyAgreement, doc.KeyAgreement},
+		{PurposeCapabilityInvocation, doc.CapabilityInvocation},
+		{PurposeCapabilityDelegation, doc.CapabilityDelegation},
+	}
+
+	keys := append(append([]did.PublicKey{}, doc.VerificationMethod...), doc.PublicKey...)
+
+	for _, rel := range relationships {
+		for _, vm := range rel.methods {
+			id := fragment(vm.PublicKey.ID)
+			purposes[id] = append(purposes[id], rel.purpose)
+
+			keys = append(keys, vm.PublicKey)
+		}
+	}
+
+	for _, pk := range keys {
+		id := fragment(pk.ID)
+		if hasPublicKey(result.PublicKeys, id) {
+			continue
+		}
+
+		jwk, err := publicKeyJWK(&pk)
+		if err != nil {
+			return nil, fmt.Errorf("public key %s: %w", pk.ID, err)
+		}
+
+		result.PublicKeys = append(result.PublicKeys, PublicKey{ID: id, Type: pk.Type, JWK: jwk, Purposes: purposes[id]})
+	}
+
+	for _, svc := range doc.Service {
+		result.Services = append(result.Services, Service{
+			ID:              fragment(svc.ID),
+			Type:            svc.Type,
+			ServiceEndpoint: svc.ServiceEndpoint,
+		})
+	}
+
+	return result, nil
+}
+
+// publicKeyJWK returns the JWK of the public key, either its publicKeyJwk value or its raw Ed25519 key
+func publicKeyJWK(pk *did.PublicKey) (*JWK, error) {
+	if pk.Encoding == did.PublicKeyJwk {
+		jwk := &JWK{}
+
+		if err := json.Unmarshal(pk.JWK, jwk); err != nil {
+			return nil, fmt.Errorf("unmarshal JWK: %w", err)
+		}
+
+		return jwk, nil
+	}
+
+	if len(pk.Value) != ed25519KeySize {
+		return nil, fmt.Errorf("key type '%s' not supported", pk.Type)
+	}
+
+	return &JWK{Kty: okpKeyType, Crv: ed25519Crv, X: encode(pk.Value)}, nil
+}
+
+// fragment returns the fragment of the DID URL id, the ID of the public keys and services in the document state
+func fragment(id string) string {
+	if i := strings.LastIndex(id, "#"); i >= 0 {
+		return id[i+1:]
+	}
+
+	return id
+}
+
+func hasPublicKey(publicKeys []PublicKey, id string) bool {
+	for _, pk := range publicKeys {
+		if pk.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// diffPatches returns the patches updating the current document state to next, the public keys and
+// services which are removed or changed are removed before the new ones are added
+func diffPatches(current, next *Document) []Patch {
+	var (
+		patches                  []Patch
+		removedKeys, removedSvcs []string
+		addedKeys                []PublicKey
+		addedSvcs                []Service
+	)
+
+	for _, pk := range current.PublicKeys {
+		if !containsPublicKey(next.PublicKeys, pk) {
+			removedKeys = append(removedKeys, pk.ID)
+		}
+	}
+
+	for _, pk := range next.PublicKeys {
+		if !containsPublicKey(current.PublicKeys, pk) {
+			addedKeys = append(addedKeys, pk)
+		}
+	}
+
+	for _, svc := range current.Services {
+		if !containsService(next.Services, svc) {
+			removedSvcs = append(removedSvcs, svc.ID)
+		}
+	}
+
+	for _, svc := range next.Services {
+		if !containsService(current.Services, svc) {
+			addedSvcs = append(addedSvcs, svc)
+		}
+	}
+
+	if len(removedKeys) > 0 {
+		patches = append(patches, RemovePublicKeys(removedKeys...))
+	}
+
+	if len(removedSvcs) > 0 {
+		patches = append(patches, RemoveServices(removedSvcs...))
+	}
+
+	if len(addedKeys) > 0 {
+		patches = append(patches, AddPublicKeys(addedKeys...))
+	}
+
+	if len(addedSvcs) > 0 {
+		patches = append(patches, AddServices(addedSvcs...))
+	}
+
+	return patches
+}
+
+func containsPublicKey(publicKeys []PublicKey, publicKey PublicKey) bool {
+	for _, pk := range publicKeys {
+		if reflect.DeepEqual(pk, publicKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsService(services []Service, service Service) bool {
+	for _, svc := range services {
+		if svc == service {
+			return true
+		}
+	}
+
+	return false
+}
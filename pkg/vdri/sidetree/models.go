@@ -0,0 +1,154 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+// Sidetree operation types
+const (
+	createOperation     = "create"
+	updateOperation     = "update"
+	recoverOperation    = "recover"
+	deactivateOperation = "deactivate"
+)
+
+// Patch actions of the operation deltas (https://identity.foundation/sidetree/spec/#did-state-patches)
+const (
+	addPublicKeysAction    = "add-public-keys"
+	removePublicKeysAction = "remove-public-keys"
+	addServicesAction      = "add-services"
+	removeServicesAction   = "remove-services"
+	replaceAction          = "replace"
+)
+
+// Purposes of the public keys, the verification relationships of the DID document
+const (
+	// PurposeAuthentication authentication verification relationship
+	PurposeAuthentication = "authentication"
+	// PurposeAssertionMethod assertion method verification relationship
+	PurposeAssertionMethod = "assertionMethod"
+	// PurposeKeyAgreement key agreement verification relationship
+	PurposeKeyAgreement = "keyAgreement"
+	// PurposeCapabilityInvocation capability invocation verification relationship
+	PurposeCapabilityInvocation = "capabilityInvocation"
+	// PurposeCapabilityDelegation capability delegation verification relationship
+	PurposeCapabilityDelegation = "capabilityDelegation"
+)
+
+// JWK is the public JSON web key of the Sidetree public keys and operation keys
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y,omitempty"`
+}
+
+// PublicKey is a public key of the Sidetree DID document
+type PublicKey struct {
+	ID       string   `json:"id"`
+	Type     string   `json:"type"`
+	JWK      *JWK     `json:"publicKeyJwk"`
+	Purposes []string `json:"purposes,omitempty"`
+}
+
+// Service is a service of the Sidetree DID document
+type Service struct {
+	ID              string `json:"id"`
+	Type            string `json:"type"`
+	ServiceEndpoint string `json:"serviceEndpoint"`
+}
+
+// Document is the Sidetree DID document state, built by the patches of the operations
+type Document struct {
+	PublicKeys []PublicKey `json:"publicKeys,omitempty"`
+	Services   []Service   `json:"services,omitempty"`
+}
+
+// Patch is a patch of the DID document state, applied by the update and recover operations
+type Patch struct {
+	Action     string      `json:"action"`
+	PublicKeys []PublicKey `json:"publicKeys,omitempty"`
+	Services   []Service   `json:"services,omitempty"`
+	IDs        []string    `json:"ids,omitempty"`
+	Document   *Document   `json:"document,omitempty"`
+}
+
+// AddPublicKeys returns the patch adding the public keys to the DID document
+func AddPublicKeys(publicKeys ...PublicKey) Patch {
+	return Patch{Action: addPublicKeysAction, PublicKeys: publicKeys}
+}
+
+// RemovePublicKeys returns the patch removing the public keys with the ids from the DID document
+func RemovePublicKeys(ids ...string) Patch {
+	return Patch{Action: removePublicKeysAction, IDs: ids}
+}
+
+// AddServices returns the patch adding the services to the DID document
+func AddServices(services ...Service) Patch {
+	return Patch{Action: addServicesAction, Services: services}
+}
+
+// RemoveServices returns the patch removing the services with the ids from the DID document
+func RemoveServices(ids ...string) Patch {
+	return Patch{Action: removeServicesAction, IDs: ids}
+}
+
+// delta is the delta of the create, update and recover operations
+type delta struct {
+	Patches          []Patch `json:"patches"`
+	UpdateCommitment string  `json:"updateCommitment"`
+}
+
+// suffixData is the suffix data of the create operation, its hash is the unique suffix of the DID
+type suffixData struct {
+	DeltaHash          string `json:"deltaHash"`
+	RecoveryCommitment string `json:"recoveryCommitment"`
+}
+
+// initialState is the initial state of the DID encoded in the long-form DIDs
+type initialState struct {
+	SuffixData *suffixData `json:"suffixData"`
+	Delta      *delta      `json:"delta"`
+}
+
+// request is a Sidetree operation request (https://identity.foundation/sidetree/spec/#sidetree-operations)
+type request struct {
+	Type        string      `json:"type"`
+	DIDSuffix   string      `json:"didSuffix,omitempty"`
+	RevealValue string      `json:"revealValue,omitempty"`
+	SuffixData  *suffixData `json:"suffixData,omitempty"`
+	Delta       *delta      `json:"delta,omitempty"`
+	SignedData  string      `json:"signedData,omitempty"`
+}
+
+// updateSignedData is the signed data of the update operations
+type updateSignedData struct {
+	UpdateKey *JWK   `json:"updateKey"`
+	DeltaHash string `json:"deltaHash"`
+}
+
+// recoverSignedData is the signed data of the recover operations
+type recoverSignedData struct {
+	RecoveryCommitment string `json:"recoveryCommitment"`
+	RecoveryKey        *JWK   `json:"recoveryKey"`
+	DeltaHash          string `json:"deltaHash"`
+}
+
+// deactivateSignedData is the signed data of the deactivate operations
+type deactivateSignedData struct {
+	DIDSuffix   string `json:"didSuffix"`
+	RecoveryKey *JWK   `json:"recoveryKey"`
+}
+
+// jwsHeader is the protected header of the signed data
+type jwsHeader struct {
+	Alg string `json:"alg"`
+}
+
+// operationKeys are the public keys (base58) of the KMS keys of the next update and recover operations of a DID
+type operationKeys struct {
+	UpdateKey   string `json:"updateKey"`
+	RecoveryKey string `json:"recoveryKey"`
+}
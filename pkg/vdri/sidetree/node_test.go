@@ -0,0 +1,335 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/internal/jcs"
+)
+
+// testNode is a stand-in of a Sidetree node, it validates the operations of the DIDs and resolves them
+type testNode struct {
+	*httptest.Server
+	mu     sync.Mutex
+	method string
+	states map[string]*nodeState
+}
+
+// nodeState is the state of a DID in the test node
+type nodeState struct {
+	doc                *Document
+	updateCommitment   string
+	recoveryCommitment string
+	deactivated        bool
+}
+
+// newTestNode starts a test node, it must be closed by the caller
+func newTestNode() *testNode {
+	n := &testNode{method: defaultMethod, states: map[string]*nodeState{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(operationsPath, n.operations)
+	mux.HandleFunc(identifiersPath, n.identifiers)
+
+	n.Server = httptest.NewServer(mux)
+
+	return n
+}
+
+func (n *testNode) operations(w http.ResponseWriter, r *http.Request) {
+	req := &request{}
+
+	err := json.NewDecoder(r.Body).Decode(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	switch req.Type {
+	case createOperation:
+		err = n.create(req)
+	case updateOperation:
+		err = n.update(req)
+	case recoverOperation:
+		err = n.recover(req)
+	case deactivateOperation:
+		err = n.deactivate(req)
+	default:
+		err = fmt.Errorf("operation type '%s' not supported", req.Type)
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
+
+func (n *testNode) create(req *request) error {
+	suffix, err := canonicalHash(req.SuffixData)
+	if err != nil {
+		return err
+	}
+
+	state := &initialState{SuffixData: req.SuffixData, Delta: req.Delta}
+
+	if err = state.validate(suffix); err != nil {
+		return err
+	}
+
+	doc, err := applyPatches(nil, req.Delta.Patches)
+	if err != nil {
+		return err
+	}
+
+	n.states[suffix] = &nodeState{
+		doc:                doc,
+		updateCommitment:   req.Delta.UpdateCommitment,
+		recoveryCommitment: req.SuffixData.RecoveryCommitment,
+	}
+
+	return nil
+}
+
+func (n *testNode) update(req *request) error {
+	state, err := n.state(req.DIDSuffix, req.RevealValue, false)
+	if err != nil {
+		return err
+	}
+
+	signedData := &updateSignedData{}
+
+	if err = verifySignedData(req.SignedData, signedData, func() *JWK { return signedData.UpdateKey }); err != nil {
+		return err
+	}
+
+	if err = checkOperation(req, signedData.UpdateKey, signedData.DeltaHash); err != nil {
+		return err
+	}
+
+	doc, err := applyPatches(state.doc, req.Delta.Patches)
+	if err != nil {
+		return err
+	}
+
+	state.doc = doc
+	state.updateCommitment = req.Delta.UpdateCommitment
+
+	return nil
+}
+
+func (n *testNode) recover(req *request) error {
+	state, err := n.state(req.DIDSuffix, req.RevealValue, true)
+	if err != nil {
+		return err
+	}
+
+	signedData := &recoverSignedData{}
+
+	if err = verifySignedData(req.SignedData, signedData, func() *JWK { return signedData.RecoveryKey }); err != nil {
+		return err
+	}
+
+	if err = checkOperation(req, signedData.RecoveryKey, signedData.DeltaHash); err != nil {
+		return err
+	}
+
+	doc, err := applyPatches(nil, req.Delta.Patches)
+	if err != nil {
+		return err
+	}
+
+	state.doc = doc
+	state.updateCommitment = req.Delta.UpdateCommitment
+	state.recoveryCommitment = signedData.RecoveryCommitment
+
+	return nil
+}
+
+func (n *testNode) deactivate(req *request) error {
+	state, err := n.state(req.DIDSuffix, req.RevealValue, true)
+	if err != nil {
+		return err
+	}
+
+	signedData := &deactivateSignedData{}
+
+	if err = verifySignedData(req.SignedData, signedData, func() *JWK { return signedData.RecoveryKey }); err != nil {
+		return err
+	}
+
+	if signedData.DIDSuffix != req.DIDSuffix {
+		return errors.New("signed DID suffix does not match")
+	}
+
+	reveal, err := revealValue(signedData.RecoveryKey)
+	if err != nil {
+		return err
+	}
+
+	if reveal != req.RevealValue {
+		return errors.New("reveal value does not match the signing key")
+	}
+
+	state.doc = nil
+	state.deactivated = true
+
+	return nil
+}
+
+// state returns the state of the active DID suffix, the reveal value must match the commitment of the
+// update or recovery key
+func (n *testNode) state(suffix, reveal string, recovery bool) (*nodeState, error) {
+	state, ok := n.states[suffix]
+	if !ok || state.deactivated {
+		return nil, fmt.Errorf("DID suffix '%s' not found", suffix)
+	}
+
+	mh, err := base64.RawURLEncoding.DecodeString(reveal)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := multihashOf(mh)
+	if err != nil {
+		return nil, err
+	}
+
+	expected := state.updateCommitment
+	if recovery {
+		expected = state.recoveryCommitment
+	}
+
+	if encode(commit) != expected {
+		return nil, errors.New("reveal value does not match the commitment")
+	}
+
+	return state, nil
+}
+
+// checkOperation checks the reveal value is the value of the signing key, and the delta hash is signed
+func checkOperation(req *request, signingKey *JWK, deltaHash string) error {
+	reveal, err := revealValue(signingKey)
+	if err != nil {
+		return err
+	}
+
+	if reveal != req.RevealValue {
+		return errors.New("reveal value does not match the signing key")
+	}
+
+	hash, err := canonicalHash(req.Delta)
+	if err != nil {
+		return err
+	}
+
+	if hash != deltaHash {
+		return errors.New("signed delta hash does not match the delta")
+	}
+
+	return nil
+}
+
+// verifySignedData decodes the compact JWS into signedData, and verifies its signature with the key of the
+// signed data
+func verifySignedData(jws string, signedData interface{}, key func() *JWK) error {
+	parts := strings.Split(jws, ".")
+	if len(parts) != 3 {
+		return errors.New("invalid compact JWS")
+	}
+
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return err
+	}
+
+	if string(header) != `{"alg":"EdDSA"}` {
+		return fmt.Errorf("unexpected JWS header %s", header)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(payload, signedData); err != nil {
+		return err
+	}
+
+	canonical, err := jcs.Transform(payload)
+	if err != nil {
+		return err
+	}
+
+	if string(canonical) != string(payload) {
+		return errors.New("signed data is not canonical")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return err
+	}
+
+	jwk := key()
+	if jwk == nil || jwk.Kty != okpKeyType || jwk.Crv != ed25519Crv {
+		return errors.New("signing key is not an Ed25519 JWK")
+	}
+
+	pubKey, err := base64.RawURLEncoding.DecodeString(jwk.X)
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(pubKey, []byte(parts[0]+"."+parts[1]), signature) {
+		return errors.New("invalid signature")
+	}
+
+	return nil
+}
+
+// identifiers resolves the short-form DIDs of the node
+func (n *testNode) identifiers(w http.ResponseWriter, r *http.Request) {
+	didID := strings.TrimPrefix(r.URL.Path, identifiersPath)
+	suffix := strings.Split(strings.TrimPrefix(didID, "did:"+n.method+":"), ":")[0]
+
+	n.mu.Lock()
+	state, ok := n.states[suffix]
+	n.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	rawDoc := rawDIDDocument(didID, state.doc)
+	// the Sidetree nodes add the base of the relative IDs to the contexts
+	rawDoc["@context"] = []interface{}{rawDoc["@context"].([]string)[0], map[string]interface{}{"@base": didID}}
+
+	result := map[string]interface{}{
+		"@context":            "https://w3id.org/did-resolution/v1",
+		"didDocument":         rawDoc,
+		"didDocumentMetadata": map[string]interface{}{"deactivated": state.deactivated},
+	}
+
+	if state.deactivated {
+		w.WriteHeader(http.StatusGone)
+	}
+
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		panic(err)
+	}
+}
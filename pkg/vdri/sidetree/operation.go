@@ -0,0 +1,367 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+	multihash "github.com/multiformats/go-multihash"
+
+	"github.com/hyperledger/aries-framework-go/pkg/internal/jcs"
+)
+
+const (
+	// edDSA is the JWS algorithm of the signed data, the operation keys are Ed25519 KMS keys
+	edDSA = "EdDSA"
+
+	okpKeyType = "OKP"
+	ed25519Crv = "Ed25519"
+)
+
+// encode returns the base64url encoding of data without padding, the encoding of the Sidetree values
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// multihashOf returns the SHA2-256 multihash of data
+func multihashOf(data []byte) ([]byte, error) {
+	mh, err := multihash.Sum(data, multihash.SHA2_256, -1)
+	if err != nil {
+		return nil, fmt.Errorf("multihash: %w", err)
+	}
+
+	return mh, nil
+}
+
+// canonicalHash returns the encoded multihash of the JCS canonical JSON of v
+func canonicalHash(v interface{}) (string, error) {
+	data, err := jcs.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	mh, err := multihashOf(data)
+	if err != nil {
+		return "", err
+	}
+
+	return encode(mh), nil
+}
+
+// revealValue returns the reveal value of the operation key jwk, the encoded multihash of its canonical JSON
+func revealValue(jwk *JWK) (string, error) {
+	return canonicalHash(jwk)
+}
+
+// commitment returns the commitment to the operation key jwk, the encoded multihash of its reveal value
+// multihash, so that the reveal value of the next operation matches the commitment of the previous one
+func commitment(jwk *JWK) (string, error) {
+	data, err := jcs.Marshal(jwk)
+	if err != nil {
+		return "", err
+	}
+
+	mh, err := multihashOf(data)
+	if err != nil {
+		return "", err
+	}
+
+	commit, err := multihashOf(mh)
+	if err != nil {
+		return "", err
+	}
+
+	return encode(commit), nil
+}
+
+// ed25519JWK returns the JWK of the KMS Ed25519 public key in base58
+func ed25519JWK(base58Key string) *JWK {
+	return &JWK{Kty: okpKeyType, Crv: ed25519Crv, X: encode(base58.Decode(base58Key))}
+}
+
+// sign returns the compact JWS of the canonical JSON of payload, signed by the KMS key of the base58 public key
+func (v *VDRI) sign(payload interface{}, base58Key string) (string, error) {
+	header, err := jcs.Marshal(&jwsHeader{Alg: edDSA})
+	if err != nil {
+		return "", err
+	}
+
+	data, err := jcs.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encode(header) + "." + encode(data)
+
+	signature, err := v.signer.SignMessage([]byte(signingInput), base58Key)
+	if err != nil {
+		return "", fmt.Errorf("sign operation: %w", err)
+	}
+
+	return signingInput + "." + encode(signature), nil
+}
+
+// newOperationKeys creates the KMS keys of the next update and recover operations, the recovery key is
+// kept when recoveryKey is not empty
+func (v *VDRI) newOperationKeys(recoveryKey string) (*operationKeys, error) {
+	_, updateKey, err := v.kms.CreateKeySet()
+	if err != nil {
+		return nil, fmt.Errorf("create update key: %w", err)
+	}
+
+	if recoveryKey == "" {
+		_, recoveryKey, err = v.kms.CreateKeySet()
+		if err != nil {
+			return nil, fmt.Errorf("create recovery key: %w", err)
+		}
+	}
+
+	return &operationKeys{UpdateKey: updateKey, RecoveryKey: recoveryKey}, nil
+}
+
+// newDelta returns the delta with the patches, committing to the next update key
+func newDelta(patches []Patch, updateKey string) (*delta, error) {
+	updateCommitment, err := commitment(ed25519JWK(updateKey))
+	if err != nil {
+		return nil, err
+	}
+
+	return &delta{Patches: patches, UpdateCommitment: updateCommitment}, nil
+}
+
+// newCreateRequest returns the create request of the DID with the document and the operation keys
+func newCreateRequest(doc *Document, keys *operationKeys) (*request, error) {
+	d, err := newDelta([]Patch{{Action: replaceAction, Document: doc}}, keys.UpdateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	deltaHash, err := canonicalHash(d)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCommitment, err := commitment(ed25519JWK(keys.RecoveryKey))
+	if err != nil {
+		return nil, err
+	}
+
+	return &request{
+		Type:       createOperation,
+		SuffixData: &suffixData{DeltaHash: deltaHash, RecoveryCommitment: recoveryCommitment},
+		Delta:      d,
+	}, nil
+}
+
+// newUpdateRequest returns the update request of the DID suffix with the patches, signed by the current
+// update key and committing to the next update key
+func (v *VDRI) newUpdateRequest(suffix string, patches []Patch, current, next *operationKeys) (*request, error) {
+	d, err := newDelta(patches, next.UpdateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	deltaHash, err := canonicalHash(d)
+	if err != nil {
+		return nil, err
+	}
+
+	updateJWK := ed25519JWK(current.UpdateKey)
+
+	reveal, err := revealValue(updateJWK)
+	if err != nil {
+		return nil, err
+	}
+
+	signedData, err := v.sign(&updateSignedData{UpdateKey: updateJWK, DeltaHash: deltaHash}, current.UpdateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &request{
+		Type:        updateOperation,
+		DIDSuffix:   suffix,
+		RevealValue: reveal,
+		Delta:       d,
+		SignedData:  signedData,
+	}, nil
+}
+
+// newRecoverRequest returns the recover request of the DID suffix replacing its document, signed by the
+// current recovery key and committing to the next operation keys
+func (v *VDRI) newRecoverRequest(suffix string, doc *Document, current, next *operationKeys) (*request, error) {
+	d, err := newDelta([]Patch{{Action: replaceAction, Document: doc}}, next.UpdateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	deltaHash, err := canonicalHash(d)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryJWK := ed25519JWK(current.RecoveryKey)
+
+	reveal, err := revealValue(recoveryJWK)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCommitment, err := commitment(ed25519JWK(next.RecoveryKey))
+	if err != nil {
+		return nil, err
+	}
+
+	signedData, err := v.sign(&recoverSignedData{
+		RecoveryCommitment: recoveryCommitment,
+		RecoveryKey:        recoveryJWK,
+		DeltaHash:          deltaHash,
+	}, current.RecoveryKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &request{
+		Type:        recoverOperation,
+		DIDSuffix:   suffix,
+		RevealValue: reveal,
+		Delta:       d,
+		SignedData:  signedData,
+	}, nil
+}
+
+// newDeactivateRequest returns the deactivate request of the DID suffix, signed by the current recovery key
+func (v *VDRI) newDeactivateRequest(suffix string, current *operationKeys) (*request, error) {
+	recoveryJWK := ed25519JWK(current.RecoveryKey)
+
+	reveal, err := revealValue(recoveryJWK)
+	if err != nil {
+		return nil, err
+	}
+
+	signedData, err := v.sign(&deactivateSignedData{DIDSuffix: suffix, RecoveryKey: recoveryJWK},
+		current.RecoveryKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &request{
+		Type:        deactivateOperation,
+		DIDSuffix:   suffix,
+		RevealValue: reveal,
+		SignedData:  signedData,
+	}, nil
+}
+
+// send posts the operation request to the Sidetree node
+func (v *VDRI) send(req *request) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("marshal %s request: %w", req.Type, err)
+	}
+
+	resp, err := v.client.Post(v.endpoint+operationsPath, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("HTTP Post request failed: %w", err)
+	}
+
+	defer closeResponseBody(resp.Body)
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response body failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s operation rejected with status '%d': %s", req.Type, resp.StatusCode, body)
+	}
+
+	return nil
+}
+
+// parseDID returns the unique suffix of the short-form or long-form Sidetree DID, and the initial state
+// of the long-form DID. The initial state must match the suffix.
+func (v *VDRI) parseDID(didID string) (string, *initialState, error) {
+	if !strings.HasPrefix(didID, v.prefix()) {
+		return "", nil, fmt.Errorf("invalid did:%s: %s", v.method, didID)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(didID, v.prefix()), ":")
+	if parts[0] == "" || len(parts) > 2 {
+		return "", nil, fmt.Errorf("invalid did:%s: %s", v.method, didID)
+	}
+
+	suffix := parts[0]
+
+	if len(parts) == 1 {
+		return suffix, nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", nil, fmt.Errorf("decode long-form initial state: %w", err)
+	}
+
+	state := &initialState{}
+
+	err = json.Unmarshal(data, state)
+	if err != nil {
+		return "", nil, fmt.Errorf("unmarshal long-form initial state: %w", err)
+	}
+
+	if state.SuffixData == nil || state.Delta == nil {
+		return "", nil, errors.New("long-form initial state is incomplete")
+	}
+
+	err = state.validate(suffix)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return suffix, state, nil
+}
+
+// validate checks the initial state hashes to the DID suffix, and its delta to the delta hash
+func (s *initialState) validate(suffix string) error {
+	suffixHash, err := canonicalHash(s.SuffixData)
+	if err != nil {
+		return err
+	}
+
+	if suffixHash != suffix {
+		return errors.New("long-form initial state does not match the DID suffix")
+	}
+
+	deltaHash, err := canonicalHash(s.Delta)
+	if err != nil {
+		return err
+	}
+
+	if deltaHash != s.SuffixData.DeltaHash {
+		return errors.New("long-form delta does not match the delta hash")
+	}
+
+	return nil
+}
+
+// longFormDID returns the long-form DID of the suffix, carrying the initial state of the DID
+func (v *VDRI) longFormDID(suffix string, state *initialState) (string, error) {
+	data, err := jcs.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+
+	return v.prefix() + suffix + ":" + encode(data), nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+// rawResolutionResult is the resolution result of the Sidetree node, the JSON-LD contexts of its
+// DID document are filtered before the document is parsed
+type rawResolutionResult struct {
+	DIDDocument      map[string]interface{}   `json:"didDocument"`
+	DocumentMetadata vdriapi.DocumentMetadata `json:"didDocumentMetadata"`
+}
+
+// Read resolves the short-form or long-form Sidetree DID didID with the Sidetree node
+func (v *VDRI) Read(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error) {
+	result, err := v.ReadResult(didID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.DIDDocument, nil
+}
+
+// ReadResult resolves the short-form or long-form Sidetree DID didID with the Sidetree node, with the
+// document metadata. The long-form DIDs not anchored yet resolve to the DID document of their initial state.
+func (v *VDRI) ReadResult(didID string, _ ...vdriapi.ResolveOpts) (*vdriapi.DIDResolutionResult, error) {
+	_, state, err := v.parseDID(didID)
+	if err != nil {
+		return nil, fmt.Errorf("read sidetree DID: %w", err)
+	}
+
+	result, err := v.resolve(didID)
+	if errors.Is(err, vdriapi.ErrNotFound) && state != nil {
+		return resolveInitialState(didID, state)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read sidetree DID: %w", err)
+	}
+
+	return result, nil
+}
+
+// resolve gets the resolution result of didID from the Sidetree node
+func (v *VDRI) resolve(didID string) (*vdriapi.DIDResolutionResult, error) {
+	resp, err := v.client.Get(v.endpoint + identifiersPath + didID)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP Get request failed: %w", err)
+	}
+
+	defer closeResponseBody(resp.Body)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", vdriapi.ErrNotFound, didID)
+	}
+
+	// the deactivated DIDs are resolved with the gone status
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusGone {
+		return nil, fmt.Errorf("got unexpected response status '%d' from the sidetree node", resp.StatusCode)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body failed: %w", err)
+	}
+
+	raw := &rawResolutionResult{}
+
+	err = json.Unmarshal(data, raw)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal resolution result: %w", err)
+	}
+
+	if raw.DIDDocument == nil {
+		return nil, errors.New("resolution result without DID document")
+	}
+
+	docBytes, err := json.Marshal(filterContexts(raw.DIDDocument))
+	if err != nil {
+		return nil, fmt.Errorf("marshal DID document: %w", err)
+	}
+
+	doc, err := did.ParseDocument(docBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse DID document: %w", err)
+	}
+
+	return &vdriapi.DIDResolutionResult{
+		DIDDocument:        doc,
+		ResolutionMetadata: vdriapi.ResolutionMetadata{ContentType: vdriapi.DIDDocumentContentType},
+		DocumentMetadata:   raw.DocumentMetadata,
+	}, nil
+}
+
+// resolveInitialState resolves the long-form DID didID to the DID document of its initial state
+func resolveInitialState(didID string, state *initialState) (*vdriapi.DIDResolutionResult, error) {
+	doc, err := applyPatches(nil, state.Delta.Patches)
+	if err != nil {
+		return nil, fmt.Errorf("read sidetree DID: %w", err)
+	}
+
+	didDoc, err := didDocument(didID, doc)
+	if err != nil {
+		return nil, fmt.Errorf("read sidetree DID: %w", err)
+	}
+
+	return &vdriapi.DIDResolutionResult{
+		DIDDocument:        didDoc,
+		ResolutionMetadata: vdriapi.ResolutionMetadata{ContentType: vdriapi.DIDDocumentContentType},
+	}, nil
+}
+
+// filterContexts keeps the JSON-LD context URIs of the DID document, the Sidetree nodes add context
+// objects (e.g {"@base": "did:sidetree:..."}) which are not supported by did.Doc
+func filterContexts(rawDoc map[string]interface{}) map[string]interface{} {
+	contexts, ok := rawDoc["@context"].([]interface{})
+	if !ok {
+		return rawDoc
+	}
+
+	var uris []interface{}
+
+	for _, ctx := range contexts {
+		if _, ok := ctx.(string); ok {
+			uris = append(uris, ctx)
+		}
+	}
+
+	rawDoc["@context"] = uris
+
+	return rawDoc
+}
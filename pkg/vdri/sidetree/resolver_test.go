@@ -0,0 +1,173 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+func TestRead(t *testing.T) {
+	node := newTestNode()
+	defer node.Close()
+
+	v, err := New(node.URL, newTestProvider(t))
+	require.NoError(t, err)
+
+	didDoc, err := v.Build(&vdriapi.PubKey{Value: testPubKey, Type: ed25519Key})
+	require.NoError(t, err)
+
+	longForm, err := v.LongFormDID(didDoc.ID)
+	require.NoError(t, err)
+
+	t.Run("test read short-form DID", func(t *testing.T) {
+		result, err := v.ReadResult(didDoc.ID)
+		require.NoError(t, err)
+		require.Equal(t, vdriapi.DIDDocumentContentType, result.ResolutionMetadata.ContentType)
+		require.False(t, result.DocumentMetadata.Deactivated)
+		require.Equal(t, didDoc.VerificationMethod, result.DIDDocument.VerificationMethod)
+	})
+
+	t.Run("test read anchored long-form DID", func(t *testing.T) {
+		resolved, err := v.Read(longForm)
+		require.NoError(t, err)
+		require.Equal(t, longForm, resolved.ID)
+		require.Len(t, resolved.VerificationMethod, 1)
+	})
+
+	t.Run("test read long-form DID not anchored", func(t *testing.T) {
+		empty := newTestNode()
+		defer empty.Close()
+
+		r, err := New(empty.URL, newTestProvider(t))
+		require.NoError(t, err)
+
+		resolved, err := r.Read(longForm)
+		require.NoError(t, err)
+		require.Equal(t, longForm, resolved.ID)
+		require.Len(t, resolved.VerificationMethod, 1)
+		require.Equal(t, didDoc.VerificationMethod[0].Value, resolved.VerificationMethod[0].Value)
+		require.Len(t, resolved.Authentication, 1)
+
+		_, err = r.Read(didDoc.ID)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, vdriapi.ErrNotFound))
+	})
+
+	t.Run("test read invalid long-form DIDs", func(t *testing.T) {
+		suffix := strings.TrimPrefix(didDoc.ID, "did:sidetree:")
+		state := strings.TrimPrefix(longForm, didDoc.ID+":")
+
+		tests := []struct {
+			name   string
+			didID  string
+			errMsg string
+		}{
+			{"invalid method", "did:example:" + suffix, "invalid did:sidetree"},
+			{"empty suffix", "did:sidetree:", "invalid did:sidetree"},
+			{"too many parts", longForm + ":x", "invalid did:sidetree"},
+			{"invalid encoding", didDoc.ID + ":!", "decode long-form initial state"},
+			{"invalid JSON", didDoc.ID + ":" + encode([]byte("{")), "unmarshal long-form initial state"},
+			{"incomplete state", didDoc.ID + ":" + encode([]byte("{}")), "long-form initial state is incomplete"},
+			{"other suffix", "did:sidetree:EiDahaOGH-liLLdDtTxEAdc8i-cfCz-WUcQdRJheMVNn3A:" + state,
+				"long-form initial state does not match the DID suffix"},
+		}
+
+		for _, tt := range tests {
+			tc := tt
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := v.Read(tc.didID)
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("test read long-form DID with tampered delta", func(t *testing.T) {
+		_, state, err := v.parseDID(longForm)
+		require.NoError(t, err)
+
+		state.Delta.UpdateCommitment = "tampered"
+
+		tampered, err := v.longFormDID(strings.TrimPrefix(didDoc.ID, "did:sidetree:"), state)
+		require.NoError(t, err)
+
+		_, err = v.Read(tampered)
+		require.EqualError(t, err, "read sidetree DID: long-form delta does not match the delta hash")
+	})
+}
+
+func TestRead_NodeErrors(t *testing.T) {
+	const didID = "did:sidetree:EiDahaOGH-liLLdDtTxEAdc8i-cfCz-WUcQdRJheMVNn3A"
+
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		errMsg string
+	}{
+		{"unexpected status", http.StatusInternalServerError, "", "got unexpected response status '500'"},
+		{"invalid result", http.StatusOK, "{", "unmarshal resolution result"},
+		{"missing document", http.StatusOK, "{}", "resolution result without DID document"},
+		{"invalid document", http.StatusOK, `{"didDocument":{"id":"` + didID + `"}}`, "parse DID document"},
+	}
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				fmt.Fprint(w, tc.body)
+			}))
+			defer srv.Close()
+
+			v, err := New(srv.URL, newTestProvider(t))
+			require.NoError(t, err)
+
+			_, err = v.Read(didID)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+
+	t.Run("unreachable node", func(t *testing.T) {
+		v, err := New("http://localhost:1", newTestProvider(t))
+		require.NoError(t, err)
+
+		_, err = v.Read(didID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "HTTP Get request failed")
+	})
+}
+
+func TestApplyPatches(t *testing.T) {
+	doc, err := applyPatches(nil, []Patch{
+		{Action: replaceAction, Document: &Document{PublicKeys: []PublicKey{{ID: "key-1"}}}},
+		AddPublicKeys(PublicKey{ID: "key-1", Type: ed25519Key}, PublicKey{ID: "key-2"}),
+		AddServices(Service{ID: "svc-1"}),
+		RemovePublicKeys("key-2"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []PublicKey{{ID: "key-1", Type: ed25519Key}}, doc.PublicKeys)
+	require.Equal(t, []Service{{ID: "svc-1"}}, doc.Services)
+
+	doc, err = applyPatches(doc, []Patch{{Action: replaceAction}})
+	require.NoError(t, err)
+	require.Empty(t, doc.PublicKeys)
+	require.Empty(t, doc.Services)
+
+	_, err = applyPatches(doc, []Patch{{Action: "ietf-json-patch"}})
+	require.EqualError(t, err, "patch action 'ietf-json-patch' not supported")
+}
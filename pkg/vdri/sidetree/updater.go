@@ -0,0 +1,160 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+// Update updates the DID document of the Sidetree DID built by this vdri with doc. The public keys and the
+// services of doc are compared with the resolved DID document, and the update operation patches the changes.
+// The signer option is ignored, the operations are signed with the KMS keys of the DID.
+func (v *VDRI) Update(doc *did.Doc, _ ...vdriapi.UpdateOpts) error {
+	if doc == nil || doc.ID == "" {
+		return errors.New("update sidetree DID: DID and document are mandatory")
+	}
+
+	next, err := fromDIDDocument(doc)
+	if err != nil {
+		return fmt.Errorf("update sidetree DID: %w", err)
+	}
+
+	current, err := v.Read(doc.ID)
+	if err != nil {
+		return fmt.Errorf("update sidetree DID: %w", err)
+	}
+
+	currentDoc, err := fromDIDDocument(current)
+	if err != nil {
+		return fmt.Errorf("update sidetree DID: %w", err)
+	}
+
+	patches := diffPatches(currentDoc, next)
+	if len(patches) == 0 {
+		logger.Debugf("no change in the DID document of %s", doc.ID)
+		return nil
+	}
+
+	return v.UpdatePatches(doc.ID, patches...)
+}
+
+// UpdatePatches applies the patches to the DID document of the Sidetree DID built by this vdri. The update
+// operation is signed by the current update key, and commits to a new update key.
+func (v *VDRI) UpdatePatches(didID string, patches ...Patch) error {
+	if len(patches) == 0 {
+		return errors.New("update sidetree DID: missing patches")
+	}
+
+	suffix, _, err := v.parseDID(didID)
+	if err != nil {
+		return fmt.Errorf("update sidetree DID: %w", err)
+	}
+
+	current, err := v.getKeys(suffix)
+	if err != nil {
+		return fmt.Errorf("update sidetree DID: %w", err)
+	}
+
+	next, err := v.newOperationKeys(current.RecoveryKey)
+	if err != nil {
+		return fmt.Errorf("update sidetree DID: %w", err)
+	}
+
+	req, err := v.newUpdateRequest(suffix, patches, current, next)
+	if err != nil {
+		return fmt.Errorf("update sidetree DID: %w", err)
+	}
+
+	return v.sendAndRotate(req, suffix, next)
+}
+
+// Recover replaces the DID document of the Sidetree DID built by this vdri with a document of the public
+// key, and a service with the service type and endpoint options. The recover operation is signed by the
+// current recovery key, and commits to new update and recovery keys.
+func (v *VDRI) Recover(didID string, pubKey *vdriapi.PubKey, opts ...vdriapi.DocOpts) error {
+	if pubKey == nil || pubKey.Value == "" {
+		return errors.New("recover sidetree DID: public key is empty")
+	}
+
+	suffix, _, err := v.parseDID(didID)
+	if err != nil {
+		return fmt.Errorf("recover sidetree DID: %w", err)
+	}
+
+	current, err := v.getKeys(suffix)
+	if err != nil {
+		return fmt.Errorf("recover sidetree DID: %w", err)
+	}
+
+	docOpts := &vdriapi.CreateDIDOpts{}
+
+	for _, opt := range opts {
+		opt(docOpts)
+	}
+
+	next, err := v.newOperationKeys("")
+	if err != nil {
+		return fmt.Errorf("recover sidetree DID: %w", err)
+	}
+
+	req, err := v.newRecoverRequest(suffix, newDocument(pubKey, docOpts), current, next)
+	if err != nil {
+		return fmt.Errorf("recover sidetree DID: %w", err)
+	}
+
+	return v.sendAndRotate(req, suffix, next)
+}
+
+// Deactivate deactivates the Sidetree DID built by this vdri, the deactivate operation is signed by the
+// current recovery key. The operation keys of the DID are removed from the store.
+func (v *VDRI) Deactivate(didID string, _ ...vdriapi.UpdateOpts) error {
+	suffix, _, err := v.parseDID(didID)
+	if err != nil {
+		return fmt.Errorf("deactivate sidetree DID: %w", err)
+	}
+
+	current, err := v.getKeys(suffix)
+	if err != nil {
+		return fmt.Errorf("deactivate sidetree DID: %w", err)
+	}
+
+	req, err := v.newDeactivateRequest(suffix, current)
+	if err != nil {
+		return fmt.Errorf("deactivate sidetree DID: %w", err)
+	}
+
+	err = v.send(req)
+	if err != nil {
+		return fmt.Errorf("deactivate sidetree DID: %w", err)
+	}
+
+	err = v.store.Delete(suffix)
+	if err != nil {
+		return fmt.Errorf("deactivate sidetree DID: delete operation keys: %w", err)
+	}
+
+	return nil
+}
+
+// sendAndRotate sends the operation request, and stores the keys of the next operations once it is accepted
+func (v *VDRI) sendAndRotate(req *request, suffix string, next *operationKeys) error {
+	err := v.send(req)
+	if err != nil {
+		return fmt.Errorf("%s sidetree DID: %w", req.Type, err)
+	}
+
+	err = v.putKeys(suffix, next)
+	if err != nil {
+		return fmt.Errorf("%s sidetree DID: %w", req.Type, err)
+	}
+
+	return nil
+}
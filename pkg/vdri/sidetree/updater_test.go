@@ -0,0 +1,310 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms/legacykms"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const otherPubKey = "H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"
+
+func TestUpdate(t *testing.T) {
+	node := newTestNode()
+	defer node.Close()
+
+	v, err := New(node.URL, newTestProvider(t))
+	require.NoError(t, err)
+
+	didDoc, err := v.Build(&vdriapi.PubKey{Value: testPubKey, Type: ed25519Key})
+	require.NoError(t, err)
+
+	t.Run("test update of DID document", func(t *testing.T) {
+		doc, err := v.Read(didDoc.ID)
+		require.NoError(t, err)
+
+		key := did.PublicKey{ID: didDoc.ID + "#key-2", Type: ed25519Key, Controller: didDoc.ID,
+			Value: base58.Decode(otherPubKey)}
+		doc.VerificationMethod = append(doc.VerificationMethod, key)
+		doc.CapabilityInvocation = []did.VerificationMethod{{PublicKey: key}}
+		doc.Service = []did.Service{{ID: didDoc.ID + "#hub", Type: "IdentityHub",
+			ServiceEndpoint: "https://example.com/hub"}}
+
+		require.NoError(t, v.Update(doc))
+
+		resolved, err := v.Read(didDoc.ID)
+		require.NoError(t, err)
+		require.Len(t, resolved.VerificationMethod, 2)
+		require.Len(t, resolved.CapabilityInvocation, 1)
+		require.Equal(t, didDoc.ID+"#key-2", resolved.CapabilityInvocation[0].PublicKey.ID)
+		require.Len(t, resolved.Service, 1)
+		require.Equal(t, didDoc.ID+"#hub", resolved.Service[0].ID)
+
+		// an unchanged document is not sent to the node
+		require.NoError(t, v.Update(resolved))
+
+		resolved.VerificationMethod = resolved.VerificationMethod[:1]
+		resolved.CapabilityInvocation = nil
+		resolved.Service = nil
+
+		require.NoError(t, v.Update(resolved))
+
+		resolved, err = v.Read(didDoc.ID)
+		require.NoError(t, err)
+		require.Len(t, resolved.VerificationMethod, 1)
+		require.Empty(t, resolved.CapabilityInvocation)
+		require.Empty(t, resolved.Service)
+	})
+
+	t.Run("test update without document", func(t *testing.T) {
+		err = v.Update(nil)
+		require.EqualError(t, err, "update sidetree DID: DID and document are mandatory")
+
+		err = v.Update(&did.Doc{})
+		require.EqualError(t, err, "update sidetree DID: DID and document are mandatory")
+	})
+
+	t.Run("test update of unresolvable DID", func(t *testing.T) {
+		err = v.Update(&did.Doc{ID: "did:sidetree:EiDahaOGH-liLLdDtTxEAdc8i-cfCz-WUcQdRJheMVNn3A"})
+		require.Error(t, err)
+		require.True(t, errors.Is(err, vdriapi.ErrNotFound))
+	})
+
+	t.Run("test update with unsupported key", func(t *testing.T) {
+		doc, err := v.Read(didDoc.ID)
+		require.NoError(t, err)
+
+		doc.VerificationMethod[0].Encoding = ""
+		doc.VerificationMethod[0].Value = []byte("short")
+
+		err = v.Update(doc)
+		require.EqualError(t, err, "update sidetree DID: public key "+didDoc.ID+
+			"#key-1: key type '"+ed25519Key+"' not supported")
+	})
+}
+
+func TestDiffPatches(t *testing.T) {
+	current := &Document{
+		PublicKeys: []PublicKey{{ID: "key-1", Type: ed25519Key}, {ID: "key-2", Type: ed25519Key}},
+		Services:   []Service{{ID: "svc-1", Type: "hub"}, {ID: "svc-2", Type: "hub"}},
+	}
+
+	next := &Document{
+		PublicKeys: []PublicKey{{ID: "key-1", Type: ed25519Key}, {ID: "key-3", Type: ed25519Key}},
+		Services:   []Service{{ID: "svc-1", Type: "hub"}, {ID: "svc-2", Type: "mediator"}},
+	}
+
+	require.Empty(t, diffPatches(current, current))
+	require.Equal(t, []Patch{
+		RemovePublicKeys("key-2"),
+		RemoveServices("svc-2"),
+		AddPublicKeys(PublicKey{ID: "key-3", Type: ed25519Key}),
+		AddServices(Service{ID: "svc-2", Type: "mediator"}),
+	}, diffPatches(current, next))
+}
+
+func TestUpdatePatches(t *testing.T) {
+	node := newTestNode()
+	defer node.Close()
+
+	v, err := New(node.URL, newTestProvider(t))
+	require.NoError(t, err)
+
+	didDoc, err := v.Build(&vdriapi.PubKey{Value: testPubKey, Type: ed25519Key})
+	require.NoError(t, err)
+
+	t.Run("test update", func(t *testing.T) {
+		err = v.UpdatePatches(didDoc.ID,
+			AddPublicKeys(PublicKey{
+				ID:       "key-2",
+				Type:     ed25519Key,
+				JWK:      ed25519JWK(otherPubKey),
+				Purposes: []string{PurposeCapabilityInvocation},
+			}),
+			AddServices(Service{ID: "hub", Type: "IdentityHub", ServiceEndpoint: "https://example.com/hub"}))
+		require.NoError(t, err)
+
+		resolved, err := v.Read(didDoc.ID)
+		require.NoError(t, err)
+		require.Len(t, resolved.VerificationMethod, 2)
+		require.Len(t, resolved.CapabilityInvocation, 1)
+		require.Equal(t, didDoc.ID+"#key-2", resolved.CapabilityInvocation[0].PublicKey.ID)
+		require.Len(t, resolved.Service, 1)
+
+		// the update key is rotated, the next update is signed by the new key
+		err = v.UpdatePatches(didDoc.ID, RemovePublicKeys("key-2"), RemoveServices("hub"))
+		require.NoError(t, err)
+
+		resolved, err = v.Read(didDoc.ID)
+		require.NoError(t, err)
+		require.Len(t, resolved.VerificationMethod, 1)
+		require.Empty(t, resolved.CapabilityInvocation)
+		require.Empty(t, resolved.Service)
+	})
+
+	t.Run("test update with stale update key", func(t *testing.T) {
+		suffix := strings.TrimPrefix(didDoc.ID, "did:sidetree:")
+
+		keys, err := v.getKeys(suffix)
+		require.NoError(t, err)
+
+		require.NoError(t, v.UpdatePatches(didDoc.ID, RemoveServices("hub")))
+		require.NoError(t, v.putKeys(suffix, keys))
+
+		err = v.UpdatePatches(didDoc.ID, RemoveServices("hub"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "reveal value does not match the commitment")
+	})
+
+	t.Run("test update without patches", func(t *testing.T) {
+		err = v.UpdatePatches(didDoc.ID)
+		require.EqualError(t, err, "update sidetree DID: missing patches")
+	})
+
+	t.Run("test update of DID not built by the vdri", func(t *testing.T) {
+		err = v.UpdatePatches("did:sidetree:EiDahaOGH-liLLdDtTxEAdc8i-cfCz-WUcQdRJheMVNn3A", RemoveServices("hub"))
+		require.Error(t, err)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("test update of invalid DID", func(t *testing.T) {
+		err = v.UpdatePatches("did:example:123", RemoveServices("hub"))
+		require.EqualError(t, err, "update sidetree DID: invalid did:sidetree: did:example:123")
+	})
+
+	t.Run("test update with signer error", func(t *testing.T) {
+		p := newTestProvider(t)
+		p.SignerValue = &mockkms.CloseableKMS{SignMessageErr: errors.New("sign error")}
+
+		s, err := New(node.URL, p)
+		require.NoError(t, err)
+
+		d, err := s.Build(&vdriapi.PubKey{Value: testPubKey, Type: ed25519Key})
+		require.NoError(t, err)
+
+		err = s.UpdatePatches(d.ID, RemoveServices("hub"))
+		require.EqualError(t, err, "update sidetree DID: sign operation: sign error")
+	})
+}
+
+func TestRecover(t *testing.T) {
+	node := newTestNode()
+	defer node.Close()
+
+	v, err := New(node.URL, newTestProvider(t))
+	require.NoError(t, err)
+
+	didDoc, err := v.Build(&vdriapi.PubKey{Value: testPubKey, Type: ed25519Key})
+	require.NoError(t, err)
+
+	suffix := strings.TrimPrefix(didDoc.ID, "did:sidetree:")
+
+	before, err := v.getKeys(suffix)
+	require.NoError(t, err)
+
+	t.Run("test recover", func(t *testing.T) {
+		err = v.Recover(didDoc.ID, &vdriapi.PubKey{Value: otherPubKey, Type: ed25519Key},
+			vdriapi.WithServiceType("IdentityHub"), vdriapi.WithServiceEndpoint("https://example.com/hub"))
+		require.NoError(t, err)
+
+		resolved, err := v.Read(didDoc.ID)
+		require.NoError(t, err)
+		require.Len(t, resolved.VerificationMethod, 1)
+		require.Equal(t, ed25519JWK(otherPubKey).X, encode(resolved.VerificationMethod[0].Value))
+		require.Len(t, resolved.Service, 1)
+
+		// both operation keys are rotated
+		after, err := v.getKeys(suffix)
+		require.NoError(t, err)
+		require.NotEqual(t, before.UpdateKey, after.UpdateKey)
+		require.NotEqual(t, before.RecoveryKey, after.RecoveryKey)
+
+		// the new update key is committed
+		require.NoError(t, v.UpdatePatches(didDoc.ID, RemoveServices("endpoint-1")))
+	})
+
+	t.Run("test recover with empty public key", func(t *testing.T) {
+		err = v.Recover(didDoc.ID, nil)
+		require.EqualError(t, err, "recover sidetree DID: public key is empty")
+	})
+
+	t.Run("test recover of DID not built by the vdri", func(t *testing.T) {
+		err = v.Recover("did:sidetree:EiDahaOGH-liLLdDtTxEAdc8i-cfCz-WUcQdRJheMVNn3A",
+			&vdriapi.PubKey{Value: otherPubKey, Type: ed25519Key})
+		require.Error(t, err)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("test recover of invalid DID", func(t *testing.T) {
+		err = v.Recover("did:example:123", &vdriapi.PubKey{Value: otherPubKey, Type: ed25519Key})
+		require.EqualError(t, err, "recover sidetree DID: invalid did:sidetree: did:example:123")
+	})
+}
+
+func TestDeactivate(t *testing.T) {
+	node := newTestNode()
+	defer node.Close()
+
+	v, err := New(node.URL, newTestProvider(t))
+	require.NoError(t, err)
+
+	didDoc, err := v.Build(&vdriapi.PubKey{Value: testPubKey, Type: ed25519Key})
+	require.NoError(t, err)
+
+	t.Run("test deactivate", func(t *testing.T) {
+		require.NoError(t, v.Deactivate(didDoc.ID))
+
+		result, err := v.ReadResult(didDoc.ID)
+		require.NoError(t, err)
+		require.True(t, result.DocumentMetadata.Deactivated)
+		require.Equal(t, didDoc.ID, result.DIDDocument.ID)
+		require.Empty(t, result.DIDDocument.VerificationMethod)
+		require.Equal(t, []string{did.CoreContext}, result.DIDDocument.Context)
+
+		// the operation keys are removed
+		err = v.UpdatePatches(didDoc.ID, RemoveServices("hub"))
+		require.Error(t, err)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("test deactivate of DID not built by the vdri", func(t *testing.T) {
+		err = v.Deactivate(didDoc.ID)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("test deactivate of invalid DID", func(t *testing.T) {
+		err = v.Deactivate("did:example:123")
+		require.EqualError(t, err, "deactivate sidetree DID: invalid did:sidetree: did:example:123")
+	})
+
+	t.Run("test deactivate rejected by the node", func(t *testing.T) {
+		d, err := v.Build(&vdriapi.PubKey{Value: testPubKey, Type: ed25519Key})
+		require.NoError(t, err)
+
+		suffix := strings.TrimPrefix(d.ID, "did:sidetree:")
+
+		keys, err := v.getKeys(suffix)
+		require.NoError(t, err)
+
+		keys.RecoveryKey = keys.UpdateKey
+		require.NoError(t, v.putKeys(suffix, keys))
+
+		err = v.Deactivate(d.ID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "deactivate operation rejected with status '400'")
+	})
+}
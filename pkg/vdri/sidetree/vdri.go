@@ -0,0 +1,134 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/legacykms"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+var logger = log.New("aries-framework/vdri/sidetree")
+
+const (
+	// StoreNamespace store name space of the operation keys of the Sidetree DIDs
+	StoreNamespace = "sidetree"
+
+	defaultMethod   = "sidetree"
+	operationsPath  = "/operations"
+	identifiersPath = "/identifiers/"
+)
+
+// provider contains dependencies for the Sidetree vdri and is typically created by using aries.Context()
+type provider interface {
+	LegacyKMS() legacykms.KeyManager
+	Signer() legacykms.Signer
+	StorageProvider() storage.Provider
+}
+
+// VDRI implements a Sidetree DID method (https://identity.foundation/sidetree/spec/) client. The operations
+// are sent to the REST API of a Sidetree node, the update and recovery keys of the DIDs are KMS keys.
+type VDRI struct {
+	endpoint string
+	method   string
+	client   *http.Client
+	kms      legacykms.KeyManager
+	signer   legacykms.Signer
+	store    storage.Store
+}
+
+// New returns new instance of Sidetree vdri sending the operations to the Sidetree node at endpoint
+// (e.g https://example.com/sidetree/v1)
+func New(endpoint string, p provider, opts ...Option) (*VDRI, error) {
+	if endpoint == "" {
+		return nil, errors.New("sidetree endpoint is empty")
+	}
+
+	store, err := p.StorageProvider().OpenStore(StoreNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("open store : %w", err)
+	}
+
+	v := &VDRI{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		method:   defaultMethod,
+		client:   &http.Client{},
+		kms:      p.LegacyKMS(),
+		signer:   p.Signer(),
+		store:    store,
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v, nil
+}
+
+// Accept did method
+func (v *VDRI) Accept(method string) bool {
+	return method == v.method
+}
+
+// Store is a no-op, the Sidetree DID documents are anchored by the operations sent to the Sidetree node
+func (v *VDRI) Store(doc *did.Doc, by *[]vdriapi.ModifiedBy) error {
+	logger.Debugf("store not supported in sidetree vdri, the DID document is anchored by the create operation")
+	return nil
+}
+
+// Close frees resources being maintained by vdri
+func (v *VDRI) Close() error {
+	return nil
+}
+
+// Option configures the Sidetree vdri
+type Option func(opts *VDRI)
+
+// WithMethod option is for the name of the Sidetree DID method (e.g "ion", "sidetree" by default)
+func WithMethod(method string) Option {
+	return func(opts *VDRI) {
+		opts.method = method
+	}
+}
+
+// WithTimeout option is for definition of HTTP(s) timeout value of the requests to the Sidetree node
+func WithTimeout(timeout time.Duration) Option {
+	return func(opts *VDRI) {
+		opts.client.Timeout = timeout
+	}
+}
+
+// WithTLSConfig option is for definition of secured HTTP transport using a tls.Config instance
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(opts *VDRI) {
+		opts.client.Transport = &http.Transport{
+			TLSClientConfig: tlsConfig,
+		}
+	}
+}
+
+// prefix returns the prefix of the DIDs of the Sidetree method
+func (v *VDRI) prefix() string {
+	return "did:" + v.method + ":"
+}
+
+func closeResponseBody(respBody io.Closer) {
+	e := respBody.Close()
+	if e != nil {
+		logger.Errorf("Failed to close response body: %v", e)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/internal/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/legacykms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+// newTestProvider returns a provider with a legacy KMS and a memory store
+func newTestProvider(t *testing.T) *mockprovider.Provider {
+	t.Helper()
+
+	kms, err := legacykms.New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()})
+	require.NoError(t, err)
+
+	return &mockprovider.Provider{
+		KMSValue:             kms,
+		SignerValue:          kms,
+		StorageProviderValue: mem.NewProvider(),
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Run("test new with no options", func(t *testing.T) {
+		v, err := New("https://example.com/sidetree/v1/", newTestProvider(t))
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/sidetree/v1", v.endpoint)
+		require.Equal(t, defaultMethod, v.method)
+	})
+
+	t.Run("test new with all options", func(t *testing.T) {
+		tlsConfig := &tls.Config{ServerName: "example.com"} //nolint:gosec
+
+		v, err := New("https://example.com/sidetree/v1", newTestProvider(t),
+			WithMethod("ion"), WithTimeout(time.Second), WithTLSConfig(tlsConfig))
+		require.NoError(t, err)
+		require.Equal(t, "ion", v.method)
+		require.Equal(t, time.Second, v.client.Timeout)
+		require.Equal(t, tlsConfig, v.client.Transport.(*http.Transport).TLSClientConfig)
+	})
+
+	t.Run("test new with empty endpoint", func(t *testing.T) {
+		_, err := New("", newTestProvider(t))
+		require.EqualError(t, err, "sidetree endpoint is empty")
+	})
+
+	t.Run("test new with store error", func(t *testing.T) {
+		p := newTestProvider(t)
+		p.StorageProviderValue = &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")}
+
+		_, err := New("https://example.com/sidetree/v1", p)
+		require.EqualError(t, err, "open store : open error")
+	})
+}
+
+func TestVDRI(t *testing.T) {
+	v, err := New("https://example.com/sidetree/v1", newTestProvider(t), WithMethod("ion"))
+	require.NoError(t, err)
+
+	require.True(t, v.Accept("ion"))
+	require.False(t, v.Accept("sidetree"))
+
+	require.NoError(t, v.Store(nil, nil))
+	require.NoError(t, v.Close())
+}
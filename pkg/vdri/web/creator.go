@@ -0,0 +1,75 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package web
+
+import (
+	"errors"
+	"time"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+const (
+	pubKeyIndex1      = "#key-1"
+	svcEndpointIndex1 = "#endpoint-1"
+)
+
+// Build builds the DID Document of the DID set with WithHostedDID, to be hosted at the URL the DID maps to
+// (e.g https://example.com/.well-known/did.json for did:web:example.com).
+func (v *VDRI) Build(pubKey *vdriapi.PubKey, opts ...vdriapi.DocOpts) (*did.Doc, error) {
+	if v.didWeb == "" {
+		return nil, errors.New("build did:web: the hosted DID is not set")
+	}
+
+	docOpts := &vdriapi.CreateDIDOpts{}
+
+	for _, opt := range opts {
+		opt(docOpts)
+	}
+
+	publicKey := did.PublicKey{
+		ID:         v.didWeb + pubKeyIndex1,
+		Type:       pubKey.Type,
+		Controller: v.didWeb,
+		// TODO fix hardcode base58 https://github.com/hyperledger/aries-framework-go/issues/1207
+		Value: base58.Decode(pubKey.Value),
+	}
+
+	var service []did.Service
+
+	if docOpts.ServiceType != "" {
+		s := did.Service{
+			ID:              v.didWeb + svcEndpointIndex1,
+			Type:            docOpts.ServiceType,
+			ServiceEndpoint: docOpts.ServiceEndpoint,
+			RoutingKeys:     docOpts.RoutingKeys,
+		}
+
+		if docOpts.ServiceType == vdriapi.DIDCommServiceType {
+			s.RecipientKeys = []string{publicKey.ID}
+			s.Priority = 0
+		}
+
+		service = append(service, s)
+	}
+
+	t := time.Now()
+
+	didDoc := did.BuildDoc(
+		did.WithPublicKey([]did.PublicKey{publicKey}),
+		did.WithAuthentication([]did.VerificationMethod{{PublicKey: publicKey}}),
+		did.WithService(service),
+		did.WithCreatedTime(t),
+		did.WithUpdatedTime(t),
+	)
+	didDoc.ID = v.didWeb
+
+	return didDoc, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package web
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+const (
+	testDID    = "did:web:example.com"
+	testPubKey = "B12NYF8RrR3h41TDCTJojY59usg3mbtbjnFs7Eud1Y6u"
+	ed25519Key = "Ed25519VerificationKey2018"
+)
+
+func TestBuild(t *testing.T) {
+	t.Run("test build", func(t *testing.T) {
+		v, err := New(WithHostedDID(testDID))
+		require.NoError(t, err)
+
+		didDoc, err := v.Build(&vdriapi.PubKey{Value: testPubKey, Type: ed25519Key})
+		require.NoError(t, err)
+
+		require.Equal(t, testDID, didDoc.ID)
+		require.Len(t, didDoc.PublicKey, 1)
+		require.Equal(t, testDID+"#key-1", didDoc.PublicKey[0].ID)
+		require.Equal(t, testDID, didDoc.PublicKey[0].Controller)
+		require.Equal(t, base58.Decode(testPubKey), didDoc.PublicKey[0].Value)
+		require.Len(t, didDoc.Authentication, 1)
+		require.Equal(t, didDoc.PublicKey[0], didDoc.Authentication[0].PublicKey)
+		require.Empty(t, didDoc.Service)
+		require.NotNil(t, didDoc.Created)
+
+		// the built document is valid
+		docBytes, err := didDoc.JSONBytes()
+		require.NoError(t, err)
+
+		_, err = did.ParseDocument(docBytes)
+		require.NoError(t, err)
+	})
+
+	t.Run("test build with DIDComm service", func(t *testing.T) {
+		v, err := New(WithHostedDID(testDID))
+		require.NoError(t, err)
+
+		didDoc, err := v.Build(&vdriapi.PubKey{Value: testPubKey, Type: ed25519Key},
+			vdriapi.WithServiceType(vdriapi.DIDCommServiceType),
+			vdriapi.WithServiceEndpoint("https://example.com/didcomm"))
+		require.NoError(t, err)
+
+		require.Len(t, didDoc.Service, 1)
+		require.Equal(t, testDID+"#endpoint-1", didDoc.Service[0].ID)
+		require.Equal(t, "https://example.com/didcomm", didDoc.Service[0].ServiceEndpoint)
+
+		recipientKeys, ok := did.LookupRecipientKeys(didDoc, vdriapi.DIDCommServiceType, ed25519Key)
+		require.True(t, ok)
+		require.Equal(t, []string{testPubKey}, recipientKeys)
+	})
+
+	t.Run("test build without hosted DID", func(t *testing.T) {
+		v, err := New()
+		require.NoError(t, err)
+
+		_, err = v.Build(&vdriapi.PubKey{Value: testPubKey, Type: ed25519Key})
+		require.EqualError(t, err, "build did:web: the hosted DID is not set")
+	})
+}
@@ -0,0 +1,130 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package web
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+const (
+	didWebPrefix    = "did:web:"
+	wellKnownPath   = "/.well-known"
+	didDocumentPath = "/did.json"
+	// maxDocumentSize is the maximum size of the fetched DID documents (1 MiB)
+	maxDocumentSize = 1 << 20
+)
+
+// nolint:gochecknoglobals
+var (
+	// hostPattern matches the domain names and IPv4 addresses, with an optional port
+	hostPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9.-]*[A-Za-z0-9])?(:[0-9]{1,5})?$`)
+	// pathSegmentPattern matches the path segments of the DIDs, percent-encoded characters are not allowed
+	pathSegmentPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+)
+
+// Read resolves the did:web didWeb by fetching its DID Document from the web domain of the DID
+// (https://w3c-ccg.github.io/did-method-web/#read-resolve)
+func (v *VDRI) Read(didWeb string, _ ...vdriapi.ResolveOpts) (*did.Doc, error) {
+	docURL, err := documentURL(didWeb)
+	if err != nil {
+		return nil, fmt.Errorf("read did:web: %w", err)
+	}
+
+	data, err := v.fetchDocument(docURL)
+	if err != nil {
+		return nil, fmt.Errorf("read did:web: %w", err)
+	}
+
+	didDoc, err := did.ParseDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("read did:web: parse document: %w", err)
+	}
+
+	if didDoc.ID != didWeb {
+		return nil, fmt.Errorf("read did:web: document ID '%s' does not match the DID '%s'", didDoc.ID, didWeb)
+	}
+
+	return didDoc, nil
+}
+
+// fetchDocument gets the DID Document at docURL
+func (v *VDRI) fetchDocument(docURL string) ([]byte, error) {
+	resp, err := v.client.Get(docURL)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP Get request failed: %w", err)
+	}
+
+	defer closeResponseBody(resp.Body)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: no document at %s", vdriapi.ErrNotFound, docURL)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got unexpected response status '%d' from %s", resp.StatusCode, docURL)
+	}
+
+	// read one more byte than the maximum size to find out whether the document is too large
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxDocumentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading response body failed: %w", err)
+	}
+
+	if len(data) > maxDocumentSize {
+		return nil, fmt.Errorf("document at %s exceeds %d bytes", docURL, maxDocumentSize)
+	}
+
+	return data, nil
+}
+
+// documentURL maps the did:web didWeb to the HTTPS URL of its DID Document. The first part of the method
+// specific ID is the domain, with an optional percent-encoded port, and the other colon separated parts are
+// the path of the document, for example:
+//
+//	did:web:example.com               -> https://example.com/.well-known/did.json
+//	did:web:example.com%3A8443        -> https://example.com:8443/.well-known/did.json
+//	did:web:example.com:user:alice    -> https://example.com/user/alice/did.json
+//
+// Only the colon of the port is percent-decoded, the path segments are not decoded so that they can not
+// reference other paths of the domain (e.g. with "%2F" or "..").
+func documentURL(didWeb string) (string, error) {
+	if !strings.HasPrefix(didWeb, didWebPrefix) {
+		return "", fmt.Errorf("invalid did:web: %s", didWeb)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(didWeb, didWebPrefix), ":")
+
+	host := strings.Replace(parts[0], "%3A", ":", 1)
+	if !hostPattern.MatchString(host) {
+		return "", fmt.Errorf("invalid did:web domain: %s", didWeb)
+	}
+
+	docPath := wellKnownPath
+
+	if len(parts) > 1 {
+		for _, segment := range parts[1:] {
+			if !pathSegmentPattern.MatchString(segment) || segment == "." || segment == ".." {
+				return "", fmt.Errorf("invalid did:web path segment '%s'", segment)
+			}
+		}
+
+		docPath = "/" + strings.Join(parts[1:], "/")
+	}
+
+	u := url.URL{Scheme: "https", Host: host, Path: docPath + didDocumentPath}
+
+	return u.String(), nil
+}
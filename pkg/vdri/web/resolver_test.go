@@ -0,0 +1,196 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package web
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+func TestDocumentURL(t *testing.T) {
+	tests := []struct {
+		didWeb string
+		url    string
+	}{
+		{didWeb: "did:web:example.com", url: "https://example.com/.well-known/did.json"},
+		{didWeb: "did:web:example.com%3A8443", url: "https://example.com:8443/.well-known/did.json"},
+		{didWeb: "did:web:example.com:user:alice", url: "https://example.com/user/alice/did.json"},
+		{didWeb: "did:web:example.com%3A8443:user:alice", url: "https://example.com:8443/user/alice/did.json"},
+	}
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.didWeb, func(t *testing.T) {
+			docURL, err := documentURL(tc.didWeb)
+			require.NoError(t, err)
+			require.Equal(t, tc.url, docURL)
+		})
+	}
+
+	t.Run("test invalid DIDs", func(t *testing.T) {
+		for _, didWeb := range []string{
+			"did:key:example.com", "did:web:", "did:web:example.com%2Fpath", "did:web:example.com%ZZ",
+			"did:web:example.com::alice", "did:web:example.com:%ZZ", "did:web:example.com%40other.com",
+			"did:web:example.com%3A8443%2F", "did:web:example.com:..", "did:web:example.com:user:.",
+			"did:web:example.com:user:..%2F..%2Fadmin", "did:web:example.com:%2E%2E:admin",
+			"did:web:example.com:user%2Falice", "did:web:example.com:user/../admin",
+		} {
+			_, err := documentURL(didWeb)
+			require.Error(t, err, didWeb)
+		}
+	})
+}
+
+func TestRead(t *testing.T) {
+	var docs map[string]string
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user/secure/did.json":
+			http.Redirect(w, r, "https://"+r.Host+"/.well-known/did.json", http.StatusFound)
+			return
+		case "/user/insecure/did.json":
+			http.Redirect(w, r, "http://"+r.Host+"/.well-known/did.json", http.StatusFound)
+			return
+		case "/user/loop/did.json":
+			http.Redirect(w, r, r.URL.Path, http.StatusFound)
+			return
+		}
+
+		doc, ok := docs[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if doc == "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-type", "application/json")
+		_, err := w.Write([]byte(doc))
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	srvURL, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	didWeb := "did:web:" + strings.Replace(srvURL.Host, ":", "%3A", 1)
+
+	certPool := x509.NewCertPool()
+	certPool.AddCert(srv.Certificate())
+
+	v, err := New(WithTLSConfig(&tls.Config{RootCAs: certPool, MinVersion: tls.VersionTLS12}),
+		WithHostedDID(didWeb))
+	require.NoError(t, err)
+
+	hosted, err := v.Build(&vdriapi.PubKey{Value: testPubKey, Type: ed25519Key})
+	require.NoError(t, err)
+
+	hostedBytes, err := hosted.JSONBytes()
+	require.NoError(t, err)
+
+	aliceDoc := strings.ReplaceAll(string(hostedBytes), didWeb, didWeb+":user:alice")
+
+	docs = map[string]string{
+		"/.well-known/did.json":  string(hostedBytes),
+		"/user/alice/did.json":   aliceDoc,
+		"/user/bob/did.json":     string(hostedBytes),
+		"/user/invalid/did.json": "{",
+		"/user/failure/did.json": "",
+		"/user/large/did.json":   strings.Repeat(" ", maxDocumentSize+1),
+	}
+
+	t.Run("test read", func(t *testing.T) {
+		didDoc, err := v.Read(didWeb)
+		require.NoError(t, err)
+		require.Equal(t, didWeb, didDoc.ID)
+		require.Equal(t, hosted.PublicKey[0].Value, didDoc.PublicKey[0].Value)
+	})
+
+	t.Run("test read with path", func(t *testing.T) {
+		didDoc, err := v.Read(didWeb + ":user:alice")
+		require.NoError(t, err)
+		require.Equal(t, didWeb+":user:alice", didDoc.ID)
+	})
+
+	t.Run("test document ID mismatch", func(t *testing.T) {
+		_, err := v.Read(didWeb + ":user:bob")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not match the DID")
+	})
+
+	t.Run("test DID not found", func(t *testing.T) {
+		_, err := v.Read(didWeb + ":user:carol")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, vdriapi.ErrNotFound))
+	})
+
+	t.Run("test unexpected response status", func(t *testing.T) {
+		_, err := v.Read(didWeb + ":user:failure")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "got unexpected response status '500'")
+	})
+
+	t.Run("test invalid document", func(t *testing.T) {
+		_, err := v.Read(didWeb + ":user:invalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "read did:web: parse document")
+	})
+
+	t.Run("test HTTPS redirect", func(t *testing.T) {
+		// the redirect is followed, the document found is the one of the hosted DID
+		_, err := v.Read(didWeb + ":user:secure")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "document ID '"+didWeb+"' does not match the DID")
+	})
+
+	t.Run("test non-HTTPS redirect", func(t *testing.T) {
+		_, err := v.Read(didWeb + ":user:insecure")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "redirect to non-HTTPS URL http://"+srvURL.Host+"/.well-known/did.json refused")
+	})
+
+	t.Run("test too many redirects", func(t *testing.T) {
+		_, err := v.Read(didWeb + ":user:loop")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "stopped after 10 redirects")
+	})
+
+	t.Run("test document too large", func(t *testing.T) {
+		_, err := v.Read(didWeb + ":user:large")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "exceeds 1048576 bytes")
+	})
+
+	t.Run("test invalid DID", func(t *testing.T) {
+		_, err := v.Read("did:web:")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "read did:web: invalid did:web domain")
+	})
+
+	t.Run("test untrusted server certificate", func(t *testing.T) {
+		untrusted, err := New()
+		require.NoError(t, err)
+
+		_, err = untrusted.Read(didWeb)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "HTTP Get request failed")
+	})
+}
@@ -0,0 +1,123 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package web
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+var logger = log.New("aries-framework/vdri/web")
+
+const (
+	didMethod = "web"
+	// maxRedirects is the maximum number of redirects followed to fetch a DID document, as the default HTTP client
+	maxRedirects = 10
+)
+
+// VDRI implements the did:web method (https://w3c-ccg.github.io/did-method-web/).
+// The DID documents are resolved over HTTPS from the web domain of the DID, they are hosted out of band.
+type VDRI struct {
+	client *http.Client
+	didWeb string
+}
+
+// New returns new instance of web vdri
+func New(opts ...Option) (*VDRI, error) {
+	v := &VDRI{client: &http.Client{CheckRedirect: checkRedirect}}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	if v.didWeb != "" {
+		if _, err := documentURL(v.didWeb); err != nil {
+			return nil, fmt.Errorf("invalid hosted DID: %w", err)
+		}
+	}
+
+	return v, nil
+}
+
+// Accept did method
+func (v *VDRI) Accept(method string) bool {
+	return method == didMethod
+}
+
+// Store is a no-op, the did:web documents are hosted on the web domain of the DID
+func (v *VDRI) Store(doc *did.Doc, by *[]vdriapi.ModifiedBy) error {
+	logger.Warnf("store not supported in web vdri, the DID document must be hosted on the web domain")
+	return nil
+}
+
+// Update is not supported, the did:web documents are updated on the web domain of the DID
+func (v *VDRI) Update(doc *did.Doc, _ ...vdriapi.UpdateOpts) error {
+	return fmt.Errorf("update %s: %w", doc.ID, vdriapi.ErrNotSupported)
+}
+
+// Deactivate is not supported, the did:web documents are removed from the web domain of the DID
+func (v *VDRI) Deactivate(didID string, _ ...vdriapi.UpdateOpts) error {
+	return fmt.Errorf("deactivate %s: %w", didID, vdriapi.ErrNotSupported)
+}
+
+// Close frees resources being maintained by vdri
+func (v *VDRI) Close() error {
+	return nil
+}
+
+// Option configures the web vdri
+type Option func(opts *VDRI)
+
+// WithTimeout option is for definition of HTTP(s) timeout value of DID Resolver
+func WithTimeout(timeout time.Duration) Option {
+	return func(opts *VDRI) {
+		opts.client.Timeout = timeout
+	}
+}
+
+// WithTLSConfig option is for definition of secured HTTP transport using a tls.Config instance
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(opts *VDRI) {
+		opts.client.Transport = &http.Transport{
+			TLSClientConfig: tlsConfig,
+		}
+	}
+}
+
+// WithHostedDID option is for the did:web DID of the documents built by the vdri (e.g did:web:example.com)
+func WithHostedDID(didWeb string) Option {
+	return func(opts *VDRI) {
+		opts.didWeb = didWeb
+	}
+}
+
+// checkRedirect refuses the redirects of the DID document requests to non-HTTPS URLs
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if req.URL.Scheme != "https" {
+		return fmt.Errorf("redirect to non-HTTPS URL %s refused", req.URL)
+	}
+
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+
+	return nil
+}
+
+func closeResponseBody(respBody io.Closer) {
+	e := respBody.Close()
+	if e != nil {
+		logger.Errorf("Failed to close response body: %v", e)
+	}
+}
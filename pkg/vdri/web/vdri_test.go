@@ -0,0 +1,68 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package web
+
+import (
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new with no options", func(t *testing.T) {
+		v, err := New()
+		require.NoError(t, err)
+		require.NotNil(t, v)
+	})
+
+	t.Run("test new with all options", func(t *testing.T) {
+		tlsConfig := &tls.Config{ServerName: "example.com"} //nolint:gosec
+
+		v, err := New(WithTimeout(time.Second), WithTLSConfig(tlsConfig), WithHostedDID("did:web:example.com"))
+		require.NoError(t, err)
+		require.Equal(t, time.Second, v.client.Timeout)
+		require.Equal(t, tlsConfig, v.client.Transport.(*http.Transport).TLSClientConfig)
+		require.Equal(t, "did:web:example.com", v.didWeb)
+	})
+
+	t.Run("test new with invalid hosted DID", func(t *testing.T) {
+		_, err := New(WithHostedDID("did:key:example.com"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid hosted DID")
+	})
+}
+
+func TestVDRI(t *testing.T) {
+	v, err := New()
+	require.NoError(t, err)
+
+	require.True(t, v.Accept("web"))
+	require.False(t, v.Accept("peer"))
+
+	require.NoError(t, v.Store(nil, nil))
+	require.NoError(t, v.Close())
+}
+
+func TestVDRI_Update(t *testing.T) {
+	v, err := New()
+	require.NoError(t, err)
+
+	err = v.Update(&did.Doc{ID: "did:web:123"})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, vdriapi.ErrNotSupported))
+
+	err = v.Deactivate("did:web:123")
+	require.Error(t, err)
+	require.True(t, errors.Is(err, vdriapi.ErrNotSupported))
+}